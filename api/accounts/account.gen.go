@@ -14,16 +14,17 @@ import (
 )
 
 type Account struct {
-	Id           string                 `json:"id,omitempty"`
-	Scope        *scopes.ScopeInfo      `json:"scope,omitempty"`
-	Name         string                 `json:"name,omitempty"`
-	Description  string                 `json:"description,omitempty"`
-	CreatedTime  time.Time              `json:"created_time,omitempty"`
-	UpdatedTime  time.Time              `json:"updated_time,omitempty"`
-	Version      uint32                 `json:"version,omitempty"`
-	Type         string                 `json:"type,omitempty"`
-	AuthMethodId string                 `json:"auth_method_id,omitempty"`
-	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Id                string                 `json:"id,omitempty"`
+	Scope             *scopes.ScopeInfo      `json:"scope,omitempty"`
+	Name              string                 `json:"name,omitempty"`
+	Description       string                 `json:"description,omitempty"`
+	CreatedTime       time.Time              `json:"created_time,omitempty"`
+	UpdatedTime       time.Time              `json:"updated_time,omitempty"`
+	Version           uint32                 `json:"version,omitempty"`
+	Type              string                 `json:"type,omitempty"`
+	AuthMethodId      string                 `json:"auth_method_id,omitempty"`
+	Attributes        map[string]interface{} `json:"attributes,omitempty"`
+	AuthorizedActions []string               `json:"authorized_actions,omitempty"`
 
 	responseBody *bytes.Buffer
 	responseMap  map[string]interface{}
@@ -72,9 +73,10 @@ func (n AccountDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type AccountListResult struct {
-	Items        []*Account
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*Account
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n AccountListResult) GetItems() interface{} {
@@ -301,6 +303,9 @@ func (c *Client) Delete(ctx context.Context, accountId string, opt ...Option) (*
 	return target, nil
 }
 
+// List returns every item in the collection. When the controller returns the
+// items in pages, List requests each following page until none remain; the
+// response body and map of the result are those of the last page.
 func (c *Client) List(ctx context.Context, authMethodId string, opt ...Option) (*AccountListResult, error) {
 	if authMethodId == "" {
 		return nil, fmt.Errorf("empty authMethodId value passed into List request")
@@ -312,33 +317,42 @@ func (c *Client) List(ctx context.Context, authMethodId string, opt ...Option) (
 	opts, apiOpts := getOpts(opt...)
 	opts.queryMap["auth_method_id"] = authMethodId
 
-	req, err := c.client.NewRequest(ctx, "GET", "accounts", nil, apiOpts...)
-	if err != nil {
-		return nil, fmt.Errorf("error creating List request: %w", err)
-	}
+	target := new(AccountListResult)
+	var pageToken string
+	for {
+		req, err := c.client.NewRequest(ctx, "GET", "accounts", nil, apiOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating List request: %w", err)
+		}
 
-	if len(opts.queryMap) > 0 {
 		q := url.Values{}
 		for k, v := range opts.queryMap {
 			q.Add(k, v)
 		}
+		if pageToken != "" {
+			q.Set("page_token", pageToken)
+		}
 		req.URL.RawQuery = q.Encode()
-	}
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error performing client request during List call: %w", err)
-	}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error performing client request during List call: %w", err)
+		}
 
-	target := new(AccountListResult)
-	apiErr, err := resp.Decode(target)
-	if err != nil {
-		return nil, fmt.Errorf("error decoding List response: %w", err)
-	}
-	if apiErr != nil {
-		return nil, apiErr
+		page := new(AccountListResult)
+		apiErr, err := resp.Decode(page)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding List response: %w", err)
+		}
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		target.Items = append(target.Items, page.Items...)
+		target.responseBody = resp.Body
+		target.responseMap = resp.Map
+		if page.NextPageToken == "" {
+			return target, nil
+		}
+		pageToken = page.NextPageToken
 	}
-	target.responseBody = resp.Body
-	target.responseMap = resp.Map
-	return target, nil
 }
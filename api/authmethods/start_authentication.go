@@ -0,0 +1,46 @@
+package authmethods
+
+import (
+	"context"
+	"fmt"
+)
+
+// StartAuthentication begins an authentication flow with an auth method that
+// delegates to an external provider, such as an OIDC auth method. It returns
+// the URL the user should visit to authenticate with the provider. Once
+// done, the provider redirects the user to redirectUrl with the state and
+// code to pass to Authenticate as credentials.
+func (c *Client) StartAuthentication(ctx context.Context, authMethodId, redirectUrl string, opt ...Option) (string, error) {
+	if c.client == nil {
+		return "", fmt.Errorf("nil client in StartAuthentication request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]interface{}{
+		"redirect_url": redirectUrl,
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("auth-methods/%s:start-authentication", authMethodId), reqBody, apiOpts...)
+	if err != nil {
+		return "", fmt.Errorf("error creating StartAuthentication request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error performing client request during StartAuthentication call: %w", err)
+	}
+
+	target := struct {
+		AuthUrl string `json:"auth_url"`
+	}{}
+	apiErr, err := resp.Decode(&target)
+	if err != nil {
+		return "", fmt.Errorf("error decoding StartAuthentication response: %w", err)
+	}
+	if apiErr != nil {
+		return "", apiErr
+	}
+
+	return target.AuthUrl, nil
+}
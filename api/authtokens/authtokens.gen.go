@@ -24,6 +24,7 @@ type AuthToken struct {
 	UpdatedTime             time.Time         `json:"updated_time,omitempty"`
 	ApproximateLastUsedTime time.Time         `json:"approximate_last_used_time,omitempty"`
 	ExpirationTime          time.Time         `json:"expiration_time,omitempty"`
+	AuthorizedActions       []string          `json:"authorized_actions,omitempty"`
 
 	responseBody *bytes.Buffer
 	responseMap  map[string]interface{}
@@ -72,9 +73,10 @@ func (n AuthTokenDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type AuthTokenListResult struct {
-	Items        []*AuthToken
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*AuthToken
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n AuthTokenListResult) GetItems() interface{} {
@@ -192,6 +194,9 @@ func (c *Client) Delete(ctx context.Context, authTokenId string, opt ...Option)
 	return target, nil
 }
 
+// List returns every item in the collection. When the controller returns the
+// items in pages, List requests each following page until none remain; the
+// response body and map of the result are those of the last page.
 func (c *Client) List(ctx context.Context, scopeId string, opt ...Option) (*AuthTokenListResult, error) {
 	if scopeId == "" {
 		return nil, fmt.Errorf("empty scopeId value passed into List request")
@@ -203,33 +208,42 @@ func (c *Client) List(ctx context.Context, scopeId string, opt ...Option) (*Auth
 	opts, apiOpts := getOpts(opt...)
 	opts.queryMap["scope_id"] = scopeId
 
-	req, err := c.client.NewRequest(ctx, "GET", "auth-tokens", nil, apiOpts...)
-	if err != nil {
-		return nil, fmt.Errorf("error creating List request: %w", err)
-	}
+	target := new(AuthTokenListResult)
+	var pageToken string
+	for {
+		req, err := c.client.NewRequest(ctx, "GET", "auth-tokens", nil, apiOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating List request: %w", err)
+		}
 
-	if len(opts.queryMap) > 0 {
 		q := url.Values{}
 		for k, v := range opts.queryMap {
 			q.Add(k, v)
 		}
+		if pageToken != "" {
+			q.Set("page_token", pageToken)
+		}
 		req.URL.RawQuery = q.Encode()
-	}
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error performing client request during List call: %w", err)
-	}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error performing client request during List call: %w", err)
+		}
 
-	target := new(AuthTokenListResult)
-	apiErr, err := resp.Decode(target)
-	if err != nil {
-		return nil, fmt.Errorf("error decoding List response: %w", err)
-	}
-	if apiErr != nil {
-		return nil, apiErr
+		page := new(AuthTokenListResult)
+		apiErr, err := resp.Decode(page)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding List response: %w", err)
+		}
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		target.Items = append(target.Items, page.Items...)
+		target.responseBody = resp.Body
+		target.responseMap = resp.Map
+		if page.NextPageToken == "" {
+			return target, nil
+		}
+		pageToken = page.NextPageToken
 	}
-	target.responseBody = resp.Body
-	target.responseMap = resp.Map
-	return target, nil
 }
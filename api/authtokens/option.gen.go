@@ -1,6 +1,8 @@
 package authtokens
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/boundary/api"
 )
 
@@ -44,3 +46,15 @@ func WithAutomaticVersioning(enable bool) Option {
 		o.withAutomaticVersioning = enable
 	}
 }
+
+func WithFilter(inFilter string) Option {
+	return func(o *options) {
+		o.queryMap["filter"] = fmt.Sprintf("%v", inFilter)
+	}
+}
+
+func WithRecursive(inRecursive bool) Option {
+	return func(o *options) {
+		o.queryMap["recursive"] = fmt.Sprintf("%v", inRecursive)
+	}
+}
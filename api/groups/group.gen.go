@@ -14,16 +14,17 @@ import (
 )
 
 type Group struct {
-	Id          string            `json:"id,omitempty"`
-	ScopeId     string            `json:"scope_id,omitempty"`
-	Scope       *scopes.ScopeInfo `json:"scope,omitempty"`
-	Name        string            `json:"name,omitempty"`
-	Description string            `json:"description,omitempty"`
-	CreatedTime time.Time         `json:"created_time,omitempty"`
-	UpdatedTime time.Time         `json:"updated_time,omitempty"`
-	Version     uint32            `json:"version,omitempty"`
-	MemberIds   []string          `json:"member_ids,omitempty"`
-	Members     []*Member         `json:"members,omitempty"`
+	Id                string            `json:"id,omitempty"`
+	ScopeId           string            `json:"scope_id,omitempty"`
+	Scope             *scopes.ScopeInfo `json:"scope,omitempty"`
+	Name              string            `json:"name,omitempty"`
+	Description       string            `json:"description,omitempty"`
+	CreatedTime       time.Time         `json:"created_time,omitempty"`
+	UpdatedTime       time.Time         `json:"updated_time,omitempty"`
+	Version           uint32            `json:"version,omitempty"`
+	MemberIds         []string          `json:"member_ids,omitempty"`
+	Members           []*Member         `json:"members,omitempty"`
+	AuthorizedActions []string          `json:"authorized_actions,omitempty"`
 
 	responseBody *bytes.Buffer
 	responseMap  map[string]interface{}
@@ -72,9 +73,10 @@ func (n GroupDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type GroupListResult struct {
-	Items        []*Group
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*Group
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n GroupListResult) GetItems() interface{} {
@@ -301,6 +303,9 @@ func (c *Client) Delete(ctx context.Context, groupId string, opt ...Option) (*Gr
 	return target, nil
 }
 
+// List returns every item in the collection. When the controller returns the
+// items in pages, List requests each following page until none remain; the
+// response body and map of the result are those of the last page.
 func (c *Client) List(ctx context.Context, scopeId string, opt ...Option) (*GroupListResult, error) {
 	if scopeId == "" {
 		return nil, fmt.Errorf("empty scopeId value passed into List request")
@@ -312,35 +317,44 @@ func (c *Client) List(ctx context.Context, scopeId string, opt ...Option) (*Grou
 	opts, apiOpts := getOpts(opt...)
 	opts.queryMap["scope_id"] = scopeId
 
-	req, err := c.client.NewRequest(ctx, "GET", "groups", nil, apiOpts...)
-	if err != nil {
-		return nil, fmt.Errorf("error creating List request: %w", err)
-	}
+	target := new(GroupListResult)
+	var pageToken string
+	for {
+		req, err := c.client.NewRequest(ctx, "GET", "groups", nil, apiOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating List request: %w", err)
+		}
 
-	if len(opts.queryMap) > 0 {
 		q := url.Values{}
 		for k, v := range opts.queryMap {
 			q.Add(k, v)
 		}
+		if pageToken != "" {
+			q.Set("page_token", pageToken)
+		}
 		req.URL.RawQuery = q.Encode()
-	}
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error performing client request during List call: %w", err)
-	}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error performing client request during List call: %w", err)
+		}
 
-	target := new(GroupListResult)
-	apiErr, err := resp.Decode(target)
-	if err != nil {
-		return nil, fmt.Errorf("error decoding List response: %w", err)
-	}
-	if apiErr != nil {
-		return nil, apiErr
+		page := new(GroupListResult)
+		apiErr, err := resp.Decode(page)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding List response: %w", err)
+		}
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		target.Items = append(target.Items, page.Items...)
+		target.responseBody = resp.Body
+		target.responseMap = resp.Map
+		if page.NextPageToken == "" {
+			return target, nil
+		}
+		pageToken = page.NextPageToken
 	}
-	target.responseBody = resp.Body
-	target.responseMap = resp.Map
-	return target, nil
 }
 
 func (c *Client) AddMembers(ctx context.Context, groupId string, version uint32, memberIds []string, opt ...Option) (*GroupUpdateResult, error) {
@@ -1,6 +1,8 @@
 package hostcatalogs
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/boundary/api"
 )
 
@@ -69,6 +71,12 @@ func DefaultDescription() Option {
 	}
 }
 
+func WithFilter(inFilter string) Option {
+	return func(o *options) {
+		o.queryMap["filter"] = fmt.Sprintf("%v", inFilter)
+	}
+}
+
 func WithName(inName string) Option {
 	return func(o *options) {
 		o.postMap["name"] = inName
@@ -80,3 +88,57 @@ func DefaultName() Option {
 		o.postMap["name"] = nil
 	}
 }
+
+func WithRecursive(inRecursive bool) Option {
+	return func(o *options) {
+		o.queryMap["recursive"] = fmt.Sprintf("%v", inRecursive)
+	}
+}
+
+func WithInventoryHostCatalogSource(inSource string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["source"] = inSource
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultInventoryHostCatalogSource() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["source"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithInventoryHostCatalogSyncIntervalSeconds(inSyncIntervalSeconds uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["sync_interval_seconds"] = inSyncIntervalSeconds
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultInventoryHostCatalogSyncIntervalSeconds() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["sync_interval_seconds"] = nil
+		o.postMap["attributes"] = val
+	}
+}
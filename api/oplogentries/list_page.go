@@ -0,0 +1,55 @@
+package oplogentries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// ListPage returns the page of oplog entries which starts after the page
+// token; an empty token requests the first page. Unlike List, which reads
+// every page before returning, it allows the entries to be processed as they
+// are read. The NextPageToken of the result is empty after the last page.
+func (c *Client) ListPage(ctx context.Context, scopeId, pageToken string, opt ...Option) (*OplogEntryListResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into ListPage request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.queryMap["scope_id"] = scopeId
+
+	req, err := c.client.NewRequest(ctx, "GET", "oplog-entries", nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ListPage request: %w", err)
+	}
+
+	q := url.Values{}
+	for k, v := range opts.queryMap {
+		q.Add(k, v)
+	}
+	if pageToken != "" {
+		q.Set("page_token", pageToken)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ListPage call: %w", err)
+	}
+
+	target := new(OplogEntryListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListPage response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
@@ -0,0 +1,79 @@
+package oplogentries
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+type OplogEntryWatchResult struct {
+	Items        []*OplogEntry
+	Cursor       string `json:"cursor,omitempty"`
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n OplogEntryWatchResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n OplogEntryWatchResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n OplogEntryWatchResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+// Watch returns the oplog entries committed after the cursor, an empty one
+// standing for the time of the request. When there are none yet, the
+// controller waits for them for up to the time set with WithWaitSeconds and
+// returns no entries. Either way the Cursor of the result is the one to pass
+// to the next call, so that changes are read in order without missing any.
+//
+// The client timeout must be longer than the wait, which defaults to 30
+// seconds.
+func (c *Client) Watch(ctx context.Context, scopeId, cursor string, opt ...Option) (*OplogEntryWatchResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into Watch request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.queryMap["scope_id"] = scopeId
+
+	req, err := c.client.NewRequest(ctx, "GET", "oplog-entries:watch", nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Watch request: %w", err)
+	}
+
+	q := url.Values{}
+	for k, v := range opts.queryMap {
+		q.Add(k, v)
+	}
+	if cursor != "" {
+		q.Set("cursor", cursor)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Watch call: %w", err)
+	}
+
+	target := new(OplogEntryWatchResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Watch response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
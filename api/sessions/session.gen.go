@@ -13,25 +13,29 @@ import (
 )
 
 type Session struct {
-	Id                string            `json:"id,omitempty"`
-	TargetId          string            `json:"target_id,omitempty"`
-	Scope             *scopes.ScopeInfo `json:"scope,omitempty"`
-	CreatedTime       time.Time         `json:"created_time,omitempty"`
-	UpdatedTime       time.Time         `json:"updated_time,omitempty"`
-	Version           uint32            `json:"version,omitempty"`
-	Type              string            `json:"type,omitempty"`
-	ExpirationTime    time.Time         `json:"expiration_time,omitempty"`
-	AuthTokenId       string            `json:"auth_token_id,omitempty"`
-	UserId            string            `json:"user_id,omitempty"`
-	HostSetId         string            `json:"host_set_id,omitempty"`
-	HostId            string            `json:"host_id,omitempty"`
-	ScopeId           string            `json:"scope_id,omitempty"`
-	Endpoint          string            `json:"endpoint,omitempty"`
-	States            []*SessionState   `json:"states,omitempty"`
-	Status            string            `json:"status,omitempty"`
-	WorkerInfo        []*WorkerInfo     `json:"worker_info,omitempty"`
-	Certificate       []byte            `json:"certificate,omitempty"`
-	TerminationReason string            `json:"termination_reason,omitempty"`
+	Id                string                 `json:"id,omitempty"`
+	TargetId          string                 `json:"target_id,omitempty"`
+	Scope             *scopes.ScopeInfo      `json:"scope,omitempty"`
+	CreatedTime       time.Time              `json:"created_time,omitempty"`
+	UpdatedTime       time.Time              `json:"updated_time,omitempty"`
+	Version           uint32                 `json:"version,omitempty"`
+	Type              string                 `json:"type,omitempty"`
+	ExpirationTime    time.Time              `json:"expiration_time,omitempty"`
+	AuthTokenId       string                 `json:"auth_token_id,omitempty"`
+	UserId            string                 `json:"user_id,omitempty"`
+	HostSetId         string                 `json:"host_set_id,omitempty"`
+	HostId            string                 `json:"host_id,omitempty"`
+	ScopeId           string                 `json:"scope_id,omitempty"`
+	Endpoint          string                 `json:"endpoint,omitempty"`
+	States            []*SessionState        `json:"states,omitempty"`
+	Status            string                 `json:"status,omitempty"`
+	WorkerInfo        []*WorkerInfo          `json:"worker_info,omitempty"`
+	Certificate       []byte                 `json:"certificate,omitempty"`
+	TerminationReason string                 `json:"termination_reason,omitempty"`
+	RecordingEnabled  bool                   `json:"recording_enabled,omitempty"`
+	Recordings        []*ConnectionRecording `json:"recordings,omitempty"`
+	Connections       []*Connection          `json:"connections,omitempty"`
+	AuthorizedActions []string               `json:"authorized_actions,omitempty"`
 
 	responseBody *bytes.Buffer
 	responseMap  map[string]interface{}
@@ -80,9 +84,10 @@ func (n SessionDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type SessionListResult struct {
-	Items        []*Session
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*Session
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n SessionListResult) GetItems() interface{} {
@@ -157,6 +162,9 @@ func (c *Client) Read(ctx context.Context, sessionId string, opt ...Option) (*Se
 	return target, nil
 }
 
+// List returns every item in the collection. When the controller returns the
+// items in pages, List requests each following page until none remain; the
+// response body and map of the result are those of the last page.
 func (c *Client) List(ctx context.Context, scopeId string, opt ...Option) (*SessionListResult, error) {
 	if scopeId == "" {
 		return nil, fmt.Errorf("empty scopeId value passed into List request")
@@ -168,33 +176,42 @@ func (c *Client) List(ctx context.Context, scopeId string, opt ...Option) (*Sess
 	opts, apiOpts := getOpts(opt...)
 	opts.queryMap["scope_id"] = scopeId
 
-	req, err := c.client.NewRequest(ctx, "GET", "sessions", nil, apiOpts...)
-	if err != nil {
-		return nil, fmt.Errorf("error creating List request: %w", err)
-	}
+	target := new(SessionListResult)
+	var pageToken string
+	for {
+		req, err := c.client.NewRequest(ctx, "GET", "sessions", nil, apiOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating List request: %w", err)
+		}
 
-	if len(opts.queryMap) > 0 {
 		q := url.Values{}
 		for k, v := range opts.queryMap {
 			q.Add(k, v)
 		}
+		if pageToken != "" {
+			q.Set("page_token", pageToken)
+		}
 		req.URL.RawQuery = q.Encode()
-	}
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error performing client request during List call: %w", err)
-	}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error performing client request during List call: %w", err)
+		}
 
-	target := new(SessionListResult)
-	apiErr, err := resp.Decode(target)
-	if err != nil {
-		return nil, fmt.Errorf("error decoding List response: %w", err)
-	}
-	if apiErr != nil {
-		return nil, apiErr
+		page := new(SessionListResult)
+		apiErr, err := resp.Decode(page)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding List response: %w", err)
+		}
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		target.Items = append(target.Items, page.Items...)
+		target.responseBody = resp.Body
+		target.responseMap = resp.Map
+		if page.NextPageToken == "" {
+			return target, nil
+		}
+		pageToken = page.NextPageToken
 	}
-	target.responseBody = resp.Body
-	target.responseMap = resp.Map
-	return target, nil
 }
@@ -1,6 +1,8 @@
 package targets
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/boundary/api"
 )
 
@@ -57,6 +59,42 @@ func DefaultAttributes() Option {
 	}
 }
 
+func WithConnectionMaxBytesPerSecond(inConnectionMaxBytesPerSecond uint32) Option {
+	return func(o *options) {
+		o.postMap["connection_max_bytes_per_second"] = inConnectionMaxBytesPerSecond
+	}
+}
+
+func DefaultConnectionMaxBytesPerSecond() Option {
+	return func(o *options) {
+		o.postMap["connection_max_bytes_per_second"] = nil
+	}
+}
+
+func WithSshTargetDefaultPort(inDefaultPort uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["default_port"] = inDefaultPort
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultSshTargetDefaultPort() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["default_port"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithTcpTargetDefaultPort(inDefaultPort uint32) Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
@@ -81,6 +119,30 @@ func DefaultTcpTargetDefaultPort() Option {
 	}
 }
 
+func WithUdpTargetDefaultPort(inDefaultPort uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["default_port"] = inDefaultPort
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultUdpTargetDefaultPort() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["default_port"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithDescription(inDescription string) Option {
 	return func(o *options) {
 		o.postMap["description"] = inDescription
@@ -93,12 +155,42 @@ func DefaultDescription() Option {
 	}
 }
 
+func WithEnableSessionRecording(inEnableSessionRecording bool) Option {
+	return func(o *options) {
+		o.postMap["enable_session_recording"] = inEnableSessionRecording
+	}
+}
+
+func DefaultEnableSessionRecording() Option {
+	return func(o *options) {
+		o.postMap["enable_session_recording"] = nil
+	}
+}
+
+func WithFilter(inFilter string) Option {
+	return func(o *options) {
+		o.queryMap["filter"] = fmt.Sprintf("%v", inFilter)
+	}
+}
+
 func WithHostId(inHostId string) Option {
 	return func(o *options) {
 		o.postMap["host_id"] = inHostId
 	}
 }
 
+func WithHostSelectionStrategy(inHostSelectionStrategy string) Option {
+	return func(o *options) {
+		o.postMap["host_selection_strategy"] = inHostSelectionStrategy
+	}
+}
+
+func DefaultHostSelectionStrategy() Option {
+	return func(o *options) {
+		o.postMap["host_selection_strategy"] = nil
+	}
+}
+
 func WithName(inName string) Option {
 	return func(o *options) {
 		o.postMap["name"] = inName
@@ -111,6 +203,48 @@ func DefaultName() Option {
 	}
 }
 
+func WithSshTargetPrincipals(inPrincipals []string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["principals"] = inPrincipals
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultSshTargetPrincipals() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["principals"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithRecursive(inRecursive bool) Option {
+	return func(o *options) {
+		o.queryMap["recursive"] = fmt.Sprintf("%v", inRecursive)
+	}
+}
+
+func WithRestrictHostId(inRestrictHostId bool) Option {
+	return func(o *options) {
+		o.postMap["restrict_host_id"] = inRestrictHostId
+	}
+}
+
+func DefaultRestrictHostId() Option {
+	return func(o *options) {
+		o.postMap["restrict_host_id"] = nil
+	}
+}
+
 func WithSessionConnectionLimit(inSessionConnectionLimit int32) Option {
 	return func(o *options) {
 		o.postMap["session_connection_limit"] = inSessionConnectionLimit
@@ -123,6 +257,30 @@ func DefaultSessionConnectionLimit() Option {
 	}
 }
 
+func WithSessionIdleTimeoutSeconds(inSessionIdleTimeoutSeconds uint32) Option {
+	return func(o *options) {
+		o.postMap["session_idle_timeout_seconds"] = inSessionIdleTimeoutSeconds
+	}
+}
+
+func DefaultSessionIdleTimeoutSeconds() Option {
+	return func(o *options) {
+		o.postMap["session_idle_timeout_seconds"] = nil
+	}
+}
+
+func WithSessionMaxBytesPerSecond(inSessionMaxBytesPerSecond uint32) Option {
+	return func(o *options) {
+		o.postMap["session_max_bytes_per_second"] = inSessionMaxBytesPerSecond
+	}
+}
+
+func DefaultSessionMaxBytesPerSecond() Option {
+	return func(o *options) {
+		o.postMap["session_max_bytes_per_second"] = nil
+	}
+}
+
 func WithSessionMaxSeconds(inSessionMaxSeconds uint32) Option {
 	return func(o *options) {
 		o.postMap["session_max_seconds"] = inSessionMaxSeconds
@@ -134,3 +292,15 @@ func DefaultSessionMaxSeconds() Option {
 		o.postMap["session_max_seconds"] = nil
 	}
 }
+
+func WithWorkerFilter(inWorkerFilter string) Option {
+	return func(o *options) {
+		o.postMap["worker_filter"] = inWorkerFilter
+	}
+}
+
+func DefaultWorkerFilter() Option {
+	return func(o *options) {
+		o.postMap["worker_filter"] = nil
+	}
+}
@@ -14,20 +14,30 @@ import (
 )
 
 type Target struct {
-	Id                     string                 `json:"id,omitempty"`
-	ScopeId                string                 `json:"scope_id,omitempty"`
-	Scope                  *scopes.ScopeInfo      `json:"scope,omitempty"`
-	Name                   string                 `json:"name,omitempty"`
-	Description            string                 `json:"description,omitempty"`
-	CreatedTime            time.Time              `json:"created_time,omitempty"`
-	UpdatedTime            time.Time              `json:"updated_time,omitempty"`
-	Version                uint32                 `json:"version,omitempty"`
-	Type                   string                 `json:"type,omitempty"`
-	HostSetIds             []string               `json:"host_set_ids,omitempty"`
-	HostSets               []*HostSet             `json:"host_sets,omitempty"`
-	SessionMaxSeconds      uint32                 `json:"session_max_seconds,omitempty"`
-	SessionConnectionLimit int32                  `json:"session_connection_limit,omitempty"`
-	Attributes             map[string]interface{} `json:"attributes,omitempty"`
+	Id                          string                 `json:"id,omitempty"`
+	ScopeId                     string                 `json:"scope_id,omitempty"`
+	Scope                       *scopes.ScopeInfo      `json:"scope,omitempty"`
+	Name                        string                 `json:"name,omitempty"`
+	Description                 string                 `json:"description,omitempty"`
+	CreatedTime                 time.Time              `json:"created_time,omitempty"`
+	UpdatedTime                 time.Time              `json:"updated_time,omitempty"`
+	Version                     uint32                 `json:"version,omitempty"`
+	Type                        string                 `json:"type,omitempty"`
+	HostSetIds                  []string               `json:"host_set_ids,omitempty"`
+	HostSets                    []*HostSet             `json:"host_sets,omitempty"`
+	SessionMaxSeconds           uint32                 `json:"session_max_seconds,omitempty"`
+	SessionConnectionLimit      int32                  `json:"session_connection_limit,omitempty"`
+	EnableSessionRecording      bool                   `json:"enable_session_recording,omitempty"`
+	ConnectionMaxBytesPerSecond uint32                 `json:"connection_max_bytes_per_second,omitempty"`
+	SessionMaxBytesPerSecond    uint32                 `json:"session_max_bytes_per_second,omitempty"`
+	SessionIdleTimeoutSeconds   uint32                 `json:"session_idle_timeout_seconds,omitempty"`
+	HostSelectionStrategy       string                 `json:"host_selection_strategy,omitempty"`
+	RestrictHostId              bool                   `json:"restrict_host_id,omitempty"`
+	WorkerFilter                string                 `json:"worker_filter,omitempty"`
+	CredentialLibraryIds        []string               `json:"credential_library_ids,omitempty"`
+	CredentialLibraries         []*CredentialLibrary   `json:"credential_libraries,omitempty"`
+	Attributes                  map[string]interface{} `json:"attributes,omitempty"`
+	AuthorizedActions           []string               `json:"authorized_actions,omitempty"`
 
 	responseBody *bytes.Buffer
 	responseMap  map[string]interface{}
@@ -76,9 +86,10 @@ func (n TargetDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type TargetListResult struct {
-	Items        []*Target
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*Target
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n TargetListResult) GetItems() interface{} {
@@ -310,6 +321,9 @@ func (c *Client) Delete(ctx context.Context, targetId string, opt ...Option) (*T
 	return target, nil
 }
 
+// List returns every item in the collection. When the controller returns the
+// items in pages, List requests each following page until none remain; the
+// response body and map of the result are those of the last page.
 func (c *Client) List(ctx context.Context, scopeId string, opt ...Option) (*TargetListResult, error) {
 	if scopeId == "" {
 		return nil, fmt.Errorf("empty scopeId value passed into List request")
@@ -321,9 +335,85 @@ func (c *Client) List(ctx context.Context, scopeId string, opt ...Option) (*Targ
 	opts, apiOpts := getOpts(opt...)
 	opts.queryMap["scope_id"] = scopeId
 
-	req, err := c.client.NewRequest(ctx, "GET", "targets", nil, apiOpts...)
+	target := new(TargetListResult)
+	var pageToken string
+	for {
+		req, err := c.client.NewRequest(ctx, "GET", "targets", nil, apiOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating List request: %w", err)
+		}
+
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		if pageToken != "" {
+			q.Set("page_token", pageToken)
+		}
+		req.URL.RawQuery = q.Encode()
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error performing client request during List call: %w", err)
+		}
+
+		page := new(TargetListResult)
+		apiErr, err := resp.Decode(page)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding List response: %w", err)
+		}
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		target.Items = append(target.Items, page.Items...)
+		target.responseBody = resp.Body
+		target.responseMap = resp.Map
+		if page.NextPageToken == "" {
+			return target, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+func (c *Client) AddCredentialLibraries(ctx context.Context, targetId string, version uint32, credentialLibraryIds []string, opt ...Option) (*TargetUpdateResult, error) {
+	if targetId == "" {
+		return nil, fmt.Errorf("empty targetId value passed into AddCredentialLibraries request")
+	}
+	if len(credentialLibraryIds) == 0 {
+		return nil, errors.New("empty credentialLibraryIds passed into AddCredentialLibraries request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if version == 0 {
+		if !opts.withAutomaticVersioning {
+			return nil, errors.New("zero version number passed into AddCredentialLibraries request")
+		}
+		existingTarget, existingErr := c.Read(ctx, targetId, opt...)
+		if existingErr != nil {
+			if api.AsServerError(existingErr) != nil {
+				return nil, fmt.Errorf("error from controller when performing initial check-and-set read: %w", existingErr)
+			}
+			return nil, fmt.Errorf("error performing initial check-and-set read: %w", existingErr)
+		}
+		if existingTarget == nil {
+			return nil, errors.New("nil resource response found when performing initial check-and-set read")
+		}
+		if existingTarget.Item == nil {
+			return nil, errors.New("nil resource found when performing initial check-and-set read")
+		}
+		version = existingTarget.Item.Version
+	}
+
+	opts.postMap["version"] = version
+	opts.postMap["credential_library_ids"] = credentialLibraryIds
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("targets/%s:add-credential-libraries", targetId), opts.postMap, apiOpts...)
 	if err != nil {
-		return nil, fmt.Errorf("error creating List request: %w", err)
+		return nil, fmt.Errorf("error creating AddCredentialLibraries request: %w", err)
 	}
 
 	if len(opts.queryMap) > 0 {
@@ -336,13 +426,14 @@ func (c *Client) List(ctx context.Context, scopeId string, opt ...Option) (*Targ
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error performing client request during List call: %w", err)
+		return nil, fmt.Errorf("error performing client request during AddCredentialLibraries call: %w", err)
 	}
 
-	target := new(TargetListResult)
-	apiErr, err := resp.Decode(target)
+	target := new(TargetUpdateResult)
+	target.Item = new(Target)
+	apiErr, err := resp.Decode(target.Item)
 	if err != nil {
-		return nil, fmt.Errorf("error decoding List response: %w", err)
+		return nil, fmt.Errorf("error decoding AddCredentialLibraries response: %w", err)
 	}
 	if apiErr != nil {
 		return nil, apiErr
@@ -420,6 +511,72 @@ func (c *Client) AddHostSets(ctx context.Context, targetId string, version uint3
 	return target, nil
 }
 
+func (c *Client) SetCredentialLibraries(ctx context.Context, targetId string, version uint32, credentialLibraryIds []string, opt ...Option) (*TargetUpdateResult, error) {
+	if targetId == "" {
+		return nil, fmt.Errorf("empty targetId value passed into SetCredentialLibraries request")
+	}
+
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if version == 0 {
+		if !opts.withAutomaticVersioning {
+			return nil, errors.New("zero version number passed into SetCredentialLibraries request")
+		}
+		existingTarget, existingErr := c.Read(ctx, targetId, opt...)
+		if existingErr != nil {
+			if api.AsServerError(existingErr) != nil {
+				return nil, fmt.Errorf("error from controller when performing initial check-and-set read: %w", existingErr)
+			}
+			return nil, fmt.Errorf("error performing initial check-and-set read: %w", existingErr)
+		}
+		if existingTarget == nil {
+			return nil, errors.New("nil resource response found when performing initial check-and-set read")
+		}
+		if existingTarget.Item == nil {
+			return nil, errors.New("nil resource found when performing initial check-and-set read")
+		}
+		version = existingTarget.Item.Version
+	}
+
+	opts.postMap["version"] = version
+	opts.postMap["credential_library_ids"] = credentialLibraryIds
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("targets/%s:set-credential-libraries", targetId), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating SetCredentialLibraries request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during SetCredentialLibraries call: %w", err)
+	}
+
+	target := new(TargetUpdateResult)
+	target.Item = new(Target)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding SetCredentialLibraries response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
+
 func (c *Client) SetHostSets(ctx context.Context, targetId string, version uint32, hostSetIds []string, opt ...Option) (*TargetUpdateResult, error) {
 	if targetId == "" {
 		return nil, fmt.Errorf("empty targetId value passed into SetHostSets request")
@@ -486,6 +643,74 @@ func (c *Client) SetHostSets(ctx context.Context, targetId string, version uint3
 	return target, nil
 }
 
+func (c *Client) RemoveCredentialLibraries(ctx context.Context, targetId string, version uint32, credentialLibraryIds []string, opt ...Option) (*TargetUpdateResult, error) {
+	if targetId == "" {
+		return nil, fmt.Errorf("empty targetId value passed into RemoveCredentialLibraries request")
+	}
+	if len(credentialLibraryIds) == 0 {
+		return nil, errors.New("empty credentialLibraryIds passed into RemoveCredentialLibraries request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if version == 0 {
+		if !opts.withAutomaticVersioning {
+			return nil, errors.New("zero version number passed into RemoveCredentialLibraries request")
+		}
+		existingTarget, existingErr := c.Read(ctx, targetId, opt...)
+		if existingErr != nil {
+			if api.AsServerError(existingErr) != nil {
+				return nil, fmt.Errorf("error from controller when performing initial check-and-set read: %w", existingErr)
+			}
+			return nil, fmt.Errorf("error performing initial check-and-set read: %w", existingErr)
+		}
+		if existingTarget == nil {
+			return nil, errors.New("nil resource response found when performing initial check-and-set read")
+		}
+		if existingTarget.Item == nil {
+			return nil, errors.New("nil resource found when performing initial check-and-set read")
+		}
+		version = existingTarget.Item.Version
+	}
+
+	opts.postMap["version"] = version
+	opts.postMap["credential_library_ids"] = credentialLibraryIds
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("targets/%s:remove-credential-libraries", targetId), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RemoveCredentialLibraries request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RemoveCredentialLibraries call: %w", err)
+	}
+
+	target := new(TargetUpdateResult)
+	target.Item = new(Target)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RemoveCredentialLibraries response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
+
 func (c *Client) RemoveHostSets(ctx context.Context, targetId string, version uint32, hostSetIds []string, opt ...Option) (*TargetUpdateResult, error) {
 	if targetId == "" {
 		return nil, fmt.Errorf("empty targetId value passed into RemoveHostSets request")
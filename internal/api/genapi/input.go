@@ -7,10 +7,13 @@ import (
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/accounts"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/authmethods"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/authtokens"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/credentiallibraries"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/credentialstores"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/groups"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostcatalogs"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/hosts"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostsets"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/oplogentries"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/roles"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/sessions"
@@ -33,6 +36,7 @@ type fieldInfo struct {
 	SubtypeName       string
 	Query             bool
 	SkipDefault       bool
+	EncodedAsString   bool
 }
 
 type structInfo struct {
@@ -86,6 +90,10 @@ type structInfo struct {
 	// createResponseTypes controls for which structs response types are created
 	createResponseTypes bool
 
+	// recursiveListing indicates that the list endpoint can also list the
+	// resources in every scope beneath the provided scope
+	recursiveListing bool
+
 	// fieldFilter is a set of field names that will not result in generated API
 	// fields
 	fieldFilter []string
@@ -113,6 +121,11 @@ var inputStructs = []*structInfo{
 		outFile:    "scopes/scope_info.gen.go",
 		outputOnly: true,
 	},
+	{
+		inProto:    &scopes.KeyRotation{},
+		outFile:    "scopes/key_rotation.gen.go",
+		outputOnly: true,
+	},
 	{
 		inProto: &scopes.Scope{},
 		outFile: "scopes/scope.gen.go",
@@ -141,6 +154,7 @@ var inputStructs = []*structInfo{
 		},
 		versionEnabled:      true,
 		createResponseTypes: true,
+		recursiveListing:    true,
 	},
 	// User related resources
 	{
@@ -165,6 +179,7 @@ var inputStructs = []*structInfo{
 		pathArgs:            []string{"user"},
 		versionEnabled:      true,
 		createResponseTypes: true,
+		recursiveListing:    true,
 	},
 	// Group related resources
 	{
@@ -189,6 +204,7 @@ var inputStructs = []*structInfo{
 		pathArgs:            []string{"group"},
 		versionEnabled:      true,
 		createResponseTypes: true,
+		recursiveListing:    true,
 	},
 	// Role related resources
 	{
@@ -224,6 +240,7 @@ var inputStructs = []*structInfo{
 		pathArgs:            []string{"role"},
 		versionEnabled:      true,
 		createResponseTypes: true,
+		recursiveListing:    true,
 	},
 	// Auth Methods related resources
 	{
@@ -241,12 +258,23 @@ var inputStructs = []*structInfo{
 		typeOnCreate:        true,
 		versionEnabled:      true,
 		createResponseTypes: true,
+		recursiveListing:    true,
 	},
 	{
 		inProto:     &authmethods.PasswordAuthMethodAttributes{},
 		outFile:     "authmethods/password_auth_method_attributes.gen.go",
 		subtypeName: "PasswordAuthMethod",
 	},
+	{
+		inProto:     &authmethods.OidcAuthMethodAttributes{},
+		outFile:     "authmethods/oidc_auth_method_attributes.gen.go",
+		subtypeName: "OidcAuthMethod",
+	},
+	{
+		inProto:     &authmethods.LdapAuthMethodAttributes{},
+		outFile:     "authmethods/ldap_auth_method_attributes.gen.go",
+		subtypeName: "LdapAuthMethod",
+	},
 	// Accounts
 	{
 		inProto: &accounts.Account{},
@@ -269,6 +297,16 @@ var inputStructs = []*structInfo{
 		outFile:     "accounts/password_account_attributes.gen.go",
 		subtypeName: "PasswordAccount",
 	},
+	{
+		inProto:     &accounts.OidcAccountAttributes{},
+		outFile:     "accounts/oidc_account_attributes.gen.go",
+		subtypeName: "OidcAccount",
+	},
+	{
+		inProto:     &accounts.LdapAccountAttributes{},
+		outFile:     "accounts/ldap_account_attributes.gen.go",
+		subtypeName: "LdapAccount",
+	},
 	// Auth Tokens
 	{
 		inProto: &authtokens.AuthToken{},
@@ -281,6 +319,7 @@ var inputStructs = []*structInfo{
 		},
 		pathArgs:            []string{"auth-token"},
 		createResponseTypes: true,
+		recursiveListing:    true,
 	},
 	// Host related resources
 	{
@@ -298,6 +337,12 @@ var inputStructs = []*structInfo{
 		typeOnCreate:        true,
 		versionEnabled:      true,
 		createResponseTypes: true,
+		recursiveListing:    true,
+	},
+	{
+		inProto:     &hostcatalogs.InventoryHostCatalogAttributes{},
+		outFile:     "hostcatalogs/inventory_host_catalog_attributes.gen.go",
+		subtypeName: "InventoryHostCatalog",
 	},
 	{
 		inProto: &hosts.Host{},
@@ -320,6 +365,11 @@ var inputStructs = []*structInfo{
 		outFile:     "hosts/static_host_attributes.gen.go",
 		subtypeName: "StaticHost",
 	},
+	{
+		inProto:     &hosts.InventoryHostAttributes{},
+		outFile:     "hosts/inventory_host_attributes.gen.go",
+		subtypeName: "InventoryHost",
+	},
 	{
 		inProto: &hostsets.HostSet{},
 		outFile: "hostsets/host_set.gen.go",
@@ -339,10 +389,58 @@ var inputStructs = []*structInfo{
 		versionEnabled:      true,
 		createResponseTypes: true,
 	},
+	{
+		inProto:     &hostsets.InventoryHostSetAttributes{},
+		outFile:     "hostsets/inventory_host_set_attributes.gen.go",
+		subtypeName: "InventoryHostSet",
+	},
+	// Credential related resources
+	{
+		inProto: &credentialstores.CredentialStore{},
+		outFile: "credentialstores/credential_store.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			createTemplate,
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pathArgs:            []string{"credential-store"},
+		typeOnCreate:        true,
+		versionEnabled:      true,
+		createResponseTypes: true,
+		recursiveListing:    true,
+	},
+	{
+		inProto: &credentiallibraries.CredentialLibrary{},
+		outFile: "credentiallibraries/credential_library.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			createTemplate,
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pathArgs:            []string{"credential-library"},
+		parentTypeName:      "credential-store",
+		versionEnabled:      true,
+		createResponseTypes: true,
+	},
+	{
+		inProto:     &credentiallibraries.StaticCredentialLibraryAttributes{},
+		outFile:     "credentiallibraries/static_credential_library_attributes.gen.go",
+		subtypeName: "StaticCredentialLibrary",
+	},
 	{
 		inProto: &targets.HostSet{},
 		outFile: "targets/host_set.gen.go",
 	},
+	{
+		inProto: &targets.CredentialLibrary{},
+		outFile: "targets/credential_library.gen.go",
+	},
 	{
 		inProto: &targets.Target{},
 		outFile: "targets/target.gen.go",
@@ -356,7 +454,8 @@ var inputStructs = []*structInfo{
 		},
 		pathArgs: []string{"target"},
 		sliceSubTypes: map[string]string{
-			"HostSets": "hostSetIds",
+			"HostSets":            "hostSetIds",
+			"CredentialLibraries": "credentialLibraryIds",
 		},
 		extraOptions: []fieldInfo{
 			{
@@ -369,12 +468,23 @@ var inputStructs = []*structInfo{
 		versionEnabled:      true,
 		typeOnCreate:        true,
 		createResponseTypes: true,
+		recursiveListing:    true,
 	},
 	{
 		inProto:     &targets.TcpTargetAttributes{},
 		outFile:     "targets/tcp_target_attributes.gen.go",
 		subtypeName: "TcpTarget",
 	},
+	{
+		inProto:     &targets.UdpTargetAttributes{},
+		outFile:     "targets/udp_target_attributes.gen.go",
+		subtypeName: "UdpTarget",
+	},
+	{
+		inProto:     &targets.SshTargetAttributes{},
+		outFile:     "targets/ssh_target_attributes.gen.go",
+		subtypeName: "SshTarget",
+	},
 	{
 		inProto: &sessions.Session{},
 		outFile: "sessions/session.gen.go",
@@ -386,6 +496,7 @@ var inputStructs = []*structInfo{
 		pathArgs:            []string{"session"},
 		createResponseTypes: true,
 		fieldFilter:         []string{"private_key"},
+		recursiveListing:    true,
 	},
 	{
 		inProto: &sessions.SessionState{},
@@ -395,6 +506,73 @@ var inputStructs = []*structInfo{
 		inProto: &sessions.WorkerInfo{},
 		outFile: "sessions/workers.gen.go",
 	},
+	{
+		inProto: &sessions.ConnectionRecording{},
+		outFile: "sessions/recording.gen.go",
+	},
+	{
+		inProto: &sessions.Connection{},
+		outFile: "sessions/connection.gen.go",
+	},
+	{
+		inProto: &oplogentries.OplogEntry{},
+		outFile: "oplogentries/oplog_entry.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			listTemplate,
+		},
+		pathArgs:            []string{"oplog-entry"},
+		createResponseTypes: true,
+		recursiveListing:    true,
+		extraOptions: []fieldInfo{
+			{
+				Name:        "AggregateName",
+				ProtoName:   "aggregate_names",
+				FieldType:   "string",
+				Query:       true,
+				SkipDefault: true,
+			},
+			{
+				Name:        "Operation",
+				ProtoName:   "operations",
+				FieldType:   "string",
+				Query:       true,
+				SkipDefault: true,
+			},
+			{
+				Name:        "StartTime",
+				ProtoName:   "start_time",
+				FieldType:   "string",
+				Query:       true,
+				SkipDefault: true,
+			},
+			{
+				Name:        "EndTime",
+				ProtoName:   "end_time",
+				FieldType:   "string",
+				Query:       true,
+				SkipDefault: true,
+			},
+			{
+				Name:        "PageSize",
+				ProtoName:   "page_size",
+				FieldType:   "uint32",
+				Query:       true,
+				SkipDefault: true,
+			},
+			{
+				Name:        "WaitSeconds",
+				ProtoName:   "wait_seconds",
+				FieldType:   "uint32",
+				Query:       true,
+				SkipDefault: true,
+			},
+		},
+	},
+	{
+		inProto: &oplogentries.OplogMessage{},
+		outFile: "oplogentries/oplog_message.gen.go",
+	},
 	{
 		inProto:     &targets.SessionAuthorization{},
 		outFile:     "targets/session_authorization.gen.go",
@@ -405,4 +583,9 @@ var inputStructs = []*structInfo{
 		outFile:     "targets/worker_info.gen.go",
 		subtypeName: "WorkerInfo",
 	},
+	{
+		inProto:     &targets.SessionCredential{},
+		outFile:     "targets/session_credential.gen.go",
+		subtypeName: "SessionCredential",
+	},
 }
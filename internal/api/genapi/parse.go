@@ -59,15 +59,22 @@ func parsePBs() {
 			if proto.GetExtension(opts, protooptions.E_GenerateSdkOption).(bool) {
 				fi.GenerateSdkOption = true
 			}
-			switch k := fd.Kind(); k {
-			case protoreflect.MessageKind:
+			switch k := fd.Kind(); {
+			case fd.IsMap():
+				// Only maps of scalars are supported
+				fi.FieldType = fmt.Sprintf("map[%s]%s", fd.MapKey().Kind(), fd.MapValue().Kind())
+			case k == protoreflect.MessageKind:
 				ptr, pkg, name := messageKind(fd)
 				if pkg != "" && pkg != in.generatedStructure.pkg {
 					name = fmt.Sprintf("%s.%s", pkg, name)
 				}
 				fi.FieldType = sliceText + ptr + name
-			case protoreflect.BytesKind:
+			case k == protoreflect.BytesKind:
 				fi.FieldType = "[]byte"
+			case k == protoreflect.Int64Kind, k == protoreflect.Uint64Kind:
+				// protojson encodes 64-bit integers as JSON strings
+				fi.FieldType = sliceText + k.String()
+				fi.EncodedAsString = sliceText == ""
 			default:
 				fi.FieldType = sliceText + k.String()
 			}
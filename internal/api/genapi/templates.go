@@ -24,7 +24,7 @@ func getArgsAndPaths(in []string, parentTypeName, action string) (colArg, resArg
 		strToReplace = in[len(in)-2]
 	}
 	colArg = fmt.Sprintf("%sId", strcase.ToLowerCamel(strings.ReplaceAll(strToReplace, "-", "_")))
-	colPath = fmt.Sprintf("%ss", in[len(in)-1])
+	colPath = pluralize(in[len(in)-1])
 
 	if action != "" {
 		action = fmt.Sprintf(":%s", action)
@@ -33,6 +33,14 @@ func getArgsAndPaths(in []string, parentTypeName, action string) (colArg, resArg
 	return
 }
 
+// pluralize returns the plural of a resource name used in paths.
+func pluralize(name string) string {
+	if strings.HasSuffix(name, "y") {
+		return strings.TrimSuffix(name, "y") + "ies"
+	}
+	return name + "s"
+}
+
 type templateInput struct {
 	Name                  string
 	Package               string
@@ -90,13 +98,14 @@ func fillTemplates() {
 		// We want to generate options per-package, not per-struct, so we
 		// collate them all here for writing later. The map argument of the
 		// package map is to prevent duplicates since we may have multiple e.g.
-		// Name or Description fields.
+		// Name or Description fields. Subtype fields are keyed by subtype as
+		// well since different subtypes may share an attribute name.
 		if !in.outputOnly {
 			pkgOptionMap := map[string]fieldInfo{}
 			for _, val := range input.Fields {
 				if val.GenerateSdkOption {
 					val.SubtypeName = in.subtypeName
-					pkgOptionMap[val.Name] = val
+					pkgOptionMap[val.SubtypeName+val.Name] = val
 				}
 			}
 			optionMap := optionsMap[input.Package]
@@ -108,6 +117,29 @@ func fillTemplates() {
 			}
 			optionsMap[input.Package] = optionMap
 		}
+		// Every list endpoint accepts a filter, so add the option for any
+		// resource that has a list function.
+		for _, t := range in.templates {
+			if t == listTemplate {
+				in.extraOptions = append(in.extraOptions, fieldInfo{
+					Name:        "Filter",
+					ProtoName:   "filter",
+					FieldType:   "string",
+					Query:       true,
+					SkipDefault: true,
+				})
+				break
+			}
+		}
+		if in.recursiveListing {
+			in.extraOptions = append(in.extraOptions, fieldInfo{
+				Name:        "Recursive",
+				ProtoName:   "recursive",
+				FieldType:   "bool",
+				Query:       true,
+				SkipDefault: true,
+			})
+		}
 		// Add in extra defined options
 		if len(in.extraOptions) > 0 {
 			optionMap := optionsMap[input.Package]
@@ -139,16 +171,16 @@ func fillTemplates() {
 	for pkg, options := range optionsMap {
 		outBuf := new(bytes.Buffer)
 
-		var fieldNames []string
-		for _, v := range options {
-			fieldNames = append(fieldNames, v.Name)
-		}
-		sort.Strings(fieldNames)
-
 		var fields []fieldInfo
-		for _, v := range fieldNames {
-			fields = append(fields, options[v])
+		for _, v := range options {
+			fields = append(fields, v)
 		}
+		sort.Slice(fields, func(i, j int) bool {
+			if fields[i].Name != fields[j].Name {
+				return fields[i].Name < fields[j].Name
+			}
+			return fields[i].SubtypeName < fields[j].SubtypeName
+		})
 
 		input := templateInput{
 			Package: pkg,
@@ -181,6 +213,9 @@ var listTemplate = template.Must(template.New("").Funcs(
 		"snakeCase": snakeCase,
 	},
 ).Parse(`
+// List returns every item in the collection. When the controller returns the
+// items in pages, List requests each following page until none remain; the
+// response body and map of the result are those of the last page.
 func (c *Client) List(ctx context.Context, {{ .CollectionFunctionArg }} string, opt... Option) (*{{ .Name }}ListResult, error) {
 	if {{ .CollectionFunctionArg }} == "" {
 		return nil, fmt.Errorf("empty {{ .CollectionFunctionArg }} value passed into List request")
@@ -191,38 +226,48 @@ func (c *Client) List(ctx context.Context, {{ .CollectionFunctionArg }} string,
 
 	opts, apiOpts := getOpts(opt...)
 	opts.queryMap["{{ snakeCase .CollectionFunctionArg }}"] = {{ .CollectionFunctionArg }}
-
-	req, err := c.client.NewRequest(ctx, "GET", "{{ .CollectionPath }}", nil, apiOpts...)
-	if err != nil {
-		return nil, fmt.Errorf("error creating List request: %w", err)
-	}
 	{{ if ( eq .CollectionPath "\"scopes\"" ) }}
 	opts.queryMap["scope_id"] = scopeId
 	{{ end }}
-	if len(opts.queryMap) > 0 {
+
+	target := new({{ .Name }}ListResult)
+	var pageToken string
+	for {
+		req, err := c.client.NewRequest(ctx, "GET", "{{ .CollectionPath }}", nil, apiOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating List request: %w", err)
+		}
+
 		q := url.Values{}
 		for k, v := range opts.queryMap {
 			q.Add(k, v)
 		}
+		if pageToken != "" {
+			q.Set("page_token", pageToken)
+		}
 		req.URL.RawQuery = q.Encode()
-	}
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error performing client request during List call: %w", err)
-	}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error performing client request during List call: %w", err)
+		}
 
-	target := new({{ .Name }}ListResult)
-	apiErr, err := resp.Decode(target)
-	if err != nil {
-		return nil, fmt.Errorf("error decoding List response: %w", err)
-	}
-	if apiErr != nil {
-		return nil, apiErr
+		page := new({{ .Name }}ListResult)
+		apiErr, err := resp.Decode(page)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding List response: %w", err)
+		}
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		target.Items = append(target.Items, page.Items...)
+		target.responseBody = resp.Body
+		target.responseMap = resp.Map
+		if page.NextPageToken == "" {
+			return target, nil
+		}
+		pageToken = page.NextPageToken
 	}
-	target.responseBody = resp.Body
-	target.responseMap = resp.Map
-	return target, nil
 }
 `))
 
@@ -543,7 +588,7 @@ import (
 )
 
 type {{ .Name }} struct { {{ range .Fields }}
-{{ .Name }}  {{ .FieldType }} `, "`json:\"{{ .ProtoName }},omitempty\"`", `{{ end }}
+{{ .Name }}  {{ .FieldType }} `, "`json:\"{{ .ProtoName }},omitempty{{ if .EncodedAsString }},string{{ end }}\"`", `{{ end }}
 {{ if ( or .CreateResponseTypes ( eq .Name "Error" ) ) }}
 	responseBody *bytes.Buffer
 	responseMap map[string]interface{}
@@ -597,6 +642,7 @@ func (n {{ .Name }}DeleteResult) GetResponseMap() map[string]interface{} {
 
 type {{ .Name }}ListResult struct {
 	Items []*{{ .Name }}
+	NextPageToken string `, "`json:\"next_page_token,omitempty\"`", `
 	responseBody *bytes.Buffer
 	responseMap map[string]interface{}
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -151,3 +152,84 @@ func TestAdditionalVerification(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchActionSetForId(t *testing.T) {
+	tc := controller.NewTestController(t, nil)
+	defer tc.Shutdown()
+
+	conn := tc.DbConn()
+	token := tc.Token()
+	_, proj := iam.TestScopes(t, tc.IamRepo(), iam.WithUserId(token.UserId), iam.WithSkipAdminRoleCreation(true), iam.WithSkipDefaultRoleCreation(true))
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return tc.IamRepo(), nil
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return tc.ServersRepo(), nil
+	}
+	authTokenRepoFn := func() (*authtoken.Repository, error) {
+		return tc.AuthTokenRepo(), nil
+	}
+
+	projRole := iam.TestRole(t, conn, proj.GetPublicId())
+	iam.TestUserRole(t, conn, projRole.PublicId, token.UserId)
+	iam.TestRoleGrant(t, conn, projRole.PublicId, "id=*;type=target;actions=list,authorize-session")
+	iam.TestRoleGrant(t, conn, projRole.PublicId, "id=ttcp_1234567890;actions=read,update")
+	iam.TestRoleGrant(t, conn, projRole.PublicId, "id=hcst_1234567890;type=host-set;actions=add-hosts")
+
+	ctx := auth.NewVerifierContext(
+		context.Background(),
+		tc.Logger(),
+		iamRepoFn,
+		authTokenRepoFn,
+		serversRepoFn,
+		tc.Kms(),
+		auth.RequestInfo{
+			PublicId:       token.Id,
+			EncryptedToken: strings.Split(token.Token, "_")[2],
+			TokenFormat:    auth.AuthTokenTypeBearer,
+		})
+	res := auth.Verify(ctx, auth.WithScopeId(proj.GetPublicId()), auth.WithType(resource.Target), auth.WithAction(action.List))
+	require.NoError(t, res.Error)
+
+	available := action.ActionSet{action.Read, action.Update, action.Delete, action.AuthorizeSession}
+	cases := []struct {
+		name string
+		id   string
+		opts []auth.Option
+		want action.ActionSet
+	}{
+		{
+			name: "granted by id",
+			id:   "ttcp_1234567890",
+			want: action.ActionSet{action.Read, action.Update, action.AuthorizeSession},
+		},
+		{
+			name: "granted by type",
+			id:   "ttcp_0987654321",
+			want: action.ActionSet{action.AuthorizeSession},
+		},
+		{
+			name: "other type",
+			id:   "hsst_1234567890",
+			opts: []auth.Option{auth.WithType(resource.HostSet)},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, res.FetchActionSetForId(ctx, tt.id, available, tt.opts...))
+		})
+	}
+
+	t.Run("pinned add-hosts", func(t *testing.T) {
+		got := res.FetchActionSetForId(ctx, "hsst_1234567890", action.ActionSet{action.Read, action.AddHosts}, auth.WithType(resource.HostSet), auth.WithPin("hcst_1234567890"))
+		assert.Equal(t, action.ActionSet{action.AddHosts}, got)
+	})
+
+	t.Run("auth disabled", func(t *testing.T) {
+		ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+		res := auth.Verify(ctx, auth.WithScopeId(proj.GetPublicId()), auth.WithType(resource.Target), auth.WithAction(action.List))
+		require.NoError(t, res.Error)
+		assert.Equal(t, available, res.FetchActionSetForId(ctx, "ttcp_0987654321", available))
+	})
+}
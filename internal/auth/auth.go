@@ -68,6 +68,9 @@ type VerifyResults struct {
 	Error       error
 	Scope       *scopes.ScopeInfo
 
+	// The type of resource which was verified
+	resourceType resource.Type
+
 	// Used for additional verification
 	v *verifier
 }
@@ -83,6 +86,30 @@ type verifier struct {
 	act             action.Type
 	ctx             context.Context
 	acl             perms.ACL
+	audit           *AuditInfo
+}
+
+// AuditInfo describes the outcome of the first authn/authz check performed for
+// a request, for use in audit events.
+type AuditInfo struct {
+	UserId       string
+	AuthTokenId  string
+	ScopeId      string
+	ResourceId   string
+	ResourceType string
+	Action       string
+	Authorized   bool
+}
+
+// AuditInfoFromContext returns the outcome of the first authn/authz check
+// performed with the verifier in ctx. It returns false if there is no verifier
+// in ctx or no check has been performed with it.
+func AuditInfoFromContext(ctx context.Context) (AuditInfo, bool) {
+	v, ok := ctx.Value(verifierKey).(*verifier)
+	if !ok || v.audit == nil {
+		return AuditInfo{}, false
+	}
+	return *v.audit, true
 }
 
 // NewVerifierContext creates a context that carries a verifier object from the
@@ -125,6 +152,7 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 	v.ctx = ctx
 
 	opts := getOpts(opt...)
+	ret.resourceType = opts.withType
 
 	ret.Scope = new(scopes.ScopeInfo)
 	if v.requestInfo.DisableAuthEntirely {
@@ -142,6 +170,7 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 		}
 		ret.UserId = v.requestInfo.userIdOverride
 		ret.Error = nil
+		v.recordAudit(opts, ret.UserId, ret.Scope.Id, true)
 		return
 	}
 
@@ -166,8 +195,10 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 	authResults, ret.UserId, ret.Scope, v.acl, err = v.performAuthCheck()
 	if err != nil {
 		v.logger.Error("error performing authn/authz check", "error", err)
+		v.recordAudit(opts, ret.UserId, v.res.ScopeId, false)
 		return
 	}
+	v.recordAudit(opts, ret.UserId, ret.Scope.GetId(), authResults.Allowed)
 
 	ret.AuthTokenId = v.requestInfo.PublicId
 	if !authResults.Allowed {
@@ -190,6 +221,51 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 	return
 }
 
+// VerifyRecursive runs Verify against the scope provided with WithScopeId and
+// against every scope beneath it, authorizing each scope separately. It
+// returns the results for the scopes in which the check succeeded, parents
+// before children. If the check fails in every scope the error for the
+// provided scope is returned.
+func VerifyRecursive(ctx context.Context, iamRepoFn common.IamRepoFactory, opt ...Option) ([]VerifyResults, error) {
+	opts := getOpts(opt...)
+	iamRepo, err := iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	scps, err := iamRepo.ListScopesRecursively(ctx, opts.withScopeId)
+	if err != nil {
+		return nil, err
+	}
+	if len(scps) == 0 {
+		return nil, handlers.NotFoundError()
+	}
+	var rootErr error
+	var ret []VerifyResults
+	for i, scp := range scps {
+		res := Verify(ctx, append(opt, WithScopeId(scp.GetPublicId()))...)
+		if res.Error != nil {
+			if i == 0 {
+				rootErr = res.Error
+			}
+			continue
+		}
+		// Populate the scope info from the listed scope, since Verify only
+		// fills in the id and type when auth is disabled.
+		res.Scope = &scopes.ScopeInfo{
+			Id:            scp.GetPublicId(),
+			Type:          scp.GetType(),
+			Name:          scp.GetName(),
+			Description:   scp.GetDescription(),
+			ParentScopeId: scp.GetParentId(),
+		}
+		ret = append(ret, res)
+	}
+	if len(ret) == 0 {
+		return nil, rootErr
+	}
+	return ret, nil
+}
+
 // AdditionalVerification is used to perform checks of additional resources for
 // actions that need to touch more than one.
 func (r *VerifyResults) AdditionalVerification(ctx context.Context, opt ...Option) (ret VerifyResults) {
@@ -285,6 +361,60 @@ func (r *VerifyResults) AdditionalVerification(ctx context.Context, opt ...Optio
 	return
 }
 
+// FetchActionSetForId returns the actions out of availableActions which the
+// verified user is allowed to perform on the resource with the given id. The
+// resource is taken to be in the scope of the results and of the verified type
+// unless WithType is given. WithPin sets the id the resource is pinned to, e.g.
+// the host catalog of a host.
+func (r *VerifyResults) FetchActionSetForId(ctx context.Context, id string, availableActions action.ActionSet, opt ...Option) action.ActionSet {
+	if r.v == nil {
+		return nil
+	}
+	// Everything is allowed when auth is disabled or when using the recovery
+	// KMS, and no ACL is built in those cases
+	if r.v.requestInfo.DisableAuthEntirely || r.v.requestInfo.TokenFormat == AuthTokenTypeRecoveryKms {
+		return availableActions
+	}
+
+	opts := getOpts(opt...)
+	res := perms.Resource{
+		ScopeId: r.Scope.GetId(),
+		Id:      id,
+		Pin:     opts.withPin,
+		Type:    r.resourceType,
+	}
+	if opts.withType != resource.Unknown {
+		res.Type = opts.withType
+	}
+
+	var ret action.ActionSet
+	for _, act := range availableActions {
+		if r.v.acl.Allowed(res, act).Allowed {
+			ret = append(ret, act)
+		}
+	}
+	return ret
+}
+
+// recordAudit records the outcome of a check for AuditInfoFromContext. Only the
+// first check of a request is recorded, as later checks (e.g. those of
+// VerifyRecursive or AdditionalVerification) refine the request rather than
+// describe it.
+func (v *verifier) recordAudit(opts options, userId, scopeId string, authorized bool) {
+	if v.audit != nil {
+		return
+	}
+	v.audit = &AuditInfo{
+		UserId:       userId,
+		AuthTokenId:  v.requestInfo.PublicId,
+		ScopeId:      scopeId,
+		ResourceId:   opts.withId,
+		ResourceType: opts.withType.String(),
+		Action:       opts.withAction.String(),
+		Authorized:   authorized,
+	}
+}
+
 func (v verifier) performAuthCheck() (aclResults perms.ACLResults, userId string, scopeInfo *scopes.ScopeInfo, retAcl perms.ACL, retErr error) {
 	// Ensure we return an error by default if we forget to set this somewhere
 	retErr = errors.New("unknown")
@@ -493,13 +623,6 @@ func (v *verifier) decryptToken() {
 			return
 		}
 
-		tokenWrapper, err := v.kms.GetWrapper(v.ctx, at.GetScopeId(), kms.KeyPurposeTokens)
-		if err != nil {
-			v.logger.Warn("decrypt bearer token: unable to get wrapper for tokens; continuing as anonymous user", "error", err)
-			v.requestInfo.TokenFormat = AuthTokenTypeUnknown
-			return
-		}
-
 		version := v.requestInfo.EncryptedToken[0:len(globals.ServiceTokenV1)]
 		switch version {
 		case globals.ServiceTokenV1:
@@ -522,6 +645,15 @@ func (v *verifier) decryptToken() {
 			return
 		}
 
+		// The token may have been encrypted by another controller with a
+		// version of the tokens key created after this controller cached it.
+		tokenWrapper, err := v.kms.GetWrapper(v.ctx, at.GetScopeId(), kms.KeyPurposeTokens, kms.WithKeyId(blobInfo.GetKeyInfo().GetKeyID()))
+		if err != nil {
+			v.logger.Warn("decrypt bearer token: unable to get wrapper for tokens; continuing as anonymous user", "error", err)
+			v.requestInfo.TokenFormat = AuthTokenTypeUnknown
+			return
+		}
+
 		s1Bytes, err := tokenWrapper.Decrypt(v.ctx, blobInfo, []byte(v.requestInfo.PublicId))
 		if err != nil {
 			v.logger.Trace("decrypt bearer token: error decrypting encrypted token; continuing as anonymous user", "error", err)
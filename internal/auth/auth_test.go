@@ -13,6 +13,8 @@ import (
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -133,3 +135,28 @@ func TestAuthTokenAuthenticator(t *testing.T) {
 		})
 	}
 }
+
+func TestAuditInfoFromContext(t *testing.T) {
+	assert := assert.New(t)
+
+	_, ok := AuditInfoFromContext(context.Background())
+	assert.False(ok)
+
+	ctx := DisabledAuthTestContext(WithScopeId("o_1234567890"), WithUserId("u_1234567890"))
+	_, ok = AuditInfoFromContext(ctx)
+	assert.False(ok)
+
+	Verify(ctx, WithId("ttcp_1234567890"), WithType(resource.Target), WithAction(action.Read))
+	Verify(ctx, WithId("ttcp_0987654321"), WithType(resource.Target), WithAction(action.Delete))
+
+	info, ok := AuditInfoFromContext(ctx)
+	assert.True(ok)
+	assert.Equal(AuditInfo{
+		UserId:       "u_1234567890",
+		ScopeId:      "o_1234567890",
+		ResourceId:   "ttcp_1234567890",
+		ResourceType: resource.Target.String(),
+		Action:       action.Read.String(),
+		Authorized:   true,
+	}, info)
+}
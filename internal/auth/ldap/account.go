@@ -0,0 +1,82 @@
+package ldap
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth/ldap/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// An Account is the identity of a user in an LDAP directory, identified by
+// the login name the user authenticates with. It is owned by an auth method.
+type Account struct {
+	*store.Account
+	tableName string
+}
+
+func allocAccount() *Account {
+	return &Account{
+		Account: &store.Account{},
+	}
+}
+
+// NewAccount creates a new in memory Account. Name, description, and login
+// name are the only valid options. All other options are ignored.
+func NewAccount(authMethodId string, opt ...Option) (*Account, error) {
+	// The scopeId in the auth_ldap_account table is populated by a trigger
+	// in the database.
+	if authMethodId == "" {
+		return nil, fmt.Errorf("new: ldap account: no auth method id: %w", db.ErrInvalidParameter)
+	}
+
+	opts := getOpts(opt...)
+	a := &Account{
+		Account: &store.Account{
+			AuthMethodId: authMethodId,
+			Name:         opts.withName,
+			Description:  opts.withDescription,
+			LoginName:    opts.withLoginName,
+		},
+	}
+	return a, nil
+}
+
+// GroupList returns the names of the user's LDAP groups as of the last
+// authentication.
+func (a *Account) GroupList() []string {
+	return splitList(a.GetMemberOfGroups())
+}
+
+func (a *Account) clone() *Account {
+	cp := proto.Clone(a.Account)
+	return &Account{
+		Account: cp.(*store.Account),
+	}
+}
+
+// TableName returns the table name.
+func (a *Account) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return "auth_ldap_account"
+}
+
+// SetTableName sets the table name.
+func (a *Account) SetTableName(n string) {
+	a.tableName = n
+}
+
+func (a *Account) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{a.GetPublicId()},
+		"resource-type":      []string{"ldap account"},
+		"op-type":            []string{op.String()},
+	}
+	if a.AuthMethodId != "" {
+		metadata["auth-method-id"] = []string{a.AuthMethodId}
+	}
+	return metadata
+}
@@ -0,0 +1,84 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+// GroupSync lists the names of the groups of the auth method's scope a user
+// who authenticated with an auth method that syncs groups must be a member
+// of, and the names of those the user must no longer be a member of.
+type GroupSync struct {
+	Add    []string
+	Remove []string
+}
+
+// Authenticate authenticates loginName and password against the directory
+// of the auth method and returns the account for loginName. The account is
+// created if it does not exist yet. Otherwise its distinguished name, full
+// name, email, and groups are updated from the user's entry. If loginName
+// and password are not valid, nil, nil, nil is returned.
+//
+// If the auth method syncs groups, the returned GroupSync lists the group
+// changes for the user of the account. Otherwise it is nil.
+func (r *Repository) Authenticate(ctx context.Context, authMethodId, loginName, password string, opt ...Option) (*Account, *GroupSync, error) {
+	if authMethodId == "" {
+		return nil, nil, fmt.Errorf("ldap authenticate: no auth method id: %w", db.ErrInvalidParameter)
+	}
+	if strings.TrimSpace(loginName) == "" {
+		return nil, nil, fmt.Errorf("ldap authenticate: no login name: %w", db.ErrInvalidParameter)
+	}
+	am, err := r.LookupAuthMethod(ctx, authMethodId)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ldap authenticate: %w", err)
+	}
+	if am == nil {
+		return nil, nil, fmt.Errorf("ldap authenticate: auth method %s: %w", authMethodId, db.ErrRecordNotFound)
+	}
+	if len(am.CtBindPassword) > 0 {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, am.GetScopeId(), kms.KeyPurposeDatabase, kms.WithKeyId(am.GetKeyId()))
+		if err != nil {
+			return nil, nil, fmt.Errorf("ldap authenticate: unable to get database wrapper: %w", err)
+		}
+		if err := am.decrypt(ctx, databaseWrapper); err != nil {
+			return nil, nil, fmt.Errorf("ldap authenticate: %w", err)
+		}
+	}
+
+	e, err := authenticateUser(am, loginName, password)
+	if err != nil {
+		if errors.Is(err, errInvalidCredentials) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("ldap authenticate: auth method %s: %w", authMethodId, err)
+	}
+
+	acct, previousGroups, err := r.linkAccount(ctx, am, e)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ldap authenticate: %w", err)
+	}
+	if !am.GetSyncGroups() {
+		return acct, nil, nil
+	}
+	gs := &GroupSync{Add: e.groups}
+	for _, g := range previousGroups {
+		if !containsFold(e.groups, g) {
+			gs.Remove = append(gs.Remove, g)
+		}
+	}
+	return acct, gs, nil
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Authenticate(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	d := testDirectoryWithUsers(t)
+	am := TestAuthMethod(t, conn, databaseWrapper, org.GetPublicId(), []string{d.Url()}, "ou=people,dc=example,dc=com",
+		WithBindCredential("cn=admin,dc=example,dc=com", "admin-password"),
+		WithGroupSearch("ou=groups,dc=example,dc=com", "", ""),
+		WithSyncGroups(true),
+	)
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	assert, require := assert.New(t), require.New(t)
+	first, gs, err := repo.Authenticate(ctx, am.GetPublicId(), "Alice", "alice-password")
+	require.NoError(err)
+	require.NotNil(first)
+	assert.Equal("alice", first.GetLoginName())
+	assert.Equal("uid=alice,ou=people,dc=example,dc=com", first.GetDn())
+	assert.Equal("Alice Doe", first.GetFullName())
+	assert.Equal("alice@example.com", first.GetEmail())
+	assert.Equal([]string{"engineering", "admins"}, first.GroupList())
+	require.NotNil(gs)
+	assert.Equal([]string{"engineering", "admins"}, gs.Add)
+	assert.Empty(gs.Remove)
+
+	// The same login name authenticates to the same account, which picks up
+	// changes to the user's entry and groups.
+	d.SetAttribute("uid=alice,ou=people,dc=example,dc=com", "displayName", "Alice Roe")
+	d.SetAttribute("cn=admins,ou=groups,dc=example,dc=com", "uniqueMember")
+	second, gs, err := repo.Authenticate(ctx, am.GetPublicId(), "alice", "alice-password")
+	require.NoError(err)
+	assert.Equal(first.GetPublicId(), second.GetPublicId())
+	assert.Equal("Alice Roe", second.GetFullName())
+	assert.Equal([]string{"engineering"}, second.GroupList())
+	require.NotNil(gs)
+	assert.Equal([]string{"engineering"}, gs.Add)
+	assert.Equal([]string{"admins"}, gs.Remove)
+
+	// An account created ahead of time is linked on the first
+	// authentication.
+	pre := TestAccount(t, conn, am.GetPublicId(), "bob")
+	third, _, err := repo.Authenticate(ctx, am.GetPublicId(), "bob", "bob-password")
+	require.NoError(err)
+	assert.Equal(pre.GetPublicId(), third.GetPublicId())
+	assert.Equal("uid=bob,ou=people,dc=example,dc=com", third.GetDn())
+
+	accts, err := repo.ListAccounts(ctx, am.GetPublicId())
+	require.NoError(err)
+	assert.Len(accts, 2)
+
+	acct, gs, err := repo.Authenticate(ctx, am.GetPublicId(), "alice", "wrong-password")
+	require.NoError(err)
+	assert.Nil(acct)
+	assert.Nil(gs)
+
+	unreachable := TestAuthMethod(t, conn, databaseWrapper, org.GetPublicId(), []string{"ldap://127.0.0.1:1"}, "ou=people,dc=example,dc=com")
+	_, _, err = repo.Authenticate(ctx, unreachable.GetPublicId(), "alice", "alice-password")
+	assert.Truef(errors.Is(err, ErrDirectoryUnavailable), "want err: %q got: %q", ErrDirectoryUnavailable, err)
+}
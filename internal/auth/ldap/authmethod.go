@@ -0,0 +1,230 @@
+package ldap
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth/ldap/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// Placeholders in the user and group search filters.
+const (
+	loginNamePlaceholder = "{{login_name}}"
+	userDnPlaceholder    = "{{user_dn}}"
+)
+
+// Defaults for the search settings of an AuthMethod.
+const (
+	DefaultUserFilter  = "(uid=" + loginNamePlaceholder + ")"
+	DefaultGroupFilter = "(|(member=" + userDnPlaceholder + ")(uniqueMember=" + userDnPlaceholder + "))"
+	DefaultGroupAttr   = "cn"
+)
+
+// A AuthMethod authenticates users against an LDAP directory, such as
+// Active Directory, and contains the accounts of the users which
+// authenticated with it. It is owned by a scope.
+type AuthMethod struct {
+	*store.AuthMethod
+	tableName string
+}
+
+func allocAuthMethod() AuthMethod {
+	return AuthMethod{
+		AuthMethod: &store.AuthMethod{},
+	}
+}
+
+// NewAuthMethod creates a new in memory AuthMethod assigned to scopeId.
+// Name, description, urls, start tls, insecure tls, certificates,
+// certificate pins, bind credential, user search, group search, and sync
+// groups are the only valid options. All other options are ignored. The
+// user filter, group filter, and group attribute default to
+// DefaultUserFilter, DefaultGroupFilter, and DefaultGroupAttr.
+func NewAuthMethod(scopeId string, opt ...Option) (*AuthMethod, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("new: ldap auth method: no scope id: %w", db.ErrInvalidParameter)
+	}
+
+	opts := getOpts(opt...)
+	a := &AuthMethod{
+		AuthMethod: &store.AuthMethod{
+			ScopeId:         scopeId,
+			Name:            opts.withName,
+			Description:     opts.withDescription,
+			Urls:            strings.Join(opts.withUrls, ","),
+			StartTls:        opts.withStartTls,
+			InsecureTls:     opts.withInsecureTls,
+			Certificates:    opts.withCertificates,
+			CertificatePins: strings.Join(opts.withCertificatePins, ","),
+			BindDn:          opts.withBindDn,
+			BindPassword:    opts.withBindPassword,
+			UserDn:          opts.withUserDn,
+			UserFilter:      opts.withUserFilter,
+			GroupDn:         opts.withGroupDn,
+			GroupFilter:     opts.withGroupFilter,
+			GroupAttr:       opts.withGroupAttr,
+			SyncGroups:      opts.withSyncGroups,
+		},
+	}
+	if a.UserFilter == "" {
+		a.UserFilter = DefaultUserFilter
+	}
+	if a.GroupFilter == "" {
+		a.GroupFilter = DefaultGroupFilter
+	}
+	if a.GroupAttr == "" {
+		a.GroupAttr = DefaultGroupAttr
+	}
+	return a, nil
+}
+
+func (a *AuthMethod) clone() *AuthMethod {
+	cp := proto.Clone(a.AuthMethod)
+	return &AuthMethod{
+		AuthMethod: cp.(*store.AuthMethod),
+	}
+}
+
+// UrlList returns the URLs of the directory servers in the order they are
+// tried.
+func (a *AuthMethod) UrlList() []string {
+	return splitList(a.GetUrls())
+}
+
+// PinList returns the SHA-256 fingerprints a server's certificate must
+// match one of. An empty list means certificates are not pinned.
+func (a *AuthMethod) PinList() []string {
+	return splitList(a.GetCertificatePins())
+}
+
+// validate checks the directory settings of a.
+func (a *AuthMethod) validate() error {
+	if err := validateUrls(a.UrlList()); err != nil {
+		return err
+	}
+	if err := validateCertificates(a.Certificates); err != nil {
+		return err
+	}
+	if err := validatePins(a.PinList()); err != nil {
+		return err
+	}
+	if strings.TrimSpace(a.UserDn) == "" {
+		return fmt.Errorf("no user dn: %w", db.ErrInvalidParameter)
+	}
+	if !strings.Contains(a.UserFilter, loginNamePlaceholder) {
+		return fmt.Errorf("user filter %q must contain %s: %w", a.UserFilter, loginNamePlaceholder, db.ErrInvalidParameter)
+	}
+	if a.BindDn != "" && a.BindPassword == "" && len(a.CtBindPassword) == 0 {
+		return fmt.Errorf("no bind password for bind dn %q: %w", a.BindDn, db.ErrInvalidParameter)
+	}
+	if a.SyncGroups && strings.TrimSpace(a.GroupDn) == "" {
+		return fmt.Errorf("syncing groups requires a group dn: %w", db.ErrInvalidParameter)
+	}
+	return nil
+}
+
+func (a *AuthMethod) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	if a.BindPassword == "" {
+		a.CtBindPassword = nil
+		a.KeyId = ""
+		return nil
+	}
+	if err := structwrapping.WrapStruct(ctx, cipher, a.AuthMethod, nil); err != nil {
+		return fmt.Errorf("error encrypting ldap bind password: %w", err)
+	}
+	a.KeyId = cipher.KeyID()
+	// The plaintext password is not stored but would otherwise be written to
+	// the oplog.
+	a.BindPassword = ""
+	return nil
+}
+
+func (a *AuthMethod) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	if len(a.CtBindPassword) == 0 {
+		return nil
+	}
+	if err := structwrapping.UnwrapStruct(ctx, cipher, a.AuthMethod, nil); err != nil {
+		return fmt.Errorf("error decrypting ldap bind password: %w", err)
+	}
+	return nil
+}
+
+// TableName returns the table name.
+func (a *AuthMethod) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return "auth_ldap_method"
+}
+
+// SetTableName sets the table name.
+func (a *AuthMethod) SetTableName(n string) {
+	a.tableName = n
+}
+
+func (a *AuthMethod) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{a.GetPublicId()},
+		"resource-type":      []string{"ldap auth method"},
+		"op-type":            []string{op.String()},
+	}
+	if a.ScopeId != "" {
+		metadata["scope-id"] = []string{a.ScopeId}
+	}
+	return metadata
+}
+
+func validateUrls(urls []string) error {
+	if len(urls) == 0 {
+		return fmt.Errorf("no urls: %w", db.ErrInvalidParameter)
+	}
+	for _, u := range urls {
+		pu, err := url.Parse(u)
+		if err != nil {
+			return fmt.Errorf("url %q: %v: %w", u, err, db.ErrInvalidParameter)
+		}
+		if (pu.Scheme != "ldap" && pu.Scheme != "ldaps") || pu.Host == "" {
+			return fmt.Errorf("url %q must be an ldap or ldaps url: %w", u, db.ErrInvalidParameter)
+		}
+	}
+	return nil
+}
+
+func validateCertificates(pem string) error {
+	if strings.TrimSpace(pem) == "" {
+		return nil
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM([]byte(pem)) {
+		return fmt.Errorf("certificates must contain at least one PEM encoded certificate: %w", db.ErrInvalidParameter)
+	}
+	return nil
+}
+
+func validatePins(pins []string) error {
+	for _, p := range pins {
+		b, err := hex.DecodeString(p)
+		if err != nil || len(b) != 32 {
+			return fmt.Errorf("certificate pin %q must be a hex encoded SHA-256 fingerprint: %w", p, db.ErrInvalidParameter)
+		}
+	}
+	return nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
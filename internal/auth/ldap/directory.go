@@ -0,0 +1,205 @@
+package ldap
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/go-multierror"
+)
+
+// directoryTimeout bounds connecting to a directory server and each
+// operation performed on it.
+const directoryTimeout = 10 * time.Second
+
+// userEntry is what is learned about a user from a successful
+// authentication against the directory.
+type userEntry struct {
+	loginName string
+	dn        string
+	fullName  string
+	email     string
+	groups    []string
+}
+
+// errInvalidCredentials results from a login name which matches no single
+// user or a password the directory rejects.
+var errInvalidCredentials = errors.New("invalid credentials")
+
+// authenticateUser searches the directory of am for the user with
+// loginName, binds as that user with password, and returns the user's entry
+// and groups. am's bind password must be decrypted. errInvalidCredentials
+// is returned if the login name or password is not valid.
+func authenticateUser(am *AuthMethod, loginName, password string) (*userEntry, error) {
+	if password == "" {
+		// An empty password would result in an unauthenticated bind which
+		// most directories accept.
+		return nil, errInvalidCredentials
+	}
+	conn, err := dial(am)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := bindSearcher(conn, am); err != nil {
+		return nil, err
+	}
+	filter := strings.Replace(am.GetUserFilter(), loginNamePlaceholder, ldap.EscapeFilter(loginName), -1)
+	res, err := conn.Search(ldap.NewSearchRequest(
+		am.GetUserDn(), ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, int(directoryTimeout.Seconds()), false,
+		filter, []string{"cn", "displayName", "mail"}, nil,
+	))
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+			return nil, errInvalidCredentials
+		}
+		return nil, fmt.Errorf("user search: %w", err)
+	}
+	if len(res.Entries) != 1 {
+		return nil, errInvalidCredentials
+	}
+	user := res.Entries[0]
+
+	if err := conn.Bind(user.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, errInvalidCredentials
+		}
+		return nil, fmt.Errorf("user bind: %w", err)
+	}
+
+	e := &userEntry{
+		loginName: normalizeLoginName(loginName),
+		dn:        user.DN,
+		fullName:  user.GetAttributeValue("displayName"),
+		email:     user.GetAttributeValue("mail"),
+	}
+	if e.fullName == "" {
+		e.fullName = user.GetAttributeValue("cn")
+	}
+	if am.GetGroupDn() == "" {
+		return e, nil
+	}
+
+	// Groups are searched with the bind credential if there is one since
+	// users may not be allowed to read group entries.
+	if am.GetBindDn() != "" {
+		if err := bindSearcher(conn, am); err != nil {
+			return nil, err
+		}
+	}
+	filter = strings.Replace(am.GetGroupFilter(), userDnPlaceholder, ldap.EscapeFilter(user.DN), -1)
+	filter = strings.Replace(filter, loginNamePlaceholder, ldap.EscapeFilter(loginName), -1)
+	res, err = conn.Search(ldap.NewSearchRequest(
+		am.GetGroupDn(), ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, int(directoryTimeout.Seconds()), false,
+		filter, []string{am.GetGroupAttr()}, nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("group search: %w", err)
+	}
+	for _, g := range res.Entries {
+		// Group names are stored comma separated with the account, so names
+		// containing a comma cannot be synced and are ignored.
+		if name := g.GetAttributeValue(am.GetGroupAttr()); name != "" && !strings.Contains(name, ",") {
+			e.groups = append(e.groups, name)
+		}
+	}
+	return e, nil
+}
+
+// bindSearcher binds conn with the bind credential of am. Without a bind
+// credential the connection stays anonymous.
+func bindSearcher(conn *ldap.Conn, am *AuthMethod) error {
+	if am.GetBindDn() == "" {
+		return nil
+	}
+	if err := conn.Bind(am.GetBindDn(), am.GetBindPassword()); err != nil {
+		return fmt.Errorf("bind as %q: %w", am.GetBindDn(), err)
+	}
+	return nil
+}
+
+// dial connects to the first reachable directory server of am.
+func dial(am *AuthMethod) (*ldap.Conn, error) {
+	tlsConfig, err := tlsConfig(am)
+	if err != nil {
+		return nil, err
+	}
+	var errs *multierror.Error
+	for _, u := range am.UrlList() {
+		conn, err := dialUrl(u, am.GetStartTls(), tlsConfig)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%s: %w", u, err))
+			continue
+		}
+		return conn, nil
+	}
+	return nil, fmt.Errorf("%v: %w", errs.ErrorOrNil(), ErrDirectoryUnavailable)
+}
+
+func dialUrl(u string, startTls bool, tlsConfig *tls.Config) (*ldap.Conn, error) {
+	pu, err := url.Parse(u)
+	if err != nil {
+		return nil, err
+	}
+	cfg := tlsConfig.Clone()
+	cfg.ServerName = pu.Hostname()
+	conn, err := ldap.DialURL(u,
+		ldap.DialWithDialer(&net.Dialer{Timeout: directoryTimeout}),
+		ldap.DialWithTLSConfig(cfg),
+	)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(directoryTimeout)
+	if pu.Scheme == "ldap" && startTls {
+		if err := conn.StartTLS(cfg); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("start tls: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+// tlsConfig returns the TLS configuration for connections to the directory
+// servers of am. The servers' certificates are verified against the
+// certificates of am, or the system roots if it has none, unless insecure
+// TLS is enabled. If am has certificate pins, a server's leaf certificate
+// must match one of them.
+func tlsConfig(am *AuthMethod) (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: am.GetInsecureTls(),
+	}
+	if strings.TrimSpace(am.GetCertificates()) != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(am.GetCertificates())) {
+			return nil, fmt.Errorf("no valid certificates in auth method %s", am.GetPublicId())
+		}
+		cfg.RootCAs = pool
+	}
+	if pins := am.PinList(); len(pins) > 0 {
+		cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return ErrCertificatePin
+			}
+			sum := sha256.Sum256(rawCerts[0])
+			fingerprint := hex.EncodeToString(sum[:])
+			for _, p := range pins {
+				if strings.EqualFold(p, fingerprint) {
+					return nil
+				}
+			}
+			return ErrCertificatePin
+		}
+	}
+	return cfg, nil
+}
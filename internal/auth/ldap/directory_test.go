@@ -0,0 +1,311 @@
+package ldap
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDirectoryWithUsers(t *testing.T) *TestDirectory {
+	t.Helper()
+	d := NewTestDirectory(t)
+	d.AddEntry("cn=admin,dc=example,dc=com", "admin-password", map[string][]string{
+		"cn": {"admin"},
+	})
+	d.AddEntry("uid=alice,ou=people,dc=example,dc=com", "alice-password", map[string][]string{
+		"uid":         {"alice"},
+		"cn":          {"alice"},
+		"displayName": {"Alice Doe"},
+		"mail":        {"alice@example.com"},
+	})
+	d.AddEntry("uid=bob,ou=people,dc=example,dc=com", "bob-password", map[string][]string{
+		"uid": {"bob"},
+		"cn":  {"Bob Smith"},
+	})
+	d.AddEntry("cn=engineering,ou=groups,dc=example,dc=com", "", map[string][]string{
+		"cn":     {"engineering"},
+		"member": {"uid=alice,ou=people,dc=example,dc=com", "uid=bob,ou=people,dc=example,dc=com"},
+	})
+	d.AddEntry("cn=admins,ou=groups,dc=example,dc=com", "", map[string][]string{
+		"cn":           {"admins"},
+		"uniqueMember": {"uid=alice,ou=people,dc=example,dc=com"},
+	})
+	return d
+}
+
+func Test_authenticateUser(t *testing.T) {
+	t.Parallel()
+	d := testDirectoryWithUsers(t)
+
+	newAuthMethod := func(t *testing.T, opt ...Option) *AuthMethod {
+		t.Helper()
+		opt = append([]Option{
+			WithUrls(d.Url()),
+			WithUserSearch("ou=people,dc=example,dc=com", ""),
+			WithGroupSearch("ou=groups,dc=example,dc=com", "", ""),
+		}, opt...)
+		am, err := NewAuthMethod("o_1234567890", opt...)
+		require.NoError(t, err)
+		require.NoError(t, am.validate())
+		return am
+	}
+
+	tests := []struct {
+		name       string
+		am         *AuthMethod
+		loginName  string
+		password   string
+		want       *userEntry
+		wantErrIs  error
+		wantErrMsg string
+	}{
+		{
+			name:      "anonymous-search",
+			am:        newAuthMethod(t),
+			loginName: "Alice",
+			password:  "alice-password",
+			want: &userEntry{
+				loginName: "alice",
+				dn:        "uid=alice,ou=people,dc=example,dc=com",
+				fullName:  "Alice Doe",
+				email:     "alice@example.com",
+				groups:    []string{"engineering", "admins"},
+			},
+		},
+		{
+			name:      "bind-credential",
+			am:        newAuthMethod(t, WithBindCredential("cn=admin,dc=example,dc=com", "admin-password")),
+			loginName: "bob",
+			password:  "bob-password",
+			want: &userEntry{
+				loginName: "bob",
+				dn:        "uid=bob,ou=people,dc=example,dc=com",
+				fullName:  "Bob Smith",
+				groups:    []string{"engineering"},
+			},
+		},
+		{
+			name:      "no-group-search",
+			am:        newAuthMethod(t, WithGroupSearch("", "", "")),
+			loginName: "alice",
+			password:  "alice-password",
+			want: &userEntry{
+				loginName: "alice",
+				dn:        "uid=alice,ou=people,dc=example,dc=com",
+				fullName:  "Alice Doe",
+				email:     "alice@example.com",
+			},
+		},
+		{
+			name:      "start-tls",
+			am:        newAuthMethod(t, WithStartTls(true), WithCertificates(d.CertificatePem())),
+			loginName: "alice",
+			password:  "alice-password",
+			want: &userEntry{
+				loginName: "alice",
+				dn:        "uid=alice,ou=people,dc=example,dc=com",
+				fullName:  "Alice Doe",
+				email:     "alice@example.com",
+				groups:    []string{"engineering", "admins"},
+			},
+		},
+		{
+			name:      "ldaps-pinned",
+			am:        newAuthMethod(t, WithUrls(d.LdapsUrl()), WithInsecureTls(true), WithCertificatePins(d.CertificatePin())),
+			loginName: "bob",
+			password:  "bob-password",
+			want: &userEntry{
+				loginName: "bob",
+				dn:        "uid=bob,ou=people,dc=example,dc=com",
+				fullName:  "Bob Smith",
+				groups:    []string{"engineering"},
+			},
+		},
+		{
+			name:      "first-url-unreachable",
+			am:        newAuthMethod(t, WithUrls("ldap://127.0.0.1:1", d.Url())),
+			loginName: "bob",
+			password:  "bob-password",
+			want: &userEntry{
+				loginName: "bob",
+				dn:        "uid=bob,ou=people,dc=example,dc=com",
+				fullName:  "Bob Smith",
+				groups:    []string{"engineering"},
+			},
+		},
+		{
+			name:      "wrong-password",
+			am:        newAuthMethod(t),
+			loginName: "alice",
+			password:  "bob-password",
+			wantErrIs: errInvalidCredentials,
+		},
+		{
+			name:      "empty-password",
+			am:        newAuthMethod(t),
+			loginName: "alice",
+			password:  "",
+			wantErrIs: errInvalidCredentials,
+		},
+		{
+			name:      "unknown-user",
+			am:        newAuthMethod(t),
+			loginName: "carol",
+			password:  "carol-password",
+			wantErrIs: errInvalidCredentials,
+		},
+		{
+			name:      "filter-injection",
+			am:        newAuthMethod(t),
+			loginName: "*",
+			password:  "alice-password",
+			wantErrIs: errInvalidCredentials,
+		},
+		{
+			name:      "ambiguous-user",
+			am:        newAuthMethod(t, WithUserSearch("ou=people,dc=example,dc=com", "(|(uid={{login_name}})(uid=bob))")),
+			loginName: "alice",
+			password:  "alice-password",
+			wantErrIs: errInvalidCredentials,
+		},
+		{
+			name:       "wrong-bind-password",
+			am:         newAuthMethod(t, WithBindCredential("cn=admin,dc=example,dc=com", "wrong")),
+			loginName:  "alice",
+			password:   "alice-password",
+			wantErrMsg: "bind as",
+		},
+		{
+			name:       "unknown-certificate",
+			am:         newAuthMethod(t, WithUrls(d.LdapsUrl())),
+			loginName:  "alice",
+			password:   "alice-password",
+			wantErrIs:  ErrDirectoryUnavailable,
+			wantErrMsg: "certificate",
+		},
+		{
+			name:       "pin-mismatch",
+			am:         newAuthMethod(t, WithUrls(d.LdapsUrl()), WithCertificates(d.CertificatePem()), WithCertificatePins(strings.Repeat("ab", 32))),
+			loginName:  "alice",
+			password:   "alice-password",
+			wantErrIs:  ErrDirectoryUnavailable,
+			wantErrMsg: ErrCertificatePin.Error(),
+		},
+		{
+			name:       "start-tls-pin-mismatch",
+			am:         newAuthMethod(t, WithStartTls(true), WithInsecureTls(true), WithCertificatePins(strings.Repeat("ab", 32))),
+			loginName:  "alice",
+			password:   "alice-password",
+			wantErrIs:  ErrDirectoryUnavailable,
+			wantErrMsg: ErrCertificatePin.Error(),
+		},
+		{
+			name:      "unreachable",
+			am:        newAuthMethod(t, WithUrls("ldap://127.0.0.1:1")),
+			loginName: "alice",
+			password:  "alice-password",
+			wantErrIs: ErrDirectoryUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			got, err := authenticateUser(tt.am, tt.loginName, tt.password)
+			if tt.wantErrIs != nil || tt.wantErrMsg != "" {
+				require.Error(err)
+				assert.Nil(got)
+				if tt.wantErrIs != nil {
+					assert.Truef(errors.Is(err, tt.wantErrIs), "want err: %q got: %q", tt.wantErrIs, err)
+				}
+				assert.Contains(err.Error(), tt.wantErrMsg)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestAuthMethod_validate(t *testing.T) {
+	t.Parallel()
+	d := NewTestDirectory(t)
+	tests := []struct {
+		name    string
+		opt     []Option
+		wantErr string
+	}{
+		{
+			name: "valid",
+			opt: []Option{
+				WithUrls("ldaps://ldap.example.com", "ldap://ldap2.example.com:389"),
+				WithUserSearch("ou=people,dc=example,dc=com", ""),
+				WithCertificates(d.CertificatePem()),
+				WithCertificatePins(d.CertificatePin()),
+				WithBindCredential("cn=admin,dc=example,dc=com", "secret"),
+				WithGroupSearch("ou=groups,dc=example,dc=com", "", ""),
+				WithSyncGroups(true),
+			},
+		},
+		{
+			name:    "no-urls",
+			opt:     []Option{WithUserSearch("ou=people,dc=example,dc=com", "")},
+			wantErr: "no urls",
+		},
+		{
+			name:    "http-url",
+			opt:     []Option{WithUrls("https://ldap.example.com"), WithUserSearch("ou=people,dc=example,dc=com", "")},
+			wantErr: "must be an ldap or ldaps url",
+		},
+		{
+			name:    "bad-certificates",
+			opt:     []Option{WithUrls("ldap://ldap.example.com"), WithUserSearch("ou=people,dc=example,dc=com", ""), WithCertificates("not a certificate")},
+			wantErr: "PEM encoded certificate",
+		},
+		{
+			name:    "bad-pin",
+			opt:     []Option{WithUrls("ldap://ldap.example.com"), WithUserSearch("ou=people,dc=example,dc=com", ""), WithCertificatePins("abcd")},
+			wantErr: "SHA-256 fingerprint",
+		},
+		{
+			name:    "no-user-dn",
+			opt:     []Option{WithUrls("ldap://ldap.example.com")},
+			wantErr: "no user dn",
+		},
+		{
+			name:    "user-filter-without-placeholder",
+			opt:     []Option{WithUrls("ldap://ldap.example.com"), WithUserSearch("ou=people,dc=example,dc=com", "(uid=alice)")},
+			wantErr: "must contain {{login_name}}",
+		},
+		{
+			name:    "bind-dn-without-password",
+			opt:     []Option{WithUrls("ldap://ldap.example.com"), WithUserSearch("ou=people,dc=example,dc=com", ""), WithBindCredential("cn=admin,dc=example,dc=com", "")},
+			wantErr: "no bind password",
+		},
+		{
+			name:    "sync-without-group-dn",
+			opt:     []Option{WithUrls("ldap://ldap.example.com"), WithUserSearch("ou=people,dc=example,dc=com", ""), WithSyncGroups(true)},
+			wantErr: "requires a group dn",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			am, err := NewAuthMethod("o_1234567890", tt.opt...)
+			require.NoError(err)
+			err = am.validate()
+			if tt.wantErr != "" {
+				require.Error(err)
+				assert.Contains(err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package ldap
+
+import "errors"
+
+var (
+	// ErrDirectoryUnavailable results from authenticating with an auth
+	// method when none of its directory servers can be reached.
+	ErrDirectoryUnavailable = errors.New("ldap directory unavailable")
+
+	// ErrCertificatePin results from connecting to a directory server whose
+	// certificate does not match any of the auth method's certificate pins.
+	ErrCertificatePin = errors.New("ldap server certificate does not match any pin")
+)
@@ -0,0 +1,161 @@
+package ldap
+
+// getOpts - iterate the inbound Options and return a struct.
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withName             string
+	withDescription      string
+	withLimit            int
+	withStartPageAfterId string
+	withPublicId         string
+	withUrls             []string
+	withStartTls         bool
+	withInsecureTls      bool
+	withCertificates     string
+	withCertificatePins  []string
+	withBindDn           string
+	withBindPassword     string
+	withUserDn           string
+	withUserFilter       string
+	withGroupDn          string
+	withGroupFilter      string
+	withGroupAttr        string
+	withSyncGroups       bool
+	withLoginName        string
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithPublicId provides an optional public id
+func WithPublicId(id string) Option {
+	return func(o *options) {
+		o.withPublicId = id
+	}
+}
+
+// WithDescription provides an optional description.
+func WithDescription(desc string) Option {
+	return func(o *options) {
+		o.withDescription = desc
+	}
+}
+
+// WithName provides an optional name.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.withName = name
+	}
+}
+
+// WithLimit provides an option to provide a limit.  Intentionally allowing
+// negative integers.   If WithLimit < 0, then unlimited results are returned.
+// If WithLimit == 0, then default limits are used for results.
+func WithLimit(l int) Option {
+	return func(o *options) {
+		o.withLimit = l
+	}
+}
+
+// WithUrls provides the optional ldap:// or ldaps:// URLs of the directory
+// servers.
+func WithUrls(urls ...string) Option {
+	return func(o *options) {
+		o.withUrls = urls
+	}
+}
+
+// WithStartTls provides an option to upgrade ldap:// connections with
+// StartTLS.
+func WithStartTls(enable bool) Option {
+	return func(o *options) {
+		o.withStartTls = enable
+	}
+}
+
+// WithInsecureTls provides an option to skip the verification of the
+// servers' certificate chains.
+func WithInsecureTls(enable bool) Option {
+	return func(o *options) {
+		o.withInsecureTls = enable
+	}
+}
+
+// WithCertificates provides an optional PEM encoded bundle of CA
+// certificates.
+func WithCertificates(pem string) Option {
+	return func(o *options) {
+		o.withCertificates = pem
+	}
+}
+
+// WithCertificatePins provides optional hex encoded SHA-256 fingerprints of
+// the servers' certificates.
+func WithCertificatePins(pins ...string) Option {
+	return func(o *options) {
+		o.withCertificatePins = pins
+	}
+}
+
+// WithBindCredential provides an optional distinguished name and password
+// to search the directory with.
+func WithBindCredential(dn, password string) Option {
+	return func(o *options) {
+		o.withBindDn = dn
+		o.withBindPassword = password
+	}
+}
+
+// WithUserSearch provides an optional base distinguished name and filter
+// of the user search.
+func WithUserSearch(dn, filter string) Option {
+	return func(o *options) {
+		o.withUserDn = dn
+		o.withUserFilter = filter
+	}
+}
+
+// WithGroupSearch provides an optional base distinguished name, filter, and
+// name attribute of the group search.
+func WithGroupSearch(dn, filter, attr string) Option {
+	return func(o *options) {
+		o.withGroupDn = dn
+		o.withGroupFilter = filter
+		o.withGroupAttr = attr
+	}
+}
+
+// WithSyncGroups provides an option to sync LDAP group membership into the
+// groups of the auth method's scope.
+func WithSyncGroups(enable bool) Option {
+	return func(o *options) {
+		o.withSyncGroups = enable
+	}
+}
+
+// WithLoginName provides an optional login name.
+func WithLoginName(loginName string) Option {
+	return func(o *options) {
+		o.withLoginName = loginName
+	}
+}
+
+// WithStartPageAfterId provides an option to only list resources whose public
+// ids sort after the given id. Used to retrieve the next page of results.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
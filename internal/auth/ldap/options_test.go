@@ -0,0 +1,105 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithPublicId", func(t *testing.T) {
+		opts := getOpts(WithPublicId("test id"))
+		testOpts := getDefaultOptions()
+		testOpts.withPublicId = "test id"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithName", func(t *testing.T) {
+		opts := getOpts(WithName("test"))
+		testOpts := getDefaultOptions()
+		testOpts.withName = "test"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithDescription", func(t *testing.T) {
+		opts := getOpts(WithDescription("test desc"))
+		testOpts := getDefaultOptions()
+		testOpts.withDescription = "test desc"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithLimit", func(t *testing.T) {
+		opts := getOpts(WithLimit(5))
+		testOpts := getDefaultOptions()
+		testOpts.withLimit = 5
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithUrls", func(t *testing.T) {
+		opts := getOpts(WithUrls("ldaps://a", "ldaps://b"))
+		testOpts := getDefaultOptions()
+		testOpts.withUrls = []string{"ldaps://a", "ldaps://b"}
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithStartTls", func(t *testing.T) {
+		opts := getOpts(WithStartTls(true))
+		testOpts := getDefaultOptions()
+		testOpts.withStartTls = true
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithInsecureTls", func(t *testing.T) {
+		opts := getOpts(WithInsecureTls(true))
+		testOpts := getDefaultOptions()
+		testOpts.withInsecureTls = true
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithCertificates", func(t *testing.T) {
+		opts := getOpts(WithCertificates("pem"))
+		testOpts := getDefaultOptions()
+		testOpts.withCertificates = "pem"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithCertificatePins", func(t *testing.T) {
+		opts := getOpts(WithCertificatePins("aa", "bb"))
+		testOpts := getDefaultOptions()
+		testOpts.withCertificatePins = []string{"aa", "bb"}
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithBindCredential", func(t *testing.T) {
+		opts := getOpts(WithBindCredential("cn=admin", "secret"))
+		testOpts := getDefaultOptions()
+		testOpts.withBindDn = "cn=admin"
+		testOpts.withBindPassword = "secret"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithUserSearch", func(t *testing.T) {
+		opts := getOpts(WithUserSearch("ou=people", "(uid={{login_name}})"))
+		testOpts := getDefaultOptions()
+		testOpts.withUserDn = "ou=people"
+		testOpts.withUserFilter = "(uid={{login_name}})"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithGroupSearch", func(t *testing.T) {
+		opts := getOpts(WithGroupSearch("ou=groups", "(member={{user_dn}})", "cn"))
+		testOpts := getDefaultOptions()
+		testOpts.withGroupDn = "ou=groups"
+		testOpts.withGroupFilter = "(member={{user_dn}})"
+		testOpts.withGroupAttr = "cn"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithSyncGroups", func(t *testing.T) {
+		opts := getOpts(WithSyncGroups(true))
+		testOpts := getDefaultOptions()
+		testOpts.withSyncGroups = true
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithLoginName", func(t *testing.T) {
+		opts := getOpts(WithLoginName("alice"))
+		testOpts := getDefaultOptions()
+		testOpts.withLoginName = "alice"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithStartPageAfterId", func(t *testing.T) {
+		opts := getOpts(WithStartPageAfterId("id_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withStartPageAfterId = "id_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
+}
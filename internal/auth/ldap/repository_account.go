@@ -0,0 +1,332 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateAccount inserts a into the repository and returns a new Account
+// containing the account's PublicId. a is not changed. a must contain a
+// valid AuthMethodId and LoginName. a must not contain a PublicId. The
+// PublicId is generated and assigned by this method. The login name is
+// stored in lower case.
+//
+// Accounts are created when a user first authenticates. Creating an account
+// in advance allows it to be associated with a user before then.
+//
+// WithPublicId is the only valid option. All other options are ignored.
+//
+// Both a.Name and a.Description are optional. If a.Name is set, it must be
+// unique within a.AuthMethodId.
+func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Account, opt ...Option) (*Account, error) {
+	if a == nil {
+		return nil, fmt.Errorf("create: ldap account: %w", db.ErrInvalidParameter)
+	}
+	if a.Account == nil {
+		return nil, fmt.Errorf("create: ldap account: embedded Account: %w", db.ErrInvalidParameter)
+	}
+	if a.AuthMethodId == "" {
+		return nil, fmt.Errorf("create: ldap account: no auth method id: %w", db.ErrInvalidParameter)
+	}
+	if a.PublicId != "" {
+		return nil, fmt.Errorf("create: ldap account: public id not empty: %w", db.ErrInvalidParameter)
+	}
+	if scopeId == "" {
+		return nil, fmt.Errorf("create: ldap account: no scope id: %w", db.ErrInvalidParameter)
+	}
+	if strings.TrimSpace(a.LoginName) == "" {
+		return nil, fmt.Errorf("create: ldap account: no login name: %w", db.ErrInvalidParameter)
+	}
+	a = a.clone()
+	a.LoginName = normalizeLoginName(a.LoginName)
+
+	opts := getOpts(opt...)
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, AccountPrefix+"_") {
+			return nil, fmt.Errorf("create: ldap account: passed-in public ID %q has wrong prefix, should be %q: %w", opts.withPublicId, AccountPrefix, db.ErrInvalidPublicId)
+		}
+		a.PublicId = opts.withPublicId
+	} else {
+		id, err := newAccountId()
+		if err != nil {
+			return nil, fmt.Errorf("create: ldap account: %w", err)
+		}
+		a.PublicId = id
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("create: ldap account: unable to get oplog wrapper: %w", err)
+	}
+
+	var newAccount *Account
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newAccount = a.clone()
+			return w.Create(ctx, newAccount, db.WithOplog(oplogWrapper, a.oplog(oplog.OpType_OP_TYPE_CREATE)))
+		},
+	)
+	if err != nil {
+		if db.IsUniqueError(err) {
+			return nil, fmt.Errorf("create: ldap account: in auth method: %s: name %q or login name %q already exists: %w",
+				a.AuthMethodId, a.Name, a.LoginName, db.ErrNotUnique)
+		}
+		return nil, fmt.Errorf("create: ldap account: in auth method: %s: %w", a.AuthMethodId, err)
+	}
+	return newAccount, nil
+}
+
+// LookupAccount will look up an account in the repository.  If the account is not
+// found, it will return nil, nil.  All options are ignored.
+func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt ...Option) (*Account, error) {
+	if withPublicId == "" {
+		return nil, fmt.Errorf("lookup: ldap account: missing public id %w", db.ErrInvalidParameter)
+	}
+	a := allocAccount()
+	a.PublicId = withPublicId
+	if err := r.reader.LookupByPublicId(ctx, a); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup: ldap account: failed %w for %s", err, withPublicId)
+	}
+	return a, nil
+}
+
+// ListAccounts in an auth method ordered by public id and supports the
+// WithLimit and WithStartPageAfterId options.
+func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string, opt ...Option) ([]*Account, error) {
+	if withAuthMethodId == "" {
+		return nil, fmt.Errorf("list: ldap account: missing auth method id %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	where, args := "auth_method_id = ?", []interface{}{withAuthMethodId}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id collate \"C\" > ?", append(args, opts.withStartPageAfterId)
+	}
+	var accts []*Account
+	err := r.reader.SearchWhere(ctx, &accts, where, args, db.WithLimit(limit), db.WithOrder(`public_id collate "C"`))
+	if err != nil {
+		return nil, fmt.Errorf("list: ldap account: %w", err)
+	}
+	return accts, nil
+}
+
+// DeleteAccount deletes the account for the provided id from the repository returning a count of the
+// number of records deleted.  All options are ignored.
+func (r *Repository) DeleteAccount(ctx context.Context, scopeId, withPublicId string, opt ...Option) (int, error) {
+	if withPublicId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: ldap account: missing public id: %w", db.ErrInvalidParameter)
+	}
+	if scopeId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: ldap account: scope id empty: %w", db.ErrInvalidParameter)
+	}
+	ac := allocAccount()
+	ac.PublicId = withPublicId
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: ldap account: unable to get oplog wrapper: %w", err)
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) (err error) {
+			metadata := ac.oplog(oplog.OpType_OP_TYPE_DELETE)
+			dAc := ac.clone()
+			rowsDeleted, err = w.Delete(ctx, dAc, db.WithOplog(oplogWrapper, metadata))
+			if err == nil && rowsDeleted > 1 {
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: ldap account: %s: %w", withPublicId, err)
+	}
+
+	return rowsDeleted, nil
+}
+
+// UpdateAccount updates the repository entry for a.PublicId with the
+// values in a for the fields listed in fieldMaskPaths. It returns a new
+// Account containing the updated values and a count of the number of
+// records updated. a is not changed.
+//
+// a must contain a valid PublicId. Only a.Name, a.Description, and
+// a.LoginName can be updated; the remaining fields are set from the user's
+// entry on each authentication. If a.Name is set to a non-empty string, it
+// must be unique within a.AuthMethodId. a.LoginName cannot be set to NULL.
+//
+// An attribute of a will be set to NULL in the database if the attribute
+// in a is the zero value and it is included in fieldMaskPaths.
+func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Account, version uint32, fieldMaskPaths []string, opt ...Option) (*Account, int, error) {
+	if a == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: ldap account: %w", db.ErrInvalidParameter)
+	}
+	if a.Account == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: ldap account: embedded Account: %w", db.ErrInvalidParameter)
+	}
+	if a.PublicId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: ldap account: missing public id: %w", db.ErrInvalidParameter)
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: ldap account: no version supplied: %w", db.ErrInvalidParameter)
+	}
+	if scopeId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: ldap account: scope id empty: %w", db.ErrInvalidParameter)
+	}
+
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("Name", f):
+		case strings.EqualFold("Description", f):
+		case strings.EqualFold("LoginName", f):
+			if strings.TrimSpace(a.LoginName) == "" {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: ldap account: no login name: %w", db.ErrInvalidParameter)
+			}
+		default:
+			return nil, db.NoRowsAffected, fmt.Errorf("update: ldap account: field: %s: %w", f, db.ErrInvalidFieldMask)
+		}
+	}
+	var dbMask, nullFields []string
+	dbMask, nullFields = dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			"Name":        a.Name,
+			"Description": a.Description,
+			"LoginName":   normalizeLoginName(a.LoginName),
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: ldap account: %w", db.ErrEmptyFieldMask)
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: ldap account: unable to get oplog wrapper: %w", err)
+	}
+
+	a = a.clone()
+	a.LoginName = normalizeLoginName(a.LoginName)
+
+	metadata := a.oplog(oplog.OpType_OP_TYPE_UPDATE)
+
+	var rowsUpdated int
+	var returnedAccount *Account
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedAccount = a.clone()
+			var err error
+			rowsUpdated, err = w.Update(ctx, returnedAccount, dbMask, nullFields, db.WithOplog(oplogWrapper, metadata), db.WithVersion(&version))
+			if err == nil && rowsUpdated > 1 {
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		if db.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: ldap account: %s: name %q or login name %q already exists: %w",
+				a.PublicId, a.Name, a.LoginName, db.ErrNotUnique)
+		}
+		return nil, db.NoRowsAffected, fmt.Errorf("update: ldap account: %s: %w", a.PublicId, err)
+	}
+
+	return returnedAccount, rowsUpdated, nil
+}
+
+// linkAccount records the entry of the user who authenticated with login
+// name e.loginName in the account with that login name, creating the account
+// if it does not exist yet. It returns the account and the names of the
+// user's groups as of the previous authentication.
+func (r *Repository) linkAccount(ctx context.Context, am *AuthMethod, e *userEntry) (*Account, []string, error) {
+	oplogWrapper, err := r.kms.GetWrapper(ctx, am.GetScopeId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get oplog wrapper: %w", err)
+	}
+
+	var acct *Account
+	var previousGroups []string
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			previousGroups = nil
+			var accts []*Account
+			if err := reader.SearchWhere(ctx, &accts, "auth_method_id = ? and login_name = ?",
+				[]interface{}{am.GetPublicId(), e.loginName}, db.WithLimit(1)); err != nil {
+				return err
+			}
+			if len(accts) == 0 {
+				id, err := newAccountId()
+				if err != nil {
+					return err
+				}
+				acct = allocAccount()
+				acct.PublicId = id
+				acct.AuthMethodId = am.GetPublicId()
+				acct.LoginName = e.loginName
+				acct.Dn = e.dn
+				acct.FullName = e.fullName
+				acct.Email = e.email
+				acct.MemberOfGroups = strings.Join(e.groups, ",")
+				return w.Create(ctx, acct, db.WithOplog(oplogWrapper, acct.oplog(oplog.OpType_OP_TYPE_CREATE)))
+			}
+
+			acct = accts[0]
+			previousGroups = acct.GroupList()
+			groups := strings.Join(e.groups, ",")
+			if acct.Dn == e.dn && acct.FullName == e.fullName && acct.Email == e.email && acct.MemberOfGroups == groups {
+				return nil
+			}
+			acct.Dn = e.dn
+			acct.FullName = e.fullName
+			acct.Email = e.email
+			acct.MemberOfGroups = groups
+			dbMask, nullFields := dbcommon.BuildUpdatePaths(
+				map[string]interface{}{
+					"Dn":             acct.Dn,
+					"FullName":       acct.FullName,
+					"Email":          acct.Email,
+					"MemberOfGroups": acct.MemberOfGroups,
+				},
+				[]string{"Dn", "FullName", "Email", "MemberOfGroups"},
+				nil,
+			)
+			version := acct.Version
+			rowsUpdated, err := w.Update(ctx, acct, dbMask, nullFields, db.WithOplog(oplogWrapper, acct.oplog(oplog.OpType_OP_TYPE_UPDATE)), db.WithVersion(&version))
+			if err == nil && rowsUpdated > 1 {
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("link ldap account: in auth method %s: %w", am.GetPublicId(), err)
+	}
+	return acct, previousGroups, nil
+}
+
+// normalizeLoginName returns the form of loginName stored in accounts.
+// Directories such as Active Directory match login names case
+// insensitively.
+func normalizeLoginName(loginName string) string {
+	return strings.ToLower(strings.TrimSpace(loginName))
+}
@@ -0,0 +1,50 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// rewrapAuthMethodQuery replaces the encrypted bind password unless it was
+// changed since it was read.
+const rewrapAuthMethodQuery = `
+update auth_ldap_method
+   set bind_password = ?,
+       key_id = ?
+ where public_id = ?
+   and key_id = ?
+   and bind_password = ?;
+`
+
+func init() {
+	kms.RegisterTableRewrapFn("auth_ldap_method", "key_id", kms.KeyPurposeDatabase, rewrapAuthMethods)
+}
+
+// rewrapAuthMethods re-encrypts the bind passwords of auth methods encrypted
+// with previous versions of the database key.
+func rewrapAuthMethods(ctx context.Context, r db.Reader, w db.Writer, wrapper wrapping.Wrapper, keyVersionIds []string, limit int) (int, error) {
+	var authMethods []*AuthMethod
+	if err := r.SearchWhere(ctx, &authMethods, "key_id in (?)", []interface{}{keyVersionIds}, db.WithLimit(limit)); err != nil {
+		return 0, fmt.Errorf("rewrap ldap auth methods: %w", err)
+	}
+	var rewrapped int
+	for _, am := range authMethods {
+		oldKeyId, oldPassword := am.KeyId, am.CtBindPassword
+		if err := am.decrypt(ctx, wrapper); err != nil {
+			return rewrapped, fmt.Errorf("rewrap ldap auth methods: %w for %s", err, am.PublicId)
+		}
+		if err := am.encrypt(ctx, wrapper); err != nil {
+			return rewrapped, fmt.Errorf("rewrap ldap auth methods: %w for %s", err, am.PublicId)
+		}
+		n, err := w.Exec(ctx, rewrapAuthMethodQuery, []interface{}{am.CtBindPassword, am.KeyId, am.PublicId, oldKeyId, oldPassword})
+		if err != nil {
+			return rewrapped, fmt.Errorf("rewrap ldap auth methods: %w for %s", err, am.PublicId)
+		}
+		rewrapped += n
+	}
+	return rewrapped, nil
+}
@@ -0,0 +1,387 @@
+package ldap
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/boundary/internal/db"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAuthMethod creates an ldap auth method in the provided DB with the
+// provided scope id, directory server urls, and user search base. The bind
+// password, if any, is encrypted with databaseWrapper, which should be the
+// database wrapper of the scope. If any errors are encountered during the
+// creation of the auth method, the test will fail.
+func TestAuthMethod(t *testing.T, conn *gorm.DB, databaseWrapper wrapping.Wrapper, scopeId string, urls []string, userDn string, opt ...Option) *AuthMethod {
+	t.Helper()
+	require := require.New(t)
+	ctx := context.Background()
+	opt = append(opt, WithUrls(urls...))
+	am, err := NewAuthMethod(scopeId, opt...)
+	require.NoError(err)
+	am.UserDn = userDn
+	require.NoError(am.validate())
+	am.PublicId, err = newAuthMethodId()
+	require.NoError(err)
+	require.NoError(am.encrypt(ctx, databaseWrapper))
+
+	w := db.New(conn)
+	require.NoError(w.Create(ctx, am))
+	return am
+}
+
+// TestAccount creates an ldap account for loginName in the provided DB with
+// the provided auth method id. The auth method must have been created
+// previously. If any errors are encountered during the creation of the
+// account, the test will fail.
+func TestAccount(t *testing.T, conn *gorm.DB, authMethodId, loginName string) *Account {
+	t.Helper()
+	require := require.New(t)
+	a, err := NewAccount(authMethodId, WithLoginName(normalizeLoginName(loginName)))
+	require.NoError(err)
+	a.PublicId, err = newAccountId()
+	require.NoError(err)
+
+	w := db.New(conn)
+	require.NoError(w.Create(context.Background(), a))
+	return a
+}
+
+const startTlsOid = "1.3.6.1.4.1.1466.20037"
+
+// TestDirectory is a minimal in-process LDAP server for tests. It supports
+// simple binds, searches with and, or, not, equality, and presence
+// filters, and the StartTLS extended operation. It listens for plain ldap
+// connections, which can be upgraded with StartTLS, and for ldaps
+// connections. Both use a self-signed certificate for 127.0.0.1.
+type TestDirectory struct {
+	ln        net.Listener
+	tlsLn     net.Listener
+	tlsConfig *tls.Config
+	certPem   string
+	pin       string
+
+	mu      sync.Mutex
+	entries []*testEntry
+}
+
+type testEntry struct {
+	dn       string
+	password string
+	attrs    map[string][]string
+}
+
+// NewTestDirectory starts a TestDirectory with no entries. It is stopped
+// when the test completes.
+func NewTestDirectory(t *testing.T) *TestDirectory {
+	t.Helper()
+	require := require.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(err)
+	sum := sha256.Sum256(der)
+
+	d := &TestDirectory{
+		certPem: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		pin:     hex.EncodeToString(sum[:]),
+		tlsConfig: &tls.Config{
+			Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+			MinVersion:   tls.VersionTLS12,
+		},
+	}
+	d.ln, err = net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	d.tlsLn, err = tls.Listen("tcp", "127.0.0.1:0", d.tlsConfig)
+	require.NoError(err)
+	go d.serve(d.ln)
+	go d.serve(d.tlsLn)
+	t.Cleanup(func() {
+		d.ln.Close()
+		d.tlsLn.Close()
+	})
+	return d
+}
+
+// Url returns the ldap url of the directory.
+func (d *TestDirectory) Url() string {
+	return fmt.Sprintf("ldap://%s", d.ln.Addr().String())
+}
+
+// LdapsUrl returns the ldaps url of the directory.
+func (d *TestDirectory) LdapsUrl() string {
+	return fmt.Sprintf("ldaps://%s", d.tlsLn.Addr().String())
+}
+
+// CertificatePem returns the PEM encoded certificate of the directory.
+func (d *TestDirectory) CertificatePem() string {
+	return d.certPem
+}
+
+// CertificatePin returns the hex encoded SHA-256 fingerprint of the
+// certificate of the directory.
+func (d *TestDirectory) CertificatePin() string {
+	return d.pin
+}
+
+// AddEntry adds an entry with dn and attrs to the directory. If password is
+// not empty, simple binds as dn with password succeed.
+func (d *TestDirectory) AddEntry(dn, password string, attrs map[string][]string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.entries = append(d.entries, &testEntry{dn: dn, password: password, attrs: attrs})
+}
+
+// SetPassword changes the password of the entry with dn.
+func (d *TestDirectory) SetPassword(dn, password string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, e := range d.entries {
+		if strings.EqualFold(e.dn, dn) {
+			e.password = password
+		}
+	}
+}
+
+// SetAttribute replaces the values of attr of the entry with dn.
+func (d *TestDirectory) SetAttribute(dn, attr string, values ...string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, e := range d.entries {
+		if strings.EqualFold(e.dn, dn) {
+			for k := range e.attrs {
+				if strings.EqualFold(k, attr) {
+					delete(e.attrs, k)
+				}
+			}
+			e.attrs[attr] = values
+		}
+	}
+}
+
+func (d *TestDirectory) serve(ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		go d.handle(conn)
+	}
+}
+
+func (d *TestDirectory) handle(conn net.Conn) {
+	defer func() { conn.Close() }()
+	for {
+		p, err := ber.ReadPacket(conn)
+		if err != nil || len(p.Children) < 2 {
+			return
+		}
+		msgId, _ := p.Children[0].Value.(int64)
+		op := p.Children[1]
+		if op.ClassType != ber.ClassApplication {
+			return
+		}
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			code := d.bind(op)
+			conn.Write(ldapResult(msgId, ldap.ApplicationBindResponse, code).Bytes())
+		case ldap.ApplicationSearchRequest:
+			for _, r := range d.search(msgId, op) {
+				conn.Write(r.Bytes())
+			}
+		case ldap.ApplicationExtendedRequest:
+			if len(op.Children) == 0 || op.Children[0].Data.String() != startTlsOid {
+				conn.Write(ldapResult(msgId, ldap.ApplicationExtendedResponse, ldap.LDAPResultProtocolError).Bytes())
+				continue
+			}
+			if _, ok := conn.(*tls.Conn); ok {
+				conn.Write(ldapResult(msgId, ldap.ApplicationExtendedResponse, ldap.LDAPResultOperationsError).Bytes())
+				continue
+			}
+			conn.Write(ldapResult(msgId, ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess).Bytes())
+			tlsConn := tls.Server(conn, d.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+		case ldap.ApplicationUnbindRequest:
+			return
+		default:
+			return
+		}
+	}
+}
+
+func (d *TestDirectory) bind(op *ber.Packet) uint16 {
+	if len(op.Children) < 3 {
+		return ldap.LDAPResultProtocolError
+	}
+	dn, _ := op.Children[1].Value.(string)
+	password := op.Children[2].Data.String()
+	if dn == "" && password == "" {
+		return ldap.LDAPResultSuccess
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, e := range d.entries {
+		if strings.EqualFold(e.dn, dn) && e.password != "" && e.password == password {
+			return ldap.LDAPResultSuccess
+		}
+	}
+	return ldap.LDAPResultInvalidCredentials
+}
+
+func (d *TestDirectory) search(msgId int64, op *ber.Packet) []*ber.Packet {
+	if len(op.Children) < 8 {
+		return []*ber.Packet{ldapResult(msgId, ldap.ApplicationSearchResultDone, ldap.LDAPResultProtocolError)}
+	}
+	base, _ := op.Children[0].Value.(string)
+	sizeLimit, _ := op.Children[3].Value.(int64)
+	filter := op.Children[6]
+	var want []string
+	for _, a := range op.Children[7].Children {
+		if s, ok := a.Value.(string); ok {
+			want = append(want, s)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var out []*ber.Packet
+	for _, e := range d.entries {
+		if !inSubtree(e.dn, base) || !e.matches(filter) {
+			continue
+		}
+		if sizeLimit > 0 && int64(len(out)) >= sizeLimit {
+			return append(out, ldapResult(msgId, ldap.ApplicationSearchResultDone, ldap.LDAPResultSizeLimitExceeded))
+		}
+		out = append(out, e.packet(msgId, want))
+	}
+	return append(out, ldapResult(msgId, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+}
+
+func inSubtree(dn, base string) bool {
+	dn, base = strings.ToLower(dn), strings.ToLower(base)
+	return base == "" || dn == base || strings.HasSuffix(dn, ","+base)
+}
+
+func (e *testEntry) values(attr string) []string {
+	for k, v := range e.attrs {
+		if strings.EqualFold(k, attr) {
+			return v
+		}
+	}
+	return nil
+}
+
+func (e *testEntry) matches(f *ber.Packet) bool {
+	if f.ClassType != ber.ClassContext {
+		return false
+	}
+	switch f.Tag {
+	case ldap.FilterAnd:
+		for _, c := range f.Children {
+			if !e.matches(c) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, c := range f.Children {
+			if e.matches(c) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return len(f.Children) == 1 && !e.matches(f.Children[0])
+	case ldap.FilterEqualityMatch:
+		if len(f.Children) != 2 {
+			return false
+		}
+		attr, _ := f.Children[0].Value.(string)
+		value, _ := f.Children[1].Value.(string)
+		if strings.EqualFold(attr, "dn") || strings.EqualFold(attr, "distinguishedName") {
+			return strings.EqualFold(e.dn, value)
+		}
+		for _, v := range e.values(attr) {
+			if strings.EqualFold(v, value) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterPresent:
+		attr := f.Data.String()
+		return strings.EqualFold(attr, "objectClass") || len(e.values(attr)) > 0
+	default:
+		return false
+	}
+}
+
+func (e *testEntry) packet(msgId int64, want []string) *ber.Packet {
+	env := ber.NewSequence("LDAP Response")
+	env.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, msgId, "MessageID"))
+	res := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	res.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, "DN"))
+	attrs := ber.NewSequence("Attributes")
+	for k, vs := range e.attrs {
+		if len(want) > 0 && !containsFold(want, k) {
+			continue
+		}
+		a := ber.NewSequence("Attribute")
+		a.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, k, "Type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, v := range vs {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+		}
+		a.AppendChild(set)
+		attrs.AppendChild(a)
+	}
+	res.AppendChild(attrs)
+	env.AppendChild(res)
+	return env
+}
+
+func ldapResult(msgId int64, app ber.Tag, code uint16) *ber.Packet {
+	env := ber.NewSequence("LDAP Response")
+	env.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, msgId, "MessageID"))
+	res := ber.Encode(ber.ClassApplication, ber.TypeConstructed, app, nil, "Result")
+	res.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	res.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	res.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	env.AppendChild(res)
+	return env
+}
@@ -0,0 +1,288 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/auth/oidc/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/vault/sdk/helper/base62"
+	"github.com/mr-tron/base58"
+	"golang.org/x/oauth2"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// requestStateTTL is how long a user has to authenticate with the
+	// provider once an authentication attempt is started.
+	requestStateTTL = 10 * time.Minute
+
+	nonceLength = 20
+
+	// codeVerifierLength is the length of PKCE code verifiers, the shortest
+	// allowed by RFC 7636.
+	codeVerifierLength = 43
+)
+
+// requestScopes are the OAuth 2.0 scopes requested from the provider.
+var requestScopes = []string{gooidc.ScopeOpenID, "profile", "email"}
+
+// accountClaims are the values of an ID token which are stored in an
+// account.
+type accountClaims struct {
+	issuer   string
+	subject  string
+	fullName string
+	email    string
+}
+
+// StartAuth begins an authentication attempt with the auth method's
+// provider and returns the URL the user must visit to authenticate. Once
+// done, the provider redirects the user's browser to redirectUrl with the
+// state and code which must be passed to Authenticate. The state expires
+// after 10 minutes. The request uses PKCE, so that a code can only be
+// redeemed with the state of the attempt it was issued for. All options are
+// ignored.
+func (r *Repository) StartAuth(ctx context.Context, authMethodId, redirectUrl string, opt ...Option) (string, error) {
+	if authMethodId == "" {
+		return "", fmt.Errorf("start auth: oidc: missing auth method id: %w", db.ErrInvalidParameter)
+	}
+	if u, err := url.Parse(redirectUrl); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return "", fmt.Errorf("start auth: oidc: redirect url %q must be an absolute http or https url: %w", redirectUrl, db.ErrInvalidParameter)
+	}
+	am, err := r.LookupAuthMethod(ctx, authMethodId)
+	if err != nil {
+		return "", fmt.Errorf("start auth: oidc: %w", err)
+	}
+	if am == nil {
+		return "", fmt.Errorf("start auth: oidc: auth method %s: %w", authMethodId, db.ErrRecordNotFound)
+	}
+
+	nonce, err := base62.Random(nonceLength)
+	if err != nil {
+		return "", fmt.Errorf("start auth: oidc: unable to generate nonce: %w", err)
+	}
+	codeVerifier, err := base62.Random(codeVerifierLength)
+	if err != nil {
+		return "", fmt.Errorf("start auth: oidc: unable to generate code verifier: %w", err)
+	}
+	exp, err := ptypes.TimestampProto(time.Now().Add(requestStateTTL))
+	if err != nil {
+		return "", fmt.Errorf("start auth: oidc: %w", err)
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, am.GetScopeId(), kms.KeyPurposeDatabase)
+	if err != nil {
+		return "", fmt.Errorf("start auth: oidc: unable to get database wrapper: %w", err)
+	}
+	rs := &store.RequestState{
+		AuthMethodId:   am.GetPublicId(),
+		Nonce:          nonce,
+		RedirectUrl:    redirectUrl,
+		ExpirationTime: &timestamp.Timestamp{Timestamp: exp},
+		CodeVerifier:   codeVerifier,
+	}
+	state, err := encryptState(ctx, databaseWrapper, rs)
+	if err != nil {
+		return "", fmt.Errorf("start auth: oidc: %w", err)
+	}
+
+	provider, err := gooidc.NewProvider(ctx, am.GetDiscoveryUrl())
+	if err != nil {
+		return "", fmt.Errorf("start auth: oidc: unable to discover provider: %w", err)
+	}
+	return oauthConfig(am, provider, redirectUrl).AuthCodeURL(state, authCodeOptions(rs)...), nil
+}
+
+// Authenticate completes an authentication attempt started by StartAuth.
+// The code is exchanged with the provider for an ID token which is
+// verified against the auth method's client id, allowed audiences, and the
+// nonce of the attempt. The account of the token's issuer and subject is
+// returned, after being created if this is its first authentication. All
+// options are ignored.
+func (r *Repository) Authenticate(ctx context.Context, authMethodId, state, code string, opt ...Option) (*Account, error) {
+	switch {
+	case authMethodId == "":
+		return nil, fmt.Errorf("authenticate: oidc: missing auth method id: %w", db.ErrInvalidParameter)
+	case state == "":
+		return nil, fmt.Errorf("authenticate: oidc: missing state: %w", db.ErrInvalidParameter)
+	case code == "":
+		return nil, fmt.Errorf("authenticate: oidc: missing code: %w", db.ErrInvalidParameter)
+	}
+	am, err := r.LookupAuthMethod(ctx, authMethodId)
+	if err != nil {
+		return nil, fmt.Errorf("authenticate: oidc: %w", err)
+	}
+	if am == nil {
+		return nil, fmt.Errorf("authenticate: oidc: auth method %s: %w", authMethodId, db.ErrRecordNotFound)
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, am.GetScopeId(), kms.KeyPurposeDatabase, kms.WithKeyId(am.GetKeyId()))
+	if err != nil {
+		return nil, fmt.Errorf("authenticate: oidc: unable to get database wrapper: %w", err)
+	}
+	if err := am.decrypt(ctx, databaseWrapper); err != nil {
+		return nil, fmt.Errorf("authenticate: oidc: %w", err)
+	}
+	rs, err := decryptState(ctx, databaseWrapper, authMethodId, state)
+	if err != nil {
+		return nil, fmt.Errorf("authenticate: oidc: %w", err)
+	}
+	c, err := exchange(ctx, am, rs, code)
+	if err != nil {
+		return nil, fmt.Errorf("authenticate: oidc: %w", err)
+	}
+	acct, err := r.upsertAccount(ctx, am, c)
+	if err != nil {
+		return nil, fmt.Errorf("authenticate: oidc: %w", err)
+	}
+	return acct, nil
+}
+
+// authCodeOptions returns the parameters of the authorization request of
+// the attempt: its nonce and the S256 PKCE challenge of its code verifier.
+func authCodeOptions(rs *store.RequestState) []oauth2.AuthCodeOption {
+	challenge := sha256.Sum256([]byte(rs.GetCodeVerifier()))
+	return []oauth2.AuthCodeOption{
+		gooidc.Nonce(rs.GetNonce()),
+		oauth2.SetAuthURLParam("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:])),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	}
+}
+
+func oauthConfig(am *AuthMethod, provider *gooidc.Provider, redirectUrl string) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     am.GetClientId(),
+		ClientSecret: am.GetClientSecret(),
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  redirectUrl,
+		Scopes:       requestScopes,
+	}
+}
+
+// exchange exchanges code for an ID token with the provider of am and
+// returns the account claims of the verified token. am must contain the
+// decrypted client secret.
+func exchange(ctx context.Context, am *AuthMethod, rs *store.RequestState, code string) (*accountClaims, error) {
+	provider, err := gooidc.NewProvider(ctx, am.GetDiscoveryUrl())
+	if err != nil {
+		return nil, fmt.Errorf("unable to discover provider: %w", err)
+	}
+	tok, err := oauthConfig(am, provider, rs.GetRedirectUrl()).Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", rs.GetCodeVerifier()))
+	if err != nil {
+		return nil, fmt.Errorf("unable to exchange code: %w", err)
+	}
+	rawIdToken, ok := tok.Extra("id_token").(string)
+	if !ok || rawIdToken == "" {
+		return nil, fmt.Errorf("no id token in token response: %w", ErrInvalidIdToken)
+	}
+	idToken, err := provider.Verifier(&gooidc.Config{ClientID: am.GetClientId()}).Verify(ctx, rawIdToken)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidIdToken)
+	}
+	if idToken.Nonce != rs.GetNonce() {
+		return nil, fmt.Errorf("nonce mismatch: %w", ErrInvalidIdToken)
+	}
+	if aud := am.AudienceList(); len(aud) > 0 && !anyIn(aud, idToken.Audience) {
+		return nil, fmt.Errorf("audience %q not allowed: %w", idToken.Audience, ErrInvalidIdToken)
+	}
+
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("unable to parse claims: %v: %w", err, ErrInvalidIdToken)
+	}
+	return toAccountClaims(am, idToken.Issuer, claims)
+}
+
+// toAccountClaims applies the account claim maps of am to claims.
+func toAccountClaims(am *AuthMethod, issuer string, claims map[string]interface{}) (*accountClaims, error) {
+	fields, err := parseClaimMaps(am.ClaimMapList())
+	if err != nil {
+		return nil, err
+	}
+	str := func(field string) string {
+		if v, ok := claims[fields[field]].(string); ok {
+			return strings.TrimSpace(v)
+		}
+		return ""
+	}
+	c := &accountClaims{
+		issuer:   issuer,
+		subject:  str(subjectField),
+		fullName: str(fullNameField),
+		email:    str(emailField),
+	}
+	if c.subject == "" {
+		return nil, fmt.Errorf("no %q claim to use as the subject: %w", fields[subjectField], ErrInvalidIdToken)
+	}
+	return c, nil
+}
+
+// encryptState encrypts s with cipher using the auth method id as
+// additional data and returns it base58 encoded.
+func encryptState(ctx context.Context, cipher wrapping.Wrapper, s *store.RequestState) (string, error) {
+	marshaled, err := proto.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling request state: %w", err)
+	}
+	blobInfo, err := cipher.Encrypt(ctx, marshaled, []byte(s.GetAuthMethodId()))
+	if err != nil {
+		return "", fmt.Errorf("error encrypting request state: %w", err)
+	}
+	marshaledBlob, err := proto.Marshal(blobInfo)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling encrypted request state: %w", err)
+	}
+	return base58.FastBase58Encoding(marshaledBlob), nil
+}
+
+// decryptState reverses encryptState and checks the state was issued by
+// the auth method and has not expired.
+func decryptState(ctx context.Context, cipher wrapping.Wrapper, authMethodId, encoded string) (*store.RequestState, error) {
+	marshaledBlob, err := base58.FastBase58Decoding(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding request state: %v: %w", err, ErrInvalidState)
+	}
+	blobInfo := new(wrapping.EncryptedBlobInfo)
+	if err := proto.Unmarshal(marshaledBlob, blobInfo); err != nil {
+		return nil, fmt.Errorf("error unmarshaling encrypted request state: %v: %w", err, ErrInvalidState)
+	}
+	marshaled, err := cipher.Decrypt(ctx, blobInfo, []byte(authMethodId))
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting request state: %v: %w", err, ErrInvalidState)
+	}
+	s := new(store.RequestState)
+	if err := proto.Unmarshal(marshaled, s); err != nil {
+		return nil, fmt.Errorf("error unmarshaling request state: %v: %w", err, ErrInvalidState)
+	}
+	if s.GetAuthMethodId() != authMethodId {
+		return nil, fmt.Errorf("request state issued for auth method %s: %w", s.GetAuthMethodId(), ErrInvalidState)
+	}
+	exp, err := ptypes.Timestamp(s.GetExpirationTime().GetTimestamp())
+	if err != nil {
+		return nil, fmt.Errorf("error reading request state expiration: %v: %w", err, ErrInvalidState)
+	}
+	if time.Now().After(exp) {
+		return nil, fmt.Errorf("request state expired: %w", ErrInvalidState)
+	}
+	return s, nil
+}
+
+func anyIn(want, have []string) bool {
+	for _, w := range want {
+		for _, h := range have {
+			if w == h {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,308 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/auth/oidc/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func testRequestState(t *testing.T, authMethodId string, ttl time.Duration) *store.RequestState {
+	t.Helper()
+	exp, err := ptypes.TimestampProto(time.Now().Add(ttl))
+	require.NoError(t, err)
+	return &store.RequestState{
+		AuthMethodId:   authMethodId,
+		Nonce:          "test-nonce",
+		RedirectUrl:    "http://127.0.0.1:1234/callback",
+		ExpirationTime: &timestamp.Timestamp{Timestamp: exp},
+		CodeVerifier:   "test-code-verifier-test-code-verifier-test-c",
+	}
+}
+
+func Test_RequestState(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+
+	t.Run("round-trip", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		want := testRequestState(t, "amoidc_1234567890", time.Minute)
+		enc, err := encryptState(ctx, wrapper, want)
+		require.NoError(err)
+		got, err := decryptState(ctx, wrapper, "amoidc_1234567890", enc)
+		require.NoError(err)
+		assert.True(proto.Equal(want, got))
+	})
+	t.Run("other-auth-method", func(t *testing.T) {
+		enc, err := encryptState(ctx, wrapper, testRequestState(t, "amoidc_1234567890", time.Minute))
+		require.NoError(t, err)
+		_, err = decryptState(ctx, wrapper, "amoidc_0987654321", enc)
+		assert.Truef(t, errors.Is(err, ErrInvalidState), "want err: %q got: %q", ErrInvalidState, err)
+	})
+	t.Run("expired", func(t *testing.T) {
+		enc, err := encryptState(ctx, wrapper, testRequestState(t, "amoidc_1234567890", -time.Minute))
+		require.NoError(t, err)
+		_, err = decryptState(ctx, wrapper, "amoidc_1234567890", enc)
+		assert.Truef(t, errors.Is(err, ErrInvalidState), "want err: %q got: %q", ErrInvalidState, err)
+	})
+	t.Run("other-key", func(t *testing.T) {
+		enc, err := encryptState(ctx, wrapper, testRequestState(t, "amoidc_1234567890", time.Minute))
+		require.NoError(t, err)
+		_, err = decryptState(ctx, db.TestWrapper(t), "amoidc_1234567890", enc)
+		assert.Truef(t, errors.Is(err, ErrInvalidState), "want err: %q got: %q", ErrInvalidState, err)
+	})
+	t.Run("garbage", func(t *testing.T) {
+		_, err := decryptState(ctx, wrapper, "amoidc_1234567890", "not-a-state")
+		assert.Truef(t, errors.Is(err, ErrInvalidState), "want err: %q got: %q", ErrInvalidState, err)
+	})
+}
+
+func Test_Exchange(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// authorize runs the browser part of the flow against p and returns the
+	// request state and code to exchange.
+	authorize := func(t *testing.T, p *TestProvider, am *AuthMethod) (*store.RequestState, string) {
+		t.Helper()
+		rs := testRequestState(t, "amoidc_1234567890", time.Minute)
+		provider, err := gooidc.NewProvider(ctx, am.GetDiscoveryUrl())
+		require.NoError(t, err)
+		_, code := p.Authorize(oauthConfig(am, provider, rs.GetRedirectUrl()).AuthCodeURL("state", authCodeOptions(rs)...))
+		return rs, code
+	}
+
+	tests := []struct {
+		name      string
+		setup     func(p *TestProvider, am *AuthMethod, rs *store.RequestState)
+		opts      []Option
+		want      *accountClaims
+		wantIsErr error
+	}{
+		{
+			name: "valid",
+			want: &accountClaims{subject: "alice", fullName: "Alice Doe", email: "alice@example.com"},
+		},
+		{
+			name: "valid-allowed-audience",
+			setup: func(p *TestProvider, _ *AuthMethod, _ *store.RequestState) {
+				p.SetAudiences("boundary")
+			},
+			opts: []Option{WithAllowedAudiences("other", "boundary")},
+			want: &accountClaims{subject: "alice", fullName: "Alice Doe", email: "alice@example.com"},
+		},
+		{
+			name: "valid-claim-maps",
+			setup: func(p *TestProvider, _ *AuthMethod, _ *store.RequestState) {
+				p.SetIdentity("ignored", map[string]interface{}{
+					"oid":                "1234",
+					"preferred_username": "Bob",
+					"upn":                "bob@example.com",
+				})
+			},
+			opts: []Option{WithAccountClaimMaps("oid=sub", "preferred_username=name", "upn=email")},
+			want: &accountClaims{subject: "1234", fullName: "Bob", email: "bob@example.com"},
+		},
+		{
+			name: "invalid-audience",
+			setup: func(p *TestProvider, _ *AuthMethod, _ *store.RequestState) {
+				p.SetAudiences("other")
+			},
+			opts:      []Option{WithAllowedAudiences("boundary")},
+			wantIsErr: ErrInvalidIdToken,
+		},
+		{
+			name: "invalid-nonce",
+			setup: func(_ *TestProvider, _ *AuthMethod, rs *store.RequestState) {
+				rs.Nonce = "other-nonce"
+			},
+			wantIsErr: ErrInvalidIdToken,
+		},
+		{
+			name: "invalid-no-subject",
+			setup: func(p *TestProvider, _ *AuthMethod, _ *store.RequestState) {
+				p.SetIdentity("alice", nil)
+			},
+			opts:      []Option{WithAccountClaimMaps("oid=sub")},
+			wantIsErr: ErrInvalidIdToken,
+		},
+		{
+			name: "invalid-client-secret",
+			setup: func(_ *TestProvider, am *AuthMethod, _ *store.RequestState) {
+				am.ClientSecret = "wrong"
+			},
+		},
+		{
+			name: "invalid-code-verifier",
+			setup: func(_ *TestProvider, _ *AuthMethod, rs *store.RequestState) {
+				rs.CodeVerifier = "other-code-verifier-other-code-verifier-oth"
+			},
+		},
+		{
+			name: "invalid-redirect-url",
+			setup: func(_ *TestProvider, _ *AuthMethod, rs *store.RequestState) {
+				rs.RedirectUrl = "http://127.0.0.1:4321/callback"
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			p := NewTestProvider(t, "test-client", "test-secret")
+			opts := append([]Option{WithDiscoveryUrl(p.Issuer()), WithClientId("test-client"), WithClientSecret("test-secret")}, tt.opts...)
+			am, err := NewAuthMethod("o_1234567890", opts...)
+			require.NoError(err)
+			require.NoError(am.validate())
+
+			rs, code := authorize(t, p, am)
+			if tt.setup != nil {
+				tt.setup(p, am, rs)
+			}
+			got, err := exchange(ctx, am, rs, code)
+			if tt.want == nil {
+				assert.Error(err)
+				if tt.wantIsErr != nil {
+					assert.Truef(errors.Is(err, tt.wantIsErr), "want err: %q got: %q", tt.wantIsErr, err)
+				}
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			tt.want.issuer = p.Issuer()
+			assert.Equal(tt.want, got)
+
+			// A code can only be exchanged once.
+			_, err = exchange(ctx, am, rs, code)
+			assert.Error(err)
+		})
+	}
+}
+
+func Test_ParseClaimMaps(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		in      []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			want: map[string]string{"sub": "sub", "name": "name", "email": "email"},
+		},
+		{
+			name: "mapped",
+			in:   []string{"oid=sub", " upn = email "},
+			want: map[string]string{"sub": "oid", "name": "name", "email": "upn"},
+		},
+		{
+			name:    "unknown-field",
+			in:      []string{"oid=login"},
+			wantErr: true,
+		},
+		{
+			name:    "missing-field",
+			in:      []string{"oid"},
+			wantErr: true,
+		},
+		{
+			name:    "missing-claim",
+			in:      []string{"=sub"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate-field",
+			in:      []string{"oid=sub", "upn=sub"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseClaimMaps(tt.in)
+			if tt.wantErr {
+				assert.Truef(t, errors.Is(err, db.ErrInvalidParameter), "want err: %q got: %q", db.ErrInvalidParameter, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRepository_Authenticate(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	p := NewTestProvider(t, "test-client", "test-secret")
+	am := TestAuthMethod(t, conn, databaseWrapper, org.GetPublicId(), p.Issuer(), "test-client", "test-secret")
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	login := func(t *testing.T) (*Account, error) {
+		t.Helper()
+		authUrl, err := repo.StartAuth(ctx, am.GetPublicId(), "http://127.0.0.1:1234/callback")
+		require.NoError(t, err)
+		state, code := p.Authorize(authUrl)
+		return repo.Authenticate(ctx, am.GetPublicId(), state, code)
+	}
+
+	assert, require := assert.New(t), require.New(t)
+	first, err := login(t)
+	require.NoError(err)
+	assert.Equal(p.Issuer(), first.GetIssuer())
+	assert.Equal("alice", first.GetSubject())
+	assert.Equal("Alice Doe", first.GetFullName())
+	assert.Equal("alice@example.com", first.GetEmail())
+
+	// The same subject authenticates to the same account, which picks up
+	// changes to the mapped claims.
+	p.SetIdentity("alice", map[string]interface{}{"name": "Alice Roe", "email": "alice@example.com"})
+	second, err := login(t)
+	require.NoError(err)
+	assert.Equal(first.GetPublicId(), second.GetPublicId())
+	assert.Equal("Alice Roe", second.GetFullName())
+
+	p.SetIdentity("bob", nil)
+	third, err := login(t)
+	require.NoError(err)
+	assert.NotEqual(first.GetPublicId(), third.GetPublicId())
+
+	accts, err := repo.ListAccounts(ctx, am.GetPublicId())
+	require.NoError(err)
+	assert.Len(accts, 2)
+
+	authUrl, err := repo.StartAuth(ctx, am.GetPublicId(), "http://127.0.0.1:1234/callback")
+	require.NoError(err)
+	state, code := p.Authorize(authUrl)
+	_, err = repo.Authenticate(ctx, am.GetPublicId(), state+"x", code)
+	assert.Truef(errors.Is(err, ErrInvalidState), "want err: %q got: %q", ErrInvalidState, err)
+
+	// A code issued for another attempt, such as one an attacker started,
+	// cannot be redeemed with the state of this one.
+	otherUrl, err := repo.StartAuth(ctx, am.GetPublicId(), "http://127.0.0.1:1234/callback")
+	require.NoError(err)
+	_, otherCode := p.Authorize(otherUrl)
+	_, err = repo.Authenticate(ctx, am.GetPublicId(), state, otherCode)
+	assert.Error(err)
+}
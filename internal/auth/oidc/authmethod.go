@@ -0,0 +1,195 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// Account fields which ID token claims can be mapped to.
+const (
+	subjectField  = "sub"
+	fullNameField = "name"
+	emailField    = "email"
+)
+
+// A AuthMethod delegates authentication to an OIDC provider and contains
+// the accounts created from the ID tokens it issues. It is owned by a
+// scope.
+type AuthMethod struct {
+	*store.AuthMethod
+	tableName string
+}
+
+func allocAuthMethod() AuthMethod {
+	return AuthMethod{
+		AuthMethod: &store.AuthMethod{},
+	}
+}
+
+// NewAuthMethod creates a new in memory AuthMethod assigned to scopeId.
+// Name, description, discovery url, client id, client secret, allowed
+// audiences, and account claim maps are the only valid options. All other
+// options are ignored.
+func NewAuthMethod(scopeId string, opt ...Option) (*AuthMethod, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("new: oidc auth method: no scope id: %w", db.ErrInvalidParameter)
+	}
+
+	opts := getOpts(opt...)
+	a := &AuthMethod{
+		AuthMethod: &store.AuthMethod{
+			ScopeId:          scopeId,
+			Name:             opts.withName,
+			Description:      opts.withDescription,
+			DiscoveryUrl:     opts.withDiscoveryUrl,
+			ClientId:         opts.withClientId,
+			ClientSecret:     opts.withClientSecret,
+			AllowedAudiences: strings.Join(opts.withAllowedAudiences, ","),
+			AccountClaimMaps: strings.Join(opts.withAccountClaimMaps, ","),
+		},
+	}
+	return a, nil
+}
+
+func (a *AuthMethod) clone() *AuthMethod {
+	cp := proto.Clone(a.AuthMethod)
+	return &AuthMethod{
+		AuthMethod: cp.(*store.AuthMethod),
+	}
+}
+
+// AudienceList returns the audiences an ID token must be issued for. An
+// empty list means any audience is accepted.
+func (a *AuthMethod) AudienceList() []string {
+	return splitList(a.GetAllowedAudiences())
+}
+
+// ClaimMapList returns the claim=field pairs which map ID token claims to
+// account fields.
+func (a *AuthMethod) ClaimMapList() []string {
+	return splitList(a.GetAccountClaimMaps())
+}
+
+// validate checks the provider settings of a.
+func (a *AuthMethod) validate() error {
+	if err := validateDiscoveryUrl(a.DiscoveryUrl); err != nil {
+		return err
+	}
+	if strings.TrimSpace(a.ClientId) == "" {
+		return fmt.Errorf("no client id: %w", db.ErrInvalidParameter)
+	}
+	for _, aud := range a.AudienceList() {
+		if strings.ContainsAny(aud, ", ") {
+			return fmt.Errorf("audience %q must not contain commas or spaces: %w", aud, db.ErrInvalidParameter)
+		}
+	}
+	if _, err := parseClaimMaps(a.ClaimMapList()); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (a *AuthMethod) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	if err := structwrapping.WrapStruct(ctx, cipher, a.AuthMethod, nil); err != nil {
+		return fmt.Errorf("error encrypting oidc client secret: %w", err)
+	}
+	a.KeyId = cipher.KeyID()
+	// The plaintext secret is not stored but would otherwise be written to
+	// the oplog.
+	a.ClientSecret = ""
+	return nil
+}
+
+func (a *AuthMethod) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	if err := structwrapping.UnwrapStruct(ctx, cipher, a.AuthMethod, nil); err != nil {
+		return fmt.Errorf("error decrypting oidc client secret: %w", err)
+	}
+	return nil
+}
+
+// TableName returns the table name.
+func (a *AuthMethod) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return "auth_oidc_method"
+}
+
+// SetTableName sets the table name.
+func (a *AuthMethod) SetTableName(n string) {
+	a.tableName = n
+}
+
+func (a *AuthMethod) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{a.GetPublicId()},
+		"resource-type":      []string{"oidc auth method"},
+		"op-type":            []string{op.String()},
+	}
+	if a.ScopeId != "" {
+		metadata["scope-id"] = []string{a.ScopeId}
+	}
+	return metadata
+}
+
+func validateDiscoveryUrl(u string) error {
+	if strings.TrimSpace(u) == "" {
+		return fmt.Errorf("no discovery url: %w", db.ErrInvalidParameter)
+	}
+	pu, err := url.Parse(u)
+	if err != nil {
+		return fmt.Errorf("discovery url %q: %v: %w", u, err, db.ErrInvalidParameter)
+	}
+	if (pu.Scheme != "https" && pu.Scheme != "http") || pu.Host == "" {
+		return fmt.Errorf("discovery url %q must be an absolute http or https url: %w", u, db.ErrInvalidParameter)
+	}
+	return nil
+}
+
+// parseClaimMaps parses claim=field pairs and returns a map of account
+// field to the claim mapped to it. Fields without a mapping are mapped to
+// the claim of the same name.
+func parseClaimMaps(maps []string) (map[string]string, error) {
+	fields := map[string]string{
+		subjectField:  subjectField,
+		fullNameField: fullNameField,
+		emailField:    emailField,
+	}
+	seen := make(map[string]bool, len(maps))
+	for _, m := range maps {
+		parts := strings.SplitN(m, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("account claim map %q must be in the form claim=field: %w", m, db.ErrInvalidParameter)
+		}
+		claim, field := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if _, ok := fields[field]; !ok {
+			return nil, fmt.Errorf("account claim map %q: unknown field %q, must be one of %q, %q, or %q: %w",
+				m, field, subjectField, fullNameField, emailField, db.ErrInvalidParameter)
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("account claim map %q: field %q is mapped more than once: %w", m, field, db.ErrInvalidParameter)
+		}
+		seen[field] = true
+		fields[field] = claim
+	}
+	return fields, nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,14 @@
+package oidc
+
+import "errors"
+
+var (
+	// ErrInvalidState results from completing an authentication attempt
+	// with a state which was not issued by the auth method or which has
+	// expired.
+	ErrInvalidState = errors.New("invalid authentication state")
+
+	// ErrInvalidIdToken results from completing an authentication attempt
+	// with an ID token which does not satisfy the auth method.
+	ErrInvalidIdToken = errors.New("invalid id token")
+)
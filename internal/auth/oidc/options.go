@@ -0,0 +1,107 @@
+package oidc
+
+// getOpts - iterate the inbound Options and return a struct.
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withName             string
+	withDescription      string
+	withLimit            int
+	withStartPageAfterId string
+	withPublicId         string
+	withDiscoveryUrl     string
+	withClientId         string
+	withClientSecret     string
+	withAllowedAudiences []string
+	withAccountClaimMaps []string
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithPublicId provides an optional public id
+func WithPublicId(id string) Option {
+	return func(o *options) {
+		o.withPublicId = id
+	}
+}
+
+// WithDescription provides an optional description.
+func WithDescription(desc string) Option {
+	return func(o *options) {
+		o.withDescription = desc
+	}
+}
+
+// WithName provides an optional name.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.withName = name
+	}
+}
+
+// WithLimit provides an option to provide a limit.  Intentionally allowing
+// negative integers.   If WithLimit < 0, then unlimited results are returned.
+// If WithLimit == 0, then default limits are used for results.
+func WithLimit(l int) Option {
+	return func(o *options) {
+		o.withLimit = l
+	}
+}
+
+// WithDiscoveryUrl provides an optional discovery url, the issuer url of
+// the OIDC provider.
+func WithDiscoveryUrl(u string) Option {
+	return func(o *options) {
+		o.withDiscoveryUrl = u
+	}
+}
+
+// WithClientId provides an optional OAuth 2.0 client id.
+func WithClientId(id string) Option {
+	return func(o *options) {
+		o.withClientId = id
+	}
+}
+
+// WithClientSecret provides an optional OAuth 2.0 client secret.
+func WithClientSecret(secret string) Option {
+	return func(o *options) {
+		o.withClientSecret = secret
+	}
+}
+
+// WithAllowedAudiences provides optional audiences an ID token must be
+// issued for.
+func WithAllowedAudiences(aud ...string) Option {
+	return func(o *options) {
+		o.withAllowedAudiences = aud
+	}
+}
+
+// WithAccountClaimMaps provides optional claim=field pairs which map ID
+// token claims to account fields.
+func WithAccountClaimMaps(m ...string) Option {
+	return func(o *options) {
+		o.withAccountClaimMaps = m
+	}
+}
+
+// WithStartPageAfterId provides an option to only list resources whose public
+// ids sort after the given id. Used to retrieve the next page of results.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
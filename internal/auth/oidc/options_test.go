@@ -0,0 +1,71 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithPublicId", func(t *testing.T) {
+		opts := getOpts(WithPublicId("test id"))
+		testOpts := getDefaultOptions()
+		testOpts.withPublicId = "test id"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithName", func(t *testing.T) {
+		opts := getOpts(WithName("test"))
+		testOpts := getDefaultOptions()
+		testOpts.withName = "test"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithDescription", func(t *testing.T) {
+		opts := getOpts(WithDescription("test desc"))
+		testOpts := getDefaultOptions()
+		testOpts.withDescription = "test desc"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithLimit", func(t *testing.T) {
+		opts := getOpts(WithLimit(5))
+		testOpts := getDefaultOptions()
+		testOpts.withLimit = 5
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithDiscoveryUrl", func(t *testing.T) {
+		opts := getOpts(WithDiscoveryUrl("https://example.com"))
+		testOpts := getDefaultOptions()
+		testOpts.withDiscoveryUrl = "https://example.com"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithClientId", func(t *testing.T) {
+		opts := getOpts(WithClientId("client"))
+		testOpts := getDefaultOptions()
+		testOpts.withClientId = "client"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithClientSecret", func(t *testing.T) {
+		opts := getOpts(WithClientSecret("secret"))
+		testOpts := getDefaultOptions()
+		testOpts.withClientSecret = "secret"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithAllowedAudiences", func(t *testing.T) {
+		opts := getOpts(WithAllowedAudiences("a", "b"))
+		testOpts := getDefaultOptions()
+		testOpts.withAllowedAudiences = []string{"a", "b"}
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithAccountClaimMaps", func(t *testing.T) {
+		opts := getOpts(WithAccountClaimMaps("oid=sub"))
+		testOpts := getDefaultOptions()
+		testOpts.withAccountClaimMaps = []string{"oid=sub"}
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithStartPageAfterId", func(t *testing.T) {
+		opts := getOpts(WithStartPageAfterId("id_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withStartPageAfterId = "id_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
+}
@@ -0,0 +1,285 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateAuthMethod inserts m into the repository and returns a new
+// AuthMethod containing the auth method's PublicId. m is not changed. m must
+// contain a valid ScopeId. m must not contain a PublicId. The PublicId is
+// generated and assigned by this method.
+//
+// m must contain a valid DiscoveryUrl, ClientId and ClientSecret. The
+// ClientSecret is encrypted with the database key of m.ScopeId and is not
+// included in the returned AuthMethod.
+//
+// WithPublicId is the only valid option. All other options are ignored.
+//
+// Both m.Name and m.Description are optional. If m.Name is set, it must be
+// unique within m.ScopeId.
+func (r *Repository) CreateAuthMethod(ctx context.Context, m *AuthMethod, opt ...Option) (*AuthMethod, error) {
+	if m == nil {
+		return nil, fmt.Errorf("create: oidc auth method: %w", db.ErrInvalidParameter)
+	}
+	if m.AuthMethod == nil {
+		return nil, fmt.Errorf("create: oidc auth method: embedded AuthMethod: %w", db.ErrInvalidParameter)
+	}
+	if m.ScopeId == "" {
+		return nil, fmt.Errorf("create: oidc auth method: no scope id: %w", db.ErrInvalidParameter)
+	}
+	if m.PublicId != "" {
+		return nil, fmt.Errorf("create: oidc auth method: public id not empty: %w", db.ErrInvalidParameter)
+	}
+	if m.ClientSecret == "" {
+		return nil, fmt.Errorf("create: oidc auth method: no client secret: %w", db.ErrInvalidParameter)
+	}
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("create: oidc auth method: %w", err)
+	}
+	m = m.clone()
+
+	opts := getOpts(opt...)
+
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, AuthMethodPrefix+"_") {
+			return nil, fmt.Errorf("create: oidc auth method: passed-in public ID %q has wrong prefix, should be %q: %w", opts.withPublicId, AuthMethodPrefix, db.ErrInvalidPublicId)
+		}
+		m.PublicId = opts.withPublicId
+	} else {
+		id, err := newAuthMethodId()
+		if err != nil {
+			return nil, fmt.Errorf("create: oidc auth method: %w", err)
+		}
+		m.PublicId = id
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, m.GetScopeId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("create: oidc auth method: unable to get oplog wrapper: %w", err)
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, m.GetScopeId(), kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, fmt.Errorf("create: oidc auth method: unable to get database wrapper: %w", err)
+	}
+	if err := m.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, fmt.Errorf("create: oidc auth method: %w", err)
+	}
+
+	var newAuthMethod *AuthMethod
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newAuthMethod = m.clone()
+			return w.Create(ctx, newAuthMethod, db.WithOplog(oplogWrapper, m.oplog(oplog.OpType_OP_TYPE_CREATE)))
+		},
+	)
+
+	if err != nil {
+		if db.IsUniqueError(err) {
+			return nil, fmt.Errorf("create: oidc auth method: in scope: %s: name %s already exists: %w",
+				m.ScopeId, m.Name, db.ErrNotUnique)
+		}
+		return nil, fmt.Errorf("create: oidc auth method: in scope: %s: %w", m.ScopeId, err)
+	}
+	return newAuthMethod, nil
+}
+
+// LookupAuthMethod will look up an auth method in the repository.  If the
+// auth method is not found, it will return nil, nil.  The client secret of
+// the returned auth method is not decrypted.  All options are ignored.
+func (r *Repository) LookupAuthMethod(ctx context.Context, publicId string, opt ...Option) (*AuthMethod, error) {
+	if publicId == "" {
+		return nil, fmt.Errorf("lookup: oidc auth method: missing public id %w", db.ErrInvalidParameter)
+	}
+	a := allocAuthMethod()
+	a.PublicId = publicId
+	if err := r.reader.LookupByPublicId(ctx, &a); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup: oidc auth method: failed %w for %s", err, publicId)
+	}
+	return &a, nil
+}
+
+// ListAuthMethods returns a slice of AuthMethods for the scopeId ordered by public id.
+// Supports the WithLimit and WithStartPageAfterId options.
+func (r *Repository) ListAuthMethods(ctx context.Context, scopeId string, opt ...Option) ([]*AuthMethod, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("list: oidc auth method: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	where, args := "scope_id = ?", []interface{}{scopeId}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id collate \"C\" > ?", append(args, opts.withStartPageAfterId)
+	}
+	var authMethods []*AuthMethod
+	err := r.reader.SearchWhere(ctx, &authMethods, where, args, db.WithLimit(limit), db.WithOrder(`public_id collate "C"`))
+	if err != nil {
+		return nil, fmt.Errorf("list: oidc auth method: %w", err)
+	}
+	return authMethods, nil
+}
+
+// DeleteAuthMethod deletes the auth method for the provided id from the repository returning a count of the
+// number of records deleted.  All options are ignored.
+func (r *Repository) DeleteAuthMethod(ctx context.Context, scopeId, publicId string, opt ...Option) (int, error) {
+	if publicId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: oidc auth method: missing public id: %w", db.ErrInvalidParameter)
+	}
+	am := allocAuthMethod()
+	am.PublicId = publicId
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: oidc auth method: unable to get oplog wrapper: %w", err)
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) (err error) {
+			metadata := am.oplog(oplog.OpType_OP_TYPE_DELETE)
+			dAc := am.clone()
+			rowsDeleted, err = w.Delete(ctx, dAc, db.WithOplog(oplogWrapper, metadata))
+			if err == nil && rowsDeleted > 1 {
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: oidc auth method: %s: %w", publicId, err)
+	}
+
+	return rowsDeleted, nil
+}
+
+// UpdateAuthMethod will update an auth method in the repository and return
+// the written auth method.  fieldMaskPaths provides field_mask.proto paths
+// for fields that should be updated.  Fields will be set to NULL if the
+// field is a zero value and included in fieldMask. Name, Description,
+// DiscoveryUrl, ClientId, ClientSecret, AllowedAudiences, and
+// AccountClaimMaps are the only updatable fields.  DiscoveryUrl, ClientId,
+// and ClientSecret cannot be set to NULL.  A new ClientSecret is encrypted
+// with the current database key of the scope.  If no updatable fields are
+// included in the fieldMaskPaths, then an error is returned.
+func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMethod, version uint32, fieldMaskPaths []string, opt ...Option) (*AuthMethod, int, error) {
+	if authMethod == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: missing authMethod: %w", db.ErrInvalidParameter)
+	}
+	if authMethod.PublicId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: missing authMethod public id: %w", db.ErrInvalidParameter)
+	}
+	if authMethod.ScopeId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: scope id empty: %w", db.ErrInvalidParameter)
+	}
+	var changeSecret bool
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("name", f):
+		case strings.EqualFold("description", f):
+		case strings.EqualFold("DiscoveryUrl", f):
+			if err := validateDiscoveryUrl(authMethod.DiscoveryUrl); err != nil {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: %w", err)
+			}
+		case strings.EqualFold("ClientId", f):
+			if strings.TrimSpace(authMethod.ClientId) == "" {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: no client id: %w", db.ErrInvalidParameter)
+			}
+		case strings.EqualFold("ClientSecret", f):
+			if authMethod.ClientSecret == "" {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: no client secret: %w", db.ErrInvalidParameter)
+			}
+			changeSecret = true
+		case strings.EqualFold("AllowedAudiences", f):
+		case strings.EqualFold("AccountClaimMaps", f):
+			if _, err := parseClaimMaps(authMethod.ClaimMapList()); err != nil {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: %w", err)
+			}
+		default:
+			return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: field: %s: %w", f, db.ErrInvalidFieldMask)
+		}
+	}
+	var dbMask, nullFields []string
+	dbMask, nullFields = dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			"Name":             authMethod.Name,
+			"Description":      authMethod.Description,
+			"DiscoveryUrl":     authMethod.DiscoveryUrl,
+			"ClientId":         authMethod.ClientId,
+			"AllowedAudiences": authMethod.AllowedAudiences,
+			"AccountClaimMaps": authMethod.AccountClaimMaps,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if changeSecret {
+		dbMask = append(dbMask, "CtClientSecret", "KeyId")
+	}
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: %w", db.ErrEmptyFieldMask)
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, authMethod.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: unable to get oplog wrapper: %w", err)
+	}
+
+	upAuthMethod := authMethod.clone()
+	if changeSecret {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, authMethod.ScopeId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: unable to get database wrapper: %w", err)
+		}
+		if err := upAuthMethod.encrypt(ctx, databaseWrapper); err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: %w", err)
+		}
+	}
+	var rowsUpdated int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			dbOpts := []db.Option{
+				db.WithOplog(oplogWrapper, upAuthMethod.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+				db.WithVersion(&version),
+			}
+			var err error
+			rowsUpdated, err = w.Update(
+				ctx,
+				upAuthMethod,
+				dbMask,
+				nullFields,
+				dbOpts...,
+			)
+			if err == nil && rowsUpdated > 1 {
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+	if err != nil {
+		if db.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: authMethod %s already exists in scope %s: %w", authMethod.Name, authMethod.ScopeId, db.ErrNotUnique)
+		}
+		return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: %w for %s", err, authMethod.PublicId)
+	}
+	return upAuthMethod, rowsUpdated, err
+}
@@ -0,0 +1,50 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// rewrapAuthMethodQuery replaces the encrypted client secret unless it was
+// changed since it was read.
+const rewrapAuthMethodQuery = `
+update auth_oidc_method
+   set client_secret = ?,
+       key_id = ?
+ where public_id = ?
+   and key_id = ?
+   and client_secret = ?;
+`
+
+func init() {
+	kms.RegisterTableRewrapFn("auth_oidc_method", "key_id", kms.KeyPurposeDatabase, rewrapAuthMethods)
+}
+
+// rewrapAuthMethods re-encrypts the client secrets of auth methods encrypted
+// with previous versions of the database key.
+func rewrapAuthMethods(ctx context.Context, r db.Reader, w db.Writer, wrapper wrapping.Wrapper, keyVersionIds []string, limit int) (int, error) {
+	var authMethods []*AuthMethod
+	if err := r.SearchWhere(ctx, &authMethods, "key_id in (?)", []interface{}{keyVersionIds}, db.WithLimit(limit)); err != nil {
+		return 0, fmt.Errorf("rewrap oidc auth methods: %w", err)
+	}
+	var rewrapped int
+	for _, am := range authMethods {
+		oldKeyId, oldSecret := am.KeyId, am.CtClientSecret
+		if err := am.decrypt(ctx, wrapper); err != nil {
+			return rewrapped, fmt.Errorf("rewrap oidc auth methods: %w for %s", err, am.PublicId)
+		}
+		if err := am.encrypt(ctx, wrapper); err != nil {
+			return rewrapped, fmt.Errorf("rewrap oidc auth methods: %w for %s", err, am.PublicId)
+		}
+		n, err := w.Exec(ctx, rewrapAuthMethodQuery, []interface{}{am.CtClientSecret, am.KeyId, am.PublicId, oldKeyId, oldSecret})
+		if err != nil {
+			return rewrapped, fmt.Errorf("rewrap oidc auth methods: %w for %s", err, am.PublicId)
+		}
+		rewrapped += n
+	}
+	return rewrapped, nil
+}
@@ -0,0 +1,284 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/vault/sdk/helper/base62"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// TestAuthMethod creates an oidc auth method in the provided DB with the
+// provided scope id and provider settings. The client secret is encrypted
+// with databaseWrapper, which should be the database wrapper of the scope.
+// If any errors are encountered during the creation of the auth method, the
+// test will fail.
+func TestAuthMethod(t *testing.T, conn *gorm.DB, databaseWrapper wrapping.Wrapper, scopeId, discoveryUrl, clientId, clientSecret string, opt ...Option) *AuthMethod {
+	t.Helper()
+	require := require.New(t)
+	ctx := context.Background()
+	opt = append(opt, WithDiscoveryUrl(discoveryUrl), WithClientId(clientId), WithClientSecret(clientSecret))
+	am, err := NewAuthMethod(scopeId, opt...)
+	require.NoError(err)
+	require.NoError(am.validate())
+	am.PublicId, err = newAuthMethodId()
+	require.NoError(err)
+	require.NoError(am.encrypt(ctx, databaseWrapper))
+
+	w := db.New(conn)
+	require.NoError(w.Create(ctx, am))
+	return am
+}
+
+// TestAccount creates an oidc account for issuer and subject in the
+// provided DB with the provided auth method id. The auth method must have
+// been created previously. If any errors are encountered during the
+// creation of the account, the test will fail.
+func TestAccount(t *testing.T, conn *gorm.DB, authMethodId, issuer, subject string) *Account {
+	t.Helper()
+	require := require.New(t)
+	a, err := NewAccount(authMethodId)
+	require.NoError(err)
+	a.PublicId, err = newAccountId()
+	require.NoError(err)
+	a.Issuer = issuer
+	a.Subject = subject
+
+	w := db.New(conn)
+	require.NoError(w.Create(context.Background(), a))
+	return a
+}
+
+// TestProvider is a stand-in OIDC provider for tests. Its authorization
+// endpoint approves every request without user interaction and redirects to
+// the requested redirect URL with a code. Its token endpoint exchanges the
+// code, along with the PKCE code verifier if the authorization request had a
+// challenge, for an ID token with the configured subject and claims, signed
+// with a key published by its JWKS endpoint.
+type TestProvider struct {
+	t            *testing.T
+	server       *httptest.Server
+	key          *rsa.PrivateKey
+	clientId     string
+	clientSecret string
+
+	mu        sync.Mutex
+	subject   string
+	claims    map[string]interface{}
+	audiences []string
+	codes     map[string]testAuthorization
+}
+
+type testAuthorization struct {
+	nonce         string
+	redirectUrl   string
+	codeChallenge string
+}
+
+// verify reports whether verifier is the PKCE code verifier of the S256
+// challenge of the authorization, if it had one.
+func (a testAuthorization) verify(verifier string) bool {
+	if a.codeChallenge == "" {
+		return true
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:]) == a.codeChallenge
+}
+
+// NewTestProvider starts a TestProvider for the OAuth 2.0 client clientId
+// with clientSecret. It is stopped when the test completes. ID tokens are
+// issued for the subject "alice" with the name "Alice Doe" and the email
+// "alice@example.com" until changed by SetIdentity.
+func NewTestProvider(t *testing.T, clientId, clientSecret string) *TestProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p := &TestProvider{
+		t:            t,
+		key:          key,
+		clientId:     clientId,
+		clientSecret: clientSecret,
+		subject:      "alice",
+		claims: map[string]interface{}{
+			"name":  "Alice Doe",
+			"email": "alice@example.com",
+		},
+		codes: make(map[string]testAuthorization),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.handleDiscovery)
+	mux.HandleFunc("/authorize", p.handleAuthorize)
+	mux.HandleFunc("/token", p.handleToken)
+	mux.HandleFunc("/keys", p.handleKeys)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+// Issuer returns the issuer URL of the provider, which is also its
+// discovery URL.
+func (p *TestProvider) Issuer() string {
+	return p.server.URL
+}
+
+// SetIdentity sets the subject and additional claims of the ID tokens the
+// provider issues.
+func (p *TestProvider) SetIdentity(subject string, claims map[string]interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.subject = subject
+	p.claims = claims
+}
+
+// SetAudiences sets the audiences, in addition to the client id, of the ID
+// tokens the provider issues.
+func (p *TestProvider) SetAudiences(aud ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.audiences = aud
+}
+
+// Authorize visits authUrl the way a user's browser would and returns the
+// state and code the provider redirected to the redirect URL with.
+func (p *TestProvider) Authorize(authUrl string) (state, code string) {
+	p.t.Helper()
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(authUrl)
+	require.NoError(p.t, err)
+	defer resp.Body.Close()
+	require.Equal(p.t, http.StatusFound, resp.StatusCode)
+	loc, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(p.t, err)
+	return loc.Query().Get("state"), loc.Query().Get("code")
+}
+
+func (p *TestProvider) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	p.writeJson(w, map[string]interface{}{
+		"issuer":                                p.server.URL,
+		"authorization_endpoint":                p.server.URL + "/authorize",
+		"token_endpoint":                        p.server.URL + "/token",
+		"jwks_uri":                              p.server.URL + "/keys",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{string(jose.RS256)},
+	})
+}
+
+func (p *TestProvider) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirectUrl, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || q.Get("client_id") != p.clientId || q.Get("response_type") != "code" {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+	code, err := base62.Random(20)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	p.mu.Lock()
+	p.codes[code] = testAuthorization{nonce: q.Get("nonce"), redirectUrl: redirectUrl.String(), codeChallenge: q.Get("code_challenge")}
+	p.mu.Unlock()
+
+	rq := redirectUrl.Query()
+	rq.Set("code", code)
+	rq.Set("state", q.Get("state"))
+	redirectUrl.RawQuery = rq.Encode()
+	http.Redirect(w, r, redirectUrl.String(), http.StatusFound)
+}
+
+func (p *TestProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id, secret, ok := r.BasicAuth()
+	if !ok {
+		id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if id != p.clientId || secret != p.clientSecret {
+		p.writeTokenError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	code := r.PostForm.Get("code")
+	authz, ok := p.codes[code]
+	if !ok || authz.redirectUrl != r.PostForm.Get("redirect_uri") || !authz.verify(r.PostForm.Get("code_verifier")) {
+		p.writeTokenError(w, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+	delete(p.codes, code)
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: p.key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "test"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	now := time.Now()
+	claims := map[string]interface{}{
+		"iss":   p.server.URL,
+		"sub":   p.subject,
+		"aud":   append([]string{p.clientId}, p.audiences...),
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"nonce": authz.nonce,
+	}
+	for k, v := range p.claims {
+		claims[k] = v
+	}
+	idToken, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	p.writeJson(w, map[string]interface{}{
+		"access_token": code,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func (p *TestProvider) handleKeys(w http.ResponseWriter, _ *http.Request) {
+	p.writeJson(w, jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{{
+			Key:       &p.key.PublicKey,
+			KeyID:     "test",
+			Algorithm: string(jose.RS256),
+			Use:       "sig",
+		}},
+	})
+}
+
+func (p *TestProvider) writeTokenError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": code})
+}
+
+func (p *TestProvider) writeJson(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
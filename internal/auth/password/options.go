@@ -14,14 +14,15 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withName        string
-	withDescription string
-	withLoginName   string
-	withLimit       int
-	withConfig      Configuration
-	withPublicId    string
-	password        string
-	withPassword    bool
+	withName             string
+	withDescription      string
+	withLoginName        string
+	withLimit            int
+	withStartPageAfterId string
+	withConfig           Configuration
+	withPublicId         string
+	password             string
+	withPassword         bool
 }
 
 func getDefaultOptions() options {
@@ -81,3 +82,11 @@ func WithConfiguration(config Configuration) Option {
 		o.withConfig = config
 	}
 }
+
+// WithStartPageAfterId provides an option to only list resources whose public
+// ids sort after the given id. Used to retrieve the next page of results.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
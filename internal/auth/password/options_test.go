@@ -57,4 +57,10 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withConfig = c
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithStartPageAfterId", func(t *testing.T) {
+		opts := getOpts(WithStartPageAfterId("id_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withStartPageAfterId = "id_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
 }
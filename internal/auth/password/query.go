@@ -41,4 +41,15 @@ select *
         where public_id = $1
     );
 `
+
+	// rewrapArgon2CredentialQuery replaces the encrypted salt unless the
+	// credential was changed since it was read.
+	rewrapArgon2CredentialQuery = `
+update auth_password_argon2_cred
+   set salt = ?,
+       key_id = ?
+ where private_id = ?
+   and key_id = ?
+   and salt = ?;
+`
 )
@@ -6,8 +6,19 @@ import (
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
 )
 
+func init() {
+	oplog.RegisterTypes(
+		&AuthMethod{},
+		&Account{},
+		&Argon2Configuration{},
+		&Argon2Credential{},
+		&Credential{},
+	)
+}
+
 // A Repository stores and retrieves the persistent types in the password
 // package. It is not safe to use a repository concurrently.
 type Repository struct {
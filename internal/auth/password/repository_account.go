@@ -131,7 +131,8 @@ func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt
 	return a, nil
 }
 
-// ListAccounts in an auth method and supports WithLimit option.
+// ListAccounts in an auth method ordered by public id and supports the
+// WithLimit and WithStartPageAfterId options.
 func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string, opt ...Option) ([]*Account, error) {
 	if withAuthMethodId == "" {
 		return nil, fmt.Errorf("list: password account: missing auth method id %w", db.ErrInvalidParameter)
@@ -142,8 +143,12 @@ func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string,
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
+	where, args := "auth_method_id = ?", []interface{}{withAuthMethodId}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id collate \"C\" > ?", append(args, opts.withStartPageAfterId)
+	}
 	var accts []*Account
-	err := r.reader.SearchWhere(ctx, &accts, "auth_method_id = ?", []interface{}{withAuthMethodId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &accts, where, args, db.WithLimit(limit), db.WithOrder(`public_id collate "C"`))
 	if err != nil {
 		return nil, fmt.Errorf("list: password account: %w", err)
 	}
@@ -112,7 +112,8 @@ func (r *Repository) LookupAuthMethod(ctx context.Context, publicId string, opt
 	return &a, nil
 }
 
-// ListAuthMethods returns a slice of AuthMethods for the scopeId. WithLimit is the only option supported.
+// ListAuthMethods returns a slice of AuthMethods for the scopeId ordered by public id.
+// Supports the WithLimit and WithStartPageAfterId options.
 func (r *Repository) ListAuthMethods(ctx context.Context, scopeId string, opt ...Option) ([]*AuthMethod, error) {
 	if scopeId == "" {
 		return nil, fmt.Errorf("list: password auth method: missing scope id: %w", db.ErrInvalidParameter)
@@ -123,8 +124,12 @@ func (r *Repository) ListAuthMethods(ctx context.Context, scopeId string, opt ..
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
+	where, args := "scope_id = ?", []interface{}{scopeId}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id collate \"C\" > ?", append(args, opts.withStartPageAfterId)
+	}
 	var authMethods []*AuthMethod
-	err := r.reader.SearchWhere(ctx, &authMethods, "scope_id = ?", []interface{}{scopeId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &authMethods, where, args, db.WithLimit(limit), db.WithOrder(`public_id collate "C"`))
 	if err != nil {
 		return nil, fmt.Errorf("list: password auth method: %w", err)
 	}
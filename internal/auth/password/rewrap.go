@@ -0,0 +1,39 @@
+package password
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+func init() {
+	kms.RegisterTableRewrapFn("auth_password_argon2_cred", "key_id", kms.KeyPurposeDatabase, rewrapArgon2Credentials)
+}
+
+// rewrapArgon2Credentials re-encrypts the salts of argon2 credentials
+// encrypted with previous versions of the database key.
+func rewrapArgon2Credentials(ctx context.Context, r db.Reader, w db.Writer, wrapper wrapping.Wrapper, keyVersionIds []string, limit int) (int, error) {
+	var creds []*Argon2Credential
+	if err := r.SearchWhere(ctx, &creds, "key_id in (?)", []interface{}{keyVersionIds}, db.WithLimit(limit)); err != nil {
+		return 0, fmt.Errorf("rewrap argon2 credentials: %w", err)
+	}
+	var rewrapped int
+	for _, c := range creds {
+		oldKeyId, oldSalt := c.KeyId, c.CtSalt
+		if err := c.decrypt(ctx, wrapper); err != nil {
+			return rewrapped, fmt.Errorf("rewrap argon2 credentials: %w for %s", err, c.PrivateId)
+		}
+		if err := c.encrypt(ctx, wrapper); err != nil {
+			return rewrapped, fmt.Errorf("rewrap argon2 credentials: %w for %s", err, c.PrivateId)
+		}
+		n, err := w.Exec(ctx, rewrapArgon2CredentialQuery, []interface{}{c.CtSalt, c.KeyId, c.PrivateId, oldKeyId, oldSalt})
+		if err != nil {
+			return rewrapped, fmt.Errorf("rewrap argon2 credentials: %w for %s", err, c.PrivateId)
+		}
+		rewrapped += n
+	}
+	return rewrapped, nil
+}
@@ -0,0 +1,109 @@
+package auth_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/servers/controller"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyRecursive(t *testing.T) {
+	tc := controller.NewTestController(t, nil)
+	defer tc.Shutdown()
+
+	conn := tc.DbConn()
+	token := tc.Token()
+	opts := []iam.Option{iam.WithUserId(token.UserId), iam.WithSkipAdminRoleCreation(true), iam.WithSkipDefaultRoleCreation(true)}
+	org, proj1 := iam.TestScopes(t, tc.IamRepo(), opts...)
+	proj2, err := iam.NewProject(org.GetPublicId())
+	require.NoError(t, err)
+	proj2, err = tc.IamRepo().CreateScope(context.Background(), proj2, token.UserId, opts...)
+	require.NoError(t, err)
+	noAccessOrg, _ := iam.TestScopes(t, tc.IamRepo(), opts...)
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return tc.IamRepo(), nil
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return tc.ServersRepo(), nil
+	}
+	authTokenRepoFn := func() (*authtoken.Repository, error) {
+		return tc.AuthTokenRepo(), nil
+	}
+
+	for _, p := range []*iam.Scope{proj1, proj2} {
+		role := iam.TestRole(t, conn, p.GetPublicId())
+		iam.TestUserRole(t, conn, role.PublicId, token.UserId)
+		iam.TestRoleGrant(t, conn, role.PublicId, "id=*;type=target;actions=list")
+	}
+
+	cases := []struct {
+		name    string
+		scopeId string
+		wantIds []string
+		wantErr bool
+	}{
+		{
+			name:    "org with authorized projects",
+			scopeId: org.GetPublicId(),
+			wantIds: []string{proj1.GetPublicId(), proj2.GetPublicId()},
+		},
+		{
+			name:    "single project",
+			scopeId: proj2.GetPublicId(),
+			wantIds: []string{proj2.GetPublicId()},
+		},
+		{
+			name:    "nothing authorized",
+			scopeId: noAccessOrg.GetPublicId(),
+			wantErr: true,
+		},
+		{
+			name:    "missing scope",
+			scopeId: "o_doesntexist",
+			wantErr: true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := auth.NewVerifierContext(
+				context.Background(),
+				tc.Logger(),
+				iamRepoFn,
+				authTokenRepoFn,
+				serversRepoFn,
+				tc.Kms(),
+				auth.RequestInfo{
+					PublicId:       token.Id,
+					EncryptedToken: strings.Split(token.Token, "_")[2],
+					TokenFormat:    auth.AuthTokenTypeBearer,
+				})
+			results, err := auth.VerifyRecursive(ctx, iamRepoFn,
+				auth.WithScopeId(tt.scopeId),
+				auth.WithType(resource.Target),
+				auth.WithAction(action.List))
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			var gotIds []string
+			for _, r := range results {
+				require.NoError(r.Error)
+				assert.Equal(token.UserId, r.UserId)
+				gotIds = append(gotIds, r.Scope.GetId())
+			}
+			assert.ElementsMatch(tt.wantIds, gotIds)
+		})
+	}
+}
@@ -14,8 +14,9 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withTokenValue bool
-	withLimit      int
+	withTokenValue       bool
+	withLimit            int
+	withStartPageAfterId string
 }
 
 func getDefaultOptions() options {
@@ -38,3 +39,11 @@ func WithLimit(limit int) Option {
 		o.withLimit = limit
 	}
 }
+
+// WithStartPageAfterId provides an option to only list resources whose public
+// ids sort after the given id. Used to retrieve the next page of results.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
@@ -16,4 +16,11 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withTokenValue = true
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithStartPageAfterId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithStartPageAfterId("id_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withStartPageAfterId = "id_1234567890"
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -271,7 +271,8 @@ func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ..
 	return retAT, nil
 }
 
-// ListAuthTokens in an org and supports the WithLimit option.
+// ListAuthTokens in an org ordered by public id and supports the WithLimit
+// and WithStartPageAfterId options.
 func (r *Repository) ListAuthTokens(ctx context.Context, withOrgId string, opt ...Option) ([]*AuthToken, error) {
 	if withOrgId == "" {
 		return nil, fmt.Errorf("list users: missing org id %w", db.ErrInvalidParameter)
@@ -282,8 +283,12 @@ func (r *Repository) ListAuthTokens(ctx context.Context, withOrgId string, opt .
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
+	where, args := "auth_account_id in (select public_id from auth_account where scope_id = ?)", []interface{}{withOrgId}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id collate \"C\" > ?", append(args, opts.withStartPageAfterId)
+	}
 	var authTokens []*AuthToken
-	if err := r.reader.SearchWhere(ctx, &authTokens, "auth_account_id in (select public_id from auth_account where scope_id = ?)", []interface{}{withOrgId}, db.WithLimit(limit)); err != nil {
+	if err := r.reader.SearchWhere(ctx, &authTokens, where, args, db.WithLimit(limit), db.WithOrder(`public_id collate "C"`)); err != nil {
 		return nil, fmt.Errorf("list users: %w", err)
 	}
 	for _, at := range authTokens {
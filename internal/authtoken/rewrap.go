@@ -0,0 +1,51 @@
+package authtoken
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// rewrapAuthTokenQuery replaces the encrypted token unless it was changed
+// since it was read.
+const rewrapAuthTokenQuery = `
+update auth_token
+   set token = ?,
+       key_id = ?
+ where public_id = ?
+   and key_id = ?
+   and token = ?;
+`
+
+func init() {
+	kms.RegisterTableRewrapFn(defaultWritableAuthTokenTableName, "key_id", kms.KeyPurposeDatabase, rewrapAuthTokens)
+}
+
+// rewrapAuthTokens re-encrypts the tokens of auth tokens encrypted with
+// previous versions of the database key.
+func rewrapAuthTokens(ctx context.Context, r db.Reader, w db.Writer, wrapper wrapping.Wrapper, keyVersionIds []string, limit int) (int, error) {
+	var tokens []*writableAuthToken
+	if err := r.SearchWhere(ctx, &tokens, "key_id in (?)", []interface{}{keyVersionIds}, db.WithLimit(limit)); err != nil {
+		return 0, fmt.Errorf("rewrap auth tokens: %w", err)
+	}
+	var rewrapped int
+	for _, t := range tokens {
+		at := t.toAuthToken()
+		if err := at.decrypt(ctx, wrapper); err != nil {
+			return rewrapped, fmt.Errorf("rewrap auth tokens: %w for %s", err, t.PublicId)
+		}
+		wt := at.toWritableAuthToken()
+		if err := wt.encrypt(ctx, wrapper); err != nil {
+			return rewrapped, fmt.Errorf("rewrap auth tokens: %w for %s", err, t.PublicId)
+		}
+		n, err := w.Exec(ctx, rewrapAuthTokenQuery, []interface{}{wt.CtToken, wt.KeyId, t.PublicId, t.KeyId, t.CtToken})
+		if err != nil {
+			return rewrapped, fmt.Errorf("rewrap auth tokens: %w for %s", err, t.PublicId)
+		}
+		rewrapped += n
+	}
+	return rewrapped, nil
+}
@@ -64,13 +64,16 @@ type Command struct {
 	FlagRecoveryConfig   string
 	flagOutputCurlString bool
 
-	FlagScopeId       string
-	FlagId            string
-	FlagName          string
-	FlagDescription   string
-	FlagAuthMethodId  string
-	FlagHostCatalogId string
-	FlagVersion       int
+	FlagScopeId           string
+	FlagId                string
+	FlagName              string
+	FlagDescription       string
+	FlagAuthMethodId      string
+	FlagHostCatalogId     string
+	FlagCredentialStoreId string
+	FlagVersion           int
+	FlagFilter            string
+	FlagRecursive         bool
 
 	client *api.Client
 }
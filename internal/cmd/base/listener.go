@@ -37,6 +37,10 @@ type WorkerAuthInfo struct {
 	Name            string `json:"name"`
 	Description     string `json:"description"`
 	ConnectionNonce string `json:"connection_nonce"`
+
+	// Tags are the worker's tags, which its upstream workers match the worker
+	// filters of sessions against.
+	Tags map[string][]string `json:"tags,omitempty"`
 }
 
 // Factory is the factory function to create a listener.
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/config"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/docker"
+	"github.com/hashicorp/boundary/internal/event"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/strutil"
@@ -59,6 +60,8 @@ type Server struct {
 	InmemSink         *metrics.InmemSink
 	PrometheusEnabled bool
 
+	Eventer *event.Eventer
+
 	ReloadFuncsLock *sync.RWMutex
 	ReloadFuncs     map[string][]reloadutil.ReloadFunc
 
@@ -207,6 +210,64 @@ func (b *Server) SetupMetrics(ui cli.Ui, telemetry *configutil.Telemetry) error
 	return nil
 }
 
+// SetupEventing creates the eventer for the sinks in the events stanza of the
+// config. If no events stanza is present, b.Eventer is left nil and events
+// are discarded.
+func (b *Server) SetupEventing(events *config.Events) error {
+	if events == nil || len(events.Sinks) == 0 {
+		return nil
+	}
+
+	conf := event.Config{}
+	conf.Source, _ = os.Hostname()
+	if events.HmacKey != "" {
+		hmacKey, err := config.ParseAddress(events.HmacKey)
+		if err != nil && err != config.ErrNotAUrl {
+			return fmt.Errorf("Error parsing event hmac key: %w", err)
+		}
+		if hmacKey == "" {
+			return errors.New("Event hmac key is empty")
+		}
+		conf.HmacKey = []byte(hmacKey)
+	}
+	if events.ChainKey != "" {
+		chainKey, err := config.ParseAddress(events.ChainKey)
+		if err != nil && err != config.ErrNotAUrl {
+			return fmt.Errorf("Error parsing event chain key: %w", err)
+		}
+		if chainKey == "" {
+			return errors.New("Event chain key is empty")
+		}
+		conf.ChainKey = []byte(chainKey)
+	}
+
+	sinkTypes := make([]string, 0, len(events.Sinks))
+	for _, s := range events.Sinks {
+		sc := event.SinkConfig{
+			Type:    event.SinkType(s.Type),
+			Path:    s.Path,
+			Network: s.Network,
+			Address: s.Address,
+		}
+		for _, t := range s.EventTypes {
+			sc.EventTypes = append(sc.EventTypes, event.Type(t))
+		}
+		conf.Sinks = append(conf.Sinks, sc)
+		sinkTypes = append(sinkTypes, s.Type)
+	}
+
+	eventer, err := event.NewEventer(b.Logger.Named("event"), conf)
+	if err != nil {
+		return fmt.Errorf("Error setting up eventing: %w", err)
+	}
+	b.Eventer = eventer
+	b.ShutdownFuncs = append(b.ShutdownFuncs, eventer.Close)
+
+	b.InfoKeys = append(b.InfoKeys, "event sinks")
+	b.Info["event sinks"] = strings.Join(sinkTypes, ", ")
+	return nil
+}
+
 func (b *Server) PrintInfo(ui cli.Ui) {
 	verInfo := version.Get()
 	if verInfo.Version != "" {
@@ -0,0 +1,70 @@
+package base
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// V1WorkerAuthConfig decrypts, with the worker-auth KMS wrapper, the
+// WorkerAuthInfo a worker sent in the "v1workerauth-" ALPN protos of its TLS
+// hello. The returned TLS config presents the worker's own certificate back to
+// it, which proves to the worker that the other end holds the same KMS, and
+// requires the worker to present it as its client certificate.
+func V1WorkerAuthConfig(ctx context.Context, wrapper wrapping.Wrapper, protos []string) (*tls.Config, *WorkerAuthInfo, error) {
+	var firstMatchProto string
+	var encString string
+	for _, p := range protos {
+		if strings.HasPrefix(p, "v1workerauth-") {
+			// Strip that and the number
+			encString += strings.TrimPrefix(p, "v1workerauth-")[3:]
+			if firstMatchProto == "" {
+				firstMatchProto = p
+			}
+		}
+	}
+	if firstMatchProto == "" {
+		return nil, nil, errors.New("no matching proto found")
+	}
+	marshaledEncInfo, err := base64.RawStdEncoding.DecodeString(encString)
+	if err != nil {
+		return nil, nil, err
+	}
+	encInfo := new(wrapping.EncryptedBlobInfo)
+	if err := proto.Unmarshal(marshaledEncInfo, encInfo); err != nil {
+		return nil, nil, err
+	}
+	marshaledInfo, err := wrapper.Decrypt(ctx, encInfo, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	info := new(WorkerAuthInfo)
+	if err := json.Unmarshal(marshaledInfo, info); err != nil {
+		return nil, nil, err
+	}
+
+	rootCAs := x509.NewCertPool()
+	if ok := rootCAs.AppendCertsFromPEM(info.CertPEM); !ok {
+		return nil, info, errors.New("unable to add ca cert to cert pool")
+	}
+	tlsCert, err := tls.X509KeyPair(info.CertPEM, info.KeyPEM)
+	if err != nil {
+		return nil, info, err
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{tlsCert},
+		ClientCAs:    rootCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		NextProtos:   []string{firstMatchProto},
+		MinVersion:   tls.VersionTLS13,
+	}
+
+	return tlsConfig, info, nil
+}
@@ -12,12 +12,15 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/commands/authtokens"
 	"github.com/hashicorp/boundary/internal/cmd/commands/config"
 	"github.com/hashicorp/boundary/internal/cmd/commands/connect"
+	"github.com/hashicorp/boundary/internal/cmd/commands/credentiallibraries"
+	"github.com/hashicorp/boundary/internal/cmd/commands/credentialstores"
 	"github.com/hashicorp/boundary/internal/cmd/commands/database"
 	"github.com/hashicorp/boundary/internal/cmd/commands/dev"
 	"github.com/hashicorp/boundary/internal/cmd/commands/groups"
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostcatalogs"
 	"github.com/hashicorp/boundary/internal/cmd/commands/hosts"
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostsets"
+	"github.com/hashicorp/boundary/internal/cmd/commands/oplog"
 	"github.com/hashicorp/boundary/internal/cmd/commands/roles"
 	"github.com/hashicorp/boundary/internal/cmd/commands/scopes"
 	"github.com/hashicorp/boundary/internal/cmd/commands/server"
@@ -70,6 +73,16 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Command: base.NewCommand(ui),
 			}, nil
 		},
+		"authenticate oidc": func() (cli.Command, error) {
+			return &authenticate.OidcCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"authenticate ldap": func() (cli.Command, error) {
+			return &authenticate.LdapCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 
 		"accounts": func() (cli.Command, error) {
 			return &accounts.Command{
@@ -118,6 +131,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"accounts create ldap": func() (cli.Command, error) {
+			return &accounts.LdapCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"accounts update": func() (cli.Command, error) {
 			return &accounts.Command{
 				Command: base.NewCommand(ui),
@@ -130,6 +149,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"accounts update ldap": func() (cli.Command, error) {
+			return &accounts.LdapCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 
 		"auth-methods": func() (cli.Command, error) {
 			return &authmethods.Command{
@@ -166,6 +191,18 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"auth-methods create oidc": func() (cli.Command, error) {
+			return &authmethods.OidcCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"auth-methods create ldap": func() (cli.Command, error) {
+			return &authmethods.LdapCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"auth-methods update": func() (cli.Command, error) {
 			return &authmethods.Command{
 				Command: base.NewCommand(ui),
@@ -178,6 +215,18 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"auth-methods update oidc": func() (cli.Command, error) {
+			return &authmethods.OidcCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"auth-methods update ldap": func() (cli.Command, error) {
+			return &authmethods.LdapCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 
 		"auth-tokens": func() (cli.Command, error) {
 			return &authtokens.Command{
@@ -276,6 +325,102 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"credential-libraries": func() (cli.Command, error) {
+			return &credentiallibraries.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"credential-libraries read": func() (cli.Command, error) {
+			return &credentiallibraries.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"credential-libraries delete": func() (cli.Command, error) {
+			return &credentiallibraries.Command{
+				Command: base.NewCommand(ui),
+				Func:    "delete",
+			}, nil
+		},
+		"credential-libraries list": func() (cli.Command, error) {
+			return &credentiallibraries.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"credential-libraries create": func() (cli.Command, error) {
+			return &credentiallibraries.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"credential-libraries create static": func() (cli.Command, error) {
+			return &credentiallibraries.StaticCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"credential-libraries update": func() (cli.Command, error) {
+			return &credentiallibraries.Command{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"credential-libraries update static": func() (cli.Command, error) {
+			return &credentiallibraries.StaticCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+
+		"credential-stores": func() (cli.Command, error) {
+			return &credentialstores.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"credential-stores read": func() (cli.Command, error) {
+			return &credentialstores.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"credential-stores delete": func() (cli.Command, error) {
+			return &credentialstores.Command{
+				Command: base.NewCommand(ui),
+				Func:    "delete",
+			}, nil
+		},
+		"credential-stores list": func() (cli.Command, error) {
+			return &credentialstores.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"credential-stores create": func() (cli.Command, error) {
+			return &credentialstores.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"credential-stores create static": func() (cli.Command, error) {
+			return &credentialstores.StaticCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"credential-stores update": func() (cli.Command, error) {
+			return &credentialstores.Command{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"credential-stores update static": func() (cli.Command, error) {
+			return &credentialstores.StaticCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+
 		"database": func() (cli.Command, error) {
 			return &database.Command{
 				Command: base.NewCommand(ui),
@@ -286,6 +431,11 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Command: base.NewCommand(ui),
 			}, nil
 		},
+		"database replay": func() (cli.Command, error) {
+			return &database.ReplayCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 
 		"groups": func() (cli.Command, error) {
 			return &groups.Command{
@@ -376,6 +526,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"host-catalogs create inventory": func() (cli.Command, error) {
+			return &hostcatalogs.InventoryCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"host-catalogs update": func() (cli.Command, error) {
 			return &hostcatalogs.Command{
 				Command: base.NewCommand(ui),
@@ -388,6 +544,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"host-catalogs update inventory": func() (cli.Command, error) {
+			return &hostcatalogs.InventoryCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 
 		"host-sets": func() (cli.Command, error) {
 			return &hostsets.Command{
@@ -424,6 +586,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"host-sets create inventory": func() (cli.Command, error) {
+			return &hostsets.InventoryCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"host-sets update": func() (cli.Command, error) {
 			return &hostsets.Command{
 				Command: base.NewCommand(ui),
@@ -436,6 +604,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"host-sets update inventory": func() (cli.Command, error) {
+			return &hostsets.InventoryCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 		"host-sets add-hosts": func() (cli.Command, error) {
 			return &hostsets.Command{
 				Command: base.NewCommand(ui),
@@ -503,6 +677,30 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"oplog": func() (cli.Command, error) {
+			return &oplog.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"oplog list": func() (cli.Command, error) {
+			return &oplog.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"oplog export": func() (cli.Command, error) {
+			return &oplog.Command{
+				Command: base.NewCommand(ui),
+				Func:    "export",
+			}, nil
+		},
+		"oplog watch": func() (cli.Command, error) {
+			return &oplog.Command{
+				Command: base.NewCommand(ui),
+				Func:    "watch",
+			}, nil
+		},
+
 		"roles": func() (cli.Command, error) {
 			return &roles.Command{
 				Command: base.NewCommand(ui),
@@ -610,6 +808,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "list",
 			}, nil
 		},
+		"scopes rotate-keys": func() (cli.Command, error) {
+			return &scopes.Command{
+				Command: base.NewCommand(ui),
+				Func:    "rotate-keys",
+			}, nil
+		},
 
 		"sessions": func() (cli.Command, error) {
 			return &sessions.Command{
@@ -676,6 +880,18 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"targets create udp": func() (cli.Command, error) {
+			return &targets.UdpCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"targets create ssh": func() (cli.Command, error) {
+			return &targets.SshCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"targets update": func() (cli.Command, error) {
 			return &targets.Command{
 				Command: base.NewCommand(ui),
@@ -688,6 +904,18 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"targets update udp": func() (cli.Command, error) {
+			return &targets.UdpCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"targets update ssh": func() (cli.Command, error) {
+			return &targets.SshCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 		"targets add-host-sets": func() (cli.Command, error) {
 			return &targets.Command{
 				Command: base.NewCommand(ui),
@@ -706,6 +934,24 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "set-host-sets",
 			}, nil
 		},
+		"targets add-credential-libraries": func() (cli.Command, error) {
+			return &targets.Command{
+				Command: base.NewCommand(ui),
+				Func:    "add-credential-libraries",
+			}, nil
+		},
+		"targets remove-credential-libraries": func() (cli.Command, error) {
+			return &targets.Command{
+				Command: base.NewCommand(ui),
+				Func:    "remove-credential-libraries",
+			}, nil
+		},
+		"targets set-credential-libraries": func() (cli.Command, error) {
+			return &targets.Command{
+				Command: base.NewCommand(ui),
+				Func:    "set-credential-libraries",
+			}, nil
+		},
 
 		"users": func() (cli.Command, error) {
 			return &users.Command{
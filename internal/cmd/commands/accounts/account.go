@@ -44,7 +44,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":            {"id"},
 	"delete":          {"id"},
-	"list":            {"auth-method-id"},
+	"list":            {"auth-method-id", "filter"},
 	"set-password":    {"id", "password", "version"},
 	"change-password": {"id", "current-password", "new-password", "version"},
 }
@@ -75,6 +75,10 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary accounts create password -name prodops -description "For ProdOps usage"`,
 			"",
+			"    Create an ldap-type account:",
+			"",
+			`      $ boundary accounts create ldap -auth-method-id amldap_1234567890 -login-name jdoe`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	case "update":
@@ -87,6 +91,10 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary accounts update password -id apw_1234567890 -name devops -description "For DevOps usage"`,
 			"",
+			"    Update an ldap-type account:",
+			"",
+			`      $ boundary accounts update ldap -id aldap_1234567890 -login-name jdoe2`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	case "set-password":
@@ -271,6 +279,9 @@ func (c *Command) Run(args []string) int {
 			err = nil
 		}
 	case "list":
+		if c.FlagFilter != "" {
+			opts = append(opts, accounts.WithFilter(c.FlagFilter))
+		}
 		listResult, err = accountClient.List(c.Context, c.FlagAuthMethodId, opts...)
 	case "set-password":
 		result, err = accountClient.SetPassword(c.Context, c.FlagId, c.flagPassword, version, opts...)
@@ -35,6 +35,14 @@ func generateAccountTableOutput(in *accounts.Account) string {
 		base.ScopeInfoForOutput(in.Scope, maxLength),
 	}
 
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
 	if len(in.Attributes) > 0 {
 		ret = append(ret,
 			"",
@@ -47,5 +55,11 @@ func generateAccountTableOutput(in *accounts.Account) string {
 }
 
 var keySubstMap = map[string]string{
-	"login_name": "Login Name",
+	"login_name":       "Login Name",
+	"issuer":           "Issuer",
+	"subject":          "Subject",
+	"full_name":        "Full Name",
+	"email":            "Email",
+	"dn":               "DN",
+	"member_of_groups": "Member Of Groups",
 }
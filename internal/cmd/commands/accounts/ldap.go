@@ -0,0 +1,202 @@
+package accounts
+
+import (
+	"fmt"
+	"net/textproto"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/accounts"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*LdapCommand)(nil)
+var _ cli.CommandAutocomplete = (*LdapCommand)(nil)
+
+type LdapCommand struct {
+	*base.Command
+
+	Func string
+
+	flagLoginName string
+}
+
+func (c *LdapCommand) Synopsis() string {
+	return fmt.Sprintf("%s an ldap-type account", textproto.CanonicalMIMEHeaderKey(c.Func))
+}
+
+var ldapFlagsMap = map[string][]string{
+	"create": {"auth-method-id", "name", "description", "login-name"},
+	"update": {"id", "name", "description", "version", "login-name"},
+}
+
+func (c *LdapCommand) Help() string {
+	var info string
+	switch c.Func {
+	case "create":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary accounts create ldap [options] [args]",
+			"",
+			"  Create an ldap-type account. Example:",
+			"",
+			`    $ boundary accounts create ldap -auth-method-id amldap_1234567890 -login-name jdoe -description "LDAP account for J. Doe"`,
+			"",
+			"",
+		})
+
+	case "update":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary accounts update ldap [options] [args]",
+			"",
+			"  Update an ldap-type account given its ID. Example:",
+			"",
+			`    $ boundary accounts update ldap -id aldap_1234567890 -login-name jdoe2`,
+			"",
+			"",
+		})
+	}
+	return info + c.Flags().Help()
+}
+
+func (c *LdapCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	if len(ldapFlagsMap[c.Func]) > 0 {
+		common.PopulateCommonFlags(c.Command, f, "ldap-type account", ldapFlagsMap[c.Func])
+	}
+
+	f = set.NewFlagSet("LDAP Account Options")
+
+	for _, name := range ldapFlagsMap[c.Func] {
+		switch name {
+		case "login-name":
+			f.StringVar(&base.StringVar{
+				Name:   "login-name",
+				Target: &c.flagLoginName,
+				Usage:  "The login name of the directory user the account is for",
+			})
+		}
+	}
+
+	return set
+}
+
+func (c *LdapCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *LdapCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *LdapCommand) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(ldapFlagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(ldapFlagsMap[c.Func], "auth-method-id") && c.FlagAuthMethodId == "" {
+		c.UI.Error("Auth Method ID must be passed in via -auth-method-id")
+		return 1
+	}
+	if c.Func == "create" && c.flagLoginName == "" {
+		c.UI.Error("Login Name must be passed in via -login-name")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []accounts.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, accounts.DefaultName())
+	default:
+		opts = append(opts, accounts.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, accounts.DefaultDescription())
+	default:
+		opts = append(opts, accounts.WithDescription(c.FlagDescription))
+	}
+
+	switch c.flagLoginName {
+	case "":
+	case "null":
+		opts = append(opts, accounts.DefaultLdapAccountLoginName())
+	default:
+		opts = append(opts, accounts.WithLdapAccountLoginName(c.flagLoginName))
+	}
+
+	accountClient := accounts.NewClient(client)
+
+	// Perform check-and-set when needed
+	var version uint32
+	switch c.Func {
+	case "create":
+		// These don't update so don't need the existing version
+	default:
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, accounts.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	var result api.GenericResult
+
+	switch c.Func {
+	case "create":
+		result, err = accountClient.Create(c.Context, c.FlagAuthMethodId, opts...)
+	case "update":
+		result, err = accountClient.Update(c.Context, c.FlagId, version, opts...)
+	}
+
+	plural := "ldap-type account"
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	account := result.GetItem().(*accounts.Account)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateAccountTableOutput(account))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(account)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
@@ -26,6 +26,14 @@ func (c *Command) Help() string {
 		"",
 		"      $ boundary authenticate password -auth-method-id ampw_1234567890 -login-name foo -password \"bar\"",
 		"",
+		"    Authenticate with oidc auth method:",
+		"",
+		"      $ boundary authenticate oidc -auth-method-id amoidc_1234567890",
+		"",
+		"    Authenticate with ldap auth method:",
+		"",
+		"      $ boundary authenticate ldap -auth-method-id amldap_1234567890 -login-name foo -password \"bar\"",
+		"",
 		"  Please see the auth method subcommand help for detailed usage information.",
 	})
 }
@@ -0,0 +1,249 @@
+package authenticate
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/authmethods"
+	"github.com/hashicorp/boundary/api/authtokens"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*OidcCommand)(nil)
+var _ cli.CommandAutocomplete = (*OidcCommand)(nil)
+
+const (
+	defaultCallbackAddress = "127.0.0.1:9210"
+	callbackPath           = "/callback"
+
+	// callbackTimeout is how long the command waits for the provider to
+	// redirect the browser back to the callback listener.
+	callbackTimeout = 5 * time.Minute
+)
+
+type OidcCommand struct {
+	*base.Command
+
+	flagCallbackAddress string
+}
+
+func (c *OidcCommand) Synopsis() string {
+	return wordwrap.WrapString("Invoke the oidc auth method to authenticate with Boundary", base.TermWidth)
+}
+
+func (c *OidcCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary authenticate oidc [options] [args]",
+		"",
+		"  Invoke the oidc auth method to authenticate the Boundary CLI. A browser is opened to the provider's login page and the command waits for the provider to redirect back to a listener on the callback address:",
+		"",
+		`    $ boundary authenticate oidc -auth-method-id amoidc_1234567890`,
+		"",
+		fmt.Sprintf("  The redirect URL, http://%s%s by default, must be registered with the provider.", defaultCallbackAddress, callbackPath),
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *OidcCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "auth-method-id",
+		EnvVar: "BOUNDARY_AUTH_METHOD_ID",
+		Target: &c.FlagAuthMethodId,
+		Usage:  "The auth-method resource to use for the operation",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:    "callback-address",
+		Target:  &c.flagCallbackAddress,
+		Default: defaultCallbackAddress,
+		EnvVar:  "BOUNDARY_AUTHENTICATE_OIDC_CALLBACK_ADDRESS",
+		Usage:   "The local address to listen on for the provider's redirect",
+	})
+
+	return set
+}
+
+func (c *OidcCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *OidcCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *OidcCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.FlagAuthMethodId == "" {
+		c.UI.Error("Auth method ID must be provided via -auth-method-id")
+		return 1
+	}
+
+	client, err := c.Client(base.WithNoTokenScope(), base.WithNoTokenValue())
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	ln, err := net.Listen("tcp", c.flagCallbackAddress)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error listening for the provider's redirect on %q: %s", c.flagCallbackAddress, err))
+		return 2
+	}
+	defer ln.Close()
+
+	amClient := authmethods.NewClient(client)
+	redirectUrl := fmt.Sprintf("http://%s%s", ln.Addr().String(), callbackPath)
+	authUrl, err := amClient.StartAuthentication(c.Context, c.FlagAuthMethodId, redirectUrl)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when starting authentication: %s", base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to start authentication: %s", err.Error()))
+		return 2
+	}
+	state, err := authUrlState(authUrl)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error reading the auth URL returned by the controller: %s", err))
+		return 2
+	}
+
+	// The listener is only served once the state is known, so that it never
+	// forwards the code of an attempt this command did not start.
+	callbacks := make(chan oidcCallback, 1)
+	srv := &http.Server{Handler: callbackHandler(state, callbacks)}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	c.UI.Info(fmt.Sprintf("Complete the authentication in your browser. If it does not open, visit:\n\n  %s\n", authUrl))
+	if err := openBrowser(authUrl); err != nil {
+		c.UI.Warn(fmt.Sprintf("Unable to open a browser: %s", err))
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, callbackTimeout)
+	defer cancel()
+
+	var cb oidcCallback
+	select {
+	case cb = <-callbacks:
+	case <-ctx.Done():
+		c.UI.Error("Timed out waiting for the provider to redirect back to the callback listener")
+		return 2
+	}
+	if cb.err != "" {
+		c.UI.Error(fmt.Sprintf("Error returned by the provider: %s", cb.err))
+		return 1
+	}
+
+	// note: Authenticate() calls SetToken() under the hood to set the
+	// auth bearer on the client so we do not need to do anything with the
+	// returned token after this call, so we ignore it
+	result, err := amClient.Authenticate(c.Context, c.FlagAuthMethodId,
+		map[string]interface{}{
+			"state": cb.state,
+			"code":  cb.code,
+		})
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing authentication: %s", base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to perform authentication: %s", err.Error()))
+		return 2
+	}
+
+	return saveAndOrPrintToken(c.Command, result.GetItem().(*authtokens.AuthToken))
+}
+
+// oidcCallback holds the query parameters of the provider's redirect.
+type oidcCallback struct {
+	state string
+	code  string
+	err   string
+}
+
+// authUrlState returns the state parameter of the provider's auth URL.
+func authUrlState(authUrl string) (string, error) {
+	u, err := url.Parse(authUrl)
+	if err != nil {
+		return "", err
+	}
+	state := u.Query().Get("state")
+	if state == "" {
+		return "", fmt.Errorf("no state in %q", authUrl)
+	}
+	return state, nil
+}
+
+// callbackHandler returns a handler which sends the first redirect it
+// receives from the provider for the attempt with state on callbacks.
+// Redirects with any other state are rejected: they were not started by
+// this command, and forwarding them would log the user in with whatever
+// account started them.
+func callbackHandler(state string, callbacks chan<- oidcCallback) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(callbackPath, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		cb := oidcCallback{
+			state: q.Get("state"),
+			code:  q.Get("code"),
+			err:   q.Get("error"),
+		}
+		if desc := q.Get("error_description"); desc != "" {
+			cb.err = fmt.Sprintf("%s: %s", cb.err, desc)
+		}
+		if subtle.ConstantTimeCompare([]byte(cb.state), []byte(state)) != 1 {
+			http.Error(w, "Invalid state.", http.StatusBadRequest)
+			return
+		}
+		if cb.err == "" && cb.code == "" {
+			http.Error(w, "Missing code.", http.StatusBadRequest)
+			return
+		}
+		select {
+		case callbacks <- cb:
+		default:
+		}
+		if cb.err != "" {
+			fmt.Fprintln(w, "Authentication failed. You may close this window and return to the terminal.")
+			return
+		}
+		fmt.Fprintln(w, "Authentication is complete. You may close this window and return to the terminal.")
+	})
+	return mux
+}
+
+// openBrowser opens url in the user's default browser.
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
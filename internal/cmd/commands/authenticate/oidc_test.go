@@ -0,0 +1,77 @@
+package authenticate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallbackHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantCode int
+		want     *oidcCallback
+	}{
+		{
+			name:     "valid",
+			query:    "state=s1&code=c1",
+			wantCode: http.StatusOK,
+			want:     &oidcCallback{state: "s1", code: "c1"},
+		},
+		{
+			name:     "provider-error",
+			query:    "state=s1&error=access_denied&error_description=denied",
+			wantCode: http.StatusOK,
+			want:     &oidcCallback{state: "s1", err: "access_denied: denied"},
+		},
+		{
+			name:     "other-state",
+			query:    "state=attacker&code=c1",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "other-state-error",
+			query:    "state=attacker&error=access_denied",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "missing-state",
+			query:    "code=c1",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "missing-code",
+			query:    "state=s1",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			callbacks := make(chan oidcCallback, 1)
+			rec := httptest.NewRecorder()
+			callbackHandler("s1", callbacks).ServeHTTP(rec, httptest.NewRequest("GET", callbackPath+"?"+tt.query, nil))
+			assert.Equal(tt.wantCode, rec.Code)
+			if tt.want == nil {
+				assert.Len(callbacks, 0)
+				return
+			}
+			require.Len(callbacks, 1)
+			assert.Equal(*tt.want, <-callbacks)
+		})
+	}
+}
+
+func TestAuthUrlState(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	state, err := authUrlState("https://idp.example.com/authorize?client_id=c&state=s1&nonce=n")
+	require.NoError(err)
+	assert.Equal("s1", state)
+
+	_, err = authUrlState("https://idp.example.com/authorize?client_id=c")
+	assert.Error(err)
+}
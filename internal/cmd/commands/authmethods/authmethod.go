@@ -30,7 +30,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":   {"id"},
 	"delete": {"id"},
-	"list":   {"scope-id"},
+	"list":   {"scope-id", "filter", "recursive"},
 }
 
 func (c *Command) Help() string {
@@ -59,6 +59,14 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary auth-methods create password -name prodops -description "For ProdOps usage"`,
 			"",
+			"    Create an oidc-type auth method:",
+			"",
+			`      $ boundary auth-methods create oidc -name sso -discovery-url https://sso.example.com -client-id boundary -client-secret "$CLIENT_SECRET"`,
+			"",
+			"    Create an ldap-type auth method:",
+			"",
+			`      $ boundary auth-methods create ldap -name corp -url ldaps://ldap.example.com -user-dn ou=people,dc=example,dc=com`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	case "update":
@@ -71,6 +79,14 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary auth-methods update password -id ampw_1234567890 -name devops -description "For DevOps usage"`,
 			"",
+			"    Update an oidc-type auth method:",
+			"",
+			`      $ boundary auth-methods update oidc -id amoidc_1234567890 -allowed-audience boundary`,
+			"",
+			"    Update an ldap-type auth method:",
+			"",
+			`      $ boundary auth-methods update ldap -id amldap_1234567890 -sync-groups true`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	default:
@@ -161,6 +177,12 @@ func (c *Command) Run(args []string) int {
 			err = nil
 		}
 	case "list":
+		if c.FlagFilter != "" {
+			opts = append(opts, authmethods.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, authmethods.WithRecursive(true))
+		}
 		listResult, err = authmethodClient.List(c.Context, c.FlagScopeId, opts...)
 	}
 
@@ -228,6 +250,11 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("  ID:             %s", m.Id),
 					)
 				}
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:     %s", m.ScopeId),
+					)
+				}
 				if m.Description != "" {
 					output = append(output,
 						fmt.Sprintf("    Description:  %s", m.Description),
@@ -20,6 +20,102 @@ func addPasswordFlags(c *PasswordCommand, f *base.FlagSet) {
 	})
 }
 
+func addOidcFlags(c *OidcCommand, f *base.FlagSet) {
+	f.StringVar(&base.StringVar{
+		Name:   "discovery-url",
+		Target: &c.flagDiscoveryUrl,
+		Usage:  "The issuer URL of the OIDC provider, used to discover its configuration",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "client-id",
+		Target: &c.flagClientId,
+		Usage:  "The client ID registered with the OIDC provider",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "client-secret",
+		Target: &c.flagClientSecret,
+		Usage:  "The client secret registered with the OIDC provider",
+	})
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:   "allowed-audience",
+		Target: &c.flagAllowedAudiences,
+		Usage:  "An audience of which at least one must be in an ID token's aud claim. May be specified multiple times.",
+	})
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:   "account-claim-map",
+		Target: &c.flagAccountClaimMaps,
+		Usage:  `A mapping of an ID token claim to an account field in the form "claim=field", where field is one of "sub", "name" or "email". May be specified multiple times.`,
+	})
+}
+
+func addLdapFlags(c *LdapCommand, f *base.FlagSet) {
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:   "url",
+		Target: &c.flagUrls,
+		Usage:  "An ldap:// or ldaps:// URL of a directory server. May be specified multiple times; the servers are tried in order.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "start-tls",
+		Target: &c.flagStartTls,
+		Usage:  "Whether to upgrade ldap:// connections to TLS with StartTLS",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "insecure-tls",
+		Target: &c.flagInsecureTls,
+		Usage:  "Whether to skip verifying the certificate chains of the directory servers. Certificate pins are still enforced.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "certificates",
+		Target: &c.flagCertificates,
+		Usage:  "The path to a file with the PEM encoded CA certificates used to verify the directory servers instead of the system roots",
+	})
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:   "certificate-pin",
+		Target: &c.flagCertificatePins,
+		Usage:  "A hex encoded SHA-256 fingerprint one of which a directory server's certificate must match. May be specified multiple times.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "bind-dn",
+		Target: &c.flagBindDn,
+		Usage:  "The distinguished name to bind as when searching for users and groups. If not set, searches are anonymous.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "bind-password",
+		Target: &c.flagBindPassword,
+		Usage:  "The password of the bind distinguished name",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "user-dn",
+		Target: &c.flagUserDn,
+		Usage:  "The base distinguished name of the user search",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "user-filter",
+		Target: &c.flagUserFilter,
+		Usage:  `The filter of the user search, in which "{{login_name}}" is replaced with the login name. Defaults to "(uid={{login_name}})".`,
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "group-dn",
+		Target: &c.flagGroupDn,
+		Usage:  "The base distinguished name of the group search. If not set, groups are not searched.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "group-filter",
+		Target: &c.flagGroupFilter,
+		Usage:  `The filter of the group search, in which "{{user_dn}}" and "{{login_name}}" are replaced with the values of the user. Defaults to "(|(member={{user_dn}})(uniqueMember={{user_dn}}))".`,
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "group-attr",
+		Target: &c.flagGroupAttr,
+		Usage:  `The attribute of group entries holding the group name. Defaults to "cn".`,
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "sync-groups",
+		Target: &c.flagSyncGroups,
+		Usage:  "Whether to add users to the groups of the auth method's scope named after their LDAP groups, and remove them when they leave",
+	})
+}
+
 func generateAuthMethodTableOutput(in *authmethods.AuthMethod) string {
 	nonAttributeMap := map[string]interface{}{
 		"ID":           in.Id,
@@ -47,6 +143,14 @@ func generateAuthMethodTableOutput(in *authmethods.AuthMethod) string {
 		base.ScopeInfoForOutput(in.Scope, maxLength),
 	}
 
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
 	if len(in.Attributes) > 0 {
 		ret = append(ret,
 			"",
@@ -61,4 +165,20 @@ func generateAuthMethodTableOutput(in *authmethods.AuthMethod) string {
 var keySubstMap = map[string]string{
 	"min_login_name_length": "Minimum Login Name Length",
 	"min_password_length":   "Minimum Password Length",
+	"discovery_url":         "Discovery URL",
+	"client_id":             "Client ID",
+	"allowed_audiences":     "Allowed Audiences",
+	"account_claim_maps":    "Account Claim Maps",
+	"urls":                  "URLs",
+	"start_tls":             "StartTLS",
+	"insecure_tls":          "Insecure TLS",
+	"certificates":          "Certificates",
+	"certificate_pins":      "Certificate Pins",
+	"bind_dn":               "Bind DN",
+	"user_dn":               "User DN",
+	"user_filter":           "User Filter",
+	"group_dn":              "Group DN",
+	"group_filter":          "Group Filter",
+	"group_attr":            "Group Attribute",
+	"sync_groups":           "Sync Groups",
 }
@@ -0,0 +1,270 @@
+package authmethods
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/textproto"
+	"strconv"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/authmethods"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*LdapCommand)(nil)
+var _ cli.CommandAutocomplete = (*LdapCommand)(nil)
+
+type LdapCommand struct {
+	*base.Command
+
+	Func string
+
+	flagUrls            []string
+	flagStartTls        string
+	flagInsecureTls     string
+	flagCertificates    string
+	flagCertificatePins []string
+	flagBindDn          string
+	flagBindPassword    string
+	flagUserDn          string
+	flagUserFilter      string
+	flagGroupDn         string
+	flagGroupFilter     string
+	flagGroupAttr       string
+	flagSyncGroups      string
+}
+
+func (c *LdapCommand) Synopsis() string {
+	return fmt.Sprintf("%s an ldap type auth-method", textproto.CanonicalMIMEHeaderKey(c.Func))
+}
+
+var ldapFlagsMap = map[string][]string{
+	"create": {"scope-id", "name", "description"},
+	"update": {"id", "name", "description", "version"},
+}
+
+func (c *LdapCommand) Help() string {
+	var info string
+	switch c.Func {
+	case "create":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods create ldap [options] [args]",
+			"",
+			"  Create an ldap-type auth method. Example:",
+			"",
+			`    $ boundary auth-methods create ldap -name corp -url ldaps://ldap.example.com -user-dn ou=people,dc=example,dc=com -bind-dn cn=boundary,dc=example,dc=com -bind-password "$BIND_PASSWORD"`,
+			"",
+			"",
+		})
+
+	case "update":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods update ldap [options] [args]",
+			"",
+			"  Update an ldap-type auth method given its ID. Example:",
+			"",
+			`    $ boundary auth-methods update ldap -id amldap_1234567890 -group-dn ou=groups,dc=example,dc=com -sync-groups true`,
+			"",
+			"",
+		})
+	}
+	return info + c.Flags().Help()
+}
+
+func (c *LdapCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "ldap-type auth method", ldapFlagsMap[c.Func])
+
+	f = set.NewFlagSet("LDAP Auth-Method Options")
+	addLdapFlags(c, f)
+
+	return set
+}
+
+func (c *LdapCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *LdapCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *LdapCommand) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(ldapFlagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(ldapFlagsMap[c.Func], "scope-id") && c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []authmethods.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, authmethods.DefaultName())
+	default:
+		opts = append(opts, authmethods.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, authmethods.DefaultDescription())
+	default:
+		opts = append(opts, authmethods.WithDescription(c.FlagDescription))
+	}
+
+	var attributes map[string]interface{}
+	addAttribute := func(name string, value interface{}) {
+		if attributes == nil {
+			attributes = make(map[string]interface{})
+		}
+		attributes[name] = value
+	}
+	addList := func(name string, values []string) {
+		switch {
+		case len(values) == 0:
+		case len(values) == 1 && values[0] == "null":
+			addAttribute(name, nil)
+		default:
+			addAttribute(name, values)
+		}
+	}
+	addString := func(name, value string) {
+		switch value {
+		case "":
+		case "null":
+			addAttribute(name, nil)
+		default:
+			addAttribute(name, value)
+		}
+	}
+	addBool := func(name, value string) error {
+		switch value {
+		case "":
+		case "null":
+			addAttribute(name, nil)
+		default:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("Error parsing %q: %w", value, err)
+			}
+			addAttribute(name, b)
+		}
+		return nil
+	}
+
+	addList("urls", c.flagUrls)
+	if err := addBool("start_tls", c.flagStartTls); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if err := addBool("insecure_tls", c.flagInsecureTls); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	switch c.flagCertificates {
+	case "":
+	case "null":
+		addAttribute("certificates", nil)
+	default:
+		pem, err := ioutil.ReadFile(c.flagCertificates)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error reading certificates file %q: %s", c.flagCertificates, err))
+			return 1
+		}
+		addAttribute("certificates", string(pem))
+	}
+	addList("certificate_pins", c.flagCertificatePins)
+	addString("bind_dn", c.flagBindDn)
+	addString("bind_password", c.flagBindPassword)
+	addString("user_dn", c.flagUserDn)
+	addString("user_filter", c.flagUserFilter)
+	addString("group_dn", c.flagGroupDn)
+	addString("group_filter", c.flagGroupFilter)
+	addString("group_attr", c.flagGroupAttr)
+	if err := addBool("sync_groups", c.flagSyncGroups); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if attributes != nil {
+		opts = append(opts, authmethods.WithAttributes(attributes))
+	}
+
+	authmethodClient := authmethods.NewClient(client)
+
+	// Perform check-and-set when needed
+	var version uint32
+	switch c.Func {
+	case "create":
+		// These don't update so don't need the existing version
+	default:
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, authmethods.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	var result api.GenericResult
+
+	switch c.Func {
+	case "create":
+		result, err = authmethodClient.Create(c.Context, "ldap", c.FlagScopeId, opts...)
+	case "update":
+		result, err = authmethodClient.Update(c.Context, c.FlagId, version, opts...)
+	}
+
+	plural := "ldap-type auth-method"
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	method := result.GetItem().(*authmethods.AuthMethod)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateAuthMethodTableOutput(method))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(method)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
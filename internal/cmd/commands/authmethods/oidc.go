@@ -0,0 +1,233 @@
+package authmethods
+
+import (
+	"fmt"
+	"net/textproto"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/authmethods"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*OidcCommand)(nil)
+var _ cli.CommandAutocomplete = (*OidcCommand)(nil)
+
+type OidcCommand struct {
+	*base.Command
+
+	Func string
+
+	flagDiscoveryUrl     string
+	flagClientId         string
+	flagClientSecret     string
+	flagAllowedAudiences []string
+	flagAccountClaimMaps []string
+}
+
+func (c *OidcCommand) Synopsis() string {
+	return fmt.Sprintf("%s an oidc type auth-method", textproto.CanonicalMIMEHeaderKey(c.Func))
+}
+
+var oidcFlagsMap = map[string][]string{
+	"create": {"scope-id", "name", "description"},
+	"update": {"id", "name", "description", "version"},
+}
+
+func (c *OidcCommand) Help() string {
+	var info string
+	switch c.Func {
+	case "create":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods create oidc [options] [args]",
+			"",
+			"  Create an oidc-type auth method. Example:",
+			"",
+			`    $ boundary auth-methods create oidc -name prodops -discovery-url https://sso.example.com -client-id boundary -client-secret "$CLIENT_SECRET"`,
+			"",
+			"",
+		})
+
+	case "update":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods update oidc [options] [args]",
+			"",
+			"  Update an oidc-type auth method given its ID. Example:",
+			"",
+			`    $ boundary auth-methods update oidc -id amoidc_1234567890 -allowed-audience boundary -allowed-audience boundary-dev`,
+			"",
+			"",
+		})
+	}
+	return info + c.Flags().Help()
+}
+
+func (c *OidcCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "oidc-type auth method", oidcFlagsMap[c.Func])
+
+	f = set.NewFlagSet("OIDC Auth-Method Options")
+	addOidcFlags(c, f)
+
+	return set
+}
+
+func (c *OidcCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *OidcCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *OidcCommand) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(oidcFlagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(oidcFlagsMap[c.Func], "scope-id") && c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []authmethods.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, authmethods.DefaultName())
+	default:
+		opts = append(opts, authmethods.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, authmethods.DefaultDescription())
+	default:
+		opts = append(opts, authmethods.WithDescription(c.FlagDescription))
+	}
+
+	var attributes map[string]interface{}
+	addAttribute := func(name string, value interface{}) {
+		if attributes == nil {
+			attributes = make(map[string]interface{})
+		}
+		attributes[name] = value
+	}
+	switch c.flagDiscoveryUrl {
+	case "":
+	case "null":
+		addAttribute("discovery_url", nil)
+	default:
+		addAttribute("discovery_url", c.flagDiscoveryUrl)
+	}
+
+	switch c.flagClientId {
+	case "":
+	case "null":
+		addAttribute("client_id", nil)
+	default:
+		addAttribute("client_id", c.flagClientId)
+	}
+
+	switch c.flagClientSecret {
+	case "":
+	case "null":
+		addAttribute("client_secret", nil)
+	default:
+		addAttribute("client_secret", c.flagClientSecret)
+	}
+
+	switch {
+	case len(c.flagAllowedAudiences) == 0:
+	case len(c.flagAllowedAudiences) == 1 && c.flagAllowedAudiences[0] == "null":
+		addAttribute("allowed_audiences", nil)
+	default:
+		addAttribute("allowed_audiences", c.flagAllowedAudiences)
+	}
+
+	switch {
+	case len(c.flagAccountClaimMaps) == 0:
+	case len(c.flagAccountClaimMaps) == 1 && c.flagAccountClaimMaps[0] == "null":
+		addAttribute("account_claim_maps", nil)
+	default:
+		addAttribute("account_claim_maps", c.flagAccountClaimMaps)
+	}
+
+	if attributes != nil {
+		opts = append(opts, authmethods.WithAttributes(attributes))
+	}
+
+	authmethodClient := authmethods.NewClient(client)
+
+	// Perform check-and-set when needed
+	var version uint32
+	switch c.Func {
+	case "create":
+		// These don't update so don't need the existing version
+	default:
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, authmethods.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	var result api.GenericResult
+
+	switch c.Func {
+	case "create":
+		result, err = authmethodClient.Create(c.Context, "oidc", c.FlagScopeId, opts...)
+	case "update":
+		result, err = authmethodClient.Update(c.Context, c.FlagId, version, opts...)
+	}
+
+	plural := "oidc-type auth-method"
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	method := result.GetItem().(*authmethods.AuthMethod)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateAuthMethodTableOutput(method))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(method)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
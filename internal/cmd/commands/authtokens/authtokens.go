@@ -31,7 +31,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":   {"id"},
 	"delete": {"id"},
-	"list":   {"scope-id"},
+	"list":   {"scope-id", "filter", "recursive"},
 }
 
 func (c *Command) Help() string {
@@ -104,7 +104,14 @@ func (c *Command) Run(args []string) int {
 			err = nil
 		}
 	case "list":
-		listResult, err = authtokenClient.List(c.Context, c.FlagScopeId)
+		var opts []authtokens.Option
+		if c.FlagFilter != "" {
+			opts = append(opts, authtokens.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, authtokens.WithRecursive(true))
+		}
+		listResult, err = authtokenClient.List(c.Context, c.FlagScopeId, opts...)
 	}
 
 	plural := "auth token"
@@ -175,6 +182,11 @@ func (c *Command) Run(args []string) int {
 					fmt.Sprintf("    Updated Time:                %s", t.UpdatedTime.Local().Format(time.RFC1123)),
 					fmt.Sprintf("    User ID:                     %s", t.UserId),
 				)
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:                    %s", t.ScopeId),
+					)
+				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
 		}
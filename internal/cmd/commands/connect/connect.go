@@ -87,7 +87,8 @@ type Command struct {
 	connWg             *sync.WaitGroup
 	listenerCloseOnce  sync.Once
 	listener           *net.TCPListener
-	listenerAddr       *net.TCPAddr
+	udpListener        *net.UDPConn
+	listenerAddr       net.Addr
 	connsLeftCh        chan int32
 	connectionsLeft    atomic.Int32
 	expiration         time.Time
@@ -121,6 +122,8 @@ func (c *Command) Help() string {
 			"",
 			`  This command performs a target authorization (or consumes an existing authorization token) and launches a proxied connection.`,
 			"",
+			`  For targets of type "udp", the local listener is a UDP listener and each datagram it receives is proxied to the endpoint.`,
+			"",
 			"  Example:",
 			"",
 			`      $ boundary connect -target-id ttcp_1234567890"`,
@@ -164,7 +167,7 @@ func (c *Command) Flags() *base.FlagSets {
 	f.StringVar(&base.StringVar{
 		Name:   "host-id",
 		Target: &c.flagHostId,
-		Usage:  "The ID of a specific host to connect to out of the hosts from the target's host sets. If not specified, one is chosen at random.",
+		Usage:  "The ID of a specific host to connect to out of the hosts from the target's host sets. If not specified, one is chosen using the target's host selection strategy. Cannot be used if the target restricts the host ID.",
 	})
 
 	f.StringVar(&base.StringVar{
@@ -348,6 +351,21 @@ func (c *Command) Run(args []string) (retCode int) {
 		return 1
 	}
 
+	sessionType := c.sessionAuthzData.GetType()
+	switch sessionType {
+	case "", "tcp", "ssh":
+		// ssh targets are proxied as tcp
+		sessionType = "tcp"
+	case "udp":
+		if c.Func != "connect" {
+			c.UI.Error(fmt.Sprintf("Targets of type %q cannot be used with the %q connect helper", sessionType, c.Func))
+			return 1
+		}
+	default:
+		c.UI.Error(fmt.Sprintf("Unsupported target type %q found in authorization string", sessionType))
+		return 1
+	}
+
 	c.connectionsLeft.Store(c.sessionAuthzData.ConnectionLimit)
 	workerAddr := c.sessionAuthzData.GetWorkerInfo()[0].GetAddress()
 
@@ -393,10 +411,24 @@ func (c *Command) Run(args []string) (retCode int) {
 	// hijacked, just setting for completeness
 	transport.IdleConnTimeout = 0
 
-	c.listener, err = net.ListenTCP("tcp", &net.TCPAddr{
-		IP:   listenAddr,
-		Port: c.flagListenPort,
-	})
+	switch sessionType {
+	case "udp":
+		c.udpListener, err = net.ListenUDP("udp", &net.UDPAddr{
+			IP:   listenAddr,
+			Port: c.flagListenPort,
+		})
+		if err == nil {
+			c.listenerAddr = c.udpListener.LocalAddr()
+		}
+	default:
+		c.listener, err = net.ListenTCP("tcp", &net.TCPAddr{
+			IP:   listenAddr,
+			Port: c.flagListenPort,
+		})
+		if err == nil {
+			c.listenerAddr = c.listener.Addr()
+		}
+	}
 	if err != nil {
 		c.UI.Error(fmt.Errorf("Error starting listening port: %w", err).Error())
 		return 1
@@ -405,7 +437,14 @@ func (c *Command) Run(args []string) (retCode int) {
 	listenerCloseFunc := func() {
 		// Forces the for loop to exist instead of spinning on errors
 		c.connectionsLeft.Store(0)
-		if err := c.listener.Close(); err != nil {
+		var err error
+		switch {
+		case c.udpListener != nil:
+			err = c.udpListener.Close()
+		default:
+			err = c.listener.Close()
+		}
+		if err != nil {
 			c.UI.Error(fmt.Errorf("Error closing listener on shutdown: %w", err).Error())
 			retCode = 1
 		}
@@ -416,13 +455,13 @@ func (c *Command) Run(args []string) (retCode int) {
 		c.listenerCloseOnce.Do(listenerCloseFunc)
 	}()
 
-	c.listenerAddr = c.listener.Addr().(*net.TCPAddr)
+	listenerIp, listenerPort := c.listenerIpPort()
 
 	if c.flagExec == "" {
 		sessInfo := SessionInfo{
-			Protocol:        "tcp",
-			Address:         c.listenerAddr.IP.String(),
-			Port:            c.listenerAddr.Port,
+			Protocol:        sessionType,
+			Address:         listenerIp.String(),
+			Port:            listenerPort,
 			Expiration:      c.expiration,
 			ConnectionLimit: c.sessionAuthzData.GetConnectionLimit(),
 			SessionId:       c.sessionAuthzData.GetSessionId(),
@@ -446,6 +485,10 @@ func (c *Command) Run(args []string) (retCode int) {
 	c.connWg.Add(1)
 	go func() {
 		defer c.connWg.Done()
+		if c.udpListener != nil {
+			c.handleUdpListener(workerAddr, tofuToken, transport)
+			return
+		}
 		for {
 			listeningConn, err := c.listener.AcceptTCP()
 			if err != nil {
@@ -496,7 +539,9 @@ func (c *Command) Run(args []string) (retCode int) {
 				return
 			case connsLeft := <-c.connsLeftCh:
 				c.updateConnsLeft(connsLeft)
-				if connsLeft == 0 {
+				// The udp listener stays open while its connection lives;
+				// handleUdpListener stops the proxy once it ends
+				if connsLeft == 0 && c.udpListener == nil {
 					return
 				}
 			}
@@ -557,6 +602,42 @@ func (c *Command) handleConnection(
 
 	defer c.connWg.Done()
 
+	conn, err := c.dialWorker(workerAddr, tofuToken, transport, globals.TcpProxyV1)
+	if err != nil {
+		return err
+	}
+
+	// Get a wrapped net.Conn so we can use io.Copy
+	netConn := websocket.NetConn(c.proxyCtx, conn, websocket.MessageBinary)
+
+	localWg := new(sync.WaitGroup)
+	localWg.Add(2)
+
+	go func() {
+		defer localWg.Done()
+		io.Copy(netConn, listeningConn)
+		netConn.Close()
+		listeningConn.Close()
+	}()
+	go func() {
+		defer localWg.Done()
+		io.Copy(listeningConn, netConn)
+		listeningConn.Close()
+		netConn.Close()
+	}()
+	localWg.Wait()
+
+	return nil
+}
+
+// dialWorker opens a websocket to the worker using the given subprotocol and
+// performs the proxy handshake, returning the connection ready to carry data.
+func (c *Command) dialWorker(
+	workerAddr string,
+	tofuToken string,
+	transport *http.Transport,
+	subprotocol string) (*websocket.Conn, error) {
+
 	conn, resp, err := websocket.Dial(
 		c.proxyCtx,
 		fmt.Sprintf("wss://%s/v1/proxy", workerAddr),
@@ -564,34 +645,34 @@ func (c *Command) handleConnection(
 			HTTPClient: &http.Client{
 				Transport: transport,
 			},
-			Subprotocols: []string{globals.TcpProxyV1},
+			Subprotocols: []string{subprotocol},
 		},
 	)
 	if err != nil {
 		switch {
 		case strings.Contains(err.Error(), "tls: internal error"):
-			return errors.New("Session is unauthorized")
+			return nil, errors.New("Session is unauthorized")
 		case strings.Contains(err.Error(), "connect: connection refused"):
-			return fmt.Errorf("Unable to connect to worker at %s", workerAddr)
+			return nil, fmt.Errorf("Unable to connect to worker at %s", workerAddr)
 		default:
-			return fmt.Errorf("Error dialing the worker: %w", err)
+			return nil, fmt.Errorf("Error dialing the worker: %w", err)
 		}
 	}
 
 	if resp == nil {
-		return errors.New("Response from worker is nil")
+		return nil, errors.New("Response from worker is nil")
 	}
 	if resp.Header == nil {
-		return errors.New("Response header is nil")
+		return nil, errors.New("Response header is nil")
 	}
 	negProto := resp.Header.Get("Sec-WebSocket-Protocol")
-	if negProto != globals.TcpProxyV1 {
-		return fmt.Errorf("Unexpected negotiated protocol: %s", negProto)
+	if negProto != subprotocol {
+		return nil, fmt.Errorf("Unexpected negotiated protocol: %s", negProto)
 	}
 
 	handshake := proxy.ClientHandshake{TofuToken: tofuToken}
 	if err := wspb.Write(c.proxyCtx, conn, &handshake); err != nil {
-		return fmt.Errorf("error sending handshake to worker: %w", err)
+		return nil, fmt.Errorf("error sending handshake to worker: %w", err)
 	}
 	var handshakeResult proxy.HandshakeResult
 	if err := wspb.Read(c.proxyCtx, conn, &handshakeResult); err != nil {
@@ -599,44 +680,38 @@ func (c *Command) handleConnection(
 		case strings.Contains(err.Error(), "unable to authorize connection"):
 			// There's no reason to think we'd be able to authorize any more
 			// connections after the first has failed
+			c.connectionsLeft.Store(0)
 			c.connsLeftCh <- 0
-			return errors.New("Unable to authorize connection")
+			return nil, errors.New("Unable to authorize connection")
 		}
 		switch {
 		case strings.Contains(err.Error(), "tofu token not allowed"):
 			// Nothing will be able to be done here, so cancel the context too
 			c.proxyCancel()
-			return errors.New("Session is already in use")
+			return nil, errors.New("Session is already in use")
 		default:
-			return fmt.Errorf("error reading handshake result: %w", err)
+			return nil, fmt.Errorf("error reading handshake result: %w", err)
 		}
 	}
 
 	if handshakeResult.GetConnectionsLeft() != -1 {
+		// Stored here as well so the caller sees the count once this returns
+		c.connectionsLeft.Store(handshakeResult.GetConnectionsLeft())
 		c.connsLeftCh <- handshakeResult.GetConnectionsLeft()
 	}
 
-	// Get a wrapped net.Conn so we can use io.Copy
-	netConn := websocket.NetConn(c.proxyCtx, conn, websocket.MessageBinary)
-
-	localWg := new(sync.WaitGroup)
-	localWg.Add(2)
-
-	go func() {
-		defer localWg.Done()
-		io.Copy(netConn, listeningConn)
-		netConn.Close()
-		listeningConn.Close()
-	}()
-	go func() {
-		defer localWg.Done()
-		io.Copy(listeningConn, netConn)
-		listeningConn.Close()
-		netConn.Close()
-	}()
-	localWg.Wait()
+	return conn, nil
+}
 
-	return nil
+// listenerIpPort returns the IP and port the local listener is bound to.
+func (c *Command) listenerIpPort() (net.IP, int) {
+	switch addr := c.listenerAddr.(type) {
+	case *net.TCPAddr:
+		return addr.IP, addr.Port
+	case *net.UDPAddr:
+		return addr.IP, addr.Port
+	}
+	return nil, 0
 }
 
 func (c *Command) updateConnsLeft(connsLeft int32) {
@@ -660,28 +735,49 @@ func (c *Command) updateConnsLeft(connsLeft int32) {
 	}
 }
 
+// brokeredCredential returns the first credential brokered to the session, or
+// nil if the target has no credential libraries.
+func (c *Command) brokeredCredential() *targetspb.SessionCredential {
+	creds := c.sessionAuthzData.GetCredentials()
+	if len(creds) == 0 {
+		return nil
+	}
+	return creds[0]
+}
+
 func (c *Command) handleExec(passthroughArgs []string) {
 	defer c.connWg.Done()
 	defer c.proxyCancel()
 
-	port := strconv.Itoa(c.listenerAddr.Port)
-	ip := c.listenerAddr.IP.String()
+	listenerIp, listenerPort := c.listenerIpPort()
+	port := strconv.Itoa(listenerPort)
+	ip := listenerIp.String()
 	addr := c.listenerAddr.String()
 
 	var args []string
+	var envs []string
 
 	switch c.Func {
 	case "http":
 		args = append(args, c.httpFlags.buildArgs(c, port, ip, addr)...)
 
 	case "postgres":
-		args = append(args, c.postgresFlags.buildArgs(c, port, ip, addr)...)
+		pgArgs, pgEnvs := c.postgresFlags.buildArgs(c, port, ip, addr)
+		args = append(args, pgArgs...)
+		envs = append(envs, pgEnvs...)
 
 	case "rdp":
 		args = append(args, c.rdpFlags.buildArgs(c, port, ip, addr)...)
 
 	case "ssh":
-		args = append(args, c.sshFlags.buildArgs(c, port, ip, addr)...)
+		sshArgs, cleanup, err := c.sshFlags.buildArgs(c, port, ip, addr)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Failed to build ssh arguments: %s", err))
+			c.execCmdReturnValue.Store(2)
+			return
+		}
+		defer cleanup()
+		args = append(args, sshArgs...)
 	}
 
 	args = append(passthroughArgs, args...)
@@ -713,6 +809,7 @@ func (c *Command) handleExec(passthroughArgs []string) {
 		fmt.Sprintf("BOUNDARY_PROXIED_IP=%s", ip),
 		fmt.Sprintf("BOUNDARY_PROXIED_ADDR=%s", addr),
 	)
+	cmd.Env = append(cmd.Env, envs...)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -1,6 +1,7 @@
 package connect
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
@@ -28,7 +29,7 @@ func postgresOptions(c *Command, set *base.FlagSets) {
 		Target:     &c.flagUsername,
 		EnvVar:     "BOUNDARY_CONNECT_USERNAME",
 		Completion: complete.PredictNothing,
-		Usage:      `Specifies the username to pass through to the client`,
+		Usage:      `Specifies the username to pass through to the client. Defaults to the username of the credential brokered to the session, if any.`,
 	})
 }
 
@@ -40,14 +41,21 @@ func (p *postgresFlags) defaultExec() string {
 	return strings.ToLower(p.flagPostgresStyle)
 }
 
-func (p *postgresFlags) buildArgs(c *Command, port, ip, addr string) []string {
-	var args []string
+func (p *postgresFlags) buildArgs(c *Command, port, ip, addr string) (args, envs []string) {
+	username := c.flagUsername
+	cred := c.brokeredCredential()
+	if username == "" && cred != nil {
+		username = cred.GetUsername()
+	}
 	switch p.flagPostgresStyle {
 	case "psql":
 		args = append(args, "-p", port, "-h", ip)
-		if c.flagUsername != "" {
-			args = append(args, "-U", c.flagUsername)
+		if username != "" {
+			args = append(args, "-U", username)
+		}
+		if cred != nil && cred.GetPassword() != "" {
+			envs = append(envs, fmt.Sprintf("PGPASSWORD=%s", cred.GetPassword()))
 		}
 	}
-	return args
+	return args, envs
 }
@@ -2,10 +2,13 @@ package connect
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"strings"
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/posener/complete"
+	"golang.org/x/crypto/ssh"
 )
 
 const (
@@ -29,7 +32,7 @@ func sshOptions(c *Command, set *base.FlagSets) {
 		Target:     &c.flagUsername,
 		EnvVar:     "BOUNDARY_CONNECT_USERNAME",
 		Completion: complete.PredictNothing,
-		Usage:      `Specifies the username to pass through to the client`,
+		Usage:      `Specifies the username to pass through to the client. Defaults to the username of the credential brokered to the session or, for targets of type "ssh", the first principal of the session's certificate.`,
 	})
 }
 
@@ -41,18 +44,88 @@ func (s *sshFlags) defaultExec() string {
 	return strings.ToLower(s.flagSshStyle)
 }
 
-func (s *sshFlags) buildArgs(c *Command, port, ip, addr string) []string {
+// buildArgs returns the arguments for the SSH client. If a private key was
+// brokered to the session, or an SSH certificate was issued for it, they are
+// written to temporary files which are removed by the returned cleanup
+// function.
+func (s *sshFlags) buildArgs(c *Command, port, ip, addr string) (args []string, cleanup func(), retErr error) {
 	// Might want -t for ssh or -tt but seems fine without it for now...
-	var args []string
+	var files []string
+	cleanup = func() {
+		for _, f := range files {
+			os.Remove(f)
+		}
+	}
+	defer func() {
+		if retErr != nil {
+			cleanup()
+			cleanup = func() {}
+		}
+	}()
+	writeFile := func(prefix, contents string) (string, error) {
+		f, err := ioutil.TempFile("", prefix)
+		if err != nil {
+			return "", err
+		}
+		files = append(files, f.Name())
+		_, err = f.WriteString(contents)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		return f.Name(), err
+	}
+
+	username := c.flagUsername
+	cred := c.brokeredCredential()
+	if username == "" && cred != nil {
+		username = cred.GetUsername()
+	}
+	sshCert := c.sessionAuthzData.GetSshCertificate()
+	if username == "" && sshCert != "" {
+		username = sshCertificatePrincipal(sshCert)
+	}
 	switch s.flagSshStyle {
 	case "ssh":
 		args = append(args, "-p", port, ip)
 		args = append(args, "-o", fmt.Sprintf("HostKeyAlias=%s", c.sessionAuthzData.HostId))
+		switch {
+		case sshCert != "":
+			keyFile, err := writeFile("boundary-ssh-key-", c.sessionAuthzData.GetSshPrivateKey())
+			if err != nil {
+				return nil, cleanup, fmt.Errorf("error writing private key file: %w", err)
+			}
+			certFile, err := writeFile("boundary-ssh-cert-", sshCert)
+			if err != nil {
+				return nil, cleanup, fmt.Errorf("error writing certificate file: %w", err)
+			}
+			args = append(args, "-i", keyFile, "-o", fmt.Sprintf("CertificateFile=%s", certFile), "-o", "IdentitiesOnly=yes")
+		case cred != nil && cred.GetPrivateKey() != "":
+			keyFile, err := writeFile("boundary-ssh-key-", cred.GetPrivateKey())
+			if err != nil {
+				return nil, cleanup, fmt.Errorf("error writing private key file: %w", err)
+			}
+			args = append(args, "-i", keyFile, "-o", "IdentitiesOnly=yes")
+		}
 	case "putty":
 		args = append(args, "-P", port, ip)
 	}
-	if c.flagUsername != "" {
-		args = append(args, "-l", c.flagUsername)
+	if username != "" {
+		args = append(args, "-l", username)
+	}
+	return args, cleanup, nil
+}
+
+// sshCertificatePrincipal returns the first principal of the SSH certificate
+// in authorized_keys format, or an empty string if it has none or cannot be
+// parsed.
+func sshCertificatePrincipal(in string) string {
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(in))
+	if err != nil {
+		return ""
+	}
+	cert, ok := pub.(*ssh.Certificate)
+	if !ok || len(cert.ValidPrincipals) == 0 {
+		return ""
 	}
-	return args
+	return cert.ValidPrincipals[0]
 }
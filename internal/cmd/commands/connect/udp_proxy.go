@@ -0,0 +1,175 @@
+package connect
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/proxy"
+	"nhooyr.io/websocket"
+)
+
+// udpPeerIdleTimeout is how long a local peer may go without sending or
+// receiving a datagram before it is forgotten.
+const udpPeerIdleTimeout = time.Minute
+
+// udpPeer is a local peer sending datagrams to the udp listener.
+type udpPeer struct {
+	id       uint32
+	addr     *net.UDPAddr
+	lastSeen time.Time
+}
+
+// udpPeers tracks the local peers whose datagrams are carried by the current
+// connection to the worker.
+type udpPeers struct {
+	mu     sync.Mutex
+	conn   *websocket.Conn
+	byAddr map[string]*udpPeer
+	byId   map[uint32]*udpPeer
+	nextId uint32
+}
+
+// reset forgets all peers and sets the connection carrying their datagrams.
+// It must be called with the lock held.
+func (p *udpPeers) reset(conn *websocket.Conn) {
+	p.conn = conn
+	p.byAddr = make(map[string]*udpPeer)
+	p.byId = make(map[uint32]*udpPeer)
+}
+
+// id returns the id of the peer at addr, giving it a new one if it is not
+// known. Peers which have been idle for longer than udpPeerIdleTimeout are
+// forgotten first. It must be called with the lock held.
+func (p *udpPeers) id(addr *net.UDPAddr, now time.Time) uint32 {
+	if peer, ok := p.byAddr[addr.String()]; ok {
+		peer.lastSeen = now
+		return peer.id
+	}
+	for id, peer := range p.byId {
+		if now.Sub(peer.lastSeen) > udpPeerIdleTimeout {
+			delete(p.byId, id)
+			delete(p.byAddr, peer.addr.String())
+		}
+	}
+	p.nextId++
+	for _, ok := p.byId[p.nextId]; ok; _, ok = p.byId[p.nextId] {
+		p.nextId++
+	}
+	peer := &udpPeer{id: p.nextId, addr: addr, lastSeen: now}
+	p.byAddr[addr.String()] = peer
+	p.byId[peer.id] = peer
+	return peer.id
+}
+
+// handleUdpListener reads datagrams from the local UDP listener and proxies
+// them to the worker. The datagrams of all local peers are carried by a single
+// connection to the worker, which is opened when the first datagram arrives
+// and opened again if it ends; each datagram is sent as a binary websocket
+// message framed with the id given to its peer.
+func (c *Command) handleUdpListener(workerAddr, tofuToken string, transport *http.Transport) {
+	peers := new(udpPeers)
+
+	buf := make([]byte, proxy.MaxUdpFrameSize)
+	for {
+		n, peerAddr, err := c.udpListener.ReadFromUDP(buf[proxy.UdpFrameHeaderSize:])
+		if err != nil {
+			select {
+			case <-c.proxyCtx.Done():
+				return
+			case <-c.Context.Done():
+				return
+			default:
+				// When this hits zero we trigger listener close so this
+				// isn't actually an error condition
+				if c.connectionsLeft.Load() == 0 {
+					return
+				}
+				c.UI.Error(fmt.Errorf("Error reading datagram: %w", err).Error())
+				continue
+			}
+		}
+
+		peers.mu.Lock()
+		conn := peers.conn
+		peers.mu.Unlock()
+		if conn == nil {
+			conn, err = c.dialWorker(workerAddr, tofuToken, transport, globals.UdpProxyV1)
+			if err != nil {
+				c.UI.Error(err.Error())
+				if c.connectionsLeft.Load() == 0 {
+					// No connection is left to carry the datagrams
+					c.proxyCancel()
+					return
+				}
+				continue
+			}
+			conn.SetReadLimit(proxy.MaxUdpFrameSize)
+			peers.mu.Lock()
+			peers.reset(conn)
+			peers.mu.Unlock()
+
+			c.connWg.Add(1)
+			go func() {
+				defer c.connWg.Done()
+				defer conn.Close(websocket.StatusNormalClosure, "done")
+				c.relayToUdpPeers(conn, peers)
+
+				peers.mu.Lock()
+				if peers.conn == conn {
+					peers.reset(nil)
+				}
+				peers.mu.Unlock()
+				if c.connectionsLeft.Load() == 0 {
+					// The session can't carry any more datagrams
+					c.proxyCancel()
+				}
+			}()
+		}
+
+		peers.mu.Lock()
+		id := peers.id(peerAddr, time.Now())
+		peers.mu.Unlock()
+		proxy.PutUdpFrameHeader(buf, id)
+		if err := conn.Write(c.proxyCtx, websocket.MessageBinary, buf[:proxy.UdpFrameHeaderSize+n]); err != nil {
+			c.UI.Error(fmt.Errorf("Error sending datagram to worker: %w", err).Error())
+		}
+	}
+}
+
+// relayToUdpPeers writes the datagrams received from the worker back to the
+// local peers they are framed for until the connection to the worker ends.
+// Datagrams for peers which have been forgotten are dropped.
+func (c *Command) relayToUdpPeers(conn *websocket.Conn, peers *udpPeers) {
+	for {
+		typ, frame, err := conn.Read(c.proxyCtx)
+		if err != nil {
+			return
+		}
+		if typ != websocket.MessageBinary {
+			continue
+		}
+		id, datagram, err := proxy.ParseUdpFrame(frame)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error reading datagram from worker: %w", err).Error())
+			return
+		}
+
+		peers.mu.Lock()
+		var addr *net.UDPAddr
+		if peer, ok := peers.byId[id]; ok && peers.conn == conn {
+			peer.lastSeen = time.Now()
+			addr = peer.addr
+		}
+		peers.mu.Unlock()
+		if addr == nil {
+			continue
+		}
+		if _, err := c.udpListener.WriteToUDP(datagram, addr); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package connect
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/proxy"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wspb"
+)
+
+// testUdpWorker starts a worker which answers each datagram of the udp proxy
+// protocol with the datagram itself, and counts the connections made to it.
+func testUdpWorker(t *testing.T, conns *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+			Subprotocols: []string{globals.UdpProxyV1},
+		})
+		if err != nil {
+			return
+		}
+		defer conn.Close(websocket.StatusNormalClosure, "done")
+		conns.Inc()
+		ctx := r.Context()
+		var handshake proxy.ClientHandshake
+		if err := wspb.Read(ctx, conn, &handshake); err != nil {
+			return
+		}
+		if err := wspb.Write(ctx, conn, &proxy.HandshakeResult{ConnectionsLeft: -1}); err != nil {
+			return
+		}
+		for {
+			typ, frame, err := conn.Read(ctx)
+			if err != nil {
+				return
+			}
+			if err := conn.Write(ctx, typ, frame); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestHandleUdpListener(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	var conns atomic.Int32
+	worker := testUdpWorker(t, &conns)
+
+	listener, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(err)
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &Command{
+		Command:     &base.Command{UI: cli.NewMockUi(), Context: ctx},
+		udpListener: listener,
+		connWg:      new(sync.WaitGroup),
+		connsLeftCh: make(chan int32),
+	}
+	c.connectionsLeft.Store(-1)
+	c.proxyCtx, c.proxyCancel = context.WithCancel(ctx)
+	c.connWg.Add(1)
+	go func() {
+		defer c.connWg.Done()
+		c.handleUdpListener(worker.Listener.Addr().String(), "token", worker.Client().Transport.(*http.Transport))
+	}()
+	defer func() {
+		cancel()
+		listener.Close()
+		c.connWg.Wait()
+	}()
+
+	// Each local peer sends from its own port and gets its own datagrams back.
+	var peers []*net.UDPConn
+	for i := 0; i < 3; i++ {
+		peer, err := net.DialUDP("udp", nil, listener.LocalAddr().(*net.UDPAddr))
+		require.NoError(err)
+		defer peer.Close()
+		peers = append(peers, peer)
+	}
+	buf := make([]byte, proxy.MaxUdpDatagramSize)
+	for round := 0; round < 2; round++ {
+		for i, peer := range peers {
+			want := peer.LocalAddr().String()
+			_, err := peer.Write([]byte(want))
+			require.NoError(err)
+			require.NoError(peer.SetReadDeadline(time.Now().Add(5 * time.Second)))
+			n, err := peer.Read(buf)
+			require.NoError(err, "peer %d", i)
+			assert.Equal(want, string(buf[:n]))
+		}
+	}
+
+	// All of the peers share a single connection to the worker.
+	assert.Equal(int32(1), conns.Load())
+}
+
+func TestUdpPeersId(t *testing.T) {
+	assert := assert.New(t)
+	peers := new(udpPeers)
+	peers.reset(nil)
+	a := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1000}
+	b := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1001}
+	now := time.Now()
+
+	idA := peers.id(a, now)
+	idB := peers.id(b, now)
+	assert.NotEqual(idA, idB)
+	assert.Equal(idA, peers.id(a, now.Add(udpPeerIdleTimeout)))
+
+	// b has been idle for too long and is forgotten once another peer
+	// arrives, while a is still known.
+	c := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1002}
+	idC := peers.id(c, now.Add(udpPeerIdleTimeout+time.Second))
+	assert.NotContains(peers.byId, idB)
+	assert.Contains(peers.byId, idA)
+	assert.NotEqual(idB, peers.id(b, now.Add(udpPeerIdleTimeout+time.Second)))
+	assert.NotEqual(idC, idA)
+}
@@ -0,0 +1,236 @@
+package credentiallibraries
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/textproto"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/credentiallibraries"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*StaticCommand)(nil)
+var _ cli.CommandAutocomplete = (*StaticCommand)(nil)
+
+type StaticCommand struct {
+	*base.Command
+
+	Func string
+
+	flagUsername       string
+	flagPassword       string
+	flagPrivateKeyFile string
+}
+
+func (c *StaticCommand) Synopsis() string {
+	return fmt.Sprintf("%s a static-type credential library", textproto.CanonicalMIMEHeaderKey(c.Func))
+}
+
+var staticFlagsMap = map[string][]string{
+	"create": {"credential-store-id", "name", "description", "username", "password", "private-key-file"},
+	"update": {"id", "name", "description", "version", "username", "password", "private-key-file"},
+}
+
+func (c *StaticCommand) Help() string {
+	var info string
+	switch c.Func {
+	case "create":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary credential-libraries create static [options] [args]",
+			"",
+			"  Create a static-type credential library. Example:",
+			"",
+			`    $ boundary credential-libraries create static -credential-store-id csst_1234567890 -name prodops -username admin -private-key-file ./id_rsa`,
+			"",
+			"",
+		})
+
+	case "update":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary credential-libraries update static [options] [args]",
+			"",
+			"  Update a static-type credential library given its ID. Example:",
+			"",
+			`    $ boundary credential-libraries update static -id clst_1234567890 -name "devops" -password "hunter2"`,
+			"",
+			"",
+		})
+	}
+	return info + c.Flags().Help()
+}
+
+func (c *StaticCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "static-type credential library", staticFlagsMap[c.Func])
+
+	f = set.NewFlagSet("Static Credential Library Options")
+
+	for _, name := range staticFlagsMap[c.Func] {
+		switch name {
+		case "username":
+			f.StringVar(&base.StringVar{
+				Name:   "username",
+				Target: &c.flagUsername,
+				Usage:  "The username brokered by the credential library",
+			})
+		case "password":
+			f.StringVar(&base.StringVar{
+				Name:   "password",
+				Target: &c.flagPassword,
+				Usage:  "The password brokered by the credential library",
+			})
+		case "private-key-file":
+			f.StringVar(&base.StringVar{
+				Name:       "private-key-file",
+				Target:     &c.flagPrivateKeyFile,
+				Completion: complete.PredictFiles("*"),
+				Usage:      "Path to a file containing the PEM encoded private key brokered by the credential library",
+			})
+		}
+	}
+
+	return set
+}
+
+func (c *StaticCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *StaticCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *StaticCommand) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(staticFlagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(staticFlagsMap[c.Func], "credential-store-id") && c.FlagCredentialStoreId == "" {
+		c.UI.Error("Credential Store ID must be passed in via -credential-store-id")
+		return 1
+	}
+	if c.Func == "create" && c.flagUsername == "" {
+		c.UI.Error("Username must be passed in via -username")
+		return 1
+	}
+	if c.Func == "create" && c.flagPassword == "" && c.flagPrivateKeyFile == "" {
+		c.UI.Error("A password or private key must be passed in via -password or -private-key-file")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []credentiallibraries.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, credentiallibraries.DefaultName())
+	default:
+		opts = append(opts, credentiallibraries.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, credentiallibraries.DefaultDescription())
+	default:
+		opts = append(opts, credentiallibraries.WithDescription(c.FlagDescription))
+	}
+
+	if c.flagUsername != "" {
+		opts = append(opts, credentiallibraries.WithStaticCredentialLibraryUsername(c.flagUsername))
+	}
+
+	switch c.flagPassword {
+	case "":
+	case "null":
+		opts = append(opts, credentiallibraries.DefaultStaticCredentialLibraryPassword())
+	default:
+		opts = append(opts, credentiallibraries.WithStaticCredentialLibraryPassword(c.flagPassword))
+	}
+
+	switch c.flagPrivateKeyFile {
+	case "":
+	case "null":
+		opts = append(opts, credentiallibraries.DefaultStaticCredentialLibraryPrivateKey())
+	default:
+		key, err := ioutil.ReadFile(c.flagPrivateKeyFile)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error reading private key file: %s", err.Error()))
+			return 1
+		}
+		opts = append(opts, credentiallibraries.WithStaticCredentialLibraryPrivateKey(string(key)))
+	}
+
+	credentiallibraryClient := credentiallibraries.NewClient(client)
+
+	// Perform check-and-set when needed
+	var version uint32
+	switch c.Func {
+	case "create":
+		// These don't update so don't need the existing version
+	default:
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, credentiallibraries.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	var result api.GenericResult
+
+	switch c.Func {
+	case "create":
+		result, err = credentiallibraryClient.Create(c.Context, c.FlagCredentialStoreId, opts...)
+	case "update":
+		result, err = credentiallibraryClient.Update(c.Context, c.FlagId, version, opts...)
+	}
+
+	plural := "static-type credential library"
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	library := result.GetItem().(*credentiallibraries.CredentialLibrary)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateCredentialLibraryTableOutput(library))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(library)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
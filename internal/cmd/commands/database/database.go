@@ -27,6 +27,10 @@ func (c *Command) Help() string {
 		"",
 		`      $ boundary database init`,
 		"",
+		"    Replay the oplog into new tables:",
+		"",
+		`      $ boundary database replay -table-suffix=_restore -end-time=2021-06-01T12:00:00Z`,
+		"",
 		"  Please see the database subcommand help for detailed usage information.",
 	})
 }
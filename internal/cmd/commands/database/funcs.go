@@ -174,3 +174,37 @@ func generateInitialTargetTableOutput(in *TargetInfo) string {
 
 	return base.WrapForHelpText(ret)
 }
+
+type ReplayInfo struct {
+	Replayed int      `json:"replayed"`
+	Skipped  int      `json:"skipped"`
+	Tables   []string `json:"tables"`
+}
+
+func generateReplayTableOutput(in *ReplayInfo) string {
+	nonAttributeMap := map[string]interface{}{
+		"Replayed Entries": in.Replayed,
+		"Skipped Entries":  in.Skipped,
+	}
+
+	maxLength := 0
+	for k := range nonAttributeMap {
+		if len(k) > maxLength {
+			maxLength = len(k)
+		}
+	}
+
+	ret := []string{
+		"",
+		"Replay information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+	if len(in.Tables) > 0 {
+		ret = append(ret, "", "  Tables:")
+		for _, t := range in.Tables {
+			ret = append(ret, fmt.Sprintf("    %s", t))
+		}
+	}
+
+	return base.WrapForHelpText(ret)
+}
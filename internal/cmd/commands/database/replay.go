@@ -0,0 +1,364 @@
+package database
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/history"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*ReplayCommand)(nil)
+var _ cli.CommandAutocomplete = (*ReplayCommand)(nil)
+
+type ReplayCommand struct {
+	*base.Command
+	srv *base.Server
+
+	Config *config.Config
+
+	configWrapper wrapping.Wrapper
+
+	flagConfig         string
+	flagConfigKms      string
+	flagLogLevel       string
+	flagLogFormat      string
+	flagMigrationUrl   string
+	flagScopeId        string
+	flagTableSuffix    string
+	flagAggregateNames []string
+	flagEndTime        string
+	flagEndCursorFile  string
+}
+
+func (c *ReplayCommand) Synopsis() string {
+	return "Replay the oplog into new tables"
+}
+
+func (c *ReplayCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database replay [options]",
+		"",
+		"  Replay the oplog entries of a scope and of every scope beneath it, up to a point in time, into tables named after the tables of the resources followed by a suffix. This reconstructs the resources as they were at that point, for instance before an accidental deletion:",
+		"",
+		"    $ boundary database replay -config=/etc/boundary/controller.hcl -table-suffix=_restore -end-time=2021-06-01T12:00:00Z",
+		"",
+		"  The point can also be the cursor file of \"boundary oplog watch\", in which case the entries up to the last one the watch wrote are replayed.",
+		"",
+		"  The tables are created like the tables of the resources, with their columns, defaults, checks and indexes but without their foreign keys, and are left for the operator to compare with or copy into the tables of the resources. Secret values are replayed as they were stored. Entries of deleted scopes cannot be decrypted, since the keys of a scope are deleted with it, and are skipped. The replay runs in a single transaction, so it either completes or leaves no tables behind; use a new suffix for every replay.",
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ReplayCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file, which has some drawbacks; see the help output for "boundary config encrypt -h" for details.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-level",
+		Target:     &c.flagLogLevel,
+		EnvVar:     "BOUNDARY_LOG_LEVEL",
+		Completion: complete.PredictSet("trace", "debug", "info", "warn", "err"),
+		Usage: "Log verbosity level. Supported values (in order of more detail to less) are " +
+			"\"trace\", \"debug\", \"info\", \"warn\", and \"err\".",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-format",
+		Target:     &c.flagLogFormat,
+		Completion: complete.PredictSet("standard", "json"),
+		Usage:      `Log format. Supported values are "standard" and "json".`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "migration-url",
+		Target: &c.flagMigrationUrl,
+		Usage:  `If set, overrides a migration URL set in config, and specifies the URL used to connect to the database for the replay, which creates tables. This can refer to a file on disk (file://) from which a URL will be read; an env var (env://) from which the URL will be read; or a direct database URL.`,
+	})
+
+	f = set.NewFlagSet("Replay Options")
+
+	f.StringVar(&base.StringVar{
+		Name:    "scope-id",
+		Target:  &c.flagScopeId,
+		Default: scope.Global.String(),
+		Usage:   "The scope whose entries are replayed, along with those of every scope beneath it. Defaults to the global scope.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "table-suffix",
+		Target: &c.flagTableSuffix,
+		Usage:  "The suffix of the names of the tables the entries are replayed into. It must start with an underscore and only contain lowercase letters, digits and underscores. Required.",
+	})
+
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:   "aggregate-name",
+		Target: &c.flagAggregateNames,
+		Usage:  "If set, only entries for the given aggregate, the table of the changed resource (for example iam_user), are replayed. May be specified multiple times.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "end-time",
+		Target: &c.flagEndTime,
+		Usage:  "If set, only entries created before the given time, in RFC3339 format, are replayed.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "end-cursor-file",
+		Target:     &c.flagEndCursorFile,
+		Completion: complete.PredictFiles("*"),
+		Usage:      `If set, only entries up to the position in the given cursor file of "boundary oplog watch" are replayed.`,
+	})
+
+	return set
+}
+
+func (c *ReplayCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ReplayCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ReplayCommand) Run(args []string) int {
+	if result := c.ParseFlagsAndConfig(args); result > 0 {
+		return result
+	}
+
+	if c.configWrapper != nil {
+		defer func() {
+			if err := c.configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+
+	var opts []history.Option
+	if len(c.flagAggregateNames) > 0 {
+		opts = append(opts, history.WithAggregateNames(c.flagAggregateNames...))
+	}
+	if c.flagEndTime != "" {
+		endTime, err := time.Parse(time.RFC3339, c.flagEndTime)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error parsing -end-time as an RFC3339 time: %w", err).Error())
+			return 1
+		}
+		opts = append(opts, history.WithEndTime(endTime))
+	}
+	if c.flagEndCursorFile != "" {
+		b, err := ioutil.ReadFile(c.flagEndCursorFile)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error reading cursor file: %w", err).Error())
+			return 1
+		}
+		cursor, err := history.ParseCursor(strings.TrimSpace(string(b)))
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error parsing cursor file: %w", err).Error())
+			return 1
+		}
+		opts = append(opts, history.WithEndCursor(cursor))
+	}
+
+	c.srv = base.NewServer(&base.Command{UI: c.UI})
+
+	if err := c.srv.SetupLogging(c.flagLogLevel, c.flagLogFormat, c.Config.LogLevel, c.Config.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if err := c.srv.SetupKMSes(c.UI, c.Config); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.srv.RootKms == nil {
+		c.UI.Error("Root KMS not found after parsing KMS blocks")
+		return 1
+	}
+
+	if c.Config.Controller.Database == nil {
+		c.UI.Error(`"controller.database" config block not found`)
+		return 1
+	}
+
+	// Creating the replay tables may need the privileges of the migration URL
+	urlToParse := c.Config.Controller.Database.MigrationUrl
+	if c.flagMigrationUrl != "" {
+		urlToParse = c.flagMigrationUrl
+	}
+	if urlToParse == "" {
+		urlToParse = c.Config.Controller.Database.Url
+	}
+	if urlToParse == "" {
+		c.UI.Error(`"url" not specified in "database" config block"`)
+		return 1
+	}
+	dbaseUrl, err := config.ParseAddress(urlToParse)
+	if err != nil && err != config.ErrNotAUrl {
+		c.UI.Error(fmt.Errorf("Error parsing database url: %w", err).Error())
+		return 1
+	}
+	c.srv.DatabaseUrl = strings.TrimSpace(dbaseUrl)
+	if err := c.srv.ConnectToDatabase("postgres"); err != nil {
+		c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
+		return 1
+	}
+
+	rw := db.New(c.srv.Database)
+	kmsRepo, err := kms.NewRepository(rw, rw)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms repository: %w", err).Error())
+		return 1
+	}
+	kmsCache, err := kms.NewKms(kmsRepo, kms.WithLogger(c.srv.Logger.Named("kms")))
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms cache: %w", err).Error())
+		return 1
+	}
+	if err := kmsCache.AddExternalWrappers(
+		kms.WithRootWrapper(c.srv.RootKms),
+	); err != nil {
+		c.UI.Error(fmt.Errorf("Error adding config keys to kms: %w", err).Error())
+		return 1
+	}
+	iamRepo, err := iam.NewRepository(rw, rw, kmsCache)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating iam repository: %w", err).Error())
+		return 1
+	}
+	scopes, err := iamRepo.ListScopesRecursively(c.Context, c.flagScopeId)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error listing scopes: %w", err).Error())
+		return 1
+	}
+	if len(scopes) == 0 {
+		c.UI.Error(fmt.Sprintf("Scope %q not found", c.flagScopeId))
+		return 1
+	}
+	scopeIds := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		scopeIds = append(scopeIds, s.GetPublicId())
+	}
+
+	tx := c.srv.Database.BeginTx(c.Context, nil)
+	if err := tx.Error; err != nil {
+		c.UI.Error(fmt.Errorf("Error starting transaction: %w", err).Error())
+		return 1
+	}
+	historyRepo, err := history.NewRepository(db.New(tx), kmsCache)
+	if err != nil {
+		tx.Rollback()
+		c.UI.Error(fmt.Errorf("Error creating history repository: %w", err).Error())
+		return 1
+	}
+	res, err := historyRepo.Replay(c.Context, &oplog.GormWriter{Tx: tx}, scopeIds, c.flagTableSuffix, opts...)
+	if err != nil {
+		tx.Rollback()
+		c.UI.Error(fmt.Errorf("Error replaying the oplog: %w", err).Error())
+		return 1
+	}
+	if err := tx.Commit().Error; err != nil {
+		c.UI.Error(fmt.Errorf("Error committing the replay: %w", err).Error())
+		return 1
+	}
+
+	replayInfo := &ReplayInfo{
+		Replayed: res.Replayed,
+		Skipped:  res.Skipped,
+		Tables:   res.Tables,
+	}
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateReplayTableOutput(replayInfo))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(replayInfo)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
+
+func (c *ReplayCommand) ParseFlagsAndConfig(args []string) int {
+	var err error
+
+	f := c.Flags()
+
+	if err = f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	wrapperPath := c.flagConfig
+	if c.flagConfigKms != "" {
+		wrapperPath = c.flagConfigKms
+	}
+	wrapper, err := wrapper.GetWrapperFromPath(wrapperPath, "config")
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if wrapper != nil {
+		c.configWrapper = wrapper
+		if err := wrapper.Init(c.Context); err != nil {
+			c.UI.Error(fmt.Errorf("Could not initialize kms: %w", err).Error())
+			return 1
+		}
+	}
+
+	// Validation
+	switch {
+	case len(c.flagConfig) == 0:
+		c.UI.Error("Must specify a config file using -config")
+		return 1
+	case len(c.flagTableSuffix) == 0:
+		c.UI.Error("Must specify a table suffix using -table-suffix")
+		return 1
+	}
+
+	c.Config, err = config.LoadFile(c.flagConfig, wrapper)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return 1
+	}
+
+	return 0
+}
@@ -2,6 +2,7 @@ package dev
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net"
 	"runtime"
 	"strings"
@@ -321,6 +322,11 @@ func (c *Command) Run(args []string) int {
 		return 1
 	}
 
+	if err := c.SetupEventing(c.Config.Events); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
 	if c.flagRecoveryKey != "" {
 		c.Config.DevRecoveryKey = c.flagRecoveryKey
 	}
@@ -356,6 +362,17 @@ func (c *Command) Run(args []string) int {
 	c.InfoKeys = append(c.InfoKeys, "worker public addr")
 	c.Info["worker public addr"] = c.Config.Worker.PublicAddr
 
+	if c.Config.Worker.RecordingStoragePath == "" {
+		recordingDir, err := ioutil.TempDir("", "boundary-dev-recordings-")
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error creating session recording directory: %w", err).Error())
+			return 1
+		}
+		c.Config.Worker.RecordingStoragePath = recordingDir
+	}
+	c.InfoKeys = append(c.InfoKeys, "worker recording storage path")
+	c.Info["worker recording storage path"] = c.Config.Worker.RecordingStoragePath
+
 	// Write out the PID to the file now that server has successfully started
 	if err := c.StorePidFile(c.Config.PidFile); err != nil {
 		c.UI.Error(fmt.Errorf("Error storing PID: %w", err).Error())
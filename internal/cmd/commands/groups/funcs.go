@@ -115,6 +115,14 @@ func generateGroupTableOutput(in *groups.Group) string {
 		base.ScopeInfoForOutput(in.Scope, maxLength),
 	}
 
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
 	if len(in.Members) > 0 {
 		ret = append(ret,
 			"",
@@ -46,7 +46,7 @@ var flagsMap = map[string][]string{
 	"update":         {"id", "name", "description", "version"},
 	"read":           {"id"},
 	"delete":         {"id"},
-	"list":           {"scope-id"},
+	"list":           {"scope-id", "filter", "recursive"},
 	"add-members":    {"id", "member", "version"},
 	"set-members":    {"id", "member", "version"},
 	"remove-members": {"id", "member", "version"},
@@ -175,6 +175,12 @@ func (c *Command) Run(args []string) int {
 			err = nil
 		}
 	case "list":
+		if c.FlagFilter != "" {
+			opts = append(opts, groups.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, groups.WithRecursive(true))
+		}
 		listResult, err = groupClient.List(c.Context, c.FlagScopeId, opts...)
 	case "add-members":
 		result, err = groupClient.AddMembers(c.Context, c.FlagId, version, members, opts...)
@@ -249,6 +255,11 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Version:     %d", g.Version),
 					)
 				}
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:    %s", g.ScopeId),
+					)
+				}
 				if g.Name != "" {
 					output = append(output,
 						fmt.Sprintf("    Name:        %s", g.Name),
@@ -34,6 +34,14 @@ func generateHostCatalogTableOutput(in *hostcatalogs.HostCatalog) string {
 		base.ScopeInfoForOutput(in.Scope, maxLength),
 	}
 
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
 	if len(in.Attributes) > 0 {
 		ret = append(ret,
 			"",
@@ -46,5 +54,9 @@ func generateHostCatalogTableOutput(in *hostcatalogs.HostCatalog) string {
 }
 
 var keySubstMap = map[string]string{
-	"address": "Address",
+	"address":               "Address",
+	"source":                "Source",
+	"sync_interval_seconds": "Sync Interval Seconds",
+	"last_sync_time":        "Last Sync Time",
+	"last_sync_error":       "Last Sync Error",
 }
@@ -30,7 +30,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":   {"id"},
 	"delete": {"id"},
-	"list":   {"scope-id"},
+	"list":   {"scope-id", "filter", "recursive"},
 }
 
 func (c *Command) Help() string {
@@ -59,6 +59,10 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary host-catalogs create static -name prodops -description "For ProdOps usage"`,
 			"",
+			"    Create an inventory-type host catalog:",
+			"",
+			`      $ boundary host-catalogs create inventory -name prodops -source https://cmdb.example.com/hosts.json`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	case "update":
@@ -71,6 +75,10 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary host-catalogs update static -id hcst_1234567890 -name devops -description "For DevOps usage"`,
 			"",
+			"    Update an inventory-type host catalog:",
+			"",
+			`      $ boundary host-catalogs update inventory -id hcinv_1234567890 -sync-interval-seconds 60`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	default:
@@ -162,6 +170,12 @@ func (c *Command) Run(args []string) int {
 			err = nil
 		}
 	case "list":
+		if c.FlagFilter != "" {
+			opts = append(opts, hostcatalogs.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, hostcatalogs.WithRecursive(true))
+		}
 		listResult, err = hostcatalogClient.List(c.Context, c.FlagScopeId, opts...)
 	}
 
@@ -231,6 +245,11 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Type:         %s", m.Type),
 					)
 				}
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:     %s", m.ScopeId),
+					)
+				}
 				if m.Name != "" {
 					output = append(output,
 						fmt.Sprintf("    Name:         %s", m.Name),
@@ -0,0 +1,222 @@
+package hostcatalogs
+
+import (
+	"fmt"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/hostcatalogs"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*InventoryCommand)(nil)
+var _ cli.CommandAutocomplete = (*InventoryCommand)(nil)
+
+type InventoryCommand struct {
+	*base.Command
+
+	Func string
+
+	flagSource              string
+	flagSyncIntervalSeconds string
+}
+
+func (c *InventoryCommand) Synopsis() string {
+	return fmt.Sprintf("%s an inventory-type host catalog", textproto.CanonicalMIMEHeaderKey(c.Func))
+}
+
+var inventoryFlagsMap = map[string][]string{
+	"create": {"scope-id", "name", "description"},
+	"update": {"id", "name", "description", "version"},
+}
+
+func (c *InventoryCommand) Help() string {
+	var info string
+	switch c.Func {
+	case "create":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary host-catalogs create inventory [options] [args]",
+			"",
+			"  Create an inventory-type host catalog. Example:",
+			"",
+			`    $ boundary host-catalogs create inventory -name prodops -source https://cmdb.example.com/hosts.json -sync-interval-seconds 60`,
+			"",
+			"",
+		})
+
+	case "update":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary host-catalogs update inventory [options] [args]",
+			"",
+			"  Update an inventory-type host catalog given its ID. Example:",
+			"",
+			`    $ boundary host-catalogs update inventory -id hcinv_1234567890 -source /etc/boundary/hosts.yaml`,
+			"",
+			"",
+		})
+	}
+	return info + c.Flags().Help()
+}
+
+func (c *InventoryCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "inventory-type host catalog", inventoryFlagsMap[c.Func])
+
+	f = set.NewFlagSet("Inventory Host-Catalog Options")
+	f.StringVar(&base.StringVar{
+		Name:   "source",
+		Target: &c.flagSource,
+		Usage:  "The absolute path or the file, http, or https URL of the JSON or YAML inventory document",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "sync-interval-seconds",
+		Target: &c.flagSyncIntervalSeconds,
+		Usage:  "The interval between syncs of the catalog with its source. Can be specified as an integer number of seconds or a duration string.",
+	})
+
+	return set
+}
+
+func (c *InventoryCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *InventoryCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *InventoryCommand) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(inventoryFlagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(inventoryFlagsMap[c.Func], "scope-id") && c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+	if c.Func == "create" && c.flagSource == "" {
+		c.UI.Error("Source must be passed in via -source")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []hostcatalogs.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, hostcatalogs.DefaultName())
+	default:
+		opts = append(opts, hostcatalogs.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, hostcatalogs.DefaultDescription())
+	default:
+		opts = append(opts, hostcatalogs.WithDescription(c.FlagDescription))
+	}
+
+	switch c.flagSource {
+	case "":
+	case "null":
+		c.UI.Error("The source of an inventory-type host catalog cannot be removed")
+		return 1
+	default:
+		opts = append(opts, hostcatalogs.WithInventoryHostCatalogSource(c.flagSource))
+	}
+
+	switch c.flagSyncIntervalSeconds {
+	case "":
+	case "null":
+		opts = append(opts, hostcatalogs.DefaultInventoryHostCatalogSyncIntervalSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagSyncIntervalSeconds, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagSyncIntervalSeconds)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSyncIntervalSeconds, err))
+				return 1
+			}
+			final = uint32(dur.Seconds())
+		}
+		opts = append(opts, hostcatalogs.WithInventoryHostCatalogSyncIntervalSeconds(final))
+	}
+
+	hostcatalogClient := hostcatalogs.NewClient(client)
+
+	// Perform check-and-set when needed
+	var version uint32
+	switch c.Func {
+	case "create":
+		// These don't update so don't need the existing version
+	default:
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, hostcatalogs.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	var result api.GenericResult
+
+	switch c.Func {
+	case "create":
+		result, err = hostcatalogClient.Create(c.Context, "inventory", c.FlagScopeId, opts...)
+	case "update":
+		result, err = hostcatalogClient.Update(c.Context, c.FlagId, version, opts...)
+	}
+
+	plural := "inventory-type host-catalog"
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	catalog := result.GetItem().(*hostcatalogs.HostCatalog)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateHostCatalogTableOutput(catalog))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(catalog)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
@@ -30,7 +30,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":   {"id"},
 	"delete": {"id"},
-	"list":   {"host-catalog-id"},
+	"list":   {"host-catalog-id", "filter"},
 }
 
 func (c *Command) Help() string {
@@ -161,6 +161,9 @@ func (c *Command) Run(args []string) int {
 			err = nil
 		}
 	case "list":
+		if c.FlagFilter != "" {
+			opts = append(opts, hosts.WithFilter(c.FlagFilter))
+		}
 		listResult, err = hostClient.List(c.Context, c.FlagHostCatalogId, opts...)
 	}
 
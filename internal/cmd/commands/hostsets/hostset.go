@@ -41,7 +41,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":         {"id"},
 	"delete":       {"id"},
-	"list":         {"host-catalog-id"},
+	"list":         {"host-catalog-id", "filter"},
 	"add-hosts":    {"id", "host", "version"},
 	"set-hosts":    {"id", "host", "version"},
 	"remove-hosts": {"id", "host", "version"},
@@ -73,6 +73,10 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary host-sets create static -name prodops -description "For ProdOps usage"`,
 			"",
+			"    Create an inventory-type host set:",
+			"",
+			`      $ boundary host-sets create inventory -host-catalog-id hcinv_1234567890 -name web -match-label role=web`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	case "update":
@@ -85,6 +89,10 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary host-sets update static -id hsst_1234567890 -name devops -description "For DevOps usage"`,
 			"",
+			"    Update an inventory-type host set:",
+			"",
+			`      $ boundary host-sets update inventory -id hsinv_1234567890 -match-label role=db`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	case "add-hosts":
@@ -250,6 +258,9 @@ func (c *Command) Run(args []string) int {
 			err = nil
 		}
 	case "list":
+		if c.FlagFilter != "" {
+			opts = append(opts, hostsets.WithFilter(c.FlagFilter))
+		}
 		listResult, err = hostsetClient.List(c.Context, c.FlagHostCatalogId, opts...)
 	case "add-hosts":
 		result, err = hostsetClient.AddHosts(c.Context, c.FlagId, version, hosts, opts...)
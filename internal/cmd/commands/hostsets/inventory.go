@@ -0,0 +1,199 @@
+package hostsets
+
+import (
+	"fmt"
+	"net/textproto"
+	"strings"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/hostsets"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*InventoryCommand)(nil)
+var _ cli.CommandAutocomplete = (*InventoryCommand)(nil)
+
+type InventoryCommand struct {
+	*base.Command
+
+	Func string
+
+	flagMatchLabels []string
+}
+
+func (c *InventoryCommand) Synopsis() string {
+	return fmt.Sprintf("%s an inventory-type host set", textproto.CanonicalMIMEHeaderKey(c.Func))
+}
+
+var inventoryFlagsMap = map[string][]string{
+	"create": {"host-catalog-id", "name", "description"},
+	"update": {"id", "name", "description", "version"},
+}
+
+func (c *InventoryCommand) Help() string {
+	var info string
+	switch c.Func {
+	case "create":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary host-sets create inventory [options] [args]",
+			"",
+			"  Create an inventory-type host set. Example:",
+			"",
+			`    $ boundary host-sets create inventory -host-catalog-id hcinv_1234567890 -name web -match-label role=web -match-label env=prod`,
+			"",
+			"",
+		})
+
+	case "update":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary host-sets update inventory [options] [args]",
+			"",
+			"  Update an inventory-type host set given its ID. Example:",
+			"",
+			`    $ boundary host-sets update inventory -id hsinv_1234567890 -match-label role=db`,
+			"",
+			"",
+		})
+	}
+	return info + c.Flags().Help()
+}
+
+func (c *InventoryCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "inventory-type host set", inventoryFlagsMap[c.Func])
+
+	f = set.NewFlagSet("Inventory Host-Set Options")
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:   "match-label",
+		Target: &c.flagMatchLabels,
+		Usage:  `A label, in "key=value" form, which hosts must have to be members of the set. May be specified multiple times. On update, the given labels replace all existing match labels.`,
+	})
+
+	return set
+}
+
+func (c *InventoryCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *InventoryCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *InventoryCommand) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(inventoryFlagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(inventoryFlagsMap[c.Func], "host-catalog-id") && c.FlagHostCatalogId == "" {
+		c.UI.Error("Host Catalog ID must be passed in via -host-catalog-id")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []hostsets.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, hostsets.DefaultName())
+	default:
+		opts = append(opts, hostsets.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, hostsets.DefaultDescription())
+	default:
+		opts = append(opts, hostsets.WithDescription(c.FlagDescription))
+	}
+
+	switch {
+	case len(c.flagMatchLabels) == 0:
+	case len(c.flagMatchLabels) == 1 && c.flagMatchLabels[0] == "null":
+		opts = append(opts, hostsets.DefaultInventoryHostSetMatchLabels())
+	default:
+		labels := make(map[string]string, len(c.flagMatchLabels))
+		for _, l := range c.flagMatchLabels {
+			kv := strings.SplitN(l, "=", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				c.UI.Error(fmt.Sprintf("Match label %q is not in key=value form", l))
+				return 1
+			}
+			labels[kv[0]] = kv[1]
+		}
+		opts = append(opts, hostsets.WithInventoryHostSetMatchLabels(labels))
+	}
+
+	hostsetClient := hostsets.NewClient(client)
+
+	// Perform check-and-set when needed
+	var version uint32
+	switch c.Func {
+	case "create":
+		// These don't update so don't need the existing version
+	default:
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, hostsets.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	var result api.GenericResult
+
+	switch c.Func {
+	case "create":
+		result, err = hostsetClient.Create(c.Context, c.FlagHostCatalogId, opts...)
+	case "update":
+		result, err = hostsetClient.Update(c.Context, c.FlagId, version, opts...)
+	}
+
+	plural := "inventory-type host-set"
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	set := result.GetItem().(*hostsets.HostSet)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateHostSetTableOutput(set))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(set)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
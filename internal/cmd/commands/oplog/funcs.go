@@ -0,0 +1,91 @@
+package oplog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api/oplogentries"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func baseHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary oplog [sub command] [options] [args]",
+		"",
+		"  This command allows reading the oplog, the record of the changes made to Boundary resources. Example:",
+		"",
+		"    List the changes made in a scope:",
+		"",
+		`      $ boundary oplog list -scope-id o_1234567890`,
+		"",
+		"    Watch for the changes made in a scope:",
+		"",
+		`      $ boundary oplog watch -scope-id o_1234567890`,
+		"",
+		"  Please see the oplog subcommand help for detailed usage information.",
+	})
+}
+
+func listHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary oplog list [options] [args]",
+		"",
+		"  List the oplog entries of a scope, oldest first. Values of secret fields are not returned. Example:",
+		"",
+		`    $ boundary oplog list -scope-id o_1234567890 -aggregate-name iam_user -operation update`,
+		"",
+		"",
+	})
+}
+
+func exportHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary oplog export [options] [args]",
+		"",
+		"  Export the oplog entries of a scope, oldest first, as JSON lines. The entries are written as they are read from the controller, one page at a time, so the export can be arbitrarily large. Values of secret fields are not exported. Example:",
+		"",
+		`    $ boundary oplog export -scope-id global -recursive -start-time 2021-06-01T00:00:00Z -output oplog.jsonl`,
+		"",
+		"",
+	})
+}
+
+func watchHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary oplog watch [options] [args]",
+		"",
+		"  Watch for the oplog entries of a scope as they are committed, and write them as JSON lines until interrupted. Values of secret fields are not written. With -cursor-file, a watch resumes after the last entry written by the previous one. Example:",
+		"",
+		`    $ boundary oplog watch -scope-id o_1234567890 -aggregate-name iam_user -cursor-file iam_user.cursor`,
+		"",
+		"",
+	})
+}
+
+func generateEntryTableOutput(in *oplogentries.OplogEntry, withScope bool) []string {
+	ret := []string{
+		fmt.Sprintf("  ID:                 %s", in.Id),
+		fmt.Sprintf("    Created Time:     %s", in.CreatedTime.Local().Format(time.RFC1123)),
+		fmt.Sprintf("    Aggregate Name:   %s", in.AggregateName),
+	}
+	if in.ResourceId != "" {
+		ret = append(ret, fmt.Sprintf("    Resource ID:      %s", in.ResourceId))
+	}
+	if in.ResourceType != "" {
+		ret = append(ret, fmt.Sprintf("    Resource Type:    %s", in.ResourceType))
+	}
+	if withScope {
+		ret = append(ret, fmt.Sprintf("    Scope ID:         %s", in.ScopeId))
+	}
+	for _, m := range in.Messages {
+		ret = append(ret, fmt.Sprintf("    Message:          %s %s", m.Operation, m.TypeName))
+		if len(m.FieldMaskPaths) > 0 {
+			ret = append(ret, fmt.Sprintf("      Fields:         %s", strings.Join(m.FieldMaskPaths, ", ")))
+		}
+		if len(m.SetToNullPaths) > 0 {
+			ret = append(ret, fmt.Sprintf("      Null Fields:    %s", strings.Join(m.SetToNullPaths, ", ")))
+		}
+	}
+	return ret
+}
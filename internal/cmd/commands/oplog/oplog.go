@@ -0,0 +1,327 @@
+package oplog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/oplogentries"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*Command)(nil)
+var _ cli.CommandAutocomplete = (*Command)(nil)
+
+type Command struct {
+	*base.Command
+
+	Func string
+
+	flagAggregateName string
+	flagOperation     string
+	flagStartTime     string
+	flagEndTime       string
+	flagOutput        string
+	flagCursorFile    string
+}
+
+func (c *Command) Synopsis() string {
+	switch c.Func {
+	case "list":
+		return "List oplog entries"
+	case "export":
+		return "Export oplog entries as JSON lines"
+	case "watch":
+		return "Watch for new oplog entries"
+	}
+	return "Read the oplog"
+}
+
+var helpMap = map[string]func() string{
+	"base":   baseHelp,
+	"list":   listHelp,
+	"export": exportHelp,
+	"watch":  watchHelp,
+}
+
+var flagsMap = map[string][]string{
+	"list":   {"scope-id", "filter", "recursive"},
+	"export": {"scope-id", "filter", "recursive"},
+	"watch":  {"scope-id", "filter", "recursive"},
+}
+
+func (c *Command) Help() string {
+	if c.Func == "" {
+		return helpMap["base"]()
+	}
+	return helpMap[c.Func]() + c.Flags().Help()
+}
+
+func (c *Command) Flags() *base.FlagSets {
+	flagSets := base.FlagSetHTTP | base.FlagSetClient
+	if c.Func == "list" {
+		flagSets |= base.FlagSetOutputFormat
+	}
+	set := c.FlagSet(flagSets)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, resource.OplogEntry.String(), flagsMap[c.Func])
+
+	if c.Func == "" {
+		return set
+	}
+	f.StringVar(&base.StringVar{
+		Name:   "aggregate-name",
+		Target: &c.flagAggregateName,
+		Usage:  "If set, only entries for the given aggregate, the table of the changed resource (for example iam_user), are returned.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:       "operation",
+		Target:     &c.flagOperation,
+		Completion: complete.PredictSet("create", "update", "delete"),
+		Usage:      `If set, only entries with a change of the given operation are returned. Must be "create", "update" or "delete".`,
+	})
+	if c.Func != "watch" {
+		f.StringVar(&base.StringVar{
+			Name:   "start-time",
+			Target: &c.flagStartTime,
+			Usage:  "If set, only entries created at or after the given time, in RFC3339 format, are returned.",
+		})
+		f.StringVar(&base.StringVar{
+			Name:   "end-time",
+			Target: &c.flagEndTime,
+			Usage:  "If set, only entries created before the given time, in RFC3339 format, are returned.",
+		})
+	}
+	if c.Func == "export" {
+		f.StringVar(&base.StringVar{
+			Name:       "output",
+			Target:     &c.flagOutput,
+			Completion: complete.PredictFiles("*"),
+			Usage:      "If set, the entries are written to the given file instead of to standard out.",
+		})
+	}
+	if c.Func == "watch" {
+		f.StringVar(&base.StringVar{
+			Name:       "cursor-file",
+			Target:     &c.flagCursorFile,
+			Completion: complete.PredictFiles("*"),
+			Usage:      "If set, the position in the oplog is read from the given file when it exists and written to it after each batch of entries, so that a later watch resumes where this one stopped.",
+		})
+	}
+
+	return set
+}
+
+func (c *Command) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *Command) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *Command) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+
+	var opts []oplogentries.Option
+	if c.FlagFilter != "" {
+		opts = append(opts, oplogentries.WithFilter(c.FlagFilter))
+	}
+	if c.FlagRecursive {
+		opts = append(opts, oplogentries.WithRecursive(true))
+	}
+	if c.flagAggregateName != "" {
+		opts = append(opts, oplogentries.WithAggregateName(c.flagAggregateName))
+	}
+	switch c.flagOperation {
+	case "":
+	case "create", "update", "delete":
+		opts = append(opts, oplogentries.WithOperation(c.flagOperation))
+	default:
+		c.UI.Error(fmt.Sprintf("Unknown operation %q; must be create, update or delete", c.flagOperation))
+		return 1
+	}
+	for _, t := range []struct {
+		name, value string
+		opt         func(string) oplogentries.Option
+	}{
+		{"start-time", c.flagStartTime, oplogentries.WithStartTime},
+		{"end-time", c.flagEndTime, oplogentries.WithEndTime},
+	} {
+		if t.value == "" {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, t.value); err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing -%s as an RFC3339 time: %s", t.name, err.Error()))
+			return 1
+		}
+		opts = append(opts, t.opt(t.value))
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	oplogClient := oplogentries.NewClient(client)
+
+	switch c.Func {
+	case "export":
+		return c.export(oplogClient, opts)
+	case "watch":
+		return c.watch(oplogClient, opts)
+	}
+
+	listResult, err := oplogClient.List(c.Context, c.FlagScopeId, opts...)
+	if err != nil {
+		return c.printError(err)
+	}
+
+	entries := listResult.GetItems().([]*oplogentries.OplogEntry)
+	switch base.Format(c.UI) {
+	case "json":
+		if len(entries) == 0 {
+			c.UI.Output("null")
+			return 0
+		}
+		b, err := base.JsonFormatter{}.Format(entries)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+
+	case "table":
+		if len(entries) == 0 {
+			c.UI.Output("No oplog entries found")
+			return 0
+		}
+		output := []string{
+			"",
+			"Oplog entry information:",
+		}
+		for i, e := range entries {
+			if i > 0 {
+				output = append(output, "")
+			}
+			output = append(output, generateEntryTableOutput(e, c.FlagRecursive)...)
+		}
+		c.UI.Output(base.WrapForHelpText(output))
+	}
+	return 0
+}
+
+// export writes the entries to the output as JSON lines, a page at a time,
+// so that they are not all held in memory.
+func (c *Command) export(oplogClient *oplogentries.Client, opts []oplogentries.Option) int {
+	var w io.Writer = os.Stdout
+	if c.flagOutput != "" {
+		file, err := os.Create(c.flagOutput)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error opening output file: %s", err.Error()))
+			return 1
+		}
+		defer file.Close()
+		w = file
+	}
+	enc := json.NewEncoder(w)
+
+	var count int
+	var pageToken string
+	for {
+		page, err := oplogClient.ListPage(c.Context, c.FlagScopeId, pageToken, opts...)
+		if err != nil {
+			return c.printError(err)
+		}
+		for _, e := range page.Items {
+			if err := enc.Encode(e); err != nil {
+				c.UI.Error(fmt.Sprintf("Error writing oplog entry %s: %s", e.Id, err.Error()))
+				return 1
+			}
+			count++
+		}
+		if page.NextPageToken == "" {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+	if c.flagOutput != "" {
+		c.UI.Info(fmt.Sprintf("Exported %d oplog entries to %s", count, c.flagOutput))
+	}
+	return 0
+}
+
+// watch writes the entries to standard out as JSON lines as they are
+// committed, until the command is interrupted.
+func (c *Command) watch(oplogClient *oplogentries.Client, opts []oplogentries.Option) int {
+	var cursor string
+	if c.flagCursorFile != "" {
+		b, err := ioutil.ReadFile(c.flagCursorFile)
+		switch {
+		case err == nil:
+			cursor = strings.TrimSpace(string(b))
+		case !os.IsNotExist(err):
+			c.UI.Error(fmt.Sprintf("Error reading cursor file: %s", err.Error()))
+			return 1
+		}
+	}
+	enc := json.NewEncoder(os.Stdout)
+
+	for {
+		result, err := oplogClient.Watch(c.Context, c.FlagScopeId, cursor, opts...)
+		if err != nil {
+			if errors.Is(c.Context.Err(), context.Canceled) {
+				return 0
+			}
+			return c.printError(err)
+		}
+		for _, e := range result.Items {
+			if err := enc.Encode(e); err != nil {
+				c.UI.Error(fmt.Sprintf("Error writing oplog entry %s: %s", e.Id, err.Error()))
+				return 1
+			}
+		}
+		cursor = result.Cursor
+		if c.flagCursorFile != "" {
+			if err := ioutil.WriteFile(c.flagCursorFile, []byte(cursor+"\n"), 0600); err != nil {
+				c.UI.Error(fmt.Sprintf("Error writing cursor file: %s", err.Error()))
+				return 1
+			}
+		}
+	}
+}
+
+func (c *Command) printError(err error) int {
+	if apiErr := api.AsServerError(err); apiErr != nil {
+		c.UI.Error(fmt.Sprintf("Error from controller when performing %s on oplog entries: %s", c.Func, base.PrintApiError(apiErr)))
+		return 1
+	}
+	c.UI.Error(fmt.Sprintf("Error trying to %s oplog entries: %s", c.Func, err.Error()))
+	return 2
+}
@@ -115,6 +115,14 @@ func generateRoleTableOutput(in *roles.Role) string {
 		base.ScopeInfoForOutput(in.Scope, maxLength),
 	}
 
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
 	if len(in.Principals) > 0 {
 		ret = append(ret,
 			"",
@@ -56,7 +56,7 @@ var flagsMap = map[string][]string{
 	"update":            {"id", "name", "description", "grantscopeid", "version"},
 	"read":              {"id"},
 	"delete":            {"id"},
-	"list":              {"scope-id"},
+	"list":              {"scope-id", "filter", "recursive"},
 	"add-principals":    {"id", "principal", "version"},
 	"set-principals":    {"id", "principal", "version"},
 	"remove-principals": {"id", "principal", "version"},
@@ -222,6 +222,12 @@ func (c *Command) Run(args []string) int {
 			err = nil
 		}
 	case "list":
+		if c.FlagFilter != "" {
+			opts = append(opts, roles.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, roles.WithRecursive(true))
+		}
 		listResult, err = roleClient.List(c.Context, c.FlagScopeId, opts...)
 	case "add-principals":
 		result, err = roleClient.AddPrincipals(c.Context, c.FlagId, version, principals, opts...)
@@ -302,6 +308,11 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Version:     %d", r.Version),
 					)
 				}
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:    %s", r.ScopeId),
+					)
+				}
 				if r.Name != "" {
 					output = append(output,
 						fmt.Sprintf("    Name:        %s", r.Name),
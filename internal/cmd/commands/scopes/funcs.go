@@ -7,6 +7,16 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/base"
 )
 
+func rotateKeysHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary scopes rotate-keys [options] [args]",
+		"",
+		"  Rotates the keys of a scope given its ID. New versions of the root key and of the data keys of the scope are created and used to encrypt values from then on. Values encrypted with previous versions are re-encrypted in the background; the progress is shown when reading the scope. Example:",
+		"",
+		`    $ boundary scopes rotate-keys -id o_1234567890`,
+	})
+}
+
 func generateScopeTableOutput(in *scopes.Scope) string {
 	nonAttributeMap := map[string]interface{}{
 		"ID":           in.Id,
@@ -33,5 +43,32 @@ func generateScopeTableOutput(in *scopes.Scope) string {
 		base.ScopeInfoForOutput(in.Scope, maxLength),
 	}
 
+	if in.KeyRotation != nil {
+		rotationMap := map[string]interface{}{
+			"ID":           in.KeyRotation.Id,
+			"Status":       in.KeyRotation.Status,
+			"Rewrapped":    in.KeyRotation.RewrappedCount,
+			"Pending":      in.KeyRotation.PendingCount,
+			"Created Time": in.KeyRotation.CreatedTime.Local().Format(time.RFC1123),
+			"Updated Time": in.KeyRotation.UpdatedTime.Local().Format(time.RFC1123),
+		}
+		if in.KeyRotation.LastError != "" {
+			rotationMap["Last Error"] = in.KeyRotation.LastError
+		}
+		ret = append(ret,
+			"",
+			"  Key Rotation:",
+			base.WrapMap(4, base.MaxAttributesLength(rotationMap, nil, nil), rotationMap),
+		)
+	}
+
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
 	return base.WrapForHelpText(ret)
 }
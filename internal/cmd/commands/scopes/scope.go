@@ -27,23 +27,34 @@ type Command struct {
 }
 
 func (c *Command) Synopsis() string {
+	if c.Func == "rotate-keys" {
+		return "Rotate the keys of a scope"
+	}
 	return common.SynopsisFunc(c.Func, "scope")
 }
 
+var helpMap = func() map[string]func() string {
+	ret := common.HelpMap("scope")
+	ret["rotate-keys"] = rotateKeysHelp
+	return ret
+}
+
 var flagsMap = map[string][]string{
 	"create": {"scope-id", "name", "description", "skip-admin-role-creation", "skip-default-role-creation"},
 	"update": {"id", "name", "description", "version"},
 	"read":   {"id"},
 	"delete": {"id"},
-	"list":   {"scope-id"},
+	"list":   {"scope-id", "filter", "recursive"},
+
+	"rotate-keys": {"id"},
 }
 
 func (c *Command) Help() string {
-	helpMap := common.HelpMap("scope")
+	hm := helpMap()
 	if c.Func == "" {
-		return helpMap["base"]()
+		return hm["base"]()
 	}
-	return helpMap[c.Func]() + c.Flags().Help()
+	return hm[c.Func]() + c.Flags().Help()
 }
 
 func (c *Command) Flags() *base.FlagSets {
@@ -132,7 +143,7 @@ func (c *Command) Run(args []string) int {
 	// Perform check-and-set when needed
 	var version uint32
 	switch c.Func {
-	case "create", "read", "delete", "list":
+	case "create", "read", "delete", "list", "rotate-keys":
 		// These don't udpate so don't need the existing version
 	default:
 		switch c.FlagVersion {
@@ -161,7 +172,15 @@ func (c *Command) Run(args []string) int {
 			err = nil
 		}
 	case "list":
+		if c.FlagFilter != "" {
+			opts = append(opts, scopes.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, scopes.WithRecursive(true))
+		}
 		listResult, err = scopeClient.List(c.Context, c.FlagScopeId, opts...)
+	case "rotate-keys":
+		result, err = scopeClient.RotateKeys(c.Context, c.FlagId, opts...)
 	}
 
 	plural := "scope"
@@ -229,6 +248,11 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Version:      %d", s.Version),
 					)
 				}
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:     %s", s.ScopeId),
+					)
+				}
 				if s.Name != "" {
 					output = append(output,
 						fmt.Sprintf("    Name:         %s", s.Name),
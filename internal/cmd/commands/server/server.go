@@ -140,6 +140,11 @@ func (c *Command) Run(args []string) int {
 		return 1
 	}
 
+	if err := c.SetupEventing(c.Config.Events); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
 	if err := c.SetupKMSes(c.UI, c.Config); err != nil {
 		c.UI.Error(err.Error())
 		return 1
@@ -214,8 +219,10 @@ func (c *Command) Run(args []string) int {
 		}
 	}
 	if c.Config.Worker != nil {
-		if !foundProxy {
-			c.UI.Error(`Config activates worker but no listener with "proxy" purpose found`)
+		// A worker with upstream workers can receive the connections of its
+		// sessions through them alone.
+		if !foundProxy && len(c.Config.Worker.Upstreams) == 0 {
+			c.UI.Error(`Config activates worker but no listener with "proxy" purpose or "upstreams" found`)
 			return 1
 		}
 		if c.Config.Controller != nil {
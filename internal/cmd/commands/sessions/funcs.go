@@ -24,6 +24,7 @@ func generateSessionTableOutput(in *sessions.Session) string {
 		"Host ID":         in.HostId,
 		"Endpoint":        in.Endpoint,
 		"Status":          in.Status,
+		"Recording":       in.RecordingEnabled,
 	}
 	if len(strings.TrimSpace(in.TerminationReason)) > 0 {
 		nonAttributeMap["Termination Reason"] = in.TerminationReason
@@ -61,6 +62,46 @@ func generateSessionTableOutput(in *sessions.Session) string {
 		}
 	}
 
+	var recordingMaps []map[string]interface{}
+	if len(in.Recordings) > 0 {
+		for _, rec := range in.Recordings {
+			m := map[string]interface{}{
+				"Connection ID": rec.ConnectionId,
+				"Worker ID":     rec.WorkerId,
+				"Storage Path":  rec.StoragePath,
+				"Bytes Up":      rec.BytesUp,
+				"Bytes Down":    rec.BytesDown,
+				"Start Time":    rec.StartTime.Local().Format(time.RFC1123),
+				"End Time":      rec.EndTime.Local().Format(time.RFC1123),
+			}
+			recordingMaps = append(recordingMaps, m)
+		}
+		if l := len("Connection ID"); l > maxLength {
+			maxLength = l
+		}
+	}
+
+	var connectionMaps []map[string]interface{}
+	if len(in.Connections) > 0 {
+		for _, c := range in.Connections {
+			m := map[string]interface{}{
+				"ID":               c.Id,
+				"Client Address":   fmt.Sprintf("%s:%d", c.ClientTcpAddress, c.ClientTcpPort),
+				"Endpoint Address": fmt.Sprintf("%s:%d", c.EndpointTcpAddress, c.EndpointTcpPort),
+				"Bytes Up":         c.BytesUp,
+				"Bytes Down":       c.BytesDown,
+				"Created Time":     c.CreatedTime.Local().Format(time.RFC1123),
+			}
+			if len(strings.TrimSpace(c.ClosedReason)) > 0 {
+				m["Closed Reason"] = c.ClosedReason
+			}
+			connectionMaps = append(connectionMaps, m)
+		}
+		if l := len("Endpoint Address"); l > maxLength {
+			maxLength = l
+		}
+	}
+
 	ret := []string{
 		"",
 		"Session information:",
@@ -70,6 +111,14 @@ func generateSessionTableOutput(in *sessions.Session) string {
 		base.ScopeInfoForOutput(in.Scope, maxLength),
 	}
 
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
 	if len(in.States) > 0 {
 		ret = append(ret,
 			"",
@@ -95,5 +144,31 @@ func generateSessionTableOutput(in *sessions.Session) string {
 		}
 	}
 
+	if len(in.Connections) > 0 {
+		ret = append(ret,
+			"",
+			"  Connections:",
+		)
+		for _, m := range connectionMaps {
+			ret = append(ret,
+				base.WrapMap(4, maxLength, m),
+				"",
+			)
+		}
+	}
+
+	if len(in.Recordings) > 0 {
+		ret = append(ret,
+			"",
+			"  Recordings:",
+		)
+		for _, m := range recordingMaps {
+			ret = append(ret,
+				base.WrapMap(4, maxLength, m),
+				"",
+			)
+		}
+	}
+
 	return base.WrapForHelpText(ret)
 }
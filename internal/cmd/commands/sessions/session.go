@@ -30,7 +30,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":   {"id"},
 	"cancel": {"id"},
-	"list":   {"scope-id"},
+	"list":   {"scope-id", "filter", "recursive"},
 }
 
 func (c *Command) Help() string {
@@ -119,7 +119,14 @@ func (c *Command) Run(args []string) int {
 	case "cancel":
 		result, err = sessionClient.Cancel(c.Context, c.FlagId, 0, sessions.WithAutomaticVersioning(true))
 	case "list":
-		listResult, err = sessionClient.List(c.Context, c.FlagScopeId)
+		var opts []sessions.Option
+		if c.FlagFilter != "" {
+			opts = append(opts, sessions.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, sessions.WithRecursive(true))
+		}
+		listResult, err = sessionClient.List(c.Context, c.FlagScopeId, opts...)
 	}
 
 	plural := "session"
@@ -174,6 +181,11 @@ func (c *Command) Run(args []string) int {
 					fmt.Sprintf("    User ID:          %s", t.UserId),
 					fmt.Sprintf("    Target ID:        %s", t.TargetId),
 				)
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:         %s", t.ScopeId),
+					)
+				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
 		}
@@ -24,15 +24,34 @@ func hostSetSynopsisFunc(inFunc string) string {
 	return wordwrap.WrapString(fmt.Sprintf("%s a target", in), base.TermWidth)
 }
 
+func credentialLibrarySynopsisFunc(inFunc string) string {
+	var in string
+	switch {
+	case strings.HasPrefix(inFunc, "add"):
+		in = "Add credential libraries to"
+	case strings.HasPrefix(inFunc, "set"):
+		in = "Set the full contents of the credential libraries on"
+	case strings.HasPrefix(inFunc, "remove"):
+		in = "Remove credential libraries from"
+	}
+	return wordwrap.WrapString(fmt.Sprintf("%s a target", in), base.TermWidth)
+}
+
 func generateTargetTableOutput(in *targets.Target) string {
 	nonAttributeMap := map[string]interface{}{
-		"ID":                       in.Id,
-		"Version":                  in.Version,
-		"Type":                     in.Type,
-		"Created Time":             in.CreatedTime.Local().Format(time.RFC1123),
-		"Updated Time":             in.UpdatedTime.Local().Format(time.RFC1123),
-		"Session Connection Limit": in.SessionConnectionLimit,
-		"Session Max Seconds":      in.SessionMaxSeconds,
+		"ID":                              in.Id,
+		"Version":                         in.Version,
+		"Type":                            in.Type,
+		"Created Time":                    in.CreatedTime.Local().Format(time.RFC1123),
+		"Updated Time":                    in.UpdatedTime.Local().Format(time.RFC1123),
+		"Session Connection Limit":        in.SessionConnectionLimit,
+		"Session Max Seconds":             in.SessionMaxSeconds,
+		"Enable Session Recording":        in.EnableSessionRecording,
+		"Connection Max Bytes Per Second": in.ConnectionMaxBytesPerSecond,
+		"Session Max Bytes Per Second":    in.SessionMaxBytesPerSecond,
+		"Session Idle Timeout Seconds":    in.SessionIdleTimeoutSeconds,
+		"Host Selection Strategy":         in.HostSelectionStrategy,
+		"Restrict Host Id":                in.RestrictHostId,
 	}
 
 	if in.Name != "" {
@@ -41,6 +60,9 @@ func generateTargetTableOutput(in *targets.Target) string {
 	if in.Description != "" {
 		nonAttributeMap["Description"] = in.Description
 	}
+	if in.WorkerFilter != "" {
+		nonAttributeMap["Worker Filter"] = in.WorkerFilter
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, in.Attributes, keySubstMap)
 
@@ -58,6 +80,20 @@ func generateTargetTableOutput(in *targets.Target) string {
 		}
 	}
 
+	var credentialLibraryMaps []map[string]interface{}
+	if len(in.CredentialLibraries) > 0 {
+		for _, lib := range in.CredentialLibraries {
+			m := map[string]interface{}{
+				"ID":                  lib.Id,
+				"Credential Store ID": lib.CredentialStoreId,
+			}
+			credentialLibraryMaps = append(credentialLibraryMaps, m)
+		}
+		if l := len("Credential Store ID"); l > maxLength {
+			maxLength = l
+		}
+	}
+
 	ret := []string{
 		"",
 		"Target information:",
@@ -67,6 +103,14 @@ func generateTargetTableOutput(in *targets.Target) string {
 		base.ScopeInfoForOutput(in.Scope, maxLength),
 	}
 
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
 	if len(in.HostSets) > 0 {
 		ret = append(ret,
 			"",
@@ -80,6 +124,19 @@ func generateTargetTableOutput(in *targets.Target) string {
 		}
 	}
 
+	if len(in.CredentialLibraries) > 0 {
+		ret = append(ret,
+			"",
+			"  Credential Libraries:",
+		)
+		for _, m := range credentialLibraryMaps {
+			ret = append(ret,
+				base.WrapMap(4, maxLength, m),
+				"",
+			)
+		}
+	}
+
 	if len(in.Attributes) > 0 {
 		ret = append(ret,
 			"  Attributes:",
@@ -122,7 +179,10 @@ func generateAuthorizationTableOutput(in *targets.SessionAuthorization) string {
 }
 
 var keySubstMap = map[string]string{
-	"default_port": "Default Port",
+	"default_port":   "Default Port",
+	"principals":     "Principals",
+	"ca_public_key":  "CA Public Key",
+	"ca_public_keys": "CA Public Keys",
 }
 
 func exampleOutput() string {
@@ -23,14 +23,17 @@ type Command struct {
 
 	Func string
 
-	flagHostSets []string
-	flagHostId   string
+	flagHostSets            []string
+	flagCredentialLibraries []string
+	flagHostId              string
 }
 
 func (c *Command) Synopsis() string {
 	switch c.Func {
 	case "add-host-sets", "set-host-sets", "remove-host-sets":
 		return hostSetSynopsisFunc(c.Func)
+	case "add-credential-libraries", "set-credential-libraries", "remove-credential-libraries":
+		return credentialLibrarySynopsisFunc(c.Func)
 	case "authorize-session":
 		return "Request session authorization against the target"
 	default:
@@ -42,10 +45,14 @@ var flagsMap = map[string][]string{
 	"authorize-session": {"id", "host-id"},
 	"read":              {"id"},
 	"delete":            {"id"},
-	"list":              {"scope-id"},
+	"list":              {"scope-id", "filter", "recursive"},
 	"add-host-sets":     {"id", "host-set", "version"},
 	"remove-host-sets":  {"id", "host-set", "version"},
 	"set-host-sets":     {"id", "host-set", "version"},
+
+	"add-credential-libraries":    {"id", "credential-library", "version"},
+	"remove-credential-libraries": {"id", "credential-library", "version"},
+	"set-credential-libraries":    {"id", "credential-library", "version"},
 }
 
 func (c *Command) Help() string {
@@ -74,6 +81,14 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary targets create tcp -name prodops -description "For ProdOps usage"`,
 			"",
+			"    Create a udp-type target:",
+			"",
+			`      $ boundary targets create udp -name dns -description "For DNS usage"`,
+			"",
+			"    Create an ssh-type target:",
+			"",
+			`      $ boundary targets create ssh -name prodops -description "For ProdOps usage" -principal ubuntu`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	case "update":
@@ -86,6 +101,14 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary targets update tcp -id ttcp_1234567890 -name devops -description "For DevOps usage"`,
 			"",
+			"    Update a udp-type target:",
+			"",
+			`      $ boundary targets update udp -id tudp_1234567890 -name dns -description "For DNS usage"`,
+			"",
+			"    Update an ssh-type target:",
+			"",
+			`      $ boundary targets update ssh -id tssh_1234567890 -name devops -description "For DevOps usage"`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	case "add-host-sets":
@@ -124,6 +147,42 @@ func (c *Command) Help() string {
 			"",
 			"",
 		})
+	case "add-credential-libraries":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary target add-credential-libraries [options] [args]",
+			"",
+			"  This command allows adding credential-library resources to target resources. Example:",
+			"",
+			"    Add credential-library resources to a tcp-type target:",
+			"",
+			`      $ boundary targets add-credential-libraries -id ttcp_1234567890 -credential-library clst_1234567890 -credential-library clst_0987654321`,
+			"",
+			"",
+		})
+	case "remove-credential-libraries":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary target remove-credential-libraries [options] [args]",
+			"",
+			"  This command allows removing credential-library resources from target resources. Example:",
+			"",
+			"    Remove credential-library resources from a tcp-type target:",
+			"",
+			`      $ boundary targets remove-credential-libraries -id ttcp_1234567890 -credential-library clst_1234567890 -credential-library clst_0987654321`,
+			"",
+			"",
+		})
+	case "set-credential-libraries":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary target set-credential-libraries [options] [args]",
+			"",
+			"  This command allows setting the complete set of credential-library resources on a target resource. Example:",
+			"",
+			"    Set credential-library resources on a tcp-type target:",
+			"",
+			`      $ boundary targets set-credential-libraries -id ttcp_1234567890 -credential-library clst_1234567890`,
+			"",
+			"",
+		})
 	case "authorize-session":
 		helpStr = base.WrapForHelpText([]string{
 			"Usage: boundary target authorize-session [options] [args]",
@@ -159,11 +218,17 @@ func (c *Command) Flags() *base.FlagSets {
 				Target: &c.flagHostSets,
 				Usage:  "The host-set resources to add, remove, or set. May be specified multiple times.",
 			})
+		case "credential-library":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "credential-library",
+				Target: &c.flagCredentialLibraries,
+				Usage:  "The credential-library resources to add, remove, or set. May be specified multiple times.",
+			})
 		case "host-id":
 			f.StringVar(&base.StringVar{
 				Name:   "host-id",
 				Target: &c.flagHostId,
-				Usage:  "The ID of a specific host to connect to out of the hosts from the target's host sets. If not specified, one is chosen at random.",
+				Usage:  "The ID of a specific host to connect to out of the hosts from the target's host sets. If not specified, one is chosen using the target's host selection strategy. Cannot be used if the target restricts the host ID.",
 			})
 		}
 	}
@@ -248,6 +313,26 @@ func (c *Command) Run(args []string) int {
 				hostSets = nil
 			}
 		}
+	}
+
+	credentialLibraries := c.flagCredentialLibraries
+	switch c.Func {
+	case "add-credential-libraries", "remove-credential-libraries":
+		if len(c.flagCredentialLibraries) == 0 {
+			c.UI.Error("No credential-libraries supplied via -credential-library")
+			return 1
+		}
+
+	case "set-credential-libraries":
+		switch len(c.flagCredentialLibraries) {
+		case 0:
+			c.UI.Error("No credential-libraries supplied via -credential-library")
+			return 1
+		case 1:
+			if c.flagCredentialLibraries[0] == "null" {
+				credentialLibraries = nil
+			}
+		}
 	case "authorize-session":
 		if len(c.flagHostId) != 0 {
 			opts = append(opts, targets.WithHostId(c.flagHostId))
@@ -257,7 +342,8 @@ func (c *Command) Run(args []string) int {
 	// Perform check-and-set when needed
 	var version uint32
 	switch c.Func {
-	case "add-host-sets", "remove-host-sets", "set-host-sets":
+	case "add-host-sets", "remove-host-sets", "set-host-sets",
+		"add-credential-libraries", "remove-credential-libraries", "set-credential-libraries":
 		switch c.FlagVersion {
 		case 0:
 			opts = append(opts, targets.WithAutomaticVersioning(true))
@@ -286,6 +372,12 @@ func (c *Command) Run(args []string) int {
 			err = nil
 		}
 	case "list":
+		if c.FlagFilter != "" {
+			opts = append(opts, targets.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, targets.WithRecursive(true))
+		}
 		listResult, err = targetClient.List(c.Context, c.FlagScopeId, opts...)
 	case "add-host-sets":
 		result, err = targetClient.AddHostSets(c.Context, c.FlagId, version, hostSets, opts...)
@@ -293,6 +385,12 @@ func (c *Command) Run(args []string) int {
 		result, err = targetClient.RemoveHostSets(c.Context, c.FlagId, version, hostSets, opts...)
 	case "set-host-sets":
 		result, err = targetClient.SetHostSets(c.Context, c.FlagId, version, hostSets, opts...)
+	case "add-credential-libraries":
+		result, err = targetClient.AddCredentialLibraries(c.Context, c.FlagId, version, credentialLibraries, opts...)
+	case "remove-credential-libraries":
+		result, err = targetClient.RemoveCredentialLibraries(c.Context, c.FlagId, version, credentialLibraries, opts...)
+	case "set-credential-libraries":
+		result, err = targetClient.SetCredentialLibraries(c.Context, c.FlagId, version, credentialLibraries, opts...)
 	case "authorize-session":
 		sar, err = targetClient.AuthorizeSession(c.Context, c.FlagId, opts...)
 	}
@@ -366,6 +464,11 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Type:         %s", m.Type),
 					)
 				}
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:     %s", m.ScopeId),
+					)
+				}
 				if m.Name != "" {
 					output = append(output,
 						fmt.Sprintf("    Name:         %s", m.Name),
@@ -21,10 +21,17 @@ var _ cli.CommandAutocomplete = (*TcpCommand)(nil)
 type TcpCommand struct {
 	*base.Command
 
-	Func                       string
-	flagDefaultPort            string
-	flagSessionMaxSeconds      string
-	flagSessionConnectionLimit string
+	Func                            string
+	flagDefaultPort                 string
+	flagSessionMaxSeconds           string
+	flagSessionConnectionLimit      string
+	flagEnableSessionRecording      string
+	flagConnectionMaxBytesPerSecond string
+	flagSessionMaxBytesPerSecond    string
+	flagSessionIdleTimeoutSeconds   string
+	flagHostSelectionStrategy       string
+	flagRestrictHostId              string
+	flagWorkerFilter                string
 }
 
 func (c *TcpCommand) Synopsis() string {
@@ -32,8 +39,8 @@ func (c *TcpCommand) Synopsis() string {
 }
 
 var tcpFlagsMap = map[string][]string{
-	"create": {"scope-id", "name", "description", "default-port", "session-max-seconds", "session-connection-limit"},
-	"update": {"id", "name", "description", "version", "default-port", "session-max-seconds", "session-connection-limit"},
+	"create": {"scope-id", "name", "description", "default-port", "session-max-seconds", "session-connection-limit", "enable-session-recording", "connection-max-bytes-per-second", "session-max-bytes-per-second", "session-idle-timeout-seconds", "host-selection-strategy", "restrict-host-id", "worker-filter"},
+	"update": {"id", "name", "description", "version", "default-port", "session-max-seconds", "session-connection-limit", "enable-session-recording", "connection-max-bytes-per-second", "session-max-bytes-per-second", "session-idle-timeout-seconds", "host-selection-strategy", "restrict-host-id", "worker-filter"},
 }
 
 func (c *TcpCommand) Help() string {
@@ -89,6 +96,49 @@ func (c *TcpCommand) Flags() *base.FlagSets {
 				Target: &c.flagSessionConnectionLimit,
 				Usage:  "The maximum number of connections allowed for a session. -1 means unlimited.",
 			})
+		case "enable-session-recording":
+			f.StringVar(&base.StringVar{
+				Name:   "enable-session-recording",
+				Target: &c.flagEnableSessionRecording,
+				Usage:  "If true, workers record the data proxied for each connection of a session.",
+			})
+		case "connection-max-bytes-per-second":
+			f.StringVar(&base.StringVar{
+				Name:   "connection-max-bytes-per-second",
+				Target: &c.flagConnectionMaxBytesPerSecond,
+				Usage:  "The maximum throughput of each connection of a session in bytes per second, enforced by the worker in each direction. 0 means unlimited.",
+			})
+		case "session-max-bytes-per-second":
+			f.StringVar(&base.StringVar{
+				Name:   "session-max-bytes-per-second",
+				Target: &c.flagSessionMaxBytesPerSecond,
+				Usage:  "The maximum combined throughput of all the connections of a session in bytes per second, enforced by the worker in each direction. 0 means unlimited.",
+			})
+		case "session-idle-timeout-seconds":
+			f.StringVar(&base.StringVar{
+				Name:   "session-idle-timeout-seconds",
+				Target: &c.flagSessionIdleTimeoutSeconds,
+				Usage:  `The time a session may go without any data being proxied before it is terminated. Can be specified as an integer number of seconds or a duration string. 0 means no idle timeout.`,
+			})
+		case "host-selection-strategy":
+			f.StringVar(&base.StringVar{
+				Name:       "host-selection-strategy",
+				Target:     &c.flagHostSelectionStrategy,
+				Completion: complete.PredictSet("random", "round-robin", "least-active-sessions", "sticky-per-user"),
+				Usage:      `How the host of a session is chosen when one is not requested with "-host-id": "random", "round-robin", "least-active-sessions" or "sticky-per-user".`,
+			})
+		case "restrict-host-id":
+			f.StringVar(&base.StringVar{
+				Name:   "restrict-host-id",
+				Target: &c.flagRestrictHostId,
+				Usage:  `If true, users cannot request the host of a session with "-host-id" and it is always chosen by the host selection strategy.`,
+			})
+		case "worker-filter":
+			f.StringVar(&base.StringVar{
+				Name:   "worker-filter",
+				Target: &c.flagWorkerFilter,
+				Usage:  `A filter expression which workers must match to proxy the target's sessions, for example '"us-east" in "/tags/region"'. It is matched against each worker's "name" and "tags".`,
+			})
 		}
 	}
 
@@ -194,6 +244,94 @@ func (c *TcpCommand) Run(args []string) int {
 		opts = append(opts, targets.WithSessionConnectionLimit(int32(limit)))
 	}
 
+	switch c.flagEnableSessionRecording {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultEnableSessionRecording())
+	default:
+		enable, err := strconv.ParseBool(c.flagEnableSessionRecording)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagEnableSessionRecording, err))
+			return 1
+		}
+		opts = append(opts, targets.WithEnableSessionRecording(enable))
+	}
+
+	switch c.flagConnectionMaxBytesPerSecond {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultConnectionMaxBytesPerSecond())
+	default:
+		limit, err := strconv.ParseUint(c.flagConnectionMaxBytesPerSecond, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagConnectionMaxBytesPerSecond, err))
+			return 1
+		}
+		opts = append(opts, targets.WithConnectionMaxBytesPerSecond(uint32(limit)))
+	}
+
+	switch c.flagSessionMaxBytesPerSecond {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultSessionMaxBytesPerSecond())
+	default:
+		limit, err := strconv.ParseUint(c.flagSessionMaxBytesPerSecond, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionMaxBytesPerSecond, err))
+			return 1
+		}
+		opts = append(opts, targets.WithSessionMaxBytesPerSecond(uint32(limit)))
+	}
+
+	switch c.flagSessionIdleTimeoutSeconds {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultSessionIdleTimeoutSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagSessionIdleTimeoutSeconds, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagSessionIdleTimeoutSeconds)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionIdleTimeoutSeconds, err))
+				return 1
+			}
+			final = uint32(dur.Seconds())
+		}
+		opts = append(opts, targets.WithSessionIdleTimeoutSeconds(final))
+	}
+
+	switch c.flagHostSelectionStrategy {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultHostSelectionStrategy())
+	default:
+		opts = append(opts, targets.WithHostSelectionStrategy(c.flagHostSelectionStrategy))
+	}
+
+	switch c.flagRestrictHostId {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultRestrictHostId())
+	default:
+		restrict, err := strconv.ParseBool(c.flagRestrictHostId)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagRestrictHostId, err))
+			return 1
+		}
+		opts = append(opts, targets.WithRestrictHostId(restrict))
+	}
+
+	switch c.flagWorkerFilter {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultWorkerFilter())
+	default:
+		opts = append(opts, targets.WithWorkerFilter(c.flagWorkerFilter))
+	}
+
 	targetClient := targets.NewClient(client)
 
 	// Perform check-and-set when needed
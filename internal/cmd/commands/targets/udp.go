@@ -0,0 +1,384 @@
+package targets
+
+import (
+	"fmt"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*UdpCommand)(nil)
+var _ cli.CommandAutocomplete = (*UdpCommand)(nil)
+
+type UdpCommand struct {
+	*base.Command
+
+	Func                            string
+	flagDefaultPort                 string
+	flagSessionMaxSeconds           string
+	flagSessionConnectionLimit      string
+	flagEnableSessionRecording      string
+	flagConnectionMaxBytesPerSecond string
+	flagSessionMaxBytesPerSecond    string
+	flagSessionIdleTimeoutSeconds   string
+	flagHostSelectionStrategy       string
+	flagRestrictHostId              string
+	flagWorkerFilter                string
+}
+
+func (c *UdpCommand) Synopsis() string {
+	return fmt.Sprintf("%s a udp-type target", textproto.CanonicalMIMEHeaderKey(c.Func))
+}
+
+var udpFlagsMap = map[string][]string{
+	"create": {"scope-id", "name", "description", "default-port", "session-max-seconds", "session-connection-limit", "enable-session-recording", "connection-max-bytes-per-second", "session-max-bytes-per-second", "session-idle-timeout-seconds", "host-selection-strategy", "restrict-host-id", "worker-filter"},
+	"update": {"id", "name", "description", "version", "default-port", "session-max-seconds", "session-connection-limit", "enable-session-recording", "connection-max-bytes-per-second", "session-max-bytes-per-second", "session-idle-timeout-seconds", "host-selection-strategy", "restrict-host-id", "worker-filter"},
+}
+
+func (c *UdpCommand) Help() string {
+	var info string
+	switch c.Func {
+	case "create":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary targets create udp [options] [args]",
+			"",
+			"  Create a udp-type target. Example:",
+			"",
+			`    $ boundary targets create udp -name dns -description "Udp target for ProdOps"`,
+			"",
+			"",
+		})
+
+	case "update":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary targets udp update [options] [args]",
+			"",
+			"  Update a udp-type target given its ID. Example:",
+			"",
+			`    $ boundary targets udp update -id tudp_1234567890 -name "dns" -description "Udp target for DevOps"`,
+			"",
+			"",
+		})
+	}
+	return info + c.Flags().Help()
+}
+
+func (c *UdpCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "udp-type target", udpFlagsMap[c.Func])
+
+	for _, name := range udpFlagsMap[c.Func] {
+		switch name {
+		case "default-port":
+			f.StringVar(&base.StringVar{
+				Name:   "default-port",
+				Target: &c.flagDefaultPort,
+				Usage:  "The default port to set on the target.",
+			})
+		case "session-max-seconds":
+			f.StringVar(&base.StringVar{
+				Name:   "session-max-seconds",
+				Target: &c.flagSessionMaxSeconds,
+				Usage:  `The maximum lifetime of the session, including all connections. Can be specified as an integer number of seconds or a duration string.`,
+			})
+		case "session-connection-limit":
+			f.StringVar(&base.StringVar{
+				Name:   "session-connection-limit",
+				Target: &c.flagSessionConnectionLimit,
+				Usage:  "The maximum number of connections allowed for a session. -1 means unlimited.",
+			})
+		case "enable-session-recording":
+			f.StringVar(&base.StringVar{
+				Name:   "enable-session-recording",
+				Target: &c.flagEnableSessionRecording,
+				Usage:  "If true, workers record the data proxied for each connection of a session.",
+			})
+		case "connection-max-bytes-per-second":
+			f.StringVar(&base.StringVar{
+				Name:   "connection-max-bytes-per-second",
+				Target: &c.flagConnectionMaxBytesPerSecond,
+				Usage:  "The maximum throughput of each connection of a session in bytes per second, enforced by the worker in each direction. 0 means unlimited.",
+			})
+		case "session-max-bytes-per-second":
+			f.StringVar(&base.StringVar{
+				Name:   "session-max-bytes-per-second",
+				Target: &c.flagSessionMaxBytesPerSecond,
+				Usage:  "The maximum combined throughput of all the connections of a session in bytes per second, enforced by the worker in each direction. 0 means unlimited.",
+			})
+		case "session-idle-timeout-seconds":
+			f.StringVar(&base.StringVar{
+				Name:   "session-idle-timeout-seconds",
+				Target: &c.flagSessionIdleTimeoutSeconds,
+				Usage:  `The time a session may go without any data being proxied before it is terminated. Can be specified as an integer number of seconds or a duration string. 0 means no idle timeout.`,
+			})
+		case "host-selection-strategy":
+			f.StringVar(&base.StringVar{
+				Name:       "host-selection-strategy",
+				Target:     &c.flagHostSelectionStrategy,
+				Completion: complete.PredictSet("random", "round-robin", "least-active-sessions", "sticky-per-user"),
+				Usage:      `How the host of a session is chosen when one is not requested with "-host-id": "random", "round-robin", "least-active-sessions" or "sticky-per-user".`,
+			})
+		case "restrict-host-id":
+			f.StringVar(&base.StringVar{
+				Name:   "restrict-host-id",
+				Target: &c.flagRestrictHostId,
+				Usage:  `If true, users cannot request the host of a session with "-host-id" and it is always chosen by the host selection strategy.`,
+			})
+		case "worker-filter":
+			f.StringVar(&base.StringVar{
+				Name:   "worker-filter",
+				Target: &c.flagWorkerFilter,
+				Usage:  `A filter expression which workers must match to proxy the target's sessions, for example '"us-east" in "/tags/region"'. It is matched against each worker's "name" and "tags".`,
+			})
+		}
+	}
+
+	return set
+}
+
+func (c *UdpCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *UdpCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *UdpCommand) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(udpFlagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(udpFlagsMap[c.Func], "scope-id") && c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []targets.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultName())
+	default:
+		opts = append(opts, targets.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultDescription())
+	default:
+		opts = append(opts, targets.WithDescription(c.FlagDescription))
+	}
+
+	switch c.flagDefaultPort {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultUdpTargetDefaultPort())
+	default:
+		port, err := strconv.ParseUint(c.flagDefaultPort, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagDefaultPort, err))
+			return 1
+		}
+		opts = append(opts, targets.WithUdpTargetDefaultPort(uint32(port)))
+	}
+
+	switch c.flagSessionMaxSeconds {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultSessionMaxSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagSessionMaxSeconds, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagSessionMaxSeconds)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionMaxSeconds, err))
+				return 1
+			}
+			final = uint32(dur.Seconds())
+		}
+		opts = append(opts, targets.WithSessionMaxSeconds(final))
+	}
+
+	switch c.flagSessionConnectionLimit {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultSessionConnectionLimit())
+	default:
+		limit, err := strconv.ParseInt(c.flagSessionConnectionLimit, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionConnectionLimit, err))
+			return 1
+		}
+		opts = append(opts, targets.WithSessionConnectionLimit(int32(limit)))
+	}
+
+	switch c.flagEnableSessionRecording {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultEnableSessionRecording())
+	default:
+		enable, err := strconv.ParseBool(c.flagEnableSessionRecording)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagEnableSessionRecording, err))
+			return 1
+		}
+		opts = append(opts, targets.WithEnableSessionRecording(enable))
+	}
+
+	switch c.flagConnectionMaxBytesPerSecond {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultConnectionMaxBytesPerSecond())
+	default:
+		limit, err := strconv.ParseUint(c.flagConnectionMaxBytesPerSecond, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagConnectionMaxBytesPerSecond, err))
+			return 1
+		}
+		opts = append(opts, targets.WithConnectionMaxBytesPerSecond(uint32(limit)))
+	}
+
+	switch c.flagSessionMaxBytesPerSecond {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultSessionMaxBytesPerSecond())
+	default:
+		limit, err := strconv.ParseUint(c.flagSessionMaxBytesPerSecond, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionMaxBytesPerSecond, err))
+			return 1
+		}
+		opts = append(opts, targets.WithSessionMaxBytesPerSecond(uint32(limit)))
+	}
+
+	switch c.flagSessionIdleTimeoutSeconds {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultSessionIdleTimeoutSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagSessionIdleTimeoutSeconds, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagSessionIdleTimeoutSeconds)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionIdleTimeoutSeconds, err))
+				return 1
+			}
+			final = uint32(dur.Seconds())
+		}
+		opts = append(opts, targets.WithSessionIdleTimeoutSeconds(final))
+	}
+
+	switch c.flagHostSelectionStrategy {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultHostSelectionStrategy())
+	default:
+		opts = append(opts, targets.WithHostSelectionStrategy(c.flagHostSelectionStrategy))
+	}
+
+	switch c.flagRestrictHostId {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultRestrictHostId())
+	default:
+		restrict, err := strconv.ParseBool(c.flagRestrictHostId)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagRestrictHostId, err))
+			return 1
+		}
+		opts = append(opts, targets.WithRestrictHostId(restrict))
+	}
+
+	switch c.flagWorkerFilter {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultWorkerFilter())
+	default:
+		opts = append(opts, targets.WithWorkerFilter(c.flagWorkerFilter))
+	}
+
+	targetClient := targets.NewClient(client)
+
+	// Perform check-and-set when needed
+	var version uint32
+	switch c.Func {
+	case "create":
+		// These don't update so don't need the existing version
+	default:
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, targets.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	var result api.GenericResult
+
+	switch c.Func {
+	case "create":
+		result, err = targetClient.Create(c.Context, "udp", c.FlagScopeId, opts...)
+	case "update":
+		result, err = targetClient.Update(c.Context, c.FlagId, version, opts...)
+	}
+
+	plural := "udp-type target"
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	target := result.GetItem().(*targets.Target)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateTargetTableOutput(target))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(target)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
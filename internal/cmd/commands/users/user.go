@@ -46,7 +46,7 @@ var flagsMap = map[string][]string{
 	"update":          {"id", "name", "description", "version"},
 	"read":            {"id"},
 	"delete":          {"id"},
-	"list":            {"scope-id"},
+	"list":            {"scope-id", "filter", "recursive"},
 	"add-accounts":    {"id", "account", "version"},
 	"set-accounts":    {"id", "account", "version"},
 	"remove-accounts": {"id", "account", "version"},
@@ -175,6 +175,12 @@ func (c *Command) Run(args []string) int {
 			err = nil
 		}
 	case "list":
+		if c.FlagFilter != "" {
+			opts = append(opts, users.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, users.WithRecursive(true))
+		}
 		listResult, err = userClient.List(c.Context, c.FlagScopeId, opts...)
 	case "add-accounts":
 		result, err = userClient.AddAccounts(c.Context, c.FlagId, version, accounts, opts...)
@@ -249,6 +255,11 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Version:      %d", u.Version),
 					)
 				}
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:     %s", u.ScopeId),
+					)
+				}
 				if u.Name != "" {
 					output = append(output,
 						fmt.Sprintf("    Name:         %s", u.Name),
@@ -57,6 +57,25 @@ func PopulateCommonFlags(c *base.Command, f *base.FlagSet, resourceType string,
 				Target: &c.FlagHostCatalogId,
 				Usage:  "The host-catalog resource to use for the operation",
 			})
+		case "credential-store-id":
+			f.StringVar(&base.StringVar{
+				Name:   "credential-store-id",
+				EnvVar: "BOUNDARY_CREDENTIAL_STORE_ID",
+				Target: &c.FlagCredentialStoreId,
+				Usage:  "The credential-store resource to use for the operation",
+			})
+		case "filter":
+			f.StringVar(&base.StringVar{
+				Name:   "filter",
+				Target: &c.FlagFilter,
+				Usage:  "If set, the list operation will be filtered before being returned. The filter operates against each item in the list. Using single quotes is recommended as filters contain double quotes. See the filtering documentation for details.",
+			})
+		case "recursive":
+			f.BoolVar(&base.BoolVar{
+				Name:   "recursive",
+				Target: &c.FlagRecursive,
+				Usage:  "If set, the list operation will be applied recursively into child scopes, if supported by the type.",
+			})
 		}
 	}
 }
@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 
 	wrapping "github.com/hashicorp/go-kms-wrapping"
@@ -85,6 +86,7 @@ type Config struct {
 
 	Worker     *Worker     `hcl:"worker"`
 	Controller *Controller `hcl:"controller"`
+	Events     *Events     `hcl:"events"`
 
 	// Dev-related options
 	DevController        bool   `hcl:"-"`
@@ -98,6 +100,30 @@ type Controller struct {
 	Name        string    `hcl:"name"`
 	Description string    `hcl:"description"`
 	Database    *Database `hcl:"database"`
+
+	// InventorySources restricts the sources inventory host catalogs can be
+	// synced from, which the controller reads with its own access to the
+	// file system and network, for example:
+	//
+	//	inventory_sources {
+	//	  allowed_paths = ["/etc/boundary/inventory"]
+	//	  allowed_hosts = ["cmdb.example.com"]
+	//	}
+	//
+	// Without it, no inventory host catalogs can be created.
+	InventorySources *InventorySources `hcl:"inventory_sources"`
+}
+
+// InventorySources lists the sources inventory host catalogs can be synced
+// from.
+type InventorySources struct {
+	// AllowedPaths are the absolute paths of the directories file sources
+	// can be in, including their subdirectories.
+	AllowedPaths []string `hcl:"allowed_paths"`
+
+	// AllowedHosts are the hosts http and https sources can be fetched from.
+	// A host with a port only allows that port.
+	AllowedHosts []string `hcl:"allowed_hosts"`
 }
 
 type Worker struct {
@@ -105,6 +131,47 @@ type Worker struct {
 	Description string   `hcl:"description"`
 	Controllers []string `hcl:"controllers"`
 	PublicAddr  string   `hcl:"public_addr"`
+
+	// RecordingStoragePath is the directory in which the worker stores the
+	// recordings of sessions with recording enabled. If it is not set, the
+	// worker refuses to proxy connections for those sessions.
+	RecordingStoragePath string `hcl:"recording_storage_path"`
+
+	// Tags are key/value pairs reported to the controllers which targets'
+	// worker filters are matched against, for example:
+	//
+	//	tags {
+	//	  region = ["us-east"]
+	//	}
+	Tags map[string][]string `hcl:"tags"`
+
+	// Upstreams are the addresses of the proxy listeners of other workers
+	// which this worker connects to as their downstream worker. The upstream
+	// workers forward the connections of sessions which only this worker
+	// matches the worker filter of, so that it never has to accept inbound
+	// connections itself.
+	Upstreams []string `hcl:"upstreams"`
+}
+
+// Events configures the audit events emitted by controllers and workers
+type Events struct {
+	// HmacKey, if set, is used to HMAC sensitive fields of events such as
+	// auth token IDs and client addresses. It can be a file:// or env:// URL.
+	HmacKey string `hcl:"hmac_key"`
+	// ChainKey, if set, keys the hash chain of the events written to each
+	// sink. It can be a file:// or env:// URL.
+	ChainKey string       `hcl:"chain_key"`
+	Sinks    []*EventSink `hcl:"sink"`
+}
+
+// EventSink is a destination for events. Its type is the label of the sink
+// block: "file", "stderr" or "socket".
+type EventSink struct {
+	Type       string   `hcl:",key"`
+	Path       string   `hcl:"path"`
+	Network    string   `hcl:"network"`
+	Address    string   `hcl:"address"`
+	EventTypes []string `hcl:"event_types"`
 }
 
 type Database struct {
@@ -210,6 +277,32 @@ func Parse(d string) (*Config, error) {
 		return nil, err
 	}
 
+	if result.Worker != nil {
+		for k, vals := range result.Worker.Tags {
+			if strings.TrimSpace(k) == "" {
+				return nil, errors.New("worker tag keys cannot be empty")
+			}
+			for _, v := range vals {
+				if strings.TrimSpace(v) == "" {
+					return nil, fmt.Errorf("worker tag %q has an empty value", k)
+				}
+			}
+		}
+	}
+
+	if result.Controller != nil && result.Controller.InventorySources != nil {
+		for _, p := range result.Controller.InventorySources.AllowedPaths {
+			if !filepath.IsAbs(p) {
+				return nil, fmt.Errorf("inventory source path %q is not absolute", p)
+			}
+		}
+		for _, h := range result.Controller.InventorySources.AllowedHosts {
+			if strings.TrimSpace(h) == "" {
+				return nil, errors.New("inventory source hosts cannot be empty")
+			}
+		}
+	}
+
 	sharedConfig, err := configutil.ParseConfig(d)
 	if err != nil {
 		return nil, err
@@ -226,6 +319,8 @@ func Parse(d string) (*Config, error) {
 // Specifically, the fields that this method strips are:
 // - KMS.Config
 // - Telemetry.CirconusAPIToken
+// - Events.HmacKey
+// - Events.ChainKey
 func (c *Config) Sanitized() map[string]interface{} {
 	// Create shared config if it doesn't exist (e.g. in tests) so that map
 	// keys are actually populated
@@ -238,6 +333,22 @@ func (c *Config) Sanitized() map[string]interface{} {
 		result[k] = v
 	}
 
+	if c.Events != nil {
+		sinks := make([]interface{}, 0, len(c.Events.Sinks))
+		for _, s := range c.Events.Sinks {
+			sinks = append(sinks, map[string]interface{}{
+				"type":        s.Type,
+				"path":        s.Path,
+				"network":     s.Network,
+				"address":     s.Address,
+				"event_types": s.EventTypes,
+			})
+		}
+		result["events"] = map[string]interface{}{
+			"sinks": sinks,
+		}
+	}
+
 	return result
 }
 
@@ -115,3 +115,123 @@ func TestDevWorker(t *testing.T) {
 
 	assert.Equal(t, exp, actual)
 }
+
+func TestParseEvents(t *testing.T) {
+	actual, err := Parse(`
+events {
+	hmac_key = "env://BOUNDARY_EVENT_HMAC_KEY"
+	chain_key = "env://BOUNDARY_EVENT_CHAIN_KEY"
+
+	sink "file" {
+		path = "/var/log/boundary/audit.log"
+		event_types = ["audit"]
+	}
+
+	sink "socket" {
+		network = "unix"
+		address = "/dev/log"
+	}
+
+	sink "stderr" {}
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := &Events{
+		HmacKey:  "env://BOUNDARY_EVENT_HMAC_KEY",
+		ChainKey: "env://BOUNDARY_EVENT_CHAIN_KEY",
+		Sinks: []*EventSink{
+			{
+				Type:       "file",
+				Path:       "/var/log/boundary/audit.log",
+				EventTypes: []string{"audit"},
+			},
+			{
+				Type:    "socket",
+				Network: "unix",
+				Address: "/dev/log",
+			},
+			{
+				Type: "stderr",
+			},
+		},
+	}
+	assert.Equal(t, exp, actual.Events)
+
+	sanitized := actual.Sanitized()
+	assert.NotContains(t, sanitized["events"], "hmac_key")
+	assert.NotContains(t, sanitized["events"], "chain_key")
+}
+
+func TestParseWorkerTags(t *testing.T) {
+	actual, err := Parse(`
+worker {
+	name = "w1"
+	tags {
+		region = ["us-east"]
+		type = ["prod", "web"]
+	}
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string][]string{
+		"region": {"us-east"},
+		"type":   {"prod", "web"},
+	}, actual.Worker.Tags)
+
+	_, err = Parse(`
+worker {
+	name = "w1"
+	tags {
+		region = [""]
+	}
+}
+`)
+	assert.Error(t, err)
+}
+
+func TestParseWorkerUpstreams(t *testing.T) {
+	actual, err := Parse(`
+worker {
+	name = "w1"
+	upstreams = ["10.0.0.1:9202", "ingress.example.com"]
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"10.0.0.1:9202", "ingress.example.com"}, actual.Worker.Upstreams)
+}
+
+func TestParseInventorySources(t *testing.T) {
+	actual, err := Parse(`
+controller {
+	name = "c1"
+	inventory_sources {
+		allowed_paths = ["/etc/boundary/inventory"]
+		allowed_hosts = ["cmdb.example.com", "inventory.example.com:8443"]
+	}
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, &InventorySources{
+		AllowedPaths: []string{"/etc/boundary/inventory"},
+		AllowedHosts: []string{"cmdb.example.com", "inventory.example.com:8443"},
+	}, actual.Controller.InventorySources)
+
+	_, err = Parse(`
+controller {
+	name = "c1"
+	inventory_sources {
+		allowed_paths = ["inventory"]
+	}
+}
+`)
+	assert.Error(t, err)
+}
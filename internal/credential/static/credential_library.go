@@ -0,0 +1,120 @@
+package static
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/protobuf/proto"
+)
+
+// A CredentialLibrary holds the username, password and private key brokered
+// to the sessions of the targets it is added to. It is owned by a
+// CredentialStore.
+type CredentialLibrary struct {
+	*store.CredentialLibrary
+	tableName string `gorm:"-"`
+}
+
+// NewCredentialLibrary creates a new in memory CredentialLibrary for
+// username assigned to storeId. Name, description, password and private key
+// are the only valid options. All other options are ignored.
+func NewCredentialLibrary(storeId, username string, opt ...Option) (*CredentialLibrary, error) {
+	if storeId == "" {
+		return nil, fmt.Errorf("new: static credential library: no store id: %w", db.ErrInvalidParameter)
+	}
+
+	opts := getOpts(opt...)
+	l := &CredentialLibrary{
+		CredentialLibrary: &store.CredentialLibrary{
+			StoreId:     storeId,
+			Username:    username,
+			Name:        opts.withName,
+			Description: opts.withDescription,
+			Password:    opts.withPassword,
+			PrivateKey:  opts.withPrivateKey,
+		},
+	}
+	return l, nil
+}
+
+// validate checks the plaintext fields of the library which are written to
+// the database, either directly or encrypted.
+func (l *CredentialLibrary) validate() error {
+	if strings.TrimSpace(l.Username) == "" {
+		return fmt.Errorf("no username: %w", db.ErrInvalidParameter)
+	}
+	if l.Password == "" && l.PrivateKey == "" {
+		return ErrNoSecret
+	}
+	if l.PrivateKey != "" {
+		if _, err := ssh.ParseRawPrivateKey([]byte(l.PrivateKey)); err != nil {
+			return fmt.Errorf("%v: %w", err, ErrInvalidPrivateKey)
+		}
+	}
+	return nil
+}
+
+func (l *CredentialLibrary) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	if err := structwrapping.WrapStruct(ctx, cipher, l.CredentialLibrary, nil); err != nil {
+		return fmt.Errorf("error encrypting static credential library secrets: %w", err)
+	}
+	l.KeyId = cipher.KeyID()
+	// The plaintext secrets are not stored but would otherwise be written to
+	// the oplog.
+	l.Password = ""
+	l.PrivateKey = ""
+	return nil
+}
+
+func (l *CredentialLibrary) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	if err := structwrapping.UnwrapStruct(ctx, cipher, l.CredentialLibrary, nil); err != nil {
+		return fmt.Errorf("error decrypting static credential library secrets: %w", err)
+	}
+	return nil
+}
+
+// TableName returns the table name for the credential library.
+func (l *CredentialLibrary) TableName() string {
+	if l.tableName != "" {
+		return l.tableName
+	}
+	return "credential_static_library"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (l *CredentialLibrary) SetTableName(n string) {
+	l.tableName = n
+}
+
+func allocCredentialLibrary() *CredentialLibrary {
+	return &CredentialLibrary{
+		CredentialLibrary: &store.CredentialLibrary{},
+	}
+}
+
+func (l *CredentialLibrary) clone() *CredentialLibrary {
+	cp := proto.Clone(l.CredentialLibrary)
+	return &CredentialLibrary{
+		CredentialLibrary: cp.(*store.CredentialLibrary),
+	}
+}
+
+func (l *CredentialLibrary) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{l.PublicId},
+		"resource-type":      []string{"static credential library"},
+		"op-type":            []string{op.String()},
+	}
+	if l.StoreId != "" {
+		metadata["store-id"] = []string{l.StoreId}
+	}
+	return metadata
+}
@@ -0,0 +1,177 @@
+package static
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPrivateKey(t *testing.T) string {
+	t.Helper()
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(k),
+	}))
+}
+
+func TestNewCredentialLibrary(t *testing.T) {
+	type args struct {
+		storeId  string
+		username string
+		opts     []Option
+	}
+
+	var tests = []struct {
+		name    string
+		args    args
+		want    *CredentialLibrary
+		wantErr bool
+	}{
+		{
+			name: "blank-storeId",
+			args: args{
+				username: "postgres",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid-no-options",
+			args: args{
+				storeId:  "csst_1234567890",
+				username: "postgres",
+			},
+			want: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:  "csst_1234567890",
+					Username: "postgres",
+				},
+			},
+		},
+		{
+			name: "valid-with-options",
+			args: args{
+				storeId:  "csst_1234567890",
+				username: "postgres",
+				opts: []Option{
+					WithName("test-name"),
+					WithDescription("test-description"),
+					WithPassword("secret"),
+					WithPrivateKey("key"),
+				},
+			},
+			want: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:     "csst_1234567890",
+					Username:    "postgres",
+					Name:        "test-name",
+					Description: "test-description",
+					Password:    "secret",
+					PrivateKey:  "key",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			got, err := NewCredentialLibrary(tt.args.storeId, tt.args.username, tt.args.opts...)
+			if tt.wantErr {
+				assert.Error(err)
+				assert.Nil(got)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestCredentialLibrary_validate(t *testing.T) {
+	privateKey := testPrivateKey(t)
+	var tests = []struct {
+		name      string
+		username  string
+		opts      []Option
+		wantIsErr error
+	}{
+		{
+			name:      "no-username",
+			opts:      []Option{WithPassword("secret")},
+			wantIsErr: db.ErrInvalidParameter,
+		},
+		{
+			name:      "no-secret",
+			username:  "postgres",
+			wantIsErr: ErrNoSecret,
+		},
+		{
+			name:      "invalid-private-key",
+			username:  "postgres",
+			opts:      []Option{WithPrivateKey("not a key")},
+			wantIsErr: ErrInvalidPrivateKey,
+		},
+		{
+			name:     "password",
+			username: "postgres",
+			opts:     []Option{WithPassword("secret")},
+		},
+		{
+			name:     "private-key",
+			username: "ubuntu",
+			opts:     []Option{WithPrivateKey(privateKey)},
+		},
+		{
+			name:     "password-and-private-key",
+			username: "ubuntu",
+			opts:     []Option{WithPassword("secret"), WithPrivateKey(privateKey)},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			l, err := NewCredentialLibrary("csst_1234567890", tt.username, tt.opts...)
+			require.NoError(err)
+			err = l.validate()
+			if tt.wantIsErr != nil {
+				assert.Truef(errors.Is(err, tt.wantIsErr), "want err: %q got: %q", tt.wantIsErr, err)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestCredentialLibrary_encrypt(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+	privateKey := testPrivateKey(t)
+
+	l, err := NewCredentialLibrary("csst_1234567890", "ubuntu", WithPassword("secret"), WithPrivateKey(privateKey))
+	require.NoError(err)
+	require.NoError(l.encrypt(ctx, wrapper))
+	assert.Empty(l.Password)
+	assert.Empty(l.PrivateKey)
+	assert.NotEmpty(l.CtPassword)
+	assert.NotEmpty(l.CtPrivateKey)
+	assert.Equal(wrapper.KeyID(), l.KeyId)
+
+	require.NoError(l.decrypt(ctx, wrapper))
+	assert.Equal("secret", l.Password)
+	assert.Equal(privateKey, l.PrivateKey)
+}
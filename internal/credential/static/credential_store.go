@@ -0,0 +1,75 @@
+package static
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// A CredentialStore contains static credential libraries. It is owned by a
+// scope.
+type CredentialStore struct {
+	*store.CredentialStore
+	tableName string `gorm:"-"`
+}
+
+// NewCredentialStore creates a new in memory CredentialStore assigned to
+// scopeId. Name and description are the only valid options. All other
+// options are ignored.
+func NewCredentialStore(scopeId string, opt ...Option) (*CredentialStore, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("new: static credential store: no scope id: %w", db.ErrInvalidParameter)
+	}
+
+	opts := getOpts(opt...)
+	cs := &CredentialStore{
+		CredentialStore: &store.CredentialStore{
+			ScopeId:     scopeId,
+			Name:        opts.withName,
+			Description: opts.withDescription,
+		},
+	}
+	return cs, nil
+}
+
+func (s *CredentialStore) clone() *CredentialStore {
+	cp := proto.Clone(s.CredentialStore)
+	return &CredentialStore{
+		CredentialStore: cp.(*store.CredentialStore),
+	}
+}
+
+// TableName returns the table name for the credential store.
+func (s *CredentialStore) TableName() string {
+	if s.tableName != "" {
+		return s.tableName
+	}
+	return "credential_static_store"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (s *CredentialStore) SetTableName(n string) {
+	s.tableName = n
+}
+
+func allocCredentialStore() *CredentialStore {
+	return &CredentialStore{
+		CredentialStore: &store.CredentialStore{},
+	}
+}
+
+func (s *CredentialStore) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{s.GetPublicId()},
+		"resource-type":      []string{"static credential store"},
+		"op-type":            []string{op.String()},
+	}
+	if s.ScopeId != "" {
+		metadata["scope-id"] = []string{s.ScopeId}
+	}
+	return metadata
+}
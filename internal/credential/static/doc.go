@@ -0,0 +1,38 @@
+// Package static provides a credential store and a credential library
+// suitable for credentials which do not change, such as the username and
+// password of a shared database account.
+//
+// A credential store contains a collection of credential libraries. Each
+// credential library holds a username and, optionally, a password and a
+// private key. The password and the private key are encrypted with the
+// credentials key of the scope of the store before they are written to the
+// database. Credential libraries are owned by a single credential store. If a
+// credential store is deleted, all credential libraries owned by it are also
+// deleted.
+//
+// Credential libraries can be added to a target. The credentials of the
+// libraries of a target are brokered to each session authorized for the
+// target.
+//
+// Repository
+//
+// A repository provides methods for creating, updating, retrieving, and
+// deleting credential stores and credential libraries. A new repository
+// should be created for each transaction. For example:
+//
+//  var wrapper wrapping.Wrapper
+//  ... init wrapper...
+//
+//  // db implements both the reader and writer interfaces.
+//  db, _ := db.Open(db.Postgres, url)
+//
+//  var repo *static.Repository
+//
+//  repo, _ = static.NewRepository(db, db, kms)
+//  store, _ := repo.LookupStore(ctx, storeId)
+//
+//  store.Name = "new name"
+//
+//  repo, _ = static.NewRepository(db, db, kms)
+//  store, _ := repo.UpdateStore(ctx, store, store.Version, []string{"Name"})
+package static
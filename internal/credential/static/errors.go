@@ -0,0 +1,13 @@
+package static
+
+import "errors"
+
+var (
+	// ErrNoSecret results from attempting to create or update a credential
+	// library so that it has neither a password nor a private key.
+	ErrNoSecret = errors.New("credential library requires a password or a private key")
+
+	// ErrInvalidPrivateKey results from attempting to set the private key of
+	// a credential library to a value which is not a PEM encoded private key.
+	ErrInvalidPrivateKey = errors.New("invalid private key")
+)
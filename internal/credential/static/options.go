@@ -0,0 +1,83 @@
+package static
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withName             string
+	withDescription      string
+	withLimit            int
+	withStartPageAfterId string
+	withPublicId         string
+	withPassword         string
+	withPrivateKey       string
+}
+
+func getDefaultOptions() options {
+	return options{
+		withDescription: "",
+		withName:        "",
+	}
+}
+
+// WithDescription provides an optional description.
+func WithDescription(desc string) Option {
+	return func(o *options) {
+		o.withDescription = desc
+	}
+}
+
+// WithName provides an optional name.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.withName = name
+	}
+}
+
+// WithLimit provides an option to provide a limit. Intentionally allowing
+// negative integers. If WithLimit < 0, then unlimited results are
+// returned. If WithLimit == 0, then default limits are used for results.
+func WithLimit(l int) Option {
+	return func(o *options) {
+		o.withLimit = l
+	}
+}
+
+// WithPublicId provides an optional public id
+func WithPublicId(id string) Option {
+	return func(o *options) {
+		o.withPublicId = id
+	}
+}
+
+// WithStartPageAfterId provides an option to only list resources whose public
+// ids sort after the given id. Used to retrieve the next page of results.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
+
+// WithPassword provides an optional password.
+func WithPassword(p string) Option {
+	return func(o *options) {
+		o.withPassword = p
+	}
+}
+
+// WithPrivateKey provides an optional PEM encoded private key.
+func WithPrivateKey(k string) Option {
+	return func(o *options) {
+		o.withPrivateKey = k
+	}
+}
@@ -0,0 +1,29 @@
+package static
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// PublicId prefixes for the resources in the static package.
+const (
+	CredentialStorePrefix   = "csst"
+	CredentialLibraryPrefix = "clst"
+)
+
+func newCredentialStoreId() (string, error) {
+	id, err := db.NewPublicId(CredentialStorePrefix)
+	if err != nil {
+		return "", fmt.Errorf("new credential store id: %w", err)
+	}
+	return id, err
+}
+
+func newCredentialLibraryId() (string, error) {
+	id, err := db.NewPublicId(CredentialLibraryPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new credential library id: %w", err)
+	}
+	return id, err
+}
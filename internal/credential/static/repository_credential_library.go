@@ -0,0 +1,342 @@
+package static
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateLibrary inserts l into the repository and returns a new
+// CredentialLibrary containing the library's PublicId. l is not changed. l
+// must contain a valid StoreId. l must not contain a PublicId. The PublicId
+// is generated and assigned by this method. WithPublicId is the only valid
+// option.
+//
+// l must contain a Username and at least one of a Password or a PrivateKey.
+// The Password and PrivateKey are encrypted with the credentials key of
+// scopeId, which must be the scope of the store, and are not returned.
+//
+// Both l.Name and l.Description are optional. If l.Name is set, it must be
+// unique within l.StoreId.
+func (r *Repository) CreateLibrary(ctx context.Context, scopeId string, l *CredentialLibrary, opt ...Option) (*CredentialLibrary, error) {
+	if l == nil {
+		return nil, fmt.Errorf("create: static credential library: %w", db.ErrInvalidParameter)
+	}
+	if l.CredentialLibrary == nil {
+		return nil, fmt.Errorf("create: static credential library: embedded CredentialLibrary: %w", db.ErrInvalidParameter)
+	}
+	if l.StoreId == "" {
+		return nil, fmt.Errorf("create: static credential library: no store id: %w", db.ErrInvalidParameter)
+	}
+	if l.PublicId != "" {
+		return nil, fmt.Errorf("create: static credential library: public id not empty: %w", db.ErrInvalidParameter)
+	}
+	if scopeId == "" {
+		return nil, fmt.Errorf("create: static credential library: no scopeId: %w", db.ErrInvalidParameter)
+	}
+	l = l.clone()
+	l.Username = strings.TrimSpace(l.Username)
+	if err := l.validate(); err != nil {
+		return nil, fmt.Errorf("create: static credential library: %w", err)
+	}
+
+	opts := getOpts(opt...)
+
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, CredentialLibraryPrefix+"_") {
+			return nil, fmt.Errorf("create: static credential library: passed-in public ID %q has wrong prefix, should be %q: %w", opts.withPublicId, CredentialLibraryPrefix, db.ErrInvalidPublicId)
+		}
+		l.PublicId = opts.withPublicId
+	} else {
+		id, err := newCredentialLibraryId()
+		if err != nil {
+			return nil, fmt.Errorf("create: static credential library: %w", err)
+		}
+		l.PublicId = id
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("create: static credential library: unable to get oplog wrapper: %w", err)
+	}
+	credentialsWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeCredentials)
+	if err != nil {
+		return nil, fmt.Errorf("create: static credential library: unable to get credentials wrapper: %w", err)
+	}
+	if err := l.encrypt(ctx, credentialsWrapper); err != nil {
+		return nil, fmt.Errorf("create: static credential library: %w", err)
+	}
+
+	var newLibrary *CredentialLibrary
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newLibrary = l.clone()
+			return w.Create(ctx, newLibrary, db.WithOplog(oplogWrapper, l.oplog(oplog.OpType_OP_TYPE_CREATE)))
+		},
+	)
+
+	if err != nil {
+		if db.IsUniqueError(err) {
+			return nil, fmt.Errorf("create: static credential library: in store: %s: name %s already exists: %w",
+				l.StoreId, l.Name, db.ErrNotUnique)
+		}
+		return nil, fmt.Errorf("create: static credential library: in store: %s: %w", l.StoreId, err)
+	}
+	return newLibrary, nil
+}
+
+// UpdateLibrary updates the repository entry for l.PublicId with the values
+// in l for the fields listed in fieldMaskPaths. It returns a new
+// CredentialLibrary containing the updated values and a count of the number
+// of records updated. l is not changed.
+//
+// l must contain a valid PublicId. Only l.Name, l.Description, l.Username,
+// l.Password and l.PrivateKey can be updated. If l.Name is set to a
+// non-empty string, it must be unique within l.StoreId. l.Username cannot be
+// set to NULL. If l.Password or l.PrivateKey is included in fieldMaskPaths
+// both secrets are encrypted again with the current credentials key of
+// scopeId, and the library must still have at least one of them afterwards.
+//
+// An attribute of l will be set to NULL in the database if the attribute
+// in l is the zero value and it is included in fieldMaskPaths.
+func (r *Repository) UpdateLibrary(ctx context.Context, scopeId string, l *CredentialLibrary, version uint32, fieldMaskPaths []string, opt ...Option) (*CredentialLibrary, int, error) {
+	if l == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential library: %w", db.ErrInvalidParameter)
+	}
+	if l.CredentialLibrary == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential library: embedded CredentialLibrary: %w", db.ErrInvalidParameter)
+	}
+	if l.PublicId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential library: missing public id: %w", db.ErrInvalidParameter)
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential library: no version supplied: %w", db.ErrInvalidParameter)
+	}
+	if scopeId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential library: no scopeId: %w", db.ErrInvalidParameter)
+	}
+
+	l = l.clone()
+	var changePassword, changePrivateKey bool
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("Name", f):
+		case strings.EqualFold("Description", f):
+		case strings.EqualFold("Username", f):
+			l.Username = strings.TrimSpace(l.Username)
+			if l.Username == "" {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: static credential library: no username: %w", db.ErrInvalidParameter)
+			}
+		case strings.EqualFold("Password", f):
+			changePassword = true
+		case strings.EqualFold("PrivateKey", f):
+			changePrivateKey = true
+		default:
+			return nil, db.NoRowsAffected, fmt.Errorf("update: static credential library: field: %s: %w", f, db.ErrInvalidFieldMask)
+		}
+	}
+	var dbMask, nullFields []string
+	dbMask, nullFields = dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			"Name":        l.Name,
+			"Description": l.Description,
+			"Username":    l.Username,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if changePassword || changePrivateKey {
+		dbMask = append(dbMask, "CtPassword", "CtPrivateKey", "KeyId")
+	}
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential library: %w", db.ErrEmptyFieldMask)
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential library: unable to get oplog wrapper: %w", err)
+	}
+	if changePassword || changePrivateKey {
+		// Both secrets are encrypted together, so the one which is not
+		// changing is carried over from the current library.
+		current := allocCredentialLibrary()
+		current.PublicId = l.PublicId
+		if err := r.reader.LookupByPublicId(ctx, current); err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: static credential library: %s: %w", l.PublicId, err)
+		}
+		credentialsWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeCredentials, kms.WithKeyId(current.KeyId))
+		if err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: static credential library: unable to get credentials wrapper: %w", err)
+		}
+		if err := current.decrypt(ctx, credentialsWrapper); err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: static credential library: %w", err)
+		}
+		if !changePassword {
+			l.Password = current.Password
+		}
+		if !changePrivateKey {
+			l.PrivateKey = current.PrivateKey
+		}
+		// validate also checks the username, which is only set if it is
+		// being updated.
+		if l.Username == "" {
+			l.Username = current.Username
+		}
+		if err := l.validate(); err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: static credential library: %w", err)
+		}
+		if err := l.encrypt(ctx, credentialsWrapper); err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: static credential library: %w", err)
+		}
+	}
+
+	var rowsUpdated int
+	var returnedLibrary *CredentialLibrary
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedLibrary = l.clone()
+			var err error
+			rowsUpdated, err = w.Update(ctx, returnedLibrary, dbMask, nullFields,
+				db.WithOplog(oplogWrapper, l.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+				db.WithVersion(&version))
+			if err == nil && rowsUpdated > 1 {
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		if db.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: static credential library: %s: name %s already exists: %w",
+				l.PublicId, l.Name, db.ErrNotUnique)
+		}
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential library: %s: %w", l.PublicId, err)
+	}
+
+	return returnedLibrary, rowsUpdated, nil
+}
+
+// LookupLibrary will look up a credential library in the repository. If the
+// library is not found, it will return nil, nil. The password and private
+// key of the returned library are not decrypted. All options are ignored.
+func (r *Repository) LookupLibrary(ctx context.Context, publicId string, opt ...Option) (*CredentialLibrary, error) {
+	if publicId == "" {
+		return nil, fmt.Errorf("lookup: static credential library: missing public id %w", db.ErrInvalidParameter)
+	}
+	l := allocCredentialLibrary()
+	l.PublicId = publicId
+	if err := r.reader.LookupByPublicId(ctx, l); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup: static credential library: failed %w for %s", err, publicId)
+	}
+	return l, nil
+}
+
+// ListLibraries returns a slice of CredentialLibraries for the storeId
+// ordered by public id. The password and private key of the returned
+// libraries are not decrypted. Supports the WithLimit and
+// WithStartPageAfterId options.
+func (r *Repository) ListLibraries(ctx context.Context, storeId string, opt ...Option) ([]*CredentialLibrary, error) {
+	if storeId == "" {
+		return nil, fmt.Errorf("list: static credential library: missing store id: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	where, args := "store_id = ?", []interface{}{storeId}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id collate \"C\" > ?", append(args, opts.withStartPageAfterId)
+	}
+	var libraries []*CredentialLibrary
+	err := r.reader.SearchWhere(ctx, &libraries, where, args, db.WithLimit(limit), db.WithOrder(`public_id collate "C"`))
+	if err != nil {
+		return nil, fmt.Errorf("list: static credential library: %w", err)
+	}
+	return libraries, nil
+}
+
+// BrokerLibraries returns the CredentialLibraries for the publicIds, ordered
+// by public id, with their password and private key decrypted with the
+// credentials key of scopeId. Only libraries of stores in scopeId are
+// returned. It is used to broker the credentials of a target to a session
+// and the returned libraries must not be returned by the API. All options
+// are ignored.
+func (r *Repository) BrokerLibraries(ctx context.Context, scopeId string, publicIds []string, opt ...Option) ([]*CredentialLibrary, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("broker: static credential library: no scopeId: %w", db.ErrInvalidParameter)
+	}
+	if len(publicIds) == 0 {
+		return nil, nil
+	}
+	var libraries []*CredentialLibrary
+	err := r.reader.SearchWhere(ctx, &libraries,
+		"public_id in (?) and store_id in (select public_id from credential_static_store where scope_id = ?)",
+		[]interface{}{publicIds, scopeId},
+		db.WithLimit(-1), db.WithOrder("public_id"))
+	if err != nil {
+		return nil, fmt.Errorf("broker: static credential library: %w", err)
+	}
+	if len(libraries) == 0 {
+		return nil, nil
+	}
+	for _, l := range libraries {
+		// The secrets may have been encrypted by another controller with a
+		// version of the credentials key created after this controller
+		// cached it.
+		credentialsWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeCredentials, kms.WithKeyId(l.KeyId))
+		if err != nil {
+			return nil, fmt.Errorf("broker: static credential library: unable to get credentials wrapper: %w", err)
+		}
+		if err := l.decrypt(ctx, credentialsWrapper); err != nil {
+			return nil, fmt.Errorf("broker: static credential library: %s: %w", l.PublicId, err)
+		}
+	}
+	return libraries, nil
+}
+
+// DeleteLibrary deletes the credential library for the provided id from the
+// repository returning a count of the number of records deleted. All
+// options are ignored.
+func (r *Repository) DeleteLibrary(ctx context.Context, scopeId string, publicId string, opt ...Option) (int, error) {
+	if publicId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: static credential library: missing public id: %w", db.ErrInvalidParameter)
+	}
+	l := allocCredentialLibrary()
+	l.PublicId = publicId
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: static credential library: unable to get oplog wrapper: %w", err)
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) (err error) {
+			dl := l.clone()
+			rowsDeleted, err = w.Delete(ctx, dl, db.WithOplog(oplogWrapper, l.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err == nil && rowsDeleted > 1 {
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: static credential library: %s: %w", publicId, err)
+	}
+
+	return rowsDeleted, nil
+}
@@ -0,0 +1,332 @@
+package static
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateLibrary(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	privateKey := testPrivateKey(t)
+
+	var tests = []struct {
+		name      string
+		username  string
+		opts      []Option
+		wantIsErr error
+	}{
+		{
+			name:      "no-username",
+			opts:      []Option{WithPassword("secret")},
+			wantIsErr: db.ErrInvalidParameter,
+		},
+		{
+			name:      "no-secret",
+			username:  "postgres",
+			wantIsErr: ErrNoSecret,
+		},
+		{
+			name:      "invalid-private-key",
+			username:  "ubuntu",
+			opts:      []Option{WithPrivateKey("not a key")},
+			wantIsErr: ErrInvalidPrivateKey,
+		},
+		{
+			name:     "valid-password",
+			username: "postgres",
+			opts:     []Option{WithPassword("secret"), WithName("test-name-repo")},
+		},
+		{
+			name:     "valid-private-key",
+			username: "ubuntu",
+			opts:     []Option{WithPrivateKey(privateKey)},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			kmsCache := kms.TestKms(t, conn, wrapper)
+			repo, err := NewRepository(rw, rw, kmsCache)
+			require.NoError(err)
+			_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+			cs := TestCredentialStores(t, conn, prj.GetPublicId(), 1)[0]
+
+			in, err := NewCredentialLibrary(cs.GetPublicId(), tt.username, tt.opts...)
+			require.NoError(err)
+			got, err := repo.CreateLibrary(context.Background(), prj.GetPublicId(), in)
+			if tt.wantIsErr != nil {
+				assert.Truef(errors.Is(err, tt.wantIsErr), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assertPublicId(t, CredentialLibraryPrefix, got.PublicId)
+			assert.Empty(in.PublicId)
+			assert.Equal(in.Name, got.Name)
+			assert.Equal(in.Username, got.Username)
+			assert.Empty(got.Password)
+			assert.Empty(got.PrivateKey)
+			assert.NotEmpty(got.CtPassword)
+			assert.NotEmpty(got.KeyId)
+
+			brokered, err := repo.BrokerLibraries(context.Background(), prj.GetPublicId(), []string{got.PublicId})
+			require.NoError(err)
+			require.Len(brokered, 1)
+			assert.Equal(in.Password, brokered[0].Password)
+			assert.Equal(in.PrivateKey, brokered[0].PrivateKey)
+		})
+	}
+}
+
+func TestRepository_UpdateLibrary(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	privateKey := testPrivateKey(t)
+
+	var tests = []struct {
+		name           string
+		chgFn          func(*CredentialLibrary) *CredentialLibrary
+		masks          []string
+		wantUsername   string
+		wantPassword   string
+		wantPrivateKey string
+		wantIsErr      error
+	}{
+		{
+			name: "change-username",
+			chgFn: func(l *CredentialLibrary) *CredentialLibrary {
+				l.Username = "admin"
+				return l
+			},
+			masks:        []string{"Username"},
+			wantUsername: "admin",
+			wantPassword: "password-0",
+		},
+		{
+			name: "delete-username",
+			chgFn: func(l *CredentialLibrary) *CredentialLibrary {
+				l.Username = ""
+				return l
+			},
+			masks:     []string{"Username"},
+			wantIsErr: db.ErrInvalidParameter,
+		},
+		{
+			name: "change-password",
+			chgFn: func(l *CredentialLibrary) *CredentialLibrary {
+				l.Password = "new-secret"
+				return l
+			},
+			masks:        []string{"Password"},
+			wantUsername: "user-0",
+			wantPassword: "new-secret",
+		},
+		{
+			name: "add-private-key-keeps-password",
+			chgFn: func(l *CredentialLibrary) *CredentialLibrary {
+				l.PrivateKey = privateKey
+				return l
+			},
+			masks:          []string{"PrivateKey"},
+			wantUsername:   "user-0",
+			wantPassword:   "password-0",
+			wantPrivateKey: privateKey,
+		},
+		{
+			name: "delete-only-secret",
+			chgFn: func(l *CredentialLibrary) *CredentialLibrary {
+				l.Password = ""
+				return l
+			},
+			masks:     []string{"Password"},
+			wantIsErr: ErrNoSecret,
+		},
+		{
+			name: "read-only-fields-in-field-mask",
+			chgFn: func(l *CredentialLibrary) *CredentialLibrary {
+				return l
+			},
+			masks:     []string{"StoreId", "KeyId"},
+			wantIsErr: db.ErrInvalidFieldMask,
+		},
+		{
+			name: "empty-field-mask",
+			chgFn: func(l *CredentialLibrary) *CredentialLibrary {
+				return l
+			},
+			wantIsErr: db.ErrEmptyFieldMask,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			kmsCache := kms.TestKms(t, conn, wrapper)
+			repo, err := NewRepository(rw, rw, kmsCache)
+			require.NoError(err)
+			_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+			credentialsWrapper, err := kmsCache.GetWrapper(ctx, prj.GetPublicId(), kms.KeyPurposeCredentials)
+			require.NoError(err)
+			cs := TestCredentialStores(t, conn, prj.GetPublicId(), 1)[0]
+			orig := TestCredentialLibraries(t, conn, credentialsWrapper, cs.GetPublicId(), 1)[0]
+
+			got, gotCount, err := repo.UpdateLibrary(ctx, prj.GetPublicId(), tt.chgFn(orig.clone()), orig.Version, tt.masks)
+			if tt.wantIsErr != nil {
+				assert.Truef(errors.Is(err, tt.wantIsErr), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Equal(db.NoRowsAffected, gotCount)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(1, gotCount)
+			assert.Equal(orig.Version+1, got.Version)
+			assert.Empty(got.Password)
+			assert.Empty(got.PrivateKey)
+
+			brokered, err := repo.BrokerLibraries(ctx, prj.GetPublicId(), []string{orig.PublicId})
+			require.NoError(err)
+			require.Len(brokered, 1)
+			assert.Equal(tt.wantUsername, brokered[0].Username)
+			assert.Equal(tt.wantPassword, brokered[0].Password)
+			assert.Equal(tt.wantPrivateKey, brokered[0].PrivateKey)
+		})
+	}
+}
+
+func TestRepository_LookupLibrary(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	credentialsWrapper, err := kmsCache.GetWrapper(ctx, prj.GetPublicId(), kms.KeyPurposeCredentials)
+	require.NoError(err)
+	cs := TestCredentialStores(t, conn, prj.GetPublicId(), 1)[0]
+	l := TestCredentialLibraries(t, conn, credentialsWrapper, cs.GetPublicId(), 1)[0]
+
+	got, err := repo.LookupLibrary(ctx, l.GetPublicId())
+	require.NoError(err)
+	require.NotNil(got)
+	assert.Equal(l.Username, got.Username)
+	assert.Empty(got.Password)
+
+	badId, err := newCredentialLibraryId()
+	require.NoError(err)
+	got, err = repo.LookupLibrary(ctx, badId)
+	require.NoError(err)
+	assert.Nil(got)
+
+	_, err = repo.LookupLibrary(ctx, "")
+	assert.Truef(errors.Is(err, db.ErrInvalidParameter), "want err: %q got: %q", db.ErrInvalidParameter, err)
+}
+
+func TestRepository_ListLibraries(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	credentialsWrapper, err := kmsCache.GetWrapper(ctx, prj.GetPublicId(), kms.KeyPurposeCredentials)
+	require.NoError(err)
+	stores := TestCredentialStores(t, conn, prj.GetPublicId(), 2)
+	libraries := TestCredentialLibraries(t, conn, credentialsWrapper, stores[0].GetPublicId(), 3)
+	TestCredentialLibraries(t, conn, credentialsWrapper, stores[1].GetPublicId(), 1)
+
+	got, err := repo.ListLibraries(ctx, stores[0].GetPublicId())
+	require.NoError(err)
+	assert.Len(got, len(libraries))
+
+	page, err := repo.ListLibraries(ctx, stores[0].GetPublicId(), WithLimit(1))
+	require.NoError(err)
+	require.Len(page, 1)
+	rest, err := repo.ListLibraries(ctx, stores[0].GetPublicId(), WithStartPageAfterId(page[0].PublicId))
+	require.NoError(err)
+	assert.Len(rest, len(libraries)-1)
+}
+
+func TestRepository_BrokerLibraries(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+	_, otherPrj := iam.TestScopes(t, iamRepo)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+
+	credentialsWrapper, err := kmsCache.GetWrapper(ctx, prj.GetPublicId(), kms.KeyPurposeCredentials)
+	require.NoError(err)
+	cs := TestCredentialStores(t, conn, prj.GetPublicId(), 1)[0]
+	libraries := TestCredentialLibraries(t, conn, credentialsWrapper, cs.GetPublicId(), 2)
+
+	otherWrapper, err := kmsCache.GetWrapper(ctx, otherPrj.GetPublicId(), kms.KeyPurposeCredentials)
+	require.NoError(err)
+	otherCs := TestCredentialStores(t, conn, otherPrj.GetPublicId(), 1)[0]
+	other := TestCredentialLibraries(t, conn, otherWrapper, otherCs.GetPublicId(), 1)[0]
+
+	got, err := repo.BrokerLibraries(ctx, prj.GetPublicId(), []string{libraries[0].PublicId, libraries[1].PublicId, other.PublicId})
+	require.NoError(err)
+	require.Len(got, 2)
+	for _, l := range got {
+		assert.NotEmpty(l.Password)
+	}
+
+	got, err = repo.BrokerLibraries(ctx, prj.GetPublicId(), nil)
+	require.NoError(err)
+	assert.Empty(got)
+
+	_, err = repo.BrokerLibraries(ctx, "", []string{libraries[0].PublicId})
+	assert.Truef(errors.Is(err, db.ErrInvalidParameter), "want err: %q got: %q", db.ErrInvalidParameter, err)
+}
+
+func TestRepository_DeleteLibrary(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	credentialsWrapper, err := kmsCache.GetWrapper(ctx, prj.GetPublicId(), kms.KeyPurposeCredentials)
+	require.NoError(err)
+	cs := TestCredentialStores(t, conn, prj.GetPublicId(), 1)[0]
+	l := TestCredentialLibraries(t, conn, credentialsWrapper, cs.GetPublicId(), 1)[0]
+
+	count, err := repo.DeleteLibrary(ctx, prj.GetPublicId(), l.GetPublicId())
+	require.NoError(err)
+	assert.Equal(1, count)
+
+	count, err = repo.DeleteLibrary(ctx, prj.GetPublicId(), l.GetPublicId())
+	require.NoError(err)
+	assert.Equal(db.NoRowsAffected, count)
+
+	_, err = repo.DeleteLibrary(ctx, prj.GetPublicId(), "")
+	assert.Truef(errors.Is(err, db.ErrInvalidParameter), "want err: %q got: %q", db.ErrInvalidParameter, err)
+}
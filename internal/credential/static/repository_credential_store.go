@@ -0,0 +1,266 @@
+package static
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateStore inserts cs into the repository and returns a new
+// CredentialStore containing the store's PublicId. cs is not changed. cs must
+// contain a valid ScopeID. cs must not contain a PublicId. The PublicId is
+// generated and assigned by the this method. opt is ignored.
+//
+// Both cs.Name and cs.Description are optional. If cs.Name is set, it must be
+// unique within cs.ScopeID.
+//
+// Both cs.CreateTime and cs.UpdateTime are ignored.
+func (r *Repository) CreateStore(ctx context.Context, cs *CredentialStore, opt ...Option) (*CredentialStore, error) {
+	if cs == nil {
+		return nil, fmt.Errorf("create: static credential store: %w", db.ErrInvalidParameter)
+	}
+	if cs.CredentialStore == nil {
+		return nil, fmt.Errorf("create: static credential store: embedded CredentialStore: %w", db.ErrInvalidParameter)
+	}
+	if cs.ScopeId == "" {
+		return nil, fmt.Errorf("create: static credential store: no scope id: %w", db.ErrInvalidParameter)
+	}
+	if cs.PublicId != "" {
+		return nil, fmt.Errorf("create: static credential store: public id not empty: %w", db.ErrInvalidParameter)
+	}
+	cs = cs.clone()
+
+	opts := getOpts(opt...)
+
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, CredentialStorePrefix+"_") {
+			return nil, fmt.Errorf("create: static credential store: passed-in public ID %q has wrong prefix, should be %q: %w", opts.withPublicId, CredentialStorePrefix, db.ErrInvalidPublicId)
+		}
+		cs.PublicId = opts.withPublicId
+	} else {
+		id, err := newCredentialStoreId()
+		if err != nil {
+			return nil, fmt.Errorf("create: static credential store: %w", err)
+		}
+		cs.PublicId = id
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, cs.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("create: static credential store: unable to get oplog wrapper: %w", err)
+	}
+
+	metadata := cs.oplog(oplog.OpType_OP_TYPE_CREATE)
+
+	var newStore *CredentialStore
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newStore = cs.clone()
+			return w.Create(
+				ctx,
+				newStore,
+				db.WithOplog(oplogWrapper, metadata),
+			)
+		},
+	)
+
+	if err != nil {
+		if db.IsUniqueError(err) {
+			return nil, fmt.Errorf("create: static credential store: in scope: %s: name %s already exists: %w",
+				cs.ScopeId, cs.Name, db.ErrNotUnique)
+		}
+		return nil, fmt.Errorf("create: static credential store: in scope: %s: %w", cs.ScopeId, err)
+	}
+	return newStore, nil
+}
+
+// UpdateStore updates the repository entry for cs.PublicId with the
+// values in cs for the fields listed in fieldMask. It returns a new
+// CredentialStore containing the updated values and a count of the number of
+// records updated. cs is not changed.
+//
+// cs must contain a valid PublicId. Only cs.Name and cs.Description can be
+// updated. If cs.Name is set to a non-empty string, it must be unique
+// within cs.ScopeID.
+//
+// An attribute of cs will be set to NULL in the database if the attribute
+// in cs is the zero value and it is included in fieldMask.
+func (r *Repository) UpdateStore(ctx context.Context, cs *CredentialStore, version uint32, fieldMask []string, opt ...Option) (*CredentialStore, int, error) {
+	if cs == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: %w", db.ErrInvalidParameter)
+	}
+	if cs.CredentialStore == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: embedded CredentialStore: %w", db.ErrInvalidParameter)
+	}
+	if cs.PublicId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: missing public id: %w", db.ErrInvalidParameter)
+	}
+	if cs.ScopeId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	if len(fieldMask) == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: %w", db.ErrEmptyFieldMask)
+	}
+
+	var dbMask, nullFields []string
+	for _, f := range fieldMask {
+		switch {
+		case strings.EqualFold("name", f) && cs.Name == "":
+			nullFields = append(nullFields, "name")
+		case strings.EqualFold("name", f) && cs.Name != "":
+			dbMask = append(dbMask, "name")
+		case strings.EqualFold("description", f) && cs.Description == "":
+			nullFields = append(nullFields, "description")
+		case strings.EqualFold("description", f) && cs.Description != "":
+			dbMask = append(dbMask, "description")
+
+		default:
+			return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: field: %s: %w", f, db.ErrInvalidFieldMask)
+		}
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, cs.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: unable to get oplog wrapper: %w", err)
+	}
+
+	cs = cs.clone()
+
+	metadata := cs.oplog(oplog.OpType_OP_TYPE_UPDATE)
+
+	var rowsUpdated int
+	var returnedStore *CredentialStore
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedStore = cs.clone()
+			var err error
+			rowsUpdated, err = w.Update(
+				ctx,
+				returnedStore,
+				dbMask,
+				nullFields,
+				db.WithOplog(oplogWrapper, metadata),
+				db.WithVersion(&version),
+			)
+			if err == nil && rowsUpdated > 1 {
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		if db.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: %s: name %s already exists: %w",
+				cs.PublicId, cs.Name, db.ErrNotUnique)
+		}
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: %s: %w", cs.PublicId, err)
+	}
+
+	return returnedStore, rowsUpdated, nil
+}
+
+// LookupStore returns the CredentialStore for id. Returns nil, nil if no
+// CredentialStore is found for id.
+func (r *Repository) LookupStore(ctx context.Context, id string, opt ...Option) (*CredentialStore, error) {
+	if id == "" {
+		return nil, fmt.Errorf("lookup: static credential store: missing public id: %w", db.ErrInvalidParameter)
+	}
+	cs := allocCredentialStore()
+	cs.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, cs); err != nil {
+		if err == db.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup: static credential store: %s: %w", id, err)
+	}
+	return cs, nil
+}
+
+// ListStores returns a slice of CredentialStores for the scopeId ordered by
+// public id. Supports the WithLimit and WithStartPageAfterId options.
+func (r *Repository) ListStores(ctx context.Context, scopeId string, opt ...Option) ([]*CredentialStore, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("list: static credential store: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	where, args := "scope_id = ?", []interface{}{scopeId}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id collate \"C\" > ?", append(args, opts.withStartPageAfterId)
+	}
+	var stores []*CredentialStore
+	err := r.reader.SearchWhere(ctx, &stores, where, args, db.WithLimit(limit), db.WithOrder(`public_id collate "C"`))
+	if err != nil {
+		return nil, fmt.Errorf("list: static credential store: %w", err)
+	}
+	return stores, nil
+}
+
+// DeleteStore deletes id from the repository returning a count of the
+// number of records deleted.
+func (r *Repository) DeleteStore(ctx context.Context, id string, opt ...Option) (int, error) {
+	if id == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: static credential store: missing public id: %w", db.ErrInvalidParameter)
+	}
+
+	cs := allocCredentialStore()
+	cs.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, cs); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.NoRowsAffected, nil
+		}
+		return db.NoRowsAffected, fmt.Errorf("delete: static credential store: failed %w for %s", err, id)
+	}
+	if cs.ScopeId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: static credential store: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, cs.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: static credential store: unable to get oplog wrapper: %w", err)
+	}
+
+	metadata := cs.oplog(oplog.OpType_OP_TYPE_DELETE)
+
+	var rowsDeleted int
+	var deleteStore *CredentialStore
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			deleteStore = cs.clone()
+			var err error
+			rowsDeleted, err = w.Delete(
+				ctx,
+				deleteStore,
+				db.WithOplog(oplogWrapper, metadata),
+			)
+			if err == nil && rowsDeleted > 1 {
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: static credential store: %s: %w", cs.PublicId, err)
+	}
+
+	return rowsDeleted, nil
+}
@@ -0,0 +1,348 @@
+package static
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertPublicId(t *testing.T, prefix, actual string) {
+	t.Helper()
+	assert.NotEmpty(t, actual)
+	parts := strings.Split(actual, "_")
+	assert.Equalf(t, 2, len(parts), "want one '_' in PublicId, got multiple in %q", actual)
+	assert.Equalf(t, prefix, parts[0], "PublicId want prefix: %q, got: %q in %q", prefix, parts[0], actual)
+}
+
+func TestRepository_CreateStore(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	var tests = []struct {
+		name      string
+		in        *CredentialStore
+		opts      []Option
+		want      *CredentialStore
+		wantIsErr error
+	}{
+		{
+			name:      "nil-store",
+			wantIsErr: db.ErrInvalidParameter,
+		},
+		{
+			name:      "nil-embedded-store",
+			in:        &CredentialStore{},
+			wantIsErr: db.ErrInvalidParameter,
+		},
+		{
+			name: "valid-no-options",
+			in: &CredentialStore{
+				CredentialStore: &store.CredentialStore{},
+			},
+			want: &CredentialStore{
+				CredentialStore: &store.CredentialStore{},
+			},
+		},
+		{
+			name: "valid-with-name-and-description",
+			in: &CredentialStore{
+				CredentialStore: &store.CredentialStore{
+					Name:        "test-name-repo",
+					Description: "test-description-repo",
+				},
+			},
+			want: &CredentialStore{
+				CredentialStore: &store.CredentialStore{
+					Name:        "test-name-repo",
+					Description: "test-description-repo",
+				},
+			},
+		},
+		{
+			name: "invalid-public-id-option",
+			in: &CredentialStore{
+				CredentialStore: &store.CredentialStore{},
+			},
+			opts:      []Option{WithPublicId("hcst_1234567890")},
+			wantIsErr: db.ErrInvalidPublicId,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			kms := kms.TestKms(t, conn, wrapper)
+			repo, err := NewRepository(rw, rw, kms)
+			require.NoError(err)
+			_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+			if tt.in != nil && tt.in.CredentialStore != nil {
+				tt.in.ScopeId = prj.GetPublicId()
+			}
+			got, err := repo.CreateStore(context.Background(), tt.in, tt.opts...)
+			if tt.wantIsErr != nil {
+				assert.Truef(errors.Is(err, tt.wantIsErr), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Empty(tt.in.PublicId)
+			assertPublicId(t, CredentialStorePrefix, got.PublicId)
+			assert.NotSame(tt.in, got)
+			assert.Equal(tt.want.Name, got.Name)
+			assert.Equal(tt.want.Description, got.Description)
+			assert.Equal(got.CreateTime, got.UpdateTime)
+		})
+	}
+
+	t.Run("invalid-duplicate-names", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		kms := kms.TestKms(t, conn, wrapper)
+		repo, err := NewRepository(rw, rw, kms)
+		require.NoError(err)
+		_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+		in, err := NewCredentialStore(prj.GetPublicId(), WithName("test-name-repo"))
+		require.NoError(err)
+
+		got, err := repo.CreateStore(context.Background(), in)
+		require.NoError(err)
+		assert.NotNil(got)
+
+		got2, err := repo.CreateStore(context.Background(), in)
+		assert.Truef(errors.Is(err, db.ErrNotUnique), "want err: %v got: %v", db.ErrNotUnique, err)
+		assert.Nil(got2)
+	})
+}
+
+func TestRepository_UpdateStore(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	var tests = []struct {
+		name      string
+		orig      *CredentialStore
+		chgFn     func(*CredentialStore) *CredentialStore
+		masks     []string
+		want      *CredentialStore
+		wantCount int
+		wantIsErr error
+	}{
+		{
+			name: "change-name",
+			orig: &CredentialStore{
+				CredentialStore: &store.CredentialStore{
+					Name:        "test-name-repo",
+					Description: "test-description-repo",
+				},
+			},
+			chgFn: func(cs *CredentialStore) *CredentialStore {
+				cs.Name = "test-update-name-repo"
+				return cs
+			},
+			masks: []string{"Name"},
+			want: &CredentialStore{
+				CredentialStore: &store.CredentialStore{
+					Name:        "test-update-name-repo",
+					Description: "test-description-repo",
+				},
+			},
+			wantCount: 1,
+		},
+		{
+			name: "delete-description",
+			orig: &CredentialStore{
+				CredentialStore: &store.CredentialStore{
+					Name:        "test-name-repo",
+					Description: "test-description-repo",
+				},
+			},
+			chgFn: func(cs *CredentialStore) *CredentialStore {
+				cs.Description = ""
+				return cs
+			},
+			masks: []string{"Description"},
+			want: &CredentialStore{
+				CredentialStore: &store.CredentialStore{
+					Name: "test-name-repo",
+				},
+			},
+			wantCount: 1,
+		},
+		{
+			name: "empty-field-mask",
+			orig: &CredentialStore{
+				CredentialStore: &store.CredentialStore{
+					Name: "test-name-repo",
+				},
+			},
+			chgFn: func(cs *CredentialStore) *CredentialStore {
+				cs.Name = "test-update-name-repo"
+				return cs
+			},
+			wantIsErr: db.ErrEmptyFieldMask,
+		},
+		{
+			name: "read-only-fields-in-field-mask",
+			orig: &CredentialStore{
+				CredentialStore: &store.CredentialStore{
+					Name: "test-name-repo",
+				},
+			},
+			chgFn: func(cs *CredentialStore) *CredentialStore {
+				cs.Name = "test-update-name-repo"
+				return cs
+			},
+			masks:     []string{"PublicId", "CreateTime", "UpdateTime", "ScopeId"},
+			wantIsErr: db.ErrInvalidFieldMask,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			kms := kms.TestKms(t, conn, wrapper)
+			repo, err := NewRepository(rw, rw, kms)
+			require.NoError(err)
+			_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+			tt.orig.ScopeId = prj.GetPublicId()
+			orig, err := repo.CreateStore(context.Background(), tt.orig)
+			require.NoError(err)
+
+			got, gotCount, err := repo.UpdateStore(context.Background(), tt.chgFn(orig.clone()), orig.Version, tt.masks)
+			if tt.wantIsErr != nil {
+				assert.Truef(errors.Is(err, tt.wantIsErr), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Equal(tt.wantCount, gotCount)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantCount, gotCount)
+			assert.Equal(orig.PublicId, got.PublicId)
+			assert.Equal(tt.want.Name, got.Name)
+			assert.Equal(tt.want.Description, got.Description)
+			assert.Equal(orig.Version+1, got.Version)
+		})
+	}
+}
+
+func TestRepository_LookupStore(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStores(t, conn, prj.GetPublicId(), 1)[0]
+
+	badId, err := newCredentialStoreId()
+	require.NoError(t, err)
+
+	var tests = []struct {
+		name      string
+		id        string
+		want      *CredentialStore
+		wantIsErr error
+	}{
+		{
+			name: "found",
+			id:   cs.GetPublicId(),
+			want: cs,
+		},
+		{
+			name: "not-found",
+			id:   badId,
+		},
+		{
+			name:      "bad-public-id",
+			wantIsErr: db.ErrInvalidParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			kms := kms.TestKms(t, conn, wrapper)
+			repo, err := NewRepository(rw, rw, kms)
+			require.NoError(err)
+
+			got, err := repo.LookupStore(context.Background(), tt.id)
+			if tt.wantIsErr != nil {
+				assert.Truef(errors.Is(err, tt.wantIsErr), "want err: %q got: %q", tt.wantIsErr, err)
+				return
+			}
+			require.NoError(err)
+			if tt.want == nil {
+				assert.Nil(got)
+				return
+			}
+			assert.Equal(tt.want.PublicId, got.PublicId)
+			assert.Equal(tt.want.ScopeId, got.ScopeId)
+		})
+	}
+}
+
+func TestRepository_ListStores(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	stores := TestCredentialStores(t, conn, prj.GetPublicId(), 5)
+
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+
+	got, err := repo.ListStores(context.Background(), prj.GetPublicId())
+	require.NoError(err)
+	assert.Len(got, len(stores))
+
+	page, err := repo.ListStores(context.Background(), prj.GetPublicId(), WithLimit(2))
+	require.NoError(err)
+	require.Len(page, 2)
+	rest, err := repo.ListStores(context.Background(), prj.GetPublicId(), WithStartPageAfterId(page[1].PublicId))
+	require.NoError(err)
+	assert.Len(rest, len(stores)-2)
+
+	_, err = repo.ListStores(context.Background(), "")
+	assert.Truef(errors.Is(err, db.ErrInvalidParameter), "want err: %q got: %q", db.ErrInvalidParameter, err)
+}
+
+func TestRepository_DeleteStore(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+
+	credentialsWrapper, err := kmsCache.GetWrapper(context.Background(), prj.GetPublicId(), kms.KeyPurposeCredentials)
+	require.NoError(err)
+	cs := TestCredentialStores(t, conn, prj.GetPublicId(), 1)[0]
+	l := TestCredentialLibraries(t, conn, credentialsWrapper, cs.GetPublicId(), 1)[0]
+
+	count, err := repo.DeleteStore(context.Background(), cs.GetPublicId())
+	require.NoError(err)
+	assert.Equal(1, count)
+
+	// The libraries of the store are deleted with it
+	got, err := repo.LookupLibrary(context.Background(), l.GetPublicId())
+	require.NoError(err)
+	assert.Nil(got)
+
+	count, err = repo.DeleteStore(context.Background(), cs.GetPublicId())
+	require.NoError(err)
+	assert.Equal(db.NoRowsAffected, count)
+}
@@ -0,0 +1,52 @@
+package static
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// rewrapCredentialLibraryQuery replaces the encrypted secrets unless they
+// were changed since they were read.
+const rewrapCredentialLibraryQuery = `
+update credential_static_library
+   set password = ?,
+       private_key = ?,
+       key_id = ?
+ where public_id = ?
+   and key_id = ?
+   and password = ?
+   and private_key = ?;
+`
+
+func init() {
+	kms.RegisterTableRewrapFn("credential_static_library", "key_id", kms.KeyPurposeCredentials, rewrapCredentialLibraries)
+}
+
+// rewrapCredentialLibraries re-encrypts the secrets of credential libraries
+// encrypted with previous versions of the credential key.
+func rewrapCredentialLibraries(ctx context.Context, r db.Reader, w db.Writer, wrapper wrapping.Wrapper, keyVersionIds []string, limit int) (int, error) {
+	var libs []*CredentialLibrary
+	if err := r.SearchWhere(ctx, &libs, "key_id in (?)", []interface{}{keyVersionIds}, db.WithLimit(limit)); err != nil {
+		return 0, fmt.Errorf("rewrap static credential libraries: %w", err)
+	}
+	var rewrapped int
+	for _, l := range libs {
+		oldKeyId, oldPassword, oldPrivateKey := l.KeyId, l.CtPassword, l.CtPrivateKey
+		if err := l.decrypt(ctx, wrapper); err != nil {
+			return rewrapped, fmt.Errorf("rewrap static credential libraries: %w for %s", err, l.PublicId)
+		}
+		if err := l.encrypt(ctx, wrapper); err != nil {
+			return rewrapped, fmt.Errorf("rewrap static credential libraries: %w for %s", err, l.PublicId)
+		}
+		n, err := w.Exec(ctx, rewrapCredentialLibraryQuery, []interface{}{l.CtPassword, l.CtPrivateKey, l.KeyId, l.PublicId, oldKeyId, oldPassword, oldPrivateKey})
+		if err != nil {
+			return rewrapped, fmt.Errorf("rewrap static credential libraries: %w for %s", err, l.PublicId)
+		}
+		rewrapped += n
+	}
+	return rewrapped, nil
+}
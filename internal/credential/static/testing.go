@@ -0,0 +1,60 @@
+package static
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCredentialStores creates count number of static credential stores in
+// the provided DB with the provided scope id. If any errors are encountered
+// during the creation of the credential stores, the test will fail.
+func TestCredentialStores(t *testing.T, conn *gorm.DB, scopeId string, count int) []*CredentialStore {
+	t.Helper()
+	require := require.New(t)
+	var stores []*CredentialStore
+	for i := 0; i < count; i++ {
+		cs, err := NewCredentialStore(scopeId)
+		require.NoError(err)
+		id, err := newCredentialStoreId()
+		require.NoError(err)
+		cs.PublicId = id
+
+		w := db.New(conn)
+		require.NoError(w.Create(context.Background(), cs))
+		stores = append(stores, cs)
+	}
+	return stores
+}
+
+// TestCredentialLibraries creates count number of static credential
+// libraries in the provided DB with the provided store id. The store must
+// have been created previously. Each library has a username and a password,
+// which is encrypted with credentialsWrapper, which should be the credentials
+// wrapper of the scope of the store. If any errors are encountered during the
+// creation of the credential libraries, the test will fail.
+func TestCredentialLibraries(t *testing.T, conn *gorm.DB, credentialsWrapper wrapping.Wrapper, storeId string, count int) []*CredentialLibrary {
+	t.Helper()
+	require := require.New(t)
+	ctx := context.Background()
+	var libraries []*CredentialLibrary
+	for i := 0; i < count; i++ {
+		l, err := NewCredentialLibrary(storeId, fmt.Sprintf("user-%d", i), WithPassword(fmt.Sprintf("password-%d", i)))
+		require.NoError(err)
+		require.NoError(l.validate())
+		id, err := newCredentialLibraryId()
+		require.NoError(err)
+		l.PublicId = id
+		require.NoError(l.encrypt(ctx, credentialsWrapper))
+
+		w := db.New(conn)
+		require.NoError(w.Create(ctx, l))
+		libraries = append(libraries, l)
+	}
+	return libraries
+}
@@ -0,0 +1,47 @@
+package static
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TestCredentialStores(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	require.NotNil(proj)
+
+	count := 4
+	stores := TestCredentialStores(t, conn, proj.GetPublicId(), count)
+	assert.Len(stores, count)
+	for _, cs := range stores {
+		assert.NotEmpty(cs.GetPublicId())
+	}
+}
+
+func Test_TestCredentialLibraries(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	require.NotNil(proj)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	credentialsWrapper, err := kmsCache.GetWrapper(context.Background(), proj.GetPublicId(), kms.KeyPurposeCredentials)
+	require.NoError(err)
+
+	cs := TestCredentialStores(t, conn, proj.GetPublicId(), 1)[0]
+	count := 4
+	libraries := TestCredentialLibraries(t, conn, credentialsWrapper, cs.GetPublicId(), count)
+	assert.Len(libraries, count)
+	for _, l := range libraries {
+		assert.NotEmpty(l.GetPublicId())
+		assert.NotEmpty(l.GetCtPassword())
+	}
+}
@@ -5355,6 +5355,4277 @@ begin;
 
 commit;
 
+`),
+	},
+	"migrations/70_session_recording.down.sql": {
+		name: "70_session_recording.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop table session_connection_recording;
+
+  drop view session_with_state;
+
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+  drop trigger immutable_columns on session;
+
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint');
+
+  alter table session
+    drop column recording_enabled;
+
+  drop view target_all_subtypes;
+
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp;
+
+  alter table target_tcp
+    drop column enable_session_recording;
+
+commit;
+
+`),
+	},
+	"migrations/70_session_recording.up.sql": {
+		name: "70_session_recording.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- enable_session_recording determines if the worker records the data
+  -- proxied for each connection of a session created for the target.
+  alter table target_tcp
+    add column enable_session_recording boolean not null default false;
+
+  drop view target_all_subtypes;
+
+  -- target_all_subtypes is a union of all target subtypes
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp;
+
+  -- recording_enabled is copied from the target when the session is created
+  -- and cannot be changed afterwards.
+  alter table session
+    add column recording_enabled boolean not null default false;
+
+  drop trigger immutable_columns on session;
+
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'recording_enabled');
+
+  drop view session_with_state;
+
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    s.recording_enabled,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+  -- session_connection_recording contains the metadata of a recording made by
+  -- a worker for a session connection. The recording itself is stored by the
+  -- worker at storage_path.
+  create table session_connection_recording (
+    connection_id wt_public_id primary key
+      references session_connection (public_id)
+      on delete cascade
+      on update cascade,
+    session_id wt_public_id not null
+      references session (public_id)
+      on delete cascade
+      on update cascade,
+    -- worker_id is the id of the worker which made the recording
+    worker_id text not null,
+    -- storage_path is the location of the recording on the worker
+    storage_path text not null
+      constraint storage_path_must_not_be_empty
+      check(length(trim(storage_path)) > 0),
+    -- the total number of bytes recorded from the client to the endpoint
+    bytes_up bigint not null default 0
+      constraint bytes_up_must_be_zero_or_greater
+      check(bytes_up >= 0),
+    -- the total number of bytes recorded from the endpoint to the client
+    bytes_down bigint not null default 0
+      constraint bytes_down_must_be_zero_or_greater
+      check(bytes_down >= 0),
+    start_time timestamp with time zone not null,
+    end_time timestamp with time zone not null
+      constraint end_time_must_not_be_before_start_time
+      check(end_time >= start_time),
+    create_time wt_timestamp
+  );
+
+  create trigger
+    immutable_columns
+  before
+  update on session_connection_recording
+    for each row execute procedure immutable_columns('connection_id', 'session_id', 'create_time');
+
+  create trigger
+    default_create_time_column
+  before
+  insert on session_connection_recording
+    for each row execute procedure default_create_time();
+
+commit;
+
+`),
+	},
+	"migrations/71_target_udp.down.sql": {
+		name: "71_target_udp.down.sql",
+		bytes: []byte(`
+begin;
+
+  delete from target
+   where public_id in (select public_id from target_udp);
+
+  drop view whx_host_dimension_source;
+
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         'tcp target'                    as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_tcp as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+  drop view target_all_subtypes;
+
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp;
+
+  drop table target_udp;
+
+  delete from oplog_ticket where name = 'target_udp';
+
+commit;
+
+`),
+	},
+	"migrations/71_target_udp.up.sql": {
+		name: "71_target_udp.up.sql",
+		bytes: []byte(`
+begin;
+
+  create table target_udp (
+    public_id wt_public_id primary key
+      references target(public_id)
+      on delete cascade
+      on update cascade,
+    scope_id wt_scope_id not null
+      references iam_scope(public_id)
+      on delete cascade
+      on update cascade,
+    name text not null, -- name is not optional for a target subtype
+    description text,
+    default_port int, -- default_port can be null
+     -- max duration of the session in seconds.
+     -- default is 8 hours
+    session_max_seconds int not null default 28800
+      constraint session_max_seconds_must_be_greater_than_0
+      check(session_max_seconds > 0),
+    -- limit on number of session connections allowed. -1 equals no limit
+    session_connection_limit int not null default 1
+      constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+      check(session_connection_limit > 0 or session_connection_limit = -1),
+    enable_session_recording boolean not null default false,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(scope_id, name) -- name must be unique within a scope
+  );
+
+  create trigger
+    insert_target_subtype
+  before insert on target_udp
+    for each row execute procedure insert_target_subtype();
+
+  create trigger
+    delete_target_subtype
+  after delete on target_udp
+    for each row execute procedure delete_target_subtype();
+
+   -- define the immutable fields for target
+  create trigger
+    immutable_columns
+  before
+  update on target_udp
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger
+    update_version_column
+  after update on target_udp
+    for each row execute procedure update_version_column();
+
+  create trigger
+    update_time_column
+  before update on target_udp
+    for each row execute procedure update_time_column();
+
+  create trigger
+    default_create_time_column
+  before
+  insert on target_udp
+    for each row execute procedure default_create_time();
+
+  create trigger
+    target_scope_valid
+  before insert on target_udp
+    for each row execute procedure target_scope_valid();
+
+  drop view target_all_subtypes;
+
+  -- target_all_subtypes is a union of all target subtypes
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp;
+
+  -- whx_host_dimension_source is recreated to include the udp targets so
+  -- sessions for udp targets are added to the warehouse.
+  drop view whx_host_dimension_source;
+
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+  insert into oplog_ticket
+    (name, version)
+  values
+    ('target_udp', 1);
+
+commit;
+
+`),
+	},
+	"migrations/72_bandwidth_limits.down.sql": {
+		name: "72_bandwidth_limits.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop view session_with_state;
+
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    s.recording_enabled,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+  drop trigger immutable_columns on session;
+
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'recording_enabled');
+
+  alter table session
+    drop column connection_max_bytes_per_second,
+    drop column session_max_bytes_per_second;
+
+  drop view whx_host_dimension_source;
+  drop view target_all_subtypes;
+
+  -- target_all_subtypes is a union of all target subtypes
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp;
+
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+  alter table target_udp
+    drop column connection_max_bytes_per_second,
+    drop column session_max_bytes_per_second;
+
+  alter table target_tcp
+    drop column connection_max_bytes_per_second,
+    drop column session_max_bytes_per_second;
+
+commit;
+
+`),
+	},
+	"migrations/72_bandwidth_limits.up.sql": {
+		name: "72_bandwidth_limits.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- connection_max_bytes_per_second limits the throughput of each connection
+  -- of a session created for the target and session_max_bytes_per_second
+  -- limits the combined throughput of all the connections of the session. The
+  -- limits are enforced by the worker in each direction. 0 equals no limit.
+  alter table target_tcp
+    add column connection_max_bytes_per_second bigint not null default 0
+      constraint connection_max_bytes_per_second_must_be_zero_or_greater
+      check(connection_max_bytes_per_second >= 0),
+    add column session_max_bytes_per_second bigint not null default 0
+      constraint session_max_bytes_per_second_must_be_zero_or_greater
+      check(session_max_bytes_per_second >= 0);
+
+  alter table target_udp
+    add column connection_max_bytes_per_second bigint not null default 0
+      constraint connection_max_bytes_per_second_must_be_zero_or_greater
+      check(connection_max_bytes_per_second >= 0),
+    add column session_max_bytes_per_second bigint not null default 0
+      constraint session_max_bytes_per_second_must_be_zero_or_greater
+      check(session_max_bytes_per_second >= 0);
+
+  drop view whx_host_dimension_source;
+  drop view target_all_subtypes;
+
+  -- target_all_subtypes is a union of all target subtypes
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp;
+
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+  -- the limits are copied from the target when the session is created and
+  -- cannot be changed afterwards.
+  alter table session
+    add column connection_max_bytes_per_second bigint not null default 0
+      constraint connection_max_bytes_per_second_must_be_zero_or_greater
+      check(connection_max_bytes_per_second >= 0),
+    add column session_max_bytes_per_second bigint not null default 0
+      constraint session_max_bytes_per_second_must_be_zero_or_greater
+      check(session_max_bytes_per_second >= 0);
+
+  drop trigger immutable_columns on session;
+
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'recording_enabled', 'connection_max_bytes_per_second', 'session_max_bytes_per_second');
+
+  drop view session_with_state;
+
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    s.recording_enabled,
+    s.connection_max_bytes_per_second,
+    s.session_max_bytes_per_second,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+commit;
+
+`),
+	},
+	"migrations/73_session_idle_timeout.down.sql": {
+		name: "73_session_idle_timeout.down.sql",
+		bytes: []byte(`
+begin;
+
+  update session
+     set termination_reason = 'unknown'
+   where termination_reason = 'idle timeout';
+
+  delete from session_termination_reason_enm
+   where name = 'idle timeout';
+
+  alter table session_termination_reason_enm
+    drop constraint only_predefined_session_termination_reasons_allowed;
+
+  alter table session_termination_reason_enm
+    add constraint only_predefined_session_termination_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'terminated',
+          'network error',
+          'system error',
+          'connection limit',
+          'canceled'
+        )
+      );
+
+  drop view session_with_state;
+
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    s.recording_enabled,
+    s.connection_max_bytes_per_second,
+    s.session_max_bytes_per_second,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+  drop trigger immutable_columns on session;
+
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'recording_enabled', 'connection_max_bytes_per_second', 'session_max_bytes_per_second');
+
+  alter table session
+    drop column idle_timeout_seconds;
+
+  drop view whx_host_dimension_source;
+  drop view target_all_subtypes;
+
+  -- target_all_subtypes is a union of all target subtypes
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp;
+
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+  alter table target_udp
+    drop column session_idle_timeout_seconds;
+
+  alter table target_tcp
+    drop column session_idle_timeout_seconds;
+
+commit;
+
+`),
+	},
+	"migrations/73_session_idle_timeout.up.sql": {
+		name: "73_session_idle_timeout.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- session_idle_timeout_seconds is the number of seconds a session created
+  -- for the target may go without any data being proxied for any of its
+  -- connections before the worker closes the connections and the session is
+  -- terminated. 0 equals no idle timeout.
+  alter table target_tcp
+    add column session_idle_timeout_seconds int not null default 0
+      constraint session_idle_timeout_seconds_must_be_zero_or_greater
+      check(session_idle_timeout_seconds >= 0);
+
+  alter table target_udp
+    add column session_idle_timeout_seconds int not null default 0
+      constraint session_idle_timeout_seconds_must_be_zero_or_greater
+      check(session_idle_timeout_seconds >= 0);
+
+  drop view whx_host_dimension_source;
+  drop view target_all_subtypes;
+
+  -- target_all_subtypes is a union of all target subtypes
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp;
+
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+  -- idle_timeout_seconds is copied from the target when the session is
+  -- created and cannot be changed afterwards.
+  alter table session
+    add column idle_timeout_seconds int not null default 0
+      constraint idle_timeout_seconds_must_be_zero_or_greater
+      check(idle_timeout_seconds >= 0);
+
+  drop trigger immutable_columns on session;
+
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'recording_enabled', 'connection_max_bytes_per_second', 'session_max_bytes_per_second', 'idle_timeout_seconds');
+
+  drop view session_with_state;
+
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    s.recording_enabled,
+    s.connection_max_bytes_per_second,
+    s.session_max_bytes_per_second,
+    s.idle_timeout_seconds,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+  alter table session_termination_reason_enm
+    drop constraint only_predefined_session_termination_reasons_allowed;
+
+  alter table session_termination_reason_enm
+    add constraint only_predefined_session_termination_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'terminated',
+          'network error',
+          'system error',
+          'connection limit',
+          'canceled',
+          'idle timeout'
+        )
+      );
+
+  insert into session_termination_reason_enm (name)
+  values
+    ('idle timeout');
+
+commit;
+
+`),
+	},
+	"migrations/74_auth_oidc.down.sql": {
+		name: "74_auth_oidc.down.sql",
+		bytes: []byte(`
+begin;
+
+  create or replace view whx_user_dimension_source as
+       select -- id is the first column in the target view
+              u.public_id                       as user_id,
+              coalesce(u.name, 'None')          as user_name,
+              coalesce(u.description, 'None')   as user_description,
+              coalesce(aa.public_id, 'None')    as auth_account_id,
+              case when aa.public_id is null then 'None'
+                   else 'password auth account'
+                   end                          as auth_account_type,
+              coalesce(apa.name, 'None')        as auth_account_name,
+              coalesce(apa.description, 'None') as auth_account_description,
+              coalesce(am.public_id, 'None')    as auth_method_id,
+              case when am.public_id is null then 'None'
+                   else 'password auth method'
+                   end                          as auth_method_type,
+              coalesce(apm.name, 'None')        as auth_method_name,
+              coalesce(apm.description, 'None') as auth_method_description,
+              org.public_id                     as user_organization_id,
+              coalesce(org.name, 'None')        as user_organization_name,
+              coalesce(org.description, 'None') as user_organization_description
+         from iam_user as u
+    left join auth_account as aa on           u.public_id = aa.iam_user_id
+    left join auth_method as am on            aa.auth_method_id = am.public_id
+    left join auth_password_account as apa on aa.public_id = apa.public_id
+    left join auth_password_method as apm on  am.public_id = apm.public_id
+         join iam_scope as org on             u.scope_id = org.public_id
+  ;
+
+  -- Deleting the base rows cascades to the oidc accounts and auth methods.
+  delete from auth_method
+   where public_id in (select public_id from auth_oidc_method);
+
+  drop table auth_oidc_account;
+  drop table auth_oidc_method;
+
+  delete from oplog_ticket where name in ('auth_oidc_method', 'auth_oidc_account');
+
+commit;
+
+`),
+	},
+	"migrations/74_auth_oidc.up.sql": {
+		name: "74_auth_oidc.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+
+       ┌────────────────┐                 ┌──────────────────────┐
+       │  auth_method   │                 │   auth_oidc_method   │
+       ├────────────────┤                 ├──────────────────────┤
+       │ public_id (pk) │                 │ public_id (pk,fk)    │
+       │ scope_id  (fk) │┼┼─────────────○┼│ scope_id  (fk)       │
+       │                │                 │ ...                  │
+       └────────────────┘                 └──────────────────────┘
+                ┼                                     ┼
+                ┼                                     ┼
+                │                                     │
+                │ ▲fk1                                │ ▲fk1
+                │                                     │
+                ○                                     ○
+               ╱│╲                                   ╱│╲
+  ┌──────────────────────────┐          ┌──────────────────────────┐
+  │       auth_account       │          │    auth_oidc_account     │
+  ├──────────────────────────┤          ├──────────────────────────┤
+  │ public_id         (pk)   │          │ public_id      (pk,fk2)  │
+  │ scope_id          (fk1)  │   ◀fk2   │ scope_id       (fk1,fk2) │
+  │ auth_method_id    (fk1)  │┼┼──────○┼│ auth_method_id (fk1,fk2) │
+  │ iam_user_scope_id (fk2)  │          │ ...                      │
+  │ iam_user_id       (fk2)  │          └──────────────────────────┘
+  └──────────────────────────┘
+
+  An auth_oidc_method is an auth_method subtype which delegates
+  authentication to an OIDC provider. For every row in auth_oidc_method there
+  is one row in auth_method with the same public_id and scope_id.
+
+  An auth_oidc_account is an auth_account subtype. For every row in
+  auth_oidc_account there is one row in auth_account with the same public_id,
+  scope_id, and auth_method_id. An auth_oidc_account is created the first
+  time a subject of an issuer authenticates with the auth_oidc_method.
+
+*/
+
+  create table auth_oidc_method (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    discovery_url text not null
+      constraint discovery_url_must_not_be_empty
+      check(length(trim(discovery_url)) > 0),
+    client_id text not null
+      constraint client_id_must_not_be_empty
+      check(length(trim(client_id)) > 0),
+    -- client_secret is encrypted with the database key of the scope
+    client_secret bytea not null
+      constraint client_secret_must_not_be_empty
+      check(length(client_secret) > 0),
+    -- TODO: Make key_id a foreign key once we have DEKs
+    key_id text not null
+      constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0),
+    -- comma separated lists
+    allowed_audiences text,
+    account_claim_maps text,
+    foreign key (scope_id, public_id)
+      references auth_method (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, name),
+    unique(scope_id, public_id)
+  );
+
+  create trigger
+    update_version_column
+  after update on auth_oidc_method
+    for each row execute procedure update_version_column();
+
+  create trigger
+    insert_auth_method_subtype
+  before insert on auth_oidc_method
+    for each row execute procedure insert_auth_method_subtype();
+
+  create table auth_oidc_account (
+    public_id wt_public_id
+      primary key,
+    auth_method_id wt_public_id
+      not null,
+    -- NOTE: The scope_id type is not wt_scope_id because the domain check is
+    -- executed before the insert trigger which retrieves the scope_id causing
+    -- an insert to fail.
+    scope_id text not null,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    issuer text not null
+      constraint issuer_must_not_be_empty
+      check(length(trim(issuer)) > 0),
+    subject text not null
+      constraint subject_must_not_be_empty
+      check(length(trim(subject)) > 0),
+    full_name text,
+    email text,
+    foreign key (scope_id, auth_method_id)
+      references auth_oidc_method (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    foreign key (scope_id, auth_method_id, public_id)
+      references auth_account (scope_id, auth_method_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(auth_method_id, name),
+    unique(auth_method_id, issuer, subject),
+    unique(auth_method_id, public_id)
+  );
+
+  create trigger
+    update_version_column
+  after update on auth_oidc_account
+    for each row execute procedure update_version_column();
+
+  create trigger
+    insert_auth_account_subtype
+  before insert on auth_oidc_account
+    for each row execute procedure insert_auth_account_subtype();
+
+  --
+  -- triggers for time columns
+  --
+
+  create trigger
+    update_time_column
+  before
+  update on auth_oidc_method
+    for each row execute procedure update_time_column();
+
+  create trigger
+    immutable_columns
+  before
+  update on auth_oidc_method
+    for each row execute procedure immutable_columns('create_time');
+
+  create trigger
+    default_create_time_column
+  before
+  insert on auth_oidc_method
+    for each row execute procedure default_create_time();
+
+  create trigger
+    update_time_column
+  before
+  update on auth_oidc_account
+    for each row execute procedure update_time_column();
+
+  create trigger
+    immutable_columns
+  before
+  update on auth_oidc_account
+    for each row execute procedure immutable_columns('create_time', 'issuer', 'subject');
+
+  create trigger
+    default_create_time_column
+  before
+  insert on auth_oidc_account
+    for each row execute procedure default_create_time();
+
+  insert into oplog_ticket
+    (name, version)
+  values
+    ('auth_oidc_method', 1),
+    ('auth_oidc_account', 1);
+
+  -- Replaces the view from 65_wh_session_dimensions to include oidc accounts
+  -- and auth methods.
+  create or replace view whx_user_dimension_source as
+       select -- id is the first column in the target view
+              u.public_id                       as user_id,
+              coalesce(u.name, 'None')          as user_name,
+              coalesce(u.description, 'None')   as user_description,
+              coalesce(aa.public_id, 'None')    as auth_account_id,
+              case when aa.public_id is null then 'None'
+                   when aoa.public_id is not null then 'oidc auth account'
+                   else 'password auth account'
+                   end                          as auth_account_type,
+              coalesce(apa.name, aoa.name, 'None') as auth_account_name,
+              coalesce(apa.description, aoa.description, 'None') as auth_account_description,
+              coalesce(am.public_id, 'None')    as auth_method_id,
+              case when am.public_id is null then 'None'
+                   when aom.public_id is not null then 'oidc auth method'
+                   else 'password auth method'
+                   end                          as auth_method_type,
+              coalesce(apm.name, aom.name, 'None') as auth_method_name,
+              coalesce(apm.description, aom.description, 'None') as auth_method_description,
+              org.public_id                     as user_organization_id,
+              coalesce(org.name, 'None')        as user_organization_name,
+              coalesce(org.description, 'None') as user_organization_description
+         from iam_user as u
+    left join auth_account as aa on           u.public_id = aa.iam_user_id
+    left join auth_method as am on            aa.auth_method_id = am.public_id
+    left join auth_password_account as apa on aa.public_id = apa.public_id
+    left join auth_password_method as apm on  am.public_id = apm.public_id
+    left join auth_oidc_account as aoa on     aa.public_id = aoa.public_id
+    left join auth_oidc_method as aom on      am.public_id = aom.public_id
+         join iam_scope as org on             u.scope_id = org.public_id
+  ;
+
+commit;
+
+`),
+	},
+	"migrations/75_auth_ldap.down.sql": {
+		name: "75_auth_ldap.down.sql",
+		bytes: []byte(`
+begin;
+
+  create or replace view whx_user_dimension_source as
+       select -- id is the first column in the target view
+              u.public_id                       as user_id,
+              coalesce(u.name, 'None')          as user_name,
+              coalesce(u.description, 'None')   as user_description,
+              coalesce(aa.public_id, 'None')    as auth_account_id,
+              case when aa.public_id is null then 'None'
+                   when aoa.public_id is not null then 'oidc auth account'
+                   else 'password auth account'
+                   end                          as auth_account_type,
+              coalesce(apa.name, aoa.name, 'None') as auth_account_name,
+              coalesce(apa.description, aoa.description, 'None') as auth_account_description,
+              coalesce(am.public_id, 'None')    as auth_method_id,
+              case when am.public_id is null then 'None'
+                   when aom.public_id is not null then 'oidc auth method'
+                   else 'password auth method'
+                   end                          as auth_method_type,
+              coalesce(apm.name, aom.name, 'None') as auth_method_name,
+              coalesce(apm.description, aom.description, 'None') as auth_method_description,
+              org.public_id                     as user_organization_id,
+              coalesce(org.name, 'None')        as user_organization_name,
+              coalesce(org.description, 'None') as user_organization_description
+         from iam_user as u
+    left join auth_account as aa on           u.public_id = aa.iam_user_id
+    left join auth_method as am on            aa.auth_method_id = am.public_id
+    left join auth_password_account as apa on aa.public_id = apa.public_id
+    left join auth_password_method as apm on  am.public_id = apm.public_id
+    left join auth_oidc_account as aoa on     aa.public_id = aoa.public_id
+    left join auth_oidc_method as aom on      am.public_id = aom.public_id
+         join iam_scope as org on             u.scope_id = org.public_id
+  ;
+
+  -- Deleting the base rows cascades to the ldap accounts and auth methods.
+  delete from auth_method
+   where public_id in (select public_id from auth_ldap_method);
+
+  drop table auth_ldap_account;
+  drop table auth_ldap_method;
+
+  delete from oplog_ticket where name in ('auth_ldap_method', 'auth_ldap_account');
+
+commit;
+
+`),
+	},
+	"migrations/75_auth_ldap.up.sql": {
+		name: "75_auth_ldap.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+
+       ┌────────────────┐                 ┌──────────────────────┐
+       │  auth_method   │                 │   auth_ldap_method   │
+       ├────────────────┤                 ├──────────────────────┤
+       │ public_id (pk) │                 │ public_id (pk,fk)    │
+       │ scope_id  (fk) │┼┼─────────────○┼│ scope_id  (fk)       │
+       │                │                 │ ...                  │
+       └────────────────┘                 └──────────────────────┘
+                ┼                                     ┼
+                ┼                                     ┼
+                │                                     │
+                │ ▲fk1                                │ ▲fk1
+                │                                     │
+                ○                                     ○
+               ╱│╲                                   ╱│╲
+  ┌──────────────────────────┐          ┌──────────────────────────┐
+  │       auth_account       │          │    auth_ldap_account     │
+  ├──────────────────────────┤          ├──────────────────────────┤
+  │ public_id         (pk)   │          │ public_id      (pk,fk2)  │
+  │ scope_id          (fk1)  │   ◀fk2   │ scope_id       (fk1,fk2) │
+  │ auth_method_id    (fk1)  │┼┼──────○┼│ auth_method_id (fk1,fk2) │
+  │ iam_user_scope_id (fk2)  │          │ ...                      │
+  │ iam_user_id       (fk2)  │          └──────────────────────────┘
+  └──────────────────────────┘
+
+  An auth_ldap_method is an auth_method subtype which authenticates users
+  against an LDAP directory. For every row in auth_ldap_method there is one
+  row in auth_method with the same public_id and scope_id.
+
+  An auth_ldap_account is an auth_account subtype. For every row in
+  auth_ldap_account there is one row in auth_account with the same public_id,
+  scope_id, and auth_method_id. An auth_ldap_account is created by an
+  administrator or the first time a user authenticates with the
+  auth_ldap_method.
+
+*/
+
+  create table auth_ldap_method (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    -- comma separated list of ldap:// or ldaps:// urls
+    urls text not null
+      constraint urls_must_not_be_empty
+      check(length(trim(urls)) > 0),
+    start_tls boolean not null default false,
+    insecure_tls boolean not null default false,
+    -- pem encoded ca certificates
+    certificates text,
+    -- comma separated list of hex encoded sha-256 fingerprints
+    certificate_pins text,
+    bind_dn text,
+    -- bind_password is encrypted with the database key of the scope
+    bind_password bytea
+      constraint bind_password_must_not_be_empty
+      check(length(bind_password) > 0),
+    -- TODO: Make key_id a foreign key once we have DEKs
+    key_id text
+      constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0),
+    user_dn text not null
+      constraint user_dn_must_not_be_empty
+      check(length(trim(user_dn)) > 0),
+    user_filter text not null
+      constraint user_filter_must_not_be_empty
+      check(length(trim(user_filter)) > 0),
+    group_dn text,
+    group_filter text not null
+      constraint group_filter_must_not_be_empty
+      check(length(trim(group_filter)) > 0),
+    group_attr text not null
+      constraint group_attr_must_not_be_empty
+      check(length(trim(group_attr)) > 0),
+    sync_groups boolean not null default false,
+    constraint bind_password_and_key_id_must_be_set_together
+      check((bind_password is null) = (key_id is null)),
+    constraint sync_groups_requires_group_dn
+      check(not sync_groups or length(trim(group_dn)) > 0),
+    foreign key (scope_id, public_id)
+      references auth_method (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, name),
+    unique(scope_id, public_id)
+  );
+
+  create trigger
+    update_version_column
+  after update on auth_ldap_method
+    for each row execute procedure update_version_column();
+
+  create trigger
+    insert_auth_method_subtype
+  before insert on auth_ldap_method
+    for each row execute procedure insert_auth_method_subtype();
+
+  create table auth_ldap_account (
+    public_id wt_public_id
+      primary key,
+    auth_method_id wt_public_id
+      not null,
+    -- NOTE: The scope_id type is not wt_scope_id because the domain check is
+    -- executed before the insert trigger which retrieves the scope_id causing
+    -- an insert to fail.
+    scope_id text not null,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    login_name text not null
+      constraint login_name_must_be_lowercase
+      check(lower(trim(login_name)) = login_name)
+      constraint login_name_must_not_be_empty
+      check(length(trim(login_name)) > 0),
+    dn text,
+    full_name text,
+    email text,
+    -- comma separated list of group names as of the last authentication
+    member_of_groups text,
+    foreign key (scope_id, auth_method_id)
+      references auth_ldap_method (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    foreign key (scope_id, auth_method_id, public_id)
+      references auth_account (scope_id, auth_method_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(auth_method_id, name),
+    unique(auth_method_id, login_name),
+    unique(auth_method_id, public_id)
+  );
+
+  create trigger
+    update_version_column
+  after update on auth_ldap_account
+    for each row execute procedure update_version_column();
+
+  create trigger
+    insert_auth_account_subtype
+  before insert on auth_ldap_account
+    for each row execute procedure insert_auth_account_subtype();
+
+  --
+  -- triggers for time columns
+  --
+
+  create trigger
+    update_time_column
+  before
+  update on auth_ldap_method
+    for each row execute procedure update_time_column();
+
+  create trigger
+    immutable_columns
+  before
+  update on auth_ldap_method
+    for each row execute procedure immutable_columns('create_time');
+
+  create trigger
+    default_create_time_column
+  before
+  insert on auth_ldap_method
+    for each row execute procedure default_create_time();
+
+  create trigger
+    update_time_column
+  before
+  update on auth_ldap_account
+    for each row execute procedure update_time_column();
+
+  create trigger
+    immutable_columns
+  before
+  update on auth_ldap_account
+    for each row execute procedure immutable_columns('create_time');
+
+  create trigger
+    default_create_time_column
+  before
+  insert on auth_ldap_account
+    for each row execute procedure default_create_time();
+
+  insert into oplog_ticket
+    (name, version)
+  values
+    ('auth_ldap_method', 1),
+    ('auth_ldap_account', 1);
+
+  -- Replaces the view from 74_auth_oidc to include ldap accounts and auth
+  -- methods.
+  create or replace view whx_user_dimension_source as
+       select -- id is the first column in the target view
+              u.public_id                       as user_id,
+              coalesce(u.name, 'None')          as user_name,
+              coalesce(u.description, 'None')   as user_description,
+              coalesce(aa.public_id, 'None')    as auth_account_id,
+              case when aa.public_id is null then 'None'
+                   when aoa.public_id is not null then 'oidc auth account'
+                   when ala.public_id is not null then 'ldap auth account'
+                   else 'password auth account'
+                   end                          as auth_account_type,
+              coalesce(apa.name, aoa.name, ala.name, 'None') as auth_account_name,
+              coalesce(apa.description, aoa.description, ala.description, 'None') as auth_account_description,
+              coalesce(am.public_id, 'None')    as auth_method_id,
+              case when am.public_id is null then 'None'
+                   when aom.public_id is not null then 'oidc auth method'
+                   when alm.public_id is not null then 'ldap auth method'
+                   else 'password auth method'
+                   end                          as auth_method_type,
+              coalesce(apm.name, aom.name, alm.name, 'None') as auth_method_name,
+              coalesce(apm.description, aom.description, alm.description, 'None') as auth_method_description,
+              org.public_id                     as user_organization_id,
+              coalesce(org.name, 'None')        as user_organization_name,
+              coalesce(org.description, 'None') as user_organization_description
+         from iam_user as u
+    left join auth_account as aa on           u.public_id = aa.iam_user_id
+    left join auth_method as am on            aa.auth_method_id = am.public_id
+    left join auth_password_account as apa on aa.public_id = apa.public_id
+    left join auth_password_method as apm on  am.public_id = apm.public_id
+    left join auth_oidc_account as aoa on     aa.public_id = aoa.public_id
+    left join auth_oidc_method as aom on      am.public_id = aom.public_id
+    left join auth_ldap_account as ala on     aa.public_id = ala.public_id
+    left join auth_ldap_method as alm on      am.public_id = alm.public_id
+         join iam_scope as org on             u.scope_id = org.public_id
+  ;
+
+commit;
+
+`),
+	},
+	"migrations/76_inventory_host.down.sql": {
+		name: "76_inventory_host.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop view whx_host_dimension_source;
+
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+  drop table inventory_host_set_member cascade;
+  drop table inventory_host_set cascade;
+  drop table inventory_host cascade;
+  drop table inventory_host_catalog_sync cascade;
+  drop table inventory_host_catalog cascade;
+
+  drop function insert_inventory_host_set_member;
+  drop function insert_inventory_host_catalog_sync;
+
+  delete
+    from oplog_ticket
+   where name in (
+          'inventory_host_catalog',
+          'inventory_host',
+          'inventory_host_set',
+          'inventory_host_set_member'
+        );
+
+commit;
+
+`),
+	},
+	"migrations/76_inventory_host.up.sql": {
+		name: "76_inventory_host.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+
+  ┌─────────────────┐          ┌────────────────────────┐
+  │      host       │          │     inventory_host     │
+  ├─────────────────┤          ├────────────────────────┤
+  │ public_id  (pk) │          │ public_id   (pk)       │
+  │ catalog_id (fk) │┼┼──────○┼│ catalog_id  (fk)       │┼┼──────────────────────┐
+  │                 │          │ external_id            │             ◀fk1       │
+  └─────────────────┘          └────────────────────────┘                        │
+          ╲│╱                              ╲│╱                                   │
+           ○                                ○                                    │
+           │                                │                                    │
+           ┼                                ┼                                    ○
+           ┼                                ┼                                   ╱│╲
+  ┌─────────────────┐          ┌────────────────────────┐         ┌───────────────────────────┐
+  │  host_catalog   │          │ inventory_host_catalog │         │ inventory_host_set_member │
+  ├─────────────────┤          ├────────────────────────┤         ├───────────────────────────┤
+  │ public_id (pk)  │          │ public_id (pk)         │         │ host_id    (pk,fk1)       │
+  │ scope_id  (fk)  │┼┼──────○┼│ scope_id  (fk)         │         │ set_id     (pk,fk2)       │
+  │                 │          │ source                 │         │ catalog_id (fk1,fk2)      │
+  └─────────────────┘          └────────────────────────┘         └───────────────────────────┘
+           ┼                                ┼                                   ╲│╱
+           ┼                                ┼                                    ○
+           │                                │                                    │
+           ○                                ○                                    │
+          ╱│╲                              ╱│╲                                   │
+  ┌─────────────────┐          ┌────────────────────────┐                        │
+  │    host_set     │          │   inventory_host_set   │                        │
+  ├─────────────────┤          ├────────────────────────┤                        │
+  │ public_id  (pk) │          │ public_id  (pk)        │             ◀fk2       │
+  │ catalog_id (fk) │┼┼──────○┼│ catalog_id (fk)        │┼┼──────────────────────┘
+  │                 │          │ match_labels           │
+  └─────────────────┘          └────────────────────────┘
+
+  An inventory_host_catalog is a host_catalog subtype whose hosts are synced
+  from an inventory document at its source. The controllers periodically
+  fetch the document and reconcile the inventory_host rows of the catalog with
+  it. The membership of an inventory_host_set is the hosts of its catalog whose
+  labels match its match_labels, and is maintained by the same reconciliation.
+
+*/
+
+  create table inventory_host_catalog (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    -- source is an absolute file path or an http, https, or file url of the
+    -- inventory document
+    source text not null
+      constraint source_must_not_be_empty
+      check(length(trim(source)) > 0),
+    sync_interval_seconds int not null default 300
+      constraint sync_interval_seconds_must_be_greater_than_0
+      check(sync_interval_seconds > 0),
+    foreign key (scope_id, public_id)
+      references host_catalog (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, name)
+  );
+
+  create trigger update_version_column after update on inventory_host_catalog
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on inventory_host_catalog
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on inventory_host_catalog
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on inventory_host_catalog
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger insert_host_catalog_subtype before insert on inventory_host_catalog
+    for each row execute procedure insert_host_catalog_subtype();
+
+  create trigger delete_host_catalog_subtype after delete on inventory_host_catalog
+    for each row execute procedure delete_host_catalog_subtype();
+
+  -- inventory_host_catalog_sync records the outcome of the last sync of an
+  -- inventory_host_catalog. It is kept apart from the catalog so syncing does
+  -- not change the version of the catalog.
+  create table inventory_host_catalog_sync (
+    catalog_id wt_public_id
+      primary key
+      references inventory_host_catalog (public_id)
+      on delete cascade
+      on update cascade,
+    -- last_sync_time is null until the first sync is attempted
+    last_sync_time timestamp with time zone,
+    -- last_sync_error is null if the last sync succeeded
+    last_sync_error text
+  );
+
+  create or replace function insert_inventory_host_catalog_sync()
+    returns trigger
+  as $$
+  begin
+    insert into inventory_host_catalog_sync (catalog_id)
+    values (new.public_id);
+    return null; -- result is ignored since this is an after trigger
+  end;
+  $$ language plpgsql;
+
+  create trigger insert_inventory_host_catalog_sync after insert on inventory_host_catalog
+    for each row execute procedure insert_inventory_host_catalog_sync();
+
+  create table inventory_host (
+    public_id wt_public_id
+      primary key,
+    catalog_id wt_public_id not null
+      references inventory_host_catalog (public_id)
+      on delete cascade
+      on update cascade,
+    -- external_id is the id of the host in the inventory document
+    external_id text not null
+      constraint external_id_must_not_be_empty
+      check(length(trim(external_id)) > 0),
+    name text,
+    description text,
+    address text not null
+      constraint address_must_be_more_than_2_characters
+      check(length(trim(address)) > 2)
+      constraint address_must_be_less_than_256_characters
+      check(length(trim(address)) < 256),
+    -- labels is a json object of the labels of the host in the inventory
+    -- document
+    labels text not null default '{}',
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(catalog_id, external_id),
+
+    foreign key (catalog_id, public_id)
+      references host (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+
+    unique(catalog_id, public_id)
+  );
+
+  create trigger update_version_column after update on inventory_host
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on inventory_host
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on inventory_host
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on inventory_host
+    for each row execute procedure immutable_columns('public_id', 'catalog_id', 'external_id', 'create_time');
+
+  create trigger insert_host_subtype before insert on inventory_host
+    for each row execute procedure insert_host_subtype();
+
+  create trigger delete_host_subtype after delete on inventory_host
+    for each row execute procedure delete_host_subtype();
+
+  create table inventory_host_set (
+    public_id wt_public_id
+      primary key,
+    catalog_id wt_public_id not null
+      references inventory_host_catalog (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    -- match_labels is a json object of the labels a host must have to be a
+    -- member of the set. An empty object matches every host of the catalog.
+    match_labels text not null default '{}',
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(catalog_id, name),
+    foreign key (catalog_id, public_id)
+      references host_set (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(catalog_id, public_id)
+  );
+
+  create trigger update_version_column after update on inventory_host_set
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on inventory_host_set
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on inventory_host_set
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on inventory_host_set
+    for each row execute procedure immutable_columns('public_id', 'catalog_id', 'create_time');
+
+  create trigger insert_host_set_subtype before insert on inventory_host_set
+    for each row execute procedure insert_host_set_subtype();
+
+  create trigger delete_host_set_subtype after delete on inventory_host_set
+    for each row execute procedure delete_host_set_subtype();
+
+  create table inventory_host_set_member (
+    host_id wt_public_id not null,
+    set_id wt_public_id not null,
+    catalog_id wt_public_id not null,
+    primary key(host_id, set_id),
+    foreign key (catalog_id, host_id) -- fk1
+      references inventory_host (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+    foreign key (catalog_id, set_id) -- fk2
+      references inventory_host_set (catalog_id, public_id)
+      on delete cascade
+      on update cascade
+  );
+
+  create trigger immutable_columns before update on inventory_host_set_member
+    for each row execute procedure immutable_columns('host_id', 'set_id', 'catalog_id');
+
+  create or replace function insert_inventory_host_set_member()
+    returns trigger
+  as $$
+  begin
+    select inventory_host_set.catalog_id
+      into new.catalog_id
+    from inventory_host_set
+    where inventory_host_set.public_id = new.set_id;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger insert_inventory_host_set_member before insert on inventory_host_set_member
+    for each row execute procedure insert_inventory_host_set_member();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('inventory_host_catalog', 1),
+    ('inventory_host', 1),
+    ('inventory_host_set', 1),
+    ('inventory_host_set_member', 1);
+
+  -- whx_host_dimension_source is recreated to include the inventory hosts so
+  -- sessions to them are added to the warehouse.
+  drop view whx_host_dimension_source;
+
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  union
+  select h.public_id                     as host_id,
+         'inventory host'                as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'inventory host set'            as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'inventory host catalog'        as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from inventory_host as h,
+         inventory_host_catalog as c,
+         inventory_host_set_member as m,
+         inventory_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+commit;
+
+`),
+	},
+	"migrations/77_credential_static.down.sql": {
+		name: "77_credential_static.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop view target_library;
+  drop table target_credential_library cascade;
+  drop table credential_static_library cascade;
+  drop table credential_static_store cascade;
+  drop table credential_library cascade;
+  drop table credential_store cascade;
+  drop table kms_credential_key_version cascade;
+  drop table kms_credential_key cascade;
+
+  drop function target_credential_library_scope_valid;
+  drop function insert_credential_library_subtype;
+  drop function delete_credential_library_subtype;
+  drop function insert_credential_store_subtype;
+  drop function delete_credential_store_subtype;
+
+  delete
+    from oplog_ticket
+   where name in (
+          'credential_static_store',
+          'credential_static_library',
+          'target_credential_library'
+        );
+
+commit;
+
+`),
+	},
+	"migrations/77_credential_static.up.sql": {
+		name: "77_credential_static.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+
+  ┌──────────────────┐          ┌─────────────────────────┐
+  │ credential_store │          │ credential_static_store │
+  ├──────────────────┤          ├─────────────────────────┤
+  │ public_id (pk)   │          │ public_id (pk)          │
+  │ scope_id  (fk)   │┼┼──────○┼│ scope_id  (fk)          │
+  │                  │          │                         │
+  └──────────────────┘          └─────────────────────────┘
+           ┼                                 ┼
+           ┼                                 ┼
+           │                                 │
+           ○                                 ○
+          ╱│╲                               ╱│╲
+  ┌──────────────────────┐      ┌─────────────────────────────┐
+  │  credential_library  │      │  credential_static_library  │
+  ├──────────────────────┤      ├─────────────────────────────┤
+  │ public_id (pk)       │      │ public_id (pk)              │
+  │ store_id  (fk)       │┼┼──○┼│ store_id  (fk)              │
+  │                      │      │ username                    │
+  └──────────────────────┘      │ password                    │
+           ┼                    │ private_key                 │
+           ┼                    │ key_id    (fk)              │
+           │                    └─────────────────────────────┘
+           ○
+          ╱│╲
+  ┌──────────────────────────────┐
+  │  target_credential_library   │
+  ├──────────────────────────────┤
+  │ target_id             (pk,fk)│
+  │ credential_library_id (pk,fk)│
+  └──────────────────────────────┘
+
+  A credential_static_store is a credential_store subtype whose libraries hold
+  a fixed username, password and private key. The password and private key are
+  encrypted with the credentials key of the scope of the store. Credential
+  libraries attached to a target are brokered to the sessions of the target.
+
+*/
+
+  create table kms_credential_key (
+    private_id wt_private_id primary key,
+    root_key_id wt_private_id not null unique -- there can be only one credential dek per root key
+      references kms_root_key(private_id)
+      on delete cascade
+      on update cascade,
+    create_time wt_timestamp
+  );
+
+  -- define the immutable fields for kms_credential_key (all of them)
+  create trigger immutable_columns before update on kms_credential_key
+    for each row execute procedure immutable_columns('private_id', 'root_key_id', 'create_time');
+
+  create trigger default_create_time_column before insert on kms_credential_key
+    for each row execute procedure default_create_time();
+
+  create table kms_credential_key_version (
+    private_id wt_private_id primary key,
+    credential_key_id wt_private_id not null
+      references kms_credential_key(private_id)
+      on delete cascade
+      on update cascade,
+    root_key_version_id wt_private_id not null
+      references kms_root_key_version(private_id)
+      on delete cascade
+      on update cascade,
+    version wt_version,
+    key bytea not null,
+    create_time wt_timestamp,
+    unique(credential_key_id, version)
+  );
+
+  -- define the immutable fields for kms_credential_key_version (all of them)
+  create trigger immutable_columns before update on kms_credential_key_version
+    for each row execute procedure immutable_columns('private_id', 'credential_key_id', 'root_key_version_id', 'version', 'key', 'create_time');
+
+  create trigger default_create_time_column before insert on kms_credential_key_version
+    for each row execute procedure default_create_time();
+
+  create trigger kms_version_column before insert on kms_credential_key_version
+    for each row execute procedure kms_version_column('credential_key_id');
+
+  create table credential_store (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, public_id)
+  );
+
+  create trigger immutable_columns before update on credential_store
+    for each row execute procedure immutable_columns('public_id', 'scope_id');
+
+  -- insert_credential_store_subtype() is a before insert trigger
+  -- function for subtypes of credential_store
+  create or replace function insert_credential_store_subtype()
+    returns trigger
+  as $$
+  begin
+    insert into credential_store
+      (public_id, scope_id)
+    values
+      (new.public_id, new.scope_id);
+    return new;
+  end;
+  $$ language plpgsql;
+
+  -- delete_credential_store_subtype() is an after delete trigger
+  -- function for subtypes of credential_store
+  create or replace function delete_credential_store_subtype()
+    returns trigger
+  as $$
+  begin
+    delete from credential_store
+    where public_id = old.public_id;
+    return null; -- result is ignored since this is an after trigger
+  end;
+  $$ language plpgsql;
+
+  create table credential_library (
+    public_id wt_public_id
+      primary key,
+    store_id wt_public_id not null
+      references credential_store (public_id)
+      on delete cascade
+      on update cascade,
+    unique(store_id, public_id)
+  );
+
+  create trigger immutable_columns before update on credential_library
+    for each row execute procedure immutable_columns('public_id', 'store_id');
+
+  -- insert_credential_library_subtype() is a before insert trigger
+  -- function for subtypes of credential_library
+  create or replace function insert_credential_library_subtype()
+    returns trigger
+  as $$
+  begin
+    insert into credential_library
+      (public_id, store_id)
+    values
+      (new.public_id, new.store_id);
+    return new;
+  end;
+  $$ language plpgsql;
+
+  -- delete_credential_library_subtype() is an after delete trigger
+  -- function for subtypes of credential_library
+  create or replace function delete_credential_library_subtype()
+    returns trigger
+  as $$
+  begin
+    delete from credential_library
+    where public_id = old.public_id;
+    return null; -- result is ignored since this is an after trigger
+  end;
+  $$ language plpgsql;
+
+  create table credential_static_store (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    foreign key (scope_id, public_id)
+      references credential_store (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, name)
+  );
+
+  create trigger update_version_column after update on credential_static_store
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on credential_static_store
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on credential_static_store
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on credential_static_store
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger insert_credential_store_subtype before insert on credential_static_store
+    for each row execute procedure insert_credential_store_subtype();
+
+  create trigger delete_credential_store_subtype after delete on credential_static_store
+    for each row execute procedure delete_credential_store_subtype();
+
+  create table credential_static_library (
+    public_id wt_public_id
+      primary key,
+    store_id wt_public_id not null
+      references credential_static_store (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    username text not null
+      constraint username_must_not_be_empty
+      check(length(trim(username)) > 0),
+    -- password and private_key are encrypted with the kms_credential_key_version
+    -- referenced by key_id. Either may be the encryption of an empty value.
+    password bytea not null,
+    private_key bytea not null,
+    key_id wt_private_id not null
+      references kms_credential_key_version (private_id)
+      on delete restrict
+      on update cascade,
+    unique(store_id, name),
+    foreign key (store_id, public_id)
+      references credential_library (store_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(store_id, public_id)
+  );
+
+  create trigger update_version_column after update on credential_static_library
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on credential_static_library
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on credential_static_library
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on credential_static_library
+    for each row execute procedure immutable_columns('public_id', 'store_id', 'create_time');
+
+  create trigger insert_credential_library_subtype before insert on credential_static_library
+    for each row execute procedure insert_credential_library_subtype();
+
+  create trigger delete_credential_library_subtype after delete on credential_static_library
+    for each row execute procedure delete_credential_library_subtype();
+
+  -- target_credential_library_scope_valid() is a before insert trigger
+  -- function for target_credential_library
+  create or replace function target_credential_library_scope_valid()
+    returns trigger
+  as $$
+  begin
+    perform from
+      credential_store cs,
+      credential_library cl,
+      target t
+    where
+      cs.public_id = cl.store_id and
+      cs.scope_id = t.scope_id and
+      cl.public_id = new.credential_library_id and
+      t.public_id = new.target_id;
+    if not found then
+      raise exception 'target scope and credential library scope are not equal';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create table target_credential_library (
+    target_id wt_public_id
+      references target (public_id)
+      on delete cascade
+      on update cascade,
+    credential_library_id wt_public_id
+      references credential_library (public_id)
+      on delete cascade
+      on update cascade,
+    primary key(target_id, credential_library_id),
+    create_time wt_timestamp
+  );
+
+  create trigger immutable_columns before update on target_credential_library
+    for each row execute procedure immutable_columns('target_id', 'credential_library_id', 'create_time');
+
+  create trigger default_create_time_column before insert on target_credential_library
+    for each row execute procedure default_create_time();
+
+  create trigger target_credential_library_scope_valid before insert on target_credential_library
+    for each row execute procedure target_credential_library_scope_valid();
+
+  create view target_library
+  as
+  select
+    cl.public_id,
+    cl.store_id,
+    tcl.target_id
+  from
+    target_credential_library tcl,
+    credential_library cl
+  where
+    cl.public_id = tcl.credential_library_id;
+
+  insert into oplog_ticket (name, version)
+  values
+    ('credential_static_store', 1),
+    ('credential_static_library', 1),
+    ('target_credential_library', 1);
+
+commit;
+
+`),
+	},
+	"migrations/78_target_ssh.down.sql": {
+		name: "78_target_ssh.down.sql",
+		bytes: []byte(`
+begin;
+
+  delete from target
+   where public_id in (select public_id from target_ssh);
+
+  drop view whx_host_dimension_source;
+  drop view target_all_subtypes;
+
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp;
+
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  union
+  select h.public_id                     as host_id,
+         'inventory host'                as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'inventory host set'            as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'inventory host catalog'        as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from inventory_host as h,
+         inventory_host_catalog as c,
+         inventory_host_set_member as m,
+         inventory_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+  drop table target_ssh;
+  drop table kms_ssh_certificate_key_version cascade;
+  drop table kms_ssh_certificate_key cascade;
+
+  delete from oplog_ticket where name = 'target_ssh';
+
+commit;
+
+`),
+	},
+	"migrations/78_target_ssh.up.sql": {
+		name: "78_target_ssh.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- target_ssh is a target whose sessions are authenticated to the host with
+  -- a short-lived ssh user certificate. The controller signs the certificate
+  -- with the certificate authority of the target's scope when the session is
+  -- authorized.
+  create table target_ssh (
+    public_id wt_public_id primary key
+      references target(public_id)
+      on delete cascade
+      on update cascade,
+    scope_id wt_scope_id not null
+      references iam_scope(public_id)
+      on delete cascade
+      on update cascade,
+    name text not null, -- name is not optional for a target subtype
+    description text,
+    default_port int, -- default_port can be null
+     -- max duration of the session in seconds.
+     -- default is 8 hours
+    session_max_seconds int not null default 28800
+      constraint session_max_seconds_must_be_greater_than_0
+      check(session_max_seconds > 0),
+    -- limit on number of session connections allowed. -1 equals no limit
+    session_connection_limit int not null default 1
+      constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+      check(session_connection_limit > 0 or session_connection_limit = -1),
+    enable_session_recording boolean not null default false,
+    connection_max_bytes_per_second bigint not null default 0
+      constraint connection_max_bytes_per_second_must_be_zero_or_greater
+      check(connection_max_bytes_per_second >= 0),
+    session_max_bytes_per_second bigint not null default 0
+      constraint session_max_bytes_per_second_must_be_zero_or_greater
+      check(session_max_bytes_per_second >= 0),
+    session_idle_timeout_seconds int not null default 0
+      constraint session_idle_timeout_seconds_must_be_zero_or_greater
+      check(session_idle_timeout_seconds >= 0),
+    -- principals is a comma separated list of the principals included in the
+    -- certificates issued for sessions. When null, the name of the user
+    -- authorizing the session is used.
+    principals text
+      constraint principals_must_not_be_empty
+      check(length(trim(principals)) > 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(scope_id, name) -- name must be unique within a scope
+  );
+
+  create trigger
+    insert_target_subtype
+  before insert on target_ssh
+    for each row execute procedure insert_target_subtype();
+
+  create trigger
+    delete_target_subtype
+  after delete on target_ssh
+    for each row execute procedure delete_target_subtype();
+
+   -- define the immutable fields for target
+  create trigger
+    immutable_columns
+  before
+  update on target_ssh
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger
+    update_version_column
+  after update on target_ssh
+    for each row execute procedure update_version_column();
+
+  create trigger
+    update_time_column
+  before update on target_ssh
+    for each row execute procedure update_time_column();
+
+  create trigger
+    default_create_time_column
+  before
+  insert on target_ssh
+    for each row execute procedure default_create_time();
+
+  create trigger
+    target_scope_valid
+  before insert on target_ssh
+    for each row execute procedure target_scope_valid();
+
+  -- kms_ssh_certificate_key is the dek from which the certificate authority
+  -- that signs the ssh certificates of sessions in a scope is derived.
+  create table kms_ssh_certificate_key (
+    private_id wt_private_id primary key,
+    root_key_id wt_private_id not null unique -- there can be only one ssh certificate dek per root key
+      references kms_root_key(private_id)
+      on delete cascade
+      on update cascade,
+    create_time wt_timestamp
+  );
+
+  -- define the immutable fields for kms_ssh_certificate_key (all of them)
+  create trigger immutable_columns before update on kms_ssh_certificate_key
+    for each row execute procedure immutable_columns('private_id', 'root_key_id', 'create_time');
+
+  create trigger default_create_time_column before insert on kms_ssh_certificate_key
+    for each row execute procedure default_create_time();
+
+  create table kms_ssh_certificate_key_version (
+    private_id wt_private_id primary key,
+    ssh_certificate_key_id wt_private_id not null
+      references kms_ssh_certificate_key(private_id)
+      on delete cascade
+      on update cascade,
+    root_key_version_id wt_private_id not null
+      references kms_root_key_version(private_id)
+      on delete cascade
+      on update cascade,
+    version wt_version,
+    key bytea not null,
+    create_time wt_timestamp,
+    unique(ssh_certificate_key_id, version)
+  );
+
+  -- define the immutable fields for kms_ssh_certificate_key_version (all of them)
+  create trigger immutable_columns before update on kms_ssh_certificate_key_version
+    for each row execute procedure immutable_columns('private_id', 'ssh_certificate_key_id', 'root_key_version_id', 'version', 'key', 'create_time');
+
+  create trigger default_create_time_column before insert on kms_ssh_certificate_key_version
+    for each row execute procedure default_create_time();
+
+  create trigger kms_version_column before insert on kms_ssh_certificate_key_version
+    for each row execute procedure kms_version_column('ssh_certificate_key_id');
+
+  -- whx_host_dimension_source depends on target_all_subtypes so it is dropped
+  -- and recreated with it to include the ssh targets.
+  drop view whx_host_dimension_source;
+  drop view target_all_subtypes;
+
+  -- target_all_subtypes is a union of all target subtypes
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    null::text as principals,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    null::text as principals,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    principals,
+    version,
+    create_time,
+    update_time,
+    'ssh' as type
+    from target_ssh;
+
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  union
+  select h.public_id                     as host_id,
+         'inventory host'                as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'inventory host set'            as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'inventory host catalog'        as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from inventory_host as h,
+         inventory_host_catalog as c,
+         inventory_host_set_member as m,
+         inventory_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+  insert into oplog_ticket
+    (name, version)
+  values
+    ('target_ssh', 1);
+
+commit;
+
+`),
+	},
+	"migrations/79_target_host_selection.down.sql": {
+		name: "79_target_host_selection.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop index session_host_id_ix;
+  drop index session_target_id_create_time_ix;
+
+  drop view whx_host_dimension_source;
+  drop view target_all_subtypes;
+
+  -- target_all_subtypes is a union of all target subtypes
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    null::text as principals,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    null::text as principals,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    principals,
+    version,
+    create_time,
+    update_time,
+    'ssh' as type
+    from target_ssh;
+
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  union
+  select h.public_id                     as host_id,
+         'inventory host'                as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'inventory host set'            as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'inventory host catalog'        as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from inventory_host as h,
+         inventory_host_catalog as c,
+         inventory_host_set_member as m,
+         inventory_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+  alter table target_tcp
+    drop column host_selection_strategy,
+    drop column restrict_host_id;
+
+  alter table target_udp
+    drop column host_selection_strategy,
+    drop column restrict_host_id;
+
+  alter table target_ssh
+    drop column host_selection_strategy,
+    drop column restrict_host_id;
+
+  drop table target_host_selection_strategy_enm;
+
+commit;
+
+`),
+	},
+	"migrations/79_target_host_selection.up.sql": {
+		name: "79_target_host_selection.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- target_host_selection_strategy_enm defines how the host of a session is
+  -- chosen from the hosts of the target's host sets when the user does not
+  -- request one.
+  create table target_host_selection_strategy_enm (
+    name text primary key
+      constraint only_predefined_host_selection_strategies_allowed
+      check (
+        name in (
+          'random',
+          'round-robin',
+          'least-active-sessions',
+          'sticky-per-user'
+        )
+      )
+  );
+
+  insert into target_host_selection_strategy_enm (name)
+  values
+    ('random'),
+    ('round-robin'),
+    ('least-active-sessions'),
+    ('sticky-per-user');
+
+  -- host_selection_strategy is how the host of a session created for the
+  -- target is chosen. restrict_host_id prevents users from requesting the
+  -- host of a session, so it is always chosen by the strategy.
+  alter table target_tcp
+    add column host_selection_strategy text not null default 'random'
+      references target_host_selection_strategy_enm(name)
+      on delete restrict
+      on update cascade,
+    add column restrict_host_id boolean not null default false;
+
+  alter table target_udp
+    add column host_selection_strategy text not null default 'random'
+      references target_host_selection_strategy_enm(name)
+      on delete restrict
+      on update cascade,
+    add column restrict_host_id boolean not null default false;
+
+  alter table target_ssh
+    add column host_selection_strategy text not null default 'random'
+      references target_host_selection_strategy_enm(name)
+      on delete restrict
+      on update cascade,
+    add column restrict_host_id boolean not null default false;
+
+  drop view whx_host_dimension_source;
+  drop view target_all_subtypes;
+
+  -- target_all_subtypes is a union of all target subtypes
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    null::text as principals,
+    host_selection_strategy,
+    restrict_host_id,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    null::text as principals,
+    host_selection_strategy,
+    restrict_host_id,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    principals,
+    host_selection_strategy,
+    restrict_host_id,
+    version,
+    create_time,
+    update_time,
+    'ssh' as type
+    from target_ssh;
+
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  union
+  select h.public_id                     as host_id,
+         'inventory host'                as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'inventory host set'            as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'inventory host catalog'        as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from inventory_host as h,
+         inventory_host_catalog as c,
+         inventory_host_set_member as m,
+         inventory_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+  -- The host selection strategies look up the most recent session of a
+  -- target and count the pending and active sessions of hosts.
+  create index session_target_id_create_time_ix
+    on session (target_id, create_time);
+  create index session_host_id_ix
+    on session (host_id);
+
+commit;
+
+`),
+	},
+	"migrations/80_worker_tags.down.sql": {
+		name: "80_worker_tags.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop view whx_host_dimension_source;
+  drop view target_all_subtypes;
+
+  -- target_all_subtypes is a union of all target subtypes
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    null::text as principals,
+    host_selection_strategy,
+    restrict_host_id,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    null::text as principals,
+    host_selection_strategy,
+    restrict_host_id,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    principals,
+    host_selection_strategy,
+    restrict_host_id,
+    version,
+    create_time,
+    update_time,
+    'ssh' as type
+    from target_ssh;
+
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  union
+  select h.public_id                     as host_id,
+         'inventory host'                as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'inventory host set'            as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'inventory host catalog'        as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from inventory_host as h,
+         inventory_host_catalog as c,
+         inventory_host_set_member as m,
+         inventory_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+  alter table target_tcp
+    drop column worker_filter;
+
+  alter table target_udp
+    drop column worker_filter;
+
+  alter table target_ssh
+    drop column worker_filter;
+
+  drop table server_tag;
+
+commit;
+
+`),
+	},
+	"migrations/80_worker_tags.up.sql": {
+		name: "80_worker_tags.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- server_tag contains the tags of workers from their configuration. They are
+  -- replaced each time a worker reports its status.
+  create table server_tag (
+    server_id text not null,
+    server_type text not null,
+    key text not null
+      constraint key_must_not_be_empty
+      check(length(trim(key)) > 0),
+    value text not null
+      constraint value_must_not_be_empty
+      check(length(trim(value)) > 0),
+    primary key (server_id, server_type, key, value),
+    foreign key (server_id, server_type)
+      references server(private_id, type)
+      on delete cascade
+      on update cascade
+  );
+
+  -- worker_filter is an expression which workers must match to proxy the
+  -- sessions created for the target.
+  alter table target_tcp
+    add column worker_filter text
+      constraint worker_filter_must_not_be_empty
+      check(length(trim(worker_filter)) > 0);
+
+  alter table target_udp
+    add column worker_filter text
+      constraint worker_filter_must_not_be_empty
+      check(length(trim(worker_filter)) > 0);
+
+  alter table target_ssh
+    add column worker_filter text
+      constraint worker_filter_must_not_be_empty
+      check(length(trim(worker_filter)) > 0);
+
+  drop view whx_host_dimension_source;
+  drop view target_all_subtypes;
+
+  -- target_all_subtypes is a union of all target subtypes
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    null::text as principals,
+    host_selection_strategy,
+    restrict_host_id,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    null::text as principals,
+    host_selection_strategy,
+    restrict_host_id,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    principals,
+    host_selection_strategy,
+    restrict_host_id,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'ssh' as type
+    from target_ssh;
+
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  union
+  select h.public_id                     as host_id,
+         'inventory host'                as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'inventory host set'            as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'inventory host catalog'        as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from inventory_host as h,
+         inventory_host_catalog as c,
+         inventory_host_set_member as m,
+         inventory_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+commit;
+
+`),
+	},
+	"migrations/81_worker_hops.down.sql": {
+		name: "81_worker_hops.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop view session_with_state;
+
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    s.recording_enabled,
+    s.connection_max_bytes_per_second,
+    s.session_max_bytes_per_second,
+    s.idle_timeout_seconds,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+  drop trigger immutable_columns on session;
+
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'recording_enabled', 'connection_max_bytes_per_second', 'session_max_bytes_per_second', 'idle_timeout_seconds');
+
+  alter table session
+    drop column worker_filter;
+
+  drop table server_worker_downstream;
+
+commit;
+
+`),
+	},
+	"migrations/81_worker_hops.up.sql": {
+		name: "81_worker_hops.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- server_worker_downstream contains the names of the workers which are
+  -- connected to a worker as its downstream workers. A downstream worker only
+  -- connects outbound, to its upstream workers, which forward the connections
+  -- of its sessions to it. They are replaced each time a worker reports its
+  -- status.
+  create table server_worker_downstream (
+    server_id text not null,
+    server_type text not null
+      constraint server_type_must_be_worker
+      check(server_type = 'worker'),
+    downstream_name text not null
+      constraint downstream_name_must_not_be_empty
+      check(length(trim(downstream_name)) > 0),
+    primary key (server_id, server_type, downstream_name),
+    foreign key (server_id, server_type)
+      references server(private_id, type)
+      on delete cascade
+      on update cascade
+  );
+
+  -- worker_filter is copied from the target when the session is created and
+  -- cannot be changed afterwards. Workers use it to choose the downstream
+  -- worker which dials the endpoint.
+  alter table session
+    add column worker_filter text
+      constraint worker_filter_must_not_be_empty
+      check(length(trim(worker_filter)) > 0);
+
+  drop trigger immutable_columns on session;
+
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'recording_enabled', 'connection_max_bytes_per_second', 'session_max_bytes_per_second', 'idle_timeout_seconds', 'worker_filter');
+
+  drop view session_with_state;
+
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    s.recording_enabled,
+    s.connection_max_bytes_per_second,
+    s.session_max_bytes_per_second,
+    s.idle_timeout_seconds,
+    s.worker_filter,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+commit;
+
+`),
+	},
+	"migrations/82_kms_key_rotation.down.sql": {
+		name: "82_kms_key_rotation.down.sql",
+		bytes: []byte(`
+begin;
+
+  create or replace function
+    immutable_auth_token_columns()
+    returns trigger
+  as $$
+  begin
+    if new.auth_account_id is distinct from old.auth_account_id then
+      raise exception 'auth_account_id is read-only';
+    end if;
+    if new.token is distinct from old.token then
+      raise exception 'token is read-only';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  drop table kms_key_rotation;
+
+commit;
+
+`),
+	},
+	"migrations/82_kms_key_rotation.up.sql": {
+		name: "82_kms_key_rotation.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- kms_key_rotation records the rotations of the keys of a scope. A rotation
+  -- creates a new version of the scope's root key and of each of its DEKs.
+  -- Values encrypted with previous versions are then re-encrypted with the
+  -- new versions by the controllers in the background, which keep track of
+  -- their progress here. A rotation is superseded when a newer rotation of the
+  -- same scope is started before it completed.
+  create table kms_key_rotation (
+    private_id wt_private_id primary key,
+    scope_id wt_scope_id not null
+      references iam_scope(public_id)
+      on delete cascade
+      on update cascade,
+    root_key_version_id wt_private_id not null
+      references kms_root_key_version(private_id)
+      on delete cascade
+      on update cascade,
+    status text not null default 'rewrapping'
+      constraint status_must_be_valid
+      check(status in ('rewrapping', 'completed', 'superseded')),
+    rewrapped_count bigint not null default 0
+      constraint rewrapped_count_must_be_zero_or_greater
+      check(rewrapped_count >= 0),
+    -- last_error is the last error the controllers ran into while rewrapping
+    -- values, which they retry until they succeed.
+    last_error text,
+    create_time wt_timestamp,
+    update_time wt_timestamp
+  );
+
+  create index kms_key_rotation_scope_id_create_time_idx
+    on kms_key_rotation (scope_id, create_time);
+
+  create trigger
+    default_create_time_column
+  before
+  insert on kms_key_rotation
+    for each row execute procedure default_create_time();
+
+  create trigger
+    update_time_column
+  before
+  update on kms_key_rotation
+    for each row execute procedure update_time_column();
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_key_rotation
+    for each row execute procedure immutable_columns('private_id', 'scope_id', 'root_key_version_id', 'create_time');
+
+  -- The token of an auth token could not be changed at all. It can now be
+  -- changed together with its key_id, which is how it is re-encrypted with a
+  -- new version of the database key.
+  create or replace function
+    immutable_auth_token_columns()
+    returns trigger
+  as $$
+  begin
+    if new.auth_account_id is distinct from old.auth_account_id then
+      raise exception 'auth_account_id is read-only';
+    end if;
+    if new.token is distinct from old.token and new.key_id is not distinct from old.key_id then
+      raise exception 'token is read-only';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+commit;
+
+`),
+	},
+	"migrations/83_oplog_change_feed.down.sql": {
+		name: "83_oplog_change_feed.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop index oplog_entry_aggregate_name_id_ix;
+
+commit;
+
+`),
+	},
+	"migrations/83_oplog_change_feed.up.sql": {
+		name: "83_oplog_change_feed.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- The change feed reads the entries of each aggregate after the last entry
+  -- of it a subscriber has seen, and looks up the last entry of each
+  -- aggregate to start a subscription.
+  create index oplog_entry_aggregate_name_id_ix
+    on oplog_entry (aggregate_name, id);
+
+commit;
+
+`),
+	},
+	"migrations/84_kms_key_version_rewrap.down.sql": {
+		name: "84_kms_key_version_rewrap.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop view session_with_state;
+
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    s.recording_enabled,
+    s.connection_max_bytes_per_second,
+    s.session_max_bytes_per_second,
+    s.idle_timeout_seconds,
+    s.worker_filter,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+  drop trigger immutable_columns on session;
+
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'recording_enabled', 'connection_max_bytes_per_second', 'session_max_bytes_per_second', 'idle_timeout_seconds', 'worker_filter');
+
+  alter table session
+    drop column session_key_version_id;
+
+  drop trigger immutable_key_columns on kms_database_key_version;
+  drop trigger immutable_columns on kms_database_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_database_key_version
+    for each row execute procedure immutable_columns('private_id', 'database_key_id', 'root_key_version_id', 'version', 'key', 'create_time');
+
+  drop trigger immutable_key_columns on kms_oplog_key_version;
+  drop trigger immutable_columns on kms_oplog_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_oplog_key_version
+    for each row execute procedure immutable_columns('private_id', 'oplog_key_id', 'root_key_version_id', 'version', 'key', 'create_time');
+
+  drop trigger immutable_key_columns on kms_session_key_version;
+  drop trigger immutable_columns on kms_session_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_session_key_version
+    for each row execute procedure immutable_columns('private_id', 'session_key_id', 'root_key_version_id', 'version', 'key', 'create_time');
+
+  drop trigger immutable_key_columns on kms_token_key_version;
+  drop trigger immutable_columns on kms_token_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_token_key_version
+    for each row execute procedure immutable_columns('private_id', 'token_key_id', 'root_key_version_id', 'version', 'key', 'create_time');
+
+  drop trigger immutable_key_columns on kms_credential_key_version;
+  drop trigger immutable_columns on kms_credential_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_credential_key_version
+    for each row execute procedure immutable_columns('private_id', 'credential_key_id', 'root_key_version_id', 'version', 'key', 'create_time');
+
+  drop trigger immutable_key_columns on kms_ssh_certificate_key_version;
+  drop trigger immutable_columns on kms_ssh_certificate_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_ssh_certificate_key_version
+    for each row execute procedure immutable_columns('private_id', 'ssh_certificate_key_id', 'root_key_version_id', 'version', 'key', 'create_time');
+
+  drop function immutable_kms_key_version_key;
+
+commit;
+
+`),
+	},
+	"migrations/84_kms_key_version_rewrap.up.sql": {
+		name: "84_kms_key_version_rewrap.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- The versions of the DEKs of a scope are re-encrypted with the new version
+  -- of its root key when its keys are rotated, so that the previous root key
+  -- versions no longer encrypt anything and can be destroyed. The key of a DEK
+  -- version can now be changed together with its root_key_version_id, which
+  -- is how it is re-encrypted; its value does not change.
+  create function
+    immutable_kms_key_version_key()
+    returns trigger
+  as $$
+  begin
+    if new.key is distinct from old.key and new.root_key_version_id is not distinct from old.root_key_version_id then
+      raise exception 'key is read-only';
+    end if;
+    if new.root_key_version_id is distinct from old.root_key_version_id and new.key is not distinct from old.key then
+      raise exception 'root_key_version_id is read-only';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  drop trigger immutable_columns on kms_database_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_database_key_version
+    for each row execute procedure immutable_columns('private_id', 'database_key_id', 'version', 'create_time');
+
+  create trigger
+    immutable_key_columns
+  before
+  update on kms_database_key_version
+    for each row execute procedure immutable_kms_key_version_key();
+
+  drop trigger immutable_columns on kms_oplog_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_oplog_key_version
+    for each row execute procedure immutable_columns('private_id', 'oplog_key_id', 'version', 'create_time');
+
+  create trigger
+    immutable_key_columns
+  before
+  update on kms_oplog_key_version
+    for each row execute procedure immutable_kms_key_version_key();
+
+  drop trigger immutable_columns on kms_session_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_session_key_version
+    for each row execute procedure immutable_columns('private_id', 'session_key_id', 'version', 'create_time');
+
+  create trigger
+    immutable_key_columns
+  before
+  update on kms_session_key_version
+    for each row execute procedure immutable_kms_key_version_key();
+
+  drop trigger immutable_columns on kms_token_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_token_key_version
+    for each row execute procedure immutable_columns('private_id', 'token_key_id', 'version', 'create_time');
+
+  create trigger
+    immutable_key_columns
+  before
+  update on kms_token_key_version
+    for each row execute procedure immutable_kms_key_version_key();
+
+  drop trigger immutable_columns on kms_credential_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_credential_key_version
+    for each row execute procedure immutable_columns('private_id', 'credential_key_id', 'version', 'create_time');
+
+  create trigger
+    immutable_key_columns
+  before
+  update on kms_credential_key_version
+    for each row execute procedure immutable_kms_key_version_key();
+
+  drop trigger immutable_columns on kms_ssh_certificate_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_ssh_certificate_key_version
+    for each row execute procedure immutable_columns('private_id', 'ssh_certificate_key_id', 'version', 'create_time');
+
+  create trigger
+    immutable_key_columns
+  before
+  update on kms_ssh_certificate_key_version
+    for each row execute procedure immutable_kms_key_version_key();
+
+  -- session_key_version_id is the version of the sessions DEK of the scope
+  -- from which the key of the session is derived. It is set when the session
+  -- is created and cannot be changed afterwards, so the key still matches the
+  -- session's certificate after the keys of the scope are rotated. Sessions
+  -- created before it was introduced use the only version their scope had.
+  alter table session
+    add column session_key_version_id text
+      constraint session_key_version_id_must_not_be_empty
+      check(length(trim(session_key_version_id)) > 0);
+
+  update session s
+     set session_key_version_id = (
+           select v.private_id
+             from kms_session_key_version v
+             join kms_session_key k
+               on k.private_id = v.session_key_id
+             join kms_root_key r
+               on r.private_id = k.root_key_id
+            where r.scope_id = s.scope_id
+            order by v.version desc
+            limit 1
+         );
+
+  drop trigger immutable_columns on session;
+
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'recording_enabled', 'connection_max_bytes_per_second', 'session_max_bytes_per_second', 'idle_timeout_seconds', 'worker_filter', 'session_key_version_id');
+
+  drop view session_with_state;
+
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    s.recording_enabled,
+    s.connection_max_bytes_per_second,
+    s.session_max_bytes_per_second,
+    s.idle_timeout_seconds,
+    s.worker_filter,
+    s.session_key_version_id,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+commit;
+
 `),
 	},
 }
@@ -0,0 +1,120 @@
+begin;
+
+  -- enable_session_recording determines if the worker records the data
+  -- proxied for each connection of a session created for the target.
+  alter table target_tcp
+    add column enable_session_recording boolean not null default false;
+
+  drop view target_all_subtypes;
+
+  -- target_all_subtypes is a union of all target subtypes
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp;
+
+  -- recording_enabled is copied from the target when the session is created
+  -- and cannot be changed afterwards.
+  alter table session
+    add column recording_enabled boolean not null default false;
+
+  drop trigger immutable_columns on session;
+
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'recording_enabled');
+
+  drop view session_with_state;
+
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    s.recording_enabled,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+  -- session_connection_recording contains the metadata of a recording made by
+  -- a worker for a session connection. The recording itself is stored by the
+  -- worker at storage_path.
+  create table session_connection_recording (
+    connection_id wt_public_id primary key
+      references session_connection (public_id)
+      on delete cascade
+      on update cascade,
+    session_id wt_public_id not null
+      references session (public_id)
+      on delete cascade
+      on update cascade,
+    -- worker_id is the id of the worker which made the recording
+    worker_id text not null,
+    -- storage_path is the location of the recording on the worker
+    storage_path text not null
+      constraint storage_path_must_not_be_empty
+      check(length(trim(storage_path)) > 0),
+    -- the total number of bytes recorded from the client to the endpoint
+    bytes_up bigint not null default 0
+      constraint bytes_up_must_be_zero_or_greater
+      check(bytes_up >= 0),
+    -- the total number of bytes recorded from the endpoint to the client
+    bytes_down bigint not null default 0
+      constraint bytes_down_must_be_zero_or_greater
+      check(bytes_down >= 0),
+    start_time timestamp with time zone not null,
+    end_time timestamp with time zone not null
+      constraint end_time_must_not_be_before_start_time
+      check(end_time >= start_time),
+    create_time wt_timestamp
+  );
+
+  create trigger
+    immutable_columns
+  before
+  update on session_connection_recording
+    for each row execute procedure immutable_columns('connection_id', 'session_id', 'create_time');
+
+  create trigger
+    default_create_time_column
+  before
+  insert on session_connection_recording
+    for each row execute procedure default_create_time();
+
+commit;
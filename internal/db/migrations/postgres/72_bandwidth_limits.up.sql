@@ -0,0 +1,164 @@
+begin;
+
+  -- connection_max_bytes_per_second limits the throughput of each connection
+  -- of a session created for the target and session_max_bytes_per_second
+  -- limits the combined throughput of all the connections of the session. The
+  -- limits are enforced by the worker in each direction. 0 equals no limit.
+  alter table target_tcp
+    add column connection_max_bytes_per_second bigint not null default 0
+      constraint connection_max_bytes_per_second_must_be_zero_or_greater
+      check(connection_max_bytes_per_second >= 0),
+    add column session_max_bytes_per_second bigint not null default 0
+      constraint session_max_bytes_per_second_must_be_zero_or_greater
+      check(session_max_bytes_per_second >= 0);
+
+  alter table target_udp
+    add column connection_max_bytes_per_second bigint not null default 0
+      constraint connection_max_bytes_per_second_must_be_zero_or_greater
+      check(connection_max_bytes_per_second >= 0),
+    add column session_max_bytes_per_second bigint not null default 0
+      constraint session_max_bytes_per_second_must_be_zero_or_greater
+      check(session_max_bytes_per_second >= 0);
+
+  drop view whx_host_dimension_source;
+  drop view target_all_subtypes;
+
+  -- target_all_subtypes is a union of all target subtypes
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp;
+
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+  -- the limits are copied from the target when the session is created and
+  -- cannot be changed afterwards.
+  alter table session
+    add column connection_max_bytes_per_second bigint not null default 0
+      constraint connection_max_bytes_per_second_must_be_zero_or_greater
+      check(connection_max_bytes_per_second >= 0),
+    add column session_max_bytes_per_second bigint not null default 0
+      constraint session_max_bytes_per_second_must_be_zero_or_greater
+      check(session_max_bytes_per_second >= 0);
+
+  drop trigger immutable_columns on session;
+
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'recording_enabled', 'connection_max_bytes_per_second', 'session_max_bytes_per_second');
+
+  drop view session_with_state;
+
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    s.recording_enabled,
+    s.connection_max_bytes_per_second,
+    s.session_max_bytes_per_second,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+commit;
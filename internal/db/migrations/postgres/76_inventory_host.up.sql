@@ -0,0 +1,347 @@
+begin;
+
+/*
+
+  ┌─────────────────┐          ┌────────────────────────┐
+  │      host       │          │     inventory_host     │
+  ├─────────────────┤          ├────────────────────────┤
+  │ public_id  (pk) │          │ public_id   (pk)       │
+  │ catalog_id (fk) │┼┼──────○┼│ catalog_id  (fk)       │┼┼──────────────────────┐
+  │                 │          │ external_id            │             ◀fk1       │
+  └─────────────────┘          └────────────────────────┘                        │
+          ╲│╱                              ╲│╱                                   │
+           ○                                ○                                    │
+           │                                │                                    │
+           ┼                                ┼                                    ○
+           ┼                                ┼                                   ╱│╲
+  ┌─────────────────┐          ┌────────────────────────┐         ┌───────────────────────────┐
+  │  host_catalog   │          │ inventory_host_catalog │         │ inventory_host_set_member │
+  ├─────────────────┤          ├────────────────────────┤         ├───────────────────────────┤
+  │ public_id (pk)  │          │ public_id (pk)         │         │ host_id    (pk,fk1)       │
+  │ scope_id  (fk)  │┼┼──────○┼│ scope_id  (fk)         │         │ set_id     (pk,fk2)       │
+  │                 │          │ source                 │         │ catalog_id (fk1,fk2)      │
+  └─────────────────┘          └────────────────────────┘         └───────────────────────────┘
+           ┼                                ┼                                   ╲│╱
+           ┼                                ┼                                    ○
+           │                                │                                    │
+           ○                                ○                                    │
+          ╱│╲                              ╱│╲                                   │
+  ┌─────────────────┐          ┌────────────────────────┐                        │
+  │    host_set     │          │   inventory_host_set   │                        │
+  ├─────────────────┤          ├────────────────────────┤                        │
+  │ public_id  (pk) │          │ public_id  (pk)        │             ◀fk2       │
+  │ catalog_id (fk) │┼┼──────○┼│ catalog_id (fk)        │┼┼──────────────────────┘
+  │                 │          │ match_labels           │
+  └─────────────────┘          └────────────────────────┘
+
+  An inventory_host_catalog is a host_catalog subtype whose hosts are synced
+  from an inventory document at its source. The controllers periodically
+  fetch the document and reconcile the inventory_host rows of the catalog with
+  it. The membership of an inventory_host_set is the hosts of its catalog whose
+  labels match its match_labels, and is maintained by the same reconciliation.
+
+*/
+
+  create table inventory_host_catalog (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    -- source is an absolute file path or an http, https, or file url of the
+    -- inventory document
+    source text not null
+      constraint source_must_not_be_empty
+      check(length(trim(source)) > 0),
+    sync_interval_seconds int not null default 300
+      constraint sync_interval_seconds_must_be_greater_than_0
+      check(sync_interval_seconds > 0),
+    foreign key (scope_id, public_id)
+      references host_catalog (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, name)
+  );
+
+  create trigger update_version_column after update on inventory_host_catalog
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on inventory_host_catalog
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on inventory_host_catalog
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on inventory_host_catalog
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger insert_host_catalog_subtype before insert on inventory_host_catalog
+    for each row execute procedure insert_host_catalog_subtype();
+
+  create trigger delete_host_catalog_subtype after delete on inventory_host_catalog
+    for each row execute procedure delete_host_catalog_subtype();
+
+  -- inventory_host_catalog_sync records the outcome of the last sync of an
+  -- inventory_host_catalog. It is kept apart from the catalog so syncing does
+  -- not change the version of the catalog.
+  create table inventory_host_catalog_sync (
+    catalog_id wt_public_id
+      primary key
+      references inventory_host_catalog (public_id)
+      on delete cascade
+      on update cascade,
+    -- last_sync_time is null until the first sync is attempted
+    last_sync_time timestamp with time zone,
+    -- last_sync_error is null if the last sync succeeded
+    last_sync_error text
+  );
+
+  create or replace function insert_inventory_host_catalog_sync()
+    returns trigger
+  as $$
+  begin
+    insert into inventory_host_catalog_sync (catalog_id)
+    values (new.public_id);
+    return null; -- result is ignored since this is an after trigger
+  end;
+  $$ language plpgsql;
+
+  create trigger insert_inventory_host_catalog_sync after insert on inventory_host_catalog
+    for each row execute procedure insert_inventory_host_catalog_sync();
+
+  create table inventory_host (
+    public_id wt_public_id
+      primary key,
+    catalog_id wt_public_id not null
+      references inventory_host_catalog (public_id)
+      on delete cascade
+      on update cascade,
+    -- external_id is the id of the host in the inventory document
+    external_id text not null
+      constraint external_id_must_not_be_empty
+      check(length(trim(external_id)) > 0),
+    name text,
+    description text,
+    address text not null
+      constraint address_must_be_more_than_2_characters
+      check(length(trim(address)) > 2)
+      constraint address_must_be_less_than_256_characters
+      check(length(trim(address)) < 256),
+    -- labels is a json object of the labels of the host in the inventory
+    -- document
+    labels text not null default '{}',
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(catalog_id, external_id),
+
+    foreign key (catalog_id, public_id)
+      references host (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+
+    unique(catalog_id, public_id)
+  );
+
+  create trigger update_version_column after update on inventory_host
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on inventory_host
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on inventory_host
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on inventory_host
+    for each row execute procedure immutable_columns('public_id', 'catalog_id', 'external_id', 'create_time');
+
+  create trigger insert_host_subtype before insert on inventory_host
+    for each row execute procedure insert_host_subtype();
+
+  create trigger delete_host_subtype after delete on inventory_host
+    for each row execute procedure delete_host_subtype();
+
+  create table inventory_host_set (
+    public_id wt_public_id
+      primary key,
+    catalog_id wt_public_id not null
+      references inventory_host_catalog (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    -- match_labels is a json object of the labels a host must have to be a
+    -- member of the set. An empty object matches every host of the catalog.
+    match_labels text not null default '{}',
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(catalog_id, name),
+    foreign key (catalog_id, public_id)
+      references host_set (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(catalog_id, public_id)
+  );
+
+  create trigger update_version_column after update on inventory_host_set
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on inventory_host_set
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on inventory_host_set
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on inventory_host_set
+    for each row execute procedure immutable_columns('public_id', 'catalog_id', 'create_time');
+
+  create trigger insert_host_set_subtype before insert on inventory_host_set
+    for each row execute procedure insert_host_set_subtype();
+
+  create trigger delete_host_set_subtype after delete on inventory_host_set
+    for each row execute procedure delete_host_set_subtype();
+
+  create table inventory_host_set_member (
+    host_id wt_public_id not null,
+    set_id wt_public_id not null,
+    catalog_id wt_public_id not null,
+    primary key(host_id, set_id),
+    foreign key (catalog_id, host_id) -- fk1
+      references inventory_host (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+    foreign key (catalog_id, set_id) -- fk2
+      references inventory_host_set (catalog_id, public_id)
+      on delete cascade
+      on update cascade
+  );
+
+  create trigger immutable_columns before update on inventory_host_set_member
+    for each row execute procedure immutable_columns('host_id', 'set_id', 'catalog_id');
+
+  create or replace function insert_inventory_host_set_member()
+    returns trigger
+  as $$
+  begin
+    select inventory_host_set.catalog_id
+      into new.catalog_id
+    from inventory_host_set
+    where inventory_host_set.public_id = new.set_id;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger insert_inventory_host_set_member before insert on inventory_host_set_member
+    for each row execute procedure insert_inventory_host_set_member();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('inventory_host_catalog', 1),
+    ('inventory_host', 1),
+    ('inventory_host_set', 1),
+    ('inventory_host_set_member', 1);
+
+  -- whx_host_dimension_source is recreated to include the inventory hosts so
+  -- sessions to them are added to the warehouse.
+  drop view whx_host_dimension_source;
+
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  union
+  select h.public_id                     as host_id,
+         'inventory host'                as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'inventory host set'            as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'inventory host catalog'        as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from inventory_host as h,
+         inventory_host_catalog as c,
+         inventory_host_set_member as m,
+         inventory_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+commit;
@@ -0,0 +1,26 @@
+begin;
+
+  drop view target_library;
+  drop table target_credential_library cascade;
+  drop table credential_static_library cascade;
+  drop table credential_static_store cascade;
+  drop table credential_library cascade;
+  drop table credential_store cascade;
+  drop table kms_credential_key_version cascade;
+  drop table kms_credential_key cascade;
+
+  drop function target_credential_library_scope_valid;
+  drop function insert_credential_library_subtype;
+  drop function delete_credential_library_subtype;
+  drop function insert_credential_store_subtype;
+  drop function delete_credential_store_subtype;
+
+  delete
+    from oplog_ticket
+   where name in (
+          'credential_static_store',
+          'credential_static_library',
+          'target_credential_library'
+        );
+
+commit;
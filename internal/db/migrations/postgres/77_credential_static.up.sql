@@ -0,0 +1,313 @@
+begin;
+
+/*
+
+  ┌──────────────────┐          ┌─────────────────────────┐
+  │ credential_store │          │ credential_static_store │
+  ├──────────────────┤          ├─────────────────────────┤
+  │ public_id (pk)   │          │ public_id (pk)          │
+  │ scope_id  (fk)   │┼┼──────○┼│ scope_id  (fk)          │
+  │                  │          │                         │
+  └──────────────────┘          └─────────────────────────┘
+           ┼                                 ┼
+           ┼                                 ┼
+           │                                 │
+           ○                                 ○
+          ╱│╲                               ╱│╲
+  ┌──────────────────────┐      ┌─────────────────────────────┐
+  │  credential_library  │      │  credential_static_library  │
+  ├──────────────────────┤      ├─────────────────────────────┤
+  │ public_id (pk)       │      │ public_id (pk)              │
+  │ store_id  (fk)       │┼┼──○┼│ store_id  (fk)              │
+  │                      │      │ username                    │
+  └──────────────────────┘      │ password                    │
+           ┼                    │ private_key                 │
+           ┼                    │ key_id    (fk)              │
+           │                    └─────────────────────────────┘
+           ○
+          ╱│╲
+  ┌──────────────────────────────┐
+  │  target_credential_library   │
+  ├──────────────────────────────┤
+  │ target_id             (pk,fk)│
+  │ credential_library_id (pk,fk)│
+  └──────────────────────────────┘
+
+  A credential_static_store is a credential_store subtype whose libraries hold
+  a fixed username, password and private key. The password and private key are
+  encrypted with the credentials key of the scope of the store. Credential
+  libraries attached to a target are brokered to the sessions of the target.
+
+*/
+
+  create table kms_credential_key (
+    private_id wt_private_id primary key,
+    root_key_id wt_private_id not null unique -- there can be only one credential dek per root key
+      references kms_root_key(private_id)
+      on delete cascade
+      on update cascade,
+    create_time wt_timestamp
+  );
+
+  -- define the immutable fields for kms_credential_key (all of them)
+  create trigger immutable_columns before update on kms_credential_key
+    for each row execute procedure immutable_columns('private_id', 'root_key_id', 'create_time');
+
+  create trigger default_create_time_column before insert on kms_credential_key
+    for each row execute procedure default_create_time();
+
+  create table kms_credential_key_version (
+    private_id wt_private_id primary key,
+    credential_key_id wt_private_id not null
+      references kms_credential_key(private_id)
+      on delete cascade
+      on update cascade,
+    root_key_version_id wt_private_id not null
+      references kms_root_key_version(private_id)
+      on delete cascade
+      on update cascade,
+    version wt_version,
+    key bytea not null,
+    create_time wt_timestamp,
+    unique(credential_key_id, version)
+  );
+
+  -- define the immutable fields for kms_credential_key_version (all of them)
+  create trigger immutable_columns before update on kms_credential_key_version
+    for each row execute procedure immutable_columns('private_id', 'credential_key_id', 'root_key_version_id', 'version', 'key', 'create_time');
+
+  create trigger default_create_time_column before insert on kms_credential_key_version
+    for each row execute procedure default_create_time();
+
+  create trigger kms_version_column before insert on kms_credential_key_version
+    for each row execute procedure kms_version_column('credential_key_id');
+
+  create table credential_store (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, public_id)
+  );
+
+  create trigger immutable_columns before update on credential_store
+    for each row execute procedure immutable_columns('public_id', 'scope_id');
+
+  -- insert_credential_store_subtype() is a before insert trigger
+  -- function for subtypes of credential_store
+  create or replace function insert_credential_store_subtype()
+    returns trigger
+  as $$
+  begin
+    insert into credential_store
+      (public_id, scope_id)
+    values
+      (new.public_id, new.scope_id);
+    return new;
+  end;
+  $$ language plpgsql;
+
+  -- delete_credential_store_subtype() is an after delete trigger
+  -- function for subtypes of credential_store
+  create or replace function delete_credential_store_subtype()
+    returns trigger
+  as $$
+  begin
+    delete from credential_store
+    where public_id = old.public_id;
+    return null; -- result is ignored since this is an after trigger
+  end;
+  $$ language plpgsql;
+
+  create table credential_library (
+    public_id wt_public_id
+      primary key,
+    store_id wt_public_id not null
+      references credential_store (public_id)
+      on delete cascade
+      on update cascade,
+    unique(store_id, public_id)
+  );
+
+  create trigger immutable_columns before update on credential_library
+    for each row execute procedure immutable_columns('public_id', 'store_id');
+
+  -- insert_credential_library_subtype() is a before insert trigger
+  -- function for subtypes of credential_library
+  create or replace function insert_credential_library_subtype()
+    returns trigger
+  as $$
+  begin
+    insert into credential_library
+      (public_id, store_id)
+    values
+      (new.public_id, new.store_id);
+    return new;
+  end;
+  $$ language plpgsql;
+
+  -- delete_credential_library_subtype() is an after delete trigger
+  -- function for subtypes of credential_library
+  create or replace function delete_credential_library_subtype()
+    returns trigger
+  as $$
+  begin
+    delete from credential_library
+    where public_id = old.public_id;
+    return null; -- result is ignored since this is an after trigger
+  end;
+  $$ language plpgsql;
+
+  create table credential_static_store (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    foreign key (scope_id, public_id)
+      references credential_store (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, name)
+  );
+
+  create trigger update_version_column after update on credential_static_store
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on credential_static_store
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on credential_static_store
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on credential_static_store
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger insert_credential_store_subtype before insert on credential_static_store
+    for each row execute procedure insert_credential_store_subtype();
+
+  create trigger delete_credential_store_subtype after delete on credential_static_store
+    for each row execute procedure delete_credential_store_subtype();
+
+  create table credential_static_library (
+    public_id wt_public_id
+      primary key,
+    store_id wt_public_id not null
+      references credential_static_store (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    username text not null
+      constraint username_must_not_be_empty
+      check(length(trim(username)) > 0),
+    -- password and private_key are encrypted with the kms_credential_key_version
+    -- referenced by key_id. Either may be the encryption of an empty value.
+    password bytea not null,
+    private_key bytea not null,
+    key_id wt_private_id not null
+      references kms_credential_key_version (private_id)
+      on delete restrict
+      on update cascade,
+    unique(store_id, name),
+    foreign key (store_id, public_id)
+      references credential_library (store_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(store_id, public_id)
+  );
+
+  create trigger update_version_column after update on credential_static_library
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on credential_static_library
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on credential_static_library
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on credential_static_library
+    for each row execute procedure immutable_columns('public_id', 'store_id', 'create_time');
+
+  create trigger insert_credential_library_subtype before insert on credential_static_library
+    for each row execute procedure insert_credential_library_subtype();
+
+  create trigger delete_credential_library_subtype after delete on credential_static_library
+    for each row execute procedure delete_credential_library_subtype();
+
+  -- target_credential_library_scope_valid() is a before insert trigger
+  -- function for target_credential_library
+  create or replace function target_credential_library_scope_valid()
+    returns trigger
+  as $$
+  begin
+    perform from
+      credential_store cs,
+      credential_library cl,
+      target t
+    where
+      cs.public_id = cl.store_id and
+      cs.scope_id = t.scope_id and
+      cl.public_id = new.credential_library_id and
+      t.public_id = new.target_id;
+    if not found then
+      raise exception 'target scope and credential library scope are not equal';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create table target_credential_library (
+    target_id wt_public_id
+      references target (public_id)
+      on delete cascade
+      on update cascade,
+    credential_library_id wt_public_id
+      references credential_library (public_id)
+      on delete cascade
+      on update cascade,
+    primary key(target_id, credential_library_id),
+    create_time wt_timestamp
+  );
+
+  create trigger immutable_columns before update on target_credential_library
+    for each row execute procedure immutable_columns('target_id', 'credential_library_id', 'create_time');
+
+  create trigger default_create_time_column before insert on target_credential_library
+    for each row execute procedure default_create_time();
+
+  create trigger target_credential_library_scope_valid before insert on target_credential_library
+    for each row execute procedure target_credential_library_scope_valid();
+
+  create view target_library
+  as
+  select
+    cl.public_id,
+    cl.store_id,
+    tcl.target_id
+  from
+    target_credential_library tcl,
+    credential_library cl
+  where
+    cl.public_id = tcl.credential_library_id;
+
+  insert into oplog_ticket (name, version)
+  values
+    ('credential_static_store', 1),
+    ('credential_static_library', 1),
+    ('target_credential_library', 1);
+
+commit;
@@ -0,0 +1,293 @@
+begin;
+
+  -- target_ssh is a target whose sessions are authenticated to the host with
+  -- a short-lived ssh user certificate. The controller signs the certificate
+  -- with the certificate authority of the target's scope when the session is
+  -- authorized.
+  create table target_ssh (
+    public_id wt_public_id primary key
+      references target(public_id)
+      on delete cascade
+      on update cascade,
+    scope_id wt_scope_id not null
+      references iam_scope(public_id)
+      on delete cascade
+      on update cascade,
+    name text not null, -- name is not optional for a target subtype
+    description text,
+    default_port int, -- default_port can be null
+     -- max duration of the session in seconds.
+     -- default is 8 hours
+    session_max_seconds int not null default 28800
+      constraint session_max_seconds_must_be_greater_than_0
+      check(session_max_seconds > 0),
+    -- limit on number of session connections allowed. -1 equals no limit
+    session_connection_limit int not null default 1
+      constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+      check(session_connection_limit > 0 or session_connection_limit = -1),
+    enable_session_recording boolean not null default false,
+    connection_max_bytes_per_second bigint not null default 0
+      constraint connection_max_bytes_per_second_must_be_zero_or_greater
+      check(connection_max_bytes_per_second >= 0),
+    session_max_bytes_per_second bigint not null default 0
+      constraint session_max_bytes_per_second_must_be_zero_or_greater
+      check(session_max_bytes_per_second >= 0),
+    session_idle_timeout_seconds int not null default 0
+      constraint session_idle_timeout_seconds_must_be_zero_or_greater
+      check(session_idle_timeout_seconds >= 0),
+    -- principals is a comma separated list of the principals included in the
+    -- certificates issued for sessions. When null, the name of the user
+    -- authorizing the session is used.
+    principals text
+      constraint principals_must_not_be_empty
+      check(length(trim(principals)) > 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(scope_id, name) -- name must be unique within a scope
+  );
+
+  create trigger
+    insert_target_subtype
+  before insert on target_ssh
+    for each row execute procedure insert_target_subtype();
+
+  create trigger
+    delete_target_subtype
+  after delete on target_ssh
+    for each row execute procedure delete_target_subtype();
+
+   -- define the immutable fields for target
+  create trigger
+    immutable_columns
+  before
+  update on target_ssh
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger
+    update_version_column
+  after update on target_ssh
+    for each row execute procedure update_version_column();
+
+  create trigger
+    update_time_column
+  before update on target_ssh
+    for each row execute procedure update_time_column();
+
+  create trigger
+    default_create_time_column
+  before
+  insert on target_ssh
+    for each row execute procedure default_create_time();
+
+  create trigger
+    target_scope_valid
+  before insert on target_ssh
+    for each row execute procedure target_scope_valid();
+
+  -- kms_ssh_certificate_key is the dek from which the certificate authority
+  -- that signs the ssh certificates of sessions in a scope is derived.
+  create table kms_ssh_certificate_key (
+    private_id wt_private_id primary key,
+    root_key_id wt_private_id not null unique -- there can be only one ssh certificate dek per root key
+      references kms_root_key(private_id)
+      on delete cascade
+      on update cascade,
+    create_time wt_timestamp
+  );
+
+  -- define the immutable fields for kms_ssh_certificate_key (all of them)
+  create trigger immutable_columns before update on kms_ssh_certificate_key
+    for each row execute procedure immutable_columns('private_id', 'root_key_id', 'create_time');
+
+  create trigger default_create_time_column before insert on kms_ssh_certificate_key
+    for each row execute procedure default_create_time();
+
+  create table kms_ssh_certificate_key_version (
+    private_id wt_private_id primary key,
+    ssh_certificate_key_id wt_private_id not null
+      references kms_ssh_certificate_key(private_id)
+      on delete cascade
+      on update cascade,
+    root_key_version_id wt_private_id not null
+      references kms_root_key_version(private_id)
+      on delete cascade
+      on update cascade,
+    version wt_version,
+    key bytea not null,
+    create_time wt_timestamp,
+    unique(ssh_certificate_key_id, version)
+  );
+
+  -- define the immutable fields for kms_ssh_certificate_key_version (all of them)
+  create trigger immutable_columns before update on kms_ssh_certificate_key_version
+    for each row execute procedure immutable_columns('private_id', 'ssh_certificate_key_id', 'root_key_version_id', 'version', 'key', 'create_time');
+
+  create trigger default_create_time_column before insert on kms_ssh_certificate_key_version
+    for each row execute procedure default_create_time();
+
+  create trigger kms_version_column before insert on kms_ssh_certificate_key_version
+    for each row execute procedure kms_version_column('ssh_certificate_key_id');
+
+  -- whx_host_dimension_source depends on target_all_subtypes so it is dropped
+  -- and recreated with it to include the ssh targets.
+  drop view whx_host_dimension_source;
+  drop view target_all_subtypes;
+
+  -- target_all_subtypes is a union of all target subtypes
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    null::text as principals,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    null::text as principals,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    enable_session_recording,
+    connection_max_bytes_per_second,
+    session_max_bytes_per_second,
+    session_idle_timeout_seconds,
+    principals,
+    version,
+    create_time,
+    update_time,
+    'ssh' as type
+    from target_ssh;
+
+  create view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  union
+  select h.public_id                     as host_id,
+         'inventory host'                as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'inventory host set'            as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'inventory host catalog'        as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         t.type || ' target'             as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from inventory_host as h,
+         inventory_host_catalog as c,
+         inventory_host_set_member as m,
+         inventory_host_set as s,
+         target_host_set as ts,
+         target_all_subtypes as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+  insert into oplog_ticket
+    (name, version)
+  values
+    ('target_ssh', 1);
+
+commit;
@@ -0,0 +1,77 @@
+begin;
+
+  -- server_worker_downstream contains the names of the workers which are
+  -- connected to a worker as its downstream workers. A downstream worker only
+  -- connects outbound, to its upstream workers, which forward the connections
+  -- of its sessions to it. They are replaced each time a worker reports its
+  -- status.
+  create table server_worker_downstream (
+    server_id text not null,
+    server_type text not null
+      constraint server_type_must_be_worker
+      check(server_type = 'worker'),
+    downstream_name text not null
+      constraint downstream_name_must_not_be_empty
+      check(length(trim(downstream_name)) > 0),
+    primary key (server_id, server_type, downstream_name),
+    foreign key (server_id, server_type)
+      references server(private_id, type)
+      on delete cascade
+      on update cascade
+  );
+
+  -- worker_filter is copied from the target when the session is created and
+  -- cannot be changed afterwards. Workers use it to choose the downstream
+  -- worker which dials the endpoint.
+  alter table session
+    add column worker_filter text
+      constraint worker_filter_must_not_be_empty
+      check(length(trim(worker_filter)) > 0);
+
+  drop trigger immutable_columns on session;
+
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'recording_enabled', 'connection_max_bytes_per_second', 'session_max_bytes_per_second', 'idle_timeout_seconds', 'worker_filter');
+
+  drop view session_with_state;
+
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    s.recording_enabled,
+    s.connection_max_bytes_per_second,
+    s.session_max_bytes_per_second,
+    s.idle_timeout_seconds,
+    s.worker_filter,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+commit;
@@ -0,0 +1,20 @@
+begin;
+
+  create or replace function
+    immutable_auth_token_columns()
+    returns trigger
+  as $$
+  begin
+    if new.auth_account_id is distinct from old.auth_account_id then
+      raise exception 'auth_account_id is read-only';
+    end if;
+    if new.token is distinct from old.token then
+      raise exception 'token is read-only';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  drop table kms_key_rotation;
+
+commit;
@@ -0,0 +1,71 @@
+begin;
+
+  -- kms_key_rotation records the rotations of the keys of a scope. A rotation
+  -- creates a new version of the scope's root key and of each of its DEKs.
+  -- Values encrypted with previous versions are then re-encrypted with the
+  -- new versions by the controllers in the background, which keep track of
+  -- their progress here. A rotation is superseded when a newer rotation of the
+  -- same scope is started before it completed.
+  create table kms_key_rotation (
+    private_id wt_private_id primary key,
+    scope_id wt_scope_id not null
+      references iam_scope(public_id)
+      on delete cascade
+      on update cascade,
+    root_key_version_id wt_private_id not null
+      references kms_root_key_version(private_id)
+      on delete cascade
+      on update cascade,
+    status text not null default 'rewrapping'
+      constraint status_must_be_valid
+      check(status in ('rewrapping', 'completed', 'superseded')),
+    rewrapped_count bigint not null default 0
+      constraint rewrapped_count_must_be_zero_or_greater
+      check(rewrapped_count >= 0),
+    -- last_error is the last error the controllers ran into while rewrapping
+    -- values, which they retry until they succeed.
+    last_error text,
+    create_time wt_timestamp,
+    update_time wt_timestamp
+  );
+
+  create index kms_key_rotation_scope_id_create_time_idx
+    on kms_key_rotation (scope_id, create_time);
+
+  create trigger
+    default_create_time_column
+  before
+  insert on kms_key_rotation
+    for each row execute procedure default_create_time();
+
+  create trigger
+    update_time_column
+  before
+  update on kms_key_rotation
+    for each row execute procedure update_time_column();
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_key_rotation
+    for each row execute procedure immutable_columns('private_id', 'scope_id', 'root_key_version_id', 'create_time');
+
+  -- The token of an auth token could not be changed at all. It can now be
+  -- changed together with its key_id, which is how it is re-encrypted with a
+  -- new version of the database key.
+  create or replace function
+    immutable_auth_token_columns()
+    returns trigger
+  as $$
+  begin
+    if new.auth_account_id is distinct from old.auth_account_id then
+      raise exception 'auth_account_id is read-only';
+    end if;
+    if new.token is distinct from old.token and new.key_id is not distinct from old.key_id then
+      raise exception 'token is read-only';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+commit;
@@ -0,0 +1,5 @@
+begin;
+
+  drop index oplog_entry_aggregate_name_id_ix;
+
+commit;
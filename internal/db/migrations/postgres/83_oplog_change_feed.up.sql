@@ -0,0 +1,9 @@
+begin;
+
+  -- The change feed reads the entries of each aggregate after the last entry
+  -- of it a subscriber has seen, and looks up the last entry of each
+  -- aggregate to start a subscription.
+  create index oplog_entry_aggregate_name_id_ix
+    on oplog_entry (aggregate_name, id);
+
+commit;
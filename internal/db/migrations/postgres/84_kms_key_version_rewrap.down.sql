@@ -0,0 +1,108 @@
+begin;
+
+  drop view session_with_state;
+
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    s.recording_enabled,
+    s.connection_max_bytes_per_second,
+    s.session_max_bytes_per_second,
+    s.idle_timeout_seconds,
+    s.worker_filter,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+  drop trigger immutable_columns on session;
+
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'recording_enabled', 'connection_max_bytes_per_second', 'session_max_bytes_per_second', 'idle_timeout_seconds', 'worker_filter');
+
+  alter table session
+    drop column session_key_version_id;
+
+  drop trigger immutable_key_columns on kms_database_key_version;
+  drop trigger immutable_columns on kms_database_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_database_key_version
+    for each row execute procedure immutable_columns('private_id', 'database_key_id', 'root_key_version_id', 'version', 'key', 'create_time');
+
+  drop trigger immutable_key_columns on kms_oplog_key_version;
+  drop trigger immutable_columns on kms_oplog_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_oplog_key_version
+    for each row execute procedure immutable_columns('private_id', 'oplog_key_id', 'root_key_version_id', 'version', 'key', 'create_time');
+
+  drop trigger immutable_key_columns on kms_session_key_version;
+  drop trigger immutable_columns on kms_session_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_session_key_version
+    for each row execute procedure immutable_columns('private_id', 'session_key_id', 'root_key_version_id', 'version', 'key', 'create_time');
+
+  drop trigger immutable_key_columns on kms_token_key_version;
+  drop trigger immutable_columns on kms_token_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_token_key_version
+    for each row execute procedure immutable_columns('private_id', 'token_key_id', 'root_key_version_id', 'version', 'key', 'create_time');
+
+  drop trigger immutable_key_columns on kms_credential_key_version;
+  drop trigger immutable_columns on kms_credential_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_credential_key_version
+    for each row execute procedure immutable_columns('private_id', 'credential_key_id', 'root_key_version_id', 'version', 'key', 'create_time');
+
+  drop trigger immutable_key_columns on kms_ssh_certificate_key_version;
+  drop trigger immutable_columns on kms_ssh_certificate_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_ssh_certificate_key_version
+    for each row execute procedure immutable_columns('private_id', 'ssh_certificate_key_id', 'root_key_version_id', 'version', 'key', 'create_time');
+
+  drop function immutable_kms_key_version_key;
+
+commit;
@@ -0,0 +1,177 @@
+begin;
+
+  -- The versions of the DEKs of a scope are re-encrypted with the new version
+  -- of its root key when its keys are rotated, so that the previous root key
+  -- versions no longer encrypt anything and can be destroyed. The key of a DEK
+  -- version can now be changed together with its root_key_version_id, which
+  -- is how it is re-encrypted; its value does not change.
+  create function
+    immutable_kms_key_version_key()
+    returns trigger
+  as $$
+  begin
+    if new.key is distinct from old.key and new.root_key_version_id is not distinct from old.root_key_version_id then
+      raise exception 'key is read-only';
+    end if;
+    if new.root_key_version_id is distinct from old.root_key_version_id and new.key is not distinct from old.key then
+      raise exception 'root_key_version_id is read-only';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  drop trigger immutable_columns on kms_database_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_database_key_version
+    for each row execute procedure immutable_columns('private_id', 'database_key_id', 'version', 'create_time');
+
+  create trigger
+    immutable_key_columns
+  before
+  update on kms_database_key_version
+    for each row execute procedure immutable_kms_key_version_key();
+
+  drop trigger immutable_columns on kms_oplog_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_oplog_key_version
+    for each row execute procedure immutable_columns('private_id', 'oplog_key_id', 'version', 'create_time');
+
+  create trigger
+    immutable_key_columns
+  before
+  update on kms_oplog_key_version
+    for each row execute procedure immutable_kms_key_version_key();
+
+  drop trigger immutable_columns on kms_session_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_session_key_version
+    for each row execute procedure immutable_columns('private_id', 'session_key_id', 'version', 'create_time');
+
+  create trigger
+    immutable_key_columns
+  before
+  update on kms_session_key_version
+    for each row execute procedure immutable_kms_key_version_key();
+
+  drop trigger immutable_columns on kms_token_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_token_key_version
+    for each row execute procedure immutable_columns('private_id', 'token_key_id', 'version', 'create_time');
+
+  create trigger
+    immutable_key_columns
+  before
+  update on kms_token_key_version
+    for each row execute procedure immutable_kms_key_version_key();
+
+  drop trigger immutable_columns on kms_credential_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_credential_key_version
+    for each row execute procedure immutable_columns('private_id', 'credential_key_id', 'version', 'create_time');
+
+  create trigger
+    immutable_key_columns
+  before
+  update on kms_credential_key_version
+    for each row execute procedure immutable_kms_key_version_key();
+
+  drop trigger immutable_columns on kms_ssh_certificate_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_ssh_certificate_key_version
+    for each row execute procedure immutable_columns('private_id', 'ssh_certificate_key_id', 'version', 'create_time');
+
+  create trigger
+    immutable_key_columns
+  before
+  update on kms_ssh_certificate_key_version
+    for each row execute procedure immutable_kms_key_version_key();
+
+  -- session_key_version_id is the version of the sessions DEK of the scope
+  -- from which the key of the session is derived. It is set when the session
+  -- is created and cannot be changed afterwards, so the key still matches the
+  -- session's certificate after the keys of the scope are rotated. Sessions
+  -- created before it was introduced use the only version their scope had.
+  alter table session
+    add column session_key_version_id text
+      constraint session_key_version_id_must_not_be_empty
+      check(length(trim(session_key_version_id)) > 0);
+
+  update session s
+     set session_key_version_id = (
+           select v.private_id
+             from kms_session_key_version v
+             join kms_session_key k
+               on k.private_id = v.session_key_id
+             join kms_root_key r
+               on r.private_id = k.root_key_id
+            where r.scope_id = s.scope_id
+            order by v.version desc
+            limit 1
+         );
+
+  drop trigger immutable_columns on session;
+
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'recording_enabled', 'connection_max_bytes_per_second', 'session_max_bytes_per_second', 'idle_timeout_seconds', 'worker_filter', 'session_key_version_id');
+
+  drop view session_with_state;
+
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    s.recording_enabled,
+    s.connection_max_bytes_per_second,
+    s.session_max_bytes_per_second,
+    s.idle_timeout_seconds,
+    s.worker_filter,
+    s.session_key_version_id,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+commit;
@@ -0,0 +1,124 @@
+package event
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// maxRecordSize is the largest record VerifyChain will read.
+const maxRecordSize = 1024 * 1024
+
+// record is a line written to a sink.  Event is the encoded event, including
+// the hash of the previous record written to the sink, and Hash is the hash of
+// Event: its HMAC-SHA256 under the chain key, or its SHA-256 if the sink has
+// no chain key.
+type record struct {
+	Event json.RawMessage `json:"event"`
+	Hash  string          `json:"hash"`
+}
+
+// chainedEvent is an Event along with the hash of the previous record of the
+// sink.  The first record of a sink has an empty PrevHash.
+type chainedEvent struct {
+	*Event
+	PrevHash string `json:"prev_hash"`
+}
+
+// newRecord encodes ev chained to the record with prevHash and hashes it with
+// key.  It returns the encoded record, without a trailing newline, and its
+// hash.
+func newRecord(ev *Event, key []byte, prevHash string) ([]byte, string, error) {
+	evJson, err := json.Marshal(&chainedEvent{Event: ev, PrevHash: prevHash})
+	if err != nil {
+		return nil, "", fmt.Errorf("error encoding event: %w", err)
+	}
+	hash := recordHash(key, evJson)
+	rec, err := json.Marshal(&record{Event: evJson, Hash: hash})
+	if err != nil {
+		return nil, "", fmt.Errorf("error encoding event record: %w", err)
+	}
+	return rec, hash, nil
+}
+
+func recordHash(key, evJson []byte) string {
+	if len(key) == 0 {
+		sum := sha256.Sum256(evJson)
+		return hex.EncodeToString(sum[:])
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(evJson)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ErrBrokenChain is returned by VerifyChain when the records have been
+// altered, removed or reordered.
+var ErrBrokenChain = errors.New("event chain is broken")
+
+// VerifyChain reads the records written by a file or stderr sink with the
+// chain key from r and checks that each record is intact and chained to the
+// record before it.  The first record must be chained to prevHash, which is
+// the hash of the last record written before r begins, or empty if r begins
+// with the first record written to the sink.  It returns the number of records
+// read and the hash of the last one.  Records removed from the end of r can
+// only be detected by comparing that hash with one recorded elsewhere.
+func VerifyChain(r io.Reader, key []byte, prevHash string) (int, string, error) {
+	return verifyChain(r, key, &prevHash)
+}
+
+// verifyChain is VerifyChain, except that if prevHash is nil the first record
+// may be chained to any hash.
+func verifyChain(r io.Reader, key []byte, prevHash *string) (int, string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+
+	var count int
+	var last string
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		count++
+		var rec record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return count, last, fmt.Errorf("error decoding record %d: %w", count, err)
+		}
+		if !hmac.Equal([]byte(recordHash(key, rec.Event)), []byte(rec.Hash)) {
+			return count, last, fmt.Errorf("record %d does not match its hash: %w", count, ErrBrokenChain)
+		}
+		var ev chainedEvent
+		if err := json.Unmarshal(rec.Event, &ev); err != nil {
+			return count, last, fmt.Errorf("error decoding event of record %d: %w", count, err)
+		}
+		switch {
+		case count == 1 && prevHash == nil:
+		case count == 1 && ev.PrevHash != *prevHash:
+			return count, last, fmt.Errorf("first record is not chained to the expected hash: %w", ErrBrokenChain)
+		case count > 1 && ev.PrevHash != last:
+			return count, last, fmt.Errorf("record %d is not chained to the previous record: %w", count, ErrBrokenChain)
+		}
+		last = rec.Hash
+	}
+	if err := scanner.Err(); err != nil {
+		return count, last, fmt.Errorf("error reading records: %w", err)
+	}
+	return count, last, nil
+}
+
+// lastHash returns the hash of the last record read from r, or an empty
+// string if r has no records.  It is used to continue the chain of an existing
+// file, whose beginning may have been removed when it was rotated.
+func lastHash(r io.Reader, key []byte) (string, error) {
+	_, hash, err := verifyChain(r, key, nil)
+	if err != nil {
+		return "", err
+	}
+	return hash, nil
+}
@@ -0,0 +1,167 @@
+package event
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testChainKey = []byte("test-chain-key")
+
+func testEvent(i int, sessionId string) *Event {
+	return &Event{
+		Id:        "evt_test",
+		Type:      SessionType,
+		CreatedAt: time.Unix(int64(i), 0).UTC(),
+		Data:      Session{Stage: SessionActivated, SessionId: sessionId},
+	}
+}
+
+// testChain returns n records chained with key and their hashes.
+func testChain(t *testing.T, key []byte, n int) ([]string, []string) {
+	t.Helper()
+	var lines, hashes []string
+	var prevHash string
+	for i := 0; i < n; i++ {
+		rec, hash, err := newRecord(testEvent(i, "s_1234567890"), key, prevHash)
+		require.NoError(t, err)
+		lines = append(lines, string(rec))
+		hashes = append(hashes, hash)
+		prevHash = hash
+	}
+	return lines, hashes
+}
+
+func TestVerifyChain(t *testing.T) {
+	cases := []struct {
+		name string
+		// lines returns the records to verify and the hash the first of them
+		// should be chained to.
+		lines     func(t *testing.T, l, h []string) ([]string, string)
+		key       []byte
+		wantCount int
+		wantErr   error
+	}{
+		{
+			name:      "intact",
+			lines:     func(_ *testing.T, l, _ []string) ([]string, string) { return l, "" },
+			key:       testChainKey,
+			wantCount: 4,
+		},
+		{
+			name:      "empty",
+			lines:     func(*testing.T, []string, []string) ([]string, string) { return nil, "" },
+			key:       testChainKey,
+			wantCount: 0,
+		},
+		{
+			name:      "continues an earlier chain",
+			lines:     func(_ *testing.T, l, h []string) ([]string, string) { return l[2:], h[1] },
+			key:       testChainKey,
+			wantCount: 2,
+		},
+		{
+			name:      "beginning removed",
+			lines:     func(_ *testing.T, l, _ []string) ([]string, string) { return l[2:], "" },
+			key:       testChainKey,
+			wantCount: 1,
+			wantErr:   ErrBrokenChain,
+		},
+		{
+			name: "altered",
+			lines: func(_ *testing.T, l, _ []string) ([]string, string) {
+				l[1] = strings.Replace(l[1], "s_1234567890", "s_0987654321", 1)
+				return l, ""
+			},
+			key:       testChainKey,
+			wantCount: 2,
+			wantErr:   ErrBrokenChain,
+		},
+		{
+			name: "altered and rehashed without the key",
+			lines: func(t *testing.T, l, h []string) ([]string, string) {
+				rec, _, err := newRecord(testEvent(1, "s_0987654321"), nil, h[0])
+				require.NoError(t, err)
+				l[1] = string(rec)
+				return l, ""
+			},
+			key:       testChainKey,
+			wantCount: 2,
+			wantErr:   ErrBrokenChain,
+		},
+		{
+			name:      "other key",
+			lines:     func(_ *testing.T, l, _ []string) ([]string, string) { return l, "" },
+			key:       []byte("other-chain-key"),
+			wantCount: 1,
+			wantErr:   ErrBrokenChain,
+		},
+		{
+			name:      "removed",
+			lines:     func(_ *testing.T, l, _ []string) ([]string, string) { return append(l[:1], l[2:]...), "" },
+			key:       testChainKey,
+			wantCount: 2,
+			wantErr:   ErrBrokenChain,
+		},
+		{
+			name: "reordered",
+			lines: func(_ *testing.T, l, _ []string) ([]string, string) {
+				l[1], l[2] = l[2], l[1]
+				return l, ""
+			},
+			key:       testChainKey,
+			wantCount: 2,
+			wantErr:   ErrBrokenChain,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			chain, hashes := testChain(t, testChainKey, 4)
+			lines, prevHash := tt.lines(t, chain, hashes)
+			var buf bytes.Buffer
+			for _, l := range lines {
+				buf.WriteString(l + "\n")
+			}
+			count, hash, err := VerifyChain(&buf, tt.key, prevHash)
+			assert.Equal(tt.wantCount, count)
+			if tt.wantErr != nil {
+				require.Error(err)
+				assert.True(errors.Is(err, tt.wantErr))
+				return
+			}
+			require.NoError(err)
+			if len(lines) > 0 {
+				assert.Contains(lines[len(lines)-1], hash)
+			}
+		})
+	}
+}
+
+func TestVerifyChain_NoKey(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	lines, hashes := testChain(t, nil, 3)
+	count, hash, err := VerifyChain(strings.NewReader(strings.Join(lines, "\n")), nil, "")
+	require.NoError(err)
+	assert.Equal(3, count)
+	assert.Equal(hashes[2], hash)
+}
+
+func TestLastHash(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	lines, hashes := testChain(t, testChainKey, 4)
+
+	// A file whose beginning was removed when it was rotated can still be
+	// continued.
+	hash, err := lastHash(strings.NewReader(strings.Join(lines[2:], "\n")), testChainKey)
+	require.NoError(err)
+	assert.Equal(hashes[3], hash)
+
+	_, err = lastHash(strings.NewReader(strings.Join(lines, "\n")), nil)
+	assert.True(errors.Is(err, ErrBrokenChain))
+}
@@ -0,0 +1,114 @@
+// Package event emits structured audit events for the activity of Boundary
+// controllers and workers.
+//
+// Events are written to one or more sinks as newline-delimited JSON records.
+// Each sink keeps its own hash chain: every record carries the hash of the
+// record written before it to the same sink, so records which are altered,
+// removed or reordered after the fact can be detected with VerifyChain. The
+// chain is only tamper-evident when it is keyed with a chain key, and records
+// removed from its end are only detected against a hash kept elsewhere.
+package event
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+)
+
+// Type is the type of an event.  A sink can be configured to only receive
+// events of some types.
+type Type string
+
+const (
+	// AuditType events describe a request made to the controller API and its
+	// result.
+	AuditType Type = "audit"
+	// SessionType events describe a change in the lifecycle of a session or
+	// one of its connections.
+	SessionType Type = "session"
+)
+
+// Stage of a session or connection described by a Session event.
+type Stage string
+
+const (
+	SessionAuthorized    Stage = "session_authorized"
+	SessionActivated     Stage = "session_activated"
+	SessionCanceled      Stage = "session_canceled"
+	ConnectionAuthorized Stage = "connection_authorized"
+	ConnectionClosed     Stage = "connection_closed"
+)
+
+// Event is a single event written to a sink.
+type Event struct {
+	Id        string      `json:"id"`
+	Type      Type        `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Source    string      `json:"source,omitempty"`
+	Data      interface{} `json:"data"`
+}
+
+// Audit is the data of an AuditType event.  AuthTokenId and ClientAddr are
+// sensitive and are HMAC'd when the eventer has an HMAC key.
+type Audit struct {
+	Method       string `json:"method"`
+	Path         string `json:"path"`
+	ClientAddr   string `json:"client_addr,omitempty"`
+	UserId       string `json:"user_id,omitempty"`
+	AuthTokenId  string `json:"auth_token_id,omitempty"`
+	ScopeId      string `json:"scope_id,omitempty"`
+	ResourceId   string `json:"resource_id,omitempty"`
+	ResourceType string `json:"resource_type,omitempty"`
+	Action       string `json:"action,omitempty"`
+	Authorized   bool   `json:"authorized"`
+	StatusCode   int    `json:"status_code"`
+}
+
+// Session is the data of a SessionType event.  ClientAddr is sensitive and
+// is HMAC'd when the eventer has an HMAC key.
+type Session struct {
+	Stage        Stage  `json:"stage"`
+	SessionId    string `json:"session_id"`
+	ConnectionId string `json:"connection_id,omitempty"`
+	UserId       string `json:"user_id,omitempty"`
+	TargetId     string `json:"target_id,omitempty"`
+	ScopeId      string `json:"scope_id,omitempty"`
+	WorkerId     string `json:"worker_id,omitempty"`
+	ClientAddr   string `json:"client_addr,omitempty"`
+	Endpoint     string `json:"endpoint,omitempty"`
+	BytesUp      uint64 `json:"bytes_up,omitempty"`
+	BytesDown    uint64 `json:"bytes_down,omitempty"`
+}
+
+// redactor is implemented by event data with sensitive fields.  redact
+// returns a copy of the data with each sensitive field replaced by the result
+// of calling fn with it.
+type redactor interface {
+	redact(fn func(string) string) interface{}
+}
+
+func (a Audit) redact(fn func(string) string) interface{} {
+	a.AuthTokenId = fn(a.AuthTokenId)
+	a.ClientAddr = fn(a.ClientAddr)
+	return a
+}
+
+func (s Session) redact(fn func(string) string) interface{} {
+	s.ClientAddr = fn(s.ClientAddr)
+	return s
+}
+
+// hmacPrefix marks values which have been replaced with their HMAC.
+const hmacPrefix = "hmac-sha256:"
+
+// HmacValue returns the value a sensitive field is replaced with when events
+// are redacted with key.  It can be used to search events for a known value.
+func HmacValue(key []byte, value string) string {
+	if value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return hmacPrefix + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
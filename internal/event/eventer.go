@@ -0,0 +1,131 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/vault/sdk/helper/base62"
+)
+
+// idPrefix is the prefix of event ids.
+const idPrefix = "evt_"
+
+// Config configures an Eventer.
+type Config struct {
+	// Source identifies the server emitting the events, e.g. its hostname.
+	Source string
+	// HmacKey, if set, is used to replace the sensitive fields of events with
+	// their HMAC before they are written.
+	HmacKey []byte
+	// ChainKey, if set, is the key of the HMAC-SHA256 each record written to
+	// the sinks is hashed with to chain it to the next one.  Without it records
+	// are hashed with SHA-256, which anyone able to write to a sink can
+	// recompute.
+	ChainKey []byte
+	Sinks    []SinkConfig
+}
+
+// Eventer writes events to its sinks.  A nil Eventer discards every event, so
+// callers do not need to check whether eventing is configured.
+type Eventer struct {
+	logger  hclog.Logger
+	source  string
+	hmacKey []byte
+	sinks   []*sink
+}
+
+// NewEventer creates an Eventer which writes to the sinks in conf.  Errors
+// writing events are logged to logger.
+func NewEventer(logger hclog.Logger, conf Config) (*Eventer, error) {
+	if len(conf.Sinks) == 0 {
+		return nil, fmt.Errorf("at least one event sink must be configured")
+	}
+	e := &Eventer{
+		logger:  logger,
+		source:  conf.Source,
+		hmacKey: conf.HmacKey,
+	}
+	for _, sc := range conf.Sinks {
+		s, err := newSink(sc, conf.ChainKey)
+		if err != nil {
+			e.Close()
+			return nil, fmt.Errorf("error creating %s event sink: %w", sc.Type, err)
+		}
+		e.sinks = append(e.sinks, s)
+	}
+	return e, nil
+}
+
+// Audit writes an AuditType event.
+func (e *Eventer) Audit(a *Audit) {
+	e.write(AuditType, *a)
+}
+
+// Session writes a SessionType event.
+func (e *Eventer) Session(s *Session) {
+	e.write(SessionType, *s)
+}
+
+func (e *Eventer) write(t Type, data redactor) {
+	if e == nil {
+		return
+	}
+	id, err := base62.Random(10)
+	if err != nil {
+		e.logger.Error("error generating event id", "error", err)
+		return
+	}
+	ev := &Event{
+		Id:        idPrefix + id,
+		Type:      t,
+		CreatedAt: time.Now(),
+		Source:    e.source,
+		Data:      data,
+	}
+	if len(e.hmacKey) > 0 {
+		ev.Data = data.redact(func(v string) string {
+			return HmacValue(e.hmacKey, v)
+		})
+	}
+	for _, s := range e.sinks {
+		if !s.wants(t) {
+			continue
+		}
+		if err := s.write(ev); err != nil {
+			e.logger.Error("error writing event", "sink", s.conf.Type, "event_id", ev.Id, "error", err)
+		}
+	}
+}
+
+// Close closes the sinks of the eventer.
+func (e *Eventer) Close() error {
+	if e == nil {
+		return nil
+	}
+	var mErr *multierror.Error
+	for _, s := range e.sinks {
+		if err := s.close(); err != nil {
+			mErr = multierror.Append(mErr, err)
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+type key int
+
+var eventerKey key
+
+// NewEventerContext returns a context carrying e, for code which is not
+// otherwise handed the eventer such as the API service handlers.
+func NewEventerContext(ctx context.Context, e *Eventer) context.Context {
+	return context.WithValue(ctx, eventerKey, e)
+}
+
+// EventerFromContext returns the eventer carried by ctx, or nil if it has none.
+func EventerFromContext(ctx context.Context) *Eventer {
+	e, _ := ctx.Value(eventerKey).(*Eventer)
+	return e
+}
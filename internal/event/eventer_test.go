@@ -0,0 +1,250 @@
+package event
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDir(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "boundary-event-test")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func readEvents(t *testing.T, path string) []map[string]interface{} {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var ret []map[string]interface{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &rec))
+		ev := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal(rec.Event, &ev))
+		ret = append(ret, ev)
+	}
+	require.NoError(t, scanner.Err())
+	return ret
+}
+
+func TestNewEventer(t *testing.T) {
+	dir := testDir(t)
+	cases := []struct {
+		name    string
+		sinks   []SinkConfig
+		wantErr string
+	}{
+		{
+			name:    "no sinks",
+			wantErr: "at least one event sink",
+		},
+		{
+			name:    "unknown sink type",
+			sinks:   []SinkConfig{{Type: "kafka"}},
+			wantErr: `unknown sink type "kafka"`,
+		},
+		{
+			name:    "file without path",
+			sinks:   []SinkConfig{{Type: FileSink}},
+			wantErr: "file sink requires a path",
+		},
+		{
+			name:    "socket without address",
+			sinks:   []SinkConfig{{Type: SocketSink, Network: "tcp"}},
+			wantErr: "socket sink requires a network and an address",
+		},
+		{
+			name:    "unknown event type",
+			sinks:   []SinkConfig{{Type: StderrSink, EventTypes: []Type{"debug"}}},
+			wantErr: `unknown event type "debug"`,
+		},
+		{
+			name: "valid",
+			sinks: []SinkConfig{
+				{Type: StderrSink},
+				{Type: FileSink, Path: filepath.Join(dir, "events.log"), EventTypes: []Type{AuditType}},
+			},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := NewEventer(hclog.NewNullLogger(), Config{Sinks: tt.sinks})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, e.sinks, len(tt.sinks))
+			assert.NoError(t, e.Close())
+		})
+	}
+}
+
+func TestEventer_FileSink(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	dir := testDir(t)
+	allPath := filepath.Join(dir, "all.log")
+	sessionPath := filepath.Join(dir, "session.log")
+
+	e, err := NewEventer(hclog.NewNullLogger(), Config{
+		Source:   "test",
+		ChainKey: testChainKey,
+		Sinks: []SinkConfig{
+			{Type: FileSink, Path: allPath},
+			{Type: FileSink, Path: sessionPath, EventTypes: []Type{SessionType}},
+		},
+	})
+	require.NoError(err)
+
+	e.Audit(&Audit{
+		Method:      "GET",
+		Path:        "/v1/targets/ttcp_1234567890",
+		UserId:      "u_1234567890",
+		AuthTokenId: "at_1234567890",
+		ScopeId:     "p_1234567890",
+		ResourceId:  "ttcp_1234567890",
+		Action:      "read",
+		Authorized:  true,
+		StatusCode:  200,
+	})
+	e.Session(&Session{
+		Stage:     SessionActivated,
+		SessionId: "s_1234567890",
+	})
+	require.NoError(e.Close())
+
+	all := readEvents(t, allPath)
+	require.Len(all, 2)
+	assert.Equal("audit", all[0]["type"])
+	assert.Equal("test", all[0]["source"])
+	assert.True(strings.HasPrefix(all[0]["id"].(string), idPrefix))
+	assert.Equal("", all[0]["prev_hash"])
+	data := all[0]["data"].(map[string]interface{})
+	assert.Equal("at_1234567890", data["auth_token_id"])
+	assert.Equal("read", data["action"])
+	assert.Equal(float64(200), data["status_code"])
+	assert.Equal("session", all[1]["type"])
+	assert.NotEmpty(all[1]["prev_hash"])
+
+	sessions := readEvents(t, sessionPath)
+	require.Len(sessions, 1)
+	assert.Equal("session", sessions[0]["type"])
+	assert.Equal(all[1]["id"], sessions[0]["id"])
+
+	// Reopening the file continues its chain
+	e, err = NewEventer(hclog.NewNullLogger(), Config{
+		ChainKey: testChainKey,
+		Sinks:    []SinkConfig{{Type: FileSink, Path: allPath}},
+	})
+	require.NoError(err)
+	e.Session(&Session{Stage: SessionCanceled, SessionId: "s_1234567890"})
+	require.NoError(e.Close())
+
+	f, err := os.Open(allPath)
+	require.NoError(err)
+	defer f.Close()
+	count, _, err := VerifyChain(f, testChainKey, "")
+	require.NoError(err)
+	assert.Equal(3, count)
+
+	// A file written with another chain key fails verification
+	_, err = NewEventer(hclog.NewNullLogger(), Config{
+		ChainKey: []byte("other-chain-key"),
+		Sinks:    []SinkConfig{{Type: FileSink, Path: allPath}},
+	})
+	assert.True(errors.Is(err, ErrBrokenChain))
+}
+
+func TestEventer_Redaction(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	path := filepath.Join(testDir(t), "events.log")
+	key := []byte("test-hmac-key")
+
+	e, err := NewEventer(hclog.NewNullLogger(), Config{
+		HmacKey: key,
+		Sinks:   []SinkConfig{{Type: FileSink, Path: path}},
+	})
+	require.NoError(err)
+	e.Audit(&Audit{
+		UserId:      "u_1234567890",
+		AuthTokenId: "at_1234567890",
+		ClientAddr:  "127.0.0.1",
+	})
+	e.Session(&Session{
+		Stage:      ConnectionAuthorized,
+		SessionId:  "s_1234567890",
+		ClientAddr: "127.0.0.1:50000",
+	})
+	require.NoError(e.Close())
+
+	evs := readEvents(t, path)
+	require.Len(evs, 2)
+	audit := evs[0]["data"].(map[string]interface{})
+	assert.Equal("u_1234567890", audit["user_id"])
+	assert.Equal(HmacValue(key, "at_1234567890"), audit["auth_token_id"])
+	assert.Equal(HmacValue(key, "127.0.0.1"), audit["client_addr"])
+	assert.True(strings.HasPrefix(audit["auth_token_id"].(string), hmacPrefix))
+	session := evs[1]["data"].(map[string]interface{})
+	assert.Equal("s_1234567890", session["session_id"])
+	assert.Equal(HmacValue(key, "127.0.0.1:50000"), session["client_addr"])
+}
+
+func TestEventer_SocketSink(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer l.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		received <- line
+	}()
+
+	e, err := NewEventer(hclog.NewNullLogger(), Config{
+		Sinks: []SinkConfig{{Type: SocketSink, Network: "tcp", Address: l.Addr().String()}},
+	})
+	require.NoError(err)
+	e.Session(&Session{Stage: SessionActivated, SessionId: "s_1234567890"})
+	require.NoError(e.Close())
+
+	msg := <-received
+	assert.True(strings.HasPrefix(msg, "<86>1 "))
+	idx := strings.Index(msg, "{")
+	require.True(idx > 0)
+	count, _, err := VerifyChain(strings.NewReader(msg[idx:]), nil, "")
+	require.NoError(err)
+	assert.Equal(1, count)
+	assert.Contains(msg, " boundary - session - ")
+}
+
+func TestEventer_Nil(t *testing.T) {
+	var e *Eventer
+	assert.NotPanics(t, func() {
+		e.Audit(&Audit{})
+		e.Session(&Session{})
+		assert.NoError(t, e.Close())
+	})
+}
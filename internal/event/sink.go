@@ -0,0 +1,172 @@
+package event
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// SinkType is the type of a sink.
+type SinkType string
+
+const (
+	// FileSink appends records to a file.
+	FileSink SinkType = "file"
+	// StderrSink writes records to stderr.
+	StderrSink SinkType = "stderr"
+	// SocketSink sends each record as a syslog message over a unix, unixgram,
+	// tcp or udp socket.
+	SocketSink SinkType = "socket"
+)
+
+// syslogPriority is the priority of the syslog messages sent by socket sinks:
+// the authpriv facility at the info severity.
+const syslogPriority = 10*8 + 6
+
+// SinkConfig configures a sink.
+type SinkConfig struct {
+	Type SinkType
+	// Path is the file written to by a file sink.
+	Path string
+	// Network and Address are the socket a socket sink sends to, e.g. "unix"
+	// and "/dev/log" or "tcp" and "siem.example.com:514".
+	Network string
+	Address string
+	// EventTypes limits the events written to the sink.  If empty, events of
+	// every type are written.
+	EventTypes []Type
+}
+
+// sink writes records for an eventer and keeps the chain of the records it
+// has written.
+type sink struct {
+	mu         sync.Mutex
+	conf       SinkConfig
+	eventTypes map[Type]bool
+	w          io.Writer
+	closer     io.Closer
+	chainKey   []byte
+	prevHash   string
+	hostname   string
+}
+
+func newSink(conf SinkConfig, chainKey []byte) (*sink, error) {
+	s := &sink{conf: conf, chainKey: chainKey}
+	if len(conf.EventTypes) > 0 {
+		s.eventTypes = make(map[Type]bool, len(conf.EventTypes))
+		for _, t := range conf.EventTypes {
+			switch t {
+			case AuditType, SessionType:
+			default:
+				return nil, fmt.Errorf("unknown event type %q", t)
+			}
+			s.eventTypes[t] = true
+		}
+	}
+
+	switch conf.Type {
+	case FileSink:
+		if conf.Path == "" {
+			return nil, fmt.Errorf("file sink requires a path")
+		}
+		f, err := os.OpenFile(conf.Path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("error opening event file: %w", err)
+		}
+		// Continue the chain of the records already in the file so the file
+		// can be verified as a whole
+		if s.prevHash, err = lastHash(f, s.chainKey); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("existing event file %q failed verification: %w", conf.Path, err)
+		}
+		s.w, s.closer = f, f
+
+	case StderrSink:
+		s.w = os.Stderr
+
+	case SocketSink:
+		if conf.Network == "" || conf.Address == "" {
+			return nil, fmt.Errorf("socket sink requires a network and an address")
+		}
+		s.hostname, _ = os.Hostname()
+		if s.hostname == "" {
+			s.hostname = "-"
+		}
+		if err := s.dial(); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", conf.Type)
+	}
+	return s, nil
+}
+
+func (s *sink) dial() error {
+	conn, err := net.DialTimeout(s.conf.Network, s.conf.Address, 10*time.Second)
+	if err != nil {
+		return fmt.Errorf("error connecting to event socket: %w", err)
+	}
+	s.w, s.closer = conn, conn
+	return nil
+}
+
+// wants reports whether events of type t are written to the sink.
+func (s *sink) wants(t Type) bool {
+	return s.eventTypes == nil || s.eventTypes[t]
+}
+
+// write chains ev to the records previously written to the sink and writes
+// it.
+func (s *sink) write(ev *Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, hash, err := newRecord(ev, s.chainKey, s.prevHash)
+	if err != nil {
+		return err
+	}
+	rec = append(rec, '\n')
+	if s.conf.Type == SocketSink {
+		rec = s.frame(ev, rec)
+	}
+
+	if _, err := s.w.Write(rec); err != nil {
+		if s.conf.Type != SocketSink {
+			return fmt.Errorf("error writing event: %w", err)
+		}
+		// The receiving end may have gone away; reconnect once before giving
+		// up on the event
+		s.closer.Close()
+		if err := s.dial(); err != nil {
+			return err
+		}
+		if _, err := s.w.Write(rec); err != nil {
+			return fmt.Errorf("error writing event: %w", err)
+		}
+	}
+	s.prevHash = hash
+	return nil
+}
+
+// frame wraps rec in an RFC 5424 syslog header.
+func (s *sink) frame(ev *Event, rec []byte) []byte {
+	header := fmt.Sprintf("<%d>1 %s %s boundary - %s - ",
+		syslogPriority,
+		ev.CreatedAt.UTC().Format(time.RFC3339Nano),
+		s.hostname,
+		ev.Type)
+	return append([]byte(header), rec...)
+}
+
+func (s *sink) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
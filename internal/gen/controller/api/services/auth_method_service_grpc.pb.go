@@ -45,6 +45,12 @@ type AuthMethodServiceClient interface {
 	DeleteAuthMethod(ctx context.Context, in *DeleteAuthMethodRequest, opts ...grpc.CallOption) (*DeleteAuthMethodResponse, error)
 	// Authenticate validates credentials provided and returns an Auth Token.
 	Authenticate(ctx context.Context, in *AuthenticateRequest, opts ...grpc.CallOption) (*AuthenticateResponse, error)
+	// StartAuthentication begins an interactive authentication flow for Auth
+	// Methods which delegate authentication to an external provider and returns
+	// the URL the user must visit to authenticate.  The provider redirects the
+	// user's browser to the provided redirect URL once done; the code and state
+	// it passes there are then sent to Authenticate as the credentials.
+	StartAuthentication(ctx context.Context, in *StartAuthenticationRequest, opts ...grpc.CallOption) (*StartAuthenticationResponse, error)
 }
 
 type authMethodServiceClient struct {
@@ -109,6 +115,15 @@ func (c *authMethodServiceClient) Authenticate(ctx context.Context, in *Authenti
 	return out, nil
 }
 
+func (c *authMethodServiceClient) StartAuthentication(ctx context.Context, in *StartAuthenticationRequest, opts ...grpc.CallOption) (*StartAuthenticationResponse, error) {
+	out := new(StartAuthenticationResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.AuthMethodService/StartAuthentication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthMethodServiceServer is the server API for AuthMethodService service.
 type AuthMethodServiceServer interface {
 	// GetAuthMethod returns a stored Auth Method if present.  The provided request
@@ -139,6 +154,12 @@ type AuthMethodServiceServer interface {
 	DeleteAuthMethod(context.Context, *DeleteAuthMethodRequest) (*DeleteAuthMethodResponse, error)
 	// Authenticate validates credentials provided and returns an Auth Token.
 	Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error)
+	// StartAuthentication begins an interactive authentication flow for Auth
+	// Methods which delegate authentication to an external provider and returns
+	// the URL the user must visit to authenticate.  The provider redirects the
+	// user's browser to the provided redirect URL once done; the code and state
+	// it passes there are then sent to Authenticate as the credentials.
+	StartAuthentication(context.Context, *StartAuthenticationRequest) (*StartAuthenticationResponse, error)
 }
 
 // UnimplementedAuthMethodServiceServer can be embedded to have forward compatible implementations.
@@ -163,6 +184,9 @@ func (*UnimplementedAuthMethodServiceServer) DeleteAuthMethod(context.Context, *
 func (*UnimplementedAuthMethodServiceServer) Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Authenticate not implemented")
 }
+func (*UnimplementedAuthMethodServiceServer) StartAuthentication(context.Context, *StartAuthenticationRequest) (*StartAuthenticationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartAuthentication not implemented")
+}
 
 func RegisterAuthMethodServiceServer(s *grpc.Server, srv AuthMethodServiceServer) {
 	s.RegisterService(&_AuthMethodService_serviceDesc, srv)
@@ -276,6 +300,24 @@ func _AuthMethodService_Authenticate_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthMethodService_StartAuthentication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartAuthenticationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthMethodServiceServer).StartAuthentication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.AuthMethodService/StartAuthentication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthMethodServiceServer).StartAuthentication(ctx, req.(*StartAuthenticationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AuthMethodService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "controller.api.services.v1.AuthMethodService",
 	HandlerType: (*AuthMethodServiceServer)(nil),
@@ -304,6 +346,10 @@ var _AuthMethodService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Authenticate",
 			Handler:    _AuthMethodService_Authenticate_Handler,
 		},
+		{
+			MethodName: "StartAuthentication",
+			Handler:    _AuthMethodService_StartAuthentication_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/auth_method_service.proto",
@@ -43,6 +43,12 @@ type ScopeServiceClient interface {
 	// DeleteScope remotes a Scope and all child resources from Boundary. If the
 	// provided Scope IDs are malformed or not provided an error is returned.
 	DeleteScope(ctx context.Context, in *DeleteScopeRequest, opts ...grpc.CallOption) (*DeleteScopeResponse, error)
+	// RotateScopeKeys creates a new version of the root key and of each data
+	// key of a Scope. Values encrypted with the previous versions are then
+	// re-encrypted in the background; the returned Scope reports the progress
+	// of the rotation, as does reading the Scope. If the provided Scope ID is
+	// malformed or not provided an error is returned.
+	RotateScopeKeys(ctx context.Context, in *RotateScopeKeysRequest, opts ...grpc.CallOption) (*RotateScopeKeysResponse, error)
 }
 
 type scopeServiceClient struct {
@@ -98,6 +104,15 @@ func (c *scopeServiceClient) DeleteScope(ctx context.Context, in *DeleteScopeReq
 	return out, nil
 }
 
+func (c *scopeServiceClient) RotateScopeKeys(ctx context.Context, in *RotateScopeKeysRequest, opts ...grpc.CallOption) (*RotateScopeKeysResponse, error) {
+	out := new(RotateScopeKeysResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/RotateScopeKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ScopeServiceServer is the server API for ScopeService service.
 type ScopeServiceServer interface {
 	// GetScope returns a stored Scope if present.  The provided request
@@ -126,6 +141,12 @@ type ScopeServiceServer interface {
 	// DeleteScope remotes a Scope and all child resources from Boundary. If the
 	// provided Scope IDs are malformed or not provided an error is returned.
 	DeleteScope(context.Context, *DeleteScopeRequest) (*DeleteScopeResponse, error)
+	// RotateScopeKeys creates a new version of the root key and of each data
+	// key of a Scope. Values encrypted with the previous versions are then
+	// re-encrypted in the background; the returned Scope reports the progress
+	// of the rotation, as does reading the Scope. If the provided Scope ID is
+	// malformed or not provided an error is returned.
+	RotateScopeKeys(context.Context, *RotateScopeKeysRequest) (*RotateScopeKeysResponse, error)
 }
 
 // UnimplementedScopeServiceServer can be embedded to have forward compatible implementations.
@@ -147,6 +168,9 @@ func (*UnimplementedScopeServiceServer) UpdateScope(context.Context, *UpdateScop
 func (*UnimplementedScopeServiceServer) DeleteScope(context.Context, *DeleteScopeRequest) (*DeleteScopeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteScope not implemented")
 }
+func (*UnimplementedScopeServiceServer) RotateScopeKeys(context.Context, *RotateScopeKeysRequest) (*RotateScopeKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateScopeKeys not implemented")
+}
 
 func RegisterScopeServiceServer(s *grpc.Server, srv ScopeServiceServer) {
 	s.RegisterService(&_ScopeService_serviceDesc, srv)
@@ -242,6 +266,24 @@ func _ScopeService_DeleteScope_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ScopeService_RotateScopeKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateScopeKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).RotateScopeKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/RotateScopeKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).RotateScopeKeys(ctx, req.(*RotateScopeKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ScopeService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "controller.api.services.v1.ScopeService",
 	HandlerType: (*ScopeServiceServer)(nil),
@@ -266,6 +308,10 @@ var _ScopeService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteScope",
 			Handler:    _ScopeService_DeleteScope_Handler,
 		},
+		{
+			MethodName: "RotateScopeKeys",
+			Handler:    _ScopeService_RotateScopeKeys_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/scope_service.proto",
@@ -70,6 +70,23 @@ type TargetServiceClient interface {
 	// returned.  An error is returned if a Host Set is attempted to be
 	// removed from the Target when the Target does not have the Host Set.
 	RemoveTargetHostSets(ctx context.Context, in *RemoveTargetHostSetsRequest, opts ...grpc.CallOption) (*RemoveTargetHostSetsResponse, error)
+	// AddTargetCredentialLibraries adds Credential Libraries to this Target. The
+	// provided request must include the Target ID to which the Credential
+	// Libraries will be added. All Credential Libraries added to the provided
+	// Target must be a child of a Credential Store that is a child of the same
+	// scope as this Target. An error is returned if a Credential Library is
+	// attempted to be added to a Target that already has it.
+	AddTargetCredentialLibraries(ctx context.Context, in *AddTargetCredentialLibrariesRequest, opts ...grpc.CallOption) (*AddTargetCredentialLibrariesResponse, error)
+	// SetTargetCredentialLibraries sets the Target's Credential Libraries. Any
+	// existing Credential Libraries on the Target are removed if they are not
+	// included in this request. All Credential Libraries in the request must be
+	// a child of a Credential Store that is in the same scope as the provided
+	// Target.
+	SetTargetCredentialLibraries(ctx context.Context, in *SetTargetCredentialLibrariesRequest, opts ...grpc.CallOption) (*SetTargetCredentialLibrariesResponse, error)
+	// RemoveTargetCredentialLibraries removes the Credential Libraries from the
+	// specified Target. An error is returned if a Credential Library is
+	// attempted to be removed from the Target when the Target does not have it.
+	RemoveTargetCredentialLibraries(ctx context.Context, in *RemoveTargetCredentialLibrariesRequest, opts ...grpc.CallOption) (*RemoveTargetCredentialLibrariesResponse, error)
 }
 
 type targetServiceClient struct {
@@ -161,6 +178,33 @@ func (c *targetServiceClient) RemoveTargetHostSets(ctx context.Context, in *Remo
 	return out, nil
 }
 
+func (c *targetServiceClient) AddTargetCredentialLibraries(ctx context.Context, in *AddTargetCredentialLibrariesRequest, opts ...grpc.CallOption) (*AddTargetCredentialLibrariesResponse, error) {
+	out := new(AddTargetCredentialLibrariesResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.TargetService/AddTargetCredentialLibraries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *targetServiceClient) SetTargetCredentialLibraries(ctx context.Context, in *SetTargetCredentialLibrariesRequest, opts ...grpc.CallOption) (*SetTargetCredentialLibrariesResponse, error) {
+	out := new(SetTargetCredentialLibrariesResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.TargetService/SetTargetCredentialLibraries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *targetServiceClient) RemoveTargetCredentialLibraries(ctx context.Context, in *RemoveTargetCredentialLibrariesRequest, opts ...grpc.CallOption) (*RemoveTargetCredentialLibrariesResponse, error) {
+	out := new(RemoveTargetCredentialLibrariesResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.TargetService/RemoveTargetCredentialLibraries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TargetServiceServer is the server API for TargetService service.
 type TargetServiceServer interface {
 	// GetTarget returns a stored Target if present.  The provided request
@@ -216,6 +260,23 @@ type TargetServiceServer interface {
 	// returned.  An error is returned if a Host Set is attempted to be
 	// removed from the Target when the Target does not have the Host Set.
 	RemoveTargetHostSets(context.Context, *RemoveTargetHostSetsRequest) (*RemoveTargetHostSetsResponse, error)
+	// AddTargetCredentialLibraries adds Credential Libraries to this Target. The
+	// provided request must include the Target ID to which the Credential
+	// Libraries will be added. All Credential Libraries added to the provided
+	// Target must be a child of a Credential Store that is a child of the same
+	// scope as this Target. An error is returned if a Credential Library is
+	// attempted to be added to a Target that already has it.
+	AddTargetCredentialLibraries(context.Context, *AddTargetCredentialLibrariesRequest) (*AddTargetCredentialLibrariesResponse, error)
+	// SetTargetCredentialLibraries sets the Target's Credential Libraries. Any
+	// existing Credential Libraries on the Target are removed if they are not
+	// included in this request. All Credential Libraries in the request must be
+	// a child of a Credential Store that is in the same scope as the provided
+	// Target.
+	SetTargetCredentialLibraries(context.Context, *SetTargetCredentialLibrariesRequest) (*SetTargetCredentialLibrariesResponse, error)
+	// RemoveTargetCredentialLibraries removes the Credential Libraries from the
+	// specified Target. An error is returned if a Credential Library is
+	// attempted to be removed from the Target when the Target does not have it.
+	RemoveTargetCredentialLibraries(context.Context, *RemoveTargetCredentialLibrariesRequest) (*RemoveTargetCredentialLibrariesResponse, error)
 }
 
 // UnimplementedTargetServiceServer can be embedded to have forward compatible implementations.
@@ -249,6 +310,15 @@ func (*UnimplementedTargetServiceServer) SetTargetHostSets(context.Context, *Set
 func (*UnimplementedTargetServiceServer) RemoveTargetHostSets(context.Context, *RemoveTargetHostSetsRequest) (*RemoveTargetHostSetsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveTargetHostSets not implemented")
 }
+func (*UnimplementedTargetServiceServer) AddTargetCredentialLibraries(context.Context, *AddTargetCredentialLibrariesRequest) (*AddTargetCredentialLibrariesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTargetCredentialLibraries not implemented")
+}
+func (*UnimplementedTargetServiceServer) SetTargetCredentialLibraries(context.Context, *SetTargetCredentialLibrariesRequest) (*SetTargetCredentialLibrariesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTargetCredentialLibraries not implemented")
+}
+func (*UnimplementedTargetServiceServer) RemoveTargetCredentialLibraries(context.Context, *RemoveTargetCredentialLibrariesRequest) (*RemoveTargetCredentialLibrariesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTargetCredentialLibraries not implemented")
+}
 
 func RegisterTargetServiceServer(s *grpc.Server, srv TargetServiceServer) {
 	s.RegisterService(&_TargetService_serviceDesc, srv)
@@ -416,6 +486,60 @@ func _TargetService_RemoveTargetHostSets_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TargetService_AddTargetCredentialLibraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTargetCredentialLibrariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TargetServiceServer).AddTargetCredentialLibraries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.TargetService/AddTargetCredentialLibraries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TargetServiceServer).AddTargetCredentialLibraries(ctx, req.(*AddTargetCredentialLibrariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TargetService_SetTargetCredentialLibraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTargetCredentialLibrariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TargetServiceServer).SetTargetCredentialLibraries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.TargetService/SetTargetCredentialLibraries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TargetServiceServer).SetTargetCredentialLibraries(ctx, req.(*SetTargetCredentialLibrariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TargetService_RemoveTargetCredentialLibraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTargetCredentialLibrariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TargetServiceServer).RemoveTargetCredentialLibraries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.TargetService/RemoveTargetCredentialLibraries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TargetServiceServer).RemoveTargetCredentialLibraries(ctx, req.(*RemoveTargetCredentialLibrariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _TargetService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "controller.api.services.v1.TargetService",
 	HandlerType: (*TargetServiceServer)(nil),
@@ -456,6 +580,18 @@ var _TargetService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveTargetHostSets",
 			Handler:    _TargetService_RemoveTargetHostSets_Handler,
 		},
+		{
+			MethodName: "AddTargetCredentialLibraries",
+			Handler:    _TargetService_AddTargetCredentialLibraries_Handler,
+		},
+		{
+			MethodName: "SetTargetCredentialLibraries",
+			Handler:    _TargetService_SetTargetCredentialLibraries_Handler,
+		},
+		{
+			MethodName: "RemoveTargetCredentialLibraries",
+			Handler:    _TargetService_RemoveTargetCredentialLibraries_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/target_service.proto",
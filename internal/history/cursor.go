@@ -0,0 +1,61 @@
+package history
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// A Cursor is a position in the oplog after which changes are read.
+//
+// Entries are not committed in the order of their ids, so a single id cannot
+// mark what has been read. Entries of an aggregate however are: the oplog
+// ticket of the aggregate, which is redeemed by every transaction writing to
+// it, only lets one of two concurrent writers commit. A cursor therefore
+// holds the id of the last entry read for each ticket, along with the version
+// of the ticket when the entries were read, which tells whether there are
+// new entries without reading them.
+type Cursor struct {
+	positions map[string]position
+}
+
+type position struct {
+	TicketVersion uint32 `json:"v"`
+	EntryId       uint32 `json:"e"`
+}
+
+// String returns the encoding of the cursor, which is opaque to its readers.
+func (c *Cursor) String() string {
+	b, err := json.Marshal(c.positions)
+	if err != nil {
+		// A map of strings to structs of numbers is always marshaled.
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ParseCursor returns the cursor of an encoding returned by Cursor.String.
+func ParseCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("parse cursor: %v: %w", err, db.ErrInvalidParameter)
+	}
+	c := &Cursor{}
+	if err := json.Unmarshal(b, &c.positions); err != nil {
+		return nil, fmt.Errorf("parse cursor: %v: %w", err, db.ErrInvalidParameter)
+	}
+	if c.positions == nil {
+		return nil, fmt.Errorf("parse cursor: no positions: %w", db.ErrInvalidParameter)
+	}
+	return c, nil
+}
+
+func (c *Cursor) clone() *Cursor {
+	positions := make(map[string]position, len(c.positions))
+	for name, p := range c.positions {
+		positions[name] = p
+	}
+	return &Cursor{positions: positions}
+}
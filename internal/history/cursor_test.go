@@ -0,0 +1,45 @@
+package history
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	t.Parallel()
+	t.Run("round-trip", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c := &Cursor{positions: map[string]position{
+			"iam_user":  {TicketVersion: 4, EntryId: 42},
+			"iam_group": {TicketVersion: 1},
+		}}
+		got, err := ParseCursor(c.String())
+		require.NoError(err)
+		assert.Equal(c, got)
+
+		clone := got.clone()
+		clone.positions["iam_user"] = position{TicketVersion: 5, EntryId: 43}
+		assert.Equal(position{TicketVersion: 4, EntryId: 42}, got.positions["iam_user"])
+	})
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{name: "empty", in: ""},
+		{name: "not-base64", in: "not base64!"},
+		{name: "not-json", in: "bm90LWpzb24"},
+		{name: "null", in: "bnVsbA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCursor(tt.in)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+			assert.Nil(t, got)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+// Package history provides read access to the oplog, the log of the changes
+// made to the resources of Boundary.
+//
+// Every oplog entry is encrypted with the oplog key of a scope. The
+// repository lists the entries of scopes, decrypts them with the keys of the
+// scopes and unmarshals their messages with the types registered by the
+// packages writing them (see oplog.RegisterTypes).
+//
+// Changes
+//
+// Entries can also be read as a feed of changes: ListChanges returns the
+// entries committed after a Cursor, along with the cursor after them, which
+// can be encoded and handed out to resume reading later. The cursor relies on
+// the oplog tickets of the aggregates to order the entries of each of them;
+// see Cursor.
+//
+// Replay
+//
+// Replay replays entries into tables named after the tables of their
+// messages with a suffix, reconstructing the resources as they were at a
+// point in time. Replayed entries are not redacted.
+//
+// Secrets
+//
+// Messages may contain the plain text of encrypted values, such as the
+// secrets of auth methods or the salts of passwords. Unless the
+// WithUnredactedData option is used, the values of fields which are
+// encrypted when stored and of bytes fields are cleared from the messages
+// returned by the repository.
+package history
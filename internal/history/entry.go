@@ -0,0 +1,108 @@
+package history
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// Entry is a decrypted oplog entry.
+type Entry struct {
+	// Id of the entry. Entries are numbered in the order they are written.
+	Id uint32
+	// CreateTime is when the entry was written.
+	CreateTime time.Time
+	// AggregateName is the name of the table of the changed resource.
+	AggregateName string
+	// ScopeId is the id of the scope whose oplog key encrypted the entry.
+	ScopeId string
+	// Metadata of the entry, such as the id and type of the changed
+	// resource.
+	Metadata oplog.Metadata
+	// Messages are the changes made to the database, in order.
+	Messages []*Message
+}
+
+// Message is a change recorded in an oplog entry.
+type Message struct {
+	// TypeName is the name of the table of the message.
+	TypeName string
+	// OpType is the operation of the change.
+	OpType oplog.OpType
+	// FieldMaskPaths are the fields set by an update.
+	FieldMaskPaths []string
+	// SetToNullPaths are the fields set to null by an update.
+	SetToNullPaths []string
+	// Value is the message, which is one of the types registered with
+	// oplog.RegisterTypes.
+	Value proto.Message
+}
+
+// ResourcePublicId returns the public id of the changed resource, if the
+// entry records it.
+func (e *Entry) ResourcePublicId() string {
+	return firstValue(e.Metadata, "resource-public-id")
+}
+
+// ResourceType returns the type of the changed resource, if the entry
+// records it.
+func (e *Entry) ResourceType() string {
+	return firstValue(e.Metadata, "resource-type")
+}
+
+// hasOpType returns true if a message of the entry is one of the operation
+// types.
+func (e *Entry) hasOpType(opTypes []oplog.OpType) bool {
+	for _, m := range e.Messages {
+		for _, t := range opTypes {
+			if m.OpType == t {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func firstValue(md oplog.Metadata, key string) string {
+	if v := md[key]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// redact clears the fields of the message which hold secrets: the fields
+// encrypted when stored, in plain text or cipher text, and bytes fields,
+// which hold salts and derived keys. Embedded structs, such as the store
+// message of a domain type, are redacted too.
+func redact(m proto.Message) {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return
+	}
+	redactStruct(v.Elem())
+}
+
+func redactStruct(v reflect.Value) {
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	typ := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		f, sf := v.Field(i), typ.Field(i)
+		if !f.CanSet() {
+			continue
+		}
+		switch {
+		case sf.Anonymous && f.Kind() == reflect.Ptr:
+			if !f.IsNil() {
+				redactStruct(f.Elem())
+			}
+		case sf.Tag.Get("wrapping") != "":
+			f.Set(reflect.Zero(f.Type()))
+		case f.Kind() == reflect.Slice && f.Type().Elem().Kind() == reflect.Uint8:
+			f.Set(reflect.Zero(f.Type()))
+		}
+	}
+}
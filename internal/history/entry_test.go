@@ -0,0 +1,65 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	oidcstore "github.com/hashicorp/boundary/internal/auth/oidc/store"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	pwstore "github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_redact(t *testing.T) {
+	t.Parallel()
+	t.Run("argon2-credential", func(t *testing.T) {
+		assert := assert.New(t)
+		cred := &password.Argon2Credential{Argon2Credential: &pwstore.Argon2Credential{
+			PrivateId:         "arg2cred_1234567890",
+			PasswordAccountId: "apwa_1234567890",
+			CtSalt:            []byte("ct-salt"),
+			Salt:              []byte("salt"),
+			DerivedKey:        []byte("derived-key"),
+			KeyId:             "kdkv_1234567890",
+		}}
+		redact(cred)
+		assert.Equal("arg2cred_1234567890", cred.PrivateId)
+		assert.Equal("apwa_1234567890", cred.PasswordAccountId)
+		assert.Equal("kdkv_1234567890", cred.KeyId)
+		assert.Nil(cred.CtSalt)
+		assert.Nil(cred.Salt)
+		assert.Nil(cred.DerivedKey)
+	})
+	t.Run("oidc-auth-method", func(t *testing.T) {
+		assert := assert.New(t)
+		am := &oidc.AuthMethod{AuthMethod: &oidcstore.AuthMethod{
+			PublicId:       "amoidc_1234567890",
+			ClientId:       "client-id",
+			ClientSecret:   "client-secret",
+			CtClientSecret: []byte("ct-client-secret"),
+		}}
+		redact(am)
+		assert.Equal("amoidc_1234567890", am.PublicId)
+		assert.Equal("client-id", am.ClientId)
+		assert.Empty(am.ClientSecret)
+		assert.Nil(am.CtClientSecret)
+	})
+	t.Run("nil", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			redact(nil)
+			redact(&password.Argon2Credential{})
+		})
+	})
+}
+
+func TestEntry_hasOpType(t *testing.T) {
+	t.Parallel()
+	e := &Entry{Messages: []*Message{
+		{OpType: oplog.OpType_OP_TYPE_CREATE},
+		{OpType: oplog.OpType_OP_TYPE_DELETE},
+	}}
+	assert.True(t, e.hasOpType([]oplog.OpType{oplog.OpType_OP_TYPE_DELETE}))
+	assert.True(t, e.hasOpType([]oplog.OpType{oplog.OpType_OP_TYPE_UPDATE, oplog.OpType_OP_TYPE_CREATE}))
+	assert.False(t, e.hasOpType([]oplog.OpType{oplog.OpType_OP_TYPE_UPDATE}))
+}
@@ -0,0 +1,101 @@
+package history
+
+import (
+	"time"
+
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withLimit            int
+	withStartPageAfterId uint32
+	withAggregateNames   []string
+	withStartTime        time.Time
+	withEndTime          time.Time
+	withOpTypes          []oplog.OpType
+	withUnredactedData   bool
+	withEndCursor        *Cursor
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithLimit provides an option to provide a limit.  Intentionally allowing
+// negative integers.   If WithLimit < 0, then unlimited results are returned.
+// If WithLimit == 0, then default limits are used for results.
+func WithLimit(limit int) Option {
+	return func(o *options) {
+		o.withLimit = limit
+	}
+}
+
+// WithStartPageAfterId provides an option to only list entries whose ids come
+// after the given id. Used to retrieve the next page of results.
+func WithStartPageAfterId(id uint32) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
+
+// WithAggregateNames provides an option to only list entries with one of the
+// aggregate names, which are the names of the tables of the changed resources.
+func WithAggregateNames(names ...string) Option {
+	return func(o *options) {
+		o.withAggregateNames = names
+	}
+}
+
+// WithStartTime provides an option to only list entries created at or after
+// the time.
+func WithStartTime(t time.Time) Option {
+	return func(o *options) {
+		o.withStartTime = t
+	}
+}
+
+// WithEndTime provides an option to only list entries created before the
+// time.
+func WithEndTime(t time.Time) Option {
+	return func(o *options) {
+		o.withEndTime = t
+	}
+}
+
+// WithOpTypes provides an option to only list entries with at least one
+// message of one of the operation types.
+func WithOpTypes(opTypes ...oplog.OpType) Option {
+	return func(o *options) {
+		o.withOpTypes = opTypes
+	}
+}
+
+// WithUnredactedData provides an option to return messages with the values
+// of their secret fields. It must never be used for entries returned to
+// users.
+func WithUnredactedData() Option {
+	return func(o *options) {
+		o.withUnredactedData = true
+	}
+}
+
+// WithEndCursor provides an option to only replay entries up to and
+// including the last entry read of their aggregate at the cursor.
+func WithEndCursor(c *Cursor) Option {
+	return func(o *options) {
+		o.withEndCursor = c
+	}
+}
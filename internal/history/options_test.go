@@ -0,0 +1,73 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_GetOpts provides unit tests for GetOpts and all the options
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithLimit", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithLimit(5))
+		testOpts := getDefaultOptions()
+		testOpts.withLimit = 5
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithStartPageAfterId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithStartPageAfterId(42))
+		testOpts := getDefaultOptions()
+		testOpts.withStartPageAfterId = 42
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithAggregateNames", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithAggregateNames("iam_user", "iam_group"))
+		testOpts := getDefaultOptions()
+		testOpts.withAggregateNames = []string{"iam_user", "iam_group"}
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithStartTime", func(t *testing.T) {
+		assert := assert.New(t)
+		now := time.Now()
+		opts := getOpts(WithStartTime(now))
+		testOpts := getDefaultOptions()
+		testOpts.withStartTime = now
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithEndTime", func(t *testing.T) {
+		assert := assert.New(t)
+		now := time.Now()
+		opts := getOpts(WithEndTime(now))
+		testOpts := getDefaultOptions()
+		testOpts.withEndTime = now
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithOpTypes", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithOpTypes(oplog.OpType_OP_TYPE_CREATE))
+		testOpts := getDefaultOptions()
+		testOpts.withOpTypes = []oplog.OpType{oplog.OpType_OP_TYPE_CREATE}
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithUnredactedData", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithUnredactedData())
+		testOpts := getDefaultOptions()
+		testOpts.withUnredactedData = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithEndCursor", func(t *testing.T) {
+		assert := assert.New(t)
+		c := &Cursor{positions: map[string]position{"iam_user": {TicketVersion: 2, EntryId: 7}}}
+		opts := getOpts(WithEndCursor(c))
+		testOpts := getDefaultOptions()
+		testOpts.withEndCursor = c
+		assert.Equal(opts, testOpts)
+	})
+}
@@ -0,0 +1,34 @@
+package history
+
+const (
+	// oplogKeyVersionScopesQuery returns the ids of the versions of the
+	// oplog keys of the scopes, along with the scope of each.
+	oplogKeyVersionScopesQuery = `
+select v.private_id, r.scope_id
+  from kms_oplog_key_version v
+  join kms_oplog_key k
+    on k.private_id = v.oplog_key_id
+  join kms_root_key r
+    on r.private_id = k.root_key_id
+ where r.scope_id in (?);
+`
+
+	// ticketPositionsQuery returns the name and version of each oplog ticket,
+	// along with the id of the last entry written with it, which is 0 when
+	// none was.
+	ticketPositionsQuery = `
+select t.name, t.version, coalesce(e.id, 0)
+  from oplog_ticket t
+  left join lateral (
+    select max(id) as id
+      from oplog_entry
+     where aggregate_name = t.name
+  ) e on true;
+`
+
+	// ticketVersionsQuery returns the name and version of each oplog ticket.
+	ticketVersionsQuery = `
+select name, version
+  from oplog_ticket;
+`
+)
@@ -0,0 +1,228 @@
+package history
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// A Repository reads the oplog. It is not safe to use a repository
+// concurrently.
+type Repository struct {
+	reader db.Reader
+	kms    *kms.Kms
+	// defaultLimit provides a default for limiting the number of results returned from the repo
+	defaultLimit int
+}
+
+// NewRepository creates a new Repository. Supports the options: WithLimit
+// which sets a default limit on results returned by repo operations.
+func NewRepository(r db.Reader, kms *kms.Kms, opt ...Option) (*Repository, error) {
+	switch {
+	case r == nil:
+		return nil, fmt.Errorf("db.Reader: history: %w", db.ErrInvalidParameter)
+	case kms == nil:
+		return nil, fmt.Errorf("kms: history: %w", db.ErrInvalidParameter)
+	}
+
+	opts := getOpts(opt...)
+	if opts.withLimit == 0 {
+		// zero signals the boundary defaults should be used.
+		opts.withLimit = db.DefaultLimit
+	}
+	return &Repository{
+		reader:       r,
+		kms:          kms,
+		defaultLimit: opts.withLimit,
+	}, nil
+}
+
+// ListEntries returns the decrypted oplog entries of the scopes, which are
+// the entries encrypted with the oplog keys of the scopes, ordered by id.
+// Supports the options WithLimit, WithStartPageAfterId, WithAggregateNames,
+// WithStartTime, WithEndTime, WithOpTypes and WithUnredactedData.
+func (r *Repository) ListEntries(ctx context.Context, scopeIds []string, opt ...Option) ([]*Entry, error) {
+	if len(scopeIds) == 0 {
+		return nil, fmt.Errorf("list entries: missing scope ids: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+
+	keyScopes, err := r.oplogKeyVersionScopes(ctx, scopeIds)
+	if err != nil {
+		return nil, fmt.Errorf("list entries: %w", err)
+	}
+	if len(keyScopes) == 0 {
+		return nil, nil
+	}
+
+	where, args := "id > ?", []interface{}{opts.withStartPageAfterId}
+	if len(opts.withAggregateNames) > 0 {
+		where, args = where+" and aggregate_name in (?)", append(args, opts.withAggregateNames)
+	}
+	if !opts.withStartTime.IsZero() {
+		where, args = where+" and create_time >= ?", append(args, opts.withStartTime)
+	}
+	if !opts.withEndTime.IsZero() {
+		where, args = where+" and create_time < ?", append(args, opts.withEndTime)
+	}
+
+	// The scope of an entry is only known once its key id is read from its
+	// data, so entries are read in batches until enough of them are found.
+	batchSize := limit
+	if batchSize < 0 {
+		batchSize = db.DefaultLimit
+	}
+	afterId := opts.withStartPageAfterId
+	var entries []*Entry
+	for {
+		args[0] = afterId
+		var batch []*store.Entry
+		if err := r.reader.SearchWhere(ctx, &batch, where, args, db.WithLimit(batchSize), db.WithOrder("id")); err != nil {
+			return nil, fmt.Errorf("list entries: %w", err)
+		}
+		for _, se := range batch {
+			afterId = se.GetId()
+			keyId, err := entryKeyId(se)
+			if err != nil {
+				return nil, fmt.Errorf("list entries: %w", err)
+			}
+			scopeId, ok := keyScopes[keyId]
+			if !ok {
+				continue
+			}
+			e, err := r.decryptEntry(ctx, se, scopeId, keyId, opts)
+			if err != nil {
+				return nil, fmt.Errorf("list entries: %w", err)
+			}
+			if len(opts.withOpTypes) > 0 && !e.hasOpType(opts.withOpTypes) {
+				continue
+			}
+			entries = append(entries, e)
+			if limit > 0 && len(entries) == limit {
+				break
+			}
+		}
+		if len(batch) < batchSize || (limit > 0 && len(entries) == limit) {
+			break
+		}
+	}
+	entries, err = r.withMetadata(ctx, entries)
+	if err != nil {
+		return nil, fmt.Errorf("list entries: %w", err)
+	}
+	return entries, nil
+}
+
+// oplogKeyVersionScopes returns the scopes of the versions of the oplog keys
+// of the scopes, by key version id.
+func (r *Repository) oplogKeyVersionScopes(ctx context.Context, scopeIds []string) (map[string]string, error) {
+	rows, err := r.reader.Query(ctx, oplogKeyVersionScopesQuery, []interface{}{scopeIds})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read oplog key versions: %w", err)
+	}
+	defer rows.Close()
+	keyScopes := make(map[string]string)
+	for rows.Next() {
+		var keyVersionId, scopeId string
+		if err := rows.Scan(&keyVersionId, &scopeId); err != nil {
+			return nil, fmt.Errorf("unable to read oplog key versions: %w", err)
+		}
+		keyScopes[keyVersionId] = scopeId
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read oplog key versions: %w", err)
+	}
+	return keyScopes, nil
+}
+
+// entryKeyId returns the id of the key version which encrypted the entry.
+func entryKeyId(se *store.Entry) (string, error) {
+	var blob wrapping.EncryptedBlobInfo
+	if err := proto.Unmarshal(se.GetCtData(), &blob); err != nil {
+		return "", fmt.Errorf("unable to unmarshal oplog entry %d: %w", se.GetId(), err)
+	}
+	return blob.GetKeyInfo().GetKeyID(), nil
+}
+
+// decryptedEntry returns the oplog entry of the stored entry with its data
+// decrypted with the key version of the scope.
+func (r *Repository) decryptedEntry(ctx context.Context, se *store.Entry, scopeId, keyId string) (*oplog.Entry, error) {
+	wrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog, kms.WithKeyId(keyId))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get oplog wrapper for scope %s: %w", scopeId, err)
+	}
+	oe := &oplog.Entry{Entry: se, Cipherer: wrapper}
+	if err := oe.DecryptData(ctx); err != nil {
+		return nil, fmt.Errorf("oplog entry %d: %w", se.GetId(), err)
+	}
+	return oe, nil
+}
+
+func (r *Repository) decryptEntry(ctx context.Context, se *store.Entry, scopeId, keyId string, opts options) (*Entry, error) {
+	oe, err := r.decryptedEntry(ctx, se, scopeId, keyId)
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := oe.UnmarshalData(oplog.RegisteredTypes())
+	if err != nil {
+		return nil, fmt.Errorf("oplog entry %d: %w", se.GetId(), err)
+	}
+
+	e := &Entry{
+		Id:            se.GetId(),
+		AggregateName: se.GetAggregateName(),
+		ScopeId:       scopeId,
+		Messages:      make([]*Message, 0, len(msgs)),
+	}
+	if ts := se.GetCreateTime().GetTimestamp(); ts != nil {
+		e.CreateTime = ts.AsTime()
+	}
+	for _, m := range msgs {
+		if !opts.withUnredactedData {
+			redact(m.Message)
+		}
+		e.Messages = append(e.Messages, &Message{
+			TypeName:       m.TypeName,
+			OpType:         m.OpType,
+			FieldMaskPaths: m.FieldMaskPaths,
+			SetToNullPaths: m.SetToNullPaths,
+			Value:          m.Message,
+		})
+	}
+	return e, nil
+}
+
+// withMetadata reads the metadata of the entries.
+func (r *Repository) withMetadata(ctx context.Context, entries []*Entry) ([]*Entry, error) {
+	if len(entries) == 0 {
+		return entries, nil
+	}
+	byId := make(map[uint32]*Entry, len(entries))
+	ids := make([]uint32, 0, len(entries))
+	for _, e := range entries {
+		e.Metadata = oplog.Metadata{}
+		byId[e.Id] = e
+		ids = append(ids, e.Id)
+	}
+	var md []*store.Metadata
+	if err := r.reader.SearchWhere(ctx, &md, "entry_id in (?)", []interface{}{ids}, db.WithLimit(-1), db.WithOrder("id")); err != nil {
+		return nil, fmt.Errorf("unable to read metadata: %w", err)
+	}
+	for _, m := range md {
+		if e, ok := byId[m.GetEntryId()]; ok {
+			e.Metadata[m.GetKey()] = append(e.Metadata[m.GetKey()], m.GetValue())
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,169 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	"github.com/hashicorp/boundary/sdk/strutil"
+)
+
+// CurrentCursor returns a cursor at the end of the oplog, after which only
+// the changes committed from then on are read.
+func (r *Repository) CurrentCursor(ctx context.Context) (*Cursor, error) {
+	rows, err := r.reader.Query(ctx, ticketPositionsQuery, nil)
+	if err != nil {
+		return nil, fmt.Errorf("current cursor: %w", err)
+	}
+	defer rows.Close()
+	c := &Cursor{positions: make(map[string]position)}
+	for rows.Next() {
+		var name string
+		var p position
+		if err := rows.Scan(&name, &p.TicketVersion, &p.EntryId); err != nil {
+			return nil, fmt.Errorf("current cursor: %w", err)
+		}
+		c.positions[name] = p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("current cursor: %w", err)
+	}
+	return c, nil
+}
+
+// ListChanges returns the decrypted oplog entries of the scopes which were
+// committed after the cursor, ordered by id, and the cursor after them. The
+// returned cursor is also advanced past the entries of other scopes and of
+// other operations, so they are not read again. When nothing was committed
+// since the cursor, only the versions of the oplog tickets are read, which
+// makes polling for changes cheap. Supports the options WithLimit,
+// WithAggregateNames, WithOpTypes and WithUnredactedData.
+func (r *Repository) ListChanges(ctx context.Context, scopeIds []string, c *Cursor, opt ...Option) ([]*Entry, *Cursor, error) {
+	if len(scopeIds) == 0 {
+		return nil, nil, fmt.Errorf("list changes: missing scope ids: %w", db.ErrInvalidParameter)
+	}
+	if c == nil {
+		return nil, nil, fmt.Errorf("list changes: missing cursor: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+
+	versions, err := r.ticketVersions(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list changes: %w", err)
+	}
+	var changed []string
+	for name, version := range versions {
+		if len(opts.withAggregateNames) > 0 && !strutil.StrListContains(opts.withAggregateNames, name) {
+			continue
+		}
+		if p, ok := c.positions[name]; !ok || p.TicketVersion != version {
+			changed = append(changed, name)
+		}
+	}
+	next := c.clone()
+	if len(changed) == 0 {
+		return nil, next, nil
+	}
+
+	keyScopes, err := r.oplogKeyVersionScopes(ctx, scopeIds)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list changes: %w", err)
+	}
+
+	batchSize := limit
+	if batchSize < 0 {
+		batchSize = db.DefaultLimit
+	}
+	var entries []*Entry
+	var full bool
+	for !full {
+		// The position of an aggregate the cursor does not know is the zero
+		// value: its ticket was created after the cursor, so all of its
+		// entries are new.
+		clauses := make([]string, 0, len(changed))
+		args := make([]interface{}, 0, 2*len(changed))
+		for _, name := range changed {
+			clauses = append(clauses, "(aggregate_name = ? and id > ?)")
+			args = append(args, name, next.positions[name].EntryId)
+		}
+		var batch []*store.Entry
+		if err := r.reader.SearchWhere(ctx, &batch, strings.Join(clauses, " or "), args, db.WithLimit(batchSize), db.WithOrder("id")); err != nil {
+			return nil, nil, fmt.Errorf("list changes: %w", err)
+		}
+		for _, se := range batch {
+			p := next.positions[se.GetAggregateName()]
+			p.EntryId = se.GetId()
+			next.positions[se.GetAggregateName()] = p
+
+			keyId, err := entryKeyId(se)
+			if err != nil {
+				return nil, nil, fmt.Errorf("list changes: %w", err)
+			}
+			scopeId, ok := keyScopes[keyId]
+			if !ok {
+				continue
+			}
+			e, err := r.decryptEntry(ctx, se, scopeId, keyId, opts)
+			if err != nil {
+				return nil, nil, fmt.Errorf("list changes: %w", err)
+			}
+			if len(opts.withOpTypes) > 0 && !e.hasOpType(opts.withOpTypes) {
+				continue
+			}
+			entries = append(entries, e)
+			if limit > 0 && len(entries) == limit {
+				full = true
+				break
+			}
+		}
+		if len(batch) < batchSize {
+			break
+		}
+	}
+
+	// Unless the limit stopped the reading, every entry committed before the
+	// versions were read has been read, so the cursor is now at those
+	// versions. Otherwise they are left as they were, so the rest of the
+	// entries are read from the positions the next time.
+	if !full {
+		for _, name := range changed {
+			p := next.positions[name]
+			p.TicketVersion = versions[name]
+			next.positions[name] = p
+		}
+	}
+	entries, err = r.withMetadata(ctx, entries)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list changes: %w", err)
+	}
+	return entries, next, nil
+}
+
+// ticketVersions returns the versions of the oplog tickets, by name.
+func (r *Repository) ticketVersions(ctx context.Context) (map[string]uint32, error) {
+	rows, err := r.reader.Query(ctx, ticketVersionsQuery, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read oplog tickets: %w", err)
+	}
+	defer rows.Close()
+	versions := make(map[string]uint32)
+	for rows.Next() {
+		var name string
+		var version uint32
+		if err := rows.Scan(&name, &version); err != nil {
+			return nil, fmt.Errorf("unable to read oplog tickets: %w", err)
+		}
+		versions[name] = version
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read oplog tickets: %w", err)
+	}
+	return versions, nil
+}
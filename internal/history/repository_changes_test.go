@@ -0,0 +1,117 @@
+package history_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/history"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ListChanges(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	otherOrg, _ := iam.TestScopes(t, iamRepo)
+
+	repo, err := history.NewRepository(rw, kmsCache)
+	require.NoError(t, err)
+
+	t.Run("missing-args", func(t *testing.T) {
+		assert := assert.New(t)
+		c, err := repo.CurrentCursor(ctx)
+		require.NoError(t, err)
+		_, _, err = repo.ListChanges(ctx, nil, c)
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+		_, _, err = repo.ListChanges(ctx, []string{org.PublicId}, nil)
+		assert.True(errors.Is(err, db.ErrInvalidParameter))
+	})
+	t.Run("no-changes", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c, err := repo.CurrentCursor(ctx)
+		require.NoError(err)
+		got, next, err := repo.ListChanges(ctx, []string{org.PublicId}, c)
+		require.NoError(err)
+		assert.Empty(got)
+		assert.Equal(c.String(), next.String())
+	})
+	t.Run("changes", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c, err := repo.CurrentCursor(ctx)
+		require.NoError(err)
+
+		user := iam.TestUser(t, iamRepo, org.PublicId)
+		iam.TestUser(t, iamRepo, otherOrg.PublicId)
+		group, err := iam.NewGroup(org.PublicId)
+		require.NoError(err)
+		group, err = iamRepo.CreateGroup(ctx, group)
+		require.NoError(err)
+		user.Name = "updated"
+		_, _, _, err = iamRepo.UpdateUser(ctx, user, user.Version, []string{"Name"})
+		require.NoError(err)
+
+		got, next, err := repo.ListChanges(ctx, []string{org.PublicId}, c)
+		require.NoError(err)
+		require.Len(got, 3)
+		assert.Equal(user.PublicId, got[0].ResourcePublicId())
+		assert.Equal(group.PublicId, got[1].ResourcePublicId())
+		assert.Equal(user.PublicId, got[2].ResourcePublicId())
+		assert.Equal(oplog.OpType_OP_TYPE_UPDATE, got[2].Messages[0].OpType)
+
+		// The cursor moved past every change, including the one of the
+		// other scope.
+		got, _, err = repo.ListChanges(ctx, []string{org.PublicId, otherOrg.PublicId}, next)
+		require.NoError(err)
+		assert.Empty(got)
+
+		got, _, err = repo.ListChanges(ctx, []string{org.PublicId}, c, history.WithAggregateNames("iam_group"))
+		require.NoError(err)
+		require.Len(got, 1)
+		assert.Equal(group.PublicId, got[0].ResourcePublicId())
+
+		got, _, err = repo.ListChanges(ctx, []string{org.PublicId}, c, history.WithOpTypes(oplog.OpType_OP_TYPE_UPDATE))
+		require.NoError(err)
+		require.Len(got, 1)
+		assert.Equal(user.PublicId, got[0].ResourcePublicId())
+	})
+	t.Run("limit", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c, err := repo.CurrentCursor(ctx)
+		require.NoError(err)
+		var want []string
+		for i := 0; i < 3; i++ {
+			want = append(want, iam.TestUser(t, iamRepo, org.PublicId).PublicId)
+		}
+
+		var got []string
+		for i := 0; i < 4; i++ {
+			var entries []*history.Entry
+			entries, c, err = repo.ListChanges(ctx, []string{org.PublicId}, c, history.WithLimit(1))
+			require.NoError(err)
+			for _, e := range entries {
+				got = append(got, e.ResourcePublicId())
+			}
+		}
+		assert.Equal(want, got)
+
+		// The cursor survives its encoding.
+		c, err = history.ParseCursor(c.String())
+		require.NoError(err)
+		user := iam.TestUser(t, iamRepo, org.PublicId)
+		entries, _, err := repo.ListChanges(ctx, []string{org.PublicId}, c)
+		require.NoError(err)
+		require.Len(entries, 1)
+		assert.Equal(user.PublicId, entries[0].ResourcePublicId())
+	})
+}
@@ -0,0 +1,141 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	"github.com/hashicorp/boundary/sdk/strutil"
+)
+
+// tableSuffixRegexp matches the table suffixes Replay accepts, which keep the
+// names of the replay tables plain identifiers.
+var tableSuffixRegexp = regexp.MustCompile(`^_[a-z0-9_]+$`)
+
+// ReplayResult reports what a replay did.
+type ReplayResult struct {
+	// Replayed is the number of entries replayed.
+	Replayed int
+	// Skipped is the number of entries which were not replayed since they
+	// were encrypted with a key of another scope, which includes the scopes
+	// deleted since, whose keys were deleted with them.
+	Skipped int
+	// Tables are the names of the tables written to.
+	Tables []string
+}
+
+// Replay replays the oplog entries of the scopes with w, in the order of
+// their ids, which is the order in which the entries of each aggregate were
+// committed. The messages of an entry are written to the table named after
+// the table of the message followed by the table suffix, which is created
+// like the table when missing; the suffix must start with an underscore and
+// only contain lowercase letters, digits and underscores. The replay tables
+// have the columns, defaults, checks and indexes of their tables, but no
+// foreign keys. Unlike listed entries, replayed entries are not redacted.
+// Supports the options WithAggregateNames, WithEndTime and WithEndCursor.
+func (r *Repository) Replay(ctx context.Context, w oplog.Writer, scopeIds []string, tableSuffix string, opt ...Option) (*ReplayResult, error) {
+	switch {
+	case w == nil:
+		return nil, fmt.Errorf("replay: missing writer: %w", db.ErrInvalidParameter)
+	case len(scopeIds) == 0:
+		return nil, fmt.Errorf("replay: missing scope ids: %w", db.ErrInvalidParameter)
+	case !tableSuffixRegexp.MatchString(tableSuffix):
+		return nil, fmt.Errorf("replay: invalid table suffix %q: %w", tableSuffix, db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	tw := &tableRecordingWriter{Writer: w}
+
+	keyScopes, err := r.oplogKeyVersionScopes(ctx, scopeIds)
+	if err != nil {
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+
+	where, args := "id > ?", []interface{}{uint32(0)}
+	if len(opts.withAggregateNames) > 0 {
+		where, args = where+" and aggregate_name in (?)", append(args, opts.withAggregateNames)
+	}
+	if !opts.withEndTime.IsZero() {
+		where, args = where+" and create_time < ?", append(args, opts.withEndTime)
+	}
+	if opts.withEndCursor != nil {
+		// The entries of aggregates the cursor does not know were all
+		// written after it.
+		clauses := []string{"false"}
+		for name, p := range opts.withEndCursor.positions {
+			clauses = append(clauses, "(aggregate_name = ? and id <= ?)")
+			args = append(args, name, p.EntryId)
+		}
+		where += " and (" + strings.Join(clauses, " or ") + ")"
+	}
+
+	res := &ReplayResult{}
+	types := oplog.RegisteredTypes()
+	batchSize := r.defaultLimit
+	if batchSize < 0 {
+		batchSize = db.DefaultLimit
+	}
+	for {
+		var batch []*store.Entry
+		if err := r.reader.SearchWhere(ctx, &batch, where, args, db.WithLimit(batchSize), db.WithOrder("id")); err != nil {
+			return nil, fmt.Errorf("replay: %w", err)
+		}
+		for _, se := range batch {
+			args[0] = se.GetId()
+			keyId, err := entryKeyId(se)
+			if err != nil {
+				return nil, fmt.Errorf("replay: %w", err)
+			}
+			scopeId, ok := keyScopes[keyId]
+			if !ok {
+				res.Skipped++
+				continue
+			}
+			oe, err := r.decryptedEntry(ctx, se, scopeId, keyId)
+			if err != nil {
+				return nil, fmt.Errorf("replay: %w", err)
+			}
+			if err := oe.Replay(ctx, tw, types, tableSuffix); err != nil {
+				return nil, fmt.Errorf("replay: oplog entry %d: %w", se.GetId(), err)
+			}
+			res.Replayed++
+		}
+		if len(batch) < batchSize {
+			res.Tables = tw.tables
+			return res, nil
+		}
+	}
+}
+
+// tableRecordingWriter is an oplog.Writer which records the tables it writes
+// to, in the order of their first write.
+type tableRecordingWriter struct {
+	oplog.Writer
+	tables []string
+}
+
+func (w *tableRecordingWriter) record(i interface{}) {
+	rm, ok := i.(oplog.ReplayableMessage)
+	if !ok || strutil.StrListContains(w.tables, rm.TableName()) {
+		return
+	}
+	w.tables = append(w.tables, rm.TableName())
+}
+
+func (w *tableRecordingWriter) Create(i interface{}) error {
+	w.record(i)
+	return w.Writer.Create(i)
+}
+
+func (w *tableRecordingWriter) Update(i interface{}, fieldMaskPaths, setToNullPaths []string) error {
+	w.record(i)
+	return w.Writer.Update(i, fieldMaskPaths, setToNullPaths)
+}
+
+func (w *tableRecordingWriter) Delete(i interface{}) error {
+	w.record(i)
+	return w.Writer.Delete(i)
+}
@@ -0,0 +1,117 @@
+package history_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/history"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Replay(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	kept := iam.TestUser(t, iamRepo, org.PublicId)
+	kept.Name = "kept"
+	_, _, _, err := iamRepo.UpdateUser(ctx, kept, kept.Version, []string{"Name"})
+	require.NoError(t, err)
+	deleted := iam.TestUser(t, iamRepo, org.PublicId)
+
+	repo, err := history.NewRepository(rw, kmsCache)
+	require.NoError(t, err)
+	cursor, err := repo.CurrentCursor(ctx)
+	require.NoError(t, err)
+	// Entries are created within the transaction writing them, so the time
+	// is moved past the last of them.
+	time.Sleep(10 * time.Millisecond)
+	end := time.Now()
+	time.Sleep(10 * time.Millisecond)
+
+	_, err = iamRepo.DeleteUser(ctx, deleted.PublicId)
+	require.NoError(t, err)
+
+	userIds := func(t *testing.T, table string) []string {
+		t.Helper()
+		rows, err := rw.Query(ctx, "select public_id from "+table+" order by public_id", nil)
+		require.NoError(t, err)
+		defer rows.Close()
+		var ids []string
+		for rows.Next() {
+			var id string
+			require.NoError(t, rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		require.NoError(t, rows.Err())
+		return ids
+	}
+	want := []string{kept.PublicId, deleted.PublicId}
+	if want[0] > want[1] {
+		want[0], want[1] = want[1], want[0]
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		w := &oplog.GormWriter{Tx: conn}
+		_, err := repo.Replay(ctx, nil, []string{org.PublicId}, "_replay")
+		assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+		_, err = repo.Replay(ctx, w, nil, "_replay")
+		assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+		for _, suffix := range []string{"", "replay", "_Replay", "_replay; drop table iam_user"} {
+			_, err = repo.Replay(ctx, w, []string{org.PublicId}, suffix)
+			assert.True(t, errors.Is(err, db.ErrInvalidParameter), suffix)
+		}
+	})
+	t.Run("end-time", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		res, err := repo.Replay(ctx, &oplog.GormWriter{Tx: conn}, []string{org.PublicId}, "_replay_time", history.WithAggregateNames("iam_user"), history.WithEndTime(end))
+		require.NoError(err)
+		assert.Equal(3, res.Replayed)
+		assert.Equal(0, res.Skipped)
+		assert.Equal([]string{"iam_user_replay_time"}, res.Tables)
+		assert.Equal(want, userIds(t, "iam_user_replay_time"))
+
+		var name string
+		rows, err := rw.Query(ctx, "select name from iam_user_replay_time where public_id = ?", []interface{}{kept.PublicId})
+		require.NoError(err)
+		defer rows.Close()
+		require.True(rows.Next())
+		require.NoError(rows.Scan(&name))
+		assert.Equal("kept", name)
+	})
+	t.Run("end-cursor", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		res, err := repo.Replay(ctx, &oplog.GormWriter{Tx: conn}, []string{org.PublicId}, "_replay_cursor", history.WithAggregateNames("iam_user"), history.WithEndCursor(cursor))
+		require.NoError(err)
+		assert.Equal(3, res.Replayed)
+		assert.Equal(want, userIds(t, "iam_user_replay_cursor"))
+	})
+	t.Run("all", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		res, err := repo.Replay(ctx, &oplog.GormWriter{Tx: conn}, []string{org.PublicId}, "_replay_all", history.WithAggregateNames("iam_user"))
+		require.NoError(err)
+		assert.Equal(4, res.Replayed)
+		assert.Equal([]string{kept.PublicId}, userIds(t, "iam_user_replay_all"))
+	})
+	t.Run("other-scope", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		otherOrg, _ := iam.TestScopes(t, iamRepo)
+		res, err := repo.Replay(ctx, &oplog.GormWriter{Tx: conn}, []string{otherOrg.PublicId}, "_replay_other", history.WithAggregateNames("iam_user"))
+		require.NoError(err)
+		assert.Equal(0, res.Replayed)
+		assert.Equal(4, res.Skipped)
+		assert.Empty(res.Tables)
+	})
+}
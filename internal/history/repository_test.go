@@ -0,0 +1,148 @@
+package history_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/history"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRepository(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, db.TestWrapper(t))
+
+	tests := []struct {
+		name    string
+		r       db.Reader
+		kms     *kms.Kms
+		wantErr bool
+	}{
+		{name: "valid", r: rw, kms: kmsCache},
+		{name: "nil-reader", kms: kmsCache, wantErr: true},
+		{name: "nil-kms", r: rw, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := history.NewRepository(tt.r, tt.kms)
+			if tt.wantErr {
+				require.Error(err)
+				assert.True(errors.Is(err, db.ErrInvalidParameter))
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.NotNil(got)
+		})
+	}
+}
+
+func TestRepository_ListEntries(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	start := time.Now().Add(-time.Minute)
+	user := iam.TestUser(t, iamRepo, org.PublicId)
+	user.Name = "updated"
+	_, _, _, err := iamRepo.UpdateUser(ctx, user, user.Version, []string{"Name"})
+	require.NoError(t, err)
+
+	pwRepo, err := password.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	authMethod := password.TestAuthMethods(t, conn, org.PublicId, 1)[0]
+	acct, err := password.NewAccount(authMethod.PublicId, password.WithLoginName("kazmierczak"))
+	require.NoError(t, err)
+	_, err = pwRepo.CreateAccount(ctx, org.PublicId, acct, password.WithPassword("a-long-enough-password"))
+	require.NoError(t, err)
+
+	repo, err := history.NewRepository(rw, kmsCache)
+	require.NoError(t, err)
+
+	t.Run("missing-scope", func(t *testing.T) {
+		_, err := repo.ListEntries(ctx, nil)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, db.ErrInvalidParameter))
+	})
+	t.Run("user", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListEntries(ctx, []string{org.PublicId}, history.WithAggregateNames("iam_user"), history.WithStartTime(start))
+		require.NoError(err)
+		require.Len(got, 2)
+		assert.Less(got[0].Id, got[1].Id)
+		for _, e := range got {
+			assert.Equal(org.PublicId, e.ScopeId)
+			assert.Equal(user.PublicId, e.ResourcePublicId())
+			require.Len(e.Messages, 1)
+			assert.Equal("iam_user", e.Messages[0].TypeName)
+			assert.IsType(&iam.User{}, e.Messages[0].Value)
+		}
+		assert.Equal(oplog.OpType_OP_TYPE_CREATE, got[0].Messages[0].OpType)
+		assert.Equal(oplog.OpType_OP_TYPE_UPDATE, got[1].Messages[0].OpType)
+		assert.Contains(got[1].Messages[0].FieldMaskPaths, "Name")
+		assert.Equal("updated", got[1].Messages[0].Value.(*iam.User).GetName())
+	})
+	t.Run("op-types", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListEntries(ctx, []string{org.PublicId}, history.WithAggregateNames("iam_user"), history.WithOpTypes(oplog.OpType_OP_TYPE_UPDATE))
+		require.NoError(err)
+		require.Len(got, 1)
+		assert.Equal(oplog.OpType_OP_TYPE_UPDATE, got[0].Messages[0].OpType)
+	})
+	t.Run("pages", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		all, err := repo.ListEntries(ctx, []string{org.PublicId})
+		require.NoError(err)
+		require.NotEmpty(all)
+		var paged []*history.Entry
+		var afterId uint32
+		for {
+			page, err := repo.ListEntries(ctx, []string{org.PublicId}, history.WithLimit(1), history.WithStartPageAfterId(afterId))
+			require.NoError(err)
+			if len(page) == 0 {
+				break
+			}
+			require.Len(page, 1)
+			paged = append(paged, page...)
+			afterId = page[0].Id
+		}
+		assert.Equal(len(all), len(paged))
+	})
+	t.Run("end-time", func(t *testing.T) {
+		got, err := repo.ListEntries(ctx, []string{org.PublicId}, history.WithEndTime(start))
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+	t.Run("redacted", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListEntries(ctx, []string{org.PublicId}, history.WithAggregateNames("auth_password_argon2_cred"))
+		require.NoError(err)
+		require.Len(got, 1)
+		cred := got[0].Messages[0].Value.(*password.Argon2Credential)
+		assert.NotEmpty(cred.PrivateId)
+		assert.Empty(cred.Salt)
+		assert.Empty(cred.CtSalt)
+		assert.Empty(cred.DerivedKey)
+
+		got, err = repo.ListEntries(ctx, []string{org.PublicId}, history.WithAggregateNames("auth_password_argon2_cred"), history.WithUnredactedData())
+		require.NoError(err)
+		require.Len(got, 1)
+		assert.NotEmpty(got[0].Messages[0].Value.(*password.Argon2Credential).DerivedKey)
+	})
+}
@@ -0,0 +1,57 @@
+// Package inventory provides a host, a host catalog, and a host set
+// suitable for hosts which are maintained in an external inventory.
+//
+// A host catalog has a source which is the location of an inventory
+// document: an absolute file path, or a file, http, or https URL. Sources
+// are read with the controllers' access to the file system and network, so
+// they must be allowed by the SourcePolicy of the repository, which the
+// controllers configure. The document is JSON or YAML and lists the hosts of
+// the catalog:
+//
+//  hosts:
+//  - id: web-1
+//    address: 10.0.0.11
+//    name: web-1
+//    description: first web server
+//    labels:
+//      role: web
+//      env: prod
+//
+// The controllers periodically fetch the document of each catalog and sync
+// the hosts of the catalog with it. Hosts are identified by their id in the
+// document, which must be unique within the document. A host which is no
+// longer in the document is deleted. If the document can not be fetched or
+// is not valid, the hosts of the catalog are left unchanged and the error is
+// recorded with the catalog.
+//
+// Hosts can not be created, updated, or deleted directly. A host set has
+// match labels instead of an explicit list of hosts. The members of a host
+// set are the hosts of its catalog which have all of the match labels with
+// the same values. A host set with no match labels contains every host of
+// its catalog. The members are updated whenever the catalog is synced or
+// the match labels of the set are changed.
+//
+// Repository
+//
+// A repository provides methods for creating, updating, retrieving, and
+// deleting host catalogs and host sets, retrieving hosts, and syncing host
+// catalogs with their inventory. A new repository should be created for
+// each transaction. For example:
+//
+//  var wrapper wrapping.Wrapper
+//  ... init wrapper...
+//
+//  // db implements both the reader and writer interfaces.
+//  db, _ := db.Open(db.Postgres, url)
+//
+//  var repo *inventory.Repository
+//  policy := inventory.SourcePolicy{AllowedHosts: []string{"inventory.example.com"}}
+//
+//  repo, _ = inventory.NewRepository(db, db, wrapper, inventory.WithSourcePolicy(policy))
+//  catalog, _ := repo.LookupCatalog(ctx, catalogId)
+//
+//  catalog.Source = "https://inventory.example.com/hosts.json"
+//
+//  repo, _ = inventory.NewRepository(db, db, wrapper, inventory.WithSourcePolicy(policy))
+//  catalog, _ := repo.UpdateCatalog(ctx, catalog, catalog.Version, []string{"Source"})
+package inventory
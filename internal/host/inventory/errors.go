@@ -0,0 +1,17 @@
+package inventory
+
+import "errors"
+
+var (
+	// ErrInvalidSource results from attempting to perform an operation
+	// that sets the source of a host catalog to an invalid value.
+	ErrInvalidSource = errors.New("invalid source")
+
+	// ErrInvalidInventory results from an inventory document which can not
+	// be parsed or which contains invalid hosts.
+	ErrInvalidInventory = errors.New("invalid inventory")
+
+	// ErrInvalidLabels results from attempting to perform an operation
+	// that sets labels to an invalid value.
+	ErrInvalidLabels = errors.New("invalid labels")
+)
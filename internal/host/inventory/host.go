@@ -0,0 +1,55 @@
+package inventory
+
+import (
+	"github.com/hashicorp/boundary/internal/host/inventory/store"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	MinHostAddressLength = 3
+	MaxHostAddressLength = 255
+)
+
+// A Host is a host synced from the inventory of its catalog. Hosts are
+// only created, updated, and deleted by syncing the catalog.
+type Host struct {
+	*store.Host
+	tableName string `gorm:"-"`
+}
+
+// TableName returns the table name for the host.
+func (h *Host) TableName() string {
+	if h.tableName != "" {
+		return h.tableName
+	}
+	return "inventory_host"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (h *Host) SetTableName(n string) {
+	h.tableName = n
+}
+
+// LabelMap returns the labels of the host. Labels which can not be decoded
+// are returned as no labels.
+func (h *Host) LabelMap() map[string]string {
+	labels, err := decodeLabels(h.GetLabels())
+	if err != nil {
+		return map[string]string{}
+	}
+	return labels
+}
+
+func allocHost() *Host {
+	return &Host{
+		Host: &store.Host{},
+	}
+}
+
+func (h *Host) clone() *Host {
+	cp := proto.Clone(h.Host)
+	return &Host{
+		Host: cp.(*store.Host),
+	}
+}
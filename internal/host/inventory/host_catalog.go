@@ -0,0 +1,112 @@
+package inventory
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/host/inventory/store"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultSyncIntervalSeconds is the number of seconds between syncs of a
+// host catalog if one is not provided.
+const DefaultSyncIntervalSeconds = 300
+
+// A HostCatalog contains hosts and host sets synced from the inventory
+// document at its source. It is owned by a scope.
+type HostCatalog struct {
+	*store.HostCatalog
+	tableName string `gorm:"-"`
+}
+
+// NewHostCatalog creates a new in memory HostCatalog assigned to scopeId
+// with hosts synced from source. Name, description, and sync interval
+// seconds are the only valid options. All other options are ignored.
+func NewHostCatalog(scopeId, source string, opt ...Option) (*HostCatalog, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("new: inventory host catalog: no scope id: %w", db.ErrInvalidParameter)
+	}
+	if source == "" {
+		return nil, fmt.Errorf("new: inventory host catalog: no source: %w", db.ErrInvalidParameter)
+	}
+
+	opts := getOpts(opt...)
+	hc := &HostCatalog{
+		HostCatalog: &store.HostCatalog{
+			ScopeId:             scopeId,
+			Source:              source,
+			SyncIntervalSeconds: opts.withSyncIntervalSeconds,
+			Name:                opts.withName,
+			Description:         opts.withDescription,
+		},
+	}
+	return hc, nil
+}
+
+func (c *HostCatalog) clone() *HostCatalog {
+	cp := proto.Clone(c.HostCatalog)
+	return &HostCatalog{
+		HostCatalog: cp.(*store.HostCatalog),
+	}
+}
+
+// TableName returns the table name for the host catalog.
+func (c *HostCatalog) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return "inventory_host_catalog"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (c *HostCatalog) SetTableName(n string) {
+	c.tableName = n
+}
+
+func allocCatalog() *HostCatalog {
+	fresh := &HostCatalog{
+		HostCatalog: &store.HostCatalog{},
+	}
+	return fresh
+}
+
+func newCatalogMetadata(c *HostCatalog, op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{c.GetPublicId()},
+		"resource-type":      []string{"inventory host catalog"},
+		"op-type":            []string{op.String()},
+	}
+	if c.ScopeId != "" {
+		metadata["scope-id"] = []string{c.ScopeId}
+	}
+	return metadata
+}
+
+// A HostCatalogSync is the status of the most recent sync of a host
+// catalog with its inventory.
+type HostCatalogSync struct {
+	*store.HostCatalogSync
+	tableName string `gorm:"-"`
+}
+
+// TableName returns the table name for the host catalog sync.
+func (s *HostCatalogSync) TableName() string {
+	if s.tableName != "" {
+		return s.tableName
+	}
+	return "inventory_host_catalog_sync"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (s *HostCatalogSync) SetTableName(n string) {
+	s.tableName = n
+}
+
+func allocCatalogSync() *HostCatalogSync {
+	return &HostCatalogSync{
+		HostCatalogSync: &store.HostCatalogSync{},
+	}
+}
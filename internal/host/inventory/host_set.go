@@ -0,0 +1,100 @@
+package inventory
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/host/inventory/store"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// A HostSet is the collection of hosts from the set's catalog which have
+// the match labels of the set.
+type HostSet struct {
+	*store.HostSet
+	tableName string `gorm:"-"`
+}
+
+// NewHostSet creates a new in memory HostSet assigned to catalogId.
+// Name, description, and match labels are the only valid options. All
+// other options are ignored.
+func NewHostSet(catalogId string, opt ...Option) (*HostSet, error) {
+	if catalogId == "" {
+		return nil, fmt.Errorf("new: inventory host set: no catalog id: %w", db.ErrInvalidParameter)
+	}
+
+	opts := getOpts(opt...)
+	labels, err := encodeLabels(opts.withMatchLabels)
+	if err != nil {
+		return nil, fmt.Errorf("new: inventory host set: match labels: %w", err)
+	}
+	set := &HostSet{
+		HostSet: &store.HostSet{
+			CatalogId:   catalogId,
+			Name:        opts.withName,
+			Description: opts.withDescription,
+			MatchLabels: labels,
+		},
+	}
+	return set, nil
+}
+
+// SetMatchLabels sets the match labels of the set.
+func (s *HostSet) SetMatchLabels(labels map[string]string) error {
+	l, err := encodeLabels(labels)
+	if err != nil {
+		return fmt.Errorf("inventory host set: match labels: %w", err)
+	}
+	s.MatchLabels = l
+	return nil
+}
+
+// MatchLabelMap returns the match labels of the set. Labels which can not
+// be decoded are returned as no labels.
+func (s *HostSet) MatchLabelMap() map[string]string {
+	labels, err := decodeLabels(s.GetMatchLabels())
+	if err != nil {
+		return map[string]string{}
+	}
+	return labels
+}
+
+// TableName returns the table name for the host set.
+func (s *HostSet) TableName() string {
+	if s.tableName != "" {
+		return s.tableName
+	}
+	return "inventory_host_set"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (s *HostSet) SetTableName(n string) {
+	s.tableName = n
+}
+
+func allocHostSet() *HostSet {
+	return &HostSet{
+		HostSet: &store.HostSet{},
+	}
+}
+
+func (s *HostSet) clone() *HostSet {
+	cp := proto.Clone(s.HostSet)
+	return &HostSet{
+		HostSet: cp.(*store.HostSet),
+	}
+}
+
+func (s *HostSet) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{s.PublicId},
+		"resource-type":      []string{"inventory-host-set"},
+		"op-type":            []string{op.String()},
+	}
+	if s.CatalogId != "" {
+		metadata["catalog-id"] = []string{s.CatalogId}
+	}
+	return metadata
+}
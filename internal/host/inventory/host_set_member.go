@@ -0,0 +1,34 @@
+package inventory
+
+import (
+	"github.com/hashicorp/boundary/internal/host/inventory/store"
+)
+
+// A HostSetMember represents the membership of a host in a host set.
+type HostSetMember struct {
+	*store.HostSetMember
+	tableName string `gorm:"-"`
+}
+
+func newHostSetMember(setId, hostId string) *HostSetMember {
+	return &HostSetMember{
+		HostSetMember: &store.HostSetMember{
+			SetId:  setId,
+			HostId: hostId,
+		},
+	}
+}
+
+// TableName returns the table name for the host set member.
+func (m *HostSetMember) TableName() string {
+	if m.tableName != "" {
+		return m.tableName
+	}
+	return "inventory_host_set_member"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (m *HostSetMember) SetTableName(n string) {
+	m.tableName = n
+}
@@ -0,0 +1,176 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+)
+
+const (
+	// fetchTimeout bounds fetching an inventory document from an http or
+	// https source.
+	fetchTimeout = 30 * time.Second
+
+	// maxInventorySize is the largest inventory document, in bytes, which
+	// will be read from a source.
+	maxInventorySize = 10 << 20
+
+	// maxRedirects is the most redirects followed when fetching an inventory
+	// document from an http or https source.
+	maxRedirects = 10
+)
+
+// An inventoryHost is a host listed in an inventory document.
+type inventoryHost struct {
+	Id          string            `json:"id"`
+	Address     string            `json:"address"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Labels      map[string]string `json:"labels"`
+}
+
+type inventoryDocument struct {
+	Hosts []*inventoryHost `json:"hosts"`
+}
+
+// ValidateSource returns an error wrapping ErrInvalidSource if source is
+// not an absolute file path or a file, http, or https URL.
+func ValidateSource(source string) error {
+	_, err := parseSource(source)
+	return err
+}
+
+// parseSource returns the URL for source. An absolute file path is
+// returned as a file URL.
+func parseSource(source string) (*url.URL, error) {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return nil, fmt.Errorf("empty source: %w", ErrInvalidSource)
+	}
+	if filepath.IsAbs(source) {
+		return &url.URL{Scheme: "file", Path: source}, nil
+	}
+	u, err := url.Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidSource)
+	}
+	switch u.Scheme {
+	case "http", "https":
+		if u.Host == "" {
+			return nil, fmt.Errorf("%q has no host: %w", source, ErrInvalidSource)
+		}
+	case "file":
+		if u.Host != "" || !filepath.IsAbs(u.Path) {
+			return nil, fmt.Errorf("%q is not an absolute file path: %w", source, ErrInvalidSource)
+		}
+	default:
+		return nil, fmt.Errorf("%q must be an absolute file path or a file, http, or https url: %w", source, ErrInvalidSource)
+	}
+	return u, nil
+}
+
+// fetchInventory reads the inventory document at source, which must be
+// allowed by p. The target of a symbolic link, and the location of a
+// redirect, must also be allowed by p.
+func fetchInventory(ctx context.Context, p SourcePolicy, source string) ([]byte, error) {
+	u, err := parseSource(source)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.check(u); err != nil {
+		return nil, err
+	}
+	var r io.Reader
+	switch u.Scheme {
+	case "file":
+		path, err := filepath.EvalSymlinks(u.Path)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.check(&url.URL{Scheme: "file", Path: path}); err != nil {
+			return nil, err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	default:
+		ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		client := &http.Client{
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= maxRedirects {
+					return fmt.Errorf("stopped after %d redirects", maxRedirects)
+				}
+				return p.check(req.URL)
+			},
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %s", resp.Status)
+		}
+		r = resp.Body
+	}
+	b, err := ioutil.ReadAll(io.LimitReader(r, maxInventorySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > maxInventorySize {
+		return nil, fmt.Errorf("inventory is larger than %d bytes", maxInventorySize)
+	}
+	return b, nil
+}
+
+// parseInventory returns the hosts of the JSON or YAML inventory document
+// b. An error wrapping ErrInvalidInventory is returned if the document can
+// not be parsed or any of its hosts are invalid.
+func parseInventory(b []byte) ([]*inventoryHost, error) {
+	var doc inventoryDocument
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidInventory)
+	}
+	seen := make(map[string]bool, len(doc.Hosts))
+	for i, h := range doc.Hosts {
+		if h == nil {
+			return nil, fmt.Errorf("host %d: empty host: %w", i, ErrInvalidInventory)
+		}
+		h.Id = strings.TrimSpace(h.Id)
+		h.Address = strings.TrimSpace(h.Address)
+		switch {
+		case h.Id == "":
+			return nil, fmt.Errorf("host %d: no id: %w", i, ErrInvalidInventory)
+		case seen[h.Id]:
+			return nil, fmt.Errorf("host %q: duplicate id: %w", h.Id, ErrInvalidInventory)
+		case len(h.Address) < MinHostAddressLength || len(h.Address) > MaxHostAddressLength:
+			return nil, fmt.Errorf("host %q: address must be between %d and %d characters: %w", h.Id, MinHostAddressLength, MaxHostAddressLength, ErrInvalidInventory)
+		}
+		if _, _, err := net.SplitHostPort(h.Address); err == nil {
+			return nil, fmt.Errorf("host %q: address must not contain a port: %w", h.Id, ErrInvalidInventory)
+		}
+		if _, err := encodeLabels(h.Labels); err != nil {
+			return nil, fmt.Errorf("host %q: %v: %w", h.Id, err, ErrInvalidInventory)
+		}
+		seen[h.Id] = true
+	}
+	return doc.Hosts, nil
+}
@@ -0,0 +1,216 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSource(t *testing.T) {
+	t.Parallel()
+	var tests = []struct {
+		source  string
+		wantErr bool
+	}{
+		{source: "/etc/boundary/hosts.yaml"},
+		{source: "file:///etc/boundary/hosts.yaml"},
+		{source: "http://inventory.example.com/hosts.json"},
+		{source: "https://inventory.example.com:8443/hosts?env=prod"},
+		{source: "", wantErr: true},
+		{source: "   ", wantErr: true},
+		{source: "hosts.yaml", wantErr: true},
+		{source: "file://relative/hosts.yaml", wantErr: true},
+		{source: "https:///hosts.json", wantErr: true},
+		{source: "ftp://inventory.example.com/hosts.json", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.source, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateSource(tt.source)
+			if tt.wantErr {
+				assert.Truef(t, errors.Is(err, ErrInvalidSource), "want err: %q got: %q", ErrInvalidSource, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParseInventory(t *testing.T) {
+	t.Parallel()
+	var tests = []struct {
+		name    string
+		doc     string
+		want    []*inventoryHost
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			doc:  "",
+		},
+		{
+			name: "json",
+			doc:  `{"hosts": [{"id": "web-1", "address": "10.0.0.11", "labels": {"role": "web"}}, {"id": "db-1", "address": "db-1.example.com", "name": "db", "description": "primary"}]}`,
+			want: []*inventoryHost{
+				{Id: "web-1", Address: "10.0.0.11", Labels: map[string]string{"role": "web"}},
+				{Id: "db-1", Address: "db-1.example.com", Name: "db", Description: "primary"},
+			},
+		},
+		{
+			name: "yaml",
+			doc: `
+hosts:
+- id: web-1
+  address: " 10.0.0.11 "
+  labels:
+    role: web
+    env: prod
+`,
+			want: []*inventoryHost{
+				{Id: "web-1", Address: "10.0.0.11", Labels: map[string]string{"role": "web", "env": "prod"}},
+			},
+		},
+		{
+			name:    "not-a-document",
+			doc:     "hosts: [",
+			wantErr: true,
+		},
+		{
+			name:    "no-id",
+			doc:     `{"hosts": [{"address": "10.0.0.11"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "duplicate-id",
+			doc:     `{"hosts": [{"id": "a", "address": "10.0.0.11"}, {"id": "a", "address": "10.0.0.12"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "short-address",
+			doc:     `{"hosts": [{"id": "a", "address": "ab"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "address-with-port",
+			doc:     `{"hosts": [{"id": "a", "address": "10.0.0.11:22"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "empty-label-key",
+			doc:     `{"hosts": [{"id": "a", "address": "10.0.0.11", "labels": {"": "x"}}]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert := assert.New(t)
+			got, err := parseInventory([]byte(tt.doc))
+			if tt.wantErr {
+				assert.Truef(errors.Is(err, ErrInvalidInventory), "want err: %q got: %q", ErrInvalidInventory, err)
+				assert.Nil(got)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestFetchInventory(t *testing.T) {
+	t.Parallel()
+	const doc = `{"hosts": [{"id": "web-1", "address": "10.0.0.11"}]}`
+	ctx := context.Background()
+
+	t.Run("file", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir, err := ioutil.TempDir("", "boundary-inventory-test")
+		require.NoError(err)
+		defer os.RemoveAll(dir)
+		allowed := filepath.Join(dir, "allowed")
+		require.NoError(os.Mkdir(allowed, 0700))
+		path := filepath.Join(allowed, "hosts.json")
+		require.NoError(ioutil.WriteFile(path, []byte(doc), 0600))
+		other := filepath.Join(dir, "other.json")
+		require.NoError(ioutil.WriteFile(other, []byte(doc), 0600))
+		p := SourcePolicy{AllowedPaths: []string{allowed}}
+
+		got, err := fetchInventory(ctx, p, path)
+		require.NoError(err)
+		assert.Equal(doc, string(got))
+
+		got, err = fetchInventory(ctx, p, "file://"+path)
+		require.NoError(err)
+		assert.Equal(doc, string(got))
+
+		_, err = fetchInventory(ctx, p, filepath.Join(allowed, "missing.json"))
+		assert.Error(err)
+
+		_, err = fetchInventory(ctx, p, other)
+		assert.Truef(errors.Is(err, ErrInvalidSource), "want err: %q got: %q", ErrInvalidSource, err)
+
+		_, err = fetchInventory(ctx, p, filepath.Join(allowed, "..", "other.json"))
+		assert.Truef(errors.Is(err, ErrInvalidSource), "want err: %q got: %q", ErrInvalidSource, err)
+
+		// A link in an allowed directory to a file outside of it is not
+		// followed.
+		link := filepath.Join(allowed, "link.json")
+		require.NoError(os.Symlink(other, link))
+		_, err = fetchInventory(ctx, p, link)
+		assert.Truef(errors.Is(err, ErrInvalidSource), "want err: %q got: %q", ErrInvalidSource, err)
+
+		_, err = fetchInventory(ctx, SourcePolicy{}, path)
+		assert.Truef(errors.Is(err, ErrInvalidSource), "want err: %q got: %q", ErrInvalidSource, err)
+	})
+	t.Run("http", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(doc))
+		}))
+		defer other.Close()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/hosts.json":
+				_, _ = w.Write([]byte(doc))
+			case "/moved.json":
+				http.Redirect(w, r, "/hosts.json", http.StatusFound)
+			case "/elsewhere.json":
+				http.Redirect(w, r, other.URL+"/hosts.json", http.StatusFound)
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer srv.Close()
+		u, err := url.Parse(srv.URL)
+		require.NoError(err)
+		p := SourcePolicy{AllowedHosts: []string{u.Host}}
+
+		got, err := fetchInventory(ctx, p, srv.URL+"/hosts.json")
+		require.NoError(err)
+		assert.Equal(doc, string(got))
+
+		got, err = fetchInventory(ctx, p, srv.URL+"/moved.json")
+		require.NoError(err)
+		assert.Equal(doc, string(got))
+
+		_, err = fetchInventory(ctx, p, srv.URL+"/missing.json")
+		assert.Error(err)
+
+		_, err = fetchInventory(ctx, p, other.URL+"/hosts.json")
+		assert.Truef(errors.Is(err, ErrInvalidSource), "want err: %q got: %q", ErrInvalidSource, err)
+
+		_, err = fetchInventory(ctx, p, srv.URL+"/elsewhere.json")
+		assert.Truef(errors.Is(err, ErrInvalidSource), "want err: %q got: %q", ErrInvalidSource, err)
+	})
+}
@@ -0,0 +1,49 @@
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// encodeLabels returns the JSON encoding of labels as stored in the
+// database. Keys are sorted so equal labels always have the same encoding.
+func encodeLabels(labels map[string]string) (string, error) {
+	if len(labels) == 0 {
+		return "{}", nil
+	}
+	for k := range labels {
+		if strings.TrimSpace(k) == "" {
+			return "", fmt.Errorf("empty label key: %w", ErrInvalidLabels)
+		}
+	}
+	b, err := json.Marshal(labels)
+	if err != nil {
+		return "", fmt.Errorf("%v: %w", err, ErrInvalidLabels)
+	}
+	return string(b), nil
+}
+
+// decodeLabels returns the labels for the JSON encoding s. An empty s
+// decodes to no labels.
+func decodeLabels(s string) (map[string]string, error) {
+	labels := map[string]string{}
+	if s == "" {
+		return labels, nil
+	}
+	if err := json.Unmarshal([]byte(s), &labels); err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidLabels)
+	}
+	return labels, nil
+}
+
+// matchLabels reports whether labels has every key of selector with the
+// same value. An empty selector matches any labels.
+func matchLabels(selector, labels map[string]string) bool {
+	for k, v := range selector {
+		if lv, ok := labels[k]; !ok || lv != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithName", func(t *testing.T) {
+		opts := getOpts(WithName("test"))
+		testOpts := getDefaultOptions()
+		testOpts.withName = "test"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithDescription", func(t *testing.T) {
+		opts := getOpts(WithDescription("test desc"))
+		testOpts := getDefaultOptions()
+		testOpts.withDescription = "test desc"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithLimit", func(t *testing.T) {
+		opts := getOpts(WithLimit(5))
+		testOpts := getDefaultOptions()
+		testOpts.withLimit = 5
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithPublicId", func(t *testing.T) {
+		opts := getOpts(WithPublicId("hcinv_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withPublicId = "hcinv_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithSyncIntervalSeconds", func(t *testing.T) {
+		opts := getOpts(WithSyncIntervalSeconds(60))
+		testOpts := getDefaultOptions()
+		testOpts.withSyncIntervalSeconds = 60
+		assert.Equal(t, opts, testOpts)
+
+		opts = getOpts(WithSyncIntervalSeconds(0))
+		assert.Equal(t, getDefaultOptions(), opts)
+	})
+	t.Run("WithMatchLabels", func(t *testing.T) {
+		opts := getOpts(WithMatchLabels(map[string]string{"role": "web"}))
+		testOpts := getDefaultOptions()
+		testOpts.withMatchLabels = map[string]string{"role": "web"}
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithStartPageAfterId", func(t *testing.T) {
+		opts := getOpts(WithStartPageAfterId("id_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withStartPageAfterId = "id_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithSourcePolicy", func(t *testing.T) {
+		p := SourcePolicy{AllowedHosts: []string{"inventory.example.com"}}
+		opts := getOpts(WithSourcePolicy(p))
+		testOpts := getDefaultOptions()
+		testOpts.withSourcePolicy = p
+		assert.Equal(t, opts, testOpts)
+	})
+}
@@ -0,0 +1,127 @@
+package inventory
+
+// A hostUpdate is a change to an existing host. dbMask and nullFields are
+// the fields of host to update and to set to NULL.
+type hostUpdate struct {
+	host       *Host
+	dbMask     []string
+	nullFields []string
+}
+
+// hostChanges are the changes which sync the hosts of a catalog with its
+// inventory. The hosts in create do not have a PublicId.
+type hostChanges struct {
+	create []*Host
+	update []*hostUpdate
+	delete []*Host
+}
+
+// empty reports whether there are no changes.
+func (c *hostChanges) empty() bool {
+	return len(c.create) == 0 && len(c.update) == 0 && len(c.delete) == 0
+}
+
+// planHosts returns the changes to the current hosts of catalogId which
+// result in the hosts of inv. Hosts are matched by their external id. The
+// hosts of inv must have been validated by parseInventory.
+func planHosts(catalogId string, current []*Host, inv []*inventoryHost) (*hostChanges, error) {
+	existing := make(map[string]*Host, len(current))
+	for _, h := range current {
+		existing[h.ExternalId] = h
+	}
+	changes := &hostChanges{}
+	seen := make(map[string]bool, len(inv))
+	for _, ih := range inv {
+		seen[ih.Id] = true
+		labels, err := encodeLabels(ih.Labels)
+		if err != nil {
+			return nil, err
+		}
+		h, ok := existing[ih.Id]
+		if !ok {
+			nh := allocHost()
+			nh.CatalogId = catalogId
+			nh.ExternalId = ih.Id
+			nh.Address = ih.Address
+			nh.Name = ih.Name
+			nh.Description = ih.Description
+			nh.Labels = labels
+			changes.create = append(changes.create, nh)
+			continue
+		}
+
+		u := &hostUpdate{host: h.clone()}
+		if h.Address != ih.Address {
+			u.host.Address = ih.Address
+			u.dbMask = append(u.dbMask, "Address")
+		}
+		if h.Labels != labels {
+			u.host.Labels = labels
+			u.dbMask = append(u.dbMask, "Labels")
+		}
+		if h.Name != ih.Name {
+			u.host.Name = ih.Name
+			if ih.Name == "" {
+				u.nullFields = append(u.nullFields, "Name")
+			} else {
+				u.dbMask = append(u.dbMask, "Name")
+			}
+		}
+		if h.Description != ih.Description {
+			u.host.Description = ih.Description
+			if ih.Description == "" {
+				u.nullFields = append(u.nullFields, "Description")
+			} else {
+				u.dbMask = append(u.dbMask, "Description")
+			}
+		}
+		if len(u.dbMask) > 0 || len(u.nullFields) > 0 {
+			changes.update = append(changes.update, u)
+		}
+	}
+	for _, h := range current {
+		if !seen[h.ExternalId] {
+			changes.delete = append(changes.delete, h)
+		}
+	}
+	return changes, nil
+}
+
+// planMembers returns the members to add and remove so that the members
+// of each of sets are the hosts which have the match labels of the set.
+// current must contain the current members of sets and hosts must contain
+// every host of the catalog of sets.
+func planMembers(sets []*HostSet, hosts []*Host, current []*HostSetMember) (add, remove []*HostSetMember) {
+	type key struct{ setId, hostId string }
+	want := make(map[key]bool)
+	hostLabels := make([]map[string]string, len(hosts))
+	for i, h := range hosts {
+		hostLabels[i] = h.LabelMap()
+	}
+	for _, s := range sets {
+		selector := s.MatchLabelMap()
+		for i, h := range hosts {
+			if matchLabels(selector, hostLabels[i]) {
+				want[key{s.PublicId, h.PublicId}] = true
+			}
+		}
+	}
+
+	have := make(map[key]bool, len(current))
+	for _, m := range current {
+		k := key{m.SetId, m.HostId}
+		have[k] = true
+		if !want[k] {
+			remove = append(remove, m)
+		}
+	}
+	for _, s := range sets {
+		for _, h := range hosts {
+			k := key{s.PublicId, h.PublicId}
+			if want[k] && !have[k] {
+				add = append(add, newHostSetMember(s.PublicId, h.PublicId))
+			}
+		}
+	}
+	return add, remove
+}
@@ -0,0 +1,107 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/host/inventory/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchLabels(t *testing.T) {
+	t.Parallel()
+	labels := map[string]string{"role": "web", "env": "prod"}
+	assert := assert.New(t)
+	assert.True(matchLabels(nil, labels))
+	assert.True(matchLabels(nil, nil))
+	assert.True(matchLabels(map[string]string{"role": "web"}, labels))
+	assert.True(matchLabels(map[string]string{"role": "web", "env": "prod"}, labels))
+	assert.False(matchLabels(map[string]string{"role": "db"}, labels))
+	assert.False(matchLabels(map[string]string{"role": "web", "region": "us"}, labels))
+	assert.False(matchLabels(map[string]string{"role": ""}, nil))
+}
+
+func testPlanHost(t *testing.T, publicId, externalId, address string, labels map[string]string) *Host {
+	t.Helper()
+	l, err := encodeLabels(labels)
+	require.NoError(t, err)
+	return &Host{
+		Host: &store.Host{
+			PublicId:   publicId,
+			CatalogId:  "hcinv_1234567890",
+			ExternalId: externalId,
+			Address:    address,
+			Labels:     l,
+		},
+	}
+}
+
+func TestPlanHosts(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	current := []*Host{
+		testPlanHost(t, "hinv_1", "same", "10.0.0.1", map[string]string{"role": "web"}),
+		testPlanHost(t, "hinv_2", "moved", "10.0.0.2", nil),
+		testPlanHost(t, "hinv_3", "gone", "10.0.0.3", nil),
+	}
+	current[1].Name = "old name"
+	inv := []*inventoryHost{
+		{Id: "same", Address: "10.0.0.1", Labels: map[string]string{"role": "web"}},
+		{Id: "moved", Address: "10.0.1.2", Labels: map[string]string{"role": "db"}},
+		{Id: "new", Address: "10.0.0.4", Name: "new host"},
+	}
+
+	changes, err := planHosts("hcinv_1234567890", current, inv)
+	require.NoError(err)
+	require.Len(changes.create, 1)
+	assert.Empty(changes.create[0].PublicId)
+	assert.Equal("hcinv_1234567890", changes.create[0].CatalogId)
+	assert.Equal("new", changes.create[0].ExternalId)
+	assert.Equal("10.0.0.4", changes.create[0].Address)
+	assert.Equal("new host", changes.create[0].Name)
+	assert.Equal("{}", changes.create[0].Labels)
+
+	require.Len(changes.update, 1)
+	u := changes.update[0]
+	assert.Equal("hinv_2", u.host.PublicId)
+	assert.Equal("10.0.1.2", u.host.Address)
+	assert.Equal(`{"role":"db"}`, u.host.Labels)
+	assert.Equal([]string{"Address", "Labels"}, u.dbMask)
+	assert.Equal([]string{"Name"}, u.nullFields)
+	assert.Equal("10.0.0.2", current[1].Address, "current hosts must not be changed")
+
+	require.Len(changes.delete, 1)
+	assert.Equal("hinv_3", changes.delete[0].PublicId)
+
+	changes, err = planHosts("hcinv_1234567890", current[:1], inv[:1])
+	require.NoError(err)
+	assert.True(changes.empty())
+}
+
+func TestPlanMembers(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	hosts := []*Host{
+		testPlanHost(t, "hinv_web", "web", "10.0.0.1", map[string]string{"role": "web", "env": "prod"}),
+		testPlanHost(t, "hinv_db", "db", "10.0.0.2", map[string]string{"role": "db", "env": "prod"}),
+	}
+	all, err := NewHostSet("hcinv_1234567890")
+	require.NoError(err)
+	all.PublicId = "hsinv_all"
+	web, err := NewHostSet("hcinv_1234567890", WithMatchLabels(map[string]string{"role": "web"}))
+	require.NoError(err)
+	web.PublicId = "hsinv_web"
+
+	current := []*HostSetMember{
+		newHostSetMember("hsinv_all", "hinv_web"),
+		newHostSetMember("hsinv_web", "hinv_db"),
+	}
+	add, remove := planMembers([]*HostSet{all, web}, hosts, current)
+	assert.Equal([]*HostSetMember{
+		newHostSetMember("hsinv_all", "hinv_db"),
+		newHostSetMember("hsinv_web", "hinv_web"),
+	}, add)
+	assert.Equal([]*HostSetMember{
+		newHostSetMember("hsinv_web", "hinv_db"),
+	}, remove)
+}
@@ -0,0 +1,37 @@
+package inventory
+
+const (
+	// dueCatalogsWhere selects the catalogs which have never been synced or
+	// which were last synced at least their sync interval ago.
+	dueCatalogsWhere = `public_id in
+       ( select s.catalog_id
+           from inventory_host_catalog_sync s
+           join inventory_host_catalog c
+             on c.public_id = s.catalog_id
+          where s.last_sync_time is null
+             or s.last_sync_time + c.sync_interval_seconds * interval '1 second' <= now()
+       )`
+
+	// claimCatalogSyncQuery claims the sync of a catalog. It only succeeds
+	// if the catalog has not been claimed since its last sync time was
+	// read, so a catalog is synced by only one controller at a time.
+	claimCatalogSyncQuery = `
+update inventory_host_catalog_sync
+   set last_sync_time = now()
+ where catalog_id = $1
+   and last_sync_time is not distinct from $2;
+`
+
+	recordCatalogSyncQuery = `
+update inventory_host_catalog_sync
+   set last_sync_time = now(),
+       last_sync_error = nullif($2, '')
+ where catalog_id = $1;
+`
+
+	resetCatalogSyncQuery = `
+update inventory_host_catalog_sync
+   set last_sync_time = null
+ where catalog_id = $1;
+`
+)
@@ -0,0 +1,78 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+func init() {
+	oplog.RegisterTypes(
+		&HostCatalog{},
+		&HostCatalogSync{},
+		&Host{},
+		&HostSet{},
+		&HostSetMember{},
+	)
+}
+
+// A Repository stores and retrieves the persistent types in the inventory
+// package. It is not safe to use a repository concurrently.
+type Repository struct {
+	reader db.Reader
+	writer db.Writer
+	kms    *kms.Kms
+	// defaultLimit provides a default for limiting the number of results
+	// returned from the repo
+	defaultLimit int
+	// sourcePolicy restricts the sources of the host catalogs which are
+	// created, updated or synced with the repo
+	sourcePolicy SourcePolicy
+}
+
+// NewRepository creates a new Repository. The returned repository should
+// only be used for one transaction and it is not safe for concurrent go
+// routines to access it. WithLimit option is used as a repo wide default
+// limit applied to all ListX methods. WithSourcePolicy option sets the
+// sources host catalogs can have; without it, no sources are allowed.
+func NewRepository(r db.Reader, w db.Writer, kms *kms.Kms, opt ...Option) (*Repository, error) {
+	switch {
+	case r == nil:
+		return nil, fmt.Errorf("db.Reader: %w", db.ErrInvalidParameter)
+	case w == nil:
+		return nil, fmt.Errorf("db.Writer: %w", db.ErrInvalidParameter)
+	case kms == nil:
+		return nil, fmt.Errorf("kms: %w", db.ErrInvalidParameter)
+	}
+
+	opts := getOpts(opt...)
+	if opts.withLimit == 0 {
+		// zero signals the boundary defaults should be used.
+		opts.withLimit = db.DefaultLimit
+	}
+
+	return &Repository{
+		reader:       r,
+		writer:       w,
+		kms:          kms,
+		defaultLimit: opts.withLimit,
+		sourcePolicy: opts.withSourcePolicy,
+	}, nil
+}
+
+// writeOplog writes msgs to the oplog in a single entry ticketed on
+// aggregate.
+func writeOplog(ctx context.Context, w db.Writer, wrapper wrapping.Wrapper, aggregate interface{}, metadata oplog.Metadata, msgs []*oplog.Message) error {
+	ticket, err := w.GetTicket(aggregate)
+	if err != nil {
+		return fmt.Errorf("unable to get ticket: %w", err)
+	}
+	if err := w.WriteOplogEntryWith(ctx, wrapper, ticket, metadata, msgs); err != nil {
+		return fmt.Errorf("unable to write oplog: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateCatalog inserts c into the repository and returns a new
+// HostCatalog containing the catalog's PublicId. c is not changed. c must
+// contain a valid ScopeID and a Source allowed by the repository's source
+// policy. c must not contain a PublicId. The PublicId is generated and
+// assigned by the this method. WithPublicId is the only valid option.
+//
+// Both c.Name and c.Description are optional. If c.Name is set, it must be
+// unique within c.ScopeID. If c.SyncIntervalSeconds is zero,
+// DefaultSyncIntervalSeconds is used.
+//
+// The hosts of the catalog are synced with its inventory by the next call
+// to SyncCatalogs.
+func (r *Repository) CreateCatalog(ctx context.Context, c *HostCatalog, opt ...Option) (*HostCatalog, error) {
+	if c == nil {
+		return nil, fmt.Errorf("create: inventory host catalog: %w", db.ErrInvalidParameter)
+	}
+	if c.HostCatalog == nil {
+		return nil, fmt.Errorf("create: inventory host catalog: embedded HostCatalog: %w", db.ErrInvalidParameter)
+	}
+	if c.ScopeId == "" {
+		return nil, fmt.Errorf("create: inventory host catalog: no scope id: %w", db.ErrInvalidParameter)
+	}
+	if c.PublicId != "" {
+		return nil, fmt.Errorf("create: inventory host catalog: public id not empty: %w", db.ErrInvalidParameter)
+	}
+	if err := r.sourcePolicy.checkSource(c.Source); err != nil {
+		return nil, fmt.Errorf("create: inventory host catalog: %w", err)
+	}
+	c = c.clone()
+	if c.SyncIntervalSeconds == 0 {
+		c.SyncIntervalSeconds = DefaultSyncIntervalSeconds
+	}
+
+	opts := getOpts(opt...)
+
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, HostCatalogPrefix+"_") {
+			return nil, fmt.Errorf("create: inventory host catalog: passed-in public ID %q has wrong prefix, should be %q: %w", opts.withPublicId, HostCatalogPrefix, db.ErrInvalidPublicId)
+		}
+		c.PublicId = opts.withPublicId
+	} else {
+		id, err := newHostCatalogId()
+		if err != nil {
+			return nil, fmt.Errorf("create: inventory host catalog: %w", err)
+		}
+		c.PublicId = id
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, c.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("create: inventory host catalog: unable to get oplog wrapper: %w", err)
+	}
+
+	metadata := newCatalogMetadata(c, oplog.OpType_OP_TYPE_CREATE)
+
+	var newHostCatalog *HostCatalog
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newHostCatalog = c.clone()
+			return w.Create(ctx, newHostCatalog, db.WithOplog(oplogWrapper, metadata))
+		},
+	)
+
+	if err != nil {
+		if db.IsUniqueError(err) {
+			return nil, fmt.Errorf("create: inventory host catalog: in scope: %s: name %s already exists: %w",
+				c.ScopeId, c.Name, db.ErrNotUnique)
+		}
+		return nil, fmt.Errorf("create: inventory host catalog: in scope: %s: %w", c.ScopeId, err)
+	}
+	return newHostCatalog, nil
+}
+
+// UpdateCatalog updates the repository entry for c.PublicId with the
+// values in c for the fields listed in fieldMaskPaths. It returns a new
+// HostCatalog containing the updated values and a count of the number of
+// records updated. c is not changed.
+//
+// c must contain a valid PublicId. Only c.Name, c.Description, c.Source,
+// and c.SyncIntervalSeconds can be updated. If c.Name is set to a
+// non-empty string, it must be unique within c.ScopeID. c.Source must be
+// allowed by the repository's source policy. If c.SyncIntervalSeconds is zero, it is set to
+// DefaultSyncIntervalSeconds.
+//
+// An attribute of c will be set to NULL in the database if the attribute
+// in c is the zero value and it is included in fieldMaskPaths.
+//
+// Updating the source of a catalog causes it to be synced by the next call
+// to SyncCatalogs.
+func (r *Repository) UpdateCatalog(ctx context.Context, c *HostCatalog, version uint32, fieldMaskPaths []string, opt ...Option) (*HostCatalog, int, error) {
+	if c == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: inventory host catalog: %w", db.ErrInvalidParameter)
+	}
+	if c.HostCatalog == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: inventory host catalog: embedded HostCatalog: %w", db.ErrInvalidParameter)
+	}
+	if c.PublicId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: inventory host catalog: missing public id: %w", db.ErrInvalidParameter)
+	}
+	if c.ScopeId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: inventory host catalog: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: inventory host catalog: no version supplied: %w", db.ErrInvalidParameter)
+	}
+	c = c.clone()
+
+	var sourceChanged bool
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("Name", f):
+		case strings.EqualFold("Description", f):
+		case strings.EqualFold("Source", f):
+			if err := r.sourcePolicy.checkSource(c.Source); err != nil {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: inventory host catalog: %w", err)
+			}
+			sourceChanged = true
+		case strings.EqualFold("SyncIntervalSeconds", f):
+			if c.SyncIntervalSeconds == 0 {
+				c.SyncIntervalSeconds = DefaultSyncIntervalSeconds
+			}
+		default:
+			return nil, db.NoRowsAffected, fmt.Errorf("update: inventory host catalog: field: %s: %w", f, db.ErrInvalidFieldMask)
+		}
+	}
+	dbMask, nullFields := dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			"Name":                c.Name,
+			"Description":         c.Description,
+			"Source":              c.Source,
+			"SyncIntervalSeconds": c.SyncIntervalSeconds,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: inventory host catalog: %w", db.ErrEmptyFieldMask)
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, c.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: inventory host catalog: unable to get oplog wrapper: %w", err)
+	}
+
+	metadata := newCatalogMetadata(c, oplog.OpType_OP_TYPE_UPDATE)
+
+	var rowsUpdated int
+	var returnedCatalog *HostCatalog
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedCatalog = c.clone()
+			var err error
+			rowsUpdated, err = w.Update(ctx, returnedCatalog, dbMask, nullFields,
+				db.WithOplog(oplogWrapper, metadata),
+				db.WithVersion(&version))
+			if err == nil && rowsUpdated > 1 {
+				return db.ErrMultipleRecords
+			}
+			if err != nil || !sourceChanged {
+				return err
+			}
+			_, err = w.Exec(ctx, resetCatalogSyncQuery, []interface{}{c.PublicId})
+			return err
+		},
+	)
+
+	if err != nil {
+		if db.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: inventory host catalog: %s: name %s already exists: %w",
+				c.PublicId, c.Name, db.ErrNotUnique)
+		}
+		return nil, db.NoRowsAffected, fmt.Errorf("update: inventory host catalog: %s: %w", c.PublicId, err)
+	}
+
+	return returnedCatalog, rowsUpdated, nil
+}
+
+// LookupCatalog returns the HostCatalog for id. Returns nil, nil if no
+// HostCatalog is found for id.
+func (r *Repository) LookupCatalog(ctx context.Context, id string, opt ...Option) (*HostCatalog, error) {
+	if id == "" {
+		return nil, fmt.Errorf("lookup: inventory host catalog: missing public id: %w", db.ErrInvalidParameter)
+	}
+	c := allocCatalog()
+	c.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, c); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup: inventory host catalog: %s: %w", id, err)
+	}
+	return c, nil
+}
+
+// LookupCatalogSync returns the status of the most recent sync of the
+// HostCatalog for catalogId. Returns nil, nil if no HostCatalog is found
+// for catalogId.
+func (r *Repository) LookupCatalogSync(ctx context.Context, catalogId string, opt ...Option) (*HostCatalogSync, error) {
+	if catalogId == "" {
+		return nil, fmt.Errorf("lookup: inventory host catalog sync: missing catalog id: %w", db.ErrInvalidParameter)
+	}
+	s := allocCatalogSync()
+	if err := r.reader.LookupWhere(ctx, s, "catalog_id = ?", catalogId); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup: inventory host catalog sync: %s: %w", catalogId, err)
+	}
+	return s, nil
+}
+
+// ListCatalogs returns a slice of HostCatalogs for the scopeId ordered by
+// public id. Supports the WithLimit and WithStartPageAfterId options.
+func (r *Repository) ListCatalogs(ctx context.Context, scopeId string, opt ...Option) ([]*HostCatalog, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("list: inventory host catalog: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	where, args := "scope_id = ?", []interface{}{scopeId}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id collate \"C\" > ?", append(args, opts.withStartPageAfterId)
+	}
+	var hostCatalogs []*HostCatalog
+	err := r.reader.SearchWhere(ctx, &hostCatalogs, where, args, db.WithLimit(limit), db.WithOrder(`public_id collate "C"`))
+	if err != nil {
+		return nil, fmt.Errorf("list: inventory host catalog: %w", err)
+	}
+	return hostCatalogs, nil
+}
+
+// DeleteCatalog deletes id from the repository returning a count of the
+// number of records deleted. The hosts and host sets of the catalog are
+// also deleted.
+func (r *Repository) DeleteCatalog(ctx context.Context, id string, opt ...Option) (int, error) {
+	if id == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: inventory host catalog: missing public id: %w", db.ErrInvalidParameter)
+	}
+
+	c := allocCatalog()
+	c.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, c); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.NoRowsAffected, nil
+		}
+		return db.NoRowsAffected, fmt.Errorf("delete: inventory host catalog: failed %w for %s", err, id)
+	}
+	if c.ScopeId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: inventory host catalog: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, c.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: inventory host catalog: unable to get oplog wrapper: %w", err)
+	}
+
+	metadata := newCatalogMetadata(c, oplog.OpType_OP_TYPE_DELETE)
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			dc := c.clone()
+			var err error
+			rowsDeleted, err = w.Delete(ctx, dc, db.WithOplog(oplogWrapper, metadata))
+			if err == nil && rowsDeleted > 1 {
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: inventory host catalog: %s: %w", c.PublicId, err)
+	}
+
+	return rowsDeleted, nil
+}
@@ -0,0 +1,86 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Catalog(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+
+	repo, err := NewRepository(rw, rw, kmsCache, WithSourcePolicy(SourcePolicy{AllowedHosts: []string{"inventory.example.com"}}))
+	require.NoError(err)
+
+	_, err = NewHostCatalog(prj.GetPublicId(), "")
+	assert.Truef(errors.Is(err, db.ErrInvalidParameter), "want err: %q got: %q", db.ErrInvalidParameter, err)
+	in, err := NewHostCatalog(prj.GetPublicId(), "hosts.json")
+	require.NoError(err)
+	_, err = repo.CreateCatalog(ctx, in)
+	assert.Truef(errors.Is(err, ErrInvalidSource), "want err: %q got: %q", ErrInvalidSource, err)
+
+	// Sources which are not allowed by the policy are rejected.
+	in, err = NewHostCatalog(prj.GetPublicId(), "http://169.254.169.254/latest/meta-data/")
+	require.NoError(err)
+	_, err = repo.CreateCatalog(ctx, in)
+	assert.Truef(errors.Is(err, ErrInvalidSource), "want err: %q got: %q", ErrInvalidSource, err)
+	in, err = NewHostCatalog(prj.GetPublicId(), "/etc/passwd")
+	require.NoError(err)
+	_, err = repo.CreateCatalog(ctx, in)
+	assert.Truef(errors.Is(err, ErrInvalidSource), "want err: %q got: %q", ErrInvalidSource, err)
+
+	in, err = NewHostCatalog(prj.GetPublicId(), "https://inventory.example.com/hosts.json", WithName("inventory"))
+	require.NoError(err)
+	cat, err := repo.CreateCatalog(ctx, in)
+	require.NoError(err)
+	assert.Equal(uint32(DefaultSyncIntervalSeconds), cat.GetSyncIntervalSeconds())
+	s, err := repo.LookupCatalogSync(ctx, cat.GetPublicId())
+	require.NoError(err)
+	require.NotNil(s)
+	assert.Nil(s.GetLastSyncTime())
+
+	_, err = repo.CreateCatalog(ctx, in)
+	assert.Truef(errors.Is(err, db.ErrNotUnique), "want err: %q got: %q", db.ErrNotUnique, err)
+
+	cat.SyncIntervalSeconds = 60
+	cat.Name = ""
+	cat, n, err := repo.UpdateCatalog(ctx, cat, cat.GetVersion(), []string{"SyncIntervalSeconds", "Name"})
+	require.NoError(err)
+	assert.Equal(1, n)
+	assert.Equal(uint32(60), cat.GetSyncIntervalSeconds())
+	assert.Empty(cat.GetName())
+
+	cat.Source = "ftp://inventory.example.com/hosts.json"
+	_, _, err = repo.UpdateCatalog(ctx, cat, cat.GetVersion(), []string{"Source"})
+	assert.Truef(errors.Is(err, ErrInvalidSource), "want err: %q got: %q", ErrInvalidSource, err)
+	cat.Source = "https://other.example.com/hosts.json"
+	_, _, err = repo.UpdateCatalog(ctx, cat, cat.GetVersion(), []string{"Source"})
+	assert.Truef(errors.Is(err, ErrInvalidSource), "want err: %q got: %q", ErrInvalidSource, err)
+	cat.Source = "https://inventory.example.com/hosts.json"
+
+	got, err := repo.LookupCatalog(ctx, cat.GetPublicId())
+	require.NoError(err)
+	assert.Equal(cat.GetSyncIntervalSeconds(), got.GetSyncIntervalSeconds())
+	cats, err := repo.ListCatalogs(ctx, prj.GetPublicId())
+	require.NoError(err)
+	assert.Len(cats, 1)
+
+	n, err = repo.DeleteCatalog(ctx, cat.GetPublicId())
+	require.NoError(err)
+	assert.Equal(1, n)
+	got, err = repo.LookupCatalog(ctx, cat.GetPublicId())
+	require.NoError(err)
+	assert.Nil(got)
+}
@@ -0,0 +1,89 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+const unlimited = -1
+
+// updateMembers adds and removes members of sets so the members of each
+// set are the hosts which have the match labels of the set. hosts must
+// contain every host of the catalog of sets and current must contain the
+// current members of sets. It returns the oplog messages for the changes.
+func updateMembers(ctx context.Context, w db.Writer, sets []*HostSet, hosts []*Host, current []*HostSetMember) ([]*oplog.Message, error) {
+	add, remove := planMembers(sets, hosts, current)
+	var msgs []*oplog.Message
+	if len(remove) > 0 {
+		items := make([]interface{}, 0, len(remove))
+		for _, m := range remove {
+			items = append(items, m)
+		}
+		var deleteMsgs []*oplog.Message
+		if _, err := w.DeleteItems(ctx, items, db.NewOplogMsgs(&deleteMsgs)); err != nil {
+			return nil, fmt.Errorf("unable to delete host set members: %w", err)
+		}
+		msgs = append(msgs, deleteMsgs...)
+	}
+	if len(add) > 0 {
+		items := make([]interface{}, 0, len(add))
+		for _, m := range add {
+			items = append(items, m)
+		}
+		var createMsgs []*oplog.Message
+		if err := w.CreateItems(ctx, items, db.NewOplogMsgs(&createMsgs)); err != nil {
+			return nil, fmt.Errorf("unable to create host set members: %w", err)
+		}
+		msgs = append(msgs, createMsgs...)
+	}
+	return msgs, nil
+}
+
+func getCatalogHosts(ctx context.Context, reader db.Reader, catalogId string) ([]*Host, error) {
+	var hosts []*Host
+	if err := reader.SearchWhere(ctx, &hosts, "catalog_id = ?", []interface{}{catalogId}, db.WithLimit(unlimited)); err != nil {
+		return nil, fmt.Errorf("get catalog hosts: %w", err)
+	}
+	return hosts, nil
+}
+
+func getHosts(ctx context.Context, reader db.Reader, setId string, limit int) ([]*Host, error) {
+	const whereNoLimit = `public_id in
+       ( select host_id
+           from inventory_host_set_member
+          where set_id = $1
+       )`
+
+	const whereLimit = `public_id in
+       ( select host_id
+           from inventory_host_set_member
+          where set_id = $1
+          limit $2
+       )`
+
+	params := []interface{}{setId}
+	var where string
+	switch limit {
+	case unlimited:
+		where = whereNoLimit
+	default:
+		where = whereLimit
+		params = append(params, limit)
+	}
+
+	var hosts []*Host
+	if err := reader.SearchWhere(ctx, &hosts,
+		where,
+		params,
+		db.WithLimit(limit),
+	); err != nil {
+		return nil, fmt.Errorf("get hosts: %w", err)
+	}
+	if len(hosts) == 0 {
+		return nil, nil
+	}
+	return hosts, nil
+}
@@ -0,0 +1,65 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateUpdateSet(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	cat := TestCatalog(t, conn, prj.GetPublicId(), "https://inventory.example.com/hosts.json")
+	web := TestHost(t, conn, cat.GetPublicId(), "web-1", "10.0.0.11", map[string]string{"role": "web", "env": "prod"})
+	dbHost := TestHost(t, conn, cat.GetPublicId(), "db-1", "10.0.0.21", map[string]string{"role": "db", "env": "prod"})
+
+	in, err := NewHostSet(cat.GetPublicId(), WithName("web"), WithMatchLabels(map[string]string{"role": "web"}))
+	require.NoError(err)
+	set, err := repo.CreateSet(ctx, prj.GetPublicId(), in)
+	require.NoError(err)
+	assert.True(len(set.GetPublicId()) > len(HostSetPrefix))
+	assert.Equal(map[string]string{"role": "web"}, set.MatchLabelMap())
+	_, hosts, err := repo.LookupSet(ctx, set.GetPublicId())
+	require.NoError(err)
+	require.Len(hosts, 1)
+	assert.Equal(web.GetPublicId(), hosts[0].GetPublicId())
+
+	_, err = repo.CreateSet(ctx, prj.GetPublicId(), in)
+	assert.Truef(errors.Is(err, db.ErrNotUnique), "want err: %q got: %q", db.ErrNotUnique, err)
+
+	require.NoError(set.SetMatchLabels(map[string]string{"env": "prod"}))
+	set, hosts, _, err = repo.UpdateSet(ctx, prj.GetPublicId(), set, set.GetVersion(), []string{"MatchLabels"})
+	require.NoError(err)
+	assert.Equal(uint32(2), set.GetVersion())
+	var ids []string
+	for _, h := range hosts {
+		ids = append(ids, h.GetPublicId())
+	}
+	assert.ElementsMatch([]string{web.GetPublicId(), dbHost.GetPublicId()}, ids)
+
+	require.NoError(set.SetMatchLabels(map[string]string{"role": "cache"}))
+	set, hosts, _, err = repo.UpdateSet(ctx, prj.GetPublicId(), set, set.GetVersion(), []string{"MatchLabels"})
+	require.NoError(err)
+	assert.Empty(hosts)
+
+	_, _, _, err = repo.UpdateSet(ctx, prj.GetPublicId(), set, set.GetVersion(), []string{"Address"})
+	assert.Truef(errors.Is(err, db.ErrInvalidFieldMask), "want err: %q got: %q", db.ErrInvalidFieldMask, err)
+
+	n, err := repo.DeleteSet(ctx, prj.GetPublicId(), set.GetPublicId())
+	require.NoError(err)
+	assert.Equal(1, n)
+}
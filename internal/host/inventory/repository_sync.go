@@ -0,0 +1,204 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/go-multierror"
+)
+
+// SyncCatalogs syncs every host catalog which has never been synced or
+// which was last synced at least its sync interval ago. A catalog which is
+// being synced by another controller is skipped. The errors from syncing
+// each catalog are returned together.
+func (r *Repository) SyncCatalogs(ctx context.Context) error {
+	var catalogs []*HostCatalog
+	if err := r.reader.SearchWhere(ctx, &catalogs, dueCatalogsWhere, nil, db.WithLimit(unlimited)); err != nil {
+		return fmt.Errorf("sync: inventory host catalogs: %w", err)
+	}
+	var errs *multierror.Error
+	for _, c := range catalogs {
+		if err := r.SyncCatalog(ctx, c.PublicId); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// SyncCatalog fetches the inventory of the host catalog for catalogId and
+// syncs the hosts of the catalog, and the members of its host sets, with
+// it. All of the changes are made in a single transaction and written to
+// the oplog as a single entry. The time and error of the sync are recorded
+// and can be retrieved with LookupCatalogSync. If the inventory can not be
+// fetched or is not valid, the hosts of the catalog are not changed.
+//
+// If the catalog is being synced by another controller, SyncCatalog does
+// nothing and returns nil.
+func (r *Repository) SyncCatalog(ctx context.Context, catalogId string) error {
+	if catalogId == "" {
+		return fmt.Errorf("sync: inventory host catalog: missing catalog id: %w", db.ErrInvalidParameter)
+	}
+	c, err := r.LookupCatalog(ctx, catalogId)
+	if err != nil {
+		return fmt.Errorf("sync: %w", err)
+	}
+	if c == nil {
+		return fmt.Errorf("sync: inventory host catalog: %s: %w", catalogId, db.ErrRecordNotFound)
+	}
+	s, err := r.LookupCatalogSync(ctx, catalogId)
+	if err != nil {
+		return fmt.Errorf("sync: %w", err)
+	}
+	if s == nil {
+		return fmt.Errorf("sync: inventory host catalog: %s: no sync status: %w", catalogId, db.ErrRecordNotFound)
+	}
+	claimed, err := r.writer.Exec(ctx, claimCatalogSyncQuery, []interface{}{catalogId, s.GetLastSyncTime()})
+	if err != nil {
+		return fmt.Errorf("sync: inventory host catalog: %s: unable to claim sync: %w", catalogId, err)
+	}
+	if claimed == 0 {
+		return nil
+	}
+
+	syncErr := r.syncCatalog(ctx, c)
+	var lastErr string
+	if syncErr != nil {
+		lastErr = syncErr.Error()
+	}
+	if _, err := r.writer.Exec(ctx, recordCatalogSyncQuery, []interface{}{catalogId, lastErr}); err != nil {
+		syncErr = multierror.Append(syncErr, fmt.Errorf("unable to record sync: %w", err))
+	}
+	if syncErr != nil {
+		return fmt.Errorf("sync: inventory host catalog: %s: %w", catalogId, syncErr)
+	}
+	return nil
+}
+
+func (r *Repository) syncCatalog(ctx context.Context, c *HostCatalog) error {
+	b, err := fetchInventory(ctx, r.sourcePolicy, c.Source)
+	if err != nil {
+		return fmt.Errorf("unable to fetch inventory: %w", err)
+	}
+	inv, err := parseInventory(b)
+	if err != nil {
+		return err
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, c.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return fmt.Errorf("unable to get oplog wrapper: %w", err)
+	}
+
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			current, err := getCatalogHosts(ctx, reader, c.PublicId)
+			if err != nil {
+				return err
+			}
+			changes, err := planHosts(c.PublicId, current, inv)
+			if err != nil {
+				return err
+			}
+			msgs, err := applyHostChanges(ctx, w, changes)
+			if err != nil {
+				return err
+			}
+
+			// The members of deleted hosts are deleted with the hosts.
+			deleted := make(map[string]bool, len(changes.delete))
+			for _, h := range changes.delete {
+				deleted[h.PublicId] = true
+			}
+			updated := make(map[string]*Host, len(changes.update))
+			for _, u := range changes.update {
+				updated[u.host.PublicId] = u.host
+			}
+			hosts := make([]*Host, 0, len(current)+len(changes.create))
+			for _, h := range current {
+				switch {
+				case deleted[h.PublicId]:
+				case updated[h.PublicId] != nil:
+					hosts = append(hosts, updated[h.PublicId])
+				default:
+					hosts = append(hosts, h)
+				}
+			}
+			hosts = append(hosts, changes.create...)
+
+			var sets []*HostSet
+			if err := reader.SearchWhere(ctx, &sets, "catalog_id = ?", []interface{}{c.PublicId}, db.WithLimit(unlimited)); err != nil {
+				return fmt.Errorf("unable to get host sets: %w", err)
+			}
+			var members []*HostSetMember
+			if err := reader.SearchWhere(ctx, &members, "catalog_id = ?", []interface{}{c.PublicId}, db.WithLimit(unlimited)); err != nil {
+				return fmt.Errorf("unable to get host set members: %w", err)
+			}
+			remaining := members[:0]
+			for _, m := range members {
+				if !deleted[m.HostId] {
+					remaining = append(remaining, m)
+				}
+			}
+			memberMsgs, err := updateMembers(ctx, w, sets, hosts, remaining)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, memberMsgs...)
+
+			if len(msgs) == 0 {
+				return nil
+			}
+			return writeOplog(ctx, w, oplogWrapper, c, newCatalogMetadata(c, oplog.OpType_OP_TYPE_UPDATE), msgs)
+		},
+	)
+	return err
+}
+
+// applyHostChanges deletes, creates, and updates hosts as listed in changes
+// and returns the oplog messages for the changes. PublicIds are assigned
+// to the hosts in changes.create.
+func applyHostChanges(ctx context.Context, w db.Writer, changes *hostChanges) ([]*oplog.Message, error) {
+	var msgs []*oplog.Message
+	if len(changes.delete) > 0 {
+		items := make([]interface{}, 0, len(changes.delete))
+		for _, h := range changes.delete {
+			items = append(items, h.clone())
+		}
+		var deleteMsgs []*oplog.Message
+		if _, err := w.DeleteItems(ctx, items, db.NewOplogMsgs(&deleteMsgs)); err != nil {
+			return nil, fmt.Errorf("unable to delete hosts: %w", err)
+		}
+		msgs = append(msgs, deleteMsgs...)
+	}
+	if len(changes.create) > 0 {
+		items := make([]interface{}, 0, len(changes.create))
+		for _, h := range changes.create {
+			id, err := newHostId()
+			if err != nil {
+				return nil, err
+			}
+			h.PublicId = id
+			items = append(items, h)
+		}
+		var createMsgs []*oplog.Message
+		if err := w.CreateItems(ctx, items, db.NewOplogMsgs(&createMsgs)); err != nil {
+			return nil, fmt.Errorf("unable to create hosts: %w", err)
+		}
+		msgs = append(msgs, createMsgs...)
+	}
+	for _, u := range changes.update {
+		msg := new(oplog.Message)
+		rowsUpdated, err := w.Update(ctx, u.host, u.dbMask, u.nullFields, db.NewOplogMsg(msg))
+		switch {
+		case err != nil:
+			return nil, fmt.Errorf("unable to update host %s: %w", u.host.PublicId, err)
+		case rowsUpdated > 1:
+			return nil, fmt.Errorf("unable to update host %s: %w", u.host.PublicId, db.ErrMultipleRecords)
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
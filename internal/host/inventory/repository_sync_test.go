@@ -0,0 +1,139 @@
+package inventory
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_SyncCatalog(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+
+	dir, err := ioutil.TempDir("", "boundary-inventory-test")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "hosts.yaml")
+	writeDoc := func(doc string) {
+		require.NoError(ioutil.WriteFile(path, []byte(doc), 0600))
+	}
+
+	repo, err := NewRepository(rw, rw, kmsCache, WithSourcePolicy(SourcePolicy{AllowedPaths: []string{dir}}))
+	require.NoError(err)
+	cat := TestCatalog(t, conn, prj.GetPublicId(), path)
+	web := TestSet(t, conn, cat.GetPublicId(), map[string]string{"role": "web"})
+	all := TestSet(t, conn, cat.GetPublicId(), nil)
+
+	memberIds := func(setId string) []string {
+		_, hosts, err := repo.LookupSet(ctx, setId)
+		require.NoError(err)
+		var ids []string
+		for _, h := range hosts {
+			ids = append(ids, h.ExternalId)
+		}
+		return ids
+	}
+
+	writeDoc(`
+hosts:
+- id: web-1
+  address: 10.0.0.11
+  labels:
+    role: web
+- id: db-1
+  address: 10.0.0.21
+  labels:
+    role: db
+`)
+	require.NoError(repo.SyncCatalog(ctx, cat.GetPublicId()))
+	hosts, err := repo.ListHosts(ctx, cat.GetPublicId())
+	require.NoError(err)
+	assert.Len(hosts, 2)
+	assert.ElementsMatch([]string{"web-1"}, memberIds(web.GetPublicId()))
+	assert.ElementsMatch([]string{"web-1", "db-1"}, memberIds(all.GetPublicId()))
+	s, err := repo.LookupCatalogSync(ctx, cat.GetPublicId())
+	require.NoError(err)
+	assert.NotNil(s.GetLastSyncTime())
+	assert.Empty(s.GetLastSyncError())
+
+	// db-1 becomes a web host, web-1 is removed, and web-2 is added.
+	writeDoc(`
+hosts:
+- id: db-1
+  address: 10.0.0.22
+  labels:
+    role: web
+- id: web-2
+  address: 10.0.0.12
+  labels:
+    role: web
+`)
+	require.NoError(repo.SyncCatalog(ctx, cat.GetPublicId()))
+	hosts, err = repo.ListHosts(ctx, cat.GetPublicId())
+	require.NoError(err)
+	require.Len(hosts, 2)
+	for _, h := range hosts {
+		if h.ExternalId == "db-1" {
+			assert.Equal("10.0.0.22", h.Address)
+			assert.Equal(map[string]string{"role": "web"}, h.LabelMap())
+		}
+	}
+	assert.ElementsMatch([]string{"db-1", "web-2"}, memberIds(web.GetPublicId()))
+	assert.ElementsMatch([]string{"db-1", "web-2"}, memberIds(all.GetPublicId()))
+
+	// An invalid inventory leaves the hosts unchanged and records the error.
+	writeDoc(`{"hosts": [{"id": "db-1", "address": "10.0.0.22:22"}]}`)
+	assert.Error(repo.SyncCatalog(ctx, cat.GetPublicId()))
+	hosts, err = repo.ListHosts(ctx, cat.GetPublicId())
+	require.NoError(err)
+	assert.Len(hosts, 2)
+	s, err = repo.LookupCatalogSync(ctx, cat.GetPublicId())
+	require.NoError(err)
+	assert.Contains(s.GetLastSyncError(), "must not contain a port")
+
+	// Catalogs are only synced by SyncCatalogs once their interval has
+	// passed.
+	writeDoc(`{"hosts": []}`)
+	require.NoError(repo.SyncCatalogs(ctx))
+	hosts, err = repo.ListHosts(ctx, cat.GetPublicId())
+	require.NoError(err)
+	assert.Len(hosts, 2)
+
+	// Updating the source causes the catalog to be synced.
+	cat.Source = "file://" + path
+	cat, _, err = repo.UpdateCatalog(ctx, cat, cat.Version, []string{"Source"})
+	require.NoError(err)
+	require.NoError(repo.SyncCatalogs(ctx))
+	hosts, err = repo.ListHosts(ctx, cat.GetPublicId())
+	require.NoError(err)
+	assert.Empty(hosts)
+	s, err = repo.LookupCatalogSync(ctx, cat.GetPublicId())
+	require.NoError(err)
+	assert.Empty(s.GetLastSyncError())
+
+	// A source which is no longer allowed by the controller configuration
+	// is not read.
+	writeDoc(`{"hosts": [{"id": "web-1", "address": "10.0.0.11"}]}`)
+	other, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	assert.Error(other.SyncCatalog(ctx, cat.GetPublicId()))
+	hosts, err = repo.ListHosts(ctx, cat.GetPublicId())
+	require.NoError(err)
+	assert.Empty(hosts)
+	s, err = repo.LookupCatalogSync(ctx, cat.GetPublicId())
+	require.NoError(err)
+	assert.Contains(s.GetLastSyncError(), "not in a directory allowed")
+}
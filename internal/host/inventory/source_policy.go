@@ -0,0 +1,76 @@
+package inventory
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// A SourcePolicy restricts the sources host catalogs can be synced from.
+// Sources are set by anyone allowed to create or update host catalogs, but
+// they are read by the controllers with the controllers' access to the local
+// file system and network, so only the directories and hosts allowed by the
+// controllers' configuration can be used. The zero value allows no sources.
+type SourcePolicy struct {
+	// AllowedPaths are the directories file sources can be in, including
+	// their subdirectories.
+	AllowedPaths []string
+
+	// AllowedHosts are the hosts http and https sources can be fetched from.
+	// A host with a port only allows that port.
+	AllowedHosts []string
+}
+
+// checkSource returns an error wrapping ErrInvalidSource if source is not
+// valid or not allowed by p.
+func (p SourcePolicy) checkSource(source string) error {
+	u, err := parseSource(source)
+	if err != nil {
+		return err
+	}
+	return p.check(u)
+}
+
+// check returns an error wrapping ErrInvalidSource if the source URL u is
+// not allowed by p.
+func (p SourcePolicy) check(u *url.URL) error {
+	switch u.Scheme {
+	case "file":
+		path := filepath.Clean(u.Path)
+		for _, dir := range p.AllowedPaths {
+			if inDir(dir, path) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not in a directory allowed by the controller configuration: %w", path, ErrInvalidSource)
+	case "http", "https":
+		for _, h := range p.AllowedHosts {
+			if strings.EqualFold(h, u.Host) || strings.EqualFold(h, u.Hostname()) {
+				return nil
+			}
+		}
+		return fmt.Errorf("host %q is not allowed by the controller configuration: %w", u.Host, ErrInvalidSource)
+	default:
+		return fmt.Errorf("%q must be a file, http, or https url: %w", u.String(), ErrInvalidSource)
+	}
+}
+
+// inDir reports whether path is in the absolute directory dir or one of its
+// subdirectories. The directory may be given through symbolic links.
+func inDir(dir, path string) bool {
+	if !filepath.IsAbs(dir) {
+		return false
+	}
+	dirs := []string{filepath.Clean(dir)}
+	if real, err := filepath.EvalSymlinks(dir); err == nil && real != dirs[0] {
+		dirs = append(dirs, real)
+	}
+	for _, d := range dirs {
+		rel, err := filepath.Rel(d, path)
+		if err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package inventory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourcePolicy_checkSource(t *testing.T) {
+	t.Parallel()
+	p := SourcePolicy{
+		AllowedPaths: []string{"/etc/boundary/inventory", "relative/inventory"},
+		AllowedHosts: []string{"cmdb.example.com", "inventory.example.com:8443"},
+	}
+	var tests = []struct {
+		source  string
+		wantErr bool
+	}{
+		{source: "/etc/boundary/inventory/hosts.yaml"},
+		{source: "file:///etc/boundary/inventory/prod/hosts.yaml"},
+		{source: "https://cmdb.example.com/hosts.json"},
+		{source: "http://CMDB.example.com:8080/hosts.json"},
+		{source: "https://inventory.example.com:8443/hosts.json"},
+		{source: "/etc/boundary/inventory", wantErr: true},
+		{source: "/etc/boundary/inventory/../boundary.hcl", wantErr: true},
+		{source: "/etc/boundary/inventory-other/hosts.yaml", wantErr: true},
+		{source: "/etc/passwd", wantErr: true},
+		{source: "/relative/inventory/hosts.yaml", wantErr: true},
+		{source: "https://inventory.example.com/hosts.json", wantErr: true},
+		{source: "https://cmdb.example.com.evil.com/hosts.json", wantErr: true},
+		{source: "http://169.254.169.254/latest/meta-data/", wantErr: true},
+		{source: "hosts.yaml", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.source, func(t *testing.T) {
+			t.Parallel()
+			err := p.checkSource(tt.source)
+			if tt.wantErr {
+				assert.Truef(t, errors.Is(err, ErrInvalidSource), "want err: %q got: %q", ErrInvalidSource, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	assert.Error(t, SourcePolicy{}.checkSource("/etc/boundary/inventory/hosts.yaml"))
+	assert.Error(t, SourcePolicy{}.checkSource("https://cmdb.example.com/hosts.json"))
+}
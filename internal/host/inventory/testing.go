@@ -0,0 +1,68 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCatalog creates an inventory host catalog in the provided DB with
+// the provided scope id and source. The catalog is not synced. If any
+// errors are encountered during the creation of the host catalog, the test
+// will fail.
+func TestCatalog(t *testing.T, conn *gorm.DB, scopeId, source string, opt ...Option) *HostCatalog {
+	t.Helper()
+	require := require.New(t)
+	cat, err := NewHostCatalog(scopeId, source, opt...)
+	require.NoError(err)
+	id, err := newHostCatalogId()
+	require.NoError(err)
+	cat.PublicId = id
+
+	w := db.New(conn)
+	require.NoError(w.Create(context.Background(), cat))
+	return cat
+}
+
+// TestHost creates an inventory host in the provided DB in the provided
+// catalog with externalId, address, and labels. The catalog must have been
+// created previously. If any errors are encountered during the creation of
+// the host, the test will fail.
+func TestHost(t *testing.T, conn *gorm.DB, catalogId, externalId, address string, labels map[string]string) *Host {
+	t.Helper()
+	require := require.New(t)
+	l, err := encodeLabels(labels)
+	require.NoError(err)
+	h := allocHost()
+	h.CatalogId = catalogId
+	h.ExternalId = externalId
+	h.Address = address
+	h.Labels = l
+	h.PublicId, err = newHostId()
+	require.NoError(err)
+
+	w := db.New(conn)
+	require.NoError(w.Create(context.Background(), h))
+	return h
+}
+
+// TestSet creates an inventory host set in the provided DB in the provided
+// catalog with matchLabels. The catalog must have been created previously.
+// The members of the set are not computed. If any errors are encountered
+// during the creation of the host set, the test will fail.
+func TestSet(t *testing.T, conn *gorm.DB, catalogId string, matchLabels map[string]string) *HostSet {
+	t.Helper()
+	require := require.New(t)
+	set, err := NewHostSet(catalogId, WithMatchLabels(matchLabels))
+	require.NoError(err)
+	id, err := newHostSetId()
+	require.NoError(err)
+	set.PublicId = id
+
+	w := db.New(conn)
+	require.NoError(w.Create(context.Background(), set))
+	return set
+}
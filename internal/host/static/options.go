@@ -14,11 +14,12 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withName        string
-	withDescription string
-	withLimit       int
-	withAddress     string
-	withPublicId    string
+	withName             string
+	withDescription      string
+	withLimit            int
+	withStartPageAfterId string
+	withAddress          string
+	withPublicId         string
 }
 
 func getDefaultOptions() options {
@@ -65,3 +66,11 @@ func WithPublicId(id string) Option {
 		o.withPublicId = id
 	}
 }
+
+// WithStartPageAfterId provides an option to only list resources whose public
+// ids sort after the given id. Used to retrieve the next page of results.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
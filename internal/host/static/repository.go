@@ -5,8 +5,18 @@ import (
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
 )
 
+func init() {
+	oplog.RegisterTypes(
+		&HostCatalog{},
+		&Host{},
+		&HostSet{},
+		&HostSetMember{},
+	)
+}
+
 // A Repository stores and retrieves the persistent types in the static
 // package. It is not safe to use a repository concurrently.
 type Repository struct {
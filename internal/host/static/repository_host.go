@@ -193,8 +193,8 @@ func (r *Repository) LookupHost(ctx context.Context, publicId string, opt ...Opt
 	return h, nil
 }
 
-// ListHosts returns a slice of Hosts for the catalogId.
-// WithLimit is the only option supported.
+// ListHosts returns a slice of Hosts for the catalogId ordered by public id.
+// Supports the WithLimit and WithStartPageAfterId options.
 func (r *Repository) ListHosts(ctx context.Context, catalogId string, opt ...Option) ([]*Host, error) {
 	if catalogId == "" {
 		return nil, fmt.Errorf("list: static host: missing catalog id: %w", db.ErrInvalidParameter)
@@ -205,8 +205,12 @@ func (r *Repository) ListHosts(ctx context.Context, catalogId string, opt ...Opt
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
+	where, args := "catalog_id = ?", []interface{}{catalogId}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id collate \"C\" > ?", append(args, opts.withStartPageAfterId)
+	}
 	var hosts []*Host
-	err := r.reader.SearchWhere(ctx, &hosts, "catalog_id = ?", []interface{}{catalogId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &hosts, where, args, db.WithLimit(limit), db.WithOrder(`public_id collate "C"`))
 	if err != nil {
 		return nil, fmt.Errorf("list: static host: %w", err)
 	}
@@ -188,7 +188,8 @@ func (r *Repository) LookupCatalog(ctx context.Context, id string, opt ...Option
 	return c, nil
 }
 
-// ListCatalogs returns a slice of HostCatalogs for the scopeId. WithLimit is the only option supported.
+// ListCatalogs returns a slice of HostCatalogs for the scopeId ordered by public id.
+// Supports the WithLimit and WithStartPageAfterId options.
 func (r *Repository) ListCatalogs(ctx context.Context, scopeId string, opt ...Option) ([]*HostCatalog, error) {
 	if scopeId == "" {
 		return nil, fmt.Errorf("list: static host catalog: missing scope id: %w", db.ErrInvalidParameter)
@@ -199,8 +200,12 @@ func (r *Repository) ListCatalogs(ctx context.Context, scopeId string, opt ...Op
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
+	where, args := "scope_id = ?", []interface{}{scopeId}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id collate \"C\" > ?", append(args, opts.withStartPageAfterId)
+	}
 	var hostCatalogs []*HostCatalog
-	err := r.reader.SearchWhere(ctx, &hostCatalogs, "scope_id = ?", []interface{}{scopeId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &hostCatalogs, where, args, db.WithLimit(limit), db.WithOrder(`public_id collate "C"`))
 	if err != nil {
 		return nil, fmt.Errorf("list: static host catalog: %w", err)
 	}
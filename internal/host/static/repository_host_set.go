@@ -210,8 +210,8 @@ func (r *Repository) LookupSet(ctx context.Context, publicId string, opt ...Opti
 	return s, hosts, nil
 }
 
-// ListSets returns a slice of HostSets for the catalogId. WithLimit is the
-// only option supported.
+// ListSets returns a slice of HostSets for the catalogId ordered by public
+// id. Supports the WithLimit and WithStartPageAfterId options.
 func (r *Repository) ListSets(ctx context.Context, catalogId string, opt ...Option) ([]*HostSet, error) {
 	if catalogId == "" {
 		return nil, fmt.Errorf("list: static host set: missing catalog id: %w", db.ErrInvalidParameter)
@@ -222,8 +222,12 @@ func (r *Repository) ListSets(ctx context.Context, catalogId string, opt ...Opti
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
+	where, args := "catalog_id = ?", []interface{}{catalogId}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id collate \"C\" > ?", append(args, opts.withStartPageAfterId)
+	}
 	var sets []*HostSet
-	err := r.reader.SearchWhere(ctx, &sets, "catalog_id = ?", []interface{}{catalogId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &sets, where, args, db.WithLimit(limit), db.WithOrder(`public_id collate "C"`))
 	if err != nil {
 		return nil, fmt.Errorf("list: static host set: %w", err)
 	}
@@ -3,6 +3,7 @@ package host
 import (
 	"strings"
 
+	"github.com/hashicorp/boundary/internal/host/inventory"
 	"github.com/hashicorp/boundary/internal/host/static"
 )
 
@@ -11,12 +12,15 @@ type SubType int
 const (
 	UnknownSubtype SubType = iota
 	StaticSubtype
+	InventorySubtype
 )
 
 func (t SubType) String() string {
 	switch t {
 	case StaticSubtype:
 		return "static"
+	case InventorySubtype:
+		return "inventory"
 	}
 	return "unknown"
 }
@@ -26,6 +30,8 @@ func SubtypeFromType(t string) SubType {
 	switch {
 	case strings.EqualFold(strings.TrimSpace(t), StaticSubtype.String()):
 		return StaticSubtype
+	case strings.EqualFold(strings.TrimSpace(t), InventorySubtype.String()):
+		return InventorySubtype
 	}
 	return UnknownSubtype
 }
@@ -36,6 +42,10 @@ func SubtypeFromId(id string) SubType {
 		strings.HasPrefix(strings.TrimSpace(id), static.HostSetPrefix),
 		strings.HasPrefix(strings.TrimSpace(id), static.HostCatalogPrefix):
 		return StaticSubtype
+	case strings.HasPrefix(strings.TrimSpace(id), inventory.HostPrefix),
+		strings.HasPrefix(strings.TrimSpace(id), inventory.HostSetPrefix),
+		strings.HasPrefix(strings.TrimSpace(id), inventory.HostCatalogPrefix):
+		return InventorySubtype
 	}
 	return UnknownSubtype
 }
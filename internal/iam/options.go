@@ -21,6 +21,7 @@ type options struct {
 	withDescription             string
 	withGroupGrants             bool
 	withLimit                   int
+	withStartPageAfterId        string
 	withAutoVivify              bool
 	withGrantScopeId            string
 	withSkipVetForWrite         bool
@@ -142,3 +143,11 @@ func WithRandomReader(reader io.Reader) Option {
 		o.withRandomReader = reader
 	}
 }
+
+// WithStartPageAfterId provides an option to only list resources whose public
+// ids sort after the given id. Used to retrieve the next page of results.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
@@ -12,6 +12,20 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 )
 
+func init() {
+	oplog.RegisterTypes(
+		&Scope{},
+		&User{},
+		&Group{},
+		&GroupMemberUser{},
+		&Role{},
+		&RoleGrant{},
+		&UserRole{},
+		&GroupRole{},
+		&authAccount{},
+	)
+}
+
 var (
 	ErrMetadataScopeNotFound = errors.New("scope not found for metadata")
 )
@@ -63,6 +77,22 @@ func (r *Repository) list(ctx context.Context, resources interface{}, where stri
 	return r.reader.SearchWhere(ctx, resources, where, args, db.WithLimit(limit))
 }
 
+// listByPublicId will list resources which are identified by a public id,
+// ordered by their public id. It supports the WithLimit and
+// WithStartPageAfterId options.
+func (r *Repository) listByPublicId(ctx context.Context, resources interface{}, where string, args []interface{}, opt ...Option) error {
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	if opts.withStartPageAfterId != "" {
+		where, args = fmt.Sprintf("(%s) and public_id collate \"C\" > ?", where), append(args, opts.withStartPageAfterId)
+	}
+	return r.reader.SearchWhere(ctx, resources, where, args, db.WithLimit(limit), db.WithOrder(`public_id collate "C"`))
+}
+
 // create will create a new iam resource in the db repository with an oplog entry
 func (r *Repository) create(ctx context.Context, resource Resource, opt ...Option) (Resource, error) {
 	if resource == nil {
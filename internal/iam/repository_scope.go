@@ -420,25 +420,64 @@ func (r *Repository) DeleteScope(ctx context.Context, withPublicId string, opt .
 	return rowsDeleted, nil
 }
 
-// ListProjects in an org and supports the WithLimit option.
+// ListProjects in an org ordered by public id and supports the WithLimit and
+// WithStartPageAfterId options.
 func (r *Repository) ListProjects(ctx context.Context, withOrgId string, opt ...Option) ([]*Scope, error) {
 	if withOrgId == "" {
 		return nil, fmt.Errorf("list projects: missing org id %w", db.ErrInvalidParameter)
 	}
 	var projects []*Scope
-	err := r.list(ctx, &projects, "parent_id = ? and type = ?", []interface{}{withOrgId, scope.Project.String()}, opt...)
+	err := r.listByPublicId(ctx, &projects, "parent_id = ? and type = ?", []interface{}{withOrgId, scope.Project.String()}, opt...)
 	if err != nil {
 		return nil, fmt.Errorf("list projects: %w", err)
 	}
 	return projects, nil
 }
 
-// ListOrgs and supports the WithLimit option.
+// ListOrgs ordered by public id and supports the WithLimit and
+// WithStartPageAfterId options.
 func (r *Repository) ListOrgs(ctx context.Context, opt ...Option) ([]*Scope, error) {
 	var orgs []*Scope
-	err := r.list(ctx, &orgs, "parent_id = ? and type = ?", []interface{}{"global", scope.Org.String()}, opt...)
+	err := r.listByPublicId(ctx, &orgs, "parent_id = ? and type = ?", []interface{}{"global", scope.Org.String()}, opt...)
 	if err != nil {
 		return nil, fmt.Errorf("list orgs: %w", err)
 	}
 	return orgs, nil
 }
+
+// ListScopesRecursively returns the scope with the provided id followed by
+// every scope beneath it, with each scope listed before its children. A nil
+// slice is returned if the root scope does not exist.
+func (r *Repository) ListScopesRecursively(ctx context.Context, withRootScopeId string) ([]*Scope, error) {
+	if withRootScopeId == "" {
+		return nil, fmt.Errorf("list scopes recursively: missing root scope id %w", db.ErrInvalidParameter)
+	}
+	root, err := r.LookupScope(ctx, withRootScopeId)
+	if err != nil {
+		return nil, fmt.Errorf("list scopes recursively: %w", err)
+	}
+	if root == nil {
+		return nil, nil
+	}
+	scopes := []*Scope{root}
+	var orgs []*Scope
+	switch root.Type {
+	case scope.Global.String():
+		orgs, err = r.ListOrgs(ctx, WithLimit(-1))
+		if err != nil {
+			return nil, fmt.Errorf("list scopes recursively: %w", err)
+		}
+	case scope.Org.String():
+		orgs = []*Scope{root}
+		scopes = nil
+	}
+	for _, org := range orgs {
+		scopes = append(scopes, org)
+		projects, err := r.ListProjects(ctx, org.PublicId, WithLimit(-1))
+		if err != nil {
+			return nil, fmt.Errorf("list scopes recursively: %w", err)
+		}
+		scopes = append(scopes, projects...)
+	}
+	return scopes, nil
+}
@@ -615,3 +615,70 @@ func Test_Repository_ListOrgs(t *testing.T) {
 		})
 	}
 }
+
+func Test_Repository_ListScopesRecursively(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	require.NoError(t, conn.Where("type = 'org'").Delete(allocScope()).Error)
+
+	org1 := testOrg(t, repo, "", "")
+	proj1 := testProject(t, repo, org1.PublicId)
+	proj2 := testProject(t, repo, org1.PublicId)
+	org2 := testOrg(t, repo, "", "")
+	proj3 := testProject(t, repo, org2.PublicId)
+
+	ids := func(scopes []*Scope) []string {
+		var ret []string
+		for _, s := range scopes {
+			ret = append(ret, s.PublicId)
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name    string
+		rootId  string
+		wantIds []string
+		wantErr bool
+	}{
+		{
+			name:    "global",
+			rootId:  scope.Global.String(),
+			wantIds: []string{scope.Global.String(), org1.PublicId, proj1.PublicId, proj2.PublicId, org2.PublicId, proj3.PublicId},
+		},
+		{
+			name:    "org",
+			rootId:  org2.PublicId,
+			wantIds: []string{org2.PublicId, proj3.PublicId},
+		},
+		{
+			name:    "project",
+			rootId:  proj1.PublicId,
+			wantIds: []string{proj1.PublicId},
+		},
+		{
+			name:   "not-found",
+			rootId: "o_doesntexist",
+		},
+		{
+			name:    "missing-id",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.ListScopesRecursively(context.Background(), tt.rootId)
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			assert.ElementsMatch(tt.wantIds, ids(got))
+			if len(got) > 0 {
+				assert.Equal(tt.rootId, got[0].PublicId)
+			}
+		})
+	}
+}
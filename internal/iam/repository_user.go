@@ -194,13 +194,14 @@ func (r *Repository) DeleteUser(ctx context.Context, withPublicId string, opt ..
 	return rowsDeleted, nil
 }
 
-// ListUsers in an org and supports the WithLimit option.
+// ListUsers in an org ordered by public id and supports the WithLimit and
+// WithStartPageAfterId options.
 func (r *Repository) ListUsers(ctx context.Context, withOrgId string, opt ...Option) ([]*User, error) {
 	if withOrgId == "" {
 		return nil, fmt.Errorf("list users: missing org id %w", db.ErrInvalidParameter)
 	}
 	var users []*User
-	err := r.list(ctx, &users, "scope_id = ?", []interface{}{withOrgId}, opt...)
+	err := r.listByPublicId(ctx, &users, "scope_id = ?", []interface{}{withOrgId}, opt...)
 	if err != nil {
 		return nil, fmt.Errorf("list users: %w", err)
 	}
@@ -541,6 +541,42 @@ func TestRepository_ListUsers(t *testing.T) {
 	}
 }
 
+func TestRepository_ListUsers_MixedCaseIds(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+
+	// Ids which only differ in case are ordered differently by most
+	// collations than byte by byte, which is how page tokens are compared.
+	ids := []string{"u_aaaaaaaaaa", "u_AAAAAAAAAA", "u_aAaAaAaAaA", "u_AaAaAaAaAa", "u_Baaaaaaaaa", "u_baaaaaaaaa"}
+	for _, id := range ids {
+		u, err := NewUser(org.PublicId)
+		require.NoError(err)
+		u.PublicId = id
+		require.NoError(rw.Create(ctx, u))
+	}
+	sort.Strings(ids)
+
+	var got []string
+	var afterId string
+	for {
+		users, err := repo.ListUsers(ctx, org.PublicId, WithLimit(1), WithStartPageAfterId(afterId))
+		require.NoError(err)
+		if len(users) == 0 {
+			break
+		}
+		require.Len(users, 1)
+		afterId = users[0].PublicId
+		got = append(got, afterId)
+	}
+	assert.Equal(ids, got)
+}
+
 func TestRepository_LookupUserWithLogin(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
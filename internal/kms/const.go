@@ -24,6 +24,14 @@ const (
 
 	// KeyPurposeSessions is used as a base key to derive session-specific encryption keys
 	KeyPurposeSessions
+
+	// KeyPurposeCredentials is used for encrypting the secrets of credential
+	// libraries brokered to sessions
+	KeyPurposeCredentials
+
+	// KeyPurposeSshCertificates is used as a base key to derive the ssh
+	// certificate authority that signs session certificates
+	KeyPurposeSshCertificates
 )
 
 // String returns the key purpose cast as a string, just so it can be called as
@@ -40,6 +48,10 @@ func (k KeyPurpose) String() string {
 		return "tokens"
 	case KeyPurposeSessions:
 		return "sessions"
+	case KeyPurposeCredentials:
+		return "credentials"
+	case KeyPurposeSshCertificates:
+		return "ssh certificates"
 	default:
 		return "unknown"
 	}
@@ -61,6 +73,10 @@ const (
 	KeyTypeTokenKeyVersion
 	KeyTypeSessionKey
 	KeyTypeSessionKeyVersion
+	KeyTypeCredentialKey
+	KeyTypeCredentialKeyVersion
+	KeyTypeSshCertificateKey
+	KeyTypeSshCertificateKeyVersion
 )
 
 // String returns the key type cast as a string, just so it can be called as
@@ -87,6 +103,14 @@ func (k KeyType) String() string {
 		return "sessionKey"
 	case KeyTypeSessionKeyVersion:
 		return "sessionKeyVersion"
+	case KeyTypeCredentialKey:
+		return "credentialKey"
+	case KeyTypeCredentialKeyVersion:
+		return "credentialKeyVersion"
+	case KeyTypeSshCertificateKey:
+		return "sshCertificateKey"
+	case KeyTypeSshCertificateKeyVersion:
+		return "sshCertificateKeyVersion"
 
 	default:
 		return "unknown"
@@ -0,0 +1,77 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms/store"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultCredentialKeyTableName = "kms_credential_key"
+)
+
+type CredentialKey struct {
+	*store.CredentialKey
+	tableName string `gorm:"-"`
+}
+
+// NewCredentialKey creates a new in memory key.  No options
+// are currently supported.
+func NewCredentialKey(rootKeyId string, opt ...Option) (*CredentialKey, error) {
+	if rootKeyId == "" {
+		return nil, fmt.Errorf("new root key: missing root key id: %w", db.ErrInvalidParameter)
+	}
+	c := &CredentialKey{
+		CredentialKey: &store.CredentialKey{
+			RootKeyId: rootKeyId,
+		},
+	}
+	return c, nil
+}
+
+// AllocCredentialKey will allocate a key
+func AllocCredentialKey() CredentialKey {
+	return CredentialKey{
+		CredentialKey: &store.CredentialKey{},
+	}
+}
+
+// Clone creates a clone of the key
+func (k *CredentialKey) Clone() interface{} {
+	cp := proto.Clone(k.CredentialKey)
+	return &CredentialKey{
+		CredentialKey: cp.(*store.CredentialKey),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the key
+// before it's written.
+func (k *CredentialKey) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if k.PrivateId == "" {
+		return fmt.Errorf("credential key vet for write: missing private id: %w", db.ErrInvalidParameter)
+	}
+	if opType == db.CreateOp {
+		if k.RootKeyId == "" {
+			return fmt.Errorf("credential key vet for write: missing root key id: %w", db.ErrInvalidParameter)
+		}
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (k *CredentialKey) TableName() string {
+	if k.tableName != "" {
+		return k.tableName
+	}
+	return DefaultCredentialKeyTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (k *CredentialKey) SetTableName(n string) {
+	k.tableName = n
+}
@@ -0,0 +1,48 @@
+package kms
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Errors returned from this package may be tested against these errors
+// with errors.Is.
+var (
+	// ErrKeyVersionInUse is returned when destroying a key version which
+	// values stored in the database are still encrypted with.
+	ErrKeyVersionInUse = errors.New("key version in use")
+
+	// ErrCurrentKeyVersion is returned when destroying the current version of
+	// a key, which new values are encrypted with.
+	ErrCurrentKeyVersion = errors.New("current key version")
+)
+
+// KeyVersionInUseError is returned when a key version cannot be destroyed
+// because values stored in the database are still encrypted with it. It
+// matches ErrKeyVersionInUse.
+type KeyVersionInUseError struct {
+	KeyVersionId string
+	// BlockingRows is the number of rows still encrypted with the key
+	// version, by table.
+	BlockingRows map[string]int64
+}
+
+func (e *KeyVersionInUseError) Error() string {
+	tables := make([]string, 0, len(e.BlockingRows))
+	for t := range e.BlockingRows {
+		tables = append(tables, t)
+	}
+	sort.Strings(tables)
+	counts := make([]string, 0, len(tables))
+	for _, t := range tables {
+		counts = append(counts, fmt.Sprintf("%s: %d", t, e.BlockingRows[t]))
+	}
+	return fmt.Sprintf("%s: %s is still used by %s", ErrKeyVersionInUse, e.KeyVersionId, strings.Join(counts, ", "))
+}
+
+// Is returns true if target is ErrKeyVersionInUse.
+func (e *KeyVersionInUseError) Is(target error) bool {
+	return target == ErrKeyVersionInUse
+}
@@ -7,16 +7,21 @@ import (
 )
 
 const (
-	RootKeyPrefix            = "krk"
-	RootKeyVersionPrefix     = "krkv"
-	DatabaseKeyPrefix        = "kdk"
-	DatabaseKeyVersionPrefix = "kdkv"
-	OplogKeyPrefix           = "kopk"
-	OplogKeyVersionPrefix    = "kopkv"
-	TokenKeyPrefix           = "ktk"
-	TokenKeyVersionPrefix    = "ktv"
-	SessionKeyPrefix         = "ksk"
-	SessionKeyVersionPrefix  = "kskv"
+	RootKeyPrefix                  = "krk"
+	RootKeyVersionPrefix           = "krkv"
+	DatabaseKeyPrefix              = "kdk"
+	DatabaseKeyVersionPrefix       = "kdkv"
+	OplogKeyPrefix                 = "kopk"
+	OplogKeyVersionPrefix          = "kopkv"
+	TokenKeyPrefix                 = "ktk"
+	TokenKeyVersionPrefix          = "ktv"
+	SessionKeyPrefix               = "ksk"
+	SessionKeyVersionPrefix        = "kskv"
+	CredentialKeyPrefix            = "kck"
+	CredentialKeyVersionPrefix     = "kckv"
+	SshCertificateKeyPrefix        = "kshk"
+	SshCertificateKeyVersionPrefix = "kshkv"
+	KeyRotationPrefix              = "kkr"
 )
 
 func newRootKeyId() (string, error) {
@@ -98,3 +103,43 @@ func newSessionKeyVersionId() (string, error) {
 	}
 	return id, nil
 }
+
+func newCredentialKeyId() (string, error) {
+	id, err := db.NewPublicId(CredentialKeyPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new credential key id: %w", err)
+	}
+	return id, nil
+}
+
+func newCredentialKeyVersionId() (string, error) {
+	id, err := db.NewPublicId(CredentialKeyVersionPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new credential key version id: %w", err)
+	}
+	return id, nil
+}
+
+func newSshCertificateKeyId() (string, error) {
+	id, err := db.NewPublicId(SshCertificateKeyPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new ssh certificate key id: %w", err)
+	}
+	return id, nil
+}
+
+func newSshCertificateKeyVersionId() (string, error) {
+	id, err := db.NewPublicId(SshCertificateKeyVersionPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new ssh certificate key version id: %w", err)
+	}
+	return id, nil
+}
+
+func newKeyRotationId() (string, error) {
+	id, err := db.NewPublicId(KeyRotationPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new key rotation id: %w", err)
+	}
+	return id, nil
+}
@@ -60,4 +60,29 @@ func Test_Ids(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, strings.HasPrefix(id, SessionKeyVersionPrefix+"_"))
 	})
+	t.Run("kck", func(t *testing.T) {
+		id, err := newCredentialKeyId()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, CredentialKeyPrefix+"_"))
+	})
+	t.Run("kckv", func(t *testing.T) {
+		id, err := newCredentialKeyVersionId()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, CredentialKeyVersionPrefix+"_"))
+	})
+	t.Run("kshk", func(t *testing.T) {
+		id, err := newSshCertificateKeyId()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, SshCertificateKeyPrefix+"_"))
+	})
+	t.Run("kshkv", func(t *testing.T) {
+		id, err := newSshCertificateKeyVersionId()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, SshCertificateKeyVersionPrefix+"_"))
+	})
+	t.Run("kkr", func(t *testing.T) {
+		id, err := newKeyRotationId()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, KeyRotationPrefix+"_"))
+	})
 }
@@ -20,6 +20,10 @@ import (
 //	kms_oplog_key_version
 //	kms_session_key
 //	kms_session_key_version
+//	kms_credential_key
+//	kms_credential_key_version
+//	kms_ssh_certificate_key
+//	kms_ssh_certificate_key_version
 
 func TestRootKeyVersion_ImmutableFields(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,67 @@
+package kms
+
+import (
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+)
+
+const (
+	DefaultKeyRotationTableName = "kms_key_rotation"
+)
+
+// KeyRotationStatus is the status of the rewrapping of the values encrypted
+// with the keys of a scope after they were rotated.
+type KeyRotationStatus string
+
+const (
+	// KeyRotationStatusRewrapping means values encrypted with previous key
+	// versions are being re-encrypted with the versions created by the
+	// rotation.
+	KeyRotationStatusRewrapping KeyRotationStatus = "rewrapping"
+
+	// KeyRotationStatusCompleted means all values that can be rewrapped are
+	// encrypted with the versions created by the rotation.
+	KeyRotationStatusCompleted KeyRotationStatus = "completed"
+
+	// KeyRotationStatusSuperseded means the keys of the scope were rotated
+	// again before the rewrapping completed. The rewrapping continues as part
+	// of the newer rotation.
+	KeyRotationStatusSuperseded KeyRotationStatus = "superseded"
+)
+
+// KeyRotation is a rotation of the keys of a scope, which created a new
+// version of its root key and of each of its DEKs.
+type KeyRotation struct {
+	PrivateId        string `gorm:"primary_key"`
+	ScopeId          string
+	RootKeyVersionId string
+	Status           string
+	// RewrappedCount is the number of values re-encrypted with the versions
+	// created by the rotation so far.
+	RewrappedCount int64
+	// LastError is the last error the controllers ran into while rewrapping
+	// values.
+	LastError  string               `gorm:"default:null"`
+	CreateTime *timestamp.Timestamp `gorm:"default:current_timestamp"`
+	UpdateTime *timestamp.Timestamp `gorm:"default:current_timestamp"`
+
+	// PendingCount is the number of values which are still encrypted with
+	// previous key versions. It is only set while rewrapping.
+	PendingCount int64 `gorm:"-"`
+
+	tableName string `gorm:"-"`
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (k *KeyRotation) TableName() string {
+	if k.tableName != "" {
+		return k.tableName
+	}
+	return DefaultKeyRotationTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (k *KeyRotation) SetTableName(n string) {
+	k.tableName = n
+}
@@ -2,10 +2,12 @@ package kms
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"sync"
 
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/go-hclog"
 	wrapping "github.com/hashicorp/go-kms-wrapping"
@@ -44,8 +46,8 @@ func (e *ExternalWrappers) Recovery() wrapping.Wrapper {
 }
 
 // Kms is a way to access wrappers for a given scope and purpose. Since keys can
-// never change, only be added or (eventually) removed, it opportunistically
-// caches, going to the database as needed.
+// never change, only be added or destroyed, it opportunistically caches, going
+// to the database as needed.
 type Kms struct {
 	logger hclog.Logger
 
@@ -53,6 +55,10 @@ type Kms struct {
 	// current encrypting key and all previous key versions, for decryption
 	scopePurposeCache sync.Map
 
+	// rotationsSeen holds the ids of the key rotations whose scopes have been
+	// cleared from scopePurposeCache since they were rotated
+	rotationsSeen sync.Map
+
 	externalScopeCache      map[string]*ExternalWrappers
 	externalScopeCacheMutex sync.RWMutex
 
@@ -151,7 +157,7 @@ func (k *Kms) GetWrapper(ctx context.Context, scopeId string, purpose KeyPurpose
 	}
 
 	switch purpose {
-	case KeyPurposeOplog, KeyPurposeDatabase, KeyPurposeTokens, KeyPurposeSessions:
+	case KeyPurposeOplog, KeyPurposeDatabase, KeyPurposeTokens, KeyPurposeSessions, KeyPurposeCredentials, KeyPurposeSshCertificates:
 	case KeyPurposeUnknown:
 		return nil, errors.New("key purpose not specified")
 	default:
@@ -192,6 +198,23 @@ func (k *Kms) GetWrapper(ctx context.Context, scopeId string, purpose KeyPurpose
 	return wrapper, nil
 }
 
+// DestroyKeyVersion deletes a key version once no value stored in the
+// database is encrypted with it anymore, and evicts the cached wrappers which
+// contain it. See Repository.DestroyKeyVersion for the checks performed.
+func (k *Kms) DestroyKeyVersion(ctx context.Context, keyVersionId string) (int, error) {
+	rowsDeleted, err := k.repo.DestroyKeyVersion(ctx, keyVersionId)
+	if err != nil {
+		return db.NoRowsAffected, err
+	}
+	k.scopePurposeCache.Range(func(key, value interface{}) bool {
+		if value.(*multiwrapper.MultiWrapper).WrapperForKeyID(keyVersionId) != nil {
+			k.scopePurposeCache.Delete(key)
+		}
+		return true
+	})
+	return rowsDeleted, nil
+}
+
 func (k *Kms) loadRoot(ctx context.Context, scopeId string, opt ...Option) (*multiwrapper.MultiWrapper, string, error) {
 	opts := getOpts(opt...)
 	repo := opts.withRepository
@@ -294,6 +317,10 @@ func (k *Kms) loadDek(ctx context.Context, scopeId string, purpose KeyPurpose, r
 		keys, err = repo.ListTokenKeys(ctx)
 	case KeyPurposeSessions:
 		keys, err = repo.ListSessionKeys(ctx)
+	case KeyPurposeCredentials:
+		keys, err = repo.ListCredentialKeys(ctx)
+	case KeyPurposeSshCertificates:
+		keys, err = repo.ListSshCertificateKeys(ctx)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("error listing root keys: %w", err)
@@ -305,6 +332,20 @@ func (k *Kms) loadDek(ctx context.Context, scopeId string, purpose KeyPurpose, r
 			break
 		}
 	}
+	if keyId == "" {
+		// Scopes created before credential and ssh certificate keys were
+		// introduced do not have them, so they are created the first time
+		// they are needed.
+		switch purpose {
+		case KeyPurposeCredentials:
+			keyId, err = createCredentialKey(ctx, repo, rootWrapper)
+		case KeyPurposeSshCertificates:
+			keyId, err = createSshCertificateKey(ctx, repo, rootWrapper)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error creating %s key for scope %s: %w", purpose.String(), scopeId, err)
+		}
+	}
 	if keyId == "" {
 		return nil, fmt.Errorf("error finding %s key for scope %s", purpose.String(), scopeId)
 	}
@@ -319,6 +360,10 @@ func (k *Kms) loadDek(ctx context.Context, scopeId string, purpose KeyPurpose, r
 		keyVersions, err = repo.ListTokenKeyVersions(ctx, rootWrapper, keyId, WithOrder("version desc"))
 	case KeyPurposeSessions:
 		keyVersions, err = repo.ListSessionKeyVersions(ctx, rootWrapper, keyId, WithOrder("version desc"))
+	case KeyPurposeCredentials:
+		keyVersions, err = repo.ListCredentialKeyVersions(ctx, rootWrapper, keyId, WithOrder("version desc"))
+	case KeyPurposeSshCertificates:
+		keyVersions, err = repo.ListSshCertificateKeyVersions(ctx, rootWrapper, keyId, WithOrder("version desc"))
 	}
 	if err != nil {
 		return nil, fmt.Errorf("error looking up %s key versions for scope %s with key ID %s: %w", purpose.String(), scopeId, rootWrapper.KeyID(), err)
@@ -347,3 +392,27 @@ func (k *Kms) loadDek(ctx context.Context, scopeId string, purpose KeyPurpose, r
 
 	return multi, err
 }
+
+func createCredentialKey(ctx context.Context, repo *Repository, rootWrapper wrapping.Wrapper) (string, error) {
+	key, err := generateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	ck, _, err := repo.CreateCredentialKey(ctx, rootWrapper, key)
+	if err != nil {
+		return "", err
+	}
+	return ck.GetPrivateId(), nil
+}
+
+func createSshCertificateKey(ctx context.Context, repo *Repository, rootWrapper wrapping.Wrapper) (string, error) {
+	key, err := generateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	sk, _, err := repo.CreateSshCertificateKey(ctx, rootWrapper, key)
+	if err != nil {
+		return "", err
+	}
+	return sk.GetPrivateId(), nil
+}
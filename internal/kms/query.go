@@ -0,0 +1,100 @@
+package kms
+
+const (
+	// keyVersionIdsQuery returns the ids of the versions of the DEK of a
+	// scope, newest first. It is formatted with the table of the DEK, the
+	// table of its versions and the column of the versions referencing the
+	// DEK.
+	keyVersionIdsQuery = `
+select v.private_id
+  from %[2]s v
+  join %[1]s k
+    on k.private_id = v.%[3]s
+  join kms_root_key r
+    on r.private_id = k.root_key_id
+ where r.scope_id = ?
+ order by v.version desc;
+`
+
+	// previousDekVersionsQuery returns the id and encrypted key of the
+	// versions of the DEK of a root key which are not encrypted with the
+	// given root key version. It is formatted with the table of the DEK, the
+	// table of its versions and the column of the versions referencing the
+	// DEK.
+	previousDekVersionsQuery = `
+select v.private_id, v.key
+  from %[2]s v
+  join %[1]s k
+    on k.private_id = v.%[3]s
+ where k.root_key_id = ?
+   and v.root_key_version_id <> ?;
+`
+
+	// rewrapDekVersionQuery sets the key of a DEK version re-encrypted with
+	// a root key version. It is formatted with the table of the versions.
+	rewrapDekVersionQuery = `
+update %s
+   set key = ?,
+       root_key_version_id = ?
+ where private_id = ?;
+`
+
+	// rewrapPendingCountQuery counts the values of a table which are
+	// encrypted with one of the given key versions. It is formatted with the
+	// table and its key id column.
+	rewrapPendingCountQuery = `
+select count(*)
+  from %s
+ where %s in (?);
+`
+
+	supersedeKeyRotationsQuery = `
+update kms_key_rotation
+   set status = 'superseded'
+ where scope_id = ?
+   and status = 'rewrapping';
+`
+
+	updateKeyRotationQuery = `
+update kms_key_rotation
+   set rewrapped_count = rewrapped_count + ?,
+       status = ?,
+       last_error = ?
+ where private_id = ?
+   and status = 'rewrapping';
+`
+
+	// currentKeyVersionIdQuery returns the id of the newest version of the
+	// key of the given key version. It is formatted with the table of the
+	// versions and its column referencing the key.
+	currentKeyVersionIdQuery = `
+select private_id
+  from %[1]s
+ where %[2]s = (select %[2]s from %[1]s where private_id = ?)
+ order by version desc
+ limit 1;
+`
+
+	// keyVersionUsageQuery counts the rows of a table which reference a key
+	// version. It is formatted with the table and its key id column.
+	keyVersionUsageQuery = `
+select count(*)
+  from %s
+ where %s = ?;
+`
+
+	// oplogEntryDataQuery returns a batch of the encrypted oplog entries.
+	oplogEntryDataQuery = `
+select id, data
+  from oplog_entry
+ where id > ?
+ order by id
+ limit ?;
+`
+
+	// deleteKeyVersionQuery is formatted with the table of the key version.
+	deleteKeyVersionQuery = `
+delete from %s
+ where private_id = ?;
+`
+)
@@ -138,6 +138,24 @@ func CreateKeysTx(ctx context.Context, dbReader db.Reader, dbWriter db.Writer, r
 		return nil, fmt.Errorf("create keys: unable to create session key in scope %s: %w", scopeId, err)
 	}
 
+	k, err = generateKey(randomReader)
+	if err != nil {
+		return nil, fmt.Errorf("create keys: error generating random bytes for credential key in scope %s: %w", scopeId, err)
+	}
+	credentialKey, credentialKeyVersion, err := createCredentialKeyTx(ctx, dbReader, dbWriter, rkvWrapper, k)
+	if err != nil {
+		return nil, fmt.Errorf("create keys: unable to create credential key in scope %s: %w", scopeId, err)
+	}
+
+	k, err = generateKey(randomReader)
+	if err != nil {
+		return nil, fmt.Errorf("create keys: error generating random bytes for ssh certificate key in scope %s: %w", scopeId, err)
+	}
+	sshCertificateKey, sshCertificateKeyVersion, err := createSshCertificateKeyTx(ctx, dbReader, dbWriter, rkvWrapper, k)
+	if err != nil {
+		return nil, fmt.Errorf("create keys: unable to create ssh certificate key in scope %s: %w", scopeId, err)
+	}
+
 	k, err = generateKey(randomReader)
 	if err != nil {
 		return nil, fmt.Errorf("create keys: error generating random bytes for token key in scope %s: %w", scopeId, err)
@@ -148,16 +166,20 @@ func CreateKeysTx(ctx context.Context, dbReader db.Reader, dbWriter db.Writer, r
 	}
 
 	keys := Keys{
-		KeyTypeRootKey:            rootKey,
-		KeyTypeRootKeyVersion:     rootKeyVersion,
-		KeyTypeDatabaseKey:        dbKey,
-		KeyTypeDatabaseKeyVersion: dbKeyVersion,
-		KeyTypeOplogKey:           oplogKey,
-		KeyTypeOplogKeyVersion:    oplogKeyVersion,
-		KeyTypeSessionKey:         sessionKey,
-		KeyTypeSessionKeyVersion:  sessionKeyVersion,
-		KeyTypeTokenKey:           tokenKey,
-		KeyTypeTokenKeyVersion:    tokenKeyVersion,
+		KeyTypeRootKey:                  rootKey,
+		KeyTypeRootKeyVersion:           rootKeyVersion,
+		KeyTypeDatabaseKey:              dbKey,
+		KeyTypeDatabaseKeyVersion:       dbKeyVersion,
+		KeyTypeOplogKey:                 oplogKey,
+		KeyTypeOplogKeyVersion:          oplogKeyVersion,
+		KeyTypeSessionKey:               sessionKey,
+		KeyTypeSessionKeyVersion:        sessionKeyVersion,
+		KeyTypeTokenKey:                 tokenKey,
+		KeyTypeTokenKeyVersion:          tokenKeyVersion,
+		KeyTypeCredentialKey:            credentialKey,
+		KeyTypeCredentialKeyVersion:     credentialKeyVersion,
+		KeyTypeSshCertificateKey:        sshCertificateKey,
+		KeyTypeSshCertificateKeyVersion: sshCertificateKeyVersion,
 	}
 	return keys, nil
 }
@@ -0,0 +1,420 @@
+package kms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/boundary/internal/db"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/aead"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/multiwrapper"
+	"google.golang.org/protobuf/proto"
+)
+
+// dekTable describes the tables of the DEK of a key purpose and of its
+// versions.
+type dekTable struct {
+	keyTable     string
+	versionTable string
+	keyIdColumn  string
+}
+
+var dekTables = map[KeyPurpose]dekTable{
+	KeyPurposeDatabase:        {DefaultDatabaseKeyTableName, DefaultDatabaseKeyVersionTableName, "database_key_id"},
+	KeyPurposeOplog:           {DefaultOplogKeyTableName, DefaultOplogKeyVersionTableName, "oplog_key_id"},
+	KeyPurposeTokens:          {DefaultTokenKeyTableName, DefaultTokenKeyVersionTableName, "token_key_id"},
+	KeyPurposeSessions:        {DefaultSessionKeyTableName, DefaultSessionKeyVersionTableName, "session_key_id"},
+	KeyPurposeCredentials:     {DefaultCredentialKeyTableName, DefaultCredentialKeyVersionTableName, "credential_key_id"},
+	KeyPurposeSshCertificates: {DefaultSshCertificateKeyTableName, DefaultSshCertificateKeyVersionTableName, "ssh_certificate_key_id"},
+}
+
+// RotateKeys creates a new version of the root key of the scope, encrypted
+// with the rootWrapper, and a new version of each of the scope's DEKs,
+// encrypted with the new root key version. The previous versions of the DEKs
+// are re-encrypted with the new root key version as well, so that previous
+// root key versions can be destroyed. Values are encrypted with the new
+// versions from then on; values encrypted with previous versions can still be
+// decrypted and are rewrapped in the background. It returns the KeyRotation
+// recording the progress of the rewrapping, which supersedes any previous
+// rotation of the scope that has not completed. There are no valid options at
+// this time.
+func (r *Repository) RotateKeys(ctx context.Context, rootWrapper wrapping.Wrapper, scopeId string, randomReader io.Reader, opt ...Option) (*KeyRotation, error) {
+	if rootWrapper == nil {
+		return nil, fmt.Errorf("rotate keys: missing root wrapper: %w", db.ErrInvalidParameter)
+	}
+	if scopeId == "" {
+		return nil, fmt.Errorf("rotate keys: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	if randomReader == nil {
+		return nil, fmt.Errorf("rotate keys: missing random reader: %w", db.ErrInvalidParameter)
+	}
+	id, err := newKeyRotationId()
+	if err != nil {
+		return nil, fmt.Errorf("rotate keys: %w", err)
+	}
+
+	var rotation *KeyRotation
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			rk := AllocRootKey()
+			if err := reader.LookupWhere(ctx, &rk, "scope_id = ?", scopeId); err != nil {
+				return fmt.Errorf("unable to lookup root key: %w", err)
+			}
+			key, err := generateKey(randomReader)
+			if err != nil {
+				return err
+			}
+			rkv := AllocRootKeyVersion()
+			if rkv.PrivateId, err = newRootKeyVersionId(); err != nil {
+				return err
+			}
+			rkv.RootKeyId = rk.PrivateId
+			rkv.Key = key
+			if err := rkv.Encrypt(ctx, rootWrapper); err != nil {
+				return err
+			}
+			// no oplog entries for root key versions
+			if err := w.Create(ctx, &rkv); err != nil {
+				return fmt.Errorf("root key version create: %w", err)
+			}
+
+			rkvWrapper := aead.NewWrapper(nil)
+			if _, err := rkvWrapper.SetConfig(map[string]string{
+				"key_id": rkv.PrivateId,
+			}); err != nil {
+				return fmt.Errorf("error setting config on aead root wrapper: %w", err)
+			}
+			if err := rkvWrapper.SetAESGCMKeyBytes(key); err != nil {
+				return fmt.Errorf("error setting key bytes on aead root wrapper: %w", err)
+			}
+			if err := rewrapDekVersionsTx(ctx, reader, w, rootWrapper, rkvWrapper, rk.PrivateId); err != nil {
+				return fmt.Errorf("unable to re-encrypt key versions: %w", err)
+			}
+			for _, purpose := range []KeyPurpose{
+				KeyPurposeDatabase,
+				KeyPurposeOplog,
+				KeyPurposeTokens,
+				KeyPurposeSessions,
+				KeyPurposeCredentials,
+				KeyPurposeSshCertificates,
+			} {
+				key, err := generateKey(randomReader)
+				if err != nil {
+					return err
+				}
+				if err := createDekVersionTx(ctx, reader, w, rkvWrapper, rk.PrivateId, purpose, key); err != nil {
+					return fmt.Errorf("unable to create %s key version: %w", purpose.String(), err)
+				}
+			}
+
+			if _, err := w.Exec(ctx, supersedeKeyRotationsQuery, []interface{}{scopeId}); err != nil {
+				return fmt.Errorf("unable to supersede previous rotations: %w", err)
+			}
+			rotation = &KeyRotation{
+				PrivateId:        id,
+				ScopeId:          scopeId,
+				RootKeyVersionId: rkv.PrivateId,
+				Status:           string(KeyRotationStatusRewrapping),
+			}
+			// no oplog entries for key rotations
+			if err := w.Create(ctx, rotation); err != nil {
+				return fmt.Errorf("key rotation create: %w", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("rotate keys: %w for scope %s", err, scopeId)
+	}
+	return rotation, nil
+}
+
+// createDekVersionTx creates a new version of the DEK of the root key for the
+// purpose, encrypted with the root key version wrapper. Scopes created before
+// credential and ssh certificate keys were introduced may not have them yet,
+// in which case the key is created along with its first version.
+func createDekVersionTx(ctx context.Context, r db.Reader, w db.Writer, rkvWrapper wrapping.Wrapper, rootKeyId string, purpose KeyPurpose, key []byte) error {
+	var kv interface {
+		Encrypt(context.Context, wrapping.Wrapper) error
+	}
+	var err error
+	switch purpose {
+	case KeyPurposeDatabase:
+		k := AllocDatabaseKey()
+		if err := r.LookupWhere(ctx, &k, "root_key_id = ?", rootKeyId); err != nil {
+			return err
+		}
+		v := AllocDatabaseKeyVersion()
+		if v.PrivateId, err = newDatabaseKeyVersionId(); err != nil {
+			return err
+		}
+		v.DatabaseKeyId = k.PrivateId
+		v.RootKeyVersionId = rkvWrapper.KeyID()
+		v.Key = key
+		kv = &v
+	case KeyPurposeOplog:
+		k := AllocOplogKey()
+		if err := r.LookupWhere(ctx, &k, "root_key_id = ?", rootKeyId); err != nil {
+			return err
+		}
+		v := AllocOplogKeyVersion()
+		if v.PrivateId, err = newOplogKeyVersionId(); err != nil {
+			return err
+		}
+		v.OplogKeyId = k.PrivateId
+		v.RootKeyVersionId = rkvWrapper.KeyID()
+		v.Key = key
+		kv = &v
+	case KeyPurposeTokens:
+		k := AllocTokenKey()
+		if err := r.LookupWhere(ctx, &k, "root_key_id = ?", rootKeyId); err != nil {
+			return err
+		}
+		v := AllocTokenKeyVersion()
+		if v.PrivateId, err = newTokenKeyVersionId(); err != nil {
+			return err
+		}
+		v.TokenKeyId = k.PrivateId
+		v.RootKeyVersionId = rkvWrapper.KeyID()
+		v.Key = key
+		kv = &v
+	case KeyPurposeSessions:
+		k := AllocSessionKey()
+		if err := r.LookupWhere(ctx, &k, "root_key_id = ?", rootKeyId); err != nil {
+			return err
+		}
+		v := AllocSessionKeyVersion()
+		if v.PrivateId, err = newSessionKeyVersionId(); err != nil {
+			return err
+		}
+		v.SessionKeyId = k.PrivateId
+		v.RootKeyVersionId = rkvWrapper.KeyID()
+		v.Key = key
+		kv = &v
+	case KeyPurposeCredentials:
+		k := AllocCredentialKey()
+		err := r.LookupWhere(ctx, &k, "root_key_id = ?", rootKeyId)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			_, _, err = createCredentialKeyTx(ctx, r, w, rkvWrapper, key)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		v := AllocCredentialKeyVersion()
+		if v.PrivateId, err = newCredentialKeyVersionId(); err != nil {
+			return err
+		}
+		v.CredentialKeyId = k.PrivateId
+		v.RootKeyVersionId = rkvWrapper.KeyID()
+		v.Key = key
+		kv = &v
+	case KeyPurposeSshCertificates:
+		k := AllocSshCertificateKey()
+		err := r.LookupWhere(ctx, &k, "root_key_id = ?", rootKeyId)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			_, _, err = createSshCertificateKeyTx(ctx, r, w, rkvWrapper, key)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		v := AllocSshCertificateKeyVersion()
+		if v.PrivateId, err = newSshCertificateKeyVersionId(); err != nil {
+			return err
+		}
+		v.SshCertificateKeyId = k.PrivateId
+		v.RootKeyVersionId = rkvWrapper.KeyID()
+		v.Key = key
+		kv = &v
+	default:
+		return fmt.Errorf("unsupported purpose %q", purpose)
+	}
+	if err := kv.Encrypt(ctx, rkvWrapper); err != nil {
+		return err
+	}
+	// no oplog entries for key versions
+	return w.Create(ctx, kv)
+}
+
+// rewrapDekVersionsTx re-encrypts the versions of the DEKs of the root key
+// which are encrypted with previous versions of the root key, which are
+// decrypted with the rootWrapper, with the root key version wrapper. The
+// previous root key versions then no longer encrypt any DEK version.
+func rewrapDekVersionsTx(ctx context.Context, r db.Reader, w db.Writer, rootWrapper, rkvWrapper wrapping.Wrapper, rootKeyId string) error {
+	var rkvs []*RootKeyVersion
+	if err := r.SearchWhere(ctx, &rkvs, "root_key_id = ? and private_id <> ?", []interface{}{rootKeyId, rkvWrapper.KeyID()}, db.WithLimit(-1)); err != nil {
+		return fmt.Errorf("unable to list root key versions: %w", err)
+	}
+	if len(rkvs) == 0 {
+		return nil
+	}
+	var previous *multiwrapper.MultiWrapper
+	for _, rkv := range rkvs {
+		if err := rkv.Decrypt(ctx, rootWrapper); err != nil {
+			return err
+		}
+		wrapper := aead.NewWrapper(nil)
+		if _, err := wrapper.SetConfig(map[string]string{
+			"key_id": rkv.PrivateId,
+		}); err != nil {
+			return fmt.Errorf("error setting config on aead root wrapper: %w", err)
+		}
+		if err := wrapper.SetAESGCMKeyBytes(rkv.Key); err != nil {
+			return fmt.Errorf("error setting key bytes on aead root wrapper: %w", err)
+		}
+		if previous == nil {
+			previous = multiwrapper.NewMultiWrapper(wrapper)
+		} else {
+			previous.AddWrapper(wrapper)
+		}
+	}
+
+	type dekVersion struct {
+		privateId string
+		key       []byte
+	}
+	for purpose, t := range dekTables {
+		rows, err := r.Query(ctx, fmt.Sprintf(previousDekVersionsQuery, t.keyTable, t.versionTable, t.keyIdColumn), []interface{}{rootKeyId, rkvWrapper.KeyID()})
+		if err != nil {
+			return fmt.Errorf("unable to list %s key versions: %w", purpose.String(), err)
+		}
+		var versions []dekVersion
+		for rows.Next() {
+			var v dekVersion
+			if err := rows.Scan(&v.privateId, &v.key); err != nil {
+				rows.Close()
+				return fmt.Errorf("unable to list %s key versions: %w", purpose.String(), err)
+			}
+			versions = append(versions, v)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return fmt.Errorf("unable to list %s key versions: %w", purpose.String(), err)
+		}
+
+		for _, v := range versions {
+			var blob wrapping.EncryptedBlobInfo
+			if err := proto.Unmarshal(v.key, &blob); err != nil {
+				return fmt.Errorf("unable to unmarshal key version %s: %w", v.privateId, err)
+			}
+			pt, err := previous.Decrypt(ctx, &blob, nil)
+			if err != nil {
+				return fmt.Errorf("unable to decrypt key version %s: %w", v.privateId, err)
+			}
+			rewrapped, err := rkvWrapper.Encrypt(ctx, pt, nil)
+			if err != nil {
+				return fmt.Errorf("unable to encrypt key version %s: %w", v.privateId, err)
+			}
+			ct, err := proto.Marshal(rewrapped)
+			if err != nil {
+				return fmt.Errorf("unable to marshal key version %s: %w", v.privateId, err)
+			}
+			// no oplog entries for key versions
+			if _, err := w.Exec(ctx, fmt.Sprintf(rewrapDekVersionQuery, t.versionTable), []interface{}{ct, rkvWrapper.KeyID(), v.privateId}); err != nil {
+				return fmt.Errorf("unable to update key version %s: %w", v.privateId, err)
+			}
+		}
+	}
+	return nil
+}
+
+// LookupLatestKeyRotation returns the most recent rotation of the keys of the
+// scope. If the keys of the scope were never rotated, it will return nil,
+// nil.
+func (r *Repository) LookupLatestKeyRotation(ctx context.Context, scopeId string, opt ...Option) (*KeyRotation, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("lookup latest key rotation: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	var rotations []*KeyRotation
+	if err := r.reader.SearchWhere(ctx, &rotations, "scope_id = ?", []interface{}{scopeId}, db.WithOrder("create_time desc"), db.WithLimit(1)); err != nil {
+		return nil, fmt.Errorf("lookup latest key rotation: %w", err)
+	}
+	if len(rotations) == 0 {
+		return nil, nil
+	}
+	return rotations[0], nil
+}
+
+// listRewrappingKeyRotations returns the rotations whose rewrapping has not
+// completed.
+func (r *Repository) listRewrappingKeyRotations(ctx context.Context) ([]*KeyRotation, error) {
+	var rotations []*KeyRotation
+	if err := r.reader.SearchWhere(ctx, &rotations, "status = ?", []interface{}{string(KeyRotationStatusRewrapping)}, db.WithLimit(-1)); err != nil {
+		return nil, fmt.Errorf("list rewrapping key rotations: %w", err)
+	}
+	return rotations, nil
+}
+
+// updateKeyRotation adds the number of values rewrapped to the rotation and
+// sets its status and last error. It does not update a rotation that was
+// superseded in the meantime.
+func (r *Repository) updateKeyRotation(ctx context.Context, privateId string, rewrapped int, status KeyRotationStatus, lastError string) error {
+	var errValue interface{}
+	if lastError != "" {
+		errValue = lastError
+	}
+	_, err := r.writer.Exec(ctx, updateKeyRotationQuery, []interface{}{rewrapped, string(status), errValue, privateId})
+	if err != nil {
+		return fmt.Errorf("update key rotation: %w for %s", err, privateId)
+	}
+	return nil
+}
+
+// keyVersionIds returns the id of the current version of the scope's DEK for
+// the purpose and the ids of its previous versions.
+func (r *Repository) keyVersionIds(ctx context.Context, scopeId string, purpose KeyPurpose) (string, []string, error) {
+	t, ok := dekTables[purpose]
+	if !ok {
+		return "", nil, fmt.Errorf("key version ids: unsupported purpose %q", purpose)
+	}
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(keyVersionIdsQuery, t.keyTable, t.versionTable, t.keyIdColumn), []interface{}{scopeId})
+	if err != nil {
+		return "", nil, fmt.Errorf("key version ids: %w", err)
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return "", nil, fmt.Errorf("key version ids: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return "", nil, fmt.Errorf("key version ids: %w", err)
+	}
+	if len(ids) == 0 {
+		return "", nil, nil
+	}
+	return ids[0], ids[1:], nil
+}
+
+// rewrapPendingCount returns the number of values in the table which are
+// encrypted with one of the key versions.
+func (r *Repository) rewrapPendingCount(ctx context.Context, table, keyIdColumn string, keyVersionIds []string) (int64, error) {
+	if len(keyVersionIds) == 0 {
+		return 0, nil
+	}
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(rewrapPendingCountQuery, table, keyIdColumn), []interface{}{keyVersionIds})
+	if err != nil {
+		return 0, fmt.Errorf("rewrap pending count: %w", err)
+	}
+	defer rows.Close()
+	var count int64
+	for rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return 0, fmt.Errorf("rewrap pending count: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("rewrap pending count: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,174 @@
+package kms_test
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_RotateKeys(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	repo, err := kms.NewRepository(rw, rw)
+	require.NoError(t, err)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	tests := []struct {
+		name        string
+		rootWrapper wrapping.Wrapper
+		scopeId     string
+		wantIsError error
+	}{
+		{
+			name:        "nil-wrapper",
+			scopeId:     org.PublicId,
+			wantIsError: db.ErrInvalidParameter,
+		},
+		{
+			name:        "empty-scope",
+			rootWrapper: wrapper,
+			wantIsError: db.ErrInvalidParameter,
+		},
+		{
+			name:        "valid",
+			rootWrapper: wrapper,
+			scopeId:     org.PublicId,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.RotateKeys(ctx, tt.rootWrapper, tt.scopeId, rand.Reader)
+			if tt.wantIsError != nil {
+				require.Error(err)
+				assert.True(errors.Is(err, tt.wantIsError))
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.scopeId, got.ScopeId)
+			assert.Equal(string(kms.KeyRotationStatusRewrapping), got.Status)
+
+			found, err := repo.LookupLatestKeyRotation(ctx, tt.scopeId)
+			require.NoError(err)
+			assert.Equal(got.PrivateId, found.PrivateId)
+		})
+	}
+	t.Run("supersedes", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		first, err := repo.LookupLatestKeyRotation(ctx, org.PublicId)
+		require.NoError(err)
+		second, err := repo.RotateKeys(ctx, wrapper, org.PublicId, rand.Reader)
+		require.NoError(err)
+		assert.NotEqual(first.PrivateId, second.PrivateId)
+
+		var superseded kms.KeyRotation
+		require.NoError(rw.LookupWhere(ctx, &superseded, "private_id = ?", first.PrivateId))
+		assert.Equal(string(kms.KeyRotationStatusSuperseded), superseded.Status)
+	})
+	t.Run("re-encrypts previous key versions", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		rotation, err := repo.LookupLatestKeyRotation(ctx, org.PublicId)
+		require.NoError(err)
+
+		rootKeys, err := repo.ListRootKeys(ctx)
+		require.NoError(err)
+		var rootKeyId string
+		for _, k := range rootKeys {
+			if k.GetScopeId() == org.PublicId {
+				rootKeyId = k.GetPrivateId()
+			}
+		}
+		require.NotEmpty(rootKeyId)
+
+		// Every version of the DEKs of the scope is encrypted with the
+		// newest root key version, so the previous ones can be destroyed.
+		databaseKeys, err := repo.ListDatabaseKeys(ctx)
+		require.NoError(err)
+		for _, k := range databaseKeys {
+			if k.GetRootKeyId() != rootKeyId {
+				continue
+			}
+			var versions []*kms.DatabaseKeyVersion
+			require.NoError(rw.SearchWhere(ctx, &versions, "database_key_id = ?", []interface{}{k.GetPrivateId()}))
+			assert.Len(versions, 3)
+			for _, v := range versions {
+				assert.Equal(rotation.RootKeyVersionId, v.GetRootKeyVersionId())
+			}
+		}
+		sessionKeys, err := repo.ListSessionKeys(ctx)
+		require.NoError(err)
+		for _, k := range sessionKeys {
+			if k.GetRootKeyId() != rootKeyId {
+				continue
+			}
+			var versions []*kms.SessionKeyVersion
+			require.NoError(rw.SearchWhere(ctx, &versions, "session_key_id = ?", []interface{}{k.GetPrivateId()}))
+			assert.Len(versions, 3)
+			for _, v := range versions {
+				assert.Equal(rotation.RootKeyVersionId, v.GetRootKeyVersionId())
+			}
+		}
+	})
+}
+
+func TestKms_RotateKeys(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	assert, require := assert.New(t), require.New(t)
+
+	rotation, err := kmsCache.LookupKeyRotation(ctx, org.PublicId)
+	require.NoError(err)
+	assert.Nil(rotation)
+
+	before, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(err)
+	blob, err := before.Encrypt(ctx, []byte("secret"), nil)
+	require.NoError(err)
+	sessionsBefore, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeSessions)
+	require.NoError(err)
+
+	rotation, err = kmsCache.RotateKeys(ctx, org.PublicId)
+	require.NoError(err)
+
+	after, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(err)
+	assert.NotEqual(before.KeyID(), after.KeyID())
+
+	// The keys of existing sessions are derived from the previous sessions
+	// key version, which is still available.
+	sessionsAfter, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeSessions, kms.WithKeyId(sessionsBefore.KeyID()))
+	require.NoError(err)
+	assert.NotEqual(sessionsBefore.KeyID(), sessionsAfter.KeyID())
+
+	// Values encrypted with the previous version can still be decrypted
+	// until they are rewrapped.
+	old, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase, kms.WithKeyId(blob.GetKeyInfo().GetKeyID()))
+	require.NoError(err)
+	pt, err := old.Decrypt(ctx, blob, nil)
+	require.NoError(err)
+	assert.Equal([]byte("secret"), pt)
+
+	_, err = kmsCache.RewrapKeys(ctx)
+	require.NoError(err)
+	found, err := kmsCache.LookupKeyRotation(ctx, org.PublicId)
+	require.NoError(err)
+	assert.Equal(rotation.PrivateId, found.PrivateId)
+	assert.Equal(string(kms.KeyRotationStatusCompleted), found.Status)
+	assert.Equal(int64(0), found.PendingCount)
+}
@@ -0,0 +1,221 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// oplogScanBatchSize is the number of oplog entries read at once when
+// looking for the entries encrypted with a key version.
+const oplogScanBatchSize = 1000
+
+// dekVersionPrefixes maps the prefix of the ids of DEK versions to the
+// purpose of the DEK.
+var dekVersionPrefixes = map[string]KeyPurpose{
+	DatabaseKeyVersionPrefix:       KeyPurposeDatabase,
+	OplogKeyVersionPrefix:          KeyPurposeOplog,
+	TokenKeyVersionPrefix:          KeyPurposeTokens,
+	SessionKeyVersionPrefix:        KeyPurposeSessions,
+	CredentialKeyVersionPrefix:     KeyPurposeCredentials,
+	SshCertificateKeyVersionPrefix: KeyPurposeSshCertificates,
+}
+
+// DestroyKeyVersion deletes a version of a root key or DEK, after verifying
+// that no value stored in the database is still encrypted with it. If values
+// still are, it returns a *KeyVersionInUseError reporting the number of rows
+// which use it by table; they are usually rewrapped after the keys of the
+// scope are rotated. Rotating the keys also re-encrypts the DEK versions with
+// the new root key version, so previous root key versions can be destroyed
+// right away. Oplog entries are never rewrapped, so an oplog key version
+// cannot be destroyed while entries encrypted with it remain; likewise, a
+// sessions key version cannot be destroyed while sessions whose keys are
+// derived from it remain. The current version of a key cannot be destroyed. It returns the number of key
+// versions deleted. There are no valid options at this time.
+func (r *Repository) DestroyKeyVersion(ctx context.Context, keyVersionId string, opt ...Option) (int, error) {
+	if keyVersionId == "" {
+		return db.NoRowsAffected, fmt.Errorf("destroy key version: missing key version id: %w", db.ErrInvalidParameter)
+	}
+	versionTable, keyIdColumn, purpose, err := keyVersionTable(keyVersionId)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("destroy key version: %w", err)
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			current, err := queryString(ctx, reader, fmt.Sprintf(currentKeyVersionIdQuery, versionTable, keyIdColumn), keyVersionId)
+			if err != nil {
+				return err
+			}
+			switch current {
+			case "":
+				return db.ErrRecordNotFound
+			case keyVersionId:
+				return ErrCurrentKeyVersion
+			}
+
+			blocking, err := keyVersionUsage(ctx, reader, keyVersionId, purpose)
+			if err != nil {
+				return err
+			}
+			if len(blocking) > 0 {
+				return &KeyVersionInUseError{KeyVersionId: keyVersionId, BlockingRows: blocking}
+			}
+
+			// no oplog entries for key versions
+			rowsDeleted, err = w.Exec(ctx, fmt.Sprintf(deleteKeyVersionQuery, versionTable), []interface{}{keyVersionId})
+			if err == nil && rowsDeleted > 1 {
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("destroy key version: %w for %s", err, keyVersionId)
+	}
+	return rowsDeleted, nil
+}
+
+// keyVersionTable returns the table of the key version, its column
+// referencing the key and the purpose of the key, which is KeyPurposeUnknown
+// for root key versions.
+func keyVersionTable(keyVersionId string) (string, string, KeyPurpose, error) {
+	if strings.HasPrefix(keyVersionId, RootKeyVersionPrefix+"_") {
+		return DefaultRootKeyVersionTableName, "root_key_id", KeyPurposeUnknown, nil
+	}
+	for prefix, purpose := range dekVersionPrefixes {
+		if strings.HasPrefix(keyVersionId, prefix+"_") {
+			t := dekTables[purpose]
+			return t.versionTable, t.keyIdColumn, purpose, nil
+		}
+	}
+	return "", "", KeyPurposeUnknown, fmt.Errorf("unknown key version id %q: %w", keyVersionId, db.ErrInvalidParameter)
+}
+
+// keyVersionUsage returns the number of rows which are encrypted with the key
+// version, by table. Tables without any are omitted.
+func keyVersionUsage(ctx context.Context, r db.Reader, keyVersionId string, purpose KeyPurpose) (map[string]int64, error) {
+	usage := make(map[string]int64)
+	count := func(table, keyIdColumn string) error {
+		n, err := queryCount(ctx, r, fmt.Sprintf(keyVersionUsageQuery, table, keyIdColumn), keyVersionId)
+		if err != nil {
+			return fmt.Errorf("unable to count rows of %s: %w", table, err)
+		}
+		if n > 0 {
+			usage[table] += n
+		}
+		return nil
+	}
+
+	if purpose == KeyPurposeUnknown {
+		// Root key versions encrypt the DEK versions of the scope.
+		for _, t := range dekTables {
+			if err := count(t.versionTable, "root_key_version_id"); err != nil {
+				return nil, err
+			}
+		}
+		return usage, nil
+	}
+
+	for _, t := range registeredTableRewraps() {
+		if t.purpose != purpose {
+			continue
+		}
+		if err := count(t.table, t.keyIdColumn); err != nil {
+			return nil, err
+		}
+	}
+	if purpose == KeyPurposeSessions {
+		// The keys of sessions are derived from the sessions key version
+		// they were created with whenever a worker asks for them.
+		if err := count("session", "session_key_version_id"); err != nil {
+			return nil, err
+		}
+	}
+	if purpose == KeyPurposeOplog {
+		n, err := oplogEntryUsage(ctx, r, keyVersionId)
+		if err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			usage["oplog_entry"] = n
+		}
+	}
+	return usage, nil
+}
+
+// oplogEntryUsage returns the number of oplog entries encrypted with the key
+// version. Oplog entries do not have a key id column, so the key id is read
+// from each of their encrypted blobs.
+func oplogEntryUsage(ctx context.Context, r db.Reader, keyVersionId string) (int64, error) {
+	var count, lastId int64
+	for {
+		rows, err := r.Query(ctx, oplogEntryDataQuery, []interface{}{lastId, oplogScanBatchSize})
+		if err != nil {
+			return 0, fmt.Errorf("unable to read oplog entries: %w", err)
+		}
+		var n int
+		for rows.Next() {
+			var data []byte
+			if err := rows.Scan(&lastId, &data); err != nil {
+				rows.Close()
+				return 0, fmt.Errorf("unable to read oplog entries: %w", err)
+			}
+			n++
+			var blob wrapping.EncryptedBlobInfo
+			if err := proto.Unmarshal(data, &blob); err != nil {
+				rows.Close()
+				return 0, fmt.Errorf("unable to unmarshal oplog entry %d: %w", lastId, err)
+			}
+			if blob.GetKeyInfo().GetKeyID() == keyVersionId {
+				count++
+			}
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return 0, fmt.Errorf("unable to read oplog entries: %w", err)
+		}
+		if n < oplogScanBatchSize {
+			return count, nil
+		}
+	}
+}
+
+func queryString(ctx context.Context, r db.Reader, query string, args ...interface{}) (string, error) {
+	rows, err := r.Query(ctx, query, args)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	var s string
+	for rows.Next() {
+		if err := rows.Scan(&s); err != nil {
+			return "", err
+		}
+	}
+	return s, rows.Err()
+}
+
+func queryCount(ctx context.Context, r db.Reader, query string, args ...interface{}) (int64, error) {
+	rows, err := r.Query(ctx, query, args)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	var n int64
+	for rows.Next() {
+		if err := rows.Scan(&n); err != nil {
+			return 0, err
+		}
+	}
+	return n, rows.Err()
+}
@@ -0,0 +1,170 @@
+package kms_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/multiwrapper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_DestroyKeyVersion(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	repo, err := kms.NewRepository(rw, rw)
+	require.NoError(t, err)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	at := authtoken.TestAuthToken(t, conn, kmsCache, org.PublicId)
+	oldDatabaseKeyVersionId := at.KeyId
+	rootKey := kms.AllocRootKey()
+	require.NoError(t, rw.LookupWhere(ctx, &rootKey, "scope_id = ?", org.PublicId))
+	oldRootKeyVersions, err := repo.ListRootKeyVersions(ctx, wrapper, rootKey.PrivateId)
+	require.NoError(t, err)
+	require.Len(t, oldRootKeyVersions, 1)
+	oldRootKeyVersionId := oldRootKeyVersions[0].PrivateId
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, id := range []string{"", "kdk_1234567890", "u_1234567890"} {
+			_, err := repo.DestroyKeyVersion(ctx, id)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, db.ErrInvalidParameter), "id %q: %v", id, err)
+		}
+	})
+	t.Run("not-found", func(t *testing.T) {
+		_, err := repo.DestroyKeyVersion(ctx, kms.DatabaseKeyVersionPrefix+"_1234567890")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, db.ErrRecordNotFound), err.Error())
+	})
+	t.Run("current", func(t *testing.T) {
+		_, err := repo.DestroyKeyVersion(ctx, oldDatabaseKeyVersionId)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, kms.ErrCurrentKeyVersion), err.Error())
+	})
+
+	_, err = kmsCache.RotateKeys(ctx, org.PublicId)
+	require.NoError(t, err)
+
+	t.Run("dek-in-use", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := repo.DestroyKeyVersion(ctx, oldDatabaseKeyVersionId)
+		require.Error(err)
+		assert.True(errors.Is(err, kms.ErrKeyVersionInUse), err.Error())
+		var inUse *kms.KeyVersionInUseError
+		require.True(errors.As(err, &inUse))
+		assert.Equal(int64(1), inUse.BlockingRows["auth_token"])
+	})
+	t.Run("destroy", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := kmsCache.RewrapKeys(ctx)
+		require.NoError(err)
+
+		rowsDeleted, err := kmsCache.DestroyKeyVersion(ctx, oldDatabaseKeyVersionId)
+		require.NoError(err)
+		assert.Equal(1, rowsDeleted)
+
+		kmsCache.GetScopePurposeCache().Range(func(key, value interface{}) bool {
+			assert.Nil(value.(*multiwrapper.MultiWrapper).WrapperForKeyID(oldDatabaseKeyVersionId))
+			return true
+		})
+		_, err = repo.DestroyKeyVersion(ctx, oldDatabaseKeyVersionId)
+		assert.True(errors.Is(err, db.ErrRecordNotFound))
+
+		// The previous versions of the DEKs were re-encrypted with the new
+		// root key version when the keys were rotated.
+		rowsDeleted, err = kmsCache.DestroyKeyVersion(ctx, oldRootKeyVersionId)
+		require.NoError(err)
+		assert.Equal(1, rowsDeleted)
+
+		// All the remaining key versions can still be decrypted.
+		fresh := kms.TestKms(t, conn, wrapper)
+		for _, purpose := range []kms.KeyPurpose{
+			kms.KeyPurposeDatabase,
+			kms.KeyPurposeOplog,
+			kms.KeyPurposeTokens,
+			kms.KeyPurposeSessions,
+			kms.KeyPurposeCredentials,
+			kms.KeyPurposeSshCertificates,
+		} {
+			_, err := fresh.GetWrapper(ctx, org.PublicId, purpose)
+			assert.NoError(err, purpose.String())
+		}
+		atRepo, err := authtoken.NewRepository(rw, rw, fresh)
+		require.NoError(err)
+		got, err := atRepo.ValidateToken(ctx, at.PublicId, at.Token)
+		require.NoError(err)
+		assert.NotNil(got)
+	})
+}
+
+func TestRepository_DestroyKeyVersion_Sessions(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	sessionRepo, err := session.NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+
+	composedOf := session.TestSessionParams(t, conn, wrapper, iamRepo)
+	s, err := session.New(composedOf)
+	require.NoError(err)
+	sessionWrapper, err := kmsCache.GetWrapper(ctx, composedOf.ScopeId, kms.KeyPurposeSessions)
+	require.NoError(err)
+	s, privKey, err := sessionRepo.CreateSession(ctx, sessionWrapper, s)
+	require.NoError(err)
+	oldSessionKeyVersionId := s.SessionKeyVersionId
+
+	rootKey := kms.AllocRootKey()
+	require.NoError(rw.LookupWhere(ctx, &rootKey, "scope_id = ?", composedOf.ScopeId))
+	repo, err := kms.NewRepository(rw, rw)
+	require.NoError(err)
+	oldRootKeyVersions, err := repo.ListRootKeyVersions(ctx, wrapper, rootKey.PrivateId)
+	require.NoError(err)
+	require.Len(oldRootKeyVersions, 1)
+
+	_, err = kmsCache.RotateKeys(ctx, composedOf.ScopeId)
+	require.NoError(err)
+	_, err = kmsCache.RewrapKeys(ctx)
+	require.NoError(err)
+
+	// The session's key is still derived from the previous sessions key
+	// version.
+	_, err = kmsCache.DestroyKeyVersion(ctx, oldSessionKeyVersionId)
+	require.Error(err)
+	var inUse *kms.KeyVersionInUseError
+	require.True(errors.As(err, &inUse))
+	assert.Equal(int64(1), inUse.BlockingRows["session"])
+
+	rowsDeleted, err := kmsCache.DestroyKeyVersion(ctx, oldRootKeyVersions[0].PrivateId)
+	require.NoError(err)
+	assert.Equal(1, rowsDeleted)
+
+	fresh := kms.TestKms(t, conn, wrapper)
+	sessionWrapper, err = fresh.GetWrapper(ctx, composedOf.ScopeId, kms.KeyPurposeSessions, kms.WithKeyId(oldSessionKeyVersionId))
+	require.NoError(err)
+	_, derived, err := session.DeriveED25519Key(sessionWrapper, oldSessionKeyVersionId, s.UserId, s.PublicId)
+	require.NoError(err)
+	assert.Equal(privKey, derived)
+
+	// Once the session is deleted, the version can be destroyed.
+	_, err = sessionRepo.DeleteSession(ctx, s.PublicId)
+	require.NoError(err)
+	rowsDeleted, err = kmsCache.DestroyKeyVersion(ctx, oldSessionKeyVersionId)
+	require.NoError(err)
+	assert.Equal(1, rowsDeleted)
+}
@@ -0,0 +1,164 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// CreateSshCertificateKeyVersion inserts into the repository and returns the new key
+// version with its PrivateId.  There are no valid options at this time.
+func (r *Repository) CreateSshCertificateKeyVersion(ctx context.Context, rkvWrapper wrapping.Wrapper, sshCertificateKeyId string, key []byte, opt ...Option) (*SshCertificateKeyVersion, error) {
+	if rkvWrapper == nil {
+		return nil, fmt.Errorf("create ssh certificate key version: missing root key version wrapper: %w", db.ErrInvalidParameter)
+	}
+	rootKeyVersionId := rkvWrapper.KeyID()
+	switch {
+	case !strings.HasPrefix(rootKeyVersionId, RootKeyVersionPrefix):
+		return nil, fmt.Errorf("create ssh certificate key version: root key version id %s doesn't start with prefix %s: %w", rootKeyVersionId, RootKeyVersionPrefix, db.ErrInvalidParameter)
+	case rootKeyVersionId == "":
+		return nil, fmt.Errorf("create ssh certificate key version: missing root key version id: %w", db.ErrInvalidParameter)
+	}
+	if sshCertificateKeyId == "" {
+		return nil, fmt.Errorf("create ssh certificate key version: missing ssh certificate key id: %w", db.ErrInvalidParameter)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("create ssh certificate key version: missing key: %w", db.ErrInvalidParameter)
+	}
+	kv := AllocSshCertificateKeyVersion()
+	id, err := newSshCertificateKeyVersionId()
+	if err != nil {
+		return nil, fmt.Errorf("create ssh certificate key version: %w", err)
+	}
+	kv.PrivateId = id
+	kv.RootKeyVersionId = rootKeyVersionId
+	kv.Key = key
+	kv.SshCertificateKeyId = sshCertificateKeyId
+	if err := kv.Encrypt(ctx, rkvWrapper); err != nil {
+		return nil, fmt.Errorf("create ssh certificate key version: encrypt: %w", err)
+	}
+
+	var returnedKey interface{}
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedKey = kv.Clone()
+			// no oplog entries for root key version
+			if err := w.Create(ctx, returnedKey); err != nil {
+				return err
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create ssh certificate key version: %w for %s ssh certificate key id", err, kv.SshCertificateKeyId)
+	}
+	return returnedKey.(*SshCertificateKeyVersion), err
+}
+
+// LookupSshCertificateKeyVersion will look up a key version in the repository.  If
+// the key version is not found, it will return nil, nil.
+func (r *Repository) LookupSshCertificateKeyVersion(ctx context.Context, keyWrapper wrapping.Wrapper, privateId string, opt ...Option) (*SshCertificateKeyVersion, error) {
+	if privateId == "" {
+		return nil, fmt.Errorf("lookup ssh certificate key version: missing private id: %w", db.ErrInvalidParameter)
+	}
+	if keyWrapper == nil {
+		return nil, fmt.Errorf("lookup ssh certificate key version: missing key wrapper: %w", db.ErrInvalidParameter)
+	}
+	k := AllocSshCertificateKeyVersion()
+	k.PrivateId = privateId
+	if err := r.reader.LookupById(ctx, &k); err != nil {
+		return nil, fmt.Errorf("lookup ssh certificate key version: failed %w for %s", err, privateId)
+	}
+	if err := k.Decrypt(ctx, keyWrapper); err != nil {
+		return nil, fmt.Errorf("lookup ssh certificate key version: decrypt: %w", err)
+	}
+	return &k, nil
+}
+
+// DeleteSshCertificateKeyVersion deletes the key version for the provided id from the
+// repository returning a count of the number of records deleted.  All options
+// are ignored.
+func (r *Repository) DeleteSshCertificateKeyVersion(ctx context.Context, privateId string, opt ...Option) (int, error) {
+	if privateId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete ssh certificate key version: missing private id: %w", db.ErrInvalidParameter)
+	}
+	k := AllocSshCertificateKeyVersion()
+	k.PrivateId = privateId
+	if err := r.reader.LookupById(ctx, &k); err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete ssh certificate key version: failed %w for %s", err, privateId)
+	}
+
+	var rowsDeleted int
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) (err error) {
+			dk := k.Clone()
+			// no oplog entries for the key version
+			rowsDeleted, err = w.Delete(ctx, dk)
+			if err == nil && rowsDeleted > 1 {
+				return db.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete ssh certificate key version: %s: %w", privateId, err)
+	}
+	return rowsDeleted, nil
+}
+
+// LatestSshCertificateKeyVersion searches for the key version with the highest
+// version number.  When no results are found, it returns nil,
+// db.ErrRecordNotFound.
+func (r *Repository) LatestSshCertificateKeyVersion(ctx context.Context, rkvWrapper wrapping.Wrapper, sshCertificateKeyId string, opt ...Option) (*SshCertificateKeyVersion, error) {
+	if sshCertificateKeyId == "" {
+		return nil, fmt.Errorf("latest ssh certificate key version: missing ssh certificate key id: %w", db.ErrInvalidParameter)
+	}
+	if rkvWrapper == nil {
+		return nil, fmt.Errorf("latest ssh certificate key version: missing root key version wrapper: %w", db.ErrInvalidParameter)
+	}
+	var foundKeys []*SshCertificateKeyVersion
+	if err := r.reader.SearchWhere(ctx, &foundKeys, "ssh_certificate_key_id = ?", []interface{}{sshCertificateKeyId}, db.WithLimit(1), db.WithOrder("version desc")); err != nil {
+		return nil, fmt.Errorf("latest ssh certificate key version: failed %w for %s", err, sshCertificateKeyId)
+	}
+	if len(foundKeys) == 0 {
+		return nil, db.ErrRecordNotFound
+	}
+	if err := foundKeys[0].Decrypt(ctx, rkvWrapper); err != nil {
+		return nil, fmt.Errorf("latest ssh certificate key version: %w", err)
+	}
+	return foundKeys[0], nil
+}
+
+// ListSshCertificateKeyVersions will lists versions of a key.  Supports the WithLimit option.
+func (r *Repository) ListSshCertificateKeyVersions(ctx context.Context, rkvWrapper wrapping.Wrapper, sshCertificateKeyId string, opt ...Option) ([]DekVersion, error) {
+	if sshCertificateKeyId == "" {
+		return nil, fmt.Errorf("list ssh certificate key versions: missing ssh certificate key id %w", db.ErrInvalidParameter)
+	}
+	if rkvWrapper == nil {
+		return nil, fmt.Errorf("list ssh certificate key versions: missing root key version wrapper: %w", db.ErrInvalidParameter)
+	}
+	var versions []*SshCertificateKeyVersion
+	err := r.list(ctx, &versions, "ssh_certificate_key_id = ?", []interface{}{sshCertificateKeyId}, opt...)
+	if err != nil {
+		return nil, fmt.Errorf("list ssh certificate key versions: %w", err)
+	}
+	for i, k := range versions {
+		if err := k.Decrypt(ctx, rkvWrapper); err != nil {
+			return nil, fmt.Errorf("list ssh certificate key versions: error decrypting key num %d: %w", i, err)
+		}
+	}
+	dekVersions := make([]DekVersion, 0, len(versions))
+	for _, version := range versions {
+		dekVersions = append(dekVersions, version)
+	}
+	return dekVersions, nil
+}
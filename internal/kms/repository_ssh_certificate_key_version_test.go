@@ -0,0 +1,373 @@
+package kms_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRepository_CreateSshCertificateKeyVersion(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	repo, err := kms.NewRepository(rw, rw)
+	require.NoError(t, err)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	require.NoError(t, conn.Where("1=1").Delete(kms.AllocRootKey()).Error)
+	rk := kms.TestRootKey(t, conn, org.PublicId)
+	_, rkvWrapper := kms.TestRootKeyVersion(t, conn, wrapper, rk.PrivateId)
+	sk := kms.TestSshCertificateKey(t, conn, rk.PrivateId)
+
+	type args struct {
+		key                 []byte
+		sshCertificateKeyId string
+		keyWrapper          wrapping.Wrapper
+		opt                 []kms.Option
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantErr     bool
+		wantIsError error
+	}{
+		{
+			name: "valid",
+			args: args{
+				key:                 []byte("test key"),
+				keyWrapper:          rkvWrapper,
+				sshCertificateKeyId: sk.PrivateId,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid-rkv-wrapper",
+			args: args{
+				key:                 []byte("test key"),
+				keyWrapper:          wrapper,
+				sshCertificateKeyId: sk.PrivateId,
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty-key",
+			args: args{
+				key:                 nil,
+				keyWrapper:          wrapper,
+				sshCertificateKeyId: sk.PrivateId,
+			},
+			wantErr:     true,
+			wantIsError: db.ErrInvalidParameter,
+		},
+		{
+			name: "nil-wrapper",
+			args: args{
+				key:        []byte("test key"),
+				keyWrapper: nil,
+			},
+			wantErr:     true,
+			wantIsError: db.ErrInvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			k, err := repo.CreateSshCertificateKeyVersion(context.Background(), tt.args.keyWrapper, tt.args.sshCertificateKeyId, tt.args.key, tt.args.opt...)
+			if tt.wantErr {
+				assert.Error(err)
+				assert.Nil(k)
+				if tt.wantIsError != nil {
+					assert.True(errors.Is(err, tt.wantIsError))
+				}
+				return
+			}
+			require.NoError(err)
+			assert.NotNil(k.CreateTime)
+			foundKey, err := repo.LookupSshCertificateKeyVersion(context.Background(), tt.args.keyWrapper, k.PrivateId)
+			assert.NoError(err)
+			assert.True(proto.Equal(foundKey, k))
+
+			// make sure there was no oplog written
+			err = db.TestVerifyOplog(t, rw, k.PrivateId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second))
+			assert.Error(err)
+			assert.True(errors.Is(db.ErrRecordNotFound, err))
+		})
+	}
+}
+
+func TestRepository_DeleteSshCertificateKeyVersion(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	repo, err := kms.NewRepository(rw, rw)
+	require.NoError(t, err)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	require.NoError(t, conn.Where("1=1").Delete(kms.AllocRootKey()).Error)
+	rk := kms.TestRootKey(t, conn, org.PublicId)
+	_, rkvWrapper := kms.TestRootKeyVersion(t, conn, wrapper, rk.PrivateId)
+	sk := kms.TestSshCertificateKey(t, conn, rk.PrivateId)
+
+	type args struct {
+		key *kms.SshCertificateKeyVersion
+		opt []kms.Option
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantRowsDeleted int
+		wantErr         bool
+		wantIsError     error
+	}{
+		{
+			name: "valid",
+			args: args{
+				key: kms.TestSshCertificateKeyVersion(t, conn, rkvWrapper, sk.PrivateId, []byte("ssh certificate key")),
+			},
+			wantRowsDeleted: 1,
+			wantErr:         false,
+		},
+		{
+			name: "no-private-id",
+			args: args{
+				key: func() *kms.SshCertificateKeyVersion {
+					k := kms.AllocSshCertificateKeyVersion()
+					return &k
+				}(),
+			},
+			wantRowsDeleted: 0,
+			wantErr:         true,
+			wantIsError:     db.ErrInvalidParameter,
+		},
+		{
+			name: "not-found",
+			args: args{
+				key: func() *kms.SshCertificateKeyVersion {
+					id, err := db.NewPublicId(kms.SshCertificateKeyPrefix)
+					require.NoError(t, err)
+					k := kms.AllocSshCertificateKeyVersion()
+					k.PrivateId = id
+					require.NoError(t, err)
+					return &k
+				}(),
+			},
+			wantRowsDeleted: 0,
+			wantErr:         true,
+			wantIsError:     db.ErrRecordNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			deletedRows, err := repo.DeleteSshCertificateKeyVersion(context.Background(), tt.args.key.PrivateId, tt.args.opt...)
+			if tt.wantErr {
+				require.Error(err)
+				assert.Equal(0, deletedRows)
+				if tt.wantIsError != nil {
+					assert.True(errors.Is(err, tt.wantIsError))
+				}
+				err = db.TestVerifyOplog(t, rw, tt.args.key.PrivateId, db.WithOperation(oplog.OpType_OP_TYPE_DELETE), db.WithCreateNotBefore(10*time.Second))
+				assert.Error(err)
+				assert.True(errors.Is(db.ErrRecordNotFound, err))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantRowsDeleted, deletedRows)
+			foundKey, err := repo.LookupSshCertificateKeyVersion(context.Background(), wrapper, tt.args.key.PrivateId)
+			assert.Error(err)
+			assert.Nil(foundKey)
+			assert.True(errors.Is(err, db.ErrRecordNotFound))
+
+			// make sure there was no oplog written
+			err = db.TestVerifyOplog(t, rw, tt.args.key.PrivateId, db.WithOperation(oplog.OpType_OP_TYPE_DELETE), db.WithCreateNotBefore(10*time.Second))
+			assert.Error(err)
+			assert.True(errors.Is(db.ErrRecordNotFound, err))
+		})
+	}
+}
+
+func TestRepository_LatestSshCertificateKeyVersion(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	repo, err := kms.NewRepository(rw, rw)
+	require.NoError(t, err)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	require.NoError(t, conn.Where("1=1").Delete(kms.AllocRootKey()).Error)
+	rk := kms.TestRootKey(t, conn, org.PublicId)
+	_, rkvWrapper := kms.TestRootKeyVersion(t, conn, wrapper, rk.PrivateId)
+	sk := kms.TestSshCertificateKey(t, conn, rk.PrivateId)
+
+	tests := []struct {
+		name        string
+		createCnt   int
+		keyWrapper  wrapping.Wrapper
+		wantVersion uint32
+		wantErr     bool
+		wantIsError error
+	}{
+		{
+			name:        "5",
+			createCnt:   5,
+			keyWrapper:  rkvWrapper,
+			wantVersion: 5,
+			wantErr:     false,
+		},
+		{
+			name:        "1",
+			createCnt:   1,
+			keyWrapper:  rkvWrapper,
+			wantVersion: 1,
+			wantErr:     false,
+		},
+		{
+			name:        "0",
+			createCnt:   0,
+			keyWrapper:  rkvWrapper,
+			wantErr:     true,
+			wantIsError: db.ErrRecordNotFound,
+		},
+		{
+			name:        "nil-wrapper",
+			createCnt:   5,
+			keyWrapper:  nil,
+			wantErr:     true,
+			wantIsError: db.ErrInvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			require.NoError(conn.Where("1=1").Delete(kms.AllocSshCertificateKeyVersion()).Error)
+			testKeys := []*kms.SshCertificateKeyVersion{}
+			for i := 0; i < tt.createCnt; i++ {
+				k := kms.TestSshCertificateKeyVersion(t, conn, rkvWrapper, sk.PrivateId, []byte("test key"))
+				testKeys = append(testKeys, k)
+			}
+			assert.Equal(tt.createCnt, len(testKeys))
+			got, err := repo.LatestSshCertificateKeyVersion(context.Background(), tt.keyWrapper, sk.PrivateId)
+			if tt.wantErr {
+				require.Error(err)
+				assert.Nil(got)
+				if tt.wantIsError != nil {
+					assert.True(errors.Is(err, tt.wantIsError))
+				}
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Equal(tt.wantVersion, got.Version)
+		})
+	}
+}
+
+func TestRepository_ListSshCertificateKeyVersions(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	const testLimit = 10
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	repo, err := kms.NewRepository(rw, rw, kms.WithLimit(testLimit))
+	require.NoError(t, err)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	require.NoError(t, conn.Where("1=1").Delete(kms.AllocRootKey()).Error)
+	rk := kms.TestRootKey(t, conn, org.PublicId)
+	_, rkvWrapper := kms.TestRootKeyVersion(t, conn, wrapper, rk.PrivateId)
+	sk := kms.TestSshCertificateKey(t, conn, rk.PrivateId)
+
+	type args struct {
+		sshCertificateKeyId string
+		keyWrapper          wrapping.Wrapper
+		opt                 []kms.Option
+	}
+	tests := []struct {
+		name      string
+		createCnt int
+		args      args
+		wantCnt   int
+		wantErr   bool
+	}{
+		{
+			name:      "no-limit",
+			createCnt: repo.DefaultLimit() + 1,
+			args: args{
+				sshCertificateKeyId: sk.PrivateId,
+				keyWrapper:          rkvWrapper,
+				opt:                 []kms.Option{kms.WithLimit(-1)},
+			},
+			wantCnt: repo.DefaultLimit() + 1,
+			wantErr: false,
+		},
+		{
+			name:      "default-limit",
+			createCnt: repo.DefaultLimit() + 1,
+			args: args{
+				keyWrapper:          rkvWrapper,
+				sshCertificateKeyId: sk.PrivateId,
+			},
+			wantCnt: repo.DefaultLimit(),
+			wantErr: false,
+		},
+		{
+			name:      "custom-limit",
+			createCnt: repo.DefaultLimit() + 1,
+			args: args{
+				keyWrapper:          rkvWrapper,
+				sshCertificateKeyId: sk.PrivateId,
+				opt:                 []kms.Option{kms.WithLimit(3)},
+			},
+			wantCnt: 3,
+			wantErr: false,
+		},
+		{
+			name:      "bad-org",
+			createCnt: 1,
+			args: args{
+				keyWrapper:          rkvWrapper,
+				sshCertificateKeyId: "bad-id",
+			},
+			wantCnt: 0,
+			wantErr: false,
+		},
+		{
+			name:      "nil-wrapper",
+			createCnt: 1,
+			args: args{
+				keyWrapper:          nil,
+				sshCertificateKeyId: sk.PrivateId,
+			},
+			wantCnt: 0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			require.NoError(conn.Where("1=1").Delete(kms.AllocSshCertificateKeyVersion()).Error)
+			keyVersions := []*kms.SshCertificateKeyVersion{}
+			for i := 0; i < tt.createCnt; i++ {
+				k := kms.TestSshCertificateKeyVersion(t, conn, rkvWrapper, sk.PrivateId, []byte("ssh certificate key"))
+				keyVersions = append(keyVersions, k)
+			}
+			assert.Equal(tt.createCnt, len(keyVersions))
+			got, err := repo.ListSshCertificateKeyVersions(context.Background(), tt.args.keyWrapper, tt.args.sshCertificateKeyId, tt.args.opt...)
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantCnt, len(got))
+		})
+	}
+}
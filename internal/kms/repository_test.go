@@ -234,6 +234,32 @@ func TestCreateKeysTx(t *testing.T) {
 			assert.Equal(sk.PrivateId, skv.SessionKeyId)
 			assert.Equal(rkv.PrivateId, skv.RootKeyVersionId)
 
+			ck := kms.AllocCredentialKey()
+			ck.PrivateId = keys[kms.KeyTypeCredentialKey].GetPrivateId()
+			err = rw.LookupById(context.Background(), &ck)
+			require.NoError(err)
+			assert.Equal(rk.PrivateId, ck.RootKeyId)
+
+			ckv := kms.AllocCredentialKeyVersion()
+			ckv.PrivateId = keys[kms.KeyTypeCredentialKeyVersion].GetPrivateId()
+			err = rw.LookupById(context.Background(), &ckv)
+			require.NoError(err)
+			assert.Equal(ck.PrivateId, ckv.CredentialKeyId)
+			assert.Equal(rkv.PrivateId, ckv.RootKeyVersionId)
+
+			shk := kms.AllocSshCertificateKey()
+			shk.PrivateId = keys[kms.KeyTypeSshCertificateKey].GetPrivateId()
+			err = rw.LookupById(context.Background(), &shk)
+			require.NoError(err)
+			assert.Equal(rk.PrivateId, shk.RootKeyId)
+
+			shkv := kms.AllocSshCertificateKeyVersion()
+			shkv.PrivateId = keys[kms.KeyTypeSshCertificateKeyVersion].GetPrivateId()
+			err = rw.LookupById(context.Background(), &shkv)
+			require.NoError(err)
+			assert.Equal(shk.PrivateId, shkv.SshCertificateKeyId)
+			assert.Equal(rkv.PrivateId, shkv.RootKeyVersionId)
+
 			tk := kms.AllocTokenKey()
 			tk.PrivateId = keys[kms.KeyTypeTokenKey].GetPrivateId()
 			err = rw.LookupById(context.Background(), &tk)
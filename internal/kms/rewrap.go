@@ -0,0 +1,224 @@
+package kms
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// rewrapBatchSize is the number of values a RewrapFn is asked to rewrap at
+// once.
+const rewrapBatchSize = 100
+
+// RewrapFn re-encrypts the values of a table which are encrypted with one of
+// the given key versions. The wrapper encrypts with the current version of
+// the key and can decrypt with all of its versions. A RewrapFn must not
+// overwrite a value which was changed since it was read. It rewraps at most
+// limit values and returns the number of values it rewrapped.
+type RewrapFn func(ctx context.Context, r db.Reader, w db.Writer, wrapper wrapping.Wrapper, keyVersionIds []string, limit int) (int, error)
+
+type tableRewrap struct {
+	table       string
+	keyIdColumn string
+	purpose     KeyPurpose
+	fn          RewrapFn
+}
+
+var (
+	tableRewrapsMu sync.RWMutex
+	tableRewraps   = make(map[string]tableRewrap)
+)
+
+// RegisterTableRewrapFn registers the function which rewraps the values of a
+// table after the keys of a scope are rotated. The keyIdColumn of the table
+// holds the id of the key version for the purpose each value is encrypted
+// with. The packages owning encrypted values register their tables when they
+// are initialized. It panics if a table is registered twice.
+func RegisterTableRewrapFn(table, keyIdColumn string, purpose KeyPurpose, fn RewrapFn) {
+	tableRewrapsMu.Lock()
+	defer tableRewrapsMu.Unlock()
+	if _, ok := tableRewraps[table]; ok {
+		panic(fmt.Sprintf("rewrap function for table %s registered twice", table))
+	}
+	tableRewraps[table] = tableRewrap{
+		table:       table,
+		keyIdColumn: keyIdColumn,
+		purpose:     purpose,
+		fn:          fn,
+	}
+}
+
+// registeredTableRewraps returns the registered tables ordered by name.
+func registeredTableRewraps() []tableRewrap {
+	tableRewrapsMu.RLock()
+	defer tableRewrapsMu.RUnlock()
+	rewraps := make([]tableRewrap, 0, len(tableRewraps))
+	for _, t := range tableRewraps {
+		rewraps = append(rewraps, t)
+	}
+	sort.Slice(rewraps, func(i, j int) bool {
+		return rewraps[i].table < rewraps[j].table
+	})
+	return rewraps
+}
+
+// RotateKeys creates a new version of the root key and of each DEK of the
+// scope. Values are encrypted with the new versions from then on, and values
+// encrypted with previous versions are rewrapped by RewrapKeys.
+func (k *Kms) RotateKeys(ctx context.Context, scopeId string) (*KeyRotation, error) {
+	if scopeId == "" {
+		return nil, errors.New("no scope ID provided")
+	}
+	k.externalScopeCacheMutex.RLock()
+	externalWrappers := k.externalScopeCache[scope.Global.String()]
+	k.externalScopeCacheMutex.RUnlock()
+	if externalWrappers == nil {
+		return nil, errors.New("could not find kms information at either the needed scope or global fallback")
+	}
+	rootWrapper := externalWrappers.Root()
+	if rootWrapper == nil {
+		return nil, fmt.Errorf("root key wrapper for scope %s is nil", scopeId)
+	}
+
+	rotation, err := k.repo.RotateKeys(ctx, rootWrapper, scopeId, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	k.rotationsSeen.Store(rotation.PrivateId, true)
+	k.clearScopeCache(scopeId)
+	return rotation, nil
+}
+
+// clearScopeCache removes the wrappers of the scope from the cache, so that
+// the next values are encrypted with the current key versions.
+func (k *Kms) clearScopeCache(scopeId string) {
+	for purpose := range dekTables {
+		k.scopePurposeCache.Delete(scopeId + purpose.String())
+	}
+}
+
+// LookupKeyRotation returns the most recent rotation of the keys of the
+// scope, with the number of values still pending rewrapping if it has not
+// completed yet. If the keys of the scope were never rotated, it returns nil,
+// nil.
+func (k *Kms) LookupKeyRotation(ctx context.Context, scopeId string) (*KeyRotation, error) {
+	rotation, err := k.repo.LookupLatestKeyRotation(ctx, scopeId)
+	if err != nil || rotation == nil {
+		return nil, err
+	}
+	if rotation.Status != string(KeyRotationStatusRewrapping) {
+		return rotation, nil
+	}
+	versions, err := k.staleKeyVersions(ctx, scopeId)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range registeredTableRewraps() {
+		count, err := k.repo.rewrapPendingCount(ctx, t.table, t.keyIdColumn, versions[t.purpose].previous)
+		if err != nil {
+			return nil, err
+		}
+		rotation.PendingCount += count
+	}
+	return rotation, nil
+}
+
+type keyVersions struct {
+	current  string
+	previous []string
+}
+
+// staleKeyVersions returns the current and previous versions of the scope's
+// DEKs of the purposes of the registered tables.
+func (k *Kms) staleKeyVersions(ctx context.Context, scopeId string) (map[KeyPurpose]keyVersions, error) {
+	versions := make(map[KeyPurpose]keyVersions)
+	for _, t := range registeredTableRewraps() {
+		if _, ok := versions[t.purpose]; ok {
+			continue
+		}
+		current, previous, err := k.repo.keyVersionIds(ctx, scopeId, t.purpose)
+		if err != nil {
+			return nil, err
+		}
+		versions[t.purpose] = keyVersions{current: current, previous: previous}
+	}
+	return versions, nil
+}
+
+// RewrapKeys rewraps the values encrypted with previous key versions of the
+// scopes whose keys were rotated, until they are all encrypted with the
+// current versions. It is run periodically by each controller, and returns
+// the number of values it rewrapped.
+func (k *Kms) RewrapKeys(ctx context.Context) (int, error) {
+	rotations, err := k.repo.listRewrappingKeyRotations(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var total int
+	for _, rotation := range rotations {
+		// The keys may have been rotated by another controller, in which case
+		// the cached wrappers still encrypt with the previous versions.
+		if _, seen := k.rotationsSeen.LoadOrStore(rotation.PrivateId, true); !seen {
+			k.clearScopeCache(rotation.ScopeId)
+		}
+		rewrapped, rewrapErr := k.rewrapScope(ctx, rotation.ScopeId)
+		total += rewrapped
+
+		status := KeyRotationStatusRewrapping
+		var lastError string
+		if rewrapErr != nil {
+			lastError = rewrapErr.Error()
+		} else {
+			done, err := k.LookupKeyRotation(ctx, rotation.ScopeId)
+			if err != nil {
+				return total, err
+			}
+			if done != nil && done.PrivateId == rotation.PrivateId && done.PendingCount == 0 {
+				status = KeyRotationStatusCompleted
+			}
+		}
+		if err := k.repo.updateKeyRotation(ctx, rotation.PrivateId, rewrapped, status, lastError); err != nil {
+			return total, err
+		}
+		if rewrapErr != nil {
+			return total, fmt.Errorf("error rewrapping values of scope %s: %w", rotation.ScopeId, rewrapErr)
+		}
+	}
+	return total, nil
+}
+
+func (k *Kms) rewrapScope(ctx context.Context, scopeId string) (int, error) {
+	versions, err := k.staleKeyVersions(ctx, scopeId)
+	if err != nil {
+		return 0, err
+	}
+	var rewrapped int
+	for _, t := range registeredTableRewraps() {
+		v := versions[t.purpose]
+		if len(v.previous) == 0 {
+			continue
+		}
+		wrapper, err := k.GetWrapper(ctx, scopeId, t.purpose, WithKeyId(v.current))
+		if err != nil {
+			return rewrapped, err
+		}
+		for {
+			n, err := t.fn(ctx, k.repo.reader, k.repo.writer, wrapper, v.previous, rewrapBatchSize)
+			rewrapped += n
+			if err != nil {
+				return rewrapped, fmt.Errorf("error rewrapping %s: %w", t.table, err)
+			}
+			if n < rewrapBatchSize {
+				break
+			}
+		}
+	}
+	return rewrapped, nil
+}
@@ -0,0 +1,110 @@
+package kms
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/aead"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/multiwrapper"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshCertificateAuthorityInfo is the hkdf info used to derive the ssh
+// certificate authority key of a scope.
+const sshCertificateAuthorityInfo = "boundary ssh certificate authority"
+
+// SshCertificateAuthority returns the ssh certificate authority of the scope
+// which signs the certificates issued for sessions. The authority's key is
+// derived from the current version of the scope's ssh certificates DEK, so it
+// changes when that key is rotated. See SshCertificateAuthorityPublicKeys for
+// the authorities hosts should trust.
+func (k *Kms) SshCertificateAuthority(ctx context.Context, scopeId string, opt ...Option) (ssh.Signer, error) {
+	if scopeId == "" {
+		return nil, errors.New("no scope ID provided")
+	}
+	wrapper, err := k.GetWrapper(ctx, scopeId, KeyPurposeSshCertificates, opt...)
+	if err != nil {
+		return nil, fmt.Errorf("error loading ssh certificates key for scope %s: %w", scopeId, err)
+	}
+	return DeriveSshCertificateAuthority(wrapper, scopeId)
+}
+
+// SshCertificateAuthorityPublicKeys returns the public keys of the ssh
+// certificate authorities derived from each version of the scope's ssh
+// certificates DEK, the current one first. Certificates issued before the key
+// was rotated are signed by the authority of a previous version, so hosts
+// should trust all of them; the authority of a version is no longer returned
+// once the version is destroyed.
+func (k *Kms) SshCertificateAuthorityPublicKeys(ctx context.Context, scopeId string, opt ...Option) ([]ssh.PublicKey, error) {
+	if scopeId == "" {
+		return nil, errors.New("no scope ID provided")
+	}
+	// Loading the wrapper creates the key of scopes which do not have one
+	// yet.
+	if _, err := k.GetWrapper(ctx, scopeId, KeyPurposeSshCertificates, opt...); err != nil {
+		return nil, fmt.Errorf("error loading ssh certificates key for scope %s: %w", scopeId, err)
+	}
+	current, previous, err := k.repo.keyVersionIds(ctx, scopeId, KeyPurposeSshCertificates)
+	if err != nil {
+		return nil, fmt.Errorf("error listing ssh certificates key versions for scope %s: %w", scopeId, err)
+	}
+	var keys []ssh.PublicKey
+	for _, id := range append([]string{current}, previous...) {
+		wrapper, err := k.GetWrapper(ctx, scopeId, KeyPurposeSshCertificates, WithKeyId(id))
+		if err != nil {
+			return nil, fmt.Errorf("error loading ssh certificates key for scope %s: %w", scopeId, err)
+		}
+		multi, ok := wrapper.(*multiwrapper.MultiWrapper)
+		if !ok {
+			return nil, errors.New("unexpected wrapper type for ssh certificates key")
+		}
+		raw := multi.WrapperForKeyID(id)
+		if raw == nil {
+			return nil, fmt.Errorf("ssh certificates key version %s not found for scope %s", id, scopeId)
+		}
+		ca, err := DeriveSshCertificateAuthority(raw, scopeId)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, ca.PublicKey())
+	}
+	return keys, nil
+}
+
+// DeriveSshCertificateAuthority derives an ed25519 ssh certificate authority
+// for the scope from the scope's ssh certificates DEK.
+func DeriveSshCertificateAuthority(wrapper wrapping.Wrapper, scopeId string) (ssh.Signer, error) {
+	var aeadWrapper *aead.Wrapper
+	switch w := wrapper.(type) {
+	case *multiwrapper.MultiWrapper:
+		raw := w.WrapperForKeyID("__base__")
+		var ok bool
+		if aeadWrapper, ok = raw.(*aead.Wrapper); !ok {
+			return nil, errors.New("unexpected wrapper type from multiwrapper base")
+		}
+	case *aead.Wrapper:
+		aeadWrapper = w
+	default:
+		return nil, errors.New("unknown wrapper type")
+	}
+	reader := hkdf.New(sha256.New, aeadWrapper.GetKeyBytes(), []byte(scopeId), []byte(sshCertificateAuthorityInfo))
+	limitedReader := &io.LimitedReader{
+		R: reader,
+		N: ed25519.SeedSize,
+	}
+	_, privKey, err := ed25519.GenerateKey(limitedReader)
+	if err != nil {
+		return nil, fmt.Errorf("error generating ssh certificate authority key: %w", err)
+	}
+	signer, err := ssh.NewSignerFromKey(privKey)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ssh certificate authority signer: %w", err)
+	}
+	return signer, nil
+}
@@ -0,0 +1,79 @@
+package kms_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveSshCertificateAuthority(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	wrapper := db.TestWrapper(t)
+
+	ca, err := kms.DeriveSshCertificateAuthority(wrapper, "p_1234567890")
+	require.NoError(err)
+	require.NotNil(ca)
+	assert.Equal("ssh-ed25519", ca.PublicKey().Type())
+
+	// The authority is stable for the same key and scope.
+	again, err := kms.DeriveSshCertificateAuthority(wrapper, "p_1234567890")
+	require.NoError(err)
+	assert.Equal(ca.PublicKey().Marshal(), again.PublicKey().Marshal())
+
+	// Each scope has a distinct authority.
+	other, err := kms.DeriveSshCertificateAuthority(wrapper, "p_0987654321")
+	require.NoError(err)
+	assert.NotEqual(ca.PublicKey().Marshal(), other.PublicKey().Marshal())
+
+	_, err = kms.DeriveSshCertificateAuthority(nil, "p_1234567890")
+	assert.Error(err)
+}
+
+func TestKms_SshCertificateAuthorityPublicKeys(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	before, err := kmsCache.SshCertificateAuthority(ctx, proj.PublicId)
+	require.NoError(err)
+	keys, err := kmsCache.SshCertificateAuthorityPublicKeys(ctx, proj.PublicId)
+	require.NoError(err)
+	require.Len(keys, 1)
+	assert.Equal(before.PublicKey().Marshal(), keys[0].Marshal())
+	oldVersion, err := kmsCache.GetWrapper(ctx, proj.PublicId, kms.KeyPurposeSshCertificates)
+	require.NoError(err)
+
+	// After a rotation the previous authority is still returned, since the
+	// certificates of sessions authorized before are signed by it.
+	_, err = kmsCache.RotateKeys(ctx, proj.PublicId)
+	require.NoError(err)
+	after, err := kmsCache.SshCertificateAuthority(ctx, proj.PublicId)
+	require.NoError(err)
+	assert.NotEqual(before.PublicKey().Marshal(), after.PublicKey().Marshal())
+	keys, err = kmsCache.SshCertificateAuthorityPublicKeys(ctx, proj.PublicId)
+	require.NoError(err)
+	require.Len(keys, 2)
+	assert.Equal(after.PublicKey().Marshal(), keys[0].Marshal())
+	assert.Equal(before.PublicKey().Marshal(), keys[1].Marshal())
+
+	// It is no longer returned once its key version is destroyed.
+	_, err = kmsCache.DestroyKeyVersion(ctx, oldVersion.KeyID())
+	require.NoError(err)
+	keys, err = kmsCache.SshCertificateAuthorityPublicKeys(ctx, proj.PublicId)
+	require.NoError(err)
+	require.Len(keys, 1)
+	assert.Equal(after.PublicKey().Marshal(), keys[0].Marshal())
+
+	_, err = kmsCache.SshCertificateAuthorityPublicKeys(ctx, "")
+	assert.Error(err)
+}
@@ -0,0 +1,77 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms/store"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultSshCertificateKeyTableName = "kms_ssh_certificate_key"
+)
+
+type SshCertificateKey struct {
+	*store.SshCertificateKey
+	tableName string `gorm:"-"`
+}
+
+// NewSshCertificateKey creates a new in memory key.  No options
+// are currently supported.
+func NewSshCertificateKey(rootKeyId string, opt ...Option) (*SshCertificateKey, error) {
+	if rootKeyId == "" {
+		return nil, fmt.Errorf("new root key: missing root key id: %w", db.ErrInvalidParameter)
+	}
+	c := &SshCertificateKey{
+		SshCertificateKey: &store.SshCertificateKey{
+			RootKeyId: rootKeyId,
+		},
+	}
+	return c, nil
+}
+
+// AllocSshCertificateKey will allocate a key
+func AllocSshCertificateKey() SshCertificateKey {
+	return SshCertificateKey{
+		SshCertificateKey: &store.SshCertificateKey{},
+	}
+}
+
+// Clone creates a clone of the key
+func (k *SshCertificateKey) Clone() interface{} {
+	cp := proto.Clone(k.SshCertificateKey)
+	return &SshCertificateKey{
+		SshCertificateKey: cp.(*store.SshCertificateKey),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the key
+// before it's written.
+func (k *SshCertificateKey) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if k.PrivateId == "" {
+		return fmt.Errorf("ssh certificate key vet for write: missing private id: %w", db.ErrInvalidParameter)
+	}
+	if opType == db.CreateOp {
+		if k.RootKeyId == "" {
+			return fmt.Errorf("ssh certificate key vet for write: missing root key id: %w", db.ErrInvalidParameter)
+		}
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (k *SshCertificateKey) TableName() string {
+	if k.tableName != "" {
+		return k.tableName
+	}
+	return DefaultSshCertificateKeyTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (k *SshCertificateKey) SetTableName(n string) {
+	k.tableName = n
+}
@@ -0,0 +1,114 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms/store"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultSshCertificateKeyVersionTableName = "kms_ssh_certificate_key_version"
+)
+
+type SshCertificateKeyVersion struct {
+	*store.SshCertificateKeyVersion
+	tableName string `gorm:"-"`
+}
+
+// SshCertificateKeyVersion creates a new in memory key version. No options are
+// currently supported.
+func NewSshCertificateKeyVersion(sshCertificateKeyId string, key []byte, rootKeyVersionId string, opt ...Option) (*SshCertificateKeyVersion, error) {
+	if sshCertificateKeyId == "" {
+		return nil, fmt.Errorf("new ssh certificate key version: missing ssh certificate key id: %w", db.ErrInvalidParameter)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("new ssh certificate key version: missing key: %w", db.ErrInvalidParameter)
+	}
+	if rootKeyVersionId == "" {
+		return nil, fmt.Errorf("new ssh certificate key version: missing root key version id: %w", db.ErrInvalidParameter)
+	}
+
+	k := &SshCertificateKeyVersion{
+		SshCertificateKeyVersion: &store.SshCertificateKeyVersion{
+			SshCertificateKeyId: sshCertificateKeyId,
+			RootKeyVersionId:    rootKeyVersionId,
+			Key:                 key,
+		},
+	}
+	return k, nil
+}
+
+// AllocSshCertificateKeyVersion allocates a key version
+func AllocSshCertificateKeyVersion() SshCertificateKeyVersion {
+	return SshCertificateKeyVersion{
+		SshCertificateKeyVersion: &store.SshCertificateKeyVersion{},
+	}
+}
+
+// Clone creates a clone of the key version
+func (k *SshCertificateKeyVersion) Clone() interface{} {
+	cp := proto.Clone(k.SshCertificateKeyVersion)
+	return &SshCertificateKeyVersion{
+		SshCertificateKeyVersion: cp.(*store.SshCertificateKeyVersion),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the key
+// version before it's written.
+func (k *SshCertificateKeyVersion) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if k.PrivateId == "" {
+		return fmt.Errorf("ssh certificate key version vet for write: missing private id: %w", db.ErrInvalidParameter)
+	}
+	if opType == db.CreateOp {
+		if k.CtKey == nil {
+			return fmt.Errorf("ssh certificate key version vet for write: missing key: %w", db.ErrInvalidParameter)
+		}
+		if k.SshCertificateKeyId == "" {
+			return fmt.Errorf("ssh certificate key version vet for write: missing ssh certificate key id: %w", db.ErrInvalidParameter)
+		}
+		if k.RootKeyVersionId == "" {
+			return fmt.Errorf("ssh certificate key version vet for write: missing root key version id: %w", db.ErrInvalidParameter)
+		}
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (k *SshCertificateKeyVersion) TableName() string {
+	if k.tableName != "" {
+		return k.tableName
+	}
+	return DefaultSshCertificateKeyVersionTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (k *SshCertificateKeyVersion) SetTableName(n string) {
+	k.tableName = n
+}
+
+// Encrypt will encrypt the key version's key
+func (k *SshCertificateKeyVersion) Encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	// structwrapping doesn't support embedding, so we'll pass in the
+	// store.SshCertificateKeyVersion directly
+	if err := structwrapping.WrapStruct(ctx, cipher, k.SshCertificateKeyVersion, nil); err != nil {
+		return fmt.Errorf("error encrypting kms ssh certificate key version: %w", err)
+	}
+	return nil
+}
+
+// Decrypt will decrypt the key version's key
+func (k *SshCertificateKeyVersion) Decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	// structwrapping doesn't support embedding, so we'll pass in the
+	// store.SshCertificateKeyVersion directly
+	if err := structwrapping.UnwrapStruct(ctx, cipher, k.SshCertificateKeyVersion, nil); err != nil {
+		return fmt.Errorf("error decrypting kms ssh certificate key version: %w", err)
+	}
+	return nil
+}
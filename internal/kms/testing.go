@@ -196,3 +196,71 @@ func TestSessionKeyVersion(t *testing.T, conn *gorm.DB, rootKeyVersionWrapper wr
 	require.NoError(err)
 	return k
 }
+
+func TestCredentialKey(t *testing.T, conn *gorm.DB, rootKeyId string) *CredentialKey {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+	require.NoError(conn.Where("root_key_id = ?", rootKeyId).Delete(AllocCredentialKey()).Error)
+	k, err := NewCredentialKey(rootKeyId)
+	require.NoError(err)
+	id, err := newCredentialKeyId()
+	require.NoError(err)
+	k.PrivateId = id
+	k.RootKeyId = rootKeyId
+	err = rw.Create(context.Background(), k)
+	require.NoError(err)
+	return k
+}
+
+func TestCredentialKeyVersion(t *testing.T, conn *gorm.DB, rootKeyVersionWrapper wrapping.Wrapper, credentialKeyId string, key []byte) *CredentialKeyVersion {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+	rootKeyVersionId := rootKeyVersionWrapper.KeyID()
+	require.NotEmpty(rootKeyVersionId)
+	k, err := NewCredentialKeyVersion(credentialKeyId, key, rootKeyVersionId)
+	require.NoError(err)
+	id, err := newCredentialKeyVersionId()
+	require.NoError(err)
+	k.PrivateId = id
+	err = k.Encrypt(context.Background(), rootKeyVersionWrapper)
+	require.NoError(err)
+	err = rw.Create(context.Background(), k)
+	require.NoError(err)
+	return k
+}
+
+func TestSshCertificateKey(t *testing.T, conn *gorm.DB, rootKeyId string) *SshCertificateKey {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+	require.NoError(conn.Where("root_key_id = ?", rootKeyId).Delete(AllocSshCertificateKey()).Error)
+	k, err := NewSshCertificateKey(rootKeyId)
+	require.NoError(err)
+	id, err := newSshCertificateKeyId()
+	require.NoError(err)
+	k.PrivateId = id
+	k.RootKeyId = rootKeyId
+	err = rw.Create(context.Background(), k)
+	require.NoError(err)
+	return k
+}
+
+func TestSshCertificateKeyVersion(t *testing.T, conn *gorm.DB, rootKeyVersionWrapper wrapping.Wrapper, sshCertificateKeyId string, key []byte) *SshCertificateKeyVersion {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+	rootKeyVersionId := rootKeyVersionWrapper.KeyID()
+	require.NotEmpty(rootKeyVersionId)
+	k, err := NewSshCertificateKeyVersion(sshCertificateKeyId, key, rootKeyVersionId)
+	require.NoError(err)
+	id, err := newSshCertificateKeyVersionId()
+	require.NoError(err)
+	k.PrivateId = id
+	err = k.Encrypt(context.Background(), rootKeyVersionWrapper)
+	require.NoError(err)
+	err = rw.Create(context.Background(), k)
+	require.NoError(err)
+	return k
+}
@@ -158,3 +158,65 @@ func Test_TestSessionKeyVersion(t *testing.T) {
 	require.NotNil(sv)
 	require.NotEmpty(sv.PrivateId)
 }
+
+func Test_TestCredentialKey(t *testing.T) {
+	t.Helper()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	require.NoError(conn.Where("1=1").Delete(kms.AllocRootKey()).Error)
+	k := kms.TestRootKey(t, conn, org.PublicId)
+	require.NotNil(k)
+	assert.NotEmpty(k.PrivateId)
+
+	ck := kms.TestCredentialKey(t, conn, k.PrivateId)
+	require.NotNil(ck)
+	assert.NotEmpty(ck.PrivateId)
+}
+
+func Test_TestCredentialKeyVersion(t *testing.T) {
+	t.Helper()
+	require := require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	kmsWrapper := db.TestWrapper(t)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, kmsWrapper))
+	require.NoError(conn.Where("1=1").Delete(kms.AllocRootKey()).Error)
+	rk := kms.TestRootKey(t, conn, org.PublicId)
+	_, rootKeyVersionWrapper := kms.TestRootKeyVersion(t, conn, kmsWrapper, rk.PrivateId)
+	ck := kms.TestCredentialKey(t, conn, rk.PrivateId)
+	cv := kms.TestCredentialKeyVersion(t, conn, rootKeyVersionWrapper, ck.PrivateId, []byte("test dek key"))
+	require.NotNil(cv)
+	require.NotEmpty(cv.PrivateId)
+}
+
+func Test_TestSshCertificateKey(t *testing.T) {
+	t.Helper()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	require.NoError(conn.Where("1=1").Delete(kms.AllocRootKey()).Error)
+	k := kms.TestRootKey(t, conn, org.PublicId)
+	require.NotNil(k)
+	assert.NotEmpty(k.PrivateId)
+
+	ck := kms.TestSshCertificateKey(t, conn, k.PrivateId)
+	require.NotNil(ck)
+	assert.NotEmpty(ck.PrivateId)
+}
+
+func Test_TestSshCertificateKeyVersion(t *testing.T) {
+	t.Helper()
+	require := require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	kmsWrapper := db.TestWrapper(t)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, kmsWrapper))
+	require.NoError(conn.Where("1=1").Delete(kms.AllocRootKey()).Error)
+	rk := kms.TestRootKey(t, conn, org.PublicId)
+	_, rootKeyVersionWrapper := kms.TestRootKeyVersion(t, conn, kmsWrapper, rk.PrivateId)
+	ck := kms.TestSshCertificateKey(t, conn, rk.PrivateId)
+	cv := kms.TestSshCertificateKeyVersion(t, conn, rootKeyVersionWrapper, ck.PrivateId, []byte("test dek key"))
+	require.NotNil(cv)
+	require.NotEmpty(cv.PrivateId)
+}
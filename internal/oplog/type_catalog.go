@@ -4,6 +4,7 @@ import (
 	"errors"
 	fmt "fmt"
 	"reflect"
+	"sync"
 )
 
 // TypeCatalog is an abstraction for dealing with oplog data and their underlying types
@@ -66,13 +67,60 @@ func (t TypeCatalog) Set(i interface{}, typeName string) error {
 	return nil
 }
 
-// Get retrieves the interface via a name
+// Get retrieves the interface via a name. Embedded struct pointers of the
+// interface, such as the store message of a domain type, are allocated so the
+// interface can be unmarshaled into.
 func (t TypeCatalog) Get(typeName string) (interface{}, error) {
 	if typeName == "" {
 		return nil, errors.New("error typeName is empty string for Get")
 	}
 	if typ, ok := t[typeName]; ok {
-		return reflect.New(typ.Elem()).Elem().Addr().Interface(), nil
+		v := reflect.New(typ.Elem()).Elem()
+		if v.Kind() == reflect.Struct {
+			for i := 0; i < v.NumField(); i++ {
+				f := v.Field(i)
+				if v.Type().Field(i).Anonymous && f.Kind() == reflect.Ptr && f.Type().Elem().Kind() == reflect.Struct && f.CanSet() {
+					f.Set(reflect.New(f.Type().Elem()))
+				}
+			}
+		}
+		return v.Addr().Interface(), nil
 	}
 	return nil, errors.New("error typeName is not found for Get")
 }
+
+var (
+	registeredTypesMu sync.RWMutex
+	registeredTypes   = TypeCatalog{}
+)
+
+// RegisterTypes adds the types of the messages to the catalog of the types
+// written to oplog entries, named after their tables. The packages writing
+// oplog entries register their types when they are initialized, so that the
+// entries can be unmarshaled with RegisteredTypes. It panics if a table is
+// registered with two different types.
+func RegisterTypes(msgs ...ReplayableMessage) {
+	registeredTypesMu.Lock()
+	defer registeredTypesMu.Unlock()
+	for _, m := range msgs {
+		name := m.TableName()
+		if typ, ok := registeredTypes[name]; ok && typ != reflect.TypeOf(m) {
+			panic(fmt.Sprintf("oplog type %s registered as both %s and %T", name, typ, m))
+		}
+		if err := registeredTypes.Set(m, name); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisteredTypes returns a catalog of the types registered with
+// RegisterTypes.
+func RegisteredTypes() *TypeCatalog {
+	registeredTypesMu.RLock()
+	defer registeredTypesMu.RUnlock()
+	types := make(TypeCatalog, len(registeredTypes))
+	for name, typ := range registeredTypes {
+		types[name] = typ
+	}
+	return &types
+}
@@ -148,6 +148,19 @@ func Test_Get(t *testing.T) {
 		require.NoError(err)
 		assert.Equal(reflect.TypeOf(u), reflect.TypeOf(new(oplog_test.TestUser)))
 	})
+	t.Run("embedded", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		types, err := NewTypeCatalog(
+			Type{new(embeddingUser), "user"},
+		)
+		require.NoError(err)
+
+		u, err := types.Get("user")
+		require.NoError(err)
+		require.IsType(new(embeddingUser), u)
+		assert.NotNil(u.(*embeddingUser).TestUser)
+	})
 	t.Run("bad name", func(t *testing.T) {
 		assert, require := assert.New(t), require.New(t)
 
@@ -212,3 +225,32 @@ func Test_Set(t *testing.T) {
 		assert.Equal(types, &TypeCatalog{})
 	})
 }
+
+// embeddingUser embeds its message like the domain types written to the oplog.
+type embeddingUser struct {
+	*oplog_test.TestUser
+}
+
+// Test_RegisterTypes provides unit tests for RegisterTypes
+func Test_RegisterTypes(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+
+	RegisterTypes(new(oplog_test.TestUser), new(oplog_test.TestCar))
+	// registering a type again is allowed
+	RegisterTypes(new(oplog_test.TestUser))
+
+	types := RegisteredTypes()
+	name, err := types.GetTypeName(new(oplog_test.TestCar))
+	require.NoError(err)
+	assert.Equal("oplog_test_car", name)
+
+	// the returned catalog is a copy
+	require.NoError(types.Set(new(oplog_test.TestRental), "oplog_test_rental"))
+	_, err = RegisteredTypes().Get("oplog_test_rental")
+	assert.Error(err)
+
+	assert.Panics(func() {
+		RegisterTypes(&oplog_test.TestRental{Table: "oplog_test_user"})
+	})
+}
@@ -6,9 +6,13 @@ https://hashicorp.atlassian.net/wiki/spaces/ICU/pages/866976600/API+Actions+and+
 speaking of which: TODO: put that chart in public docs.
 
 Anyways, from that page you can see that there are really only a few patterns of
-ACLs that are ever allowed; see the Allowed function for a description along
+ACLs that are ever allowed; see the matches function for a description along
 with the code.
 
+Any of these may also set deny=true, in which case the actions matched by the
+grant are denied. A matching deny grant always takes precedence over matching
+allow grants, regardless of the roles or order they come from.
+
 This makes it actually quite simple to perform the ACL checking. Much of ACL
 construction is thus synthesizing something reasonable from a set of Grants.
 */
@@ -65,70 +69,84 @@ func NewACL(grants ...Grant) ACL {
 }
 
 // Allowed determines if the grants for an ACL allow an action for a resource.
+// An action is allowed when at least one allow grant and no deny grant in the
+// resource's scope match it; a matching deny grant always wins, regardless of
+// the order of the grants.
 func (a ACL) Allowed(r Resource, aType action.Type) (results ACLResults) {
 	// First, get the grants within the specified scope
 	grants := a.scopeMap[r.ScopeId]
 	results.scopeMap = a.scopeMap
 
-	// Now, go through and check the cases indicated above
 	for _, grant := range grants {
-		if !(grant.actions[aType] || grant.actions[action.All]) {
+		if !grant.matches(r, aType) {
 			continue
 		}
-		switch {
-		// id=<resource.id>;actions=<action> where ID cannot be a wildcard
-		case grant.id == r.Id &&
-			grant.id != "" &&
-			grant.id != "*" &&
-			grant.typ == resource.Unknown:
-
-			results.Allowed = true
+		if grant.deny {
+			results.Allowed = false
 			return
+		}
+		results.Allowed = true
+	}
+	return
+}
 
-		// type=<resource.type>;actions=<action> when action is list or create.
-		// Must be a top level collection, otherwise must be one of the two
-		// formats specified below.
-		case grant.id == "" &&
-			r.Id == "" &&
-			grant.typ == r.Type &&
-			grant.typ != resource.Unknown &&
-			topLevelType(r.Type) &&
-			(aType == action.List || aType == action.Create):
-
-			results.Allowed = true
-			return
+// matches reports whether the grant applies to the action on the resource,
+// regardless of whether the grant allows or denies it.
+func (g Grant) matches(r Resource, aType action.Type) bool {
+	if !(g.actions[aType] || g.actions[action.All]) {
+		return false
+	}
+	switch {
+	// id=<resource.id>;actions=<action> where ID cannot be a wildcard
+	case g.id == r.Id &&
+		g.id != "" &&
+		g.id != "*" &&
+		g.typ == resource.Unknown:
 
-		// id=*;type=<resource.type>;actions=<action> where type cannot be
-		// unknown but can be a wildcard to allow any resource at all
-		case grant.id == "*" &&
-			grant.typ != resource.Unknown &&
-			(grant.typ == r.Type ||
-				grant.typ == resource.All):
+		return true
 
-			results.Allowed = true
-			return
+	// type=<resource.type>;actions=<action> when action is list or create.
+	// Must be a top level collection, otherwise must be one of the two
+	// formats specified below.
+	case g.id == "" &&
+		r.Id == "" &&
+		g.typ == r.Type &&
+		g.typ != resource.Unknown &&
+		topLevelType(r.Type) &&
+		(aType == action.List || aType == action.Create):
 
-		// id=<pin>;type=<resource.type>;actions=<action> where type can be a
-		// wildcard and this this is operating on a non-top-level type
-		case grant.id != "" &&
-			grant.id == r.Pin &&
-			grant.typ != resource.Unknown &&
-			(grant.typ == r.Type || grant.typ == resource.All) &&
-			!topLevelType(r.Type):
+		return true
 
-			results.Allowed = true
-			return
-		}
+	// id=*;type=<resource.type>;actions=<action> where type cannot be
+	// unknown but can be a wildcard to allow any resource at all
+	case g.id == "*" &&
+		g.typ != resource.Unknown &&
+		(g.typ == r.Type ||
+			g.typ == resource.All):
+
+		return true
+
+	// id=<pin>;type=<resource.type>;actions=<action> where type can be a
+	// wildcard and this this is operating on a non-top-level type
+	case g.id != "" &&
+		g.id == r.Pin &&
+		g.typ != resource.Unknown &&
+		(g.typ == r.Type || g.typ == resource.All) &&
+		!topLevelType(r.Type):
+
+		return true
 	}
-	return
+	return false
 }
 
 func topLevelType(typ resource.Type) bool {
 	switch typ {
 	case resource.AuthMethod,
 		resource.AuthToken,
+		resource.CredentialStore,
 		resource.Group,
 		resource.HostCatalog,
+		resource.OplogEntry,
 		resource.Role,
 		resource.Scope,
 		resource.Session,
@@ -61,6 +61,14 @@ func Test_ACLAllowed(t *testing.T) {
 				"id=*;type=*;actions=create,update",
 			},
 		},
+		{
+			scope: "p_e",
+			grants: []string{
+				"id=ttcp_prod;actions=*;deny=true",
+				"id=*;type=*;actions=*",
+				"id=*;type=host-set;actions=delete;deny=true",
+			},
+		},
 	}
 
 	// See acl.go for expected allowed formats. The goal here is to basically
@@ -252,6 +260,35 @@ func Test_ACLAllowed(t *testing.T) {
 			},
 			userId: "u_abcd1234",
 		},
+		{
+			name:        "deny by id wins over wildcard allow",
+			resource:    Resource{ScopeId: "p_e", Id: "ttcp_prod", Type: resource.Target},
+			scopeGrants: commonGrants,
+			actionsAllowed: []actionAllowed{
+				{action: action.Read},
+				{action: action.AuthorizeSession},
+				{action: action.Delete},
+			},
+		},
+		{
+			name:        "wildcard allow not matched by deny",
+			resource:    Resource{ScopeId: "p_e", Id: "ttcp_dev", Type: resource.Target},
+			scopeGrants: commonGrants,
+			actionsAllowed: []actionAllowed{
+				{action: action.Read, allowed: true},
+				{action: action.AuthorizeSession, allowed: true},
+			},
+		},
+		{
+			name:        "deny by type limited to its actions",
+			resource:    Resource{ScopeId: "p_e", Id: "hsst_1234567890", Type: resource.HostSet, Pin: "hcst_1234567890"},
+			scopeGrants: commonGrants,
+			actionsAllowed: []actionAllowed{
+				{action: action.Read, allowed: true},
+				{action: action.Update, allowed: true},
+				{action: action.Delete},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -15,6 +15,10 @@ ACLs that are ever allowed:
 
 and of course a matching scope.
 
+Any of these may also set deny=true, in which case the actions matched by the
+grant are denied. A matching deny grant always takes precedence over matching
+allow grants, regardless of the roles or order they come from.
+
 This makes it actually quite simple to perform the ACL checking. Much of ACL
 construction is thus synthesizing something reasonable from a set of Grants.
 */
@@ -43,6 +43,9 @@ type Grant struct {
 	// The set of actions being granted
 	actions map[action.Type]bool
 
+	// Whether the grant denies rather than allows the actions
+	deny bool
+
 	// This is used as a temporary staging area before validating permissions to
 	// allow the same validation code across grant string formats
 	actionsBeingParsed []string
@@ -56,6 +59,11 @@ func (g Grant) Type() resource.Type {
 	return g.typ
 }
 
+// Deny returns whether the grant denies its actions rather than allowing them.
+func (g Grant) Deny() bool {
+	return g.deny
+}
+
 func (g Grant) Actions() (typs []action.Type, strs []string) {
 	typs = make([]action.Type, 0, len(g.actions))
 	strs = make([]string, 0, len(g.actions))
@@ -75,6 +83,7 @@ func (g Grant) clone() *Grant {
 		scope: g.scope,
 		id:    g.id,
 		typ:   g.typ,
+		deny:  g.deny,
 	}
 	if g.actionsBeingParsed != nil {
 		ret.actionsBeingParsed = append(ret.actionsBeingParsed, g.actionsBeingParsed...)
@@ -109,6 +118,10 @@ func (g Grant) CanonicalString() string {
 		builder = append(builder, fmt.Sprintf("actions=%s", strings.Join(actions, ",")))
 	}
 
+	if g.deny {
+		builder = append(builder, "deny=true")
+	}
+
 	return strings.Join(builder, ";")
 }
 
@@ -118,6 +131,9 @@ func (g Grant) MarshalJSON() ([]byte, error) {
 	if g.id != "" {
 		res["id"] = g.id
 	}
+	if g.deny {
+		res["deny"] = true
+	}
 	if g.typ != resource.Unknown {
 		res["type"] = g.typ.String()
 	}
@@ -157,6 +173,13 @@ func (g *Grant) unmarshalJSON(data []byte) error {
 			return fmt.Errorf("unknown type specifier %q", typ)
 		}
 	}
+	if rawDeny, ok := raw["deny"]; ok {
+		deny, ok := rawDeny.(bool)
+		if !ok {
+			return fmt.Errorf("unable to interpret %q as bool", "deny")
+		}
+		g.deny = deny
+	}
 	if rawActions, ok := raw["actions"]; ok {
 		interfaceActions, ok := rawActions.([]interface{})
 		if !ok {
@@ -206,6 +229,16 @@ func (g *Grant) unmarshalText(grantString string) error {
 				return fmt.Errorf("unknown type specifier %q", typeString)
 			}
 
+		case "deny":
+			switch strings.ToLower(kv[1]) {
+			case "true":
+				g.deny = true
+			case "false":
+				g.deny = false
+			default:
+				return fmt.Errorf("unable to interpret %q as bool in segment %q", kv[1], segment)
+			}
+
 		case "actions":
 			actions := strings.Split(kv[1], ",")
 			if len(actions) > 0 {
@@ -269,8 +302,12 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 	opts := getOpts(opt...)
 
 	// Check for templated values ID, and substitute in with the authenticated values
-	// if so
+	// if so. Templates are left in place when no value is provided, which is
+	// the case when grants are validated and stored on a role.
 	if grant.id != "" && strings.HasPrefix(grant.id, "{{") {
+		if !strings.HasSuffix(grant.id, "}}") {
+			return Grant{}, fmt.Errorf("unterminated template %q in grant %q value", grant.id, "id")
+		}
 		id := strings.TrimSuffix(strings.TrimPrefix(grant.id, "{{"), "}}")
 		id = strings.ToLower(strings.TrimSpace(id))
 		switch id {
@@ -297,8 +334,11 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 
 	if !opts.withSkipFinalValidation {
 		// Validate the grant. Create a dummy resource and pass it through
-		// Allowed and ensure that we get allowed.
-		acl := NewACL(grant)
+		// Allowed and ensure that we get allowed. Deny grants are validated
+		// as the equivalent allow grant since they match the same resources.
+		check := grant.clone()
+		check.deny = false
+		acl := NewACL(*check)
 		r := Resource{
 			ScopeId: scopeId,
 			Id:      grant.id,
@@ -336,7 +376,10 @@ func (g Grant) validateType() error {
 		resource.HostSet,
 		resource.Host,
 		resource.Target,
-		resource.Session:
+		resource.Session,
+		resource.CredentialStore,
+		resource.CredentialLibrary,
+		resource.OplogEntry:
 		return nil
 	}
 	return fmt.Errorf("unknown type specifier %q", g.typ)
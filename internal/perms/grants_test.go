@@ -175,6 +175,21 @@ func Test_MarshalingAndCloning(t *testing.T) {
 			jsonOutput:      `{"actions":["create","read"],"id":"baz","type":"group"}`,
 			canonicalString: `id=baz;type=group;actions=create,read`,
 		},
+		{
+			name: "deny",
+			input: Grant{
+				id: "baz",
+				scope: Scope{
+					Type: scope.Project,
+				},
+				actions: map[action.Type]bool{
+					action.Delete: true,
+				},
+				deny: true,
+			},
+			jsonOutput:      `{"actions":["delete"],"deny":true,"id":"baz"}`,
+			canonicalString: `id=baz;actions=delete;deny=true`,
+		},
 	}
 
 	for _, test := range tests {
@@ -272,6 +287,21 @@ func Test_Unmarshaling(t *testing.T) {
 			textInput: `actions=,`,
 			textErr:   `empty action found`,
 		},
+		{
+			name: "good deny",
+			expected: Grant{
+				deny: true,
+			},
+			jsonInput: `{"deny":true}`,
+			textInput: `deny=TRUE`,
+		},
+		{
+			name:      "bad deny",
+			jsonInput: `{"deny":"yes"}`,
+			jsonErr:   `unable to interpret "deny" as bool`,
+			textInput: `deny=yes`,
+			textErr:   `unable to interpret "yes" as bool in segment "deny=yes"`,
+		},
 		{
 			name:      "bad json action",
 			jsonInput: `{"actions":[1, true]}`,
@@ -458,6 +488,21 @@ func Test_Parse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "deny",
+			input: `id=ttcp_1234567890;actions=*;deny=true`,
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				id: "ttcp_1234567890",
+				actions: map[action.Type]bool{
+					action.All: true,
+				},
+				deny: true,
+			},
+		},
 		{
 			name:   "bad user id template",
 			input:  `id={{superman}};actions=create,read`,
@@ -480,6 +525,26 @@ func Test_Parse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "unterminated user id template",
+			input:  `id={{user.id;actions=create,read`,
+			userId: "u_abcd1234",
+			err:    `unterminated template "{{user.id" in grant "id" value`,
+		},
+		{
+			name:  "user id template without user id",
+			input: `id={{user.id}};actions=read`,
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				id: "{{user.id}}",
+				actions: map[action.Type]bool{
+					action.Read: true,
+				},
+			},
+		},
 		{
 			name:      "bad account id template",
 			input:     `id={{superman}};actions=create,read`,
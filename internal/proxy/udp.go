@@ -0,0 +1,41 @@
+package proxy
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// The udp proxy protocol carries the datagrams of all the peers of the
+// client's local listener over one connection to the worker. Each binary
+// websocket message is a frame holding a single datagram, preceded by the id
+// the client gave the peer which sent it or which it is sent to, in big-endian
+// order. The worker sends the datagrams of each peer from its own socket, so
+// that the endpoint can tell the peers apart, and sends back the datagrams it
+// receives on the socket with the peer's id.
+const (
+	// UdpFrameHeaderSize is the size of the header of the frames of the udp
+	// proxy protocol, which holds the id of the client's peer.
+	UdpFrameHeaderSize = 4
+
+	// MaxUdpDatagramSize is the largest datagram carried by a frame; it is the
+	// largest possible UDP payload.
+	MaxUdpDatagramSize = 64 * 1024
+
+	// MaxUdpFrameSize is the largest frame of the udp proxy protocol.
+	MaxUdpFrameSize = UdpFrameHeaderSize + MaxUdpDatagramSize
+)
+
+// PutUdpFrameHeader writes the header of the frame of a datagram of the peer
+// peerId to the start of frame, which must be at least UdpFrameHeaderSize
+// bytes long. The datagram follows the header.
+func PutUdpFrameHeader(frame []byte, peerId uint32) {
+	binary.BigEndian.PutUint32(frame, peerId)
+}
+
+// ParseUdpFrame returns the peer id and the datagram of frame.
+func ParseUdpFrame(frame []byte) (uint32, []byte, error) {
+	if len(frame) < UdpFrameHeaderSize {
+		return 0, nil, fmt.Errorf("udp frame of %d bytes is shorter than its header", len(frame))
+	}
+	return binary.BigEndian.Uint32(frame), frame[UdpFrameHeaderSize:], nil
+}
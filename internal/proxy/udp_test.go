@@ -0,0 +1,31 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUdpFrame(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	frame := make([]byte, UdpFrameHeaderSize+len("datagram"))
+	copy(frame[UdpFrameHeaderSize:], "datagram")
+	PutUdpFrameHeader(frame, 0x01020304)
+	assert.Equal([]byte{1, 2, 3, 4}, frame[:UdpFrameHeaderSize])
+
+	id, datagram, err := ParseUdpFrame(frame)
+	require.NoError(err)
+	assert.Equal(uint32(0x01020304), id)
+	assert.Equal([]byte("datagram"), datagram)
+
+	// An empty datagram is valid.
+	id, datagram, err = ParseUdpFrame(frame[:UdpFrameHeaderSize])
+	require.NoError(err)
+	assert.Equal(uint32(0x01020304), id)
+	assert.Empty(datagram)
+
+	_, _, err = ParseUdpFrame(frame[:UdpFrameHeaderSize-1])
+	assert.Error(err)
+}
@@ -1,8 +1,13 @@
 package common
 
 import (
+	"github.com/hashicorp/boundary/internal/auth/ldap"
+	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
+	credstatic "github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/history"
+	"github.com/hashicorp/boundary/internal/host/inventory"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/servers"
@@ -11,11 +16,16 @@ import (
 )
 
 type (
-	AuthTokenRepoFactory    func() (*authtoken.Repository, error)
-	IamRepoFactory          func() (*iam.Repository, error)
-	PasswordAuthRepoFactory func() (*password.Repository, error)
-	ServersRepoFactory      func() (*servers.Repository, error)
-	StaticRepoFactory       func() (*static.Repository, error)
-	SessionRepoFactory      func() (*session.Repository, error)
-	TargetRepoFactory       func() (*target.Repository, error)
+	AuthTokenRepoFactory        func() (*authtoken.Repository, error)
+	HistoryRepoFactory          func() (*history.Repository, error)
+	IamRepoFactory              func() (*iam.Repository, error)
+	InventoryRepoFactory        func() (*inventory.Repository, error)
+	LdapAuthRepoFactory         func() (*ldap.Repository, error)
+	OidcAuthRepoFactory         func() (*oidc.Repository, error)
+	PasswordAuthRepoFactory     func() (*password.Repository, error)
+	ServersRepoFactory          func() (*servers.Repository, error)
+	StaticRepoFactory           func() (*static.Repository, error)
+	SessionRepoFactory          func() (*session.Repository, error)
+	StaticCredentialRepoFactory func() (*credstatic.Repository, error)
+	TargetRepoFactory           func() (*target.Repository, error)
 )
@@ -6,10 +6,15 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/hashicorp/boundary/internal/auth/ldap"
+	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/cmd/config"
+	credstatic "github.com/hashicorp/boundary/internal/credential/static"
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/history"
+	"github.com/hashicorp/boundary/internal/host/inventory"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
@@ -38,13 +43,18 @@ type Controller struct {
 	workerStatusUpdateTimes *sync.Map
 
 	// Repo factory methods
-	AuthTokenRepoFn    common.AuthTokenRepoFactory
-	IamRepoFn          common.IamRepoFactory
-	PasswordAuthRepoFn common.PasswordAuthRepoFactory
-	ServersRepoFn      common.ServersRepoFactory
-	SessionRepoFn      common.SessionRepoFactory
-	StaticHostRepoFn   common.StaticRepoFactory
-	TargetRepoFn       common.TargetRepoFactory
+	AuthTokenRepoFn        common.AuthTokenRepoFactory
+	HistoryRepoFn          common.HistoryRepoFactory
+	IamRepoFn              common.IamRepoFactory
+	InventoryHostRepoFn    common.InventoryRepoFactory
+	LdapAuthRepoFn         common.LdapAuthRepoFactory
+	OidcAuthRepoFn         common.OidcAuthRepoFactory
+	PasswordAuthRepoFn     common.PasswordAuthRepoFactory
+	ServersRepoFn          common.ServersRepoFactory
+	SessionRepoFn          common.SessionRepoFactory
+	StaticCredentialRepoFn common.StaticCredentialRepoFactory
+	StaticHostRepoFn       common.StaticRepoFactory
+	TargetRepoFn           common.TargetRepoFactory
 
 	kms *kms.Kms
 
@@ -113,6 +123,17 @@ func New(conf *Config) (*Controller, error) {
 	c.StaticHostRepoFn = func() (*static.Repository, error) {
 		return static.NewRepository(dbase, dbase, c.kms)
 	}
+	var inventorySources inventory.SourcePolicy
+	if s := c.conf.RawConfig.Controller.InventorySources; s != nil {
+		inventorySources.AllowedPaths = s.AllowedPaths
+		inventorySources.AllowedHosts = s.AllowedHosts
+	}
+	c.InventoryHostRepoFn = func() (*inventory.Repository, error) {
+		return inventory.NewRepository(dbase, dbase, c.kms, inventory.WithSourcePolicy(inventorySources))
+	}
+	c.StaticCredentialRepoFn = func() (*credstatic.Repository, error) {
+		return credstatic.NewRepository(dbase, dbase, c.kms)
+	}
 	c.AuthTokenRepoFn = func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(dbase, dbase, c.kms)
 	}
@@ -122,12 +143,21 @@ func New(conf *Config) (*Controller, error) {
 	c.PasswordAuthRepoFn = func() (*password.Repository, error) {
 		return password.NewRepository(dbase, dbase, c.kms)
 	}
+	c.OidcAuthRepoFn = func() (*oidc.Repository, error) {
+		return oidc.NewRepository(dbase, dbase, c.kms)
+	}
+	c.LdapAuthRepoFn = func() (*ldap.Repository, error) {
+		return ldap.NewRepository(dbase, dbase, c.kms)
+	}
 	c.TargetRepoFn = func() (*target.Repository, error) {
 		return target.NewRepository(dbase, dbase, c.kms)
 	}
 	c.SessionRepoFn = func() (*session.Repository, error) {
 		return session.NewRepository(dbase, dbase, c.kms)
 	}
+	c.HistoryRepoFn = func() (*history.Repository, error) {
+		return history.NewRepository(dbase, c.kms)
+	}
 
 	c.workerAuthCache = cache.New(0, 0)
 
@@ -148,6 +178,8 @@ func (c *Controller) Start() error {
 	c.startStatusTicking(c.baseContext)
 	c.startRecoveryNonceCleanupTicking(c.baseContext)
 	c.startTerminateCompletedSessionsTicking(c.baseContext)
+	c.startInventorySyncTicking(c.baseContext)
+	c.startKeyRewrapTicking(c.baseContext)
 	c.started.Store(true)
 
 	return nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"strings"
@@ -13,10 +14,14 @@ import (
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/event"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/accounts"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authmethods"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/credential_libraries"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/credential_stores"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/host_sets"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/oplog_entries"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/targets"
 	"github.com/hashicorp/boundary/sdk/strutil"
@@ -79,35 +84,49 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 		runtime.WithErrorHandler(handlers.ErrorHandler(c.logger)),
 		runtime.WithForwardResponseOption(handlers.OutgoingInterceptor),
 	)
-	hcs, err := host_catalogs.NewService(c.StaticHostRepoFn, c.IamRepoFn)
+	hcs, err := host_catalogs.NewService(c.StaticHostRepoFn, c.InventoryHostRepoFn, c.IamRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create host catalog handler service: %w", err)
 	}
 	if err := services.RegisterHostCatalogServiceHandlerServer(ctx, mux, hcs); err != nil {
 		return nil, fmt.Errorf("failed to register host catalog service handler: %w", err)
 	}
-	hss, err := host_sets.NewService(c.StaticHostRepoFn)
+	css, err := credential_stores.NewService(c.StaticCredentialRepoFn, c.IamRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credential store handler service: %w", err)
+	}
+	if err := services.RegisterCredentialStoreServiceHandlerServer(ctx, mux, css); err != nil {
+		return nil, fmt.Errorf("failed to register credential store service handler: %w", err)
+	}
+	cls, err := credential_libraries.NewService(c.StaticCredentialRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credential library handler service: %w", err)
+	}
+	if err := services.RegisterCredentialLibraryServiceHandlerServer(ctx, mux, cls); err != nil {
+		return nil, fmt.Errorf("failed to register credential library service handler: %w", err)
+	}
+	hss, err := host_sets.NewService(c.StaticHostRepoFn, c.InventoryHostRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create host set handler service: %w", err)
 	}
 	if err := services.RegisterHostSetServiceHandlerServer(ctx, mux, hss); err != nil {
 		return nil, fmt.Errorf("failed to register host set service handler: %w", err)
 	}
-	hs, err := hosts.NewService(c.StaticHostRepoFn)
+	hs, err := hosts.NewService(c.StaticHostRepoFn, c.InventoryHostRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create host handler service: %w", err)
 	}
 	if err := services.RegisterHostServiceHandlerServer(ctx, mux, hs); err != nil {
 		return nil, fmt.Errorf("failed to register host service handler: %w", err)
 	}
-	accts, err := accounts.NewService(c.PasswordAuthRepoFn)
+	accts, err := accounts.NewService(c.PasswordAuthRepoFn, c.OidcAuthRepoFn, c.LdapAuthRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create account handler service: %w", err)
 	}
 	if err := services.RegisterAccountServiceHandlerServer(ctx, mux, accts); err != nil {
 		return nil, fmt.Errorf("failed to register account service handler: %w", err)
 	}
-	authMethods, err := authmethods.NewService(c.kms, c.PasswordAuthRepoFn, c.IamRepoFn, c.AuthTokenRepoFn)
+	authMethods, err := authmethods.NewService(c.kms, c.PasswordAuthRepoFn, c.OidcAuthRepoFn, c.LdapAuthRepoFn, c.IamRepoFn, c.AuthTokenRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create auth method handler service: %w", err)
 	}
@@ -121,7 +140,7 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	if err := services.RegisterAuthTokenServiceHandlerServer(ctx, mux, authtoks); err != nil {
 		return nil, fmt.Errorf("failed to register auth token service handler: %w", err)
 	}
-	os, err := scopes.NewService(c.IamRepoFn)
+	os, err := scopes.NewService(c.IamRepoFn, c.kms)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create scope handler service: %w", err)
 	}
@@ -141,7 +160,9 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 		c.IamRepoFn,
 		c.ServersRepoFn,
 		c.SessionRepoFn,
-		c.StaticHostRepoFn)
+		c.StaticHostRepoFn,
+		c.InventoryHostRepoFn,
+		c.StaticCredentialRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create target handler service: %w", err)
 	}
@@ -169,6 +190,13 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	if err := services.RegisterSessionServiceHandlerServer(ctx, mux, ss); err != nil {
 		return nil, fmt.Errorf("failed to register session service handler: %w", err)
 	}
+	ops, err := oplog_entries.NewService(c.HistoryRepoFn, c.IamRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oplog entry handler service: %w", err)
+	}
+	if err := services.RegisterOplogEntryServiceHandlerServer(ctx, mux, ops); err != nil {
+		return nil, fmt.Errorf("failed to register oplog entry service handler: %w", err)
+	}
 
 	return mux, nil
 }
@@ -221,14 +249,64 @@ func wrapHandlerWithCommonFuncs(h http.Handler, c *Controller, props HandlerProp
 
 		requestInfo.PublicId, requestInfo.EncryptedToken, requestInfo.TokenFormat = auth.GetTokenFromRequest(c.logger, c.kms, r)
 		ctx = auth.NewVerifierContext(ctx, c.logger, c.IamRepoFn, c.AuthTokenRepoFn, c.ServersRepoFn, c.kms, requestInfo)
+		ctx = event.NewEventerContext(ctx, c.conf.Eventer)
 
 		// Set the context back on the request
 		r = r.WithContext(ctx)
 
-		h.ServeHTTP(w, r)
+		// Only API requests are audited, not requests for the UI's assets
+		if c.conf.Eventer == nil || !strings.HasPrefix(r.URL.Path, "/v1/") {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		h.ServeHTTP(sw, r)
+		c.auditRequest(r, sw.status)
 	})
 }
 
+// auditRequest emits an audit event for a request the API handled with the
+// given status.
+func (c *Controller) auditRequest(r *http.Request, status int) {
+	a := &event.Audit{
+		Method:     r.Method,
+		Path:       r.URL.Path,
+		ClientAddr: r.RemoteAddr,
+		StatusCode: status,
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		a.ClientAddr = host
+	}
+	if info, ok := auth.AuditInfoFromContext(r.Context()); ok {
+		a.UserId = info.UserId
+		a.AuthTokenId = info.AuthTokenId
+		a.ScopeId = info.ScopeId
+		a.ResourceId = info.ResourceId
+		a.ResourceType = info.ResourceType
+		a.Action = info.Action
+		a.Authorized = info.Authorized
+	}
+	c.conf.Eventer.Audit(a)
+}
+
+// statusWriter records the status code written to the response.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 func wrapHandlerWithCors(h http.Handler, props HandlerProperties) http.Handler {
 	allowedMethods := []string{
 		http.MethodDelete,
@@ -6,10 +6,19 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/event"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -195,3 +204,46 @@ func TestHandleImplementedPaths(t *testing.T) {
 		}
 	}
 }
+
+func TestAuditRequest(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	dir, err := ioutil.TempDir("", "boundary-audit-test")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.log")
+
+	eventer, err := event.NewEventer(hclog.NewNullLogger(), event.Config{
+		Sinks: []event.SinkConfig{{Type: event.FileSink, Path: path}},
+	})
+	require.NoError(err)
+	c := &Controller{conf: &Config{Server: &base.Server{Eventer: eventer}}}
+
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId("p_1234567890"), auth.WithUserId("u_1234567890"))
+	auth.Verify(ctx, auth.WithId("ttcp_1234567890"), auth.WithType(resource.Target), auth.WithAction(action.Delete))
+	req := httptest.NewRequest(http.MethodDelete, "/v1/targets/ttcp_1234567890", nil).WithContext(ctx)
+	c.auditRequest(req, http.StatusNoContent)
+	require.NoError(eventer.Close())
+
+	raw, err := ioutil.ReadFile(path)
+	require.NoError(err)
+	var rec struct {
+		Event struct {
+			Type string      `json:"type"`
+			Data event.Audit `json:"data"`
+		} `json:"event"`
+	}
+	require.NoError(json.Unmarshal(raw, &rec))
+	assert.Equal("audit", rec.Event.Type)
+	assert.Equal(event.Audit{
+		Method:       http.MethodDelete,
+		Path:         "/v1/targets/ttcp_1234567890",
+		ClientAddr:   "192.0.2.1",
+		UserId:       "u_1234567890",
+		ScopeId:      "p_1234567890",
+		ResourceId:   "ttcp_1234567890",
+		ResourceType: resource.Target.String(),
+		Action:       action.Delete.String(),
+		Authorized:   true,
+		StatusCode:   http.StatusNoContent,
+	}, rec.Event.Data)
+}
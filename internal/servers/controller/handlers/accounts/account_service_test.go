@@ -1,6 +1,7 @@
 package accounts_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -9,6 +10,8 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
+	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/db"
@@ -37,8 +40,14 @@ func TestGet(t *testing.T) {
 	repoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 
-	s, err := accounts.NewService(repoFn)
+	s, err := accounts.NewService(repoFn, oidcRepoFn, ldapRepoFn)
 	require.NoError(t, err, "Couldn't create new auth token service.")
 
 	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
@@ -46,14 +55,15 @@ func TestGet(t *testing.T) {
 	aa := password.TestAccounts(t, conn, am.GetPublicId(), 1)[0]
 
 	wireAccount := pb.Account{
-		Id:           aa.GetPublicId(),
-		AuthMethodId: aa.GetAuthMethodId(),
-		CreatedTime:  aa.GetCreateTime().GetTimestamp(),
-		UpdatedTime:  aa.GetUpdateTime().GetTimestamp(),
-		Scope:        &scopepb.ScopeInfo{Id: org.GetPublicId(), Type: scope.Org.String()},
-		Version:      1,
-		Type:         "password",
-		Attributes:   &structpb.Struct{Fields: map[string]*structpb.Value{"login_name": structpb.NewStringValue(aa.GetLoginName())}},
+		Id:                aa.GetPublicId(),
+		AuthMethodId:      aa.GetAuthMethodId(),
+		CreatedTime:       aa.GetCreateTime().GetTimestamp(),
+		UpdatedTime:       aa.GetUpdateTime().GetTimestamp(),
+		Scope:             &scopepb.ScopeInfo{Id: org.GetPublicId(), Type: scope.Org.String()},
+		Version:           1,
+		Type:              "password",
+		Attributes:        &structpb.Struct{Fields: map[string]*structpb.Value{"login_name": structpb.NewStringValue(aa.GetLoginName())}},
+		AuthorizedActions: accounts.IdActions.Strings(),
 	}
 
 	cases := []struct {
@@ -107,6 +117,12 @@ func TestList(t *testing.T) {
 	repoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 
 	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
 	ams := password.TestAuthMethods(t, conn, o.GetPublicId(), 3)
@@ -115,28 +131,30 @@ func TestList(t *testing.T) {
 	var wantSomeAccounts []*pb.Account
 	for _, aa := range password.TestAccounts(t, conn, amSomeAccounts.GetPublicId(), 3) {
 		wantSomeAccounts = append(wantSomeAccounts, &pb.Account{
-			Id:           aa.GetPublicId(),
-			AuthMethodId: aa.GetAuthMethodId(),
-			CreatedTime:  aa.GetCreateTime().GetTimestamp(),
-			UpdatedTime:  aa.GetUpdateTime().GetTimestamp(),
-			Scope:        &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: scope.Org.String()},
-			Version:      1,
-			Type:         "password",
-			Attributes:   &structpb.Struct{Fields: map[string]*structpb.Value{"login_name": structpb.NewStringValue(aa.GetLoginName())}},
+			Id:                aa.GetPublicId(),
+			AuthMethodId:      aa.GetAuthMethodId(),
+			CreatedTime:       aa.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       aa.GetUpdateTime().GetTimestamp(),
+			Scope:             &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: scope.Org.String()},
+			Version:           1,
+			Type:              "password",
+			Attributes:        &structpb.Struct{Fields: map[string]*structpb.Value{"login_name": structpb.NewStringValue(aa.GetLoginName())}},
+			AuthorizedActions: accounts.IdActions.Strings(),
 		})
 	}
 
 	var wantOtherAccounts []*pb.Account
 	for _, aa := range password.TestAccounts(t, conn, amOtherAccounts.GetPublicId(), 3) {
 		wantOtherAccounts = append(wantOtherAccounts, &pb.Account{
-			Id:           aa.GetPublicId(),
-			AuthMethodId: aa.GetAuthMethodId(),
-			CreatedTime:  aa.GetCreateTime().GetTimestamp(),
-			UpdatedTime:  aa.GetUpdateTime().GetTimestamp(),
-			Scope:        &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: scope.Org.String()},
-			Version:      1,
-			Type:         "password",
-			Attributes:   &structpb.Struct{Fields: map[string]*structpb.Value{"login_name": structpb.NewStringValue(aa.GetLoginName())}},
+			Id:                aa.GetPublicId(),
+			AuthMethodId:      aa.GetAuthMethodId(),
+			CreatedTime:       aa.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       aa.GetUpdateTime().GetTimestamp(),
+			Scope:             &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: scope.Org.String()},
+			Version:           1,
+			Type:              "password",
+			Attributes:        &structpb.Struct{Fields: map[string]*structpb.Value{"login_name": structpb.NewStringValue(aa.GetLoginName())}},
+			AuthorizedActions: accounts.IdActions.Strings(),
 		})
 	}
 
@@ -170,7 +188,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := accounts.NewService(repoFn)
+			s, err := accounts.NewService(repoFn, oidcRepoFn, ldapRepoFn)
 			require.NoError(err, "Couldn't create new user service.")
 
 			got, gErr := s.ListAccounts(auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId())), &pbs.ListAccountsRequest{AuthMethodId: tc.authMethod})
@@ -191,12 +209,18 @@ func TestDelete(t *testing.T) {
 	repoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 
 	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
 	am1 := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
 	ac := password.TestAccounts(t, conn, am1.GetPublicId(), 1)[0]
 
-	s, err := accounts.NewService(repoFn)
+	s, err := accounts.NewService(repoFn, oidcRepoFn, ldapRepoFn)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	cases := []struct {
@@ -250,12 +274,18 @@ func TestDelete_twice(t *testing.T) {
 	repoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 
 	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
 	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
 	ac := password.TestAccounts(t, conn, am.GetPublicId(), 1)[0]
 
-	s, err := accounts.NewService(repoFn)
+	s, err := accounts.NewService(repoFn, oidcRepoFn, ldapRepoFn)
 	require.NoError(err, "Error when getting new user service")
 	req := &pbs.DeleteAccountRequest{
 		Id: ac.GetPublicId(),
@@ -275,8 +305,14 @@ func TestCreate(t *testing.T) {
 	repoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 
-	s, err := accounts.NewService(repoFn)
+	s, err := accounts.NewService(repoFn, oidcRepoFn, ldapRepoFn)
 	require.NoError(t, err, "Error when getting new account service.")
 
 	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
@@ -467,10 +503,16 @@ func TestUpdate(t *testing.T) {
 	repoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 
 	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
 	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
-	tested, err := accounts.NewService(repoFn)
+	tested, err := accounts.NewService(repoFn, oidcRepoFn, ldapRepoFn)
 	require.NoError(t, err, "Error when getting new auth_method service.")
 
 	defaultScopeInfo := &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: o.GetType()}
@@ -819,9 +861,15 @@ func TestSetPassword(t *testing.T) {
 	repoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 
 	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
-	tested, err := accounts.NewService(repoFn)
+	tested, err := accounts.NewService(repoFn, oidcRepoFn, ldapRepoFn)
 	require.NoError(t, err, "Error when getting new auth_method service.")
 
 	createAccount := func(t *testing.T, pw string) *pb.Account {
@@ -932,9 +980,15 @@ func TestChangePassword(t *testing.T) {
 	repoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 
 	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
-	tested, err := accounts.NewService(repoFn)
+	tested, err := accounts.NewService(repoFn, oidcRepoFn, ldapRepoFn)
 	require.NoError(t, err, "Error when getting new auth_method service.")
 
 	createAccount := func(t *testing.T, pw string) *pb.Account {
@@ -1066,3 +1120,142 @@ func TestChangePassword(t *testing.T) {
 		})
 	}
 }
+
+func TestOidcAccounts(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrap)
+	repoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kmsCache)
+	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kmsCache)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kmsCache)
+	}
+	tested, err := accounts.NewService(repoFn, oidcRepoFn, ldapRepoFn)
+	require.NoError(t, err)
+
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	databaseWrapper, err := kmsCache.GetWrapper(context.Background(), org.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+	am := oidc.TestAuthMethod(t, conn, databaseWrapper, org.GetPublicId(), "https://idp.example.com", "client", "secret")
+	acct := oidc.TestAccount(t, conn, am.GetPublicId(), "https://idp.example.com", "alice")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(org.GetPublicId()))
+
+	t.Run("get", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := tested.GetAccount(ctx, &pbs.GetAccountRequest{Id: acct.GetPublicId()})
+		require.NoError(err)
+		assert.Equal(auth.OidcSubtype.String(), got.GetItem().GetType())
+		assert.Equal("alice", got.GetItem().GetAttributes().GetFields()["subject"].GetStringValue())
+	})
+	t.Run("list", func(t *testing.T) {
+		got, err := tested.ListAccounts(ctx, &pbs.ListAccountsRequest{AuthMethodId: am.GetPublicId()})
+		require.NoError(t, err)
+		assert.Len(t, got.GetItems(), 1)
+	})
+	t.Run("create-is-rejected", func(t *testing.T) {
+		_, err := tested.CreateAccount(ctx, &pbs.CreateAccountRequest{Item: &pb.Account{
+			AuthMethodId: am.GetPublicId(),
+			Type:         auth.OidcSubtype.String(),
+		}})
+		assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Got %#v", err)
+	})
+	t.Run("update-name", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := tested.UpdateAccount(ctx, &pbs.UpdateAccountRequest{
+			Id:         acct.GetPublicId(),
+			UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+			Item: &pb.Account{
+				Name:    wrapperspb.String("alice"),
+				Version: acct.GetVersion(),
+			},
+		})
+		require.NoError(err)
+		assert.Equal("alice", got.GetItem().GetName().GetValue())
+	})
+	t.Run("delete", func(t *testing.T) {
+		_, err := tested.DeleteAccount(ctx, &pbs.DeleteAccountRequest{Id: acct.GetPublicId()})
+		require.NoError(t, err)
+	})
+}
+
+func TestLdapAccounts(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrap)
+	repoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kmsCache)
+	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kmsCache)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kmsCache)
+	}
+	tested, err := accounts.NewService(repoFn, oidcRepoFn, ldapRepoFn)
+	require.NoError(t, err)
+
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	databaseWrapper, err := kmsCache.GetWrapper(context.Background(), org.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+	am := ldap.TestAuthMethod(t, conn, databaseWrapper, org.GetPublicId(), []string{"ldaps://ldap.example.com"}, "ou=people,dc=example,dc=com")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(org.GetPublicId()))
+
+	var acct *pb.Account
+	t.Run("create", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		attrs, err := handlers.ProtoToStruct(&pb.LdapAccountAttributes{LoginName: "Alice"})
+		require.NoError(err)
+		got, err := tested.CreateAccount(ctx, &pbs.CreateAccountRequest{Item: &pb.Account{
+			AuthMethodId: am.GetPublicId(),
+			Type:         auth.LdapSubtype.String(),
+			Attributes:   attrs,
+		}})
+		require.NoError(err)
+		acct = got.GetItem()
+		assert.True(strings.HasPrefix(acct.GetId(), ldap.AccountPrefix))
+		assert.Equal(auth.LdapSubtype.String(), acct.GetType())
+		assert.Equal("alice", acct.GetAttributes().GetFields()["login_name"].GetStringValue())
+	})
+	t.Run("create-requires-login-name", func(t *testing.T) {
+		_, err := tested.CreateAccount(ctx, &pbs.CreateAccountRequest{Item: &pb.Account{
+			AuthMethodId: am.GetPublicId(),
+			Type:         auth.LdapSubtype.String(),
+		}})
+		assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Got %#v", err)
+	})
+	t.Run("get", func(t *testing.T) {
+		got, err := tested.GetAccount(ctx, &pbs.GetAccountRequest{Id: acct.GetId()})
+		require.NoError(t, err)
+		assert.Equal(t, auth.LdapSubtype.String(), got.GetItem().GetType())
+	})
+	t.Run("list", func(t *testing.T) {
+		got, err := tested.ListAccounts(ctx, &pbs.ListAccountsRequest{AuthMethodId: am.GetPublicId()})
+		require.NoError(t, err)
+		assert.Len(t, got.GetItems(), 1)
+	})
+	t.Run("update-login-name", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		attrs, err := handlers.ProtoToStruct(&pb.LdapAccountAttributes{LoginName: "alice.doe"})
+		require.NoError(err)
+		got, err := tested.UpdateAccount(ctx, &pbs.UpdateAccountRequest{
+			Id:         acct.GetId(),
+			UpdateMask: &field_mask.FieldMask{Paths: []string{"attributes.login_name"}},
+			Item: &pb.Account{
+				Attributes: attrs,
+				Version:    acct.GetVersion(),
+			},
+		})
+		require.NoError(err)
+		assert.Equal("alice.doe", got.GetItem().GetAttributes().GetFields()["login_name"].GetStringValue())
+	})
+	t.Run("delete", func(t *testing.T) {
+		_, err := tested.DeleteAccount(ctx, &pbs.DeleteAccountRequest{Id: acct.GetId()})
+		require.NoError(t, err)
+	})
+}
@@ -4,9 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
+	ldapstore "github.com/hashicorp/boundary/internal/auth/ldap/store"
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	oidcstore "github.com/hashicorp/boundary/internal/auth/oidc/store"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/auth/password/store"
 	"github.com/hashicorp/boundary/internal/authtoken"
@@ -29,10 +34,14 @@ import (
 const (
 	loginNameKey = "login_name"
 	pwKey        = "password"
+	stateKey     = "state"
+	codeKey      = "code"
 )
 
 var (
-	maskManager handlers.MaskManager
+	maskManager     handlers.MaskManager
+	oidcMaskManager handlers.MaskManager
+	ldapMaskManager handlers.MaskManager
 )
 
 func init() {
@@ -40,28 +49,51 @@ func init() {
 	if maskManager, err = handlers.NewMaskManager(&store.AuthMethod{}, &pb.AuthMethod{}, &pb.PasswordAuthMethodAttributes{}); err != nil {
 		panic(err)
 	}
+	if oidcMaskManager, err = handlers.NewMaskManager(&oidcstore.AuthMethod{}, &pb.AuthMethod{}, &pb.OidcAuthMethodAttributes{}); err != nil {
+		panic(err)
+	}
+	if ldapMaskManager, err = handlers.NewMaskManager(&ldapstore.AuthMethod{}, &pb.AuthMethod{}, &pb.LdapAuthMethodAttributes{}); err != nil {
+		panic(err)
+	}
+}
+
+// IdActions contains the set of actions that can be performed on
+// individual resources
+var IdActions = action.ActionSet{
+	action.Read,
+	action.Update,
+	action.Delete,
+	action.Authenticate,
 }
 
 // Service handles request as described by the pbs.AuthMethodServiceServer interface.
 type Service struct {
-	kms       *kms.Kms
-	pwRepoFn  common.PasswordAuthRepoFactory
-	iamRepoFn common.IamRepoFactory
-	atRepoFn  common.AuthTokenRepoFactory
+	kms        *kms.Kms
+	pwRepoFn   common.PasswordAuthRepoFactory
+	oidcRepoFn common.OidcAuthRepoFactory
+	ldapRepoFn common.LdapAuthRepoFactory
+	iamRepoFn  common.IamRepoFactory
+	atRepoFn   common.AuthTokenRepoFactory
 }
 
 // NewService returns a auth method service which handles auth method related requests to boundary.
-func NewService(kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, iamRepoFn common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory) (Service, error) {
+func NewService(kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, oidcRepoFn common.OidcAuthRepoFactory, ldapRepoFn common.LdapAuthRepoFactory, iamRepoFn common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory) (Service, error) {
 	if kms == nil {
 		return Service{}, errors.New("nil kms provided")
 	}
 	if pwRepoFn == nil {
 		return Service{}, fmt.Errorf("nil password repository provided")
 	}
+	if oidcRepoFn == nil {
+		return Service{}, fmt.Errorf("nil oidc repository provided")
+	}
+	if ldapRepoFn == nil {
+		return Service{}, fmt.Errorf("nil ldap repository provided")
+	}
 	if iamRepoFn == nil {
 		return Service{}, fmt.Errorf("nil iam repository provided")
 	}
-	return Service{kms: kms, pwRepoFn: pwRepoFn, iamRepoFn: iamRepoFn, atRepoFn: atRepoFn}, nil
+	return Service{kms: kms, pwRepoFn: pwRepoFn, oidcRepoFn: oidcRepoFn, ldapRepoFn: ldapRepoFn, iamRepoFn: iamRepoFn, atRepoFn: atRepoFn}, nil
 }
 
 var _ pbs.AuthMethodServiceServer = Service{}
@@ -71,18 +103,37 @@ func (s Service) ListAuthMethods(ctx context.Context, req *pbs.ListAuthMethodsRe
 	if err := validateListRequest(req); err != nil {
 		return nil, err
 	}
-	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
-		return nil, authResults.Error
+	scopeResults, err := s.listAuthResults(ctx, req.GetScopeId(), req.GetRecursive())
+	if err != nil {
+		return nil, err
 	}
-	ul, err := s.listFromRepo(ctx, authResults.Scope.GetId())
+	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range ul {
-		item.Scope = authResults.Scope
+	items, nextPageToken, err := handlers.Paginate(req.GetPageSize(), req.GetPageToken(), filter, func(afterId string, limit int) ([]handlers.PageItem, error) {
+		var items []handlers.PageItem
+		for _, authResults := range scopeResults {
+			ul, err := s.listFromRepo(ctx, authResults.Scope.GetId(), afterId, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range ul {
+				item.Scope = authResults.Scope
+				item.AuthorizedActions = authResults.FetchActionSetForId(ctx, item.Id, IdActions).Strings()
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return &pbs.ListAuthMethodsResponse{Items: ul}, nil
+	var finalItems []*pb.AuthMethod
+	for _, item := range items {
+		finalItems = append(finalItems, item.(*pb.AuthMethod))
+	}
+	return &pbs.ListAuthMethodsResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetAuthMethod implements the interface pbs.AuthMethodServiceServer.
@@ -99,6 +150,7 @@ func (s Service) GetAuthMethod(ctx context.Context, req *pbs.GetAuthMethodReques
 		return nil, err
 	}
 	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
 	return &pbs.GetAuthMethodResponse{Item: u}, nil
 }
 
@@ -162,14 +214,74 @@ func (s Service) Authenticate(ctx context.Context, req *pbs.AuthenticateRequest)
 		return nil, authResults.Error
 	}
 	creds := req.GetCredentials().GetFields()
-	tok, err := s.authenticateWithRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), creds[loginNameKey].GetStringValue(), creds[pwKey].GetStringValue())
+	var tok *pba.AuthToken
+	var err error
+	switch auth.SubtypeFromId(req.GetAuthMethodId()) {
+	case auth.OidcSubtype:
+		tok, err = s.authenticateWithOidcRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), creds[stateKey].GetStringValue(), creds[codeKey].GetStringValue())
+	case auth.LdapSubtype:
+		tok, err = s.authenticateWithLdapRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), creds[loginNameKey].GetStringValue(), creds[pwKey].GetStringValue())
+	default:
+		tok, err = s.authenticateWithRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), creds[loginNameKey].GetStringValue(), creds[pwKey].GetStringValue())
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &pbs.AuthenticateResponse{Item: tok, TokenType: req.GetTokenType()}, nil
 }
 
+// StartAuthentication implements the interface pbs.AuthMethodServiceServer.
+func (s Service) StartAuthentication(ctx context.Context, req *pbs.StartAuthenticationRequest) (*pbs.StartAuthenticationResponse, error) {
+	if err := validateStartAuthenticationRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetAuthMethodId(), action.Authenticate)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.oidcRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	authUrl, err := repo.StartAuth(ctx, req.GetAuthMethodId(), req.GetRedirectUrl())
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidParameter) {
+			return nil, handlers.InvalidArgumentErrorf("Invalid fields provided in request.", map[string]string{"redirect_url": "Must be an absolute http or https URL."})
+		}
+		return nil, fmt.Errorf("unable to start authentication: %w", err)
+	}
+	return &pbs.StartAuthenticationResponse{AuthUrl: authUrl}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*pb.AuthMethod, error) {
+	switch auth.SubtypeFromId(id) {
+	case auth.OidcSubtype:
+		repo, err := s.oidcRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		u, err := repo.LookupAuthMethod(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if u == nil {
+			return nil, handlers.NotFoundErrorf("AuthMethod %q doesn't exist.", id)
+		}
+		return toOidcAuthMethodProto(u)
+	case auth.LdapSubtype:
+		repo, err := s.ldapRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		u, err := repo.LookupAuthMethod(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if u == nil {
+			return nil, handlers.NotFoundErrorf("AuthMethod %q doesn't exist.", id)
+		}
+		return toLdapAuthMethodProto(u)
+	}
 	repo, err := s.pwRepoFn()
 	if err != nil {
 		return nil, err
@@ -187,12 +299,12 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.AuthMethod, er
 	return toAuthMethodProto(u)
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.AuthMethod, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeId, afterId string, limit int) ([]*pb.AuthMethod, error) {
 	repo, err := s.pwRepoFn()
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListAuthMethods(ctx, scopeId)
+	ul, err := repo.ListAuthMethods(ctx, scopeId, password.WithLimit(limit), password.WithStartPageAfterId(afterId))
 	if err != nil {
 		return nil, err
 	}
@@ -204,10 +316,57 @@ func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.AuthMe
 		}
 		outUl = append(outUl, ou)
 	}
+
+	oidcRepo, err := s.oidcRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	oidcUl, err := oidcRepo.ListAuthMethods(ctx, scopeId, oidc.WithLimit(limit), oidc.WithStartPageAfterId(afterId))
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range oidcUl {
+		ou, err := toOidcAuthMethodProto(u)
+		if err != nil {
+			return nil, err
+		}
+		outUl = append(outUl, ou)
+	}
+
+	ldapRepo, err := s.ldapRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	ldapUl, err := ldapRepo.ListAuthMethods(ctx, scopeId, ldap.WithLimit(limit), ldap.WithStartPageAfterId(afterId))
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range ldapUl {
+		ou, err := toLdapAuthMethodProto(u)
+		if err != nil {
+			return nil, err
+		}
+		outUl = append(outUl, ou)
+	}
+
+	// Each repository lists its auth methods in id order, so merge them into a
+	// single ordered list before applying the limit.
+	sort.Slice(outUl, func(i, j int) bool {
+		return outUl[i].GetId() < outUl[j].GetId()
+	})
+	if len(outUl) > limit {
+		outUl = outUl[:limit]
+	}
 	return outUl, nil
 }
 
 func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.AuthMethod) (*pb.AuthMethod, error) {
+	switch auth.SubtypeFromType(item.GetType()) {
+	case auth.OidcSubtype:
+		return s.createOidcInRepo(ctx, scopeId, item)
+	case auth.LdapSubtype:
+		return s.createLdapInRepo(ctx, scopeId, item)
+	}
 	var opts []password.Option
 	if item.GetName() != nil {
 		opts = append(opts, password.WithName(item.GetName().GetValue()))
@@ -233,7 +392,87 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Auth
 	return toAuthMethodProto(out)
 }
 
+func (s Service) createOidcInRepo(ctx context.Context, scopeId string, item *pb.AuthMethod) (*pb.AuthMethod, error) {
+	oidcAttrs := &pb.OidcAuthMethodAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), oidcAttrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	opts := []oidc.Option{
+		oidc.WithDiscoveryUrl(oidcAttrs.GetDiscoveryUrl()),
+		oidc.WithClientId(oidcAttrs.GetClientId()),
+		oidc.WithClientSecret(oidcAttrs.GetClientSecret()),
+		oidc.WithAllowedAudiences(oidcAttrs.GetAllowedAudiences()...),
+		oidc.WithAccountClaimMaps(oidcAttrs.GetAccountClaimMaps()...),
+	}
+	if item.GetName() != nil {
+		opts = append(opts, oidc.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, oidc.WithDescription(item.GetDescription().GetValue()))
+	}
+	u, err := oidc.NewAuthMethod(scopeId, opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build auth method for creation: %v.", err)
+	}
+	repo, err := s.oidcRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateAuthMethod(ctx, u)
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidParameter) {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"attributes": err.Error()})
+		}
+		return nil, fmt.Errorf("unable to create auth method: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create auth method but no error returned from repository.")
+	}
+	return toOidcAuthMethodProto(out)
+}
+
+func (s Service) createLdapInRepo(ctx context.Context, scopeId string, item *pb.AuthMethod) (*pb.AuthMethod, error) {
+	ldapAttrs := &pb.LdapAuthMethodAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), ldapAttrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	opts := ldapOptions(ldapAttrs)
+	if item.GetName() != nil {
+		opts = append(opts, ldap.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, ldap.WithDescription(item.GetDescription().GetValue()))
+	}
+	u, err := ldap.NewAuthMethod(scopeId, opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build auth method for creation: %v.", err)
+	}
+	repo, err := s.ldapRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateAuthMethod(ctx, u)
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidParameter) {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"attributes": err.Error()})
+		}
+		return nil, fmt.Errorf("unable to create auth method: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create auth method but no error returned from repository.")
+	}
+	return toLdapAuthMethodProto(out)
+}
+
 func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.AuthMethod) (*pb.AuthMethod, error) {
+	switch auth.SubtypeFromId(id) {
+	case auth.OidcSubtype:
+		return s.updateOidcInRepo(ctx, scopeId, id, mask, item)
+	case auth.LdapSubtype:
+		return s.updateLdapInRepo(ctx, scopeId, id, mask, item)
+	}
 	var opts []password.Option
 	if desc := item.GetDescription(); desc != nil {
 		opts = append(opts, password.WithDescription(desc.GetValue()))
@@ -278,12 +517,132 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	return toAuthMethodProto(out)
 }
 
-func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool, error) {
-	repo, err := s.pwRepoFn()
+func (s Service) updateOidcInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.AuthMethod) (*pb.AuthMethod, error) {
+	oidcAttrs := &pb.OidcAuthMethodAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), oidcAttrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	opts := []oidc.Option{
+		oidc.WithDiscoveryUrl(oidcAttrs.GetDiscoveryUrl()),
+		oidc.WithClientId(oidcAttrs.GetClientId()),
+		oidc.WithClientSecret(oidcAttrs.GetClientSecret()),
+		oidc.WithAllowedAudiences(oidcAttrs.GetAllowedAudiences()...),
+		oidc.WithAccountClaimMaps(oidcAttrs.GetAccountClaimMaps()...),
+	}
+	if desc := item.GetDescription(); desc != nil {
+		opts = append(opts, oidc.WithDescription(desc.GetValue()))
+	}
+	if name := item.GetName(); name != nil {
+		opts = append(opts, oidc.WithName(name.GetValue()))
+	}
+	u, err := oidc.NewAuthMethod(scopeId, opts...)
 	if err != nil {
-		return false, err
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build auth method for update: %v.", err)
+	}
+	version := item.GetVersion()
+
+	u.PublicId = id
+	dbMask := oidcMaskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.oidcRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, rowsUpdated, err := repo.UpdateAuthMethod(ctx, u, version, dbMask)
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidParameter) {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"attributes": err.Error()})
+		}
+		return nil, fmt.Errorf("unable to update auth method: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("AuthMethod %q doesn't exist or incorrect version provided.", id)
+	}
+	return toOidcAuthMethodProto(out)
+}
+
+func (s Service) updateLdapInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.AuthMethod) (*pb.AuthMethod, error) {
+	ldapAttrs := &pb.LdapAuthMethodAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), ldapAttrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	opts := ldapOptions(ldapAttrs)
+	if desc := item.GetDescription(); desc != nil {
+		opts = append(opts, ldap.WithDescription(desc.GetValue()))
+	}
+	if name := item.GetName(); name != nil {
+		opts = append(opts, ldap.WithName(name.GetValue()))
+	}
+	u, err := ldap.NewAuthMethod(scopeId, opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build auth method for update: %v.", err)
+	}
+	version := item.GetVersion()
+
+	u.PublicId = id
+	dbMask := ldapMaskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.ldapRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, rowsUpdated, err := repo.UpdateAuthMethod(ctx, u, version, dbMask)
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidParameter) {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"attributes": err.Error()})
+		}
+		return nil, fmt.Errorf("unable to update auth method: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("AuthMethod %q doesn't exist or incorrect version provided.", id)
+	}
+	return toLdapAuthMethodProto(out)
+}
+
+// ldapOptions returns the options for the directory settings in attrs.
+func ldapOptions(attrs *pb.LdapAuthMethodAttributes) []ldap.Option {
+	return []ldap.Option{
+		ldap.WithUrls(attrs.GetUrls()...),
+		ldap.WithStartTls(attrs.GetStartTls().GetValue()),
+		ldap.WithInsecureTls(attrs.GetInsecureTls().GetValue()),
+		ldap.WithCertificates(attrs.GetCertificates()),
+		ldap.WithCertificatePins(attrs.GetCertificatePins()...),
+		ldap.WithBindCredential(attrs.GetBindDn(), attrs.GetBindPassword()),
+		ldap.WithUserSearch(attrs.GetUserDn(), attrs.GetUserFilter()),
+		ldap.WithGroupSearch(attrs.GetGroupDn(), attrs.GetGroupFilter(), attrs.GetGroupAttr()),
+		ldap.WithSyncGroups(attrs.GetSyncGroups().GetValue()),
+	}
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool, error) {
+	var rows int
+	var err error
+	switch auth.SubtypeFromId(id) {
+	case auth.OidcSubtype:
+		var repo *oidc.Repository
+		if repo, err = s.oidcRepoFn(); err != nil {
+			return false, err
+		}
+		rows, err = repo.DeleteAuthMethod(ctx, scopeId, id)
+	case auth.LdapSubtype:
+		var repo *ldap.Repository
+		if repo, err = s.ldapRepoFn(); err != nil {
+			return false, err
+		}
+		rows, err = repo.DeleteAuthMethod(ctx, scopeId, id)
+	default:
+		var repo *password.Repository
+		if repo, err = s.pwRepoFn(); err != nil {
+			return false, err
+		}
+		rows, err = repo.DeleteAuthMethod(ctx, scopeId, id)
 	}
-	rows, err := repo.DeleteAuthMethod(ctx, scopeId, id)
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
 			return false, nil
@@ -294,32 +653,136 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 }
 
 func (s Service) authenticateWithRepo(ctx context.Context, scopeId, authMethodId, loginName, pw string) (*pba.AuthToken, error) {
-	iamRepo, err := s.iamRepoFn()
+	pwRepo, err := s.pwRepoFn()
 	if err != nil {
 		return nil, err
 	}
-	atRepo, err := s.atRepoFn()
+
+	acct, err := pwRepo.Authenticate(ctx, scopeId, authMethodId, loginName, pw)
 	if err != nil {
 		return nil, err
 	}
-	pwRepo, err := s.pwRepoFn()
+	if acct == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+	}
+	return s.issueAuthToken(ctx, scopeId, acct.GetPublicId())
+}
+
+func (s Service) authenticateWithOidcRepo(ctx context.Context, scopeId, authMethodId, state, code string) (*pba.AuthToken, error) {
+	oidcRepo, err := s.oidcRepoFn()
 	if err != nil {
 		return nil, err
 	}
 
-	acct, err := pwRepo.Authenticate(ctx, scopeId, authMethodId, loginName, pw)
+	acct, err := oidcRepo.Authenticate(ctx, authMethodId, state, code)
+	if err != nil {
+		if errors.Is(err, oidc.ErrInvalidState) || errors.Is(err, oidc.ErrInvalidIdToken) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+		}
+		return nil, err
+	}
+	return s.issueAuthToken(ctx, scopeId, acct.GetPublicId())
+}
+
+func (s Service) authenticateWithLdapRepo(ctx context.Context, scopeId, authMethodId, loginName, pw string) (*pba.AuthToken, error) {
+	ldapRepo, err := s.ldapRepoFn()
+	if err != nil {
+		return nil, err
+	}
+
+	acct, gs, err := ldapRepo.Authenticate(ctx, authMethodId, loginName, pw)
 	if err != nil {
+		if errors.Is(err, ldap.ErrDirectoryUnavailable) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unavailable, "Unable to reach the directory of the auth method.")
+		}
 		return nil, err
 	}
 	if acct == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
 	}
+	if gs != nil {
+		if err := s.syncGroups(ctx, scopeId, acct.GetPublicId(), gs); err != nil {
+			return nil, err
+		}
+	}
+	return s.issueAuthToken(ctx, scopeId, acct.GetPublicId())
+}
+
+// syncGroups adds the user of the account to the groups of the scope named
+// in gs.Add and removes the user from those named in gs.Remove. Group names
+// are matched case insensitively and names without a matching group are
+// ignored.
+func (s Service) syncGroups(ctx context.Context, scopeId, accountId string, gs *ldap.GroupSync) error {
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return err
+	}
+	u, err := iamRepo.LookupUserWithLogin(ctx, accountId, iam.WithAutoVivify(true))
+	if err != nil {
+		return err
+	}
+	grps, err := iamRepo.ListGroups(ctx, scopeId, iam.WithLimit(-1))
+	if err != nil {
+		return fmt.Errorf("unable to sync groups: %w", err)
+	}
+	for _, g := range grps {
+		add, remove := containsFold(gs.Add, g.GetName()), containsFold(gs.Remove, g.GetName())
+		if !add && !remove {
+			continue
+		}
+		members, err := iamRepo.ListGroupMembers(ctx, g.GetPublicId(), iam.WithLimit(-1))
+		if err != nil {
+			return fmt.Errorf("unable to sync groups: %w", err)
+		}
+		var isMember bool
+		for _, m := range members {
+			if m.GetMemberId() == u.GetPublicId() {
+				isMember = true
+				break
+			}
+		}
+		switch {
+		case add && !isMember:
+			_, err = iamRepo.AddGroupMembers(ctx, g.GetPublicId(), g.GetVersion(), []string{u.GetPublicId()})
+		case remove && isMember:
+			_, err = iamRepo.DeleteGroupMembers(ctx, g.GetPublicId(), g.GetVersion(), []string{u.GetPublicId()})
+		}
+		if err != nil {
+			return fmt.Errorf("unable to sync group %s: %w", g.GetPublicId(), err)
+		}
+	}
+	return nil
+}
+
+func containsFold(list []string, s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
 
-	u, err := iamRepo.LookupUserWithLogin(ctx, acct.GetPublicId(), iam.WithAutoVivify(true))
+// issueAuthToken creates an auth token for the user of the authenticated
+// account, creating the user if the account has none.
+func (s Service) issueAuthToken(ctx context.Context, scopeId, accountId string) (*pba.AuthToken, error) {
+	iamRepo, err := s.iamRepoFn()
 	if err != nil {
 		return nil, err
 	}
-	tok, err := atRepo.CreateAuthToken(ctx, u, acct.GetPublicId())
+	atRepo, err := s.atRepoFn()
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := iamRepo.LookupUserWithLogin(ctx, accountId, iam.WithAutoVivify(true))
+	if err != nil {
+		return nil, err
+	}
+	tok, err := atRepo.CreateAuthToken(ctx, u, accountId)
 	if err != nil {
 		return nil, err
 	}
@@ -371,27 +834,72 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 			return res
 		}
 	default:
-		repo, err := s.pwRepoFn()
-		if err != nil {
-			res.Error = err
-			return res
-		}
-		authMeth, err := repo.LookupAuthMethod(ctx, id)
+		scopeId, err := s.lookupScopeId(ctx, id)
 		if err != nil {
 			res.Error = err
 			return res
 		}
-		if authMeth == nil {
+		if scopeId == "" {
 			res.Error = handlers.NotFoundError()
 			return res
 		}
-		parentId = authMeth.GetScopeId()
+		parentId = scopeId
 		opts = append(opts, auth.WithId(id))
 	}
 	opts = append(opts, auth.WithScopeId(parentId))
 	return auth.Verify(ctx, opts...)
 }
 
+// listAuthResults authorizes listing in the provided scope or, when recursive,
+// separately in it and every scope beneath it. It returns the results for the
+// scopes in which listing is allowed.
+func (s Service) listAuthResults(ctx context.Context, scopeId string, recursive bool) ([]auth.VerifyResults, error) {
+	if recursive {
+		return auth.VerifyRecursive(ctx, s.iamRepoFn, auth.WithType(resource.AuthMethod), auth.WithAction(action.List), auth.WithScopeId(scopeId))
+	}
+	authResults := s.authResult(ctx, scopeId, action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	return []auth.VerifyResults{authResults}, nil
+}
+
+// lookupScopeId returns the scope id of the auth method or an empty string
+// if it does not exist.
+func (s Service) lookupScopeId(ctx context.Context, id string) (string, error) {
+	switch auth.SubtypeFromId(id) {
+	case auth.OidcSubtype:
+		repo, err := s.oidcRepoFn()
+		if err != nil {
+			return "", err
+		}
+		authMeth, err := repo.LookupAuthMethod(ctx, id)
+		if err != nil || authMeth == nil {
+			return "", err
+		}
+		return authMeth.GetScopeId(), nil
+	case auth.LdapSubtype:
+		repo, err := s.ldapRepoFn()
+		if err != nil {
+			return "", err
+		}
+		authMeth, err := repo.LookupAuthMethod(ctx, id)
+		if err != nil || authMeth == nil {
+			return "", err
+		}
+		return authMeth.GetScopeId(), nil
+	}
+	repo, err := s.pwRepoFn()
+	if err != nil {
+		return "", err
+	}
+	authMeth, err := repo.LookupAuthMethod(ctx, id)
+	if err != nil || authMeth == nil {
+		return "", err
+	}
+	return authMeth.GetScopeId(), nil
+}
+
 func toAuthMethodProto(in *password.AuthMethod) (*pb.AuthMethod, error) {
 	out := pb.AuthMethod{
 		Id:          in.GetPublicId(),
@@ -418,6 +926,72 @@ func toAuthMethodProto(in *password.AuthMethod) (*pb.AuthMethod, error) {
 	return &out, nil
 }
 
+func toOidcAuthMethodProto(in *oidc.AuthMethod) (*pb.AuthMethod, error) {
+	out := pb.AuthMethod{
+		Id:          in.GetPublicId(),
+		ScopeId:     in.GetScopeId(),
+		CreatedTime: in.GetCreateTime().GetTimestamp(),
+		UpdatedTime: in.GetUpdateTime().GetTimestamp(),
+		Version:     in.GetVersion(),
+		Type:        auth.OidcSubtype.String(),
+	}
+	if in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	// The client secret is never returned.
+	st, err := handlers.ProtoToStruct(&pb.OidcAuthMethodAttributes{
+		DiscoveryUrl:     in.GetDiscoveryUrl(),
+		ClientId:         in.GetClientId(),
+		AllowedAudiences: in.AudienceList(),
+		AccountClaimMaps: in.ClaimMapList(),
+	})
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building oidc attribute struct: %v", err)
+	}
+	out.Attributes = st
+	return &out, nil
+}
+
+func toLdapAuthMethodProto(in *ldap.AuthMethod) (*pb.AuthMethod, error) {
+	out := pb.AuthMethod{
+		Id:          in.GetPublicId(),
+		ScopeId:     in.GetScopeId(),
+		CreatedTime: in.GetCreateTime().GetTimestamp(),
+		UpdatedTime: in.GetUpdateTime().GetTimestamp(),
+		Version:     in.GetVersion(),
+		Type:        auth.LdapSubtype.String(),
+	}
+	if in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	// The bind password is never returned.
+	st, err := handlers.ProtoToStruct(&pb.LdapAuthMethodAttributes{
+		Urls:            in.UrlList(),
+		StartTls:        wrapperspb.Bool(in.GetStartTls()),
+		InsecureTls:     wrapperspb.Bool(in.GetInsecureTls()),
+		Certificates:    in.GetCertificates(),
+		CertificatePins: in.PinList(),
+		BindDn:          in.GetBindDn(),
+		UserDn:          in.GetUserDn(),
+		UserFilter:      in.GetUserFilter(),
+		GroupDn:         in.GetGroupDn(),
+		GroupFilter:     in.GetGroupFilter(),
+		GroupAttr:       in.GetGroupAttr(),
+		SyncGroups:      wrapperspb.Bool(in.GetSyncGroups()),
+	})
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building ldap attribute struct: %v", err)
+	}
+	out.Attributes = st
+	return &out, nil
+}
+
 func toAuthTokenProto(t *authtoken.AuthToken) *pba.AuthToken {
 	return &pba.AuthToken{
 		Id:                      t.GetPublicId(),
@@ -438,7 +1012,7 @@ func toAuthTokenProto(t *authtoken.AuthToken) *pba.AuthToken {
 //  * All required parameters are set
 //  * There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetAuthMethodRequest) error {
-	return handlers.ValidateGetRequest(password.AuthMethodPrefix, req, handlers.NoopValidatorFn)
+	return handlers.ValidateGetRequest(prefixFromId(req.GetId()), req, handlers.NoopValidatorFn)
 }
 
 func validateCreateRequest(req *pbs.CreateAuthMethodRequest) error {
@@ -454,15 +1028,42 @@ func validateCreateRequest(req *pbs.CreateAuthMethodRequest) error {
 			if err := handlers.StructToProto(req.GetItem().GetAttributes(), pwAttrs); err != nil {
 				badFields["attributes"] = "Attribute fields do not match the expected format."
 			}
+		case auth.OidcSubtype:
+			oidcAttrs := &pb.OidcAuthMethodAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), oidcAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if oidcAttrs.GetDiscoveryUrl() == "" {
+				badFields["attributes.discovery_url"] = "This is a required field."
+			}
+			if oidcAttrs.GetClientId() == "" {
+				badFields["attributes.client_id"] = "This is a required field."
+			}
+			if oidcAttrs.GetClientSecret() == "" {
+				badFields["attributes.client_secret"] = "This is a required field."
+			}
+		case auth.LdapSubtype:
+			ldapAttrs := &pb.LdapAuthMethodAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), ldapAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if len(ldapAttrs.GetUrls()) == 0 {
+				badFields["attributes.urls"] = "This is a required field."
+			}
+			if ldapAttrs.GetUserDn() == "" {
+				badFields["attributes.user_dn"] = "This is a required field."
+			}
 		default:
-			badFields["type"] = fmt.Sprintf("This is a required field and must be %q.", auth.PasswordSubtype.String())
+			badFields["type"] = fmt.Sprintf("This is a required field and must be %q, %q, or %q.", auth.PasswordSubtype.String(), auth.OidcSubtype.String(), auth.LdapSubtype.String())
 		}
 		return badFields
 	})
 }
 
 func validateUpdateRequest(req *pbs.UpdateAuthMethodRequest) error {
-	return handlers.ValidateUpdateRequest(password.AuthMethodPrefix, req, req.GetItem(), func() map[string]string {
+	return handlers.ValidateUpdateRequest(prefixFromId(req.GetId()), req, req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
 		switch auth.SubtypeFromId(req.GetId()) {
 		case auth.PasswordSubtype:
@@ -473,6 +1074,22 @@ func validateUpdateRequest(req *pbs.UpdateAuthMethodRequest) error {
 			if err := handlers.StructToProto(req.GetItem().GetAttributes(), pwAttrs); err != nil {
 				badFields["attributes"] = "Attribute fields do not match the expected format."
 			}
+		case auth.OidcSubtype:
+			if req.GetItem().GetType() != "" && auth.SubtypeFromType(req.GetItem().GetType()) != auth.OidcSubtype {
+				badFields["type"] = "Cannot modify the resource type."
+			}
+			oidcAttrs := &pb.OidcAuthMethodAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), oidcAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+			}
+		case auth.LdapSubtype:
+			if req.GetItem().GetType() != "" && auth.SubtypeFromType(req.GetItem().GetType()) != auth.LdapSubtype {
+				badFields["type"] = "Cannot modify the resource type."
+			}
+			ldapAttrs := &pb.LdapAuthMethodAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), ldapAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+			}
 		default:
 			badFields["id"] = "Incorrectly formatted identifier."
 		}
@@ -481,7 +1098,7 @@ func validateUpdateRequest(req *pbs.UpdateAuthMethodRequest) error {
 }
 
 func validateDeleteRequest(req *pbs.DeleteAuthMethodRequest) error {
-	return handlers.ValidateDeleteRequest(password.AuthMethodPrefix, req, handlers.NoopValidatorFn)
+	return handlers.ValidateDeleteRequest(prefixFromId(req.GetId()), req, handlers.NoopValidatorFn)
 }
 
 func validateListRequest(req *pbs.ListAuthMethodsRequest) error {
@@ -490,6 +1107,12 @@ func validateListRequest(req *pbs.ListAuthMethodsRequest) error {
 		req.GetScopeId() != scope.Global.String() {
 		badFields["scope_id"] = "This field must be 'global' or a valid org scope id."
 	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if _, err := handlers.DecodePageToken(req.GetPageToken()); err != nil {
+		badFields["page_token"] = "This is not a valid page token."
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
 	}
@@ -500,19 +1123,28 @@ func validateAuthenticateRequest(req *pbs.AuthenticateRequest) error {
 	badFields := make(map[string]string)
 	if strings.TrimSpace(req.GetAuthMethodId()) == "" {
 		badFields["auth_method_id"] = "This is a required field."
-	} else if !handlers.ValidId(password.AuthMethodPrefix, req.GetAuthMethodId()) {
+	} else if !handlers.ValidId(prefixFromId(req.GetAuthMethodId()), req.GetAuthMethodId()) {
 		badFields["auth_method_id"] = "Invalid formatted identifier."
 	}
-	// TODO: Update this when we enable different auth method types.
 	if req.GetCredentials() == nil {
 		badFields["credentials"] = "This is a required field."
 	}
 	creds := req.GetCredentials().GetFields()
-	if _, ok := creds[loginNameKey]; !ok {
-		badFields["credentials.login_name"] = "This is a required field."
-	}
-	if _, ok := creds[pwKey]; !ok {
-		badFields["credentials.password"] = "This is a required field."
+	switch auth.SubtypeFromId(req.GetAuthMethodId()) {
+	case auth.OidcSubtype:
+		if _, ok := creds[stateKey]; !ok {
+			badFields["credentials.state"] = "This is a required field."
+		}
+		if _, ok := creds[codeKey]; !ok {
+			badFields["credentials.code"] = "This is a required field."
+		}
+	default:
+		if _, ok := creds[loginNameKey]; !ok {
+			badFields["credentials.login_name"] = "This is a required field."
+		}
+		if _, ok := creds[pwKey]; !ok {
+			badFields["credentials.password"] = "This is a required field."
+		}
 	}
 	tType := strings.ToLower(strings.TrimSpace(req.GetTokenType()))
 	if tType != "" && tType != "token" && tType != "cookie" {
@@ -523,3 +1155,32 @@ func validateAuthenticateRequest(req *pbs.AuthenticateRequest) error {
 	}
 	return nil
 }
+
+func validateStartAuthenticationRequest(req *pbs.StartAuthenticationRequest) error {
+	badFields := make(map[string]string)
+	if strings.TrimSpace(req.GetAuthMethodId()) == "" {
+		badFields["auth_method_id"] = "This is a required field."
+	} else if !handlers.ValidId(oidc.AuthMethodPrefix, req.GetAuthMethodId()) {
+		badFields["auth_method_id"] = "Only OIDC auth methods support starting an authentication flow."
+	}
+	if strings.TrimSpace(req.GetRedirectUrl()) == "" {
+		badFields["redirect_url"] = "This is a required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
+}
+
+// prefixFromId returns the id prefix of the auth method subtype the provided
+// id belongs to.  The password prefix is returned for ids of an unknown
+// subtype so they fail validation.
+func prefixFromId(id string) string {
+	switch auth.SubtypeFromId(id) {
+	case auth.OidcSubtype:
+		return oidc.AuthMethodPrefix
+	case auth.LdapSubtype:
+		return ldap.AuthMethodPrefix
+	}
+	return password.AuthMethodPrefix
+}
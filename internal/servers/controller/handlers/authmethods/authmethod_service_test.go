@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
+	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/db"
@@ -47,6 +50,12 @@ func TestGet(t *testing.T) {
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
@@ -70,6 +79,7 @@ func TestGet(t *testing.T) {
 			Id:   o.GetPublicId(),
 			Type: o.GetType(),
 		},
+		AuthorizedActions: authmethods.IdActions.Strings(),
 	}
 
 	cases := []struct {
@@ -111,7 +121,7 @@ func TestGet(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+			s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 			require.NoError(err, "Couldn't create new auth_method service.")
 
 			got, gErr := s.GetAuthMethod(auth.DisabledAuthTestContext(auth.WithScopeId(tc.scopeId)), tc.req)
@@ -135,6 +145,12 @@ func TestList(t *testing.T) {
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
@@ -158,6 +174,7 @@ func TestList(t *testing.T) {
 				"min_password_length":   structpb.NewNumberValue(8),
 				"min_login_name_length": structpb.NewNumberValue(3),
 			}},
+			AuthorizedActions: authmethods.IdActions.Strings(),
 		})
 	}
 
@@ -175,9 +192,13 @@ func TestList(t *testing.T) {
 				"min_password_length":   structpb.NewNumberValue(8),
 				"min_login_name_length": structpb.NewNumberValue(3),
 			}},
+			AuthorizedActions: authmethods.IdActions.Strings(),
 		})
 	}
 
+	sort.Slice(wantSomeAuthMethods, func(i, j int) bool { return wantSomeAuthMethods[i].GetId() < wantSomeAuthMethods[j].GetId() })
+	sort.Slice(wantOtherAuthMethods, func(i, j int) bool { return wantOtherAuthMethods[i].GetId() < wantOtherAuthMethods[j].GetId() })
+
 	cases := []struct {
 		name    string
 		scopeId string
@@ -208,7 +229,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+			s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 			require.NoError(err, "Couldn't create new auth_method service.")
 
 			got, gErr := s.ListAuthMethods(auth.DisabledAuthTestContext(auth.WithScopeId(tc.scopeId)), &pbs.ListAuthMethodsRequest{ScopeId: tc.scopeId})
@@ -232,6 +253,12 @@ func TestDelete(t *testing.T) {
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
@@ -240,7 +267,7 @@ func TestDelete(t *testing.T) {
 	o, _ := iam.TestScopes(t, iamRepo)
 	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
 
-	s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+	s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 	require.NoError(t, err, "Error when getting new auth_method service.")
 
 	cases := []struct {
@@ -296,6 +323,12 @@ func TestDelete_twice(t *testing.T) {
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
@@ -304,7 +337,7 @@ func TestDelete_twice(t *testing.T) {
 	o, _ := iam.TestScopes(t, iamRepo)
 	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
 
-	s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+	s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 	require.NoError(err, "Error when getting new auth_method service.")
 
 	req := &pbs.DeleteAuthMethodRequest{
@@ -328,6 +361,12 @@ func TestCreate(t *testing.T) {
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
@@ -467,7 +506,7 @@ func TestCreate(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+			s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 			require.NoError(err, "Error when getting new auth_method service.")
 
 			got, gErr := s.CreateAuthMethod(auth.DisabledAuthTestContext(auth.WithScopeId(tc.req.GetItem().GetScopeId())), tc.req)
@@ -510,13 +549,19 @@ func TestUpdate(t *testing.T) {
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
 	iamRepo := iam.TestRepo(t, conn, wrapper)
 
 	o, _ := iam.TestScopes(t, iamRepo)
-	tested, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+	tested, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 	require.NoError(t, err, "Error when getting new auth_method service.")
 
 	defaultScopeInfo := &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: o.GetType()}
@@ -902,6 +947,12 @@ func TestAuthenticate(t *testing.T) {
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
@@ -1028,7 +1079,7 @@ func TestAuthenticate(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+			s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 			require.NoError(err)
 
 			resp, err := s.Authenticate(auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId())), tc.request)
@@ -1066,6 +1117,12 @@ func TestAuthenticate_AuthAccountConnectedToIamUser(t *testing.T) {
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
@@ -1085,7 +1142,7 @@ func TestAuthenticate_AuthAccountConnectedToIamUser(t *testing.T) {
 	iamUser, err := iamRepo.LookupUserWithLogin(context.Background(), acct.GetPublicId(), iam.WithAutoVivify(true))
 	require.NoError(err)
 
-	s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+	s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 	require.NoError(err)
 	resp, err := s.Authenticate(auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId())), &pbs.AuthenticateRequest{
 		AuthMethodId: am.GetPublicId(),
@@ -1108,3 +1165,186 @@ func TestAuthenticate_AuthAccountConnectedToIamUser(t *testing.T) {
 	assert.NotEmpty(aToken.GetToken())
 	assert.True(strings.HasPrefix(aToken.GetToken(), aToken.GetId()))
 }
+
+func TestAuthenticate_Oidc(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	databaseWrapper, err := kmsCache.GetWrapper(context.Background(), o.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kmsCache)
+	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kmsCache)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kmsCache)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
+
+	p := oidc.NewTestProvider(t, "test-client", "test-secret")
+	am := oidc.TestAuthMethod(t, conn, databaseWrapper, o.GetPublicId(), p.Issuer(), "test-client", "test-secret")
+
+	s, err := authmethods.NewService(kmsCache, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
+	require.NoError(t, err)
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId()))
+
+	credentials := func(state, code string) *structpb.Struct {
+		creds := map[string]*structpb.Value{
+			"state": {Kind: &structpb.Value_StringValue{StringValue: state}},
+			"code":  {Kind: &structpb.Value_StringValue{StringValue: code}},
+		}
+		return &structpb.Struct{Fields: creds}
+	}
+
+	t.Run("start-requires-redirect-url", func(t *testing.T) {
+		_, err := s.StartAuthentication(ctx, &pbs.StartAuthenticationRequest{AuthMethodId: am.GetPublicId()})
+		assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Got %#v", err)
+	})
+	t.Run("start-requires-oidc-auth-method", func(t *testing.T) {
+		pwAm := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+		_, err := s.StartAuthentication(ctx, &pbs.StartAuthenticationRequest{
+			AuthMethodId: pwAm.GetPublicId(),
+			RedirectUrl:  "http://127.0.0.1:1234/callback",
+		})
+		assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Got %#v", err)
+	})
+	t.Run("missing-code", func(t *testing.T) {
+		_, err := s.Authenticate(ctx, &pbs.AuthenticateRequest{
+			AuthMethodId: am.GetPublicId(),
+			Credentials: &structpb.Struct{Fields: map[string]*structpb.Value{
+				"state": {Kind: &structpb.Value_StringValue{StringValue: "state"}},
+			}},
+		})
+		assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Got %#v", err)
+	})
+	t.Run("bad-state", func(t *testing.T) {
+		_, err := s.Authenticate(ctx, &pbs.AuthenticateRequest{
+			AuthMethodId: am.GetPublicId(),
+			Credentials:  credentials("bad", "bad"),
+		})
+		assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.Unauthenticated)), "Got %#v", err)
+	})
+	t.Run("basic", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		start, err := s.StartAuthentication(ctx, &pbs.StartAuthenticationRequest{
+			AuthMethodId: am.GetPublicId(),
+			RedirectUrl:  "http://127.0.0.1:1234/callback",
+		})
+		require.NoError(err)
+		state, code := p.Authorize(start.GetAuthUrl())
+
+		resp, err := s.Authenticate(ctx, &pbs.AuthenticateRequest{
+			AuthMethodId: am.GetPublicId(),
+			TokenType:    "token",
+			Credentials:  credentials(state, code),
+		})
+		require.NoError(err)
+		aToken := resp.GetItem()
+		assert.NotEmpty(aToken.GetToken())
+		assert.Equal(am.GetPublicId(), aToken.GetAuthMethodId())
+		assert.True(strings.HasPrefix(aToken.GetAccountId(), oidc.AccountPrefix))
+	})
+}
+
+func TestAuthenticate_Ldap(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iamRepo)
+	databaseWrapper, err := kmsCache.GetWrapper(context.Background(), o.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kmsCache)
+	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kmsCache)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kmsCache)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
+
+	d := ldap.NewTestDirectory(t)
+	d.AddEntry("uid=alice,ou=people,dc=example,dc=com", "alice-password", map[string][]string{
+		"uid":         {"alice"},
+		"displayName": {"Alice Doe"},
+	})
+	d.AddEntry("cn=engineering,ou=groups,dc=example,dc=com", "", map[string][]string{
+		"cn":     {"engineering"},
+		"member": {"uid=alice,ou=people,dc=example,dc=com"},
+	})
+	am := ldap.TestAuthMethod(t, conn, databaseWrapper, o.GetPublicId(), []string{d.Url()}, "ou=people,dc=example,dc=com",
+		ldap.WithGroupSearch("ou=groups,dc=example,dc=com", "", ""),
+		ldap.WithSyncGroups(true),
+	)
+	grp := iam.TestGroup(t, conn, o.GetPublicId(), iam.WithName("Engineering"))
+
+	s, err := authmethods.NewService(kmsCache, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
+	require.NoError(t, err)
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId()))
+
+	credentials := func(loginName, pw string) *structpb.Struct {
+		creds := map[string]*structpb.Value{
+			"login_name": {Kind: &structpb.Value_StringValue{StringValue: loginName}},
+			"password":   {Kind: &structpb.Value_StringValue{StringValue: pw}},
+		}
+		return &structpb.Struct{Fields: creds}
+	}
+
+	t.Run("wrong-password", func(t *testing.T) {
+		_, err := s.Authenticate(ctx, &pbs.AuthenticateRequest{
+			AuthMethodId: am.GetPublicId(),
+			Credentials:  credentials("alice", "wrong"),
+		})
+		assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.Unauthenticated)), "Got %#v", err)
+	})
+	t.Run("basic", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		resp, err := s.Authenticate(ctx, &pbs.AuthenticateRequest{
+			AuthMethodId: am.GetPublicId(),
+			TokenType:    "token",
+			Credentials:  credentials("alice", "alice-password"),
+		})
+		require.NoError(err)
+		aToken := resp.GetItem()
+		assert.NotEmpty(aToken.GetToken())
+		assert.Equal(am.GetPublicId(), aToken.GetAuthMethodId())
+		assert.True(strings.HasPrefix(aToken.GetAccountId(), ldap.AccountPrefix))
+
+		members, err := iamRepo.ListGroupMembers(context.Background(), grp.GetPublicId())
+		require.NoError(err)
+		require.Len(members, 1)
+		assert.Equal(aToken.GetUserId(), members[0].GetMemberId())
+
+		// Leaving the LDAP group removes the user from the group on the next
+		// authentication.
+		d.SetAttribute("cn=engineering,ou=groups,dc=example,dc=com", "member")
+		_, err = s.Authenticate(ctx, &pbs.AuthenticateRequest{
+			AuthMethodId: am.GetPublicId(),
+			TokenType:    "token",
+			Credentials:  credentials("alice", "alice-password"),
+		})
+		require.NoError(err)
+		members, err = iamRepo.ListGroupMembers(context.Background(), grp.GetPublicId())
+		require.NoError(err)
+		assert.Empty(members)
+	})
+}
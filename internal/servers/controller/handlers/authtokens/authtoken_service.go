@@ -17,6 +17,13 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 )
 
+// IdActions contains the set of actions that can be performed on
+// individual resources
+var IdActions = action.ActionSet{
+	action.Read,
+	action.Delete,
+}
+
 // Service handles request as described by the pbs.AuthTokenServiceServer interface.
 type Service struct {
 	repoFn    common.AuthTokenRepoFactory
@@ -41,18 +48,37 @@ func (s Service) ListAuthTokens(ctx context.Context, req *pbs.ListAuthTokensRequ
 	if err := validateListRequest(req); err != nil {
 		return nil, err
 	}
-	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
-		return nil, authResults.Error
+	scopeResults, err := s.listAuthResults(ctx, req.GetScopeId(), req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
 	}
-	ul, err := s.listFromRepo(ctx, req.GetScopeId())
+	items, nextPageToken, err := handlers.Paginate(req.GetPageSize(), req.GetPageToken(), filter, func(afterId string, limit int) ([]handlers.PageItem, error) {
+		var items []handlers.PageItem
+		for _, authResults := range scopeResults {
+			ul, err := s.listFromRepo(ctx, authResults.Scope.GetId(), afterId, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range ul {
+				item.Scope = authResults.Scope
+				item.AuthorizedActions = authResults.FetchActionSetForId(ctx, item.Id, IdActions).Strings()
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range ul {
-		item.Scope = authResults.Scope
+	var finalItems []*pb.AuthToken
+	for _, item := range items {
+		finalItems = append(finalItems, item.(*pb.AuthToken))
 	}
-	return &pbs.ListAuthTokensResponse{Items: ul}, nil
+	return &pbs.ListAuthTokensResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetAuthToken implements the interface pbs.AuthTokenServiceServer.
@@ -69,6 +95,7 @@ func (s Service) GetAuthToken(ctx context.Context, req *pbs.GetAuthTokenRequest)
 		return nil, err
 	}
 	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
 	return &pbs.GetAuthTokenResponse{Item: u}, nil
 }
 
@@ -121,13 +148,13 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, orgId string) ([]*pb.AuthToken, error) {
+func (s Service) listFromRepo(ctx context.Context, orgId, afterId string, limit int) ([]*pb.AuthToken, error) {
 	repo, err := s.repoFn()
 	_ = repo
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListAuthTokens(ctx, orgId)
+	ul, err := repo.ListAuthTokens(ctx, orgId, authtoken.WithLimit(limit), authtoken.WithStartPageAfterId(afterId))
 	if err != nil {
 		return nil, err
 	}
@@ -182,6 +209,20 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
+// listAuthResults authorizes listing in the provided scope or, when recursive,
+// separately in it and every scope beneath it. It returns the results for the
+// scopes in which listing is allowed.
+func (s Service) listAuthResults(ctx context.Context, scopeId string, recursive bool) ([]auth.VerifyResults, error) {
+	if recursive {
+		return auth.VerifyRecursive(ctx, s.iamRepoFn, auth.WithType(resource.AuthToken), auth.WithAction(action.List), auth.WithScopeId(scopeId))
+	}
+	authResults := s.authResult(ctx, scopeId, action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	return []auth.VerifyResults{authResults}, nil
+}
+
 func toProto(in *authtoken.AuthToken) *pb.AuthToken {
 	out := pb.AuthToken{
 		Id:                      in.GetPublicId(),
@@ -216,6 +257,12 @@ func validateListRequest(req *pbs.ListAuthTokensRequest) error {
 		req.GetScopeId() != scope.Global.String() {
 		badFields["scope_id"] = "This field must be 'global' or a valid org scope id."
 	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if _, err := handlers.DecodePageToken(req.GetPageToken()); err != nil {
+		badFields["page_token"] = "This is not a valid page token."
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
 	}
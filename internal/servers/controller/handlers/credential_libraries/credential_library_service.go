@@ -0,0 +1,448 @@
+package credential_libraries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/db"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/credentiallibraries"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var maskManager handlers.MaskManager
+
+func init() {
+	var err error
+	if maskManager, err = handlers.NewMaskManager(&store.CredentialLibrary{}, &pb.CredentialLibrary{}, &pb.StaticCredentialLibraryAttributes{}); err != nil {
+		panic(err)
+	}
+}
+
+// IdActions contains the set of actions that can be performed on
+// individual resources
+var IdActions = action.ActionSet{
+	action.Read,
+	action.Update,
+	action.Delete,
+}
+
+type Service struct {
+	staticRepoFn common.StaticCredentialRepoFactory
+}
+
+var _ pbs.CredentialLibraryServiceServer = Service{}
+
+// NewService returns a credential library Service which handles credential library related requests to boundary and uses the provided
+// repositories for storage and retrieval.
+func NewService(repoFn common.StaticCredentialRepoFactory) (Service, error) {
+	if repoFn == nil {
+		return Service{}, fmt.Errorf("nil static credential repository provided")
+	}
+	return Service{staticRepoFn: repoFn}, nil
+}
+
+// ListCredentialLibraries implements the interface pbs.CredentialLibraryServiceServer.
+func (s Service) ListCredentialLibraries(ctx context.Context, req *pbs.ListCredentialLibrariesRequest) (*pbs.ListCredentialLibrariesResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetCredentialStoreId(), action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	items, nextPageToken, err := handlers.Paginate(req.GetPageSize(), req.GetPageToken(), filter, func(afterId string, limit int) ([]handlers.PageItem, error) {
+		ll, err := s.listFromRepo(ctx, req.GetCredentialStoreId(), afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		var items []handlers.PageItem
+		for _, item := range ll {
+			item.Scope = authResults.Scope
+			item.AuthorizedActions = authResults.FetchActionSetForId(ctx, item.Id, IdActions, auth.WithPin(item.GetCredentialStoreId())).Strings()
+			items = append(items, item)
+		}
+		return items, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var finalItems []*pb.CredentialLibrary
+	for _, item := range items {
+		finalItems = append(finalItems, item.(*pb.CredentialLibrary))
+	}
+	return &pbs.ListCredentialLibrariesResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
+}
+
+// GetCredentialLibrary implements the interface pbs.CredentialLibraryServiceServer.
+func (s Service) GetCredentialLibrary(ctx context.Context, req *pbs.GetCredentialLibraryRequest) (*pbs.GetCredentialLibraryResponse, error) {
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	l, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	l.Scope = authResults.Scope
+	l.AuthorizedActions = authResults.FetchActionSetForId(ctx, l.Id, IdActions, auth.WithPin(l.GetCredentialStoreId())).Strings()
+	return &pbs.GetCredentialLibraryResponse{Item: l}, nil
+}
+
+// CreateCredentialLibrary implements the interface pbs.CredentialLibraryServiceServer.
+func (s Service) CreateCredentialLibrary(ctx context.Context, req *pbs.CreateCredentialLibraryRequest) (*pbs.CreateCredentialLibraryResponse, error) {
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	cs, authResults := s.parentAndAuthResult(ctx, req.GetItem().GetCredentialStoreId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	l, err := s.createInRepo(ctx, authResults.Scope.GetId(), cs.GetPublicId(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+	l.Scope = authResults.Scope
+	return &pbs.CreateCredentialLibraryResponse{
+		Item: l,
+		Uri:  fmt.Sprintf("credential-libraries/%s", l.GetId()),
+	}, nil
+}
+
+// UpdateCredentialLibrary implements the interface pbs.CredentialLibraryServiceServer.
+func (s Service) UpdateCredentialLibrary(ctx context.Context, req *pbs.UpdateCredentialLibraryRequest) (*pbs.UpdateCredentialLibraryResponse, error) {
+	if err := validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	cs, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	l, err := s.updateInRepo(ctx, authResults.Scope.GetId(), cs.GetPublicId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+	l.Scope = authResults.Scope
+	return &pbs.UpdateCredentialLibraryResponse{Item: l}, nil
+}
+
+// DeleteCredentialLibrary implements the interface pbs.CredentialLibraryServiceServer.
+func (s Service) DeleteCredentialLibrary(ctx context.Context, req *pbs.DeleteCredentialLibraryRequest) (*pbs.DeleteCredentialLibraryResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, authResults.Scope.GetId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.DeleteCredentialLibraryResponse{}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*pb.CredentialLibrary, error) {
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	l, err := repo.LookupLibrary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if l == nil {
+		return nil, handlers.NotFoundErrorf("Credential Library %q doesn't exist.", id)
+	}
+	return toProto(l)
+}
+
+func (s Service) listFromRepo(ctx context.Context, storeId, afterId string, limit int) ([]*pb.CredentialLibrary, error) {
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	ll, err := repo.ListLibraries(ctx, storeId, static.WithLimit(limit), static.WithStartPageAfterId(afterId))
+	if err != nil {
+		return nil, err
+	}
+	var outLl []*pb.CredentialLibrary
+	for _, l := range ll {
+		ol, err := toProto(l)
+		if err != nil {
+			return nil, err
+		}
+		outLl = append(outLl, ol)
+	}
+	return outLl, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, scopeId, storeId string, item *pb.CredentialLibrary) (*pb.CredentialLibrary, error) {
+	attrs := &pb.StaticCredentialLibraryAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	var opts []static.Option
+	if item.GetName() != nil {
+		opts = append(opts, static.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, static.WithDescription(item.GetDescription().GetValue()))
+	}
+	if attrs.GetPassword() != nil {
+		opts = append(opts, static.WithPassword(attrs.GetPassword().GetValue()))
+	}
+	if attrs.GetPrivateKey() != nil {
+		opts = append(opts, static.WithPrivateKey(attrs.GetPrivateKey().GetValue()))
+	}
+	l, err := static.NewCredentialLibrary(storeId, attrs.GetUsername().GetValue(), opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build credential library for creation: %v.", err)
+	}
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateLibrary(ctx, scopeId, l)
+	if err != nil {
+		if db.IsUniqueError(err) || errors.Is(err, db.ErrNotUnique) {
+			// Push this error through so the error interceptor can interpret it correctly.
+			return nil, err
+		}
+		if badFields := secretErrorFields(err); badFields != nil {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+		}
+		return nil, fmt.Errorf("unable to create credential library: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create credential library but no error returned from repository.")
+	}
+	return toProto(out)
+}
+
+func (s Service) updateInRepo(ctx context.Context, scopeId, storeId, id string, mask []string, item *pb.CredentialLibrary) (*pb.CredentialLibrary, error) {
+	attrs := &pb.StaticCredentialLibraryAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	var opts []static.Option
+	if desc := item.GetDescription(); desc != nil {
+		opts = append(opts, static.WithDescription(desc.GetValue()))
+	}
+	if name := item.GetName(); name != nil {
+		opts = append(opts, static.WithName(name.GetValue()))
+	}
+	if pw := attrs.GetPassword(); pw != nil {
+		opts = append(opts, static.WithPassword(pw.GetValue()))
+	}
+	if pk := attrs.GetPrivateKey(); pk != nil {
+		opts = append(opts, static.WithPrivateKey(pk.GetValue()))
+	}
+	l, err := static.NewCredentialLibrary(storeId, attrs.GetUsername().GetValue(), opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build credential library for update: %v.", err)
+	}
+	l.PublicId = id
+	dbMask := maskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, rowsUpdated, err := repo.UpdateLibrary(ctx, scopeId, l, item.GetVersion(), dbMask)
+	if err != nil {
+		if badFields := secretErrorFields(err); badFields != nil {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+		}
+		return nil, fmt.Errorf("unable to update credential library: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Credential Library %q doesn't exist or incorrect version provided.", id)
+	}
+	return toProto(out)
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool, error) {
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteLibrary(ctx, scopeId, id)
+	if err != nil {
+		return false, fmt.Errorf("unable to delete credential library: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (*static.CredentialStore, auth.VerifyResults) {
+	res := auth.VerifyResults{}
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		res.Error = err
+		return nil, res
+	}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.CredentialLibrary), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+	default:
+		l, err := repo.LookupLibrary(ctx, id)
+		if err != nil {
+			res.Error = err
+			return nil, res
+		}
+		if l == nil {
+			res.Error = handlers.NotFoundError()
+			return nil, res
+		}
+		parentId = l.GetStoreId()
+		opts = append(opts, auth.WithId(id))
+	}
+
+	cs, err := repo.LookupStore(ctx, parentId)
+	if err != nil {
+		res.Error = err
+		return nil, res
+	}
+	if cs == nil {
+		res.Error = handlers.NotFoundError()
+		return nil, res
+	}
+	opts = append(opts, auth.WithScopeId(cs.GetScopeId()), auth.WithPin(parentId))
+	return cs, auth.Verify(ctx, opts...)
+}
+
+// secretErrorFields returns the request fields to report for an error from
+// the repository about the secrets of a library, or nil if err is not such
+// an error.
+func secretErrorFields(err error) map[string]string {
+	switch {
+	case errors.Is(err, static.ErrNoSecret):
+		return map[string]string{"attributes": "A password or a private key is required."}
+	case errors.Is(err, static.ErrInvalidPrivateKey):
+		return map[string]string{"attributes.private_key": "Must be a PEM encoded private key."}
+	}
+	return nil
+}
+
+// toProto returns the API representation of the library. The password and
+// private key are never returned.
+func toProto(in *static.CredentialLibrary) (*pb.CredentialLibrary, error) {
+	out := pb.CredentialLibrary{
+		Id:                in.GetPublicId(),
+		CredentialStoreId: in.GetStoreId(),
+		Type:              credential.StaticSubtype.String(),
+		CreatedTime:       in.GetCreateTime().GetTimestamp(),
+		UpdatedTime:       in.GetUpdateTime().GetTimestamp(),
+		Version:           in.GetVersion(),
+	}
+	if in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	st, err := handlers.ProtoToStruct(&pb.StaticCredentialLibraryAttributes{Username: wrapperspb.String(in.GetUsername())})
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building static attribute struct: %v", err)
+	}
+	out.Attributes = st
+	return &out, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//  * The path passed in is correctly formatted
+//  * All required parameters are set
+//  * There are no conflicting parameters provided
+//  * The type asserted by the ID and/or field is known
+//  * If relevant, the type derived from the id prefix matches what is claimed by the type field
+func validateGetRequest(req *pbs.GetCredentialLibraryRequest) error {
+	return handlers.ValidateGetRequest(static.CredentialLibraryPrefix, req, handlers.NoopValidatorFn)
+}
+
+func validateCreateRequest(req *pbs.CreateCredentialLibraryRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if !handlers.ValidId(static.CredentialStorePrefix, req.GetItem().GetCredentialStoreId()) {
+			badFields["credential_store_id"] = "The field is incorrectly formatted."
+		}
+		if req.GetItem().GetType() != "" && credential.SubtypeFromType(req.GetItem().GetType()) != credential.StaticSubtype {
+			badFields["type"] = "Doesn't match the parent resource's type."
+		}
+		attrs := &pb.StaticCredentialLibraryAttributes{}
+		if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+			badFields["attributes"] = "Attribute fields do not match the expected format."
+			return badFields
+		}
+		if attrs.GetUsername().GetValue() == "" {
+			badFields["attributes.username"] = "This is a required field."
+		}
+		if attrs.GetPassword().GetValue() == "" && attrs.GetPrivateKey().GetValue() == "" {
+			badFields["attributes"] = "A password or a private key is required."
+		}
+		return badFields
+	})
+}
+
+func validateUpdateRequest(req *pbs.UpdateCredentialLibraryRequest) error {
+	return handlers.ValidateUpdateRequest(static.CredentialLibraryPrefix, req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if req.GetItem().GetType() != "" && credential.SubtypeFromType(req.GetItem().GetType()) != credential.StaticSubtype {
+			badFields["type"] = "Cannot modify the resource type."
+		}
+		attrs := &pb.StaticCredentialLibraryAttributes{}
+		if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+			badFields["attributes"] = "Attribute fields do not match the expected format."
+			return badFields
+		}
+		if handlers.MaskContains(req.GetUpdateMask().GetPaths(), "attributes.username") && attrs.GetUsername().GetValue() == "" {
+			badFields["attributes.username"] = "This field cannot be cleared."
+		}
+		return badFields
+	})
+}
+
+func validateDeleteRequest(req *pbs.DeleteCredentialLibraryRequest) error {
+	return handlers.ValidateDeleteRequest(static.CredentialLibraryPrefix, req, handlers.NoopValidatorFn)
+}
+
+func validateListRequest(req *pbs.ListCredentialLibrariesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(static.CredentialStorePrefix, req.GetCredentialStoreId()) {
+		badFields["credential_store_id"] = "The field is incorrectly formatted."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if _, err := handlers.DecodePageToken(req.GetPageToken()); err != nil {
+		badFields["page_token"] = "This is not a valid page token."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
@@ -0,0 +1,446 @@
+package credential_libraries_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/db"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/credentiallibraries"
+	scopepb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/credential_libraries"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPrivateKey(t *testing.T) string {
+	t.Helper()
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}))
+}
+
+func createDefaultCredentialLibraryAndRepo(t *testing.T) (*static.CredentialLibrary, *iam.Scope, common.StaticCredentialRepoFactory) {
+	t.Helper()
+	require := require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+
+	_, pRes := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kmsCache)
+	}
+
+	cs := static.TestCredentialStores(t, conn, pRes.GetPublicId(), 1)[0]
+	l, err := static.NewCredentialLibrary(cs.GetPublicId(), "user", static.WithName("default"), static.WithDescription("default"), static.WithPassword("secret"))
+	require.NoError(err, "Couldn't get new credential library.")
+	repo, err := repoFn()
+	require.NoError(err, "Couldn't create static credential repository")
+	lRes, err := repo.CreateLibrary(context.Background(), pRes.GetPublicId(), l)
+	require.NoError(err, "Couldn't persist new credential library.")
+
+	return lRes, pRes, repoFn
+}
+
+func usernameAttributes(t *testing.T, username string) *structpb.Struct {
+	t.Helper()
+	st, err := handlers.ProtoToStruct(&pb.StaticCredentialLibraryAttributes{Username: wrapperspb.String(username)})
+	require.NoError(t, err)
+	return st
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+	l, proj, repoFn := createDefaultCredentialLibraryAndRepo(t)
+
+	want := &pb.CredentialLibrary{
+		Id:                l.GetPublicId(),
+		CredentialStoreId: l.GetStoreId(),
+		Scope:             &scopepb.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+		Name:              &wrappers.StringValue{Value: l.GetName()},
+		Description:       &wrappers.StringValue{Value: l.GetDescription()},
+		CreatedTime:       l.CreateTime.GetTimestamp(),
+		UpdatedTime:       l.UpdateTime.GetTimestamp(),
+		Version:           1,
+		Type:              "static",
+		Attributes:        usernameAttributes(t, "user"),
+		AuthorizedActions: credential_libraries.IdActions.Strings(),
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.GetCredentialLibraryRequest
+		res  *pbs.GetCredentialLibraryResponse
+		err  error
+	}{
+		{
+			name: "Get an Existing Credential Library",
+			req:  &pbs.GetCredentialLibraryRequest{Id: l.GetPublicId()},
+			res:  &pbs.GetCredentialLibraryResponse{Item: want},
+		},
+		{
+			name: "Get a non existing Credential Library",
+			req:  &pbs.GetCredentialLibraryRequest{Id: static.CredentialLibraryPrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.GetCredentialLibraryRequest{Id: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := credential_libraries.NewService(repoFn)
+			require.NoError(err, "Couldn't create a new credential library service.")
+
+			got, gErr := s.GetCredentialLibrary(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetCredentialLibrary(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+			}
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "GetCredentialLibrary(%q) got response %q, wanted %q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kmsCache)
+	}
+	credentialsWrapper, err := kmsCache.GetWrapper(context.Background(), proj.GetPublicId(), kms.KeyPurposeCredentials)
+	require.NoError(t, err)
+
+	stores := static.TestCredentialStores(t, conn, proj.GetPublicId(), 2)
+	emptyStore, store := stores[0], stores[1]
+	var wantLibraries []*pb.CredentialLibrary
+	for _, l := range static.TestCredentialLibraries(t, conn, credentialsWrapper, store.GetPublicId(), 3) {
+		wantLibraries = append(wantLibraries, &pb.CredentialLibrary{
+			Id:                l.GetPublicId(),
+			CredentialStoreId: l.GetStoreId(),
+			Scope:             &scopepb.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+			CreatedTime:       l.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       l.GetUpdateTime().GetTimestamp(),
+			Version:           l.GetVersion(),
+			Type:              "static",
+			Attributes:        usernameAttributes(t, l.GetUsername()),
+			AuthorizedActions: credential_libraries.IdActions.Strings(),
+		})
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.ListCredentialLibrariesRequest
+		res  *pbs.ListCredentialLibrariesResponse
+		err  error
+	}{
+		{
+			name: "List Many Credential Libraries",
+			req:  &pbs.ListCredentialLibrariesRequest{CredentialStoreId: store.GetPublicId()},
+			res:  &pbs.ListCredentialLibrariesResponse{Items: wantLibraries},
+		},
+		{
+			name: "List No Credential Libraries",
+			req:  &pbs.ListCredentialLibrariesRequest{CredentialStoreId: emptyStore.GetPublicId()},
+			res:  &pbs.ListCredentialLibrariesResponse{},
+		},
+		{
+			name: "Filter To One Credential Library",
+			req:  &pbs.ListCredentialLibrariesRequest{CredentialStoreId: store.GetPublicId(), Filter: fmt.Sprintf(`"/item/id"==%q`, wantLibraries[1].GetId())},
+			res:  &pbs.ListCredentialLibrariesResponse{Items: wantLibraries[1:2]},
+		},
+		{
+			name: "Nonexistent Credential Store",
+			req:  &pbs.ListCredentialLibrariesRequest{CredentialStoreId: static.CredentialStorePrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad Credential Store Id",
+			req:  &pbs.ListCredentialLibrariesRequest{CredentialStoreId: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := credential_libraries.NewService(repoFn)
+			require.NoError(err, "Couldn't create new credential library service.")
+
+			got, gErr := s.ListCredentialLibraries(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "ListCredentialLibraries(%q) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(got.GetItems(), tc.res.GetItems(), protocmp.Transform()), "ListCredentialLibraries(%q) got response %q, wanted %q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+	defaultLibrary, proj, repoFn := createDefaultCredentialLibraryAndRepo(t)
+	storeId := defaultLibrary.GetStoreId()
+	privateKey := testPrivateKey(t)
+
+	attributes := func(username, password, privateKey string) *structpb.Struct {
+		attrs := &pb.StaticCredentialLibraryAttributes{}
+		if username != "" {
+			attrs.Username = wrapperspb.String(username)
+		}
+		if password != "" {
+			attrs.Password = wrapperspb.String(password)
+		}
+		if privateKey != "" {
+			attrs.PrivateKey = wrapperspb.String(privateKey)
+		}
+		st, err := handlers.ProtoToStruct(attrs)
+		require.NoError(t, err)
+		return st
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.CreateCredentialLibraryRequest
+		want *pb.CredentialLibrary
+		err  error
+	}{
+		{
+			name: "Create with a password",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: storeId,
+				Name:              &wrappers.StringValue{Value: "password"},
+				Attributes:        attributes("alice", "secret", ""),
+			}},
+			want: &pb.CredentialLibrary{
+				CredentialStoreId: storeId,
+				Scope:             &scopepb.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+				Name:              &wrappers.StringValue{Value: "password"},
+				Version:           1,
+				Type:              "static",
+				Attributes:        usernameAttributes(t, "alice"),
+			},
+		},
+		{
+			name: "Create with a private key",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: storeId,
+				Name:              &wrappers.StringValue{Value: "private-key"},
+				Type:              "static",
+				Attributes:        attributes("bob", "", privateKey),
+			}},
+			want: &pb.CredentialLibrary{
+				CredentialStoreId: storeId,
+				Scope:             &scopepb.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+				Name:              &wrappers.StringValue{Value: "private-key"},
+				Version:           1,
+				Type:              "static",
+				Attributes:        usernameAttributes(t, "bob"),
+			},
+		},
+		{
+			name: "No secret",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: storeId,
+				Attributes:        attributes("carol", "", ""),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "No username",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: storeId,
+				Attributes:        attributes("", "secret", ""),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Invalid private key",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: storeId,
+				Attributes:        attributes("dave", "", "not a key"),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Wrong type",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: storeId,
+				Type:              "vault",
+				Attributes:        attributes("erin", "secret", ""),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := credential_libraries.NewService(repoFn)
+			require.NoError(err, "Error when getting new credential library service.")
+
+			got, gErr := s.CreateCredentialLibrary(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateCredentialLibrary(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(fmt.Sprintf("credential-libraries/%s", got.GetItem().GetId()), got.GetUri())
+			assert.True(len(got.GetItem().GetId()) > len(static.CredentialLibraryPrefix))
+			// Clear all values which are hard to compare against.
+			got.Item.Id, got.Item.CreatedTime, got.Item.UpdatedTime = "", nil, nil
+			assert.Empty(cmp.Diff(got.GetItem(), tc.want, protocmp.Transform()), "CreateCredentialLibrary(%q) got response %q, wanted %q", tc.req, got, tc.want)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+	l, proj, repoFn := createDefaultCredentialLibraryAndRepo(t)
+	s, err := credential_libraries.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new credential library service.")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+
+	privateKeyAttrs, err := handlers.ProtoToStruct(&pb.StaticCredentialLibraryAttributes{PrivateKey: wrapperspb.String(testPrivateKey(t))})
+	require.NoError(t, err)
+	emptyUsernameAttrs, err := handlers.ProtoToStruct(&pb.StaticCredentialLibraryAttributes{Username: wrapperspb.String("")})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name         string
+		req          *pbs.UpdateCredentialLibraryRequest
+		wantName     string
+		wantUsername string
+		wantErr      error
+	}{
+		{
+			name: "Update name and username",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"name", "attributes.username"}},
+				Item: &pb.CredentialLibrary{
+					Name:       &wrappers.StringValue{Value: "new"},
+					Attributes: usernameAttributes(t, "newuser"),
+				},
+			},
+			wantName:     "new",
+			wantUsername: "newuser",
+		},
+		{
+			name: "Update private key",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"attributes.private_key"}},
+				Item:       &pb.CredentialLibrary{Attributes: privateKeyAttrs},
+			},
+			wantName:     "new",
+			wantUsername: "newuser",
+		},
+		{
+			name: "Clear username",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"attributes.username"}},
+				Item:       &pb.CredentialLibrary{Attributes: emptyUsernameAttrs},
+			},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "No update mask",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				Item: &pb.CredentialLibrary{Name: &wrappers.StringValue{Value: "updated"}},
+			},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	version := l.GetVersion()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			tc.req.Id = l.GetPublicId()
+			tc.req.Item.Version = version
+
+			got, gErr := s.UpdateCredentialLibrary(ctx, tc.req)
+			if tc.wantErr != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.wantErr), "UpdateCredentialLibrary(%+v) got error %v, wanted %v", tc.req, gErr, tc.wantErr)
+				return
+			}
+			require.NoError(gErr)
+			version = got.GetItem().GetVersion()
+			assert.Equal(tc.wantName, got.GetItem().GetName().GetValue())
+			assert.Empty(cmp.Diff(got.GetItem().GetAttributes(), usernameAttributes(t, tc.wantUsername), protocmp.Transform()))
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+	l, proj, repoFn := createDefaultCredentialLibraryAndRepo(t)
+	s, err := credential_libraries.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new credential library service.")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+
+	cases := []struct {
+		name string
+		req  *pbs.DeleteCredentialLibraryRequest
+		err  error
+	}{
+		{
+			name: "Delete an Existing Credential Library",
+			req:  &pbs.DeleteCredentialLibraryRequest{Id: l.GetPublicId()},
+		},
+		{
+			name: "Delete a deleted Credential Library",
+			req:  &pbs.DeleteCredentialLibraryRequest{Id: l.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad Credential Library Id formatting",
+			req:  &pbs.DeleteCredentialLibraryRequest{Id: static.CredentialLibraryPrefix + "_bad_format"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.DeleteCredentialLibrary(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "DeleteCredentialLibrary(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.NotNil(got)
+		})
+	}
+}
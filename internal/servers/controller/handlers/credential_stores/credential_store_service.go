@@ -0,0 +1,412 @@
+package credential_stores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/db"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/credentialstores"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var maskManager handlers.MaskManager
+
+func init() {
+	var err error
+	if maskManager, err = handlers.NewMaskManager(&store.CredentialStore{}, &pb.CredentialStore{}); err != nil {
+		panic(err)
+	}
+}
+
+// IdActions contains the set of actions that can be performed on
+// individual resources
+var IdActions = action.ActionSet{
+	action.Read,
+	action.Update,
+	action.Delete,
+}
+
+type Service struct {
+	staticRepoFn common.StaticCredentialRepoFactory
+	iamRepoFn    common.IamRepoFactory
+}
+
+var _ pbs.CredentialStoreServiceServer = Service{}
+
+// NewService returns a credential store Service which handles credential store related requests to boundary and uses the provided
+// repositories for storage and retrieval.
+func NewService(repoFn common.StaticCredentialRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+	if repoFn == nil {
+		return Service{}, fmt.Errorf("nil static credential repository provided")
+	}
+	if iamRepoFn == nil {
+		return Service{}, fmt.Errorf("nil iam repository provided")
+	}
+	return Service{staticRepoFn: repoFn, iamRepoFn: iamRepoFn}, nil
+}
+
+// ListCredentialStores implements the interface pbs.CredentialStoreServiceServer.
+func (s Service) ListCredentialStores(ctx context.Context, req *pbs.ListCredentialStoresRequest) (*pbs.ListCredentialStoresResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	scopeResults, err := s.listAuthResults(ctx, req.GetScopeId(), req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	items, nextPageToken, err := handlers.Paginate(req.GetPageSize(), req.GetPageToken(), filter, func(afterId string, limit int) ([]handlers.PageItem, error) {
+		var items []handlers.PageItem
+		for _, authResults := range scopeResults {
+			sl, err := s.listFromRepo(ctx, authResults.Scope.GetId(), afterId, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range sl {
+				item.Scope = authResults.Scope
+				item.AuthorizedActions = authResults.FetchActionSetForId(ctx, item.Id, IdActions).Strings()
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var finalItems []*pb.CredentialStore
+	for _, item := range items {
+		finalItems = append(finalItems, item.(*pb.CredentialStore))
+	}
+	return &pbs.ListCredentialStoresResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
+}
+
+// GetCredentialStore implements the interface pbs.CredentialStoreServiceServer.
+func (s Service) GetCredentialStore(ctx context.Context, req *pbs.GetCredentialStoreRequest) (*pbs.GetCredentialStoreResponse, error) {
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	cs, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	cs.Scope = authResults.Scope
+	cs.AuthorizedActions = authResults.FetchActionSetForId(ctx, cs.Id, IdActions).Strings()
+	return &pbs.GetCredentialStoreResponse{Item: cs}, nil
+}
+
+// CreateCredentialStore implements the interface pbs.CredentialStoreServiceServer.
+func (s Service) CreateCredentialStore(ctx context.Context, req *pbs.CreateCredentialStoreRequest) (*pbs.CreateCredentialStoreResponse, error) {
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	cs, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+	cs.Scope = authResults.Scope
+	return &pbs.CreateCredentialStoreResponse{
+		Item: cs,
+		Uri:  fmt.Sprintf("credential-stores/%s", cs.GetId()),
+	}, nil
+}
+
+// UpdateCredentialStore implements the interface pbs.CredentialStoreServiceServer.
+func (s Service) UpdateCredentialStore(ctx context.Context, req *pbs.UpdateCredentialStoreRequest) (*pbs.UpdateCredentialStoreResponse, error) {
+	if err := validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	cs, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+	cs.Scope = authResults.Scope
+	return &pbs.UpdateCredentialStoreResponse{Item: cs}, nil
+}
+
+// DeleteCredentialStore implements the interface pbs.CredentialStoreServiceServer.
+func (s Service) DeleteCredentialStore(ctx context.Context, req *pbs.DeleteCredentialStoreRequest) (*pbs.DeleteCredentialStoreResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.DeleteCredentialStoreResponse{}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*pb.CredentialStore, error) {
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	cs, err := repo.LookupStore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cs == nil {
+		return nil, handlers.NotFoundErrorf("Credential Store %q doesn't exist.", id)
+	}
+	return toProto(cs), nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, scopeId, afterId string, limit int) ([]*pb.CredentialStore, error) {
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	sl, err := repo.ListStores(ctx, scopeId, static.WithLimit(limit), static.WithStartPageAfterId(afterId))
+	if err != nil {
+		return nil, err
+	}
+	var outSl []*pb.CredentialStore
+	for _, cs := range sl {
+		outSl = append(outSl, toProto(cs))
+	}
+	return outSl, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, projId string, item *pb.CredentialStore) (*pb.CredentialStore, error) {
+	var opts []static.Option
+	if item.GetName() != nil {
+		opts = append(opts, static.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, static.WithDescription(item.GetDescription().GetValue()))
+	}
+	cs, err := static.NewCredentialStore(projId, opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build credential store for creation: %v.", err)
+	}
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateStore(ctx, cs)
+	if err != nil {
+		if db.IsUniqueError(err) || errors.Is(err, db.ErrNotUnique) {
+			// Push this error through so the error interceptor can interpret it correctly.
+			return nil, err
+		}
+		return nil, fmt.Errorf("unable to create credential store: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create credential store but no error returned from repository.")
+	}
+	return toProto(out), nil
+}
+
+func (s Service) updateInRepo(ctx context.Context, projId, id string, mask []string, item *pb.CredentialStore) (*pb.CredentialStore, error) {
+	var opts []static.Option
+	if desc := item.GetDescription(); desc != nil {
+		opts = append(opts, static.WithDescription(desc.GetValue()))
+	}
+	if name := item.GetName(); name != nil {
+		opts = append(opts, static.WithName(name.GetValue()))
+	}
+	cs, err := static.NewCredentialStore(projId, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build credential store for update: %w", err)
+	}
+	cs.PublicId = id
+	dbMask := maskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, rowsUpdated, err := repo.UpdateStore(ctx, cs, item.GetVersion(), dbMask)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update credential store: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Credential Store %q doesn't exist or incorrect version provided.", id)
+	}
+	return toProto(out), nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteStore(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("unable to delete credential store: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.CredentialStore), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+		iamRepo, err := s.iamRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		scp, err := iamRepo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	default:
+		repo, err := s.staticRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		cs, err := repo.LookupStore(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if cs == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = cs.GetScopeId()
+		opts = append(opts, auth.WithId(id))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+// listAuthResults authorizes listing in the provided scope or, when recursive,
+// separately in it and every scope beneath it. It returns the results for the
+// scopes in which listing is allowed.
+func (s Service) listAuthResults(ctx context.Context, scopeId string, recursive bool) ([]auth.VerifyResults, error) {
+	if recursive {
+		return auth.VerifyRecursive(ctx, s.iamRepoFn, auth.WithType(resource.CredentialStore), auth.WithAction(action.List), auth.WithScopeId(scopeId))
+	}
+	authResults := s.authResult(ctx, scopeId, action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	return []auth.VerifyResults{authResults}, nil
+}
+
+func toProto(in *static.CredentialStore) *pb.CredentialStore {
+	out := pb.CredentialStore{
+		Id:          in.GetPublicId(),
+		ScopeId:     in.GetScopeId(),
+		CreatedTime: in.GetCreateTime().GetTimestamp(),
+		UpdatedTime: in.GetUpdateTime().GetTimestamp(),
+		Version:     in.GetVersion(),
+		Type:        credential.StaticSubtype.String(),
+	}
+	if in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	return &out
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//  * The path passed in is correctly formatted
+//  * All required parameters are set
+//  * There are no conflicting parameters provided
+//  * The type asserted by the ID and/or field is known
+//  * If relevant, the type derived from the id prefix matches what is claimed by the type field
+func validateGetRequest(req *pbs.GetCredentialStoreRequest) error {
+	return handlers.ValidateGetRequest(static.CredentialStorePrefix, req, handlers.NoopValidatorFn)
+}
+
+func validateCreateRequest(req *pbs.CreateCredentialStoreRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if !handlers.ValidId(scope.Project.Prefix(), req.GetItem().GetScopeId()) {
+			badFields["scope_id"] = "This field must be a valid project scope id."
+		}
+		if credential.SubtypeFromType(req.GetItem().GetType()) != credential.StaticSubtype {
+			badFields["type"] = fmt.Sprintf("This is a required field and must be %q.", credential.StaticSubtype.String())
+		}
+		if len(req.GetItem().GetAttributes().GetFields()) > 0 {
+			badFields["attributes"] = "Static credential stores have no attributes."
+		}
+		return badFields
+	})
+}
+
+func validateUpdateRequest(req *pbs.UpdateCredentialStoreRequest) error {
+	return handlers.ValidateUpdateRequest(static.CredentialStorePrefix, req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if req.GetItem().GetType() != "" && credential.SubtypeFromType(req.GetItem().GetType()) != credential.StaticSubtype {
+			badFields["type"] = "Cannot modify resource type."
+		}
+		return badFields
+	})
+}
+
+func validateDeleteRequest(req *pbs.DeleteCredentialStoreRequest) error {
+	return handlers.ValidateDeleteRequest(static.CredentialStorePrefix, req, handlers.NoopValidatorFn)
+}
+
+func validateListRequest(req *pbs.ListCredentialStoresRequest) error {
+	badFields := map[string]string{}
+	switch {
+	case req.GetRecursive():
+		if req.GetScopeId() != scope.Global.String() &&
+			!handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()) &&
+			!handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) {
+			badFields["scope_id"] = "This field must be 'global' or a valid org or project scope id when listing recursively."
+		}
+	case !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()):
+		badFields["scope_id"] = "This field must be a valid project scope id."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if _, err := handlers.DecodePageToken(req.GetPageToken()); err != nil {
+		badFields["page_token"] = "This is not a valid page token."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
@@ -0,0 +1,389 @@
+package credential_stores_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/db"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/credentialstores"
+	scopepb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/credential_stores"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createDefaultCredentialStoreAndRepo(t *testing.T) (*static.CredentialStore, *iam.Scope, common.StaticCredentialRepoFactory, common.IamRepoFactory) {
+	t.Helper()
+	require := require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	_, pRes := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+
+	cs, err := static.NewCredentialStore(pRes.GetPublicId(), static.WithName("default"), static.WithDescription("default"))
+	require.NoError(err, "Couldn't get new credential store.")
+	repo, err := repoFn()
+	require.NoError(err, "Couldn't create static credential repository")
+	csRes, err := repo.CreateStore(context.Background(), cs)
+	require.NoError(err, "Couldn't persist new credential store.")
+
+	return csRes, pRes, repoFn, iamRepoFn
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+	cs, proj, repoFn, iamRepoFn := createDefaultCredentialStoreAndRepo(t)
+
+	want := &pb.CredentialStore{
+		Id:                cs.GetPublicId(),
+		ScopeId:           cs.GetScopeId(),
+		Scope:             &scopepb.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+		Name:              &wrappers.StringValue{Value: cs.GetName()},
+		Description:       &wrappers.StringValue{Value: cs.GetDescription()},
+		CreatedTime:       cs.CreateTime.GetTimestamp(),
+		UpdatedTime:       cs.UpdateTime.GetTimestamp(),
+		Version:           1,
+		Type:              "static",
+		AuthorizedActions: credential_stores.IdActions.Strings(),
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.GetCredentialStoreRequest
+		res  *pbs.GetCredentialStoreResponse
+		err  error
+	}{
+		{
+			name: "Get an Existing Credential Store",
+			req:  &pbs.GetCredentialStoreRequest{Id: cs.GetPublicId()},
+			res:  &pbs.GetCredentialStoreResponse{Item: want},
+		},
+		{
+			name: "Get a non existing Credential Store",
+			req:  &pbs.GetCredentialStoreRequest{Id: static.CredentialStorePrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.GetCredentialStoreRequest{Id: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := credential_stores.NewService(repoFn, iamRepoFn)
+			require.NoError(err, "Couldn't create a new credential store service.")
+
+			got, gErr := s.GetCredentialStore(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetCredentialStore(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+			}
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "GetCredentialStore(%q) got response %q, wanted %q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, pNoStores := iam.TestScopes(t, iamRepo)
+	_, pWithStores := iam.TestScopes(t, iamRepo)
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kmsCache)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+
+	var wantStores []*pb.CredentialStore
+	for _, cs := range static.TestCredentialStores(t, conn, pWithStores.GetPublicId(), 3) {
+		wantStores = append(wantStores, &pb.CredentialStore{
+			Id:                cs.GetPublicId(),
+			ScopeId:           cs.GetScopeId(),
+			Scope:             &scopepb.ScopeInfo{Id: pWithStores.GetPublicId(), Type: scope.Project.String()},
+			CreatedTime:       cs.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       cs.GetUpdateTime().GetTimestamp(),
+			Version:           cs.GetVersion(),
+			Type:              "static",
+			AuthorizedActions: credential_stores.IdActions.Strings(),
+		})
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.ListCredentialStoresRequest
+		res  *pbs.ListCredentialStoresResponse
+		err  error
+	}{
+		{
+			name: "List Many Credential Stores",
+			req:  &pbs.ListCredentialStoresRequest{ScopeId: pWithStores.GetPublicId()},
+			res:  &pbs.ListCredentialStoresResponse{Items: wantStores},
+		},
+		{
+			name: "List No Credential Stores",
+			req:  &pbs.ListCredentialStoresRequest{ScopeId: pNoStores.GetPublicId()},
+			res:  &pbs.ListCredentialStoresResponse{},
+		},
+		{
+			name: "Filter To One Credential Store",
+			req:  &pbs.ListCredentialStoresRequest{ScopeId: pWithStores.GetPublicId(), Filter: fmt.Sprintf(`"/item/id"==%q`, wantStores[1].GetId())},
+			res:  &pbs.ListCredentialStoresResponse{Items: wantStores[1:2]},
+		},
+		{
+			name: "Paginate Credential Stores",
+			req:  &pbs.ListCredentialStoresRequest{ScopeId: pWithStores.GetPublicId(), PageSize: 2},
+			res:  &pbs.ListCredentialStoresResponse{Items: wantStores[:2]},
+		},
+		{
+			name: "Invalid Scope",
+			req:  &pbs.ListCredentialStoresRequest{ScopeId: "o_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := credential_stores.NewService(repoFn, iamRepoFn)
+			require.NoError(err, "Couldn't create new credential store service.")
+
+			got, gErr := s.ListCredentialStores(auth.DisabledAuthTestContext(auth.WithScopeId(tc.req.GetScopeId())), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "ListCredentialStores(%q) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(got.GetItems(), tc.res.GetItems(), protocmp.Transform()), "ListCredentialStores(%q) got response %q, wanted %q", tc.req, got, tc.res)
+			assert.Equal(tc.req.GetPageSize() != 0, got.GetNextPageToken() != "")
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+	_, proj, repoFn, iamRepoFn := createDefaultCredentialStoreAndRepo(t)
+
+	cases := []struct {
+		name string
+		req  *pbs.CreateCredentialStoreRequest
+		res  *pbs.CreateCredentialStoreResponse
+		err  error
+	}{
+		{
+			name: "Create a valid Credential Store",
+			req: &pbs.CreateCredentialStoreRequest{Item: &pb.CredentialStore{
+				ScopeId:     proj.GetPublicId(),
+				Name:        &wrappers.StringValue{Value: "name"},
+				Description: &wrappers.StringValue{Value: "desc"},
+				Type:        "static",
+			}},
+			res: &pbs.CreateCredentialStoreResponse{
+				Uri: fmt.Sprintf("credential-stores/%s_", static.CredentialStorePrefix),
+				Item: &pb.CredentialStore{
+					ScopeId:     proj.GetPublicId(),
+					Scope:       &scopepb.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+					Name:        &wrappers.StringValue{Value: "name"},
+					Description: &wrappers.StringValue{Value: "desc"},
+					Version:     1,
+					Type:        "static",
+				},
+			},
+		},
+		{
+			name: "Missing type",
+			req: &pbs.CreateCredentialStoreRequest{Item: &pb.CredentialStore{
+				ScopeId: proj.GetPublicId(),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Invalid scope",
+			req: &pbs.CreateCredentialStoreRequest{Item: &pb.CredentialStore{
+				ScopeId: "o_1234567890",
+				Type:    "static",
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify Id",
+			req: &pbs.CreateCredentialStoreRequest{Item: &pb.CredentialStore{
+				Id:      static.CredentialStorePrefix + "_notallowed",
+				ScopeId: proj.GetPublicId(),
+				Type:    "static",
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := credential_stores.NewService(repoFn, iamRepoFn)
+			require.NoError(err, "Error when getting new credential store service.")
+
+			got, gErr := s.CreateCredentialStore(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateCredentialStore(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Contains(got.GetUri(), tc.res.GetUri())
+			assert.True(len(got.GetItem().GetId()) > len(static.CredentialStorePrefix))
+			assert.NotNil(got.GetItem().GetCreatedTime())
+			assert.NotNil(got.GetItem().GetUpdatedTime())
+			// Clear all values which are hard to compare against.
+			got.Uri, tc.res.Uri = "", ""
+			got.Item.Id, tc.res.Item.Id = "", ""
+			got.Item.CreatedTime, got.Item.UpdatedTime = nil, nil
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "CreateCredentialStore(%q) got response %q, wanted %q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+	cs, proj, repoFn, iamRepoFn := createDefaultCredentialStoreAndRepo(t)
+	s, err := credential_stores.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err, "Error when getting new credential store service.")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+
+	cases := []struct {
+		name    string
+		req     *pbs.UpdateCredentialStoreRequest
+		want    *pb.CredentialStore
+		wantErr error
+	}{
+		{
+			name: "Update name and description",
+			req: &pbs.UpdateCredentialStoreRequest{
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"name", "description"}},
+				Item: &pb.CredentialStore{
+					Name:        &wrappers.StringValue{Value: "new"},
+					Description: &wrappers.StringValue{Value: "desc"},
+				},
+			},
+			want: &pb.CredentialStore{
+				Name:        &wrappers.StringValue{Value: "new"},
+				Description: &wrappers.StringValue{Value: "desc"},
+			},
+		},
+		{
+			name: "Unset description",
+			req: &pbs.UpdateCredentialStoreRequest{
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"description"}},
+				Item:       &pb.CredentialStore{},
+			},
+			want: &pb.CredentialStore{
+				Name: &wrappers.StringValue{Value: "new"},
+			},
+		},
+		{
+			name: "No update mask",
+			req: &pbs.UpdateCredentialStoreRequest{
+				Item: &pb.CredentialStore{Name: &wrappers.StringValue{Value: "updated"}},
+			},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Cannot change type",
+			req: &pbs.UpdateCredentialStoreRequest{
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"name", "type"}},
+				Item:       &pb.CredentialStore{Name: &wrappers.StringValue{Value: "updated"}, Type: "vault"},
+			},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	version := cs.GetVersion()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			tc.req.Id = cs.GetPublicId()
+			tc.req.Item.Version = version
+
+			got, gErr := s.UpdateCredentialStore(ctx, tc.req)
+			if tc.wantErr != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.wantErr), "UpdateCredentialStore(%+v) got error %v, wanted %v", tc.req, gErr, tc.wantErr)
+				return
+			}
+			require.NoError(gErr)
+			version = got.GetItem().GetVersion()
+			assert.Equal(tc.want.GetName(), got.GetItem().GetName())
+			assert.Equal(tc.want.GetDescription(), got.GetItem().GetDescription())
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+	cs, proj, repoFn, iamRepoFn := createDefaultCredentialStoreAndRepo(t)
+	s, err := credential_stores.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err, "Error when getting new credential store service.")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+
+	cases := []struct {
+		name string
+		req  *pbs.DeleteCredentialStoreRequest
+		err  error
+	}{
+		{
+			name: "Delete an Existing Credential Store",
+			req:  &pbs.DeleteCredentialStoreRequest{Id: cs.GetPublicId()},
+		},
+		{
+			name: "Delete a deleted Credential Store",
+			req:  &pbs.DeleteCredentialStoreRequest{Id: cs.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad Credential Store Id formatting",
+			req:  &pbs.DeleteCredentialStoreRequest{Id: static.CredentialStorePrefix + "_bad_format"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.DeleteCredentialStore(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "DeleteCredentialStore(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.NotNil(got)
+		})
+	}
+}
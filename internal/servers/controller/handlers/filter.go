@@ -0,0 +1,496 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// A Filter is a boolean expression which items returned by List requests are
+// matched against. Items are matched as they are rendered in API responses
+// and are available to the expression under "/item". For example:
+//
+//	"/item/name" matches "prod-*" and "/item/type" == "tcp"
+//
+// Selectors are JSON pointers in double quotes, or dotted paths such as
+// item.scope.id. The following operators are supported:
+//
+//	<selector> == <value>
+//	<selector> != <value>
+//	<selector> matches <pattern>
+//	<selector> not matches <pattern>
+//	<selector> contains <value>
+//	<selector> not contains <value>
+//	<value> in <selector>
+//	<value> not in <selector>
+//	<selector> is empty
+//	<selector> is not empty
+//
+// Values are strings in double quotes or bare words such as 8080 or true.
+// Patterns are matched against the whole value with the syntax of path.Match,
+// where "*" matches any sequence of characters other than "/".
+// Expressions can be combined with "and", "or", "not", and parentheses; "not"
+// binds tighter than "and", which binds tighter than "or". A selector which
+// does not exist in the item never equals, matches, or contains a value, and
+// is empty.
+type Filter struct {
+	expr filterExpr
+}
+
+// NewFilter parses the filter expression. An empty expression results in a
+// filter which matches every item.
+func NewFilter(expr string) (*Filter, error) {
+	if strings.TrimSpace(expr) == "" {
+		return &Filter{}, nil
+	}
+	toks, err := lexFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{toks: toks}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t != nil {
+		return nil, fmt.Errorf("unexpected %s", t)
+	}
+	return &Filter{expr: e}, nil
+}
+
+// Match reports whether the item matches the filter. Items which can not be
+// rendered do not match a non empty filter.
+func (f *Filter) Match(item proto.Message) bool {
+	if f == nil || f.expr == nil {
+		return true
+	}
+	js, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(item)
+	if err != nil {
+		return false
+	}
+	var v interface{}
+	if err := json.Unmarshal(js, &v); err != nil {
+		return false
+	}
+	return f.expr.eval(map[string]interface{}{"item": v})
+}
+
+// MatchDocument reports whether the document matches the filter. Unlike
+// Match, selectors are resolved from the root of the document, whose values
+// must be of the types produced by encoding/json.
+func (f *Filter) MatchDocument(doc map[string]interface{}) bool {
+	if f == nil || f.expr == nil {
+		return true
+	}
+	return f.expr.eval(doc)
+}
+
+type filterExpr interface {
+	eval(doc interface{}) bool
+}
+
+type filterAnd struct{ left, right filterExpr }
+
+func (e filterAnd) eval(doc interface{}) bool { return e.left.eval(doc) && e.right.eval(doc) }
+
+type filterOr struct{ left, right filterExpr }
+
+func (e filterOr) eval(doc interface{}) bool { return e.left.eval(doc) || e.right.eval(doc) }
+
+type filterNot struct{ expr filterExpr }
+
+func (e filterNot) eval(doc interface{}) bool { return !e.expr.eval(doc) }
+
+type filterOp int
+
+const (
+	filterOpEqual filterOp = iota
+	filterOpMatches
+	filterOpContains
+	filterOpEmpty
+)
+
+type filterMatch struct {
+	selector []string
+	op       filterOp
+	value    string
+}
+
+func (e filterMatch) eval(doc interface{}) bool {
+	v, ok := resolveSelector(doc, e.selector)
+	switch e.op {
+	case filterOpEqual:
+		s, isScalar := scalarString(v)
+		return ok && isScalar && s == e.value
+	case filterOpMatches:
+		s, isScalar := scalarString(v)
+		if !ok || !isScalar {
+			return false
+		}
+		matched, _ := path.Match(e.value, s)
+		return matched
+	case filterOpContains:
+		if !ok {
+			return false
+		}
+		switch v := v.(type) {
+		case []interface{}:
+			for _, elem := range v {
+				if s, isScalar := scalarString(elem); isScalar && s == e.value {
+					return true
+				}
+			}
+			return false
+		case map[string]interface{}:
+			_, found := v[e.value]
+			return found
+		case string:
+			return strings.Contains(v, e.value)
+		}
+		return false
+	case filterOpEmpty:
+		if !ok || v == nil {
+			return true
+		}
+		switch v := v.(type) {
+		case []interface{}:
+			return len(v) == 0
+		case map[string]interface{}:
+			return len(v) == 0
+		case string:
+			return v == ""
+		}
+		return false
+	}
+	return false
+}
+
+func resolveSelector(doc interface{}, selector []string) (interface{}, bool) {
+	cur := doc
+	for _, key := range selector {
+		switch v := cur.(type) {
+		case map[string]interface{}:
+			next, ok := v[key]
+			if !ok {
+				return nil, false
+			}
+			cur = next
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			cur = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+func scalarString(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+type filterTokenKind int
+
+const (
+	filterTokenWord filterTokenKind = iota
+	filterTokenString
+	filterTokenSymbol
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+}
+
+func (t *filterToken) String() string {
+	if t.kind == filterTokenString {
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+func (t *filterToken) isKeyword(k string) bool {
+	return t != nil && t.kind == filterTokenWord && t.text == k
+}
+
+func (t *filterToken) isSymbol(s string) bool {
+	return t != nil && t.kind == filterTokenSymbol && t.text == s
+}
+
+var filterKeywords = map[string]bool{
+	"and": true, "or": true, "not": true, "matches": true,
+	"contains": true, "in": true, "is": true, "empty": true,
+}
+
+func lexFilter(expr string) ([]*filterToken, error) {
+	var toks []*filterToken
+	rs := []rune(expr)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			toks = append(toks, &filterToken{kind: filterTokenSymbol, text: string(r)})
+			i++
+		case r == '=' || r == '!':
+			if i+1 >= len(rs) || rs[i+1] != '=' {
+				return nil, fmt.Errorf("unexpected %q", string(r))
+			}
+			toks = append(toks, &filterToken{kind: filterTokenSymbol, text: string(rs[i : i+2])})
+			i += 2
+		case r == '"':
+			j := i + 1
+			for ; j < len(rs) && rs[j] != '"'; j++ {
+				if rs[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(rs) {
+				return nil, errors.New("unterminated string")
+			}
+			s, err := strconv.Unquote(string(rs[i : j+1]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid string %s: %w", string(rs[i:j+1]), err)
+			}
+			toks = append(toks, &filterToken{kind: filterTokenString, text: s})
+			i = j + 1
+		default:
+			j := i
+			for ; j < len(rs) && !unicode.IsSpace(rs[j]) && !strings.ContainsRune(`()"=!`, rs[j]); j++ {
+			}
+			toks = append(toks, &filterToken{kind: filterTokenWord, text: string(rs[i:j])})
+			i = j
+		}
+	}
+	return toks, nil
+}
+
+type filterParser struct {
+	toks []*filterToken
+	pos  int
+}
+
+func (p *filterParser) peek() *filterToken {
+	if p.pos >= len(p.toks) {
+		return nil
+	}
+	return p.toks[p.pos]
+}
+
+func (p *filterParser) next() (*filterToken, error) {
+	t := p.peek()
+	if t == nil {
+		return nil, errors.New("unexpected end of filter")
+	}
+	p.pos++
+	return t, nil
+}
+
+func (p *filterParser) parseOr() (filterExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = filterOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("and") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = filterAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterExpr, error) {
+	switch t := p.peek(); {
+	case t.isKeyword("not"):
+		p.pos++
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return filterNot{expr: e}, nil
+	case t.isSymbol("("):
+		p.pos++
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		t, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if !t.isSymbol(")") {
+			return nil, fmt.Errorf("expected \")\" but found %s", t)
+		}
+		return e, nil
+	}
+	return p.parseMatch()
+}
+
+func (p *filterParser) parseMatch() (filterExpr, error) {
+	first, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	negate := false
+	if op.isKeyword("not") {
+		negate = true
+		if op, err = p.next(); err != nil {
+			return nil, err
+		}
+		if !op.isKeyword("matches") && !op.isKeyword("contains") && !op.isKeyword("in") {
+			return nil, fmt.Errorf("expected \"matches\", \"contains\", or \"in\" after \"not\" but found %s", op)
+		}
+	}
+
+	var m filterMatch
+	switch {
+	case op.isSymbol("=="), op.isSymbol("!="):
+		negate = op.text == "!="
+		m.op = filterOpEqual
+		if m.selector, err = parseSelector(first); err != nil {
+			return nil, err
+		}
+		if m.value, err = p.value(); err != nil {
+			return nil, err
+		}
+	case op.isKeyword("matches"):
+		m.op = filterOpMatches
+		if m.selector, err = parseSelector(first); err != nil {
+			return nil, err
+		}
+		if m.value, err = p.value(); err != nil {
+			return nil, err
+		}
+		if _, err := path.Match(m.value, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", m.value, err)
+		}
+	case op.isKeyword("contains"):
+		m.op = filterOpContains
+		if m.selector, err = parseSelector(first); err != nil {
+			return nil, err
+		}
+		if m.value, err = p.value(); err != nil {
+			return nil, err
+		}
+	case op.isKeyword("in"):
+		m.op = filterOpContains
+		if first.kind == filterTokenSymbol {
+			return nil, fmt.Errorf("unexpected %s", first)
+		}
+		m.value = first.text
+		sel, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		if m.selector, err = parseSelector(sel); err != nil {
+			return nil, err
+		}
+	case op.isKeyword("is"):
+		m.op = filterOpEmpty
+		if m.selector, err = parseSelector(first); err != nil {
+			return nil, err
+		}
+		t, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if t.isKeyword("not") {
+			negate = true
+			if t, err = p.next(); err != nil {
+				return nil, err
+			}
+		}
+		if !t.isKeyword("empty") {
+			return nil, fmt.Errorf("expected \"empty\" but found %s", t)
+		}
+	default:
+		return nil, fmt.Errorf("expected an operator but found %s", op)
+	}
+	if negate {
+		return filterNot{expr: m}, nil
+	}
+	return m, nil
+}
+
+// operand returns the next token if it can be a selector or a value.
+func (p *filterParser) operand() (*filterToken, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if t.kind == filterTokenSymbol || (t.kind == filterTokenWord && filterKeywords[t.text]) {
+		return nil, fmt.Errorf("unexpected %s", t)
+	}
+	return t, nil
+}
+
+func (p *filterParser) value() (string, error) {
+	t, err := p.operand()
+	if err != nil {
+		return "", err
+	}
+	return t.text, nil
+}
+
+// parseSelector splits a JSON pointer or dotted path selector into its keys.
+func parseSelector(t *filterToken) ([]string, error) {
+	if t.kind == filterTokenString {
+		if !strings.HasPrefix(t.text, "/") {
+			return nil, fmt.Errorf("invalid selector %s: JSON pointers must start with \"/\"", t)
+		}
+		keys := strings.Split(t.text[1:], "/")
+		for i, k := range keys {
+			keys[i] = strings.ReplaceAll(strings.ReplaceAll(k, "~1", "/"), "~0", "~")
+		}
+		return keys, nil
+	}
+	keys := strings.Split(t.text, ".")
+	for _, k := range keys {
+		if k == "" {
+			return nil, fmt.Errorf("invalid selector %s", t)
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"testing"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	scopes "github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	targetspb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestFilter_Match(t *testing.T) {
+	item := &targetspb.Target{
+		Id:                "ttcp_1234567890",
+		Scope:             &scopes.ScopeInfo{Id: "p_1234567890", Type: "project"},
+		Name:              &wrapperspb.StringValue{Value: "prod-db"},
+		Type:              "tcp",
+		HostSetIds:        []string{"hsst_1", "hsst_2"},
+		SessionMaxSeconds: &wrapperspb.UInt32Value{Value: 28800},
+		Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+			"default_port": {Kind: &structpb.Value_NumberValue{NumberValue: 5432}},
+		}},
+	}
+
+	testCases := []struct {
+		name   string
+		filter string
+		want   bool
+	}{
+		{name: "empty", filter: "", want: true},
+		{name: "whitespace", filter: "  ", want: true},
+		{name: "equal", filter: `"/item/name" == "prod-db"`, want: true},
+		{name: "equal bare value", filter: `"/item/type" == tcp`, want: true},
+		{name: "equal mismatch", filter: `"/item/name" == "dev-db"`, want: false},
+		{name: "not equal", filter: `"/item/name" != "dev-db"`, want: true},
+		{name: "dotted selector", filter: `item.scope.type == "project"`, want: true},
+		{name: "number", filter: `"/item/attributes/default_port" == 5432`, want: true},
+		{name: "wrapped number", filter: `"/item/session_max_seconds" == 28800`, want: true},
+		{name: "matches", filter: `"/item/name" matches "prod-*"`, want: true},
+		{name: "not matches", filter: `"/item/name" not matches "prod-*"`, want: false},
+		{name: "matches whole value", filter: `"/item/name" matches "prod"`, want: false},
+		{name: "matches single character", filter: `"/item/name" matches "prod-d?"`, want: true},
+		{name: "matches character class", filter: `"/item/name" matches "[dp]rod-*"`, want: true},
+		{name: "matches glob not regexp", filter: `"/item/name" matches "p.*-db"`, want: false},
+		{name: "and", filter: `"/item/name" matches "prod-*" and "/item/type" == "tcp"`, want: true},
+		{name: "and mismatch", filter: `"/item/name" matches "prod-*" and "/item/type" == "ssh"`, want: false},
+		{name: "or", filter: `"/item/type" == "ssh" or "/item/type" == "tcp"`, want: true},
+		{name: "not", filter: `not "/item/type" == "ssh"`, want: true},
+		{name: "precedence", filter: `"/item/type" == "tcp" or "/item/type" == "ssh" and "/item/name" == "x"`, want: true},
+		{name: "parentheses", filter: `("/item/type" == "tcp" or "/item/type" == "ssh") and "/item/name" == "x"`, want: false},
+		{name: "in", filter: `"hsst_2" in "/item/host_set_ids"`, want: true},
+		{name: "not in", filter: `"hsst_3" not in "/item/host_set_ids"`, want: true},
+		{name: "contains", filter: `"/item/host_set_ids" contains "hsst_1"`, want: true},
+		{name: "contains map key", filter: `"/item/attributes" contains "default_port"`, want: true},
+		{name: "contains substring", filter: `"/item/name" contains "-"`, want: true},
+		{name: "not contains", filter: `"/item/host_set_ids" not contains "hsst_1"`, want: false},
+		{name: "array index", filter: `"/item/host_set_ids/1" == "hsst_2"`, want: true},
+		{name: "is empty missing", filter: `"/item/description" is empty`, want: true},
+		{name: "is not empty", filter: `"/item/name" is not empty`, want: true},
+		{name: "missing never equal", filter: `"/item/description" == ""`, want: false},
+		{name: "missing not equal", filter: `"/item/description" != "x"`, want: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := NewFilter(tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, f.Match(item))
+		})
+	}
+}
+
+func TestFilter_MatchDocument(t *testing.T) {
+	doc := map[string]interface{}{
+		"name": "worker-1",
+		"tags": map[string]interface{}{
+			"region": []interface{}{"us-east"},
+			"type":   []interface{}{"prod", "web"},
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		filter string
+		want   bool
+	}{
+		{name: "empty", filter: "", want: true},
+		{name: "name", filter: `"/name" == "worker-1"`, want: true},
+		{name: "tag", filter: `"us-east" in "/tags/region"`, want: true},
+		{name: "tag mismatch", filter: `"us-west" in "/tags/region"`, want: false},
+		{name: "tags and", filter: `"prod" in "/tags/type" and "web" in "/tags/type"`, want: true},
+		{name: "missing tag", filter: `"/tags/az" is empty`, want: true},
+		{name: "item is not the root", filter: `"/item/name" == "worker-1"`, want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := NewFilter(tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, f.MatchDocument(doc))
+		})
+	}
+}
+
+func TestNewFilter_Errors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		filter string
+	}{
+		{name: "unterminated string", filter: `"/item/name == "x"`},
+		{name: "missing value", filter: `"/item/name" ==`},
+		{name: "missing operator", filter: `"/item/name"`},
+		{name: "unknown operator", filter: `"/item/name" like "x"`},
+		{name: "single equals", filter: `"/item/name" = "x"`},
+		{name: "bad pointer", filter: `"item/name" == "x"`},
+		{name: "bad dotted selector", filter: `item..name == "x"`},
+		{name: "bad pattern", filter: `"/item/name" matches "[prod"`},
+		{name: "unbalanced parentheses", filter: `("/item/name" == "x"`},
+		{name: "trailing tokens", filter: `"/item/name" == "x" "y"`},
+		{name: "dangling and", filter: `"/item/name" == "x" and`},
+		{name: "is without empty", filter: `"/item/name" is "x"`},
+		{name: "not without operator", filter: `"/item/name" not == "x"`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := NewFilter(tc.filter)
+			assert.Error(t, err)
+			assert.Nil(t, f)
+		})
+	}
+}
@@ -32,6 +32,17 @@ func init() {
 	}
 }
 
+// IdActions contains the set of actions that can be performed on
+// individual resources
+var IdActions = action.ActionSet{
+	action.Read,
+	action.Update,
+	action.Delete,
+	action.AddMembers,
+	action.SetMembers,
+	action.RemoveMembers,
+}
+
 // Service handles request as described by the pbs.GroupServiceServer interface.
 type Service struct {
 	repoFn common.IamRepoFactory
@@ -52,18 +63,37 @@ func (s Service) ListGroups(ctx context.Context, req *pbs.ListGroupsRequest) (*p
 	if err := validateListRequest(req); err != nil {
 		return nil, err
 	}
-	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
-		return nil, authResults.Error
+	scopeResults, err := s.listAuthResults(ctx, req.GetScopeId(), req.GetRecursive())
+	if err != nil {
+		return nil, err
 	}
-	gl, err := s.listFromRepo(ctx, req.GetScopeId())
+	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range gl {
-		item.Scope = authResults.Scope
+	items, nextPageToken, err := handlers.Paginate(req.GetPageSize(), req.GetPageToken(), filter, func(afterId string, limit int) ([]handlers.PageItem, error) {
+		var items []handlers.PageItem
+		for _, authResults := range scopeResults {
+			gl, err := s.listFromRepo(ctx, authResults.Scope.GetId(), afterId, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range gl {
+				item.Scope = authResults.Scope
+				item.AuthorizedActions = authResults.FetchActionSetForId(ctx, item.Id, IdActions).Strings()
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return &pbs.ListGroupsResponse{Items: gl}, nil
+	var finalItems []*pb.Group
+	for _, item := range items {
+		finalItems = append(finalItems, item.(*pb.Group))
+	}
+	return &pbs.ListGroupsResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetGroups implements the interface pbs.GroupServiceServer.
@@ -80,6 +110,7 @@ func (s Service) GetGroup(ctx context.Context, req *pbs.GetGroupRequest) (*pbs.G
 		return nil, err
 	}
 	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
 	return &pbs.GetGroupResponse{Item: u}, nil
 }
 
@@ -275,12 +306,12 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Group, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeId, afterId string, limit int) ([]*pb.Group, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	gl, err := repo.ListGroups(ctx, scopeId)
+	gl, err := repo.ListGroups(ctx, scopeId, iam.WithLimit(limit), iam.WithStartPageAfterId(afterId))
 	if err != nil {
 		return nil, fmt.Errorf("unable to list groups: %w", err)
 	}
@@ -390,6 +421,20 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
+// listAuthResults authorizes listing in the provided scope or, when recursive,
+// separately in it and every scope beneath it. It returns the results for the
+// scopes in which listing is allowed.
+func (s Service) listAuthResults(ctx context.Context, scopeId string, recursive bool) ([]auth.VerifyResults, error) {
+	if recursive {
+		return auth.VerifyRecursive(ctx, s.repoFn, auth.WithType(resource.Group), auth.WithAction(action.List), auth.WithScopeId(scopeId))
+	}
+	authResults := s.authResult(ctx, scopeId, action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	return []auth.VerifyResults{authResults}, nil
+}
+
 func toProto(in *iam.Group, members []*iam.GroupMember) *pb.Group {
 	out := pb.Group{
 		Id:          in.GetPublicId(),
@@ -450,6 +495,12 @@ func validateListRequest(req *pbs.ListGroupsRequest) error {
 		req.GetScopeId() != scope.Global.String() {
 		badFields["scope_id"] = "Incorrectly formatted identifier."
 	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if _, err := handlers.DecodePageToken(req.GetPageToken()); err != nil {
+		badFields["page_token"] = "This is not a valid page token."
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
 	}
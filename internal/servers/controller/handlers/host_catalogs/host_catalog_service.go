@@ -2,12 +2,16 @@ package host_catalogs
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/boundary/internal/auth"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostcatalogs"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/host/inventory"
+	invstore "github.com/hashicorp/boundary/internal/host/inventory/store"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/host/static/store"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
@@ -20,7 +24,8 @@ import (
 )
 
 var (
-	maskManager handlers.MaskManager
+	maskManager          handlers.MaskManager
+	inventoryMaskManager handlers.MaskManager
 )
 
 func init() {
@@ -28,43 +33,77 @@ func init() {
 	if maskManager, err = handlers.NewMaskManager(&store.HostCatalog{}, &pb.HostCatalog{}); err != nil {
 		panic(err)
 	}
+	if inventoryMaskManager, err = handlers.NewMaskManager(&invstore.HostCatalog{}, &pb.HostCatalog{}, &pb.InventoryHostCatalogAttributes{}); err != nil {
+		panic(err)
+	}
+}
+
+// IdActions contains the set of actions that can be performed on
+// individual resources
+var IdActions = action.ActionSet{
+	action.Read,
+	action.Update,
+	action.Delete,
 }
 
 type Service struct {
-	staticRepoFn common.StaticRepoFactory
-	iamRepoFn    common.IamRepoFactory
+	staticRepoFn    common.StaticRepoFactory
+	inventoryRepoFn common.InventoryRepoFactory
+	iamRepoFn       common.IamRepoFactory
 }
 
 var _ pbs.HostCatalogServiceServer = Service{}
 
 // NewService returns a host catalog Service which handles host catalog related requests to boundary and uses the provided
 // repositories for storage and retrieval.
-func NewService(repoFn common.StaticRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+func NewService(repoFn common.StaticRepoFactory, inventoryRepoFn common.InventoryRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
 	if repoFn == nil {
 		return Service{}, fmt.Errorf("nil static repository provided")
 	}
+	if inventoryRepoFn == nil {
+		return Service{}, fmt.Errorf("nil inventory repository provided")
+	}
 	if iamRepoFn == nil {
 		return Service{}, fmt.Errorf("nil iam repository provided")
 	}
-	return Service{staticRepoFn: repoFn, iamRepoFn: iamRepoFn}, nil
+	return Service{staticRepoFn: repoFn, inventoryRepoFn: inventoryRepoFn, iamRepoFn: iamRepoFn}, nil
 }
 
 func (s Service) ListHostCatalogs(ctx context.Context, req *pbs.ListHostCatalogsRequest) (*pbs.ListHostCatalogsResponse, error) {
 	if err := validateListRequest(req); err != nil {
 		return nil, err
 	}
-	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
-		return nil, authResults.Error
+	scopeResults, err := s.listAuthResults(ctx, req.GetScopeId(), req.GetRecursive())
+	if err != nil {
+		return nil, err
 	}
-	ul, err := s.listFromRepo(ctx, authResults.Scope.GetId())
+	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range ul {
-		item.Scope = authResults.Scope
+	items, nextPageToken, err := handlers.Paginate(req.GetPageSize(), req.GetPageToken(), filter, func(afterId string, limit int) ([]handlers.PageItem, error) {
+		var items []handlers.PageItem
+		for _, authResults := range scopeResults {
+			ul, err := s.listFromRepo(ctx, authResults.Scope.GetId(), afterId, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range ul {
+				item.Scope = authResults.Scope
+				item.AuthorizedActions = authResults.FetchActionSetForId(ctx, item.Id, IdActions).Strings()
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return &pbs.ListHostCatalogsResponse{Items: ul}, nil
+	var finalItems []*pb.HostCatalog
+	for _, item := range items {
+		finalItems = append(finalItems, item.(*pb.HostCatalog))
+	}
+	return &pbs.ListHostCatalogsResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetHostCatalog implements the interface pbs.HostCatalogServiceServer.
@@ -81,6 +120,7 @@ func (s Service) GetHostCatalog(ctx context.Context, req *pbs.GetHostCatalogRequ
 		return nil, err
 	}
 	hc.Scope = authResults.Scope
+	hc.AuthorizedActions = authResults.FetchActionSetForId(ctx, hc.Id, IdActions).Strings()
 	return &pbs.GetHostCatalogResponse{Item: hc}, nil
 }
 
@@ -138,6 +178,24 @@ func (s Service) DeleteHostCatalog(ctx context.Context, req *pbs.DeleteHostCatal
 }
 
 func (s Service) getFromRepo(ctx context.Context, id string) (*pb.HostCatalog, error) {
+	if host.SubtypeFromId(id) == host.InventorySubtype {
+		repo, err := s.inventoryRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		hc, err := repo.LookupCatalog(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if hc == nil {
+			return nil, handlers.NotFoundErrorf("Host Catalog %q doesn't exist.", id)
+		}
+		sync, err := repo.LookupCatalogSync(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return toInventoryProto(hc, sync)
+	}
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
@@ -152,12 +210,12 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.HostCatalog, e
 	return toProto(hc), nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.HostCatalog, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeId, afterId string, limit int) ([]*pb.HostCatalog, error) {
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListCatalogs(ctx, scopeId)
+	ul, err := repo.ListCatalogs(ctx, scopeId, static.WithLimit(limit), static.WithStartPageAfterId(afterId))
 	if err != nil {
 		return nil, err
 	}
@@ -165,10 +223,42 @@ func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.HostCa
 	for _, u := range ul {
 		outUl = append(outUl, toProto(u))
 	}
+
+	invRepo, err := s.inventoryRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	invUl, err := invRepo.ListCatalogs(ctx, scopeId, inventory.WithLimit(limit), inventory.WithStartPageAfterId(afterId))
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range invUl {
+		sync, err := invRepo.LookupCatalogSync(ctx, u.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		ou, err := toInventoryProto(u, sync)
+		if err != nil {
+			return nil, err
+		}
+		outUl = append(outUl, ou)
+	}
+
+	// Each repository lists its host catalogs in id order, so merge them into a
+	// single ordered list before applying the limit.
+	sort.Slice(outUl, func(i, j int) bool {
+		return outUl[i].GetId() < outUl[j].GetId()
+	})
+	if len(outUl) > limit {
+		outUl = outUl[:limit]
+	}
 	return outUl, nil
 }
 
 func (s Service) createInRepo(ctx context.Context, projId string, item *pb.HostCatalog) (*pb.HostCatalog, error) {
+	if host.SubtypeFromType(item.GetType()) == host.InventorySubtype {
+		return s.createInventoryInRepo(ctx, projId, item)
+	}
 	var opts []static.Option
 	if item.GetName() != nil {
 		opts = append(opts, static.WithName(item.GetName().GetValue()))
@@ -195,6 +285,9 @@ func (s Service) createInRepo(ctx context.Context, projId string, item *pb.HostC
 }
 
 func (s Service) updateInRepo(ctx context.Context, projId, id string, mask []string, item *pb.HostCatalog) (*pb.HostCatalog, error) {
+	if host.SubtypeFromId(id) == host.InventorySubtype {
+		return s.updateInventoryInRepo(ctx, projId, id, mask, item)
+	}
 	var opts []static.Option
 	if desc := item.GetDescription(); desc != nil {
 		opts = append(opts, static.WithDescription(desc.GetValue()))
@@ -226,7 +319,103 @@ func (s Service) updateInRepo(ctx context.Context, projId, id string, mask []str
 	return toProto(out), nil
 }
 
+func (s Service) createInventoryInRepo(ctx context.Context, projId string, item *pb.HostCatalog) (*pb.HostCatalog, error) {
+	attrs := &pb.InventoryHostCatalogAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	var opts []inventory.Option
+	if item.GetName() != nil {
+		opts = append(opts, inventory.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, inventory.WithDescription(item.GetDescription().GetValue()))
+	}
+	if attrs.GetSyncIntervalSeconds() != nil {
+		opts = append(opts, inventory.WithSyncIntervalSeconds(attrs.GetSyncIntervalSeconds().GetValue()))
+	}
+	h, err := inventory.NewHostCatalog(projId, attrs.GetSource().GetValue(), opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build host catalog for creation: %v.", err)
+	}
+	repo, err := s.inventoryRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateCatalog(ctx, h)
+	if err != nil {
+		if errors.Is(err, inventory.ErrInvalidSource) {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"attributes.source": err.Error()})
+		}
+		return nil, fmt.Errorf("unable to create host catalog: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create host catalog but no error returned from repository.")
+	}
+	sync, err := repo.LookupCatalogSync(ctx, out.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	return toInventoryProto(out, sync)
+}
+
+func (s Service) updateInventoryInRepo(ctx context.Context, projId, id string, mask []string, item *pb.HostCatalog) (*pb.HostCatalog, error) {
+	attrs := &pb.InventoryHostCatalogAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	// The catalog is built directly since any of its fields, including
+	// the source, may be left out of an update.
+	h := &inventory.HostCatalog{
+		HostCatalog: &invstore.HostCatalog{
+			ScopeId:             projId,
+			Name:                item.GetName().GetValue(),
+			Description:         item.GetDescription().GetValue(),
+			Source:              attrs.GetSource().GetValue(),
+			SyncIntervalSeconds: attrs.GetSyncIntervalSeconds().GetValue(),
+		},
+	}
+	version := item.GetVersion()
+	h.PublicId = id
+	dbMask := inventoryMaskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.inventoryRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, rowsUpdated, err := repo.UpdateCatalog(ctx, h, version, dbMask)
+	if err != nil {
+		if errors.Is(err, inventory.ErrInvalidSource) {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"attributes.source": err.Error()})
+		}
+		return nil, fmt.Errorf("unable to update host catalog: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Host Catalog %q doesn't exist or incorrect version provided.", id)
+	}
+	sync, err := repo.LookupCatalogSync(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toInventoryProto(out, sync)
+}
+
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	if host.SubtypeFromId(id) == host.InventorySubtype {
+		repo, err := s.inventoryRepoFn()
+		if err != nil {
+			return false, err
+		}
+		rows, err := repo.DeleteCatalog(ctx, id)
+		if err != nil {
+			return false, fmt.Errorf("unable to delete host catalog: %w", err)
+		}
+		return rows > 0, nil
+	}
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return false, err
@@ -261,27 +450,61 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 			return res
 		}
 	default:
-		repo, err := s.staticRepoFn()
+		scopeId, err := s.catalogScopeId(ctx, id)
 		if err != nil {
 			res.Error = err
 			return res
 		}
-		cat, err := repo.LookupCatalog(ctx, id)
-		if err != nil {
-			res.Error = err
-			return res
-		}
-		if cat == nil {
+		if scopeId == "" {
 			res.Error = handlers.NotFoundError()
 			return res
 		}
-		parentId = cat.GetScopeId()
+		parentId = scopeId
 		opts = append(opts, auth.WithId(id))
 	}
 	opts = append(opts, auth.WithScopeId(parentId))
 	return auth.Verify(ctx, opts...)
 }
 
+// listAuthResults authorizes listing in the provided scope or, when recursive,
+// separately in it and every scope beneath it. It returns the results for the
+// scopes in which listing is allowed.
+func (s Service) listAuthResults(ctx context.Context, scopeId string, recursive bool) ([]auth.VerifyResults, error) {
+	if recursive {
+		return auth.VerifyRecursive(ctx, s.iamRepoFn, auth.WithType(resource.HostCatalog), auth.WithAction(action.List), auth.WithScopeId(scopeId))
+	}
+	authResults := s.authResult(ctx, scopeId, action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	return []auth.VerifyResults{authResults}, nil
+}
+
+// catalogScopeId returns the scope id of the host catalog for id or an
+// empty string if the catalog does not exist.
+func (s Service) catalogScopeId(ctx context.Context, id string) (string, error) {
+	if host.SubtypeFromId(id) == host.InventorySubtype {
+		repo, err := s.inventoryRepoFn()
+		if err != nil {
+			return "", err
+		}
+		cat, err := repo.LookupCatalog(ctx, id)
+		if err != nil || cat == nil {
+			return "", err
+		}
+		return cat.GetScopeId(), nil
+	}
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return "", err
+	}
+	cat, err := repo.LookupCatalog(ctx, id)
+	if err != nil || cat == nil {
+		return "", err
+	}
+	return cat.GetScopeId(), nil
+}
+
 func toProto(in *static.HostCatalog) *pb.HostCatalog {
 	out := pb.HostCatalog{
 		Id:          in.GetPublicId(),
@@ -300,6 +523,37 @@ func toProto(in *static.HostCatalog) *pb.HostCatalog {
 	return &out
 }
 
+func toInventoryProto(in *inventory.HostCatalog, sync *inventory.HostCatalogSync) (*pb.HostCatalog, error) {
+	out := pb.HostCatalog{
+		Id:          in.GetPublicId(),
+		ScopeId:     in.GetScopeId(),
+		CreatedTime: in.GetCreateTime().GetTimestamp(),
+		UpdatedTime: in.GetUpdateTime().GetTimestamp(),
+		Version:     in.GetVersion(),
+		Type:        host.InventorySubtype.String(),
+	}
+	if in.GetDescription() != "" {
+		out.Description = &wrapperspb.StringValue{Value: in.GetDescription()}
+	}
+	if in.GetName() != "" {
+		out.Name = &wrapperspb.StringValue{Value: in.GetName()}
+	}
+	attrs := &pb.InventoryHostCatalogAttributes{
+		Source:              wrapperspb.String(in.GetSource()),
+		SyncIntervalSeconds: wrapperspb.UInt32(in.GetSyncIntervalSeconds()),
+	}
+	if sync != nil {
+		attrs.LastSyncTime = sync.GetLastSyncTime().GetTimestamp()
+		attrs.LastSyncError = sync.GetLastSyncError()
+	}
+	st, err := handlers.ProtoToStruct(attrs)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building inventory attribute struct: %v", err)
+	}
+	out.Attributes = st
+	return &out, nil
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //  * The path passed in is correctly formatted
@@ -308,7 +562,7 @@ func toProto(in *static.HostCatalog) *pb.HostCatalog {
 //  * The type asserted by the ID and/or field is known
 //  * If relevant, the type derived from the id prefix matches what is claimed by the type field
 func validateGetRequest(req *pbs.GetHostCatalogRequest) error {
-	return handlers.ValidateGetRequest(static.HostCatalogPrefix, req, handlers.NoopValidatorFn)
+	return handlers.ValidateGetRequest(prefixFromId(req.GetId()), req, handlers.NoopValidatorFn)
 }
 
 func validateCreateRequest(req *pbs.CreateHostCatalogRequest) error {
@@ -319,37 +573,88 @@ func validateCreateRequest(req *pbs.CreateHostCatalogRequest) error {
 		}
 		switch host.SubtypeFromType(req.GetItem().GetType()) {
 		case host.StaticSubtype:
+		case host.InventorySubtype:
+			attrs := &pb.InventoryHostCatalogAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if attrs.GetSource() == nil {
+				badFields["attributes.source"] = "This is a required field."
+			} else if err := inventory.ValidateSource(attrs.GetSource().GetValue()); err != nil {
+				badFields["attributes.source"] = "Must be an absolute file path or a file, http, or https URL."
+			}
+			if attrs.GetLastSyncTime() != nil {
+				badFields["attributes.last_sync_time"] = "This is a read only field."
+			}
+			if attrs.GetLastSyncError() != "" {
+				badFields["attributes.last_sync_error"] = "This is a read only field."
+			}
 		default:
-			badFields["type"] = fmt.Sprintf("This is a required field and must be %q.", host.StaticSubtype.String())
+			badFields["type"] = fmt.Sprintf("This is a required field and must be %q or %q.", host.StaticSubtype.String(), host.InventorySubtype.String())
 		}
 		return badFields
 	})
 }
 
 func validateUpdateRequest(req *pbs.UpdateHostCatalogRequest) error {
-	return handlers.ValidateUpdateRequest(static.HostCatalogPrefix, req, req.GetItem(), func() map[string]string {
+	return handlers.ValidateUpdateRequest(prefixFromId(req.GetId()), req, req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
 		switch host.SubtypeFromId(req.GetId()) {
 		case host.StaticSubtype:
 			if req.GetItem().GetType() != "" && host.SubtypeFromType(req.GetItem().GetType()) != host.StaticSubtype {
 				badFields["type"] = "Cannot modify resource type."
 			}
+		case host.InventorySubtype:
+			if req.GetItem().GetType() != "" && host.SubtypeFromType(req.GetItem().GetType()) != host.InventorySubtype {
+				badFields["type"] = "Cannot modify resource type."
+			}
+			attrs := &pb.InventoryHostCatalogAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), "attributes.source") {
+				if err := inventory.ValidateSource(attrs.GetSource().GetValue()); err != nil {
+					badFields["attributes.source"] = "Must be an absolute file path or a file, http, or https URL."
+				}
+			}
 		}
 		return badFields
 	})
 }
 
 func validateDeleteRequest(req *pbs.DeleteHostCatalogRequest) error {
-	return handlers.ValidateDeleteRequest(static.HostCatalogPrefix, req, handlers.NoopValidatorFn)
+	return handlers.ValidateDeleteRequest(prefixFromId(req.GetId()), req, handlers.NoopValidatorFn)
 }
 
 func validateListRequest(req *pbs.ListHostCatalogsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) {
+	switch {
+	case req.GetRecursive():
+		if req.GetScopeId() != scope.Global.String() &&
+			!handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()) &&
+			!handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) {
+			badFields["scope_id"] = "This field must be 'global' or a valid org or project scope id when listing recursively."
+		}
+	case !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()):
 		badFields["scope_id"] = "This field must be a valid project scope id."
 	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if _, err := handlers.DecodePageToken(req.GetPageToken()); err != nil {
+		badFields["page_token"] = "This is not a valid page token."
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
 	}
 	return nil
 }
+
+func prefixFromId(id string) string {
+	if host.SubtypeFromId(id) == host.InventorySubtype {
+		return inventory.HostCatalogPrefix
+	}
+	return static.HostCatalogPrefix
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 
@@ -15,6 +16,7 @@ import (
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostcatalogs"
 	scopepb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/host/inventory"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
@@ -26,12 +28,13 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func createDefaultHostCatalogAndRepo(t *testing.T) (*static.HostCatalog, *iam.Scope, common.StaticRepoFactory, common.IamRepoFactory) {
+func createDefaultHostCatalogAndRepo(t *testing.T) (*static.HostCatalog, *iam.Scope, common.StaticRepoFactory, common.InventoryRepoFactory, common.IamRepoFactory) {
 	t.Helper()
 	require := require.New(t)
 	conn, _ := db.TestSetup(t, "postgres")
@@ -44,6 +47,11 @@ func createDefaultHostCatalogAndRepo(t *testing.T) (*static.HostCatalog, *iam.Sc
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	invRepoFn := func() (*inventory.Repository, error) {
+		return inventory.NewRepository(rw, rw, kms, inventory.WithSourcePolicy(inventory.SourcePolicy{
+			AllowedHosts: []string{"inventory.example.com"},
+		}))
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.TestRepo(t, conn, wrapper), nil
 	}
@@ -55,25 +63,26 @@ func createDefaultHostCatalogAndRepo(t *testing.T) (*static.HostCatalog, *iam.Sc
 	hcRes, err := repo.CreateCatalog(context.Background(), hc)
 	require.NoError(err, "Couldn't persist new catalog.")
 
-	return hcRes, pRes, repoFn, iamRepoFn
+	return hcRes, pRes, repoFn, invRepoFn, iamRepoFn
 }
 
 func TestGet(t *testing.T) {
 	t.Parallel()
-	hc, proj, repo, iamRepoFn := createDefaultHostCatalogAndRepo(t)
+	hc, proj, repo, invRepoFn, iamRepoFn := createDefaultHostCatalogAndRepo(t)
 	toMerge := &pbs.GetHostCatalogRequest{
 		Id: hc.GetPublicId(),
 	}
 
 	pHostCatalog := &pb.HostCatalog{
-		Id:          hc.GetPublicId(),
-		ScopeId:     hc.GetScopeId(),
-		Scope:       &scopepb.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
-		Name:        &wrappers.StringValue{Value: hc.GetName()},
-		Description: &wrappers.StringValue{Value: hc.GetDescription()},
-		CreatedTime: hc.CreateTime.GetTimestamp(),
-		UpdatedTime: hc.UpdateTime.GetTimestamp(),
-		Type:        "static",
+		Id:                hc.GetPublicId(),
+		ScopeId:           hc.GetScopeId(),
+		Scope:             &scopepb.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+		Name:              &wrappers.StringValue{Value: hc.GetName()},
+		Description:       &wrappers.StringValue{Value: hc.GetDescription()},
+		CreatedTime:       hc.CreateTime.GetTimestamp(),
+		UpdatedTime:       hc.UpdateTime.GetTimestamp(),
+		Type:              "static",
+		AuthorizedActions: host_catalogs.IdActions.Strings(),
 	}
 
 	cases := []struct {
@@ -112,7 +121,7 @@ func TestGet(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetHostCatalogRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := host_catalogs.NewService(repo, iamRepoFn)
+			s, err := host_catalogs.NewService(repo, invRepoFn, iamRepoFn)
 			require.NoError(err, "Couldn't create a new host catalog service.")
 
 			got, gErr := s.GetHostCatalog(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), req)
@@ -140,6 +149,9 @@ func TestList(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	invRepoFn := func() (*inventory.Repository, error) {
+		return inventory.NewRepository(rw, rw, kms)
+	}
 	iamRepo := iam.TestRepo(t, conn, wrapper)
 
 	_, pNoCatalogs := iam.TestScopes(t, iamRepo)
@@ -149,29 +161,34 @@ func TestList(t *testing.T) {
 	var wantSomeCatalogs []*pb.HostCatalog
 	for _, hc := range static.TestCatalogs(t, conn, pWithCatalogs.GetPublicId(), 3) {
 		wantSomeCatalogs = append(wantSomeCatalogs, &pb.HostCatalog{
-			Id:          hc.GetPublicId(),
-			ScopeId:     hc.GetScopeId(),
-			CreatedTime: hc.GetCreateTime().GetTimestamp(),
-			UpdatedTime: hc.GetUpdateTime().GetTimestamp(),
-			Scope:       &scopepb.ScopeInfo{Id: pWithCatalogs.GetPublicId(), Type: scope.Project.String()},
-			Version:     1,
-			Type:        "static",
+			Id:                hc.GetPublicId(),
+			ScopeId:           hc.GetScopeId(),
+			CreatedTime:       hc.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       hc.GetUpdateTime().GetTimestamp(),
+			Scope:             &scopepb.ScopeInfo{Id: pWithCatalogs.GetPublicId(), Type: scope.Project.String()},
+			Version:           1,
+			Type:              "static",
+			AuthorizedActions: host_catalogs.IdActions.Strings(),
 		})
 	}
 
 	var wantOtherCatalogs []*pb.HostCatalog
 	for _, hc := range static.TestCatalogs(t, conn, pWithOtherCatalogs.GetPublicId(), 3) {
 		wantOtherCatalogs = append(wantOtherCatalogs, &pb.HostCatalog{
-			Id:          hc.GetPublicId(),
-			ScopeId:     hc.GetScopeId(),
-			CreatedTime: hc.GetCreateTime().GetTimestamp(),
-			UpdatedTime: hc.GetUpdateTime().GetTimestamp(),
-			Scope:       &scopepb.ScopeInfo{Id: pWithOtherCatalogs.GetPublicId(), Type: scope.Project.String()},
-			Version:     1,
-			Type:        "static",
+			Id:                hc.GetPublicId(),
+			ScopeId:           hc.GetScopeId(),
+			CreatedTime:       hc.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       hc.GetUpdateTime().GetTimestamp(),
+			Scope:             &scopepb.ScopeInfo{Id: pWithOtherCatalogs.GetPublicId(), Type: scope.Project.String()},
+			Version:           1,
+			Type:              "static",
+			AuthorizedActions: host_catalogs.IdActions.Strings(),
 		})
 	}
 
+	sort.Slice(wantSomeCatalogs, func(i, j int) bool { return wantSomeCatalogs[i].GetId() < wantSomeCatalogs[j].GetId() })
+	sort.Slice(wantOtherCatalogs, func(i, j int) bool { return wantOtherCatalogs[i].GetId() < wantOtherCatalogs[j].GetId() })
+
 	cases := []struct {
 		name    string
 		scopeId string
@@ -207,7 +224,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := host_catalogs.NewService(repoFn, iamRepoFn)
+			s, err := host_catalogs.NewService(repoFn, invRepoFn, iamRepoFn)
 			require.NoError(err, "Couldn't create new auth_method service.")
 
 			got, gErr := s.ListHostCatalogs(auth.DisabledAuthTestContext(auth.WithScopeId(tc.scopeId)), &pbs.ListHostCatalogsRequest{ScopeId: tc.scopeId})
@@ -222,9 +239,9 @@ func TestList(t *testing.T) {
 
 func TestDelete(t *testing.T) {
 	t.Parallel()
-	hc, proj, repo, iamRepoFn := createDefaultHostCatalogAndRepo(t)
+	hc, proj, repo, invRepoFn, iamRepoFn := createDefaultHostCatalogAndRepo(t)
 
-	s, err := host_catalogs.NewService(repo, iamRepoFn)
+	s, err := host_catalogs.NewService(repo, invRepoFn, iamRepoFn)
 	require.NoError(t, err, "Couldn't create a new host catalog service.")
 
 	cases := []struct {
@@ -275,9 +292,9 @@ func TestDelete(t *testing.T) {
 func TestDelete_twice(t *testing.T) {
 	t.Parallel()
 	assert, require := assert.New(t), require.New(t)
-	hc, proj, repo, iamRepoFn := createDefaultHostCatalogAndRepo(t)
+	hc, proj, repo, invRepoFn, iamRepoFn := createDefaultHostCatalogAndRepo(t)
 
-	s, err := host_catalogs.NewService(repo, iamRepoFn)
+	s, err := host_catalogs.NewService(repo, invRepoFn, iamRepoFn)
 	require.NoError(err, "Couldn't create a new host catalog service.")
 	req := &pbs.DeleteHostCatalogRequest{
 		Id: hc.GetPublicId(),
@@ -292,7 +309,7 @@ func TestDelete_twice(t *testing.T) {
 
 func TestCreate(t *testing.T) {
 	t.Parallel()
-	defaultHc, proj, repo, iamRepoFn := createDefaultHostCatalogAndRepo(t)
+	defaultHc, proj, repo, invRepoFn, iamRepoFn := createDefaultHostCatalogAndRepo(t)
 	defaultHcCreated, err := ptypes.Timestamp(defaultHc.GetCreateTime().GetTimestamp())
 	require.NoError(t, err, "Error converting proto to timestamp.")
 	toMerge := &pbs.CreateHostCatalogRequest{}
@@ -390,7 +407,7 @@ func TestCreate(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.CreateHostCatalogRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := host_catalogs.NewService(repo, iamRepoFn)
+			s, err := host_catalogs.NewService(repo, invRepoFn, iamRepoFn)
 			require.NoError(err, "Failed to create a new host catalog service.")
 
 			got, gErr := s.CreateHostCatalog(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), req)
@@ -424,8 +441,8 @@ func TestCreate(t *testing.T) {
 
 func TestUpdate(t *testing.T) {
 	t.Parallel()
-	hc, proj, repoFn, iamRepoFn := createDefaultHostCatalogAndRepo(t)
-	tested, err := host_catalogs.NewService(repoFn, iamRepoFn)
+	hc, proj, repoFn, invRepoFn, iamRepoFn := createDefaultHostCatalogAndRepo(t)
+	tested, err := host_catalogs.NewService(repoFn, invRepoFn, iamRepoFn)
 	require.NoError(t, err, "Failed to create a new host catalog service.")
 
 	var version uint32 = 1
@@ -750,3 +767,51 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestCreate_Inventory(t *testing.T) {
+	t.Parallel()
+	_, proj, repo, invRepoFn, iamRepoFn := createDefaultHostCatalogAndRepo(t)
+	s, err := host_catalogs.NewService(repo, invRepoFn, iamRepoFn)
+	require.NoError(t, err, "Failed to create a new host catalog service.")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+
+	attrs := func(m map[string]interface{}) *structpb.Struct {
+		st, err := structpb.NewStruct(m)
+		require.NoError(t, err)
+		return st
+	}
+
+	assert, require := assert.New(t), require.New(t)
+	got, err := s.CreateHostCatalog(ctx, &pbs.CreateHostCatalogRequest{Item: &pb.HostCatalog{
+		ScopeId: proj.GetPublicId(),
+		Type:    "inventory",
+		Attributes: attrs(map[string]interface{}{
+			"source":                "https://inventory.example.com/hosts.json",
+			"sync_interval_seconds": 60,
+		}),
+	}})
+	require.NoError(err)
+	assert.True(strings.HasPrefix(got.GetItem().GetId(), inventory.HostCatalogPrefix+"_"))
+	assert.Equal("inventory", got.GetItem().GetType())
+	assert.Equal("https://inventory.example.com/hosts.json", got.GetItem().GetAttributes().GetFields()["source"].GetStringValue())
+	assert.Equal(float64(60), got.GetItem().GetAttributes().GetFields()["sync_interval_seconds"].GetNumberValue())
+
+	read, err := s.GetHostCatalog(ctx, &pbs.GetHostCatalogRequest{Id: got.GetItem().GetId()})
+	require.NoError(err)
+	assert.Empty(cmp.Diff(got.GetItem(), read.GetItem(), protocmp.Transform()))
+
+	for name, a := range map[string]map[string]interface{}{
+		"no source":        {},
+		"relative source":  {"source": "hosts.json"},
+		"read only field":  {"source": "/etc/hosts.json", "last_sync_error": "oops"},
+		"file not allowed": {"source": "/etc/passwd"},
+		"host not allowed": {"source": "http://169.254.169.254/latest/meta-data/"},
+	} {
+		_, err = s.CreateHostCatalog(ctx, &pbs.CreateHostCatalogRequest{Item: &pb.HostCatalog{
+			ScopeId:    proj.GetPublicId(),
+			Type:       "inventory",
+			Attributes: attrs(a),
+		}})
+		assert.Truef(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "%s: got error %v", name, err)
+	}
+}
@@ -10,6 +10,8 @@ import (
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostsets"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/host/inventory"
+	invstore "github.com/hashicorp/boundary/internal/host/inventory/store"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/host/static/store"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
@@ -18,11 +20,13 @@ import (
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/sdk/strutil"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 var (
-	maskManager handlers.MaskManager
+	maskManager          handlers.MaskManager
+	inventoryMaskManager handlers.MaskManager
 )
 
 func init() {
@@ -30,21 +34,46 @@ func init() {
 	if maskManager, err = handlers.NewMaskManager(&store.HostSet{}, &pb.HostSet{}); err != nil {
 		panic(err)
 	}
+	if inventoryMaskManager, err = handlers.NewMaskManager(&invstore.HostSet{}, &pb.HostSet{}, &pb.InventoryHostSetAttributes{}); err != nil {
+		panic(err)
+	}
+}
+
+// hostCatalog is the part of a host catalog of any subtype needed to
+// authorize requests for its host sets.
+type hostCatalog interface {
+	GetPublicId() string
+	GetScopeId() string
+}
+
+// IdActions contains the set of actions that can be performed on
+// individual resources
+var IdActions = action.ActionSet{
+	action.Read,
+	action.Update,
+	action.Delete,
+	action.AddHosts,
+	action.SetHosts,
+	action.RemoveHosts,
 }
 
 type Service struct {
-	staticRepoFn common.StaticRepoFactory
+	staticRepoFn    common.StaticRepoFactory
+	inventoryRepoFn common.InventoryRepoFactory
 }
 
 var _ pbs.HostSetServiceServer = Service{}
 
 // NewService returns a host set Service which handles host set related requests to boundary and uses the provided
 // repositories for storage and retrieval.
-func NewService(repoFn common.StaticRepoFactory) (Service, error) {
+func NewService(repoFn common.StaticRepoFactory, inventoryRepoFn common.InventoryRepoFactory) (Service, error) {
 	if repoFn == nil {
 		return Service{}, fmt.Errorf("nil static repository provided")
 	}
-	return Service{staticRepoFn: repoFn}, nil
+	if inventoryRepoFn == nil {
+		return Service{}, fmt.Errorf("nil inventory repository provided")
+	}
+	return Service{staticRepoFn: repoFn, inventoryRepoFn: inventoryRepoFn}, nil
 }
 
 func (s Service) ListHostSets(ctx context.Context, req *pbs.ListHostSetsRequest) (*pbs.ListHostSetsResponse, error) {
@@ -55,14 +84,31 @@ func (s Service) ListHostSets(ctx context.Context, req *pbs.ListHostSetsRequest)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hl, err := s.listFromRepo(ctx, req.GetHostCatalogId())
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	items, nextPageToken, err := handlers.Paginate(req.GetPageSize(), req.GetPageToken(), filter, func(afterId string, limit int) ([]handlers.PageItem, error) {
+		hl, err := s.listFromRepo(ctx, req.GetHostCatalogId(), afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		var items []handlers.PageItem
+		for _, item := range hl {
+			item.Scope = authResults.Scope
+			item.AuthorizedActions = authResults.FetchActionSetForId(ctx, item.Id, IdActions, auth.WithPin(item.GetHostCatalogId())).Strings()
+			items = append(items, item)
+		}
+		return items, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range hl {
-		item.Scope = authResults.Scope
+	var finalItems []*pb.HostSet
+	for _, item := range items {
+		finalItems = append(finalItems, item.(*pb.HostSet))
 	}
-	return &pbs.ListHostSetsResponse{Items: hl}, nil
+	return &pbs.ListHostSetsResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetHostSet implements the interface pbs.HostSetServiceServer.
@@ -79,6 +125,7 @@ func (s Service) GetHostSet(ctx context.Context, req *pbs.GetHostSetRequest) (*p
 		return nil, err
 	}
 	hc.Scope = authResults.Scope
+	hc.AuthorizedActions = authResults.FetchActionSetForId(ctx, hc.Id, IdActions, auth.WithPin(hc.GetHostCatalogId())).Strings()
 	return &pbs.GetHostSetResponse{Item: hc}, nil
 }
 
@@ -140,7 +187,7 @@ func (s Service) AddHostSetHosts(ctx context.Context, req *pbs.AddHostSetHostsRe
 	if err := validateAddRequest(req); err != nil {
 		return nil, err
 	}
-	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.AddHostSets)
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.AddHosts)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
@@ -157,7 +204,7 @@ func (s Service) SetHostSetHosts(ctx context.Context, req *pbs.SetHostSetHostsRe
 	if err := validateSetRequest(req); err != nil {
 		return nil, err
 	}
-	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.SetHostSets)
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.SetHosts)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
@@ -174,7 +221,7 @@ func (s Service) RemoveHostSetHosts(ctx context.Context, req *pbs.RemoveHostSetH
 	if err := validateRemoveRequest(req); err != nil {
 		return nil, err
 	}
-	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.RemoveHostSets)
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.RemoveHosts)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
@@ -187,6 +234,20 @@ func (s Service) RemoveHostSetHosts(ctx context.Context, req *pbs.RemoveHostSetH
 }
 
 func (s Service) getFromRepo(ctx context.Context, id string) (*pb.HostSet, error) {
+	if host.SubtypeFromId(id) == host.InventorySubtype {
+		repo, err := s.inventoryRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		h, m, err := repo.LookupSet(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if h == nil {
+			return nil, handlers.NotFoundErrorf("Host Set %q doesn't exist.", id)
+		}
+		return toInventoryProto(h, m)
+	}
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
@@ -202,6 +263,9 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.HostSet, error
 }
 
 func (s Service) createInRepo(ctx context.Context, scopeId, catalogId string, item *pb.HostSet) (*pb.HostSet, error) {
+	if host.SubtypeFromId(catalogId) == host.InventorySubtype {
+		return s.createInventoryInRepo(ctx, scopeId, catalogId, item)
+	}
 	var opts []static.Option
 	if item.GetName() != nil {
 		opts = append(opts, static.WithName(item.GetName().GetValue()))
@@ -232,6 +296,9 @@ func (s Service) createInRepo(ctx context.Context, scopeId, catalogId string, it
 }
 
 func (s Service) updateInRepo(ctx context.Context, scopeId, catalogId, id string, mask []string, item *pb.HostSet) (*pb.HostSet, error) {
+	if host.SubtypeFromId(id) == host.InventorySubtype {
+		return s.updateInventoryInRepo(ctx, scopeId, catalogId, id, mask, item)
+	}
 	var opts []static.Option
 	if desc := item.GetDescription(); desc != nil {
 		opts = append(opts, static.WithDescription(desc.GetValue()))
@@ -262,7 +329,95 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, catalogId, id string
 	return toProto(out, m), nil
 }
 
+func (s Service) createInventoryInRepo(ctx context.Context, scopeId, catalogId string, item *pb.HostSet) (*pb.HostSet, error) {
+	attrs := &pb.InventoryHostSetAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	opts := []inventory.Option{inventory.WithMatchLabels(attrs.GetMatchLabels())}
+	if item.GetName() != nil {
+		opts = append(opts, inventory.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, inventory.WithDescription(item.GetDescription().GetValue()))
+	}
+	h, err := inventory.NewHostSet(catalogId, opts...)
+	if err != nil {
+		if errors.Is(err, inventory.ErrInvalidLabels) {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"attributes.match_labels": err.Error()})
+		}
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build host set for creation: %v.", err)
+	}
+	repo, err := s.inventoryRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateSet(ctx, scopeId, h)
+	if err != nil {
+		if db.IsUniqueError(err) || errors.Is(err, db.ErrNotUnique) {
+			// Push this error through so the error interceptor can interpret it correctly.
+			return nil, err
+		}
+		return nil, fmt.Errorf("unable to create host set: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create host set but no error returned from repository.")
+	}
+	return toInventoryProto(out, nil)
+}
+
+func (s Service) updateInventoryInRepo(ctx context.Context, scopeId, catalogId, id string, mask []string, item *pb.HostSet) (*pb.HostSet, error) {
+	attrs := &pb.InventoryHostSetAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	var opts []inventory.Option
+	if desc := item.GetDescription(); desc != nil {
+		opts = append(opts, inventory.WithDescription(desc.GetValue()))
+	}
+	if name := item.GetName(); name != nil {
+		opts = append(opts, inventory.WithName(name.GetValue()))
+	}
+	h, err := inventory.NewHostSet(catalogId, opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build host set for update: %v.", err)
+	}
+	if err := h.SetMatchLabels(attrs.GetMatchLabels()); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"attributes.match_labels": err.Error()})
+	}
+	h.PublicId = id
+	dbMask := inventoryMaskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.inventoryRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, m, rowsUpdated, err := repo.UpdateSet(ctx, scopeId, h, item.GetVersion(), dbMask)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update host set: %w.", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Host Set %q doesn't exist or incorrect version provided.", id)
+	}
+	return toInventoryProto(out, m)
+}
+
 func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool, error) {
+	if host.SubtypeFromId(id) == host.InventorySubtype {
+		repo, err := s.inventoryRepoFn()
+		if err != nil {
+			return false, err
+		}
+		rows, err := repo.DeleteSet(ctx, scopeId, id)
+		if err != nil {
+			return false, fmt.Errorf("unable to delete host set: %w", err)
+		}
+		return rows > 0, nil
+	}
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return false, err
@@ -274,12 +429,31 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]*pb.HostSet, error) {
+func (s Service) listFromRepo(ctx context.Context, catalogId, afterId string, limit int) ([]*pb.HostSet, error) {
+	if host.SubtypeFromId(catalogId) == host.InventorySubtype {
+		repo, err := s.inventoryRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		hl, err := repo.ListSets(ctx, catalogId, inventory.WithLimit(limit), inventory.WithStartPageAfterId(afterId))
+		if err != nil {
+			return nil, err
+		}
+		var outH []*pb.HostSet
+		for _, h := range hl {
+			oh, err := toInventoryProto(h, nil)
+			if err != nil {
+				return nil, err
+			}
+			outH = append(outH, oh)
+		}
+		return outH, nil
+	}
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
 	}
-	hl, err := repo.ListSets(ctx, catalogId)
+	hl, err := repo.ListSets(ctx, catalogId, static.WithLimit(limit), static.WithStartPageAfterId(afterId))
 	if err != nil {
 		return nil, err
 	}
@@ -351,13 +525,8 @@ func (s Service) removeInRepo(ctx context.Context, scopeId, setId string, hostId
 	return toProto(out, m), nil
 }
 
-func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (*static.HostCatalog, auth.VerifyResults) {
+func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (hostCatalog, auth.VerifyResults) {
 	res := auth.VerifyResults{}
-	repo, err := s.staticRepoFn()
-	if err != nil {
-		res.Error = err
-		return nil, res
-	}
 
 	var parentId string
 	opts := []auth.Option{auth.WithType(resource.HostSet), auth.WithAction(a)}
@@ -365,20 +534,20 @@ func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Ty
 	case action.List, action.Create:
 		parentId = id
 	default:
-		set, _, err := repo.LookupSet(ctx, id)
+		catalogId, err := s.setCatalogId(ctx, id)
 		if err != nil {
 			res.Error = err
 			return nil, res
 		}
-		if set == nil {
+		if catalogId == "" {
 			res.Error = handlers.NotFoundError()
 			return nil, res
 		}
-		parentId = set.GetCatalogId()
+		parentId = catalogId
 		opts = append(opts, auth.WithId(id))
 	}
 
-	cat, err := repo.LookupCatalog(ctx, parentId)
+	cat, err := s.lookupCatalog(ctx, parentId)
 	if err != nil {
 		res.Error = err
 		return nil, res
@@ -391,6 +560,56 @@ func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Ty
 	return cat, auth.Verify(ctx, opts...)
 }
 
+// setCatalogId returns the catalog id of the host set for id or an empty
+// string if the host set does not exist.
+func (s Service) setCatalogId(ctx context.Context, id string) (string, error) {
+	if host.SubtypeFromId(id) == host.InventorySubtype {
+		repo, err := s.inventoryRepoFn()
+		if err != nil {
+			return "", err
+		}
+		set, _, err := repo.LookupSet(ctx, id)
+		if err != nil || set == nil {
+			return "", err
+		}
+		return set.GetCatalogId(), nil
+	}
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return "", err
+	}
+	set, _, err := repo.LookupSet(ctx, id)
+	if err != nil || set == nil {
+		return "", err
+	}
+	return set.GetCatalogId(), nil
+}
+
+// lookupCatalog returns the host catalog for id or nil if the host catalog
+// does not exist.
+func (s Service) lookupCatalog(ctx context.Context, id string) (hostCatalog, error) {
+	if host.SubtypeFromId(id) == host.InventorySubtype {
+		repo, err := s.inventoryRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		cat, err := repo.LookupCatalog(ctx, id)
+		if err != nil || cat == nil {
+			return nil, err
+		}
+		return cat, nil
+	}
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	cat, err := repo.LookupCatalog(ctx, id)
+	if err != nil || cat == nil {
+		return nil, err
+	}
+	return cat, nil
+}
+
 func toProto(in *static.HostSet, hs []*static.Host) *pb.HostSet {
 	out := pb.HostSet{
 		Id:            in.GetPublicId(),
@@ -412,6 +631,32 @@ func toProto(in *static.HostSet, hs []*static.Host) *pb.HostSet {
 	return &out
 }
 
+func toInventoryProto(in *inventory.HostSet, hs []*inventory.Host) (*pb.HostSet, error) {
+	out := pb.HostSet{
+		Id:            in.GetPublicId(),
+		HostCatalogId: in.GetCatalogId(),
+		Type:          host.InventorySubtype.String(),
+		CreatedTime:   in.GetCreateTime().GetTimestamp(),
+		UpdatedTime:   in.GetUpdateTime().GetTimestamp(),
+		Version:       in.GetVersion(),
+	}
+	if in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	for _, h := range hs {
+		out.HostIds = append(out.HostIds, h.GetPublicId())
+	}
+	st, err := handlers.ProtoToStruct(&pb.InventoryHostSetAttributes{MatchLabels: in.MatchLabelMap()})
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building inventory attribute struct: %v", err)
+	}
+	out.Attributes = st
+	return &out, nil
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //  * The path passed in is correctly formatted
@@ -420,13 +665,13 @@ func toProto(in *static.HostSet, hs []*static.Host) *pb.HostSet {
 //  * The type asserted by the ID and/or field is known
 //  * If relevant, the type derived from the id prefix matches what is claimed by the type field
 func validateGetRequest(req *pbs.GetHostSetRequest) error {
-	return handlers.ValidateGetRequest(static.HostSetPrefix, req, handlers.NoopValidatorFn)
+	return handlers.ValidateGetRequest(setPrefixFromId(req.GetId()), req, handlers.NoopValidatorFn)
 }
 
 func validateCreateRequest(req *pbs.CreateHostSetRequest) error {
 	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
-		if !handlers.ValidId(static.HostCatalogPrefix, req.GetItem().GetHostCatalogId()) {
+		if !handlers.ValidId(catalogPrefixFromId(req.GetItem().GetHostCatalogId()), req.GetItem().GetHostCatalogId()) {
 			badFields["host_catalog_id"] = "The field is incorrectly formatted."
 		}
 		switch host.SubtypeFromId(req.GetItem().GetHostCatalogId()) {
@@ -434,33 +679,52 @@ func validateCreateRequest(req *pbs.CreateHostSetRequest) error {
 			if req.GetItem().GetType() != "" && req.GetItem().GetType() != host.StaticSubtype.String() {
 				badFields["type"] = "Doesn't match the parent resource's type."
 			}
+		case host.InventorySubtype:
+			if req.GetItem().GetType() != "" && req.GetItem().GetType() != host.InventorySubtype.String() {
+				badFields["type"] = "Doesn't match the parent resource's type."
+			}
+			if len(req.GetItem().GetHostIds()) > 0 {
+				badFields["host_ids"] = "Hosts of an inventory host set are determined by its match labels."
+			}
+			validateInventoryAttributes(req.GetItem().GetAttributes(), badFields)
 		}
 		return badFields
 	})
 }
 
 func validateUpdateRequest(req *pbs.UpdateHostSetRequest) error {
-	return handlers.ValidateUpdateRequest(static.HostSetPrefix, req, req.GetItem(), func() map[string]string {
+	return handlers.ValidateUpdateRequest(setPrefixFromId(req.GetId()), req, req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
 		switch host.SubtypeFromId(req.GetId()) {
 		case host.StaticSubtype:
 			if req.GetItem().GetType() != "" && req.GetItem().GetType() != host.StaticSubtype.String() {
 				badFields["type"] = "Cannot modify the resource type."
 			}
+		case host.InventorySubtype:
+			if req.GetItem().GetType() != "" && req.GetItem().GetType() != host.InventorySubtype.String() {
+				badFields["type"] = "Cannot modify the resource type."
+			}
+			validateInventoryAttributes(req.GetItem().GetAttributes(), badFields)
 		}
 		return badFields
 	})
 }
 
 func validateDeleteRequest(req *pbs.DeleteHostSetRequest) error {
-	return handlers.ValidateDeleteRequest(static.HostSetPrefix, req, handlers.NoopValidatorFn)
+	return handlers.ValidateDeleteRequest(setPrefixFromId(req.GetId()), req, handlers.NoopValidatorFn)
 }
 
 func validateListRequest(req *pbs.ListHostSetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(static.HostCatalogPrefix, req.GetHostCatalogId()) {
+	if !handlers.ValidId(catalogPrefixFromId(req.GetHostCatalogId()), req.GetHostCatalogId()) {
 		badFields["host_catalog_id"] = "The field is incorrectly formatted."
 	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if _, err := handlers.DecodePageToken(req.GetPageToken()); err != nil {
+		badFields["page_token"] = "This is not a valid page token."
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
 	}
@@ -469,7 +733,10 @@ func validateListRequest(req *pbs.ListHostSetsRequest) error {
 
 func validateAddRequest(req *pbs.AddHostSetHostsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(static.HostSetPrefix, req.GetId()) {
+	switch {
+	case handlers.ValidId(inventory.HostSetPrefix, req.GetId()):
+		badFields["id"] = "Hosts of an inventory host set are determined by its match labels."
+	case !handlers.ValidId(static.HostSetPrefix, req.GetId()):
 		badFields["id"] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -492,7 +759,10 @@ func validateAddRequest(req *pbs.AddHostSetHostsRequest) error {
 
 func validateSetRequest(req *pbs.SetHostSetHostsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(static.HostSetPrefix, req.GetId()) {
+	switch {
+	case handlers.ValidId(inventory.HostSetPrefix, req.GetId()):
+		badFields["id"] = "Hosts of an inventory host set are determined by its match labels."
+	case !handlers.ValidId(static.HostSetPrefix, req.GetId()):
 		badFields["id"] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -512,7 +782,10 @@ func validateSetRequest(req *pbs.SetHostSetHostsRequest) error {
 
 func validateRemoveRequest(req *pbs.RemoveHostSetHostsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(static.HostSetPrefix, req.GetId()) {
+	switch {
+	case handlers.ValidId(inventory.HostSetPrefix, req.GetId()):
+		badFields["id"] = "Hosts of an inventory host set are determined by its match labels."
+	case !handlers.ValidId(static.HostSetPrefix, req.GetId()):
 		badFields["id"] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -532,3 +805,31 @@ func validateRemoveRequest(req *pbs.RemoveHostSetHostsRequest) error {
 	}
 	return nil
 }
+
+func validateInventoryAttributes(attributes *structpb.Struct, badFields map[string]string) {
+	attrs := &pb.InventoryHostSetAttributes{}
+	if err := handlers.StructToProto(attributes, attrs); err != nil {
+		badFields["attributes"] = "Attribute fields do not match the expected format."
+		return
+	}
+	for k := range attrs.GetMatchLabels() {
+		if k == "" {
+			badFields["attributes.match_labels"] = "Label keys must not be empty."
+			return
+		}
+	}
+}
+
+func catalogPrefixFromId(id string) string {
+	if host.SubtypeFromId(id) == host.InventorySubtype {
+		return inventory.HostCatalogPrefix
+	}
+	return static.HostCatalogPrefix
+}
+
+func setPrefixFromId(id string) string {
+	if host.SubtypeFromId(id) == host.InventorySubtype {
+		return inventory.HostSetPrefix
+	}
+	return static.HostSetPrefix
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/host/inventory"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
@@ -29,6 +31,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func TestGet(t *testing.T) {
@@ -43,6 +46,9 @@ func TestGet(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	invRepoFn := func() (*inventory.Repository, error) {
+		return inventory.NewRepository(rw, rw, kms)
+	}
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
 
@@ -53,13 +59,14 @@ func TestGet(t *testing.T) {
 	toMerge := &pbs.GetHostSetRequest{}
 
 	pHost := &pb.HostSet{
-		HostCatalogId: hc.GetPublicId(),
-		Id:            hs.GetPublicId(),
-		CreatedTime:   hs.CreateTime.GetTimestamp(),
-		UpdatedTime:   hs.UpdateTime.GetTimestamp(),
-		Scope:         &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
-		Type:          "static",
-		HostIds:       hIds,
+		HostCatalogId:     hc.GetPublicId(),
+		Id:                hs.GetPublicId(),
+		CreatedTime:       hs.CreateTime.GetTimestamp(),
+		UpdatedTime:       hs.UpdateTime.GetTimestamp(),
+		Scope:             &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+		Type:              "static",
+		HostIds:           hIds,
+		AuthorizedActions: host_sets.IdActions.Strings(),
 	}
 
 	cases := []struct {
@@ -98,7 +105,7 @@ func TestGet(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetHostSetRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := host_sets.NewService(repoFn)
+			s, err := host_sets.NewService(repoFn, invRepoFn)
 			require.NoError(err, "Couldn't create a new host set service.")
 
 			got, gErr := s.GetHostSet(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), req)
@@ -126,22 +133,28 @@ func TestList(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	invRepoFn := func() (*inventory.Repository, error) {
+		return inventory.NewRepository(rw, rw, kms)
+	}
 	hcs := static.TestCatalogs(t, conn, proj.GetPublicId(), 2)
 	hc, hcNoHosts := hcs[0], hcs[1]
 
 	var wantHs []*pb.HostSet
 	for _, h := range static.TestSets(t, conn, hc.GetPublicId(), 10) {
 		wantHs = append(wantHs, &pb.HostSet{
-			Id:            h.GetPublicId(),
-			HostCatalogId: h.GetCatalogId(),
-			Scope:         &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
-			CreatedTime:   h.GetCreateTime().GetTimestamp(),
-			UpdatedTime:   h.GetUpdateTime().GetTimestamp(),
-			Version:       h.GetVersion(),
-			Type:          host.StaticSubtype.String(),
+			Id:                h.GetPublicId(),
+			HostCatalogId:     h.GetCatalogId(),
+			Scope:             &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+			CreatedTime:       h.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       h.GetUpdateTime().GetTimestamp(),
+			Version:           h.GetVersion(),
+			Type:              host.StaticSubtype.String(),
+			AuthorizedActions: host_sets.IdActions.Strings(),
 		})
 	}
 
+	sort.Slice(wantHs, func(i, j int) bool { return wantHs[i].GetId() < wantHs[j].GetId() })
+
 	cases := []struct {
 		name          string
 		hostCatalogId string
@@ -162,7 +175,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := host_sets.NewService(repoFn)
+			s, err := host_sets.NewService(repoFn, invRepoFn)
 			require.NoError(err, "Couldn't create new host set service.")
 
 			got, gErr := s.ListHostSets(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), &pbs.ListHostSetsRequest{HostCatalogId: tc.hostCatalogId})
@@ -187,10 +200,13 @@ func TestDelete(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	invRepoFn := func() (*inventory.Repository, error) {
+		return inventory.NewRepository(rw, rw, kms)
+	}
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 	h := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
 
-	s, err := host_sets.NewService(repoFn)
+	s, err := host_sets.NewService(repoFn, invRepoFn)
 	require.NoError(t, err, "Couldn't create a new host set service.")
 
 	cases := []struct {
@@ -259,10 +275,13 @@ func TestDelete_twice(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	invRepoFn := func() (*inventory.Repository, error) {
+		return inventory.NewRepository(rw, rw, kms)
+	}
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 	h := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
 
-	s, err := host_sets.NewService(repoFn)
+	s, err := host_sets.NewService(repoFn, invRepoFn)
 	require.NoError(err, "Couldn't create a new host set service.")
 	req := &pbs.DeleteHostSetRequest{
 		Id: h.GetPublicId(),
@@ -287,6 +306,9 @@ func TestCreate(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	invRepoFn := func() (*inventory.Repository, error) {
+		return inventory.NewRepository(rw, rw, kms)
+	}
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 
 	defaultHcCreated, err := ptypes.Timestamp(hc.GetCreateTime().GetTimestamp())
@@ -377,7 +399,7 @@ func TestCreate(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := host_sets.NewService(repoFn)
+			s, err := host_sets.NewService(repoFn, invRepoFn)
 			require.NoError(err, "Failed to create a new host set service.")
 
 			got, gErr := s.CreateHostSet(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), tc.req)
@@ -421,6 +443,9 @@ func TestUpdate(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	invRepoFn := func() (*inventory.Repository, error) {
+		return inventory.NewRepository(rw, rw, kms)
+	}
 	repo, err := repoFn()
 	require.NoError(t, err, "Couldn't create new static repo.")
 
@@ -451,7 +476,7 @@ func TestUpdate(t *testing.T) {
 		Id: hs.GetPublicId(),
 	}
 
-	tested, err := host_sets.NewService(repoFn)
+	tested, err := host_sets.NewService(repoFn, invRepoFn)
 	require.NoError(t, err, "Failed to create a new host set service.")
 
 	cases := []struct {
@@ -776,7 +801,10 @@ func TestAddHostSetHosts(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
-	s, err := host_sets.NewService(repoFn)
+	invRepoFn := func() (*inventory.Repository, error) {
+		return inventory.NewRepository(rw, rw, kms)
+	}
+	s, err := host_sets.NewService(repoFn, invRepoFn)
 	require.NoError(t, err, "Error when getting new host set service.")
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
@@ -888,7 +916,10 @@ func TestSetHostSetHosts(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
-	s, err := host_sets.NewService(repoFn)
+	invRepoFn := func() (*inventory.Repository, error) {
+		return inventory.NewRepository(rw, rw, kms)
+	}
+	s, err := host_sets.NewService(repoFn, invRepoFn)
 	require.NoError(t, err, "Error when getting new host set service.")
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
@@ -996,7 +1027,10 @@ func TestRemoveHostSetHosts(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
-	s, err := host_sets.NewService(repoFn)
+	invRepoFn := func() (*inventory.Repository, error) {
+		return inventory.NewRepository(rw, rw, kms)
+	}
+	s, err := host_sets.NewService(repoFn, invRepoFn)
 	require.NoError(t, err, "Error when getting new host set service.")
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
@@ -1110,3 +1144,63 @@ func TestRemoveHostSetHosts(t *testing.T) {
 		})
 	}
 }
+
+func TestCreate_Inventory(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	invRepoFn := func() (*inventory.Repository, error) {
+		return inventory.NewRepository(rw, rw, kms)
+	}
+	hc := inventory.TestCatalog(t, conn, proj.GetPublicId(), "/etc/boundary/hosts.json")
+	web := inventory.TestHost(t, conn, hc.GetPublicId(), "web-1", "10.0.0.1", map[string]string{"role": "web"})
+	inventory.TestHost(t, conn, hc.GetPublicId(), "db-1", "10.0.0.2", map[string]string{"role": "db"})
+
+	s, err := host_sets.NewService(repoFn, invRepoFn)
+	require.NoError(t, err, "Failed to create a new host set service.")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+
+	attrs := func(m map[string]interface{}) *structpb.Struct {
+		st, err := structpb.NewStruct(m)
+		require.NoError(t, err)
+		return st
+	}
+
+	assert, require := assert.New(t), require.New(t)
+	got, err := s.CreateHostSet(ctx, &pbs.CreateHostSetRequest{Item: &pb.HostSet{
+		HostCatalogId: hc.GetPublicId(),
+		Type:          "inventory",
+		Attributes: attrs(map[string]interface{}{
+			"match_labels": map[string]interface{}{"role": "web"},
+		}),
+	}})
+	require.NoError(err)
+	assert.True(strings.HasPrefix(got.GetItem().GetId(), inventory.HostSetPrefix+"_"))
+	assert.Equal("inventory", got.GetItem().GetType())
+
+	read, err := s.GetHostSet(ctx, &pbs.GetHostSetRequest{Id: got.GetItem().GetId()})
+	require.NoError(err)
+	assert.Equal([]string{web.GetPublicId()}, read.GetItem().GetHostIds())
+	assert.Equal("web", read.GetItem().GetAttributes().GetFields()["match_labels"].GetStructValue().GetFields()["role"].GetStringValue())
+
+	_, err = s.AddHostSetHosts(ctx, &pbs.AddHostSetHostsRequest{
+		Id:      got.GetItem().GetId(),
+		Version: got.GetItem().GetVersion(),
+		HostIds: []string{web.GetPublicId()},
+	})
+	assert.Truef(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+
+	_, err = s.CreateHostSet(ctx, &pbs.CreateHostSetRequest{Item: &pb.HostSet{
+		HostCatalogId: hc.GetPublicId(),
+		Type:          "static",
+	}})
+	assert.Truef(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+}
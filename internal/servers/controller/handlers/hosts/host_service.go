@@ -12,6 +12,7 @@ import (
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/hosts"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/host/inventory"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/host/static/store"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
@@ -33,19 +34,38 @@ func init() {
 	}
 }
 
+// hostCatalog is the part of a host catalog of any subtype needed to
+// authorize requests for its hosts.
+type hostCatalog interface {
+	GetPublicId() string
+	GetScopeId() string
+}
+
+// IdActions contains the set of actions that can be performed on
+// individual resources
+var IdActions = action.ActionSet{
+	action.Read,
+	action.Update,
+	action.Delete,
+}
+
 type Service struct {
-	staticRepoFn common.StaticRepoFactory
+	staticRepoFn    common.StaticRepoFactory
+	inventoryRepoFn common.InventoryRepoFactory
 }
 
 var _ pbs.HostServiceServer = Service{}
 
 // NewService returns a host Service which handles host related requests to boundary and uses the provided
 // repositories for storage and retrieval.
-func NewService(repoFn common.StaticRepoFactory) (Service, error) {
+func NewService(repoFn common.StaticRepoFactory, inventoryRepoFn common.InventoryRepoFactory) (Service, error) {
 	if repoFn == nil {
 		return Service{}, fmt.Errorf("nil static repository provided")
 	}
-	return Service{staticRepoFn: repoFn}, nil
+	if inventoryRepoFn == nil {
+		return Service{}, fmt.Errorf("nil inventory repository provided")
+	}
+	return Service{staticRepoFn: repoFn, inventoryRepoFn: inventoryRepoFn}, nil
 }
 
 func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs.ListHostsResponse, error) {
@@ -56,14 +76,31 @@ func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hl, err := s.listFromRepo(ctx, req.GetHostCatalogId())
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	items, nextPageToken, err := handlers.Paginate(req.GetPageSize(), req.GetPageToken(), filter, func(afterId string, limit int) ([]handlers.PageItem, error) {
+		hl, err := s.listFromRepo(ctx, req.GetHostCatalogId(), afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		var items []handlers.PageItem
+		for _, item := range hl {
+			item.Scope = authResults.Scope
+			item.AuthorizedActions = authResults.FetchActionSetForId(ctx, item.Id, IdActions, auth.WithPin(item.GetHostCatalogId())).Strings()
+			items = append(items, item)
+		}
+		return items, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range hl {
-		item.Scope = authResults.Scope
+	var finalItems []*pb.Host
+	for _, item := range items {
+		finalItems = append(finalItems, item.(*pb.Host))
 	}
-	return &pbs.ListHostsResponse{Items: hl}, nil
+	return &pbs.ListHostsResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetHost implements the interface pbs.HostServiceServer.
@@ -80,6 +117,7 @@ func (s Service) GetHost(ctx context.Context, req *pbs.GetHostRequest) (*pbs.Get
 		return nil, err
 	}
 	hc.Scope = authResults.Scope
+	hc.AuthorizedActions = authResults.FetchActionSetForId(ctx, hc.Id, IdActions, auth.WithPin(hc.GetHostCatalogId())).Strings()
 	return &pbs.GetHostResponse{Item: hc}, nil
 }
 
@@ -137,6 +175,20 @@ func (s Service) DeleteHost(ctx context.Context, req *pbs.DeleteHostRequest) (*p
 }
 
 func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Host, error) {
+	if host.SubtypeFromId(id) == host.InventorySubtype {
+		repo, err := s.inventoryRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		h, err := repo.LookupHost(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if h == nil {
+			return nil, handlers.NotFoundErrorf("Host %q doesn't exist.", id)
+		}
+		return toInventoryProto(h)
+	}
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
@@ -239,12 +291,31 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]*pb.Host, error) {
+func (s Service) listFromRepo(ctx context.Context, catalogId, afterId string, limit int) ([]*pb.Host, error) {
+	if host.SubtypeFromId(catalogId) == host.InventorySubtype {
+		repo, err := s.inventoryRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		hl, err := repo.ListHosts(ctx, catalogId, inventory.WithLimit(limit), inventory.WithStartPageAfterId(afterId))
+		if err != nil {
+			return nil, err
+		}
+		var outHl []*pb.Host
+		for _, h := range hl {
+			p, err := toInventoryProto(h)
+			if err != nil {
+				return nil, err
+			}
+			outHl = append(outHl, p)
+		}
+		return outHl, nil
+	}
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
 	}
-	hl, err := repo.ListHosts(ctx, catalogId)
+	hl, err := repo.ListHosts(ctx, catalogId, static.WithLimit(limit), static.WithStartPageAfterId(afterId))
 	if err != nil {
 		return nil, err
 	}
@@ -259,13 +330,8 @@ func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]*pb.Host
 	return outHl, nil
 }
 
-func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (*static.HostCatalog, auth.VerifyResults) {
+func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (hostCatalog, auth.VerifyResults) {
 	res := auth.VerifyResults{}
-	repo, err := s.staticRepoFn()
-	if err != nil {
-		res.Error = err
-		return nil, res
-	}
 
 	var parentId string
 	opts := []auth.Option{auth.WithType(resource.Host), auth.WithAction(a)}
@@ -273,20 +339,20 @@ func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Ty
 	case action.List, action.Create:
 		parentId = id
 	default:
-		h, err := repo.LookupHost(ctx, id)
+		catalogId, err := s.hostCatalogId(ctx, id)
 		if err != nil {
 			res.Error = err
 			return nil, res
 		}
-		if h == nil {
+		if catalogId == "" {
 			res.Error = handlers.NotFoundError()
 			return nil, res
 		}
-		parentId = h.GetCatalogId()
+		parentId = catalogId
 		opts = append(opts, auth.WithId(id))
 	}
 
-	cat, err := repo.LookupCatalog(ctx, parentId)
+	cat, err := s.lookupCatalog(ctx, parentId)
 	if err != nil {
 		res.Error = err
 		return nil, res
@@ -299,6 +365,56 @@ func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Ty
 	return cat, auth.Verify(ctx, opts...)
 }
 
+// hostCatalogId returns the catalog id of the host for id or an empty
+// string if the host does not exist.
+func (s Service) hostCatalogId(ctx context.Context, id string) (string, error) {
+	if host.SubtypeFromId(id) == host.InventorySubtype {
+		repo, err := s.inventoryRepoFn()
+		if err != nil {
+			return "", err
+		}
+		h, err := repo.LookupHost(ctx, id)
+		if err != nil || h == nil {
+			return "", err
+		}
+		return h.GetCatalogId(), nil
+	}
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return "", err
+	}
+	h, err := repo.LookupHost(ctx, id)
+	if err != nil || h == nil {
+		return "", err
+	}
+	return h.GetCatalogId(), nil
+}
+
+// lookupCatalog returns the host catalog for id or nil if the host catalog
+// does not exist.
+func (s Service) lookupCatalog(ctx context.Context, id string) (hostCatalog, error) {
+	if host.SubtypeFromId(id) == host.InventorySubtype {
+		repo, err := s.inventoryRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		cat, err := repo.LookupCatalog(ctx, id)
+		if err != nil || cat == nil {
+			return nil, err
+		}
+		return cat, nil
+	}
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	cat, err := repo.LookupCatalog(ctx, id)
+	if err != nil || cat == nil {
+		return nil, err
+	}
+	return cat, nil
+}
+
 func toProto(in *static.Host, members []*static.HostSet) (*pb.Host, error) {
 	out := pb.Host{
 		Id:            in.GetPublicId(),
@@ -325,6 +441,33 @@ func toProto(in *static.Host, members []*static.HostSet) (*pb.Host, error) {
 	return &out, nil
 }
 
+func toInventoryProto(in *inventory.Host) (*pb.Host, error) {
+	out := pb.Host{
+		Id:            in.GetPublicId(),
+		HostCatalogId: in.GetCatalogId(),
+		Type:          host.InventorySubtype.String(),
+		CreatedTime:   in.GetCreateTime().GetTimestamp(),
+		UpdatedTime:   in.GetUpdateTime().GetTimestamp(),
+		Version:       in.GetVersion(),
+	}
+	if in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	st, err := handlers.ProtoToStruct(&pb.InventoryHostAttributes{
+		ExternalId: in.GetExternalId(),
+		Address:    in.GetAddress(),
+		Labels:     in.LabelMap(),
+	})
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to convert inventory attribute to struct: %s", err)
+	}
+	out.Attributes = st
+	return &out, nil
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //  * The path passed in is correctly formatted
@@ -333,7 +476,7 @@ func toProto(in *static.Host, members []*static.HostSet) (*pb.Host, error) {
 //  * The type asserted by the ID and/or field is known
 //  * If relevant, the type derived from the id prefix matches what is claimed by the type field
 func validateGetRequest(req *pbs.GetHostRequest) error {
-	return handlers.ValidateGetRequest(static.HostPrefix, req, func() map[string]string {
+	return handlers.ValidateGetRequest(hostPrefixFromId(req.GetId()), req, func() map[string]string {
 		badFields := map[string]string{}
 		ct := host.SubtypeFromId(req.GetId())
 		if ct == host.UnknownSubtype {
@@ -346,10 +489,12 @@ func validateGetRequest(req *pbs.GetHostRequest) error {
 func validateCreateRequest(req *pbs.CreateHostRequest) error {
 	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
-		if !handlers.ValidId(static.HostCatalogPrefix, req.GetItem().GetHostCatalogId()) {
+		if !handlers.ValidId(catalogPrefixFromId(req.GetItem().GetHostCatalogId()), req.GetItem().GetHostCatalogId()) {
 			badFields["host_catalog_id"] = "The field is incorrectly formatted."
 		}
 		switch host.SubtypeFromId(req.GetItem().GetHostCatalogId()) {
+		case host.InventorySubtype:
+			badFields["host_catalog_id"] = "Hosts of an inventory host catalog are managed by its source."
 		case host.StaticSubtype:
 			if req.GetItem().GetType() != "" && req.GetItem().GetType() != host.StaticSubtype.String() {
 				badFields["type"] = "Doesn't match the parent resource's type."
@@ -378,9 +523,11 @@ func validateCreateRequest(req *pbs.CreateHostRequest) error {
 }
 
 func validateUpdateRequest(req *pbs.UpdateHostRequest) error {
-	return handlers.ValidateUpdateRequest(static.HostPrefix, req, req.GetItem(), func() map[string]string {
+	return handlers.ValidateUpdateRequest(hostPrefixFromId(req.GetId()), req, req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
 		switch host.SubtypeFromId(req.GetId()) {
+		case host.InventorySubtype:
+			badFields["id"] = "Hosts of an inventory host catalog are managed by its source."
 		case host.StaticSubtype:
 			if req.GetItem().GetType() != "" && req.GetItem().GetType() != host.StaticSubtype.String() {
 				badFields["type"] = "Cannot modify the resource type."
@@ -406,16 +553,42 @@ func validateUpdateRequest(req *pbs.UpdateHostRequest) error {
 }
 
 func validateDeleteRequest(req *pbs.DeleteHostRequest) error {
-	return handlers.ValidateDeleteRequest(static.HostPrefix, req, handlers.NoopValidatorFn)
+	return handlers.ValidateDeleteRequest(hostPrefixFromId(req.GetId()), req, func() map[string]string {
+		badFields := map[string]string{}
+		if host.SubtypeFromId(req.GetId()) == host.InventorySubtype {
+			badFields["id"] = "Hosts of an inventory host catalog are managed by its source."
+		}
+		return badFields
+	})
 }
 
 func validateListRequest(req *pbs.ListHostsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(static.HostCatalogPrefix, req.GetHostCatalogId()) {
+	if !handlers.ValidId(catalogPrefixFromId(req.GetHostCatalogId()), req.GetHostCatalogId()) {
 		badFields["host_catalog_id"] = "The field is incorrectly formatted."
 	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if _, err := handlers.DecodePageToken(req.GetPageToken()); err != nil {
+		badFields["page_token"] = "This is not a valid page token."
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
 	}
 	return nil
 }
+
+func catalogPrefixFromId(id string) string {
+	if host.SubtypeFromId(id) == host.InventorySubtype {
+		return inventory.HostCatalogPrefix
+	}
+	return static.HostCatalogPrefix
+}
+
+func hostPrefixFromId(id string) string {
+	if host.SubtypeFromId(id) == host.InventorySubtype {
+		return inventory.HostPrefix
+	}
+	return static.HostPrefix
+}
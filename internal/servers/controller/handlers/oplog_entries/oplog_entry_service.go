@@ -0,0 +1,367 @@
+package oplog_entries
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/oplogentries"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/history"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// operations maps the operations of the API to the operation types of the
+// oplog.
+var operations = map[string]oplog.OpType{
+	"create": oplog.OpType_OP_TYPE_CREATE,
+	"update": oplog.OpType_OP_TYPE_UPDATE,
+	"delete": oplog.OpType_OP_TYPE_DELETE,
+}
+
+const (
+	// defaultWaitSeconds and maxWaitSeconds bound the time a watch request
+	// waits for new entries.
+	defaultWaitSeconds = 30
+	maxWaitSeconds     = 60
+)
+
+// watchPollInterval is the time between two reads of the oplog of a watch
+// request waiting for new entries.
+var watchPollInterval = time.Second
+
+// Service handles request as described by the pbs.OplogEntryServiceServer interface.
+type Service struct {
+	repoFn    common.HistoryRepoFactory
+	iamRepoFn common.IamRepoFactory
+}
+
+// NewService returns an oplog entry service which handles oplog entry related requests to boundary.
+func NewService(repoFn common.HistoryRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+	if repoFn == nil {
+		return Service{}, fmt.Errorf("nil history repository provided")
+	}
+	if iamRepoFn == nil {
+		return Service{}, fmt.Errorf("nil iam repository provided")
+	}
+	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn}, nil
+}
+
+var _ pbs.OplogEntryServiceServer = Service{}
+
+// ListOplogEntries implements the interface pbs.OplogEntryServiceServer.
+func (s Service) ListOplogEntries(ctx context.Context, req *pbs.ListOplogEntriesRequest) (*pbs.ListOplogEntriesResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	scopeResults, err := s.listAuthResults(ctx, req.GetScopeId(), req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	scopeInfos := make(map[string]*scopes.ScopeInfo, len(scopeResults))
+	scopeIds := make([]string, 0, len(scopeResults))
+	for _, authResults := range scopeResults {
+		scopeInfos[authResults.Scope.GetId()] = authResults.Scope
+		scopeIds = append(scopeIds, authResults.Scope.GetId())
+	}
+
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	afterId, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	size := int(req.GetPageSize())
+	if size == 0 || size > handlers.DefaultPageSize {
+		size = handlers.DefaultPageSize
+	}
+	opts := []history.Option{
+		history.WithAggregateNames(req.GetAggregateNames()...),
+		// Asking for one more item than the page holds tells us whether
+		// there is anything left to list after this batch.
+		history.WithLimit(size + 1),
+	}
+	if req.GetStartTime() != nil {
+		opts = append(opts, history.WithStartTime(req.GetStartTime().AsTime()))
+	}
+	if req.GetEndTime() != nil {
+		opts = append(opts, history.WithEndTime(req.GetEndTime().AsTime()))
+	}
+	opts = append(opts, history.WithOpTypes(opTypes(req.GetOperations())...))
+
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	// Entry ids are numbers, which handlers.Paginate would order as strings,
+	// so the entries are paginated here in the same way.
+	var finalItems []*pb.OplogEntry
+	for {
+		entries, err := repo.ListEntries(ctx, scopeIds, append(opts, history.WithStartPageAfterId(afterId))...)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			item, err := toProto(e)
+			if err != nil {
+				return nil, err
+			}
+			item.Scope = scopeInfos[e.ScopeId]
+			if !filter.Match(item) {
+				continue
+			}
+			if len(finalItems) == size {
+				nextPageToken := handlers.EncodePageToken(finalItems[len(finalItems)-1].GetId())
+				return &pbs.ListOplogEntriesResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
+			}
+			finalItems = append(finalItems, item)
+		}
+		if len(entries) <= size {
+			break
+		}
+		afterId = entries[len(entries)-1].Id
+	}
+	return &pbs.ListOplogEntriesResponse{Items: finalItems}, nil
+}
+
+// WatchOplogEntries implements the interface pbs.OplogEntryServiceServer.
+func (s Service) WatchOplogEntries(ctx context.Context, req *pbs.WatchOplogEntriesRequest) (*pbs.WatchOplogEntriesResponse, error) {
+	if err := validateWatchRequest(req); err != nil {
+		return nil, err
+	}
+	scopeResults, err := s.listAuthResults(ctx, req.GetScopeId(), req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	scopeInfos := make(map[string]*scopes.ScopeInfo, len(scopeResults))
+	scopeIds := make([]string, 0, len(scopeResults))
+	for _, authResults := range scopeResults {
+		scopeInfos[authResults.Scope.GetId()] = authResults.Scope
+		scopeIds = append(scopeIds, authResults.Scope.GetId())
+	}
+
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	size := int(req.GetPageSize())
+	if size == 0 || size > handlers.DefaultPageSize {
+		size = handlers.DefaultPageSize
+	}
+	wait := time.Duration(req.GetWaitSeconds()) * time.Second
+	if wait == 0 {
+		wait = defaultWaitSeconds * time.Second
+	}
+	opts := []history.Option{
+		history.WithAggregateNames(req.GetAggregateNames()...),
+		history.WithOpTypes(opTypes(req.GetOperations())...),
+		history.WithLimit(size),
+	}
+
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	var cursor *history.Cursor
+	if req.GetCursor() == "" {
+		if cursor, err = repo.CurrentCursor(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		if cursor, err = history.ParseCursor(req.GetCursor()); err != nil {
+			return nil, err
+		}
+	}
+
+	deadline := time.Now().Add(wait)
+	for {
+		entries, next, err := repo.ListChanges(ctx, scopeIds, cursor, opts...)
+		if err != nil {
+			return nil, err
+		}
+		var items []*pb.OplogEntry
+		for _, e := range entries {
+			item, err := toProto(e)
+			if err != nil {
+				return nil, err
+			}
+			item.Scope = scopeInfos[e.ScopeId]
+			if filter.Match(item) {
+				items = append(items, item)
+			}
+		}
+		moved := next.String() != cursor.String()
+		cursor = next
+		if len(items) > 0 || !time.Now().Before(deadline) {
+			return &pbs.WatchOplogEntriesResponse{Items: items, Cursor: cursor.String()}, nil
+		}
+		if moved {
+			// Everything read was filtered out; there may be more to read.
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(watchPollInterval):
+		}
+	}
+}
+
+func (s Service) authResult(ctx context.Context, scopeId string) auth.VerifyResults {
+	res := auth.VerifyResults{}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		res.Error = err
+		return res
+	}
+	scp, err := iamRepo.LookupScope(ctx, scopeId)
+	if err != nil {
+		res.Error = err
+		return res
+	}
+	if scp == nil {
+		res.Error = handlers.NotFoundError()
+		return res
+	}
+	return auth.Verify(ctx, auth.WithType(resource.OplogEntry), auth.WithAction(action.List), auth.WithScopeId(scopeId))
+}
+
+// listAuthResults authorizes listing in the provided scope or, when recursive,
+// separately in it and every scope beneath it. It returns the results for the
+// scopes in which listing is allowed.
+func (s Service) listAuthResults(ctx context.Context, scopeId string, recursive bool) ([]auth.VerifyResults, error) {
+	if recursive {
+		return auth.VerifyRecursive(ctx, s.iamRepoFn, auth.WithType(resource.OplogEntry), auth.WithAction(action.List), auth.WithScopeId(scopeId))
+	}
+	authResults := s.authResult(ctx, scopeId)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	return []auth.VerifyResults{authResults}, nil
+}
+
+// opTypes returns the operation types of the oplog of the operations of a
+// request, which have been validated.
+func opTypes(ops []string) []oplog.OpType {
+	var opTypes []oplog.OpType
+	for _, op := range ops {
+		opTypes = append(opTypes, operations[op])
+	}
+	return opTypes
+}
+
+// decodePageToken returns the id of the entry after which the page of the
+// token starts.
+func decodePageToken(token string) (uint32, error) {
+	id, err := handlers.DecodePageToken(token)
+	if err != nil || id == "" {
+		return 0, err
+	}
+	afterId, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid page token")
+	}
+	return uint32(afterId), nil
+}
+
+func toProto(in *history.Entry) (*pb.OplogEntry, error) {
+	out := pb.OplogEntry{
+		Id:            strconv.FormatUint(uint64(in.Id), 10),
+		ScopeId:       in.ScopeId,
+		AggregateName: in.AggregateName,
+		ResourceId:    in.ResourcePublicId(),
+		ResourceType:  in.ResourceType(),
+		CreatedTime:   timestamppb.New(in.CreateTime),
+	}
+	for _, m := range in.Messages {
+		value, err := handlers.ProtoToStruct(m.Value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert oplog entry %d: %w", in.Id, err)
+		}
+		msg := &pb.OplogMessage{
+			TypeName:       m.TypeName,
+			FieldMaskPaths: m.FieldMaskPaths,
+			SetToNullPaths: m.SetToNullPaths,
+			Value:          value,
+		}
+		for op, opType := range operations {
+			if m.OpType == opType {
+				msg.Operation = op
+			}
+		}
+		out.Messages = append(out.Messages, msg)
+	}
+	return &out, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//  * The path passed in is correctly formatted
+//  * All required parameters are set
+//  * There are no conflicting parameters provided
+func validateListRequest(req *pbs.ListOplogEntriesRequest) error {
+	badFields := map[string]string{}
+	if req.GetScopeId() != scope.Global.String() &&
+		!handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()) &&
+		!handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) {
+		badFields["scope_id"] = "This field must be 'global' or a valid org or project scope id."
+	}
+	for _, op := range req.GetOperations() {
+		if _, ok := operations[op]; !ok {
+			badFields["operations"] = fmt.Sprintf("Unknown operation %q; must be create, update or delete.", op)
+		}
+	}
+	if req.GetStartTime() != nil && req.GetEndTime() != nil && !req.GetStartTime().AsTime().Before(req.GetEndTime().AsTime()) {
+		badFields["end_time"] = "This field must be after the start time."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if _, err := decodePageToken(req.GetPageToken()); err != nil {
+		badFields["page_token"] = "This is not a valid page token."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
+func validateWatchRequest(req *pbs.WatchOplogEntriesRequest) error {
+	badFields := map[string]string{}
+	if req.GetScopeId() != scope.Global.String() &&
+		!handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()) &&
+		!handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) {
+		badFields["scope_id"] = "This field must be 'global' or a valid org or project scope id."
+	}
+	for _, op := range req.GetOperations() {
+		if _, ok := operations[op]; !ok {
+			badFields["operations"] = fmt.Sprintf("Unknown operation %q; must be create, update or delete.", op)
+		}
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if req.GetCursor() != "" {
+		if _, err := history.ParseCursor(req.GetCursor()); err != nil {
+			badFields["cursor"] = "This is not a valid cursor."
+		}
+	}
+	if req.GetWaitSeconds() > maxWaitSeconds {
+		badFields["wait_seconds"] = fmt.Sprintf("This field must not be greater than %d.", maxWaitSeconds)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package oplog_entries_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/history"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/oplog_entries"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrap)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	historyRepoFn := func() (*history.Repository, error) {
+		return history.NewRepository(rw, kmsCache)
+	}
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	user := iam.TestUser(t, iamRepo, o.GetPublicId())
+	user.Name = "updated"
+	_, _, _, err := iamRepo.UpdateUser(ctx, user, user.Version, []string{"Name"})
+	require.NoError(t, err)
+
+	pwRepo, err := password.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	acct, err := password.NewAccount(am.GetPublicId(), password.WithLoginName("kazmierczak"))
+	require.NoError(t, err)
+	_, err = pwRepo.CreateAccount(ctx, o.GetPublicId(), acct, password.WithPassword("a-long-enough-password"))
+	require.NoError(t, err)
+
+	s, err := oplog_entries.NewService(historyRepoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	t.Run("user", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ListOplogEntries(auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId())), &pbs.ListOplogEntriesRequest{
+			ScopeId:        o.GetPublicId(),
+			AggregateNames: []string{"iam_user"},
+		})
+		require.NoError(err)
+		require.Len(got.GetItems(), 2)
+		assert.Empty(got.GetNextPageToken())
+		for _, item := range got.GetItems() {
+			assert.Equal(o.GetPublicId(), item.GetScopeId())
+			assert.Equal(o.GetPublicId(), item.GetScope().GetId())
+			assert.Equal(user.GetPublicId(), item.GetResourceId())
+			require.Len(item.GetMessages(), 1)
+			assert.Equal("iam_user", item.GetMessages()[0].GetTypeName())
+			assert.Equal(user.GetPublicId(), item.GetMessages()[0].GetValue().GetFields()["public_id"].GetStringValue())
+		}
+		assert.Equal("create", got.GetItems()[0].GetMessages()[0].GetOperation())
+		assert.Equal("update", got.GetItems()[1].GetMessages()[0].GetOperation())
+		assert.Equal("updated", got.GetItems()[1].GetMessages()[0].GetValue().GetFields()["name"].GetStringValue())
+	})
+	t.Run("operations", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ListOplogEntries(auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId())), &pbs.ListOplogEntriesRequest{
+			ScopeId:        o.GetPublicId(),
+			AggregateNames: []string{"iam_user"},
+			Operations:     []string{"update"},
+		})
+		require.NoError(err)
+		require.Len(got.GetItems(), 1)
+		assert.Equal("update", got.GetItems()[0].GetMessages()[0].GetOperation())
+	})
+	t.Run("filter", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ListOplogEntries(auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId())), &pbs.ListOplogEntriesRequest{
+			ScopeId:  o.GetPublicId(),
+			Filter:   fmt.Sprintf(`"/item/resource_id" == %q`, user.GetPublicId()),
+			PageSize: 1,
+		})
+		require.NoError(err)
+		require.Len(got.GetItems(), 1)
+		assert.NotEmpty(got.GetNextPageToken())
+		assert.Equal(user.GetPublicId(), got.GetItems()[0].GetResourceId())
+	})
+	t.Run("redacted", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ListOplogEntries(auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId())), &pbs.ListOplogEntriesRequest{
+			ScopeId:        o.GetPublicId(),
+			AggregateNames: []string{"auth_password_argon2_cred"},
+		})
+		require.NoError(err)
+		require.Len(got.GetItems(), 1)
+		fields := got.GetItems()[0].GetMessages()[0].GetValue().GetFields()
+		assert.Contains(fields, "private_id")
+		assert.NotContains(fields, "salt")
+		assert.NotContains(fields, "ct_salt")
+		assert.NotContains(fields, "derived_key")
+	})
+	t.Run("pages", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		all, err := s.ListOplogEntries(auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId())), &pbs.ListOplogEntriesRequest{ScopeId: o.GetPublicId()})
+		require.NoError(err)
+		require.Greater(len(all.GetItems()), 2)
+
+		var ids []string
+		req := &pbs.ListOplogEntriesRequest{ScopeId: o.GetPublicId(), PageSize: 2}
+		for {
+			got, err := s.ListOplogEntries(auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId())), req)
+			require.NoError(err)
+			for _, item := range got.GetItems() {
+				ids = append(ids, item.GetId())
+			}
+			if got.GetNextPageToken() == "" {
+				break
+			}
+			require.Len(got.GetItems(), 2)
+			req.PageToken = got.GetNextPageToken()
+		}
+		var wantIds []string
+		for _, item := range all.GetItems() {
+			wantIds = append(wantIds, item.GetId())
+		}
+		assert.Equal(wantIds, ids)
+	})
+	t.Run("recursive", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ListOplogEntries(auth.DisabledAuthTestContext(auth.WithScopeId(scope.Global.String())), &pbs.ListOplogEntriesRequest{
+			ScopeId:   scope.Global.String(),
+			Recursive: true,
+		})
+		require.NoError(err)
+		scopeIds := map[string]bool{}
+		for _, item := range got.GetItems() {
+			scopeIds[item.GetScope().GetId()] = true
+		}
+		assert.True(scopeIds[o.GetPublicId()])
+	})
+
+	invalid := []struct {
+		name string
+		req  *pbs.ListOplogEntriesRequest
+	}{
+		{name: "bad-scope", req: &pbs.ListOplogEntriesRequest{ScopeId: "j_1234567890"}},
+		{name: "bad-operation", req: &pbs.ListOplogEntriesRequest{ScopeId: o.GetPublicId(), Operations: []string{"upsert"}}},
+		{name: "bad-filter", req: &pbs.ListOplogEntriesRequest{ScopeId: o.GetPublicId(), Filter: "/item/id =="}},
+		{name: "bad-page-token", req: &pbs.ListOplogEntriesRequest{ScopeId: o.GetPublicId(), PageToken: handlers.EncodePageToken("not-a-number")}},
+		{name: "bad-time-range", req: &pbs.ListOplogEntriesRequest{
+			ScopeId:   o.GetPublicId(),
+			StartTime: timestamppb.Now(),
+			EndTime:   timestamppb.New(timestamppb.Now().AsTime().Add(-1)),
+		}},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := s.ListOplogEntries(auth.DisabledAuthTestContext(auth.WithScopeId(tc.req.GetScopeId())), tc.req)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), err.Error())
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrap)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	historyRepoFn := func() (*history.Repository, error) {
+		return history.NewRepository(rw, kmsCache)
+	}
+	o, _ := iam.TestScopes(t, iamRepo)
+	authCtx := auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId()))
+
+	s, err := oplog_entries.NewService(historyRepoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	// Without changes the request waits, then returns a cursor to resume
+	// from.
+	got, err := s.WatchOplogEntries(authCtx, &pbs.WatchOplogEntriesRequest{ScopeId: o.GetPublicId(), WaitSeconds: 1})
+	require.NoError(t, err)
+	assert.Empty(t, got.GetItems())
+	require.NotEmpty(t, got.GetCursor())
+	cursor := got.GetCursor()
+
+	user := iam.TestUser(t, iamRepo, o.GetPublicId())
+	user.Name = "updated"
+	_, _, _, err = iamRepo.UpdateUser(ctx, user, user.Version, []string{"Name"})
+	require.NoError(t, err)
+
+	t.Run("changes", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.WatchOplogEntries(authCtx, &pbs.WatchOplogEntriesRequest{ScopeId: o.GetPublicId(), Cursor: cursor})
+		require.NoError(err)
+		require.Len(got.GetItems(), 2)
+		assert.Equal("create", got.GetItems()[0].GetMessages()[0].GetOperation())
+		assert.Equal("update", got.GetItems()[1].GetMessages()[0].GetOperation())
+		assert.Equal(o.GetPublicId(), got.GetItems()[0].GetScope().GetId())
+
+		got, err = s.WatchOplogEntries(authCtx, &pbs.WatchOplogEntriesRequest{ScopeId: o.GetPublicId(), Cursor: got.GetCursor(), WaitSeconds: 1})
+		require.NoError(err)
+		assert.Empty(got.GetItems())
+	})
+	t.Run("page-size", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.WatchOplogEntries(authCtx, &pbs.WatchOplogEntriesRequest{ScopeId: o.GetPublicId(), Cursor: cursor, PageSize: 1})
+		require.NoError(err)
+		require.Len(got.GetItems(), 1)
+		assert.Equal("create", got.GetItems()[0].GetMessages()[0].GetOperation())
+
+		got, err = s.WatchOplogEntries(authCtx, &pbs.WatchOplogEntriesRequest{ScopeId: o.GetPublicId(), Cursor: got.GetCursor(), PageSize: 1})
+		require.NoError(err)
+		require.Len(got.GetItems(), 1)
+		assert.Equal("update", got.GetItems()[0].GetMessages()[0].GetOperation())
+	})
+	t.Run("filtered", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.WatchOplogEntries(authCtx, &pbs.WatchOplogEntriesRequest{
+			ScopeId:    o.GetPublicId(),
+			Cursor:     cursor,
+			Operations: []string{"update"},
+			Filter:     fmt.Sprintf(`"/item/resource_id" == %q`, user.GetPublicId()),
+		})
+		require.NoError(err)
+		require.Len(got.GetItems(), 1)
+		assert.Equal("updated", got.GetItems()[0].GetMessages()[0].GetValue().GetFields()["name"].GetStringValue())
+	})
+
+	invalid := []struct {
+		name string
+		req  *pbs.WatchOplogEntriesRequest
+	}{
+		{name: "bad-scope", req: &pbs.WatchOplogEntriesRequest{ScopeId: "j_1234567890"}},
+		{name: "bad-operation", req: &pbs.WatchOplogEntriesRequest{ScopeId: o.GetPublicId(), Operations: []string{"upsert"}}},
+		{name: "bad-filter", req: &pbs.WatchOplogEntriesRequest{ScopeId: o.GetPublicId(), Filter: "/item/id =="}},
+		{name: "bad-cursor", req: &pbs.WatchOplogEntriesRequest{ScopeId: o.GetPublicId(), Cursor: "not a cursor"}},
+		{name: "bad-wait", req: &pbs.WatchOplogEntriesRequest{ScopeId: o.GetPublicId(), WaitSeconds: 61}},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := s.WatchOplogEntries(auth.DisabledAuthTestContext(auth.WithScopeId(tc.req.GetScopeId())), tc.req)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), err.Error())
+		})
+	}
+}
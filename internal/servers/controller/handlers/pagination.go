@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"sort"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultPageSize is the number of items returned by a List request which
+// does not set a page size. It is also the largest page size allowed.
+const DefaultPageSize = db.DefaultLimit
+
+// A PageItem is an item returned by a List request.
+type PageItem interface {
+	proto.Message
+	GetId() string
+}
+
+// ListPageFunc returns the first limit items by id which come after the item
+// with the id afterId. An empty afterId starts from the first item. Ids are
+// compared byte by byte, which is how Paginate orders them; repositories
+// compare and order them with collate "C" since the database's default
+// collation may order mixed case ids differently. Items
+// merged from several sources may be returned unordered and beyond the limit;
+// Paginate orders them by id and keeps only the first limit items.
+type ListPageFunc func(afterId string, limit int) ([]PageItem, error)
+
+// Paginate returns the page of items matching the filter which starts after
+// the page token, along with the token for the following page. The returned
+// token is empty when no more items match the filter.
+func Paginate(pageSize uint32, pageToken string, filter *Filter, list ListPageFunc) ([]PageItem, string, error) {
+	afterId, err := DecodePageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	size := int(pageSize)
+	if size == 0 || size > DefaultPageSize {
+		size = DefaultPageSize
+	}
+	var page []PageItem
+	for {
+		// Asking for one more item than the page holds tells us whether
+		// there is anything left to list after this batch.
+		items, err := list(afterId, size+1)
+		if err != nil {
+			return nil, "", err
+		}
+		sort.Slice(items, func(i, j int) bool { return items[i].GetId() < items[j].GetId() })
+		if len(items) > size+1 {
+			items = items[:size+1]
+		}
+		for _, item := range items {
+			if !filter.Match(item) {
+				continue
+			}
+			if len(page) == size {
+				return page, EncodePageToken(page[len(page)-1].GetId()), nil
+			}
+			page = append(page, item)
+		}
+		if len(items) <= size {
+			return page, "", nil
+		}
+		afterId = items[len(items)-1].GetId()
+	}
+}
+
+// EncodePageToken returns the opaque page token for the page starting after
+// the item with the provided id.
+func EncodePageToken(afterId string) string {
+	if afterId == "" {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(afterId))
+}
+
+// DecodePageToken returns the id of the item after which the page of the
+// provided token starts. An empty token starts from the first item.
+func DecodePageToken(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	id, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(id) == 0 {
+		return "", errors.New("invalid page token")
+	}
+	return string(id), nil
+}
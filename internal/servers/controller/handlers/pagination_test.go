@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	targetspb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func testListPageFunc(t *testing.T, items []*targetspb.Target, calls *int) ListPageFunc {
+	t.Helper()
+	sort.Slice(items, func(i, j int) bool { return items[i].GetId() < items[j].GetId() })
+	return func(afterId string, limit int) ([]PageItem, error) {
+		*calls++
+		var out []PageItem
+		for _, item := range items {
+			if item.GetId() <= afterId {
+				continue
+			}
+			if len(out) == limit {
+				break
+			}
+			out = append(out, item)
+		}
+		return out, nil
+	}
+}
+
+func pageIds(items []PageItem) []string {
+	var ids []string
+	for _, item := range items {
+		ids = append(ids, item.GetId())
+	}
+	return ids
+}
+
+func TestPaginate(t *testing.T) {
+	var targets []*targetspb.Target
+	for i := 0; i < 10; i++ {
+		name := "dev"
+		if i%2 == 0 {
+			name = "prod"
+		}
+		targets = append(targets, &targetspb.Target{
+			Id:   fmt.Sprintf("ttcp_%010d", i),
+			Name: &wrapperspb.StringValue{Value: name},
+		})
+	}
+
+	t.Run("all items", func(t *testing.T) {
+		var calls int
+		page, next, err := Paginate(0, "", nil, testListPageFunc(t, targets, &calls))
+		require.NoError(t, err)
+		assert.Len(t, page, 10)
+		assert.Empty(t, next)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		var calls int
+		list := testListPageFunc(t, targets, &calls)
+		var got []string
+		var token string
+		for pages := 1; ; pages++ {
+			page, next, err := Paginate(4, token, nil, list)
+			require.NoError(t, err)
+			got = append(got, pageIds(page)...)
+			if next == "" {
+				assert.Equal(t, 3, pages)
+				break
+			}
+			assert.Len(t, page, 4)
+			token = next
+		}
+		assert.Equal(t, pageIds(toPageItems(targets)), got)
+	})
+
+	t.Run("exact page", func(t *testing.T) {
+		var calls int
+		page, next, err := Paginate(10, "", nil, testListPageFunc(t, targets, &calls))
+		require.NoError(t, err)
+		assert.Len(t, page, 10)
+		assert.Empty(t, next, "no token when nothing follows the page")
+	})
+
+	t.Run("filtered pages", func(t *testing.T) {
+		filter, err := NewFilter(`"/item/name" == "prod"`)
+		require.NoError(t, err)
+		var calls int
+		list := testListPageFunc(t, targets, &calls)
+
+		page, next, err := Paginate(2, "", filter, list)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ttcp_0000000000", "ttcp_0000000002"}, pageIds(page))
+		require.NotEmpty(t, next)
+
+		page, next, err = Paginate(2, next, filter, list)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ttcp_0000000004", "ttcp_0000000006"}, pageIds(page))
+		require.NotEmpty(t, next)
+
+		page, next, err = Paginate(2, next, filter, list)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ttcp_0000000008"}, pageIds(page))
+		assert.Empty(t, next)
+	})
+
+	t.Run("filter spanning batches", func(t *testing.T) {
+		filter, err := NewFilter(`"/item/id" == "ttcp_0000000009"`)
+		require.NoError(t, err)
+		var calls int
+		page, next, err := Paginate(1, "", filter, testListPageFunc(t, targets, &calls))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ttcp_0000000009"}, pageIds(page))
+		assert.Empty(t, next)
+		assert.Equal(t, 6, calls)
+	})
+
+	t.Run("merged sources", func(t *testing.T) {
+		var evens, odds []*targetspb.Target
+		for i, target := range targets {
+			if i%2 == 0 {
+				evens = append(evens, target)
+			} else {
+				odds = append(odds, target)
+			}
+		}
+		var calls int
+		listEvens := testListPageFunc(t, evens, &calls)
+		listOdds := testListPageFunc(t, odds, &calls)
+		list := func(afterId string, limit int) ([]PageItem, error) {
+			e, _ := listEvens(afterId, limit)
+			o, _ := listOdds(afterId, limit)
+			return append(o, e...), nil
+		}
+
+		var got []string
+		var token string
+		for {
+			page, next, err := Paginate(3, token, nil, list)
+			require.NoError(t, err)
+			got = append(got, pageIds(page)...)
+			if next == "" {
+				break
+			}
+			token = next
+		}
+		assert.Equal(t, pageIds(toPageItems(targets)), got)
+	})
+
+	t.Run("ids differing in case", func(t *testing.T) {
+		var mixed []*targetspb.Target
+		for _, id := range []string{"ttcp_aaaaaaaaaa", "ttcp_AAAAAAAAAA", "ttcp_aAaAaAaAaA", "ttcp_Baaaaaaaaa"} {
+			mixed = append(mixed, &targetspb.Target{Id: id})
+		}
+		var calls int
+		list := testListPageFunc(t, mixed, &calls)
+		var got []string
+		var token string
+		for {
+			page, next, err := Paginate(1, token, nil, list)
+			require.NoError(t, err)
+			got = append(got, pageIds(page)...)
+			if next == "" {
+				break
+			}
+			token = next
+		}
+		assert.Equal(t, []string{"ttcp_AAAAAAAAAA", "ttcp_Baaaaaaaaa", "ttcp_aAaAaAaAaA", "ttcp_aaaaaaaaaa"}, got)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		var calls int
+		_, _, err := Paginate(1, "not a token!", nil, testListPageFunc(t, targets, &calls))
+		assert.Error(t, err)
+		assert.Equal(t, 0, calls)
+	})
+}
+
+func toPageItems(targets []*targetspb.Target) []PageItem {
+	var items []PageItem
+	for _, t := range targets {
+		items = append(items, t)
+	}
+	return items
+}
+
+func TestPageToken(t *testing.T) {
+	assert.Empty(t, EncodePageToken(""))
+	id, err := DecodePageToken("")
+	require.NoError(t, err)
+	assert.Empty(t, id)
+
+	token := EncodePageToken("ttcp_1234567890")
+	assert.NotEqual(t, "ttcp_1234567890", token)
+	id, err = DecodePageToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "ttcp_1234567890", id)
+
+	_, err = DecodePageToken("%%%")
+	assert.Error(t, err)
+}
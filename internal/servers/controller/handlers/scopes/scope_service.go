@@ -12,6 +12,7 @@ import (
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -32,17 +33,30 @@ func init() {
 	}
 }
 
+// IdActions contains the set of actions that can be performed on
+// individual resources
+var IdActions = action.ActionSet{
+	action.Read,
+	action.Update,
+	action.Delete,
+	action.RotateKeys,
+}
+
 // Service handles requests as described by the pbs.ScopeServiceServer interface.
 type Service struct {
-	repoFn common.IamRepoFactory
+	repoFn   common.IamRepoFactory
+	kmsCache *kms.Kms
 }
 
 // NewService returns a project service which handles project related requests to boundary.
-func NewService(repo common.IamRepoFactory) (Service, error) {
+func NewService(repo common.IamRepoFactory, kmsCache *kms.Kms) (Service, error) {
 	if repo == nil {
 		return Service{}, fmt.Errorf("nil iam repository provided")
 	}
-	return Service{repoFn: repo}, nil
+	if kmsCache == nil {
+		return Service{}, fmt.Errorf("nil kms provided")
+	}
+	return Service{repoFn: repo, kmsCache: kmsCache}, nil
 }
 
 var _ pbs.ScopeServiceServer = Service{}
@@ -55,20 +69,41 @@ func (s Service) ListScopes(ctx context.Context, req *pbs.ListScopesRequest) (*p
 	if err := validateListRequest(req); err != nil {
 		return nil, err
 	}
-	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
-		return nil, authResults.Error
+	scopeResults, err := s.listAuthResults(ctx, req.GetScopeId(), req.GetRecursive())
+	if err != nil {
+		return nil, err
 	}
-	pl, err := s.listFromRepo(ctx, authResults.Scope.GetId())
+	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-
-	for _, item := range pl {
-		item.Scope = authResults.Scope
+	items, nextPageToken, err := handlers.Paginate(req.GetPageSize(), req.GetPageToken(), filter, func(afterId string, limit int) ([]handlers.PageItem, error) {
+		var items []handlers.PageItem
+		for _, authResults := range scopeResults {
+			if authResults.Scope.GetType() == scope.Project.String() {
+				// Projects have no child scopes to list
+				continue
+			}
+			pl, err := s.listFromRepo(ctx, authResults.Scope.GetId(), afterId, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range pl {
+				item.Scope = authResults.Scope
+				item.AuthorizedActions = authResults.FetchActionSetForId(ctx, item.Id, IdActions).Strings()
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	return &pbs.ListScopesResponse{Items: pl}, nil
+	var finalItems []*pb.Scope
+	for _, item := range items {
+		finalItems = append(finalItems, item.(*pb.Scope))
+	}
+	return &pbs.ListScopesResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetScopes implements the interface pbs.ScopeServiceServer.
@@ -84,7 +119,13 @@ func (s Service) GetScope(ctx context.Context, req *pbs.GetScopeRequest) (*pbs.G
 	if err != nil {
 		return nil, err
 	}
+	rotation, err := s.kmsCache.LookupKeyRotation(ctx, req.GetId())
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to look up key rotation: %v", err)
+	}
+	p.KeyRotation = keyRotationToProto(rotation)
 	p.Scope = authResults.Scope
+	p.AuthorizedActions = authResults.FetchActionSetForId(ctx, p.Id, IdActions).Strings()
 	return &pbs.GetScopeResponse{Item: p}, nil
 }
 
@@ -138,6 +179,32 @@ func (s Service) DeleteScope(ctx context.Context, req *pbs.DeleteScopeRequest) (
 	return &pbs.DeleteScopeResponse{}, nil
 }
 
+// RotateScopeKeys implements the interface pbs.ScopeServiceServer.
+func (s Service) RotateScopeKeys(ctx context.Context, req *pbs.RotateScopeKeysRequest) (*pbs.RotateScopeKeysResponse, error) {
+	if err := validateRotateKeysRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RotateKeys)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	p, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.kmsCache.RotateKeys(ctx, req.GetId()); err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to rotate keys: %v", err)
+	}
+	rotation, err := s.kmsCache.LookupKeyRotation(ctx, req.GetId())
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to look up key rotation: %v", err)
+	}
+	p.KeyRotation = keyRotationToProto(rotation)
+	p.Scope = authResults.Scope
+	p.AuthorizedActions = authResults.FetchActionSetForId(ctx, p.Id, IdActions).Strings()
+	return &pbs.RotateScopeKeysResponse{Item: p}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -251,7 +318,7 @@ func SortScopes(scps []*pb.Scope) {
 	})
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Scope, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeId, afterId string, limit int) ([]*pb.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -260,9 +327,9 @@ func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Scope,
 	var scps []*iam.Scope
 	switch {
 	case scopeId == "global":
-		scps, err = repo.ListOrgs(ctx)
+		scps, err = repo.ListOrgs(ctx, iam.WithLimit(limit), iam.WithStartPageAfterId(afterId))
 	case strings.HasPrefix(scopeId, scope.Org.Prefix()):
-		scps, err = repo.ListProjects(ctx, scopeId)
+		scps, err = repo.ListProjects(ctx, scopeId, iam.WithLimit(limit), iam.WithStartPageAfterId(afterId))
 	default:
 		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
 			map[string]string{"scope_id": "This field must be 'global' or a valid org scope id."})
@@ -318,6 +385,20 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
+// listAuthResults authorizes listing in the provided scope or, when recursive,
+// separately in it and every scope beneath it. It returns the results for the
+// scopes in which listing is allowed.
+func (s Service) listAuthResults(ctx context.Context, scopeId string, recursive bool) ([]auth.VerifyResults, error) {
+	if recursive {
+		return auth.VerifyRecursive(ctx, s.repoFn, auth.WithType(resource.Scope), auth.WithAction(action.List), auth.WithScopeId(scopeId))
+	}
+	authResults := s.authResult(ctx, scopeId, action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	return []auth.VerifyResults{authResults}, nil
+}
+
 func ToProto(in *iam.Scope) *pb.Scope {
 	out := pb.Scope{
 		Id:          in.GetPublicId(),
@@ -336,6 +417,21 @@ func ToProto(in *iam.Scope) *pb.Scope {
 	return &out
 }
 
+func keyRotationToProto(in *kms.KeyRotation) *pb.KeyRotation {
+	if in == nil {
+		return nil
+	}
+	return &pb.KeyRotation{
+		Id:             in.PrivateId,
+		Status:         in.Status,
+		RewrappedCount: uint64(in.RewrappedCount),
+		PendingCount:   uint64(in.PendingCount),
+		LastError:      in.LastError,
+		CreatedTime:    in.CreateTime.GetTimestamp(),
+		UpdatedTime:    in.UpdateTime.GetTimestamp(),
+	}
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //  * The path passed in is correctly formatted
@@ -390,6 +486,9 @@ func validateCreateRequest(req *pbs.CreateScopeRequest) error {
 	if item.GetUpdatedTime() != nil {
 		badFields["updated_time"] = "This is a read only field."
 	}
+	if item.GetAuthorizedActions() != nil {
+		badFields["authorized_actions"] = "This is a read only field."
+	}
 	if item.GetVersion() != 0 {
 		badFields["version"] = "This cannot be specified at create time."
 	}
@@ -446,6 +545,9 @@ func validateUpdateRequest(req *pbs.UpdateScopeRequest) error {
 	if item.GetUpdatedTime() != nil {
 		badFields["updated_time"] = "This is a read only field and cannot be specified in an update request."
 	}
+	if item.GetAuthorizedActions() != nil {
+		badFields["authorized_actions"] = "This is a read only field and cannot be specified in an update request."
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
 	}
@@ -481,6 +583,34 @@ func validateListRequest(req *pbs.ListScopesRequest) error {
 	if req.GetScopeId() != scope.Global.String() && !handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()) {
 		badFields["scope_id"] = "Must be 'global' or a valid org scope id when listing."
 	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if _, err := handlers.DecodePageToken(req.GetPageToken()); err != nil {
+		badFields["page_token"] = "This is not a valid page token."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateRotateKeysRequest(req *pbs.RotateScopeKeysRequest) error {
+	badFields := map[string]string{}
+	id := req.GetId()
+	switch {
+	case id == "global":
+	case strings.HasPrefix(id, scope.Org.Prefix()):
+		if !handlers.ValidId(scope.Org.Prefix(), id) {
+			badFields["id"] = "Invalidly formatted scope id."
+		}
+	case strings.HasPrefix(id, scope.Project.Prefix()):
+		if !handlers.ValidId(scope.Project.Prefix(), id) {
+			badFields["id"] = "Invalidly formatted scope id."
+		}
+	default:
+		badFields["id"] = "Invalidly formatted scope id."
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
 	}
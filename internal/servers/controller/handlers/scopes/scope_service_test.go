@@ -15,6 +15,7 @@ import (
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/scopes"
 	"github.com/hashicorp/boundary/internal/types/scope"
@@ -28,7 +29,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*iam.Repository, error)) {
+func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*iam.Repository, error), *kms.Kms) {
 	t.Helper()
 	conn, _ := db.TestSetup(t, "postgres")
 	wrap := db.TestWrapper(t)
@@ -52,37 +53,39 @@ func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*
 	require.NoError(t, err)
 	pRes, _, err = repo.UpdateScope(context.Background(), pRes, 1, []string{"Name", "Description"})
 	require.NoError(t, err)
-	return oRes, pRes, repoFn
+	return oRes, pRes, repoFn, kms.TestKms(t, conn, wrap)
 }
 
 func TestGet(t *testing.T) {
-	org, proj, repo := createDefaultScopesAndRepo(t)
+	org, proj, repo, kmsCache := createDefaultScopesAndRepo(t)
 	toMerge := &pbs.GetScopeRequest{
 		Id: proj.GetPublicId(),
 	}
 
 	oScope := &pb.Scope{
-		Id:          org.GetPublicId(),
-		ScopeId:     org.GetParentId(),
-		Scope:       &pb.ScopeInfo{Id: "global", Type: scope.Global.String()},
-		Name:        &wrapperspb.StringValue{Value: org.GetName()},
-		Description: &wrapperspb.StringValue{Value: org.GetDescription()},
-		CreatedTime: org.CreateTime.GetTimestamp(),
-		UpdatedTime: org.UpdateTime.GetTimestamp(),
-		Version:     2,
-		Type:        scope.Org.String(),
+		Id:                org.GetPublicId(),
+		ScopeId:           org.GetParentId(),
+		Scope:             &pb.ScopeInfo{Id: "global", Type: scope.Global.String()},
+		Name:              &wrapperspb.StringValue{Value: org.GetName()},
+		Description:       &wrapperspb.StringValue{Value: org.GetDescription()},
+		CreatedTime:       org.CreateTime.GetTimestamp(),
+		UpdatedTime:       org.UpdateTime.GetTimestamp(),
+		Version:           2,
+		Type:              scope.Org.String(),
+		AuthorizedActions: scopes.IdActions.Strings(),
 	}
 
 	pScope := &pb.Scope{
-		Id:          proj.GetPublicId(),
-		ScopeId:     proj.GetParentId(),
-		Scope:       &pb.ScopeInfo{Id: oScope.Id, Type: scope.Org.String()},
-		Name:        &wrapperspb.StringValue{Value: proj.GetName()},
-		Description: &wrapperspb.StringValue{Value: proj.GetDescription()},
-		CreatedTime: proj.CreateTime.GetTimestamp(),
-		UpdatedTime: proj.UpdateTime.GetTimestamp(),
-		Version:     2,
-		Type:        scope.Project.String(),
+		Id:                proj.GetPublicId(),
+		ScopeId:           proj.GetParentId(),
+		Scope:             &pb.ScopeInfo{Id: oScope.Id, Type: scope.Org.String()},
+		Name:              &wrapperspb.StringValue{Value: proj.GetName()},
+		Description:       &wrapperspb.StringValue{Value: proj.GetDescription()},
+		CreatedTime:       proj.CreateTime.GetTimestamp(),
+		UpdatedTime:       proj.UpdateTime.GetTimestamp(),
+		Version:           2,
+		Type:              scope.Project.String(),
+		AuthorizedActions: scopes.IdActions.Strings(),
 	}
 
 	cases := []struct {
@@ -140,7 +143,7 @@ func TestGet(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetScopeRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := scopes.NewService(repo)
+			s, err := scopes.NewService(repo, kmsCache)
 			require.NoError(err, "Couldn't create new project service.")
 
 			got, gErr := s.GetScope(auth.DisabledAuthTestContext(auth.WithScopeId(tc.scopeId)), req)
@@ -160,6 +163,7 @@ func TestList(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
+	kmsCache := kms.TestKms(t, conn, wrap)
 	repo, err := repoFn()
 	require.NoError(t, err)
 
@@ -174,8 +178,10 @@ func TestList(t *testing.T) {
 	globalScope := &pb.ScopeInfo{Id: "global", Type: scope.Global.String()}
 	oNoProjectsProto := scopes.ToProto(oNoProjects)
 	oNoProjectsProto.Scope = globalScope
+	oNoProjectsProto.AuthorizedActions = scopes.IdActions.Strings()
 	oWithProjectsProto := scopes.ToProto(oWithProjects)
 	oWithProjectsProto.Scope = globalScope
+	oWithProjectsProto.AuthorizedActions = scopes.IdActions.Strings()
 	initialOrgs = append(initialOrgs, oNoProjectsProto, oWithProjectsProto)
 	scopes.SortScopes(initialOrgs)
 
@@ -208,7 +214,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := scopes.NewService(repoFn)
+			s, err := scopes.NewService(repoFn, kmsCache)
 			require.NoError(err, "Couldn't create new role service.")
 
 			got, gErr := s.ListScopes(auth.DisabledAuthTestContext(auth.WithScopeId(tc.scopeId)), tc.req)
@@ -227,13 +233,14 @@ func TestList(t *testing.T) {
 		o, err := repo.CreateScope(context.Background(), newO, "")
 		require.NoError(t, err)
 		wantOrgs = append(wantOrgs, &pb.Scope{
-			Id:          o.GetPublicId(),
-			ScopeId:     globalScope.GetId(),
-			Scope:       globalScope,
-			CreatedTime: o.GetCreateTime().GetTimestamp(),
-			UpdatedTime: o.GetUpdateTime().GetTimestamp(),
-			Version:     1,
-			Type:        scope.Org.String(),
+			Id:                o.GetPublicId(),
+			ScopeId:           globalScope.GetId(),
+			Scope:             globalScope,
+			CreatedTime:       o.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       o.GetUpdateTime().GetTimestamp(),
+			Version:           1,
+			Type:              scope.Org.String(),
+			AuthorizedActions: scopes.IdActions.Strings(),
 		})
 	}
 	wantOrgs = append(wantOrgs, initialOrgs...)
@@ -246,13 +253,14 @@ func TestList(t *testing.T) {
 		p, err := repo.CreateScope(context.Background(), newP, "")
 		require.NoError(t, err)
 		wantProjects = append(wantProjects, &pb.Scope{
-			Id:          p.GetPublicId(),
-			ScopeId:     oWithProjects.GetPublicId(),
-			Scope:       &pb.ScopeInfo{Id: oWithProjects.GetPublicId(), Type: scope.Org.String()},
-			CreatedTime: p.GetCreateTime().GetTimestamp(),
-			UpdatedTime: p.GetUpdateTime().GetTimestamp(),
-			Version:     1,
-			Type:        scope.Project.String(),
+			Id:                p.GetPublicId(),
+			ScopeId:           oWithProjects.GetPublicId(),
+			Scope:             &pb.ScopeInfo{Id: oWithProjects.GetPublicId(), Type: scope.Org.String()},
+			CreatedTime:       p.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       p.GetUpdateTime().GetTimestamp(),
+			Version:           1,
+			Type:              scope.Project.String(),
+			AuthorizedActions: scopes.IdActions.Strings(),
 		})
 	}
 	scopes.SortScopes(wantProjects)
@@ -280,7 +288,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := scopes.NewService(repoFn)
+			s, err := scopes.NewService(repoFn, kmsCache)
 			require.NoError(err, "Couldn't create new role service.")
 
 			got, gErr := s.ListScopes(auth.DisabledAuthTestContext(auth.WithScopeId(tc.scopeId)), tc.req)
@@ -294,9 +302,9 @@ func TestList(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
-	org, proj, repo := createDefaultScopesAndRepo(t)
+	org, proj, repo, kmsCache := createDefaultScopesAndRepo(t)
 
-	s, err := scopes.NewService(repo)
+	s, err := scopes.NewService(repo, kmsCache)
 	require.NoError(t, err, "Error when getting new project service.")
 
 	cases := []struct {
@@ -370,9 +378,9 @@ func TestDelete(t *testing.T) {
 
 func TestDelete_twice(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
-	org, proj, repo := createDefaultScopesAndRepo(t)
+	org, proj, repo, kmsCache := createDefaultScopesAndRepo(t)
 
-	s, err := scopes.NewService(repo)
+	s, err := scopes.NewService(repo, kmsCache)
 	require.NoError(err, "Error when getting new scopes service")
 	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(org.GetPublicId()))
 	req := &pbs.DeleteScopeRequest{
@@ -397,7 +405,7 @@ func TestDelete_twice(t *testing.T) {
 
 func TestCreate(t *testing.T) {
 	ctx := context.Background()
-	defaultOrg, defaultProj, repoFn := createDefaultScopesAndRepo(t)
+	defaultOrg, defaultProj, repoFn, kmsCache := createDefaultScopesAndRepo(t)
 	defaultProjCreated, err := ptypes.Timestamp(defaultProj.GetCreateTime().GetTimestamp())
 	require.NoError(t, err, "Error converting proto to timestamp.")
 	toMerge := &pbs.CreateScopeRequest{}
@@ -573,7 +581,7 @@ func TestCreate(t *testing.T) {
 				req := proto.Clone(toMerge).(*pbs.CreateScopeRequest)
 				proto.Merge(req, tc.req)
 
-				s, err := scopes.NewService(repoFn)
+				s, err := scopes.NewService(repoFn, kmsCache)
 				require.NoError(err, "Error when getting new project service.")
 
 				if name != "" {
@@ -647,8 +655,8 @@ func TestCreate(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
-	org, proj, repoFn := createDefaultScopesAndRepo(t)
-	tested, err := scopes.NewService(repoFn)
+	org, proj, repoFn, kmsCache := createDefaultScopesAndRepo(t)
+	tested, err := scopes.NewService(repoFn, kmsCache)
 	require.NoError(t, err, "Error when getting new project service.")
 
 	var orgVersion uint32 = 2
@@ -1014,3 +1022,31 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestRotateKeys(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	org, proj, repo, kmsCache := createDefaultScopesAndRepo(t)
+
+	s, err := scopes.NewService(repo, kmsCache)
+	require.NoError(err, "Error when getting new scopes service")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(org.GetPublicId()))
+
+	got, err := s.GetScope(ctx, &pbs.GetScopeRequest{Id: proj.GetPublicId()})
+	require.NoError(err)
+	assert.Nil(got.GetItem().GetKeyRotation(), "Expected no key rotation before the keys were rotated.")
+
+	rotated, err := s.RotateScopeKeys(ctx, &pbs.RotateScopeKeysRequest{Id: proj.GetPublicId()})
+	require.NoError(err)
+	rotation := rotated.GetItem().GetKeyRotation()
+	require.NotNil(rotation)
+	assert.NotEmpty(rotation.GetId())
+	assert.Equal(proj.GetPublicId(), rotated.GetItem().GetId())
+	assert.Equal(scopes.IdActions.Strings(), rotated.GetItem().GetAuthorizedActions())
+
+	got, err = s.GetScope(ctx, &pbs.GetScopeRequest{Id: proj.GetPublicId()})
+	require.NoError(err)
+	assert.Equal(rotation.GetId(), got.GetItem().GetKeyRotation().GetId())
+
+	_, err = s.RotateScopeKeys(ctx, &pbs.RotateScopeKeysRequest{Id: "p_1 23456789"})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Expected invalid argument for a malformed id, got %v", err)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/auth"
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/event"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/sessions"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
@@ -18,6 +19,13 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 )
 
+// IdActions contains the set of actions that can be performed on
+// individual resources
+var IdActions = action.ActionSet{
+	action.Read,
+	action.Cancel,
+}
+
 // Service handles request as described by the pbs.SessionServiceServer interface.
 type Service struct {
 	repoFn    common.SessionRepoFactory
@@ -51,6 +59,7 @@ func (s Service) GetSession(ctx context.Context, req *pbs.GetSessionRequest) (*p
 		return nil, err
 	}
 	ses.Scope = authResults.Scope
+	ses.AuthorizedActions = authResults.FetchActionSetForId(ctx, ses.Id, IdActions).Strings()
 	return &pbs.GetSessionResponse{Item: ses}, nil
 }
 
@@ -59,18 +68,37 @@ func (s Service) ListSessions(ctx context.Context, req *pbs.ListSessionsRequest)
 	if err := validateListRequest(req); err != nil {
 		return nil, err
 	}
-	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
-		return nil, authResults.Error
+	scopeResults, err := s.listAuthResults(ctx, req.GetScopeId(), req.GetRecursive())
+	if err != nil {
+		return nil, err
 	}
-	seslist, err := s.listFromRepo(ctx, authResults.Scope.GetId())
+	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range seslist {
-		item.Scope = authResults.Scope
+	items, nextPageToken, err := handlers.Paginate(req.GetPageSize(), req.GetPageToken(), filter, func(afterId string, limit int) ([]handlers.PageItem, error) {
+		var items []handlers.PageItem
+		for _, authResults := range scopeResults {
+			seslist, err := s.listFromRepo(ctx, authResults.Scope.GetId(), afterId, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range seslist {
+				item.Scope = authResults.Scope
+				item.AuthorizedActions = authResults.FetchActionSetForId(ctx, item.Id, IdActions).Strings()
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return &pbs.ListSessionsResponse{Items: seslist}, nil
+	var finalItems []*pb.Session
+	for _, item := range items {
+		finalItems = append(finalItems, item.(*pb.Session))
+	}
+	return &pbs.ListSessionsResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // CancelSession implements the interface pbs.SessionServiceServer.
@@ -87,6 +115,13 @@ func (s Service) CancelSession(ctx context.Context, req *pbs.CancelSessionReques
 		return nil, err
 	}
 	ses.Scope = authResults.Scope
+	event.EventerFromContext(ctx).Session(&event.Session{
+		Stage:     event.SessionCanceled,
+		SessionId: ses.GetId(),
+		UserId:    authResults.UserId,
+		TargetId:  ses.GetTargetId(),
+		ScopeId:   ses.GetScopeId(),
+	})
 	return &pbs.CancelSessionResponse{Item: ses}, nil
 }
 
@@ -108,12 +143,12 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Session, error
 	return toProto(sess), nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Session, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeId, afterId string, limit int) ([]*pb.Session, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	seslist, err := repo.ListSessions(ctx, session.WithScopeId(scopeId))
+	seslist, err := repo.ListSessions(ctx, session.WithScopeId(scopeId), session.WithLimit(limit), session.WithStartPageAfterId(afterId))
 	if err != nil {
 		return nil, err
 	}
@@ -183,6 +218,20 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
+// listAuthResults authorizes listing in the provided scope or, when recursive,
+// separately in it and every scope beneath it. It returns the results for the
+// scopes in which listing is allowed.
+func (s Service) listAuthResults(ctx context.Context, scopeId string, recursive bool) ([]auth.VerifyResults, error) {
+	if recursive {
+		return auth.VerifyRecursive(ctx, s.iamRepoFn, auth.WithType(resource.Session), auth.WithAction(action.List), auth.WithScopeId(scopeId))
+	}
+	authResults := s.authResult(ctx, scopeId, action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	return []auth.VerifyResults{authResults}, nil
+}
+
 func toProto(in *session.Session) *pb.Session {
 	out := pb.Session{
 		Id:          in.GetPublicId(),
@@ -197,11 +246,12 @@ func toProto(in *session.Session) *pb.Session {
 		Type:        target.SubtypeFromId(in.TargetId).String(),
 		// TODO: Provide the ServerType and the ServerId when that information becomes relevant in the API.
 
-		CreatedTime:    in.CreateTime.GetTimestamp(),
-		UpdatedTime:    in.UpdateTime.GetTimestamp(),
-		ExpirationTime: in.ExpirationTime.GetTimestamp(),
-		Certificate:    in.Certificate,
+		CreatedTime:       in.CreateTime.GetTimestamp(),
+		UpdatedTime:       in.UpdateTime.GetTimestamp(),
+		ExpirationTime:    in.ExpirationTime.GetTimestamp(),
+		Certificate:       in.Certificate,
 		TerminationReason: in.TerminationReason,
+		RecordingEnabled:  in.RecordingEnabled,
 	}
 	if len(in.States) > 0 {
 		out.Status = in.States[0].Status.String()
@@ -218,6 +268,31 @@ func toProto(in *session.Session) *pb.Session {
 		}
 		out.States = append(out.States, sessState)
 	}
+	for _, r := range in.Recordings {
+		out.Recordings = append(out.Recordings, &pb.ConnectionRecording{
+			ConnectionId: r.ConnectionId,
+			WorkerId:     r.WorkerId,
+			StoragePath:  r.StoragePath,
+			BytesUp:      r.BytesUp,
+			BytesDown:    r.BytesDown,
+			StartTime:    r.StartTime.GetTimestamp(),
+			EndTime:      r.EndTime.GetTimestamp(),
+		})
+	}
+	for _, c := range in.Connections {
+		out.Connections = append(out.Connections, &pb.Connection{
+			Id:                 c.PublicId,
+			ClientTcpAddress:   c.ClientTcpAddress,
+			ClientTcpPort:      c.ClientTcpPort,
+			EndpointTcpAddress: c.EndpointTcpAddress,
+			EndpointTcpPort:    c.EndpointTcpPort,
+			BytesUp:            c.BytesUp,
+			BytesDown:          c.BytesDown,
+			ClosedReason:       c.ClosedReason,
+			CreatedTime:        c.CreateTime.GetTimestamp(),
+			UpdatedTime:        c.UpdateTime.GetTimestamp(),
+		})
+	}
 	return &out
 }
 
@@ -232,9 +307,22 @@ func validateGetRequest(req *pbs.GetSessionRequest) error {
 
 func validateListRequest(req *pbs.ListSessionsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) {
+	switch {
+	case req.GetRecursive():
+		if req.GetScopeId() != scope.Global.String() &&
+			!handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()) &&
+			!handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) {
+			badFields["scope_id"] = "This field must be 'global' or a valid org or project scope id when listing recursively."
+		}
+	case !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()):
 		badFields["scope_id"] = "This field is required to have a properly formatted project scope id."
 	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if _, err := handlers.DecodePageToken(req.GetPageToken()); err != nil {
+		badFields["page_token"] = "This is not a valid page token."
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
 	}
@@ -0,0 +1,93 @@
+package targets
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/target"
+)
+
+// compoundHost is a host of one of a target's host sets.
+type compoundHost struct {
+	hostSetId string
+	hostId    string
+}
+
+// chooseHost chooses the host of a session for the target from the
+// candidates using the target's host selection strategy.
+func chooseHost(ctx context.Context, sessionRepo *session.Repository, t target.Target, userId string, candidates []compoundHost) (*compoundHost, error) {
+	if len(candidates) == 0 {
+		return nil, errors.New("no candidate hosts")
+	}
+	switch target.HostSelectionStrategyFromString(t.GetHostSelectionStrategy()) {
+	case target.RoundRobinHostSelection:
+		lastHostId, err := sessionRepo.LastSessionHostId(ctx, t.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		return nextHost(candidates, lastHostId), nil
+	case target.LeastActiveSessionsHostSelection:
+		hostIds := make([]string, 0, len(candidates))
+		for _, c := range candidates {
+			hostIds = append(hostIds, c.hostId)
+		}
+		counts, err := sessionRepo.ActiveSessionCountsByHost(ctx, hostIds)
+		if err != nil {
+			return nil, err
+		}
+		return leastActiveHost(candidates, counts), nil
+	case target.StickyPerUserHostSelection:
+		lastHostId, err := sessionRepo.LastSessionHostId(ctx, t.GetPublicId(), session.WithUserId(userId))
+		if err != nil {
+			return nil, err
+		}
+		for i := range candidates {
+			if candidates[i].hostId == lastHostId {
+				return &candidates[i], nil
+			}
+		}
+		// The user has no previous session, or its host is no longer
+		// available, so fall through to choosing one at random.
+	}
+	return &candidates[rand.Intn(len(candidates))], nil
+}
+
+// nextHost returns the candidate with the lowest host id which sorts after
+// lastHostId, wrapping around to the lowest host id. Ordering by id rather
+// than by position keeps the rotation stable as hosts are added to or removed
+// from the target's host sets.
+func nextHost(candidates []compoundHost, lastHostId string) *compoundHost {
+	var first, next *compoundHost
+	for i := range candidates {
+		c := &candidates[i]
+		if first == nil || c.hostId < first.hostId {
+			first = c
+		}
+		if c.hostId > lastHostId && (next == nil || c.hostId < next.hostId) {
+			next = c
+		}
+	}
+	if next == nil {
+		return first
+	}
+	return next
+}
+
+// leastActiveHost returns the candidate with the fewest active sessions,
+// choosing at random between candidates with the same count.
+func leastActiveHost(candidates []compoundHost, counts map[string]int) *compoundHost {
+	var least []*compoundHost
+	min := -1
+	for i := range candidates {
+		c := &candidates[i]
+		switch n := counts[c.hostId]; {
+		case min == -1 || n < min:
+			min, least = n, []*compoundHost{c}
+		case n == min:
+			least = append(least, c)
+		}
+	}
+	return least[rand.Intn(len(least))]
+}
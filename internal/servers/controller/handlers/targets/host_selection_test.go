@@ -0,0 +1,46 @@
+package targets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextHost(t *testing.T) {
+	candidates := []compoundHost{
+		{hostSetId: "hsst_1", hostId: "hst_c"},
+		{hostSetId: "hsst_1", hostId: "hst_a"},
+		{hostSetId: "hsst_2", hostId: "hst_b"},
+	}
+	tests := []struct {
+		name       string
+		lastHostId string
+		want       string
+	}{
+		{name: "no-previous-session", lastHostId: "", want: "hst_a"},
+		{name: "next", lastHostId: "hst_a", want: "hst_b"},
+		{name: "wrap-around", lastHostId: "hst_c", want: "hst_a"},
+		{name: "removed-host", lastHostId: "hst_bb", want: "hst_c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nextHost(candidates, tt.lastHostId).hostId)
+		})
+	}
+}
+
+func TestLeastActiveHost(t *testing.T) {
+	assert := assert.New(t)
+	candidates := []compoundHost{
+		{hostSetId: "hsst_1", hostId: "hst_a"},
+		{hostSetId: "hsst_1", hostId: "hst_b"},
+		{hostSetId: "hsst_1", hostId: "hst_c"},
+	}
+	got := leastActiveHost(candidates, map[string]int{"hst_a": 3, "hst_c": 1})
+	assert.Equal("hst_b", got.hostId)
+
+	for i := 0; i < 10; i++ {
+		got = leastActiveHost(candidates, map[string]int{"hst_a": 2, "hst_b": 1, "hst_c": 1})
+		assert.Contains([]string{"hst_b", "hst_c"}, got.hostId)
+	}
+}
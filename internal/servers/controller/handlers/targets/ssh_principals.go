@@ -0,0 +1,34 @@
+package targets
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/target"
+	"google.golang.org/grpc/codes"
+)
+
+// sshCertificatePrincipals returns the principals of the ssh certificate
+// issued for a session to the ssh target t: the target's principals or, when
+// it has none, the id of the user the session is for. The user's id is used
+// rather than anything users can change, such as their name, so that a user
+// can only ever get certificates for their own principal.
+func sshCertificatePrincipals(t *target.SshTarget, userId string) ([]string, error) {
+	principals := t.PrincipalList()
+	if len(principals) == 0 {
+		principals = []string{userId}
+	}
+	for _, p := range principals {
+		if !validSshPrincipal(p) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "Target %q has an invalid principal %q.", t.GetPublicId(), p)
+		}
+	}
+	return principals, nil
+}
+
+// validSshPrincipal reports whether p can be a principal of an ssh
+// certificate, which cannot be empty or contain commas or whitespace.
+func validSshPrincipal(p string) bool {
+	return p != "" && !strings.ContainsRune(p, ',') && strings.IndexFunc(p, unicode.IsSpace) < 0
+}
@@ -0,0 +1,47 @@
+package targets
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSshCertificatePrincipals(t *testing.T) {
+	tests := []struct {
+		name       string
+		principals string
+		want       []string
+		wantErr    bool
+	}{
+		{name: "target", principals: "ubuntu,ec2-user", want: []string{"ubuntu", "ec2-user"}},
+		{name: "user", principals: "", want: []string{"u_1234567890"}},
+		{name: "invalid", principals: "ubuntu,root admin", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tar := &target.SshTarget{SshTarget: &store.SshTarget{PublicId: "tssh_1234567890", Principals: tt.principals}}
+			got, err := sshCertificatePrincipals(tar, "u_1234567890")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidSshPrincipal(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(validSshPrincipal("ubuntu"))
+	assert.True(validSshPrincipal("u_1234567890"))
+	assert.False(validSshPrincipal(""))
+	assert.False(validSshPrincipal("root,ubuntu"))
+	assert.False(validSshPrincipal("root ubuntu"))
+	assert.False(validSshPrincipal("root\tubuntu"))
+	assert.False(validSshPrincipal("root\r"))
+	assert.False(validSshPrincipal("root "))
+}
@@ -4,16 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/rand"
 	"net/url"
+	"strings"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/hashicorp/boundary/internal/auth"
+	credstatic "github.com/hashicorp/boundary/internal/credential/static"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/event"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/host/inventory"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/servers"
@@ -27,14 +30,18 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/strutil"
 	"github.com/mr-tron/base58"
+	"golang.org/x/crypto/ssh"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 var (
-	maskManager handlers.MaskManager
+	maskManager    handlers.MaskManager
+	udpMaskManager handlers.MaskManager
+	sshMaskManager handlers.MaskManager
 )
 
 func init() {
@@ -42,16 +49,39 @@ func init() {
 	if maskManager, err = handlers.NewMaskManager(&store.TcpTarget{}, &pb.Target{}, &pb.TcpTargetAttributes{}); err != nil {
 		panic(err)
 	}
+	if udpMaskManager, err = handlers.NewMaskManager(&store.UdpTarget{}, &pb.Target{}, &pb.UdpTargetAttributes{}); err != nil {
+		panic(err)
+	}
+	if sshMaskManager, err = handlers.NewMaskManager(&store.SshTarget{}, &pb.Target{}, &pb.SshTargetAttributes{}); err != nil {
+		panic(err)
+	}
+}
+
+// IdActions contains the set of actions that can be performed on
+// individual resources
+var IdActions = action.ActionSet{
+	action.Read,
+	action.Update,
+	action.Delete,
+	action.AddHostSets,
+	action.SetHostSets,
+	action.RemoveHostSets,
+	action.AddCredentialLibraries,
+	action.SetCredentialLibraries,
+	action.RemoveCredentialLibraries,
+	action.AuthorizeSession,
 }
 
 // Service handles request as described by the pbs.TargetServiceServer interface.
 type Service struct {
-	repoFn           common.TargetRepoFactory
-	iamRepoFn        common.IamRepoFactory
-	serversRepoFn    common.ServersRepoFactory
-	sessionRepoFn    common.SessionRepoFactory
-	staticHostRepoFn common.StaticRepoFactory
-	kmsCache         *kms.Kms
+	repoFn              common.TargetRepoFactory
+	iamRepoFn           common.IamRepoFactory
+	serversRepoFn       common.ServersRepoFactory
+	sessionRepoFn       common.SessionRepoFactory
+	staticHostRepoFn    common.StaticRepoFactory
+	inventoryHostRepoFn common.InventoryRepoFactory
+	staticCredRepoFn    common.StaticCredentialRepoFactory
+	kmsCache            *kms.Kms
 }
 
 // NewService returns a target service which handles target related requests to boundary.
@@ -61,7 +91,9 @@ func NewService(
 	iamRepoFn common.IamRepoFactory,
 	serversRepoFn common.ServersRepoFactory,
 	sessionRepoFn common.SessionRepoFactory,
-	staticHostRepoFn common.StaticRepoFactory) (Service, error) {
+	staticHostRepoFn common.StaticRepoFactory,
+	inventoryHostRepoFn common.InventoryRepoFactory,
+	staticCredRepoFn common.StaticCredentialRepoFactory) (Service, error) {
 	if repoFn == nil {
 		return Service{}, fmt.Errorf("nil target repository provided")
 	}
@@ -77,13 +109,21 @@ func NewService(
 	if staticHostRepoFn == nil {
 		return Service{}, fmt.Errorf("nil static host repository provided")
 	}
+	if inventoryHostRepoFn == nil {
+		return Service{}, fmt.Errorf("nil inventory host repository provided")
+	}
+	if staticCredRepoFn == nil {
+		return Service{}, fmt.Errorf("nil static credential repository provided")
+	}
 	return Service{
-		repoFn:           repoFn,
-		iamRepoFn:        iamRepoFn,
-		serversRepoFn:    serversRepoFn,
-		sessionRepoFn:    sessionRepoFn,
-		staticHostRepoFn: staticHostRepoFn,
-		kmsCache:         kmsCache,
+		repoFn:              repoFn,
+		iamRepoFn:           iamRepoFn,
+		serversRepoFn:       serversRepoFn,
+		sessionRepoFn:       sessionRepoFn,
+		staticHostRepoFn:    staticHostRepoFn,
+		inventoryHostRepoFn: inventoryHostRepoFn,
+		staticCredRepoFn:    staticCredRepoFn,
+		kmsCache:            kmsCache,
 	}, nil
 }
 
@@ -94,18 +134,37 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 	if err := validateListRequest(req); err != nil {
 		return nil, err
 	}
-	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
-		return nil, authResults.Error
+	scopeResults, err := s.listAuthResults(ctx, req.GetScopeId(), req.GetRecursive())
+	if err != nil {
+		return nil, err
 	}
-	ul, err := s.listFromRepo(ctx, authResults.Scope.GetId())
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	items, nextPageToken, err := handlers.Paginate(req.GetPageSize(), req.GetPageToken(), filter, func(afterId string, limit int) ([]handlers.PageItem, error) {
+		var items []handlers.PageItem
+		for _, authResults := range scopeResults {
+			ul, err := s.listFromRepo(ctx, authResults.Scope.GetId(), afterId, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range ul {
+				item.Scope = authResults.Scope
+				item.AuthorizedActions = authResults.FetchActionSetForId(ctx, item.Id, IdActions).Strings()
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range ul {
-		item.Scope = authResults.Scope
+	var finalItems []*pb.Target
+	for _, item := range items {
+		finalItems = append(finalItems, item.(*pb.Target))
 	}
-	return &pbs.ListTargetsResponse{Items: ul}, nil
+	return &pbs.ListTargetsResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetTargets implements the interface pbs.TargetServiceServer.
@@ -122,6 +181,7 @@ func (s Service) GetTarget(ctx context.Context, req *pbs.GetTargetRequest) (*pbs
 		return nil, err
 	}
 	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
 	return &pbs.GetTargetResponse{Item: u}, nil
 }
 
@@ -226,6 +286,57 @@ func (s Service) RemoveTargetHostSets(ctx context.Context, req *pbs.RemoveTarget
 	return &pbs.RemoveTargetHostSetsResponse{Item: u}, nil
 }
 
+// AddTargetCredentialLibraries implements the interface pbs.TargetServiceServer.
+func (s Service) AddTargetCredentialLibraries(ctx context.Context, req *pbs.AddTargetCredentialLibrariesRequest) (*pbs.AddTargetCredentialLibrariesResponse, error) {
+	if err := validateAddCredentialLibrariesRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.AddCredentialLibraries)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, err := s.addCredentialLibrariesInRepo(ctx, req.GetId(), req.GetCredentialLibraryIds(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	return &pbs.AddTargetCredentialLibrariesResponse{Item: u}, nil
+}
+
+// SetTargetCredentialLibraries implements the interface pbs.TargetServiceServer.
+func (s Service) SetTargetCredentialLibraries(ctx context.Context, req *pbs.SetTargetCredentialLibrariesRequest) (*pbs.SetTargetCredentialLibrariesResponse, error) {
+	if err := validateSetCredentialLibrariesRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.SetCredentialLibraries)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, err := s.setCredentialLibrariesInRepo(ctx, req.GetId(), req.GetCredentialLibraryIds(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	return &pbs.SetTargetCredentialLibrariesResponse{Item: u}, nil
+}
+
+// RemoveTargetCredentialLibraries implements the interface pbs.TargetServiceServer.
+func (s Service) RemoveTargetCredentialLibraries(ctx context.Context, req *pbs.RemoveTargetCredentialLibrariesRequest) (*pbs.RemoveTargetCredentialLibrariesResponse, error) {
+	if err := validateRemoveCredentialLibrariesRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RemoveCredentialLibraries)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, err := s.removeCredentialLibrariesInRepo(ctx, req.GetId(), req.GetCredentialLibraryIds(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	return &pbs.RemoveTargetCredentialLibrariesResponse{Item: u}, nil
+}
+
 func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSessionRequest) (*pbs.AuthorizeSessionResponse, error) {
 	if err := validateAuthorizeSessionRequest(req); err != nil {
 		return nil, err
@@ -268,6 +379,15 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 		return nil, handlers.NotFoundErrorf("Target %q not found.", req.GetId())
 	}
 
+	// The principals of an ssh target's certificate are resolved before the
+	// session is created so a target without valid ones fails early.
+	var sshPrincipals []string
+	if sshT, ok := t.(*target.SshTarget); ok {
+		if sshPrincipals, err = sshCertificatePrincipals(sshT, authResults.UserId); err != nil {
+			return nil, err
+		}
+	}
+
 	// Instantiate some repos
 	sessionRepo, err := s.sessionRepoFn()
 	if err != nil {
@@ -279,18 +399,24 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	}
 
 	// First, fetch all available hosts. Unless one was chosen in the request,
-	// we will pick one at random.
-	type compoundHost struct {
-		hostSetId string
-		hostId    string
-	}
-
+	// we will pick one using the target's host selection strategy.
 	var chosenId *compoundHost
 	requestedId := req.GetHostId()
+	if requestedId != "" && t.GetRestrictHostId() {
+		return nil, handlers.InvalidArgumentErrorf(
+			"Errors in provided fields.",
+			map[string]string{
+				"host_id": "The target does not allow requesting a host.",
+			})
+	}
 	staticHostRepo, err := s.staticHostRepoFn()
 	if err != nil {
 		return nil, err
 	}
+	inventoryHostRepo, err := s.inventoryHostRepoFn()
+	if err != nil {
+		return nil, err
+	}
 
 	hostIds := make([]compoundHost, 0, len(hostSets)*10)
 
@@ -311,6 +437,19 @@ HostSetIterationLoop:
 					break HostSetIterationLoop
 				}
 			}
+		case host.InventorySubtype:
+			_, hosts, err := inventoryHostRepo.LookupSet(ctx, hsId)
+			if err != nil {
+				return nil, err
+			}
+			for _, host := range hosts {
+				compoundId := compoundHost{hostSetId: hsId, hostId: host.PublicId}
+				hostIds = append(hostIds, compoundId)
+				if host.PublicId == requestedId {
+					chosenId = &compoundId
+					break HostSetIterationLoop
+				}
+			}
 		}
 	}
 	if requestedId != "" && chosenId == nil {
@@ -326,13 +465,18 @@ HostSetIterationLoop:
 			// No hosts were found, error
 			return nil, handlers.NotFoundErrorf("No hosts found from available target host sets.")
 		}
-		chosenId = &hostIds[rand.Intn(len(hostIds))]
+		if chosenId, err = chooseHost(ctx, sessionRepo, t, authResults.UserId, hostIds); err != nil {
+			return nil, err
+		}
 	}
 
-	// Generate the endpoint URL
+	// Generate the endpoint URL. The worker proxies ssh sessions as tcp.
 	endpointUrl := &url.URL{
 		Scheme: t.GetType(),
 	}
+	if t.GetType() == target.SshTargetType.String() {
+		endpointUrl.Scheme = target.TcpTargetType.String()
+	}
 	defaultPort := t.GetDefaultPort()
 	var endpointHost string
 	switch host.SubtypeFromId(chosenId.hostId) {
@@ -345,6 +489,18 @@ HostSetIterationLoop:
 		if endpointHost == "" {
 			return nil, errors.New("host had empty address")
 		}
+	case host.InventorySubtype:
+		h, err := inventoryHostRepo.LookupHost(ctx, chosenId.hostId)
+		if err != nil {
+			return nil, fmt.Errorf("error looking up host: %w", err)
+		}
+		if h == nil {
+			return nil, errors.New("host no longer exists")
+		}
+		endpointHost = h.Address
+		if endpointHost == "" {
+			return nil, errors.New("host had empty address")
+		}
 	}
 	if defaultPort != 0 {
 		endpointUrl.Host = fmt.Sprintf("%s:%d", endpointHost, defaultPort)
@@ -352,18 +508,41 @@ HostSetIterationLoop:
 		endpointUrl.Host = endpointHost
 	}
 
+	// Only the workers matching the target's worker filter are given to the
+	// client, and the session is not created if there are none.
+	liveWorkers, err := serversRepo.ListServers(ctx, servers.ServerTypeWorker)
+	if err != nil {
+		return nil, err
+	}
+	matchedWorkers, err := filterWorkers(t, liveWorkers)
+	if err != nil {
+		return nil, err
+	}
+	if t.GetWorkerFilter() != "" && len(matchedWorkers) == 0 {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "No workers matching the target's worker filter are available.")
+	}
+	var workers []*pb.WorkerInfo
+	for _, v := range matchedWorkers {
+		workers = append(workers, &pb.WorkerInfo{Address: v.Address})
+	}
+
 	expTime := timestamppb.Now()
 	expTime.Seconds += int64(t.GetSessionMaxSeconds())
 	sessionComposition := session.ComposedOf{
-		UserId:          authResults.UserId,
-		HostId:          chosenId.hostId,
-		TargetId:        t.GetPublicId(),
-		HostSetId:       chosenId.hostSetId,
-		AuthTokenId:     authResults.AuthTokenId,
-		ScopeId:         authResults.Scope.Id,
-		Endpoint:        endpointUrl.String(),
-		ExpirationTime:  &timestamp.Timestamp{Timestamp: expTime},
-		ConnectionLimit: t.GetSessionConnectionLimit(),
+		UserId:                      authResults.UserId,
+		HostId:                      chosenId.hostId,
+		TargetId:                    t.GetPublicId(),
+		HostSetId:                   chosenId.hostSetId,
+		AuthTokenId:                 authResults.AuthTokenId,
+		ScopeId:                     authResults.Scope.Id,
+		Endpoint:                    endpointUrl.String(),
+		ExpirationTime:              &timestamp.Timestamp{Timestamp: expTime},
+		ConnectionLimit:             t.GetSessionConnectionLimit(),
+		RecordingEnabled:            t.GetEnableSessionRecording(),
+		ConnectionMaxBytesPerSecond: t.GetConnectionMaxBytesPerSecond(),
+		SessionMaxBytesPerSecond:    t.GetSessionMaxBytesPerSecond(),
+		IdleTimeoutSeconds:          t.GetSessionIdleTimeoutSeconds(),
+		WorkerFilter:                t.GetWorkerFilter(),
 	}
 
 	sess, err := session.New(sessionComposition)
@@ -379,13 +558,21 @@ HostSetIterationLoop:
 		return nil, err
 	}
 
-	var workers []*pb.WorkerInfo
-	servers, err := serversRepo.ListServers(ctx, servers.ServerTypeWorker)
+	creds, err := s.brokerCredentials(ctx, t)
 	if err != nil {
 		return nil, err
 	}
-	for _, v := range servers {
-		workers = append(workers, &pb.WorkerInfo{Address: v.Address})
+
+	var sshCert, sshKey string
+	if sshPrincipals != nil {
+		ca, err := s.kmsCache.SshCertificateAuthority(ctx, t.GetScopeId())
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to load ssh certificate authority: %v.", err)
+		}
+		sshCert, sshKey, err = session.NewSshCertificate(ca, sess.PublicId, sshPrincipals, sess.ExpirationTime.GetTimestamp().AsTime())
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to issue ssh certificate: %v.", err)
+		}
 	}
 
 	sad := &pb.SessionAuthorizationData{
@@ -399,6 +586,9 @@ HostSetIterationLoop:
 		HostId:          chosenId.hostId,
 		WorkerInfo:      workers,
 		ConnectionLimit: t.GetSessionConnectionLimit(),
+		Credentials:     creds,
+		SshCertificate:  sshCert,
+		SshPrivateKey:   sshKey,
 	}
 	marshaledSad, err := proto.Marshal(sad)
 	if err != nil {
@@ -417,6 +607,14 @@ HostSetIterationLoop:
 		HostId:             chosenId.hostId,
 		HostSetId:          chosenId.hostSetId,
 	}
+	event.EventerFromContext(ctx).Session(&event.Session{
+		Stage:     event.SessionAuthorized,
+		SessionId: sess.PublicId,
+		UserId:    authResults.UserId,
+		TargetId:  t.GetPublicId(),
+		ScopeId:   authResults.Scope.GetId(),
+		Endpoint:  sess.Endpoint,
+	})
 	return &pbs.AuthorizeSessionResponse{Item: ret}, nil
 }
 
@@ -435,7 +633,11 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Target, error)
 	if u == nil {
 		return nil, handlers.NotFoundErrorf("Target %q doesn't exist.", id)
 	}
-	return toProto(u, m)
+	libs, err := repo.ListTargetCredentialLibraries(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toProto(ctx, u, m, libs)
 }
 
 func (s Service) createInRepo(ctx context.Context, item *pb.Target) (*pb.Target, error) {
@@ -449,29 +651,84 @@ func (s Service) createInRepo(ctx context.Context, item *pb.Target) (*pb.Target,
 	if item.GetSessionConnectionLimit() != nil {
 		opts = append(opts, target.WithSessionConnectionLimit(item.GetSessionConnectionLimit().GetValue()))
 	}
-	tcpAttrs := &pb.TcpTargetAttributes{}
-	if err := handlers.StructToProto(item.GetAttributes(), tcpAttrs); err != nil {
-		return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+	if item.GetEnableSessionRecording() != nil {
+		opts = append(opts, target.WithEnableSessionRecording(item.GetEnableSessionRecording().GetValue()))
 	}
-	if tcpAttrs.GetDefaultPort().GetValue() != 0 {
-		opts = append(opts, target.WithDefaultPort(tcpAttrs.GetDefaultPort().GetValue()))
+	if item.GetConnectionMaxBytesPerSecond() != nil {
+		opts = append(opts, target.WithConnectionMaxBytesPerSecond(item.GetConnectionMaxBytesPerSecond().GetValue()))
 	}
-	u, err := target.NewTcpTarget(item.GetScopeId(), opts...)
-	if err != nil {
-		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for creation: %v.", err)
+	if item.GetSessionMaxBytesPerSecond() != nil {
+		opts = append(opts, target.WithSessionMaxBytesPerSecond(item.GetSessionMaxBytesPerSecond().GetValue()))
+	}
+	if item.GetSessionIdleTimeoutSeconds() != nil {
+		opts = append(opts, target.WithSessionIdleTimeoutSeconds(item.GetSessionIdleTimeoutSeconds().GetValue()))
+	}
+	if item.GetHostSelectionStrategy() != nil {
+		opts = append(opts, target.WithHostSelectionStrategy(target.HostSelectionStrategyFromString(item.GetHostSelectionStrategy().GetValue())))
+	}
+	if item.GetRestrictHostId() != nil {
+		opts = append(opts, target.WithRestrictHostId(item.GetRestrictHostId().GetValue()))
+	}
+	if item.GetWorkerFilter() != nil {
+		opts = append(opts, target.WithWorkerFilter(item.GetWorkerFilter().GetValue()))
 	}
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	out, m, err := repo.CreateTcpTarget(ctx, u)
+	var out target.Target
+	var m []*target.TargetSet
+	switch target.SubtypeFromType(item.GetType()) {
+	case target.UdpSubType:
+		udpAttrs := &pb.UdpTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), udpAttrs); err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if udpAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(udpAttrs.GetDefaultPort().GetValue()))
+		}
+		u, err := target.NewUdpTarget(item.GetScopeId(), opts...)
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for creation: %v.", err)
+		}
+		out, m, err = repo.CreateUdpTarget(ctx, u)
+	case target.SshSubType:
+		sshAttrs := &pb.SshTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), sshAttrs); err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if sshAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(sshAttrs.GetDefaultPort().GetValue()))
+		}
+		if len(sshAttrs.GetPrincipals()) > 0 {
+			opts = append(opts, target.WithPrincipals(strutil.RemoveDuplicates(sshAttrs.GetPrincipals(), false)))
+		}
+		u, err := target.NewSshTarget(item.GetScopeId(), opts...)
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for creation: %v.", err)
+		}
+		out, m, err = repo.CreateSshTarget(ctx, u)
+	default:
+		tcpAttrs := &pb.TcpTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), tcpAttrs); err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if tcpAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(tcpAttrs.GetDefaultPort().GetValue()))
+		}
+		u, err := target.NewTcpTarget(item.GetScopeId(), opts...)
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for creation: %v.", err)
+		}
+		out, m, err = repo.CreateTcpTarget(ctx, u)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("unable to create target: %w", err)
 	}
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create target but no error returned from repository.")
 	}
-	return toProto(out, m)
+	return s.toProto(ctx, out, m, nil)
 }
 
 func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Target) (*pb.Target, error) {
@@ -488,35 +745,105 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if item.GetSessionConnectionLimit() != nil {
 		opts = append(opts, target.WithSessionConnectionLimit(item.GetSessionConnectionLimit().GetValue()))
 	}
-	tcpAttrs := &pb.TcpTargetAttributes{}
-	if err := handlers.StructToProto(item.GetAttributes(), tcpAttrs); err != nil {
-		return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+	if item.GetEnableSessionRecording() != nil {
+		opts = append(opts, target.WithEnableSessionRecording(item.GetEnableSessionRecording().GetValue()))
 	}
-	if tcpAttrs.GetDefaultPort().GetValue() != 0 {
-		opts = append(opts, target.WithDefaultPort(tcpAttrs.GetDefaultPort().GetValue()))
+	if item.GetConnectionMaxBytesPerSecond() != nil {
+		opts = append(opts, target.WithConnectionMaxBytesPerSecond(item.GetConnectionMaxBytesPerSecond().GetValue()))
 	}
-	version := item.GetVersion()
-	u, err := target.NewTcpTarget(scopeId, opts...)
-	if err != nil {
-		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for update: %v.", err)
+	if item.GetSessionMaxBytesPerSecond() != nil {
+		opts = append(opts, target.WithSessionMaxBytesPerSecond(item.GetSessionMaxBytesPerSecond().GetValue()))
+	}
+	if item.GetSessionIdleTimeoutSeconds() != nil {
+		opts = append(opts, target.WithSessionIdleTimeoutSeconds(item.GetSessionIdleTimeoutSeconds().GetValue()))
 	}
-	u.PublicId = id
-	dbMask := maskManager.Translate(mask)
-	if len(dbMask) == 0 {
-		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid paths provided in the update mask."})
+	if item.GetHostSelectionStrategy() != nil {
+		opts = append(opts, target.WithHostSelectionStrategy(target.HostSelectionStrategyFromString(item.GetHostSelectionStrategy().GetValue())))
 	}
+	if item.GetRestrictHostId() != nil {
+		opts = append(opts, target.WithRestrictHostId(item.GetRestrictHostId().GetValue()))
+	}
+	if item.GetWorkerFilter() != nil {
+		opts = append(opts, target.WithWorkerFilter(item.GetWorkerFilter().GetValue()))
+	}
+	version := item.GetVersion()
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	out, m, rowsUpdated, err := repo.UpdateTcpTarget(ctx, u, version, dbMask)
+	var out target.Target
+	var m []*target.TargetSet
+	var rowsUpdated int
+	switch target.SubtypeFromId(id) {
+	case target.UdpSubType:
+		udpAttrs := &pb.UdpTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), udpAttrs); err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if udpAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(udpAttrs.GetDefaultPort().GetValue()))
+		}
+		u, err := target.NewUdpTarget(scopeId, opts...)
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for update: %v.", err)
+		}
+		u.PublicId = id
+		dbMask := udpMaskManager.Translate(mask)
+		if len(dbMask) == 0 {
+			return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid paths provided in the update mask."})
+		}
+		out, m, rowsUpdated, err = repo.UpdateUdpTarget(ctx, u, version, dbMask)
+	case target.SshSubType:
+		sshAttrs := &pb.SshTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), sshAttrs); err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if sshAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(sshAttrs.GetDefaultPort().GetValue()))
+		}
+		if len(sshAttrs.GetPrincipals()) > 0 {
+			opts = append(opts, target.WithPrincipals(strutil.RemoveDuplicates(sshAttrs.GetPrincipals(), false)))
+		}
+		u, err := target.NewSshTarget(scopeId, opts...)
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for update: %v.", err)
+		}
+		u.PublicId = id
+		dbMask := sshMaskManager.Translate(mask)
+		if len(dbMask) == 0 {
+			return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid paths provided in the update mask."})
+		}
+		out, m, rowsUpdated, err = repo.UpdateSshTarget(ctx, u, version, dbMask)
+	default:
+		tcpAttrs := &pb.TcpTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), tcpAttrs); err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if tcpAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(tcpAttrs.GetDefaultPort().GetValue()))
+		}
+		u, err := target.NewTcpTarget(scopeId, opts...)
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for update: %v.", err)
+		}
+		u.PublicId = id
+		dbMask := maskManager.Translate(mask)
+		if len(dbMask) == 0 {
+			return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid paths provided in the update mask."})
+		}
+		out, m, rowsUpdated, err = repo.UpdateTcpTarget(ctx, u, version, dbMask)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("unable to update target: %w", err)
 	}
 	if rowsUpdated == 0 {
 		return nil, handlers.NotFoundErrorf("Target %q not found or incorrect version provided.", id)
 	}
-	return toProto(out, m)
+	libs, err := repo.ListTargetCredentialLibraries(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toProto(ctx, out, m, libs)
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
@@ -534,18 +861,18 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Target, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeId, afterId string, limit int) ([]*pb.Target, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListTargets(ctx, target.WithScopeId(scopeId))
+	ul, err := repo.ListTargets(ctx, target.WithScopeId(scopeId), target.WithLimit(limit), target.WithStartPageAfterId(afterId))
 	if err != nil {
 		return nil, err
 	}
 	var outUl []*pb.Target
 	for _, u := range ul {
-		o, err := toProto(u, nil)
+		o, err := s.toProto(ctx, u, nil, nil)
 		if err != nil {
 			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to convert value to proto: %v.", err)
 		}
@@ -567,7 +894,11 @@ func (s Service) addInRepo(ctx context.Context, targetId string, hostSetId []str
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup target after adding host sets to it.")
 	}
-	return toProto(out, m)
+	libs, err := repo.ListTargetCredentialLibraries(ctx, targetId)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up target credential libraries after adding host sets: %w", err)
+	}
+	return s.toProto(ctx, out, m, libs)
 }
 
 func (s Service) setInRepo(ctx context.Context, targetId string, hostSetIds []string, version uint32) (*pb.Target, error) {
@@ -588,7 +919,11 @@ func (s Service) setInRepo(ctx context.Context, targetId string, hostSetIds []st
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup target after setting host sets for it.")
 	}
-	return toProto(out, m)
+	libs, err := repo.ListTargetCredentialLibraries(ctx, targetId)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up target credential libraries after setting host sets: %w", err)
+	}
+	return s.toProto(ctx, out, m, libs)
 }
 
 func (s Service) removeInRepo(ctx context.Context, targetId string, hostSetIds []string, version uint32) (*pb.Target, error) {
@@ -608,7 +943,114 @@ func (s Service) removeInRepo(ctx context.Context, targetId string, hostSetIds [
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup target after removing host sets from it.")
 	}
-	return toProto(out, m)
+	libs, err := repo.ListTargetCredentialLibraries(ctx, targetId)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up target credential libraries after removing host sets: %w", err)
+	}
+	return s.toProto(ctx, out, m, libs)
+}
+
+func (s Service) addCredentialLibrariesInRepo(ctx context.Context, targetId string, credentialLibraryIds []string, version uint32) (*pb.Target, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	_, libs, err := repo.AddTargetCredentialLibraries(ctx, targetId, version, strutil.RemoveDuplicates(credentialLibraryIds, false))
+	if err != nil {
+		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add credential libraries to target: %v.", err)
+	}
+	out, m, err := repo.LookupTarget(ctx, targetId)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up target after adding credential libraries: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup target after adding credential libraries to it.")
+	}
+	return s.toProto(ctx, out, m, libs)
+}
+
+func (s Service) setCredentialLibrariesInRepo(ctx context.Context, targetId string, credentialLibraryIds []string, version uint32) (*pb.Target, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	libs, _, err := repo.SetTargetCredentialLibraries(ctx, targetId, version, strutil.RemoveDuplicates(credentialLibraryIds, false))
+	if err != nil {
+		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set credential libraries in target: %v.", err)
+	}
+	out, m, err := repo.LookupTarget(ctx, targetId)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up target after setting credential libraries: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup target after setting credential libraries for it.")
+	}
+	return s.toProto(ctx, out, m, libs)
+}
+
+func (s Service) removeCredentialLibrariesInRepo(ctx context.Context, targetId string, credentialLibraryIds []string, version uint32) (*pb.Target, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	_, err = repo.DeleteTargetCredentialLibraries(ctx, targetId, version, strutil.RemoveDuplicates(credentialLibraryIds, false))
+	if err != nil {
+		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove credential libraries from target: %v.", err)
+	}
+	out, m, err := repo.LookupTarget(ctx, targetId)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up target after removing credential libraries: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup target after removing credential libraries from it.")
+	}
+	libs, err := repo.ListTargetCredentialLibraries(ctx, targetId)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up target credential libraries after removing credential libraries: %w", err)
+	}
+	return s.toProto(ctx, out, m, libs)
+}
+
+// brokerCredentials returns the credentials of the credential libraries of
+// the target t for a session of the target.
+func (s Service) brokerCredentials(ctx context.Context, t target.Target) ([]*pb.SessionCredential, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	libs, err := repo.ListTargetCredentialLibraries(ctx, t.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	if len(libs) == 0 {
+		return nil, nil
+	}
+	libIds := make([]string, 0, len(libs))
+	for _, l := range libs {
+		libIds = append(libIds, l.GetPublicId())
+	}
+	credRepo, err := s.staticCredRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	brokered, err := credRepo.BrokerLibraries(ctx, t.GetScopeId(), libIds)
+	if err != nil {
+		return nil, fmt.Errorf("unable to broker credentials: %w", err)
+	}
+	creds := make([]*pb.SessionCredential, 0, len(brokered))
+	for _, l := range brokered {
+		creds = append(creds, &pb.SessionCredential{
+			CredentialLibraryId: l.GetPublicId(),
+			Name:                l.GetName(),
+			Username:            l.GetUsername(),
+			Password:            l.GetPassword(),
+			PrivateKey:          l.GetPrivateKey(),
+		})
+	}
+	return creds, nil
 }
 
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
@@ -655,16 +1097,39 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
-func toProto(in target.Target, m []*target.TargetSet) (*pb.Target, error) {
+// listAuthResults authorizes listing in the provided scope or, when recursive,
+// separately in it and every scope beneath it. It returns the results for the
+// scopes in which listing is allowed.
+func (s Service) listAuthResults(ctx context.Context, scopeId string, recursive bool) ([]auth.VerifyResults, error) {
+	if recursive {
+		return auth.VerifyRecursive(ctx, s.iamRepoFn, auth.WithType(resource.Target), auth.WithAction(action.List), auth.WithScopeId(scopeId))
+	}
+	authResults := s.authResult(ctx, scopeId, action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	return []auth.VerifyResults{authResults}, nil
+}
+
+func (s Service) toProto(ctx context.Context, in target.Target, m []*target.TargetSet, libs []*target.TargetLibrary) (*pb.Target, error) {
 	out := pb.Target{
-		Id:                     in.GetPublicId(),
-		ScopeId:                in.GetScopeId(),
-		CreatedTime:            in.GetCreateTime().GetTimestamp(),
-		UpdatedTime:            in.GetUpdateTime().GetTimestamp(),
-		Version:                in.GetVersion(),
-		Type:                   target.TcpTargetType.String(),
-		SessionMaxSeconds:      wrapperspb.UInt32(in.GetSessionMaxSeconds()),
-		SessionConnectionLimit: wrapperspb.Int32(in.GetSessionConnectionLimit()),
+		Id:                          in.GetPublicId(),
+		ScopeId:                     in.GetScopeId(),
+		CreatedTime:                 in.GetCreateTime().GetTimestamp(),
+		UpdatedTime:                 in.GetUpdateTime().GetTimestamp(),
+		Version:                     in.GetVersion(),
+		Type:                        in.GetType(),
+		SessionMaxSeconds:           wrapperspb.UInt32(in.GetSessionMaxSeconds()),
+		SessionConnectionLimit:      wrapperspb.Int32(in.GetSessionConnectionLimit()),
+		EnableSessionRecording:      wrapperspb.Bool(in.GetEnableSessionRecording()),
+		ConnectionMaxBytesPerSecond: wrapperspb.UInt32(in.GetConnectionMaxBytesPerSecond()),
+		SessionMaxBytesPerSecond:    wrapperspb.UInt32(in.GetSessionMaxBytesPerSecond()),
+		SessionIdleTimeoutSeconds:   wrapperspb.UInt32(in.GetSessionIdleTimeoutSeconds()),
+		HostSelectionStrategy:       wrapperspb.String(in.GetHostSelectionStrategy()),
+		RestrictHostId:              wrapperspb.Bool(in.GetRestrictHostId()),
+	}
+	if in.GetWorkerFilter() != "" {
+		out.WorkerFilter = wrapperspb.String(in.GetWorkerFilter())
 	}
 	if in.GetDescription() != "" {
 		out.Description = wrapperspb.String(in.GetDescription())
@@ -672,9 +1137,37 @@ func toProto(in target.Target, m []*target.TargetSet) (*pb.Target, error) {
 	if in.GetName() != "" {
 		out.Name = wrapperspb.String(in.GetName())
 	}
-	attrs := &pb.TcpTargetAttributes{}
-	if in.GetDefaultPort() > 0 {
-		attrs.DefaultPort = &wrappers.UInt32Value{Value: in.GetDefaultPort()}
+	var attrs proto.Message
+	switch in.GetType() {
+	case target.UdpTargetType.String():
+		udpAttrs := &pb.UdpTargetAttributes{}
+		if in.GetDefaultPort() > 0 {
+			udpAttrs.DefaultPort = &wrappers.UInt32Value{Value: in.GetDefaultPort()}
+		}
+		attrs = udpAttrs
+	case target.SshTargetType.String():
+		sshAttrs := &pb.SshTargetAttributes{}
+		if in.GetDefaultPort() > 0 {
+			sshAttrs.DefaultPort = &wrappers.UInt32Value{Value: in.GetDefaultPort()}
+		}
+		if sshT, ok := in.(*target.SshTarget); ok {
+			sshAttrs.Principals = sshT.PrincipalList()
+		}
+		caKeys, err := s.kmsCache.SshCertificateAuthorityPublicKeys(ctx, in.GetScopeId())
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to load ssh certificate authority: %v.", err)
+		}
+		for _, k := range caKeys {
+			sshAttrs.CaPublicKeys = append(sshAttrs.CaPublicKeys, strings.TrimSpace(string(ssh.MarshalAuthorizedKey(k))))
+		}
+		sshAttrs.CaPublicKey = sshAttrs.CaPublicKeys[0]
+		attrs = sshAttrs
+	default:
+		tcpAttrs := &pb.TcpTargetAttributes{}
+		if in.GetDefaultPort() > 0 {
+			tcpAttrs.DefaultPort = &wrappers.UInt32Value{Value: in.GetDefaultPort()}
+		}
+		attrs = tcpAttrs
 	}
 	st, err := handlers.ProtoToStruct(attrs)
 	if err != nil {
@@ -688,6 +1181,13 @@ func toProto(in target.Target, m []*target.TargetSet) (*pb.Target, error) {
 			HostCatalogId: hs.GetCatalogId(),
 		})
 	}
+	for _, l := range libs {
+		out.CredentialLibraryIds = append(out.CredentialLibraryIds, l.GetPublicId())
+		out.CredentialLibraries = append(out.CredentialLibraries, &pb.CredentialLibrary{
+			Id:                l.GetPublicId(),
+			CredentialStoreId: l.GetStoreId(),
+		})
+	}
 	return &out, nil
 }
 
@@ -697,7 +1197,7 @@ func toProto(in target.Target, m []*target.TargetSet) (*pb.Target, error) {
 //  * All required parameters are set
 //  * There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetTargetRequest) error {
-	return handlers.ValidateGetRequest(target.TcpTargetPrefix, req, handlers.NoopValidatorFn)
+	return handlers.ValidateGetRequest(prefixFromId(req.GetId()), req, handlers.NoopValidatorFn)
 }
 
 func validateCreateRequest(req *pbs.CreateTargetRequest) error {
@@ -721,6 +1221,14 @@ func validateCreateRequest(req *pbs.CreateTargetRequest) error {
 		if req.GetItem().GetSessionMaxSeconds() != nil && req.GetItem().GetSessionMaxSeconds().GetValue() == 0 {
 			badFields["session_max_seconds"] = "This must be greater than zero."
 		}
+		if req.GetItem().GetHostSelectionStrategy() != nil && target.HostSelectionStrategyFromString(req.GetItem().GetHostSelectionStrategy().GetValue()) == target.UnknownHostSelection {
+			badFields["host_selection_strategy"] = "Unknown host selection strategy provided."
+		}
+		if req.GetItem().GetWorkerFilter() != nil {
+			if _, err := handlers.NewFilter(req.GetItem().GetWorkerFilter().GetValue()); err != nil {
+				badFields["worker_filter"] = fmt.Sprintf("This field could not be parsed: %v.", err)
+			}
+		}
 		switch target.SubtypeFromType(req.GetItem().GetType()) {
 		case target.TcpSubType:
 			tcpAttrs := &pb.TcpTargetAttributes{}
@@ -730,9 +1238,21 @@ func validateCreateRequest(req *pbs.CreateTargetRequest) error {
 			if tcpAttrs.GetDefaultPort() != nil && tcpAttrs.GetDefaultPort().GetValue() == 0 {
 				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
 			}
+		case target.UdpSubType:
+			udpAttrs := &pb.UdpTargetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), udpAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+			}
+			if udpAttrs.GetDefaultPort() != nil && udpAttrs.GetDefaultPort().GetValue() == 0 {
+				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
+			}
+		case target.SshSubType:
+			validateSshAttributes(req.GetItem().GetAttributes(), badFields)
 		}
 		switch req.GetItem().GetType() {
 		case target.TcpTargetType.String():
+		case target.UdpTargetType.String():
+		case target.SshTargetType.String():
 		case "":
 			badFields["type"] = "This is a required field."
 		default:
@@ -743,7 +1263,7 @@ func validateCreateRequest(req *pbs.CreateTargetRequest) error {
 }
 
 func validateUpdateRequest(req *pbs.UpdateTargetRequest) error {
-	return handlers.ValidateUpdateRequest(target.TcpTargetPrefix, req, req.GetItem(), func() map[string]string {
+	return handlers.ValidateUpdateRequest(prefixFromId(req.GetId()), req, req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
 		if handlers.MaskContains(req.GetUpdateMask().GetPaths(), "name") && req.GetItem().GetName().GetValue() == "" {
 			badFields["name"] = "This field cannot be set to empty."
@@ -760,7 +1280,15 @@ func validateUpdateRequest(req *pbs.UpdateTargetRequest) error {
 		if req.GetItem().GetSessionMaxSeconds() != nil && req.GetItem().GetSessionMaxSeconds().GetValue() == 0 {
 			badFields["session_max_seconds"] = "This must be greater than zero."
 		}
-		switch target.SubtypeFromId(req.GetItem().GetType()) {
+		if req.GetItem().GetHostSelectionStrategy() != nil && target.HostSelectionStrategyFromString(req.GetItem().GetHostSelectionStrategy().GetValue()) == target.UnknownHostSelection {
+			badFields["host_selection_strategy"] = "Unknown host selection strategy provided."
+		}
+		if req.GetItem().GetWorkerFilter() != nil {
+			if _, err := handlers.NewFilter(req.GetItem().GetWorkerFilter().GetValue()); err != nil {
+				badFields["worker_filter"] = fmt.Sprintf("This field could not be parsed: %v.", err)
+			}
+		}
+		switch target.SubtypeFromId(req.GetId()) {
 		case target.TcpSubType:
 			if req.GetItem().GetType() != "" && target.SubtypeFromType(req.GetItem().GetType()) != target.TcpSubType {
 				badFields["type"] = "Cannot modify the resource type."
@@ -772,20 +1300,74 @@ func validateUpdateRequest(req *pbs.UpdateTargetRequest) error {
 			if tcpAttrs.GetDefaultPort() != nil && tcpAttrs.GetDefaultPort().GetValue() == 0 {
 				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
 			}
+		case target.UdpSubType:
+			if req.GetItem().GetType() != "" && target.SubtypeFromType(req.GetItem().GetType()) != target.UdpSubType {
+				badFields["type"] = "Cannot modify the resource type."
+			}
+			udpAttrs := &pb.UdpTargetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), udpAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+			}
+			if udpAttrs.GetDefaultPort() != nil && udpAttrs.GetDefaultPort().GetValue() == 0 {
+				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
+			}
+		case target.SshSubType:
+			if req.GetItem().GetType() != "" && target.SubtypeFromType(req.GetItem().GetType()) != target.SshSubType {
+				badFields["type"] = "Cannot modify the resource type."
+			}
+			validateSshAttributes(req.GetItem().GetAttributes(), badFields)
 		}
 		return badFields
 	})
 }
 
+// validateSshAttributes adds the problems found with the attributes of an ssh
+// target to badFields.
+func validateSshAttributes(attributes *structpb.Struct, badFields map[string]string) {
+	sshAttrs := &pb.SshTargetAttributes{}
+	if err := handlers.StructToProto(attributes, sshAttrs); err != nil {
+		badFields["attributes"] = "Attribute fields do not match the expected format."
+		return
+	}
+	if sshAttrs.GetDefaultPort() != nil && sshAttrs.GetDefaultPort().GetValue() == 0 {
+		badFields["attributes.default_port"] = "This optional field cannot be set to 0."
+	}
+	for _, p := range sshAttrs.GetPrincipals() {
+		if !validSshPrincipal(p) {
+			badFields["attributes.principals"] = "Principals cannot be empty or contain commas or whitespace."
+			break
+		}
+	}
+	if sshAttrs.GetCaPublicKey() != "" {
+		badFields["attributes.ca_public_key"] = "This is a read only field."
+	}
+	if len(sshAttrs.GetCaPublicKeys()) > 0 {
+		badFields["attributes.ca_public_keys"] = "This is a read only field."
+	}
+}
+
 func validateDeleteRequest(req *pbs.DeleteTargetRequest) error {
-	return handlers.ValidateDeleteRequest(target.TcpTargetPrefix, req, handlers.NoopValidatorFn)
+	return handlers.ValidateDeleteRequest(prefixFromId(req.GetId()), req, handlers.NoopValidatorFn)
 }
 
 func validateListRequest(req *pbs.ListTargetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) {
+	switch {
+	case req.GetRecursive():
+		if req.GetScopeId() != scope.Global.String() &&
+			!handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()) &&
+			!handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) {
+			badFields["scope_id"] = "This field must be 'global' or a valid org or project scope id when listing recursively."
+		}
+	case !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()):
 		badFields["scope_id"] = "This field is required to have a properly formatted project scope id."
 	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if _, err := handlers.DecodePageToken(req.GetPageToken()); err != nil {
+		badFields["page_token"] = "This is not a valid page token."
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
 	}
@@ -794,7 +1376,7 @@ func validateListRequest(req *pbs.ListTargetsRequest) error {
 
 func validateAddRequest(req *pbs.AddTargetHostSetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(target.TcpTargetPrefix, req.GetId()) {
+	if !handlers.ValidId(prefixFromId(req.GetId()), req.GetId()) {
 		badFields["id"] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -804,7 +1386,7 @@ func validateAddRequest(req *pbs.AddTargetHostSetsRequest) error {
 		badFields["host_set_ids"] = "Must be non-empty."
 	}
 	for _, id := range req.GetHostSetIds() {
-		if !handlers.ValidId(static.HostSetPrefix, id) {
+		if !validHostSetId(id) {
 			badFields["host_set_ids"] = fmt.Sprintf("Incorrectly formatted host set identifier %q.", id)
 			break
 		}
@@ -817,14 +1399,14 @@ func validateAddRequest(req *pbs.AddTargetHostSetsRequest) error {
 
 func validateSetRequest(req *pbs.SetTargetHostSetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(target.TcpTargetPrefix, req.GetId()) {
+	if !handlers.ValidId(prefixFromId(req.GetId()), req.GetId()) {
 		badFields["id"] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
 		badFields["version"] = "Required field."
 	}
 	for _, id := range req.GetHostSetIds() {
-		if !handlers.ValidId(static.HostSetPrefix, id) {
+		if !validHostSetId(id) {
 			badFields["host_set_ids"] = fmt.Sprintf("Incorrectly formatted host set identifier %q.", id)
 			break
 		}
@@ -837,7 +1419,7 @@ func validateSetRequest(req *pbs.SetTargetHostSetsRequest) error {
 
 func validateRemoveRequest(req *pbs.RemoveTargetHostSetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(target.TcpTargetPrefix, req.GetId()) {
+	if !handlers.ValidId(prefixFromId(req.GetId()), req.GetId()) {
 		badFields["id"] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -847,7 +1429,7 @@ func validateRemoveRequest(req *pbs.RemoveTargetHostSetsRequest) error {
 		badFields["host_set_ids"] = "Must be non-empty."
 	}
 	for _, id := range req.GetHostSetIds() {
-		if !handlers.ValidId(static.HostSetPrefix, id) {
+		if !validHostSetId(id) {
 			badFields["host_set_ids"] = fmt.Sprintf("Incorrectly formatted host set identifier %q.", id)
 			break
 		}
@@ -858,14 +1440,80 @@ func validateRemoveRequest(req *pbs.RemoveTargetHostSetsRequest) error {
 	return nil
 }
 
+func validateAddCredentialLibrariesRequest(req *pbs.AddTargetCredentialLibrariesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(prefixFromId(req.GetId()), req.GetId()) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(req.GetCredentialLibraryIds()) == 0 {
+		badFields["credential_library_ids"] = "Must be non-empty."
+	}
+	for _, id := range req.GetCredentialLibraryIds() {
+		if !handlers.ValidId(credstatic.CredentialLibraryPrefix, id) {
+			badFields["credential_library_ids"] = fmt.Sprintf("Incorrectly formatted credential library identifier %q.", id)
+			break
+		}
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateSetCredentialLibrariesRequest(req *pbs.SetTargetCredentialLibrariesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(prefixFromId(req.GetId()), req.GetId()) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	for _, id := range req.GetCredentialLibraryIds() {
+		if !handlers.ValidId(credstatic.CredentialLibraryPrefix, id) {
+			badFields["credential_library_ids"] = fmt.Sprintf("Incorrectly formatted credential library identifier %q.", id)
+			break
+		}
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateRemoveCredentialLibrariesRequest(req *pbs.RemoveTargetCredentialLibrariesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(prefixFromId(req.GetId()), req.GetId()) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(req.GetCredentialLibraryIds()) == 0 {
+		badFields["credential_library_ids"] = "Must be non-empty."
+	}
+	for _, id := range req.GetCredentialLibraryIds() {
+		if !handlers.ValidId(credstatic.CredentialLibraryPrefix, id) {
+			badFields["credential_library_ids"] = fmt.Sprintf("Incorrectly formatted credential library identifier %q.", id)
+			break
+		}
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
 func validateAuthorizeSessionRequest(req *pbs.AuthorizeSessionRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(target.TcpTargetPrefix, req.GetId()) {
+	if !handlers.ValidId(prefixFromId(req.GetId()), req.GetId()) {
 		badFields["id"] = "Incorrectly formatted identifier."
 	}
 	if req.GetHostId() != "" {
 		switch host.SubtypeFromId(req.GetHostId()) {
-		case host.StaticSubtype:
+		case host.StaticSubtype, host.InventorySubtype:
 		default:
 			badFields["host_id"] = "Incorrectly formatted identifier."
 		}
@@ -875,3 +1523,22 @@ func validateAuthorizeSessionRequest(req *pbs.AuthorizeSessionRequest) error {
 	}
 	return nil
 }
+
+// validHostSetId reports whether id is a well formed id of a host set of any
+// subtype.
+func validHostSetId(id string) bool {
+	return handlers.ValidId(static.HostSetPrefix, id) || handlers.ValidId(inventory.HostSetPrefix, id)
+}
+
+// prefixFromId returns the id prefix of the target subtype the provided id
+// belongs to.  The tcp prefix is returned for ids of an unknown subtype so they
+// fail validation.
+func prefixFromId(id string) string {
+	switch target.SubtypeFromId(id) {
+	case target.UdpSubType:
+		return target.UdpTargetPrefix
+	case target.SshSubType:
+		return target.SshTargetPrefix
+	}
+	return target.TcpTargetPrefix
+}
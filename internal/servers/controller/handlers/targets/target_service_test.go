@@ -4,16 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/auth"
+	credstatic "github.com/hashicorp/boundary/internal/credential/static"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/host/inventory"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
@@ -27,6 +30,7 @@ import (
 	"github.com/jinzhu/gorm"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
 	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -53,7 +57,13 @@ func testService(t *testing.T, conn *gorm.DB, kms *kms.Kms, wrapper wrapping.Wra
 	staticHostRepoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
-	return targets.NewService(kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, staticHostRepoFn)
+	inventoryHostRepoFn := func() (*inventory.Repository, error) {
+		return inventory.NewRepository(rw, rw, kms)
+	}
+	staticCredRepoFn := func() (*credstatic.Repository, error) {
+		return credstatic.NewRepository(rw, rw, kms)
+	}
+	return targets.NewService(kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, staticHostRepoFn, inventoryHostRepoFn, staticCredRepoFn)
 }
 
 func TestGet(t *testing.T) {
@@ -70,17 +80,24 @@ func TestGet(t *testing.T) {
 	tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), "test", target.WithHostSets([]string{hs[0].GetPublicId(), hs[1].GetPublicId()}))
 
 	pTar := &pb.Target{
-		Id:                     tar.GetPublicId(),
-		ScopeId:                proj.GetPublicId(),
-		Name:                   wrapperspb.String("test"),
-		CreatedTime:            tar.CreateTime.GetTimestamp(),
-		UpdatedTime:            tar.UpdateTime.GetTimestamp(),
-		Scope:                  &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
-		Type:                   target.TcpTargetType.String(),
-		HostSetIds:             []string{hs[0].GetPublicId(), hs[1].GetPublicId()},
-		Attributes:             new(structpb.Struct),
-		SessionMaxSeconds:      wrapperspb.UInt32(28800),
-		SessionConnectionLimit: wrapperspb.Int32(1),
+		Id:                          tar.GetPublicId(),
+		ScopeId:                     proj.GetPublicId(),
+		Name:                        wrapperspb.String("test"),
+		CreatedTime:                 tar.CreateTime.GetTimestamp(),
+		UpdatedTime:                 tar.UpdateTime.GetTimestamp(),
+		Scope:                       &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+		Type:                        target.TcpTargetType.String(),
+		HostSetIds:                  []string{hs[0].GetPublicId(), hs[1].GetPublicId()},
+		Attributes:                  new(structpb.Struct),
+		SessionMaxSeconds:           wrapperspb.UInt32(28800),
+		SessionConnectionLimit:      wrapperspb.Int32(1),
+		EnableSessionRecording:      wrapperspb.Bool(false),
+		ConnectionMaxBytesPerSecond: wrapperspb.UInt32(0),
+		SessionMaxBytesPerSecond:    wrapperspb.UInt32(0),
+		SessionIdleTimeoutSeconds:   wrapperspb.UInt32(0),
+		HostSelectionStrategy:       wrapperspb.String("random"),
+		RestrictHostId:              wrapperspb.Bool(false),
+		AuthorizedActions:           targets.IdActions.Strings(),
 	}
 	for _, ihs := range hs {
 		pTar.HostSets = append(pTar.HostSets, &pb.HostSet{Id: ihs.GetPublicId(), HostCatalogId: ihs.GetCatalogId()})
@@ -152,20 +169,29 @@ func TestList(t *testing.T) {
 		name := fmt.Sprintf("tar%d", i)
 		tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), name, target.WithHostSets([]string{hss[0].GetPublicId(), hss[1].GetPublicId()}))
 		wantTars = append(wantTars, &pb.Target{
-			Id:                     tar.GetPublicId(),
-			ScopeId:                proj.GetPublicId(),
-			Name:                   wrapperspb.String(name),
-			Scope:                  &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
-			CreatedTime:            tar.GetCreateTime().GetTimestamp(),
-			UpdatedTime:            tar.GetUpdateTime().GetTimestamp(),
-			Version:                tar.GetVersion(),
-			Type:                   target.TcpTargetType.String(),
-			Attributes:             new(structpb.Struct),
-			SessionMaxSeconds:      wrapperspb.UInt32(28800),
-			SessionConnectionLimit: wrapperspb.Int32(1),
+			Id:                          tar.GetPublicId(),
+			ScopeId:                     proj.GetPublicId(),
+			Name:                        wrapperspb.String(name),
+			Scope:                       &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+			CreatedTime:                 tar.GetCreateTime().GetTimestamp(),
+			UpdatedTime:                 tar.GetUpdateTime().GetTimestamp(),
+			Version:                     tar.GetVersion(),
+			Type:                        target.TcpTargetType.String(),
+			Attributes:                  new(structpb.Struct),
+			SessionMaxSeconds:           wrapperspb.UInt32(28800),
+			SessionConnectionLimit:      wrapperspb.Int32(1),
+			EnableSessionRecording:      wrapperspb.Bool(false),
+			ConnectionMaxBytesPerSecond: wrapperspb.UInt32(0),
+			SessionMaxBytesPerSecond:    wrapperspb.UInt32(0),
+			SessionIdleTimeoutSeconds:   wrapperspb.UInt32(0),
+			HostSelectionStrategy:       wrapperspb.String("random"),
+			RestrictHostId:              wrapperspb.Bool(false),
+			AuthorizedActions:           targets.IdActions.Strings(),
 		})
 	}
 
+	sort.Slice(wantTars, func(i, j int) bool { return wantTars[i].GetId() < wantTars[j].GetId() })
+
 	cases := []struct {
 		name    string
 		scopeId string
@@ -199,6 +225,69 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestList_Recursive(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+
+	org, proj1 := iam.TestScopes(t, iamRepo)
+	proj2, err := iam.NewProject(org.GetPublicId())
+	require.NoError(t, err)
+	proj2, err = iamRepo.CreateScope(context.Background(), proj2, "")
+	require.NoError(t, err)
+	_, otherProj := iam.TestScopes(t, iamRepo)
+
+	wantScopes := map[string]string{}
+	for _, p := range []*iam.Scope{proj1, proj2, otherProj} {
+		for i := 0; i < 2; i++ {
+			tar := target.TestTcpTarget(t, conn, p.GetPublicId(), fmt.Sprintf("tar%d", i))
+			if p != otherProj {
+				wantScopes[tar.GetPublicId()] = p.GetPublicId()
+			}
+		}
+	}
+
+	s, err := testService(t, conn, kms, wrapper)
+	require.NoError(t, err)
+
+	t.Run("org", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ListTargets(auth.DisabledAuthTestContext(), &pbs.ListTargetsRequest{ScopeId: org.GetPublicId(), Recursive: true})
+		require.NoError(err)
+		gotScopes := map[string]string{}
+		for _, item := range got.GetItems() {
+			gotScopes[item.GetId()] = item.GetScope().GetId()
+			assert.Equal(org.GetPublicId(), item.GetScope().GetParentScopeId())
+		}
+		assert.Equal(wantScopes, gotScopes)
+		assert.True(sort.SliceIsSorted(got.GetItems(), func(i, j int) bool { return got.GetItems()[i].GetId() < got.GetItems()[j].GetId() }))
+	})
+
+	t.Run("paged", func(t *testing.T) {
+		require := require.New(t)
+		req := &pbs.ListTargetsRequest{ScopeId: scope.Global.String(), Recursive: true, PageSize: 4}
+		var ids []string
+		for {
+			got, err := s.ListTargets(auth.DisabledAuthTestContext(), req)
+			require.NoError(err)
+			for _, item := range got.GetItems() {
+				ids = append(ids, item.GetId())
+			}
+			if got.GetNextPageToken() == "" {
+				break
+			}
+			req.PageToken = got.GetNextPageToken()
+		}
+		assert.Len(t, ids, 6)
+	})
+
+	t.Run("not recursive from org", func(t *testing.T) {
+		_, err := s.ListTargets(auth.DisabledAuthTestContext(), &pbs.ListTargetsRequest{ScopeId: org.GetPublicId()})
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+	})
+}
+
 func TestDelete(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -287,6 +376,9 @@ func TestCreate(t *testing.T) {
 	kms := kms.TestKms(t, conn, wrapper)
 
 	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ca, err := kms.SshCertificateAuthority(context.Background(), proj.GetPublicId())
+	require.NoError(t, err)
+	caPublicKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(ca.PublicKey())))
 
 	cases := []struct {
 		name string
@@ -316,11 +408,189 @@ func TestCreate(t *testing.T) {
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
 						"default_port": structpb.NewNumberValue(2),
 					}},
-					SessionMaxSeconds:      wrapperspb.UInt32(28800),
-					SessionConnectionLimit: wrapperspb.Int32(1),
+					SessionMaxSeconds:           wrapperspb.UInt32(28800),
+					SessionConnectionLimit:      wrapperspb.Int32(1),
+					EnableSessionRecording:      wrapperspb.Bool(false),
+					ConnectionMaxBytesPerSecond: wrapperspb.UInt32(0),
+					SessionMaxBytesPerSecond:    wrapperspb.UInt32(0),
+					SessionIdleTimeoutSeconds:   wrapperspb.UInt32(0),
+					HostSelectionStrategy:       wrapperspb.String("random"),
+					RestrictHostId:              wrapperspb.Bool(false),
 				},
 			},
 		},
+		{
+			name: "Create a valid udp target",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId:     proj.GetPublicId(),
+				Name:        wrapperspb.String("udp name"),
+				Description: wrapperspb.String("desc"),
+				Type:        target.UdpTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"default_port": structpb.NewNumberValue(53),
+				}},
+				ConnectionMaxBytesPerSecond: wrapperspb.UInt32(1024),
+				SessionMaxBytesPerSecond:    wrapperspb.UInt32(4096),
+				SessionIdleTimeoutSeconds:   wrapperspb.UInt32(600),
+			}},
+			res: &pbs.CreateTargetResponse{
+				Uri: fmt.Sprintf("targets/%s_", target.UdpTargetPrefix),
+				Item: &pb.Target{
+					ScopeId:     proj.GetPublicId(),
+					Scope:       &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+					Name:        wrapperspb.String("udp name"),
+					Description: wrapperspb.String("desc"),
+					Type:        target.UdpTargetType.String(),
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"default_port": structpb.NewNumberValue(53),
+					}},
+					SessionMaxSeconds:           wrapperspb.UInt32(28800),
+					SessionConnectionLimit:      wrapperspb.Int32(1),
+					EnableSessionRecording:      wrapperspb.Bool(false),
+					ConnectionMaxBytesPerSecond: wrapperspb.UInt32(1024),
+					SessionMaxBytesPerSecond:    wrapperspb.UInt32(4096),
+					SessionIdleTimeoutSeconds:   wrapperspb.UInt32(600),
+					HostSelectionStrategy:       wrapperspb.String("random"),
+					RestrictHostId:              wrapperspb.Bool(false),
+				},
+			},
+		},
+		{
+			name: "Create a valid ssh target",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId:     proj.GetPublicId(),
+				Name:        wrapperspb.String("ssh name"),
+				Description: wrapperspb.String("desc"),
+				Type:        target.SshTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"default_port": structpb.NewNumberValue(22),
+					"principals": structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{
+						structpb.NewStringValue("admin"),
+						structpb.NewStringValue("deploy"),
+					}}),
+				}},
+			}},
+			res: &pbs.CreateTargetResponse{
+				Uri: fmt.Sprintf("targets/%s_", target.SshTargetPrefix),
+				Item: &pb.Target{
+					ScopeId:     proj.GetPublicId(),
+					Scope:       &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+					Name:        wrapperspb.String("ssh name"),
+					Description: wrapperspb.String("desc"),
+					Type:        target.SshTargetType.String(),
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"default_port": structpb.NewNumberValue(22),
+						"principals": structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{
+							structpb.NewStringValue("admin"),
+							structpb.NewStringValue("deploy"),
+						}}),
+						"ca_public_key": structpb.NewStringValue(caPublicKey),
+						"ca_public_keys": structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{
+							structpb.NewStringValue(caPublicKey),
+						}}),
+					}},
+					SessionMaxSeconds:           wrapperspb.UInt32(28800),
+					SessionConnectionLimit:      wrapperspb.Int32(1),
+					EnableSessionRecording:      wrapperspb.Bool(false),
+					ConnectionMaxBytesPerSecond: wrapperspb.UInt32(0),
+					SessionMaxBytesPerSecond:    wrapperspb.UInt32(0),
+					SessionIdleTimeoutSeconds:   wrapperspb.UInt32(0),
+					HostSelectionStrategy:       wrapperspb.String("random"),
+					RestrictHostId:              wrapperspb.Bool(false),
+				},
+			},
+		},
+		{
+			name: "Create a target with a host selection strategy",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId:               proj.GetPublicId(),
+				Name:                  wrapperspb.String("round robin name"),
+				Type:                  target.TcpTargetType.String(),
+				HostSelectionStrategy: wrapperspb.String("round-robin"),
+				RestrictHostId:        wrapperspb.Bool(true),
+			}},
+			res: &pbs.CreateTargetResponse{
+				Uri: fmt.Sprintf("targets/%s_", target.TcpTargetPrefix),
+				Item: &pb.Target{
+					ScopeId:                     proj.GetPublicId(),
+					Scope:                       &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+					Name:                        wrapperspb.String("round robin name"),
+					Type:                        target.TcpTargetType.String(),
+					Attributes:                  new(structpb.Struct),
+					SessionMaxSeconds:           wrapperspb.UInt32(28800),
+					SessionConnectionLimit:      wrapperspb.Int32(1),
+					EnableSessionRecording:      wrapperspb.Bool(false),
+					ConnectionMaxBytesPerSecond: wrapperspb.UInt32(0),
+					SessionMaxBytesPerSecond:    wrapperspb.UInt32(0),
+					SessionIdleTimeoutSeconds:   wrapperspb.UInt32(0),
+					HostSelectionStrategy:       wrapperspb.String("round-robin"),
+					RestrictHostId:              wrapperspb.Bool(true),
+				},
+			},
+		},
+		{
+			name: "Create a target with a worker filter",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId:      proj.GetPublicId(),
+				Name:         wrapperspb.String("worker filter name"),
+				Type:         target.TcpTargetType.String(),
+				WorkerFilter: wrapperspb.String(`"us-east" in "/tags/region"`),
+			}},
+			res: &pbs.CreateTargetResponse{
+				Uri: fmt.Sprintf("targets/%s_", target.TcpTargetPrefix),
+				Item: &pb.Target{
+					ScopeId:                     proj.GetPublicId(),
+					Scope:                       &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+					Name:                        wrapperspb.String("worker filter name"),
+					Type:                        target.TcpTargetType.String(),
+					Attributes:                  new(structpb.Struct),
+					SessionMaxSeconds:           wrapperspb.UInt32(28800),
+					SessionConnectionLimit:      wrapperspb.Int32(1),
+					EnableSessionRecording:      wrapperspb.Bool(false),
+					ConnectionMaxBytesPerSecond: wrapperspb.UInt32(0),
+					SessionMaxBytesPerSecond:    wrapperspb.UInt32(0),
+					SessionIdleTimeoutSeconds:   wrapperspb.UInt32(0),
+					HostSelectionStrategy:       wrapperspb.String("random"),
+					RestrictHostId:              wrapperspb.Bool(false),
+					WorkerFilter:                wrapperspb.String(`"us-east" in "/tags/region"`),
+				},
+			},
+		},
+		{
+			name: "Create with an invalid worker filter",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId:      proj.GetPublicId(),
+				Name:         wrapperspb.String("name"),
+				Type:         target.TcpTargetType.String(),
+				WorkerFilter: wrapperspb.String(`"/tags/region" ==`),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Create with an unknown host selection strategy",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId:               proj.GetPublicId(),
+				Name:                  wrapperspb.String("name"),
+				Type:                  target.TcpTargetType.String(),
+				HostSelectionStrategy: wrapperspb.String("fastest"),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Create an ssh target with an invalid principal",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("ssh bad principal"),
+				Type:    target.SshTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"principals": structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{
+						structpb.NewStringValue("admin,root"),
+					}}),
+				}},
+			}},
+			res: nil,
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 		{
 			name: "Create with default port 0",
 			req: &pbs.CreateTargetRequest{Item: &pb.Target{
@@ -389,7 +659,7 @@ func TestCreate(t *testing.T) {
 			}
 			if got != nil {
 				assert.Contains(got.GetUri(), tc.res.GetUri())
-				assert.True(strings.HasPrefix(got.GetItem().GetId(), target.TcpTargetPrefix), got.GetItem().GetId())
+				assert.True(strings.HasPrefix(got.GetItem().GetId(), strings.TrimPrefix(tc.res.GetUri(), "targets/")), got.GetItem().GetId())
 
 				// Clear all values which are hard to compare against.
 				got.Uri, tc.res.Uri = "", ""
@@ -462,13 +732,14 @@ func TestUpdate(t *testing.T) {
 			name: "Update an Existing Target",
 			req: &pbs.UpdateTargetRequest{
 				UpdateMask: &field_mask.FieldMask{
-					Paths: []string{"name", "description", "session_max_seconds", "session_connection_limit", "type"},
+					Paths: []string{"name", "description", "session_max_seconds", "session_connection_limit", "enable_session_recording", "type"},
 				},
 				Item: &pb.Target{
 					Name:                   wrapperspb.String("name"),
 					Description:            wrapperspb.String("desc"),
 					SessionMaxSeconds:      wrapperspb.UInt32(3600),
 					SessionConnectionLimit: wrapperspb.Int32(5),
+					EnableSessionRecording: wrapperspb.Bool(true),
 					Type:                   target.TcpSubType.String(),
 				},
 			},
@@ -483,11 +754,17 @@ func TestUpdate(t *testing.T) {
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
 						"default_port": structpb.NewNumberValue(2),
 					}},
-					CreatedTime:            tar.GetCreateTime().GetTimestamp(),
-					HostSetIds:             hsIds,
-					HostSets:               hostSets,
-					SessionMaxSeconds:      wrapperspb.UInt32(3600),
-					SessionConnectionLimit: wrapperspb.Int32(5),
+					CreatedTime:                 tar.GetCreateTime().GetTimestamp(),
+					HostSetIds:                  hsIds,
+					HostSets:                    hostSets,
+					SessionMaxSeconds:           wrapperspb.UInt32(3600),
+					SessionConnectionLimit:      wrapperspb.Int32(5),
+					EnableSessionRecording:      wrapperspb.Bool(true),
+					ConnectionMaxBytesPerSecond: wrapperspb.UInt32(0),
+					SessionMaxBytesPerSecond:    wrapperspb.UInt32(0),
+					SessionIdleTimeoutSeconds:   wrapperspb.UInt32(0),
+					HostSelectionStrategy:       wrapperspb.String("random"),
+					RestrictHostId:              wrapperspb.Bool(false),
 				},
 			},
 		},
@@ -515,10 +792,16 @@ func TestUpdate(t *testing.T) {
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
 						"default_port": structpb.NewNumberValue(2),
 					}},
-					HostSetIds:             hsIds,
-					HostSets:               hostSets,
-					SessionMaxSeconds:      wrapperspb.UInt32(3600),
-					SessionConnectionLimit: wrapperspb.Int32(5),
+					HostSetIds:                  hsIds,
+					HostSets:                    hostSets,
+					SessionMaxSeconds:           wrapperspb.UInt32(3600),
+					SessionConnectionLimit:      wrapperspb.Int32(5),
+					EnableSessionRecording:      wrapperspb.Bool(true),
+					ConnectionMaxBytesPerSecond: wrapperspb.UInt32(0),
+					SessionMaxBytesPerSecond:    wrapperspb.UInt32(0),
+					SessionIdleTimeoutSeconds:   wrapperspb.UInt32(0),
+					HostSelectionStrategy:       wrapperspb.String("random"),
+					RestrictHostId:              wrapperspb.Bool(false),
 				},
 			},
 		},
@@ -599,10 +882,16 @@ func TestUpdate(t *testing.T) {
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
 						"default_port": structpb.NewNumberValue(2),
 					}},
-					HostSetIds:             hsIds,
-					HostSets:               hostSets,
-					SessionMaxSeconds:      wrapperspb.UInt32(3600),
-					SessionConnectionLimit: wrapperspb.Int32(5),
+					HostSetIds:                  hsIds,
+					HostSets:                    hostSets,
+					SessionMaxSeconds:           wrapperspb.UInt32(3600),
+					SessionConnectionLimit:      wrapperspb.Int32(5),
+					EnableSessionRecording:      wrapperspb.Bool(true),
+					ConnectionMaxBytesPerSecond: wrapperspb.UInt32(0),
+					SessionMaxBytesPerSecond:    wrapperspb.UInt32(0),
+					SessionIdleTimeoutSeconds:   wrapperspb.UInt32(0),
+					HostSelectionStrategy:       wrapperspb.String("random"),
+					RestrictHostId:              wrapperspb.Bool(false),
 				},
 			},
 		},
@@ -629,10 +918,16 @@ func TestUpdate(t *testing.T) {
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
 						"default_port": structpb.NewNumberValue(2),
 					}},
-					HostSetIds:             hsIds,
-					HostSets:               hostSets,
-					SessionMaxSeconds:      wrapperspb.UInt32(3600),
-					SessionConnectionLimit: wrapperspb.Int32(5),
+					HostSetIds:                  hsIds,
+					HostSets:                    hostSets,
+					SessionMaxSeconds:           wrapperspb.UInt32(3600),
+					SessionConnectionLimit:      wrapperspb.Int32(5),
+					EnableSessionRecording:      wrapperspb.Bool(true),
+					ConnectionMaxBytesPerSecond: wrapperspb.UInt32(0),
+					SessionMaxBytesPerSecond:    wrapperspb.UInt32(0),
+					SessionIdleTimeoutSeconds:   wrapperspb.UInt32(0),
+					HostSelectionStrategy:       wrapperspb.String("random"),
+					RestrictHostId:              wrapperspb.Bool(false),
 				},
 			},
 		},
@@ -658,11 +953,17 @@ func TestUpdate(t *testing.T) {
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
 						"default_port": structpb.NewNumberValue(2),
 					}},
-					Type:                   target.TcpTargetType.String(),
-					HostSetIds:             hsIds,
-					HostSets:               hostSets,
-					SessionMaxSeconds:      wrapperspb.UInt32(3600),
-					SessionConnectionLimit: wrapperspb.Int32(5),
+					Type:                        target.TcpTargetType.String(),
+					HostSetIds:                  hsIds,
+					HostSets:                    hostSets,
+					SessionMaxSeconds:           wrapperspb.UInt32(3600),
+					SessionConnectionLimit:      wrapperspb.Int32(5),
+					EnableSessionRecording:      wrapperspb.Bool(true),
+					ConnectionMaxBytesPerSecond: wrapperspb.UInt32(0),
+					SessionMaxBytesPerSecond:    wrapperspb.UInt32(0),
+					SessionIdleTimeoutSeconds:   wrapperspb.UInt32(0),
+					HostSelectionStrategy:       wrapperspb.String("random"),
+					RestrictHostId:              wrapperspb.Bool(false),
 				},
 			},
 		},
@@ -1136,3 +1437,360 @@ func TestRemoveTargetHostSets(t *testing.T) {
 		})
 	}
 }
+
+func testCredentialLibraryIds(t *testing.T, conn *gorm.DB, kmsCache *kms.Kms, scopeId string, count int) []string {
+	t.Helper()
+	wrapper, err := kmsCache.GetWrapper(context.Background(), scopeId, kms.KeyPurposeCredentials)
+	require.NoError(t, err)
+	cs := credstatic.TestCredentialStores(t, conn, scopeId, 1)[0]
+	libs := credstatic.TestCredentialLibraries(t, conn, wrapper, cs.GetPublicId(), count)
+	ids := make([]string, 0, len(libs))
+	for _, l := range libs {
+		ids = append(ids, l.GetPublicId())
+	}
+	return ids
+}
+
+func testTargetWithCredentialLibraries(t *testing.T, conn *gorm.DB, kmsCache *kms.Kms, scopeId, name string, libIds []string) *target.TcpTarget {
+	t.Helper()
+	tar := target.TestTcpTarget(t, conn, scopeId, name)
+	if len(libIds) == 0 {
+		return tar
+	}
+	rw := db.New(conn)
+	repo, err := target.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	got, _, err := repo.AddTargetCredentialLibraries(context.Background(), tar.GetPublicId(), tar.GetVersion(), libIds)
+	require.NoError(t, err)
+	return got.(*target.TcpTarget)
+}
+
+func TestAddTargetCredentialLibraries(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	s, err := testService(t, conn, kms, wrapper)
+	require.NoError(t, err, "Error when getting new target service.")
+
+	libs := testCredentialLibraryIds(t, conn, kms, proj.GetPublicId(), 2)
+
+	addCases := []struct {
+		name       string
+		tar        *target.TcpTarget
+		addLibs    []string
+		resultLibs []string
+	}{
+		{
+			name:       "Add library on empty target",
+			tar:        testTargetWithCredentialLibraries(t, conn, kms, proj.GetPublicId(), "empty", nil),
+			addLibs:    []string{libs[1]},
+			resultLibs: []string{libs[1]},
+		},
+		{
+			name:       "Add library on populated target",
+			tar:        testTargetWithCredentialLibraries(t, conn, kms, proj.GetPublicId(), "populated", []string{libs[0]}),
+			addLibs:    []string{libs[1]},
+			resultLibs: []string{libs[0], libs[1]},
+		},
+		{
+			name:       "Add duplicated libraries on populated target",
+			tar:        testTargetWithCredentialLibraries(t, conn, kms, proj.GetPublicId(), "duplicated", []string{libs[0]}),
+			addLibs:    []string{libs[1], libs[1]},
+			resultLibs: []string{libs[0], libs[1]},
+		},
+	}
+
+	for _, tc := range addCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &pbs.AddTargetCredentialLibrariesRequest{
+				Id:                   tc.tar.GetPublicId(),
+				Version:              tc.tar.GetVersion(),
+				CredentialLibraryIds: tc.addLibs,
+			}
+
+			got, err := s.AddTargetCredentialLibraries(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), req)
+			s, ok := status.FromError(err)
+			require.True(t, ok)
+			require.NoError(t, err, "Got error: %v", s)
+
+			assert.ElementsMatch(t, tc.resultLibs, got.GetItem().GetCredentialLibraryIds())
+			assert.Len(t, got.GetItem().GetCredentialLibraries(), len(tc.resultLibs))
+		})
+	}
+
+	tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), "test")
+
+	failCases := []struct {
+		name string
+		req  *pbs.AddTargetCredentialLibrariesRequest
+		err  error
+	}{
+		{
+			name: "Bad target id",
+			req: &pbs.AddTargetCredentialLibrariesRequest{
+				Id:                   "bad id",
+				Version:              tar.GetVersion(),
+				CredentialLibraryIds: []string{libs[0]},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad version",
+			req: &pbs.AddTargetCredentialLibrariesRequest{
+				Id:                   tar.GetPublicId(),
+				Version:              tar.GetVersion() + 2,
+				CredentialLibraryIds: []string{libs[0]},
+			},
+			err: handlers.ApiErrorWithCode(codes.Internal),
+		},
+		{
+			name: "Empty credential library list",
+			req: &pbs.AddTargetCredentialLibrariesRequest{
+				Id:      tar.GetPublicId(),
+				Version: tar.GetVersion(),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Incorrect credential library ids",
+			req: &pbs.AddTargetCredentialLibrariesRequest{
+				Id:                   tar.GetPublicId(),
+				Version:              tar.GetVersion(),
+				CredentialLibraryIds: []string{"incorrect"},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range failCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			_, gErr := s.AddTargetCredentialLibraries(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "AddTargetCredentialLibraries(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+			}
+		})
+	}
+}
+
+func TestSetTargetCredentialLibraries(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	s, err := testService(t, conn, kms, wrapper)
+	require.NoError(t, err, "Error when getting new target service.")
+
+	libs := testCredentialLibraryIds(t, conn, kms, proj.GetPublicId(), 2)
+
+	setCases := []struct {
+		name       string
+		tar        *target.TcpTarget
+		setLibs    []string
+		resultLibs []string
+	}{
+		{
+			name:       "Set on empty target",
+			tar:        testTargetWithCredentialLibraries(t, conn, kms, proj.GetPublicId(), "empty", nil),
+			setLibs:    []string{libs[1]},
+			resultLibs: []string{libs[1]},
+		},
+		{
+			name:       "Set on populated target",
+			tar:        testTargetWithCredentialLibraries(t, conn, kms, proj.GetPublicId(), "populated", []string{libs[0]}),
+			setLibs:    []string{libs[1]},
+			resultLibs: []string{libs[1]},
+		},
+		{
+			name:       "Set duplicate libraries on populated target",
+			tar:        testTargetWithCredentialLibraries(t, conn, kms, proj.GetPublicId(), "duplicate", []string{libs[0]}),
+			setLibs:    []string{libs[1], libs[1]},
+			resultLibs: []string{libs[1]},
+		},
+		{
+			name:       "Set empty on populated target",
+			tar:        testTargetWithCredentialLibraries(t, conn, kms, proj.GetPublicId(), "another populated", []string{libs[0]}),
+			setLibs:    []string{},
+			resultLibs: nil,
+		},
+	}
+
+	for _, tc := range setCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &pbs.SetTargetCredentialLibrariesRequest{
+				Id:                   tc.tar.GetPublicId(),
+				Version:              tc.tar.GetVersion(),
+				CredentialLibraryIds: tc.setLibs,
+			}
+
+			got, err := s.SetTargetCredentialLibraries(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), req)
+			s, ok := status.FromError(err)
+			require.True(t, ok)
+			require.NoError(t, err, "Got error: %v", s)
+
+			assert.ElementsMatch(t, tc.resultLibs, got.GetItem().GetCredentialLibraryIds())
+		})
+	}
+
+	tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), "test name")
+
+	failCases := []struct {
+		name string
+		req  *pbs.SetTargetCredentialLibrariesRequest
+		err  error
+	}{
+		{
+			name: "Bad target id",
+			req: &pbs.SetTargetCredentialLibrariesRequest{
+				Id:      "bad id",
+				Version: tar.GetVersion(),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad version",
+			req: &pbs.SetTargetCredentialLibrariesRequest{
+				Id:                   tar.GetPublicId(),
+				Version:              tar.GetVersion() + 3,
+				CredentialLibraryIds: []string{libs[0]},
+			},
+			err: handlers.ApiErrorWithCode(codes.Internal),
+		},
+		{
+			name: "Incorrect credential library ids",
+			req: &pbs.SetTargetCredentialLibrariesRequest{
+				Id:                   tar.GetPublicId(),
+				Version:              tar.GetVersion(),
+				CredentialLibraryIds: []string{"incorrect"},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range failCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			_, gErr := s.SetTargetCredentialLibraries(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "SetTargetCredentialLibraries(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+			}
+		})
+	}
+}
+
+func TestRemoveTargetCredentialLibraries(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	s, err := testService(t, conn, kms, wrapper)
+	require.NoError(t, err, "Error when getting new target service.")
+
+	libs := testCredentialLibraryIds(t, conn, kms, proj.GetPublicId(), 2)
+
+	removeCases := []struct {
+		name       string
+		tar        *target.TcpTarget
+		removeLibs []string
+		resultLibs []string
+		wantErr    bool
+	}{
+		{
+			name:       "Remove from empty",
+			tar:        testTargetWithCredentialLibraries(t, conn, kms, proj.GetPublicId(), "empty", nil),
+			removeLibs: []string{libs[1]},
+			wantErr:    true,
+		},
+		{
+			name:       "Remove 1 of 2 libraries",
+			tar:        testTargetWithCredentialLibraries(t, conn, kms, proj.GetPublicId(), "remove partial", libs),
+			removeLibs: []string{libs[1]},
+			resultLibs: []string{libs[0]},
+		},
+		{
+			name:       "Remove 1 duplicate library of 2 libraries",
+			tar:        testTargetWithCredentialLibraries(t, conn, kms, proj.GetPublicId(), "remove duplicate", libs),
+			removeLibs: []string{libs[1], libs[1]},
+			resultLibs: []string{libs[0]},
+		},
+		{
+			name:       "Remove all libraries",
+			tar:        testTargetWithCredentialLibraries(t, conn, kms, proj.GetPublicId(), "remove all", libs),
+			removeLibs: libs,
+			resultLibs: nil,
+		},
+	}
+
+	for _, tc := range removeCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &pbs.RemoveTargetCredentialLibrariesRequest{
+				Id:                   tc.tar.GetPublicId(),
+				Version:              tc.tar.GetVersion(),
+				CredentialLibraryIds: tc.removeLibs,
+			}
+
+			got, err := s.RemoveTargetCredentialLibraries(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), req)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			s, ok := status.FromError(err)
+			require.True(t, ok)
+			require.NoError(t, err, "Got error: %v", s)
+
+			assert.ElementsMatch(t, tc.resultLibs, got.GetItem().GetCredentialLibraryIds())
+		})
+	}
+
+	tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), "testing")
+
+	failCases := []struct {
+		name string
+		req  *pbs.RemoveTargetCredentialLibrariesRequest
+		err  error
+	}{
+		{
+			name: "Bad version",
+			req: &pbs.RemoveTargetCredentialLibrariesRequest{
+				Id:                   tar.GetPublicId(),
+				Version:              tar.GetVersion() + 3,
+				CredentialLibraryIds: []string{libs[0]},
+			},
+			err: handlers.ApiErrorWithCode(codes.Internal),
+		},
+		{
+			name: "Empty credential library list",
+			req: &pbs.RemoveTargetCredentialLibrariesRequest{
+				Id:      tar.GetPublicId(),
+				Version: tar.GetVersion(),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Incorrect credential library ids",
+			req: &pbs.RemoveTargetCredentialLibrariesRequest{
+				Id:                   tar.GetPublicId(),
+				Version:              tar.GetVersion(),
+				CredentialLibraryIds: []string{"incorrect"},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range failCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			_, gErr := s.RemoveTargetCredentialLibraries(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "RemoveTargetCredentialLibraries(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package targets
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/target"
+)
+
+// filterWorkers returns the workers which clients can connect to for the
+// target's sessions. A worker which is connected to another worker as its
+// downstream worker is only reachable through that worker, and so is never
+// returned itself. A worker matches the target's worker filter if either it
+// or one of its downstream workers matches the filter; all workers match a
+// target without a worker filter.
+func filterWorkers(t target.Target, workers []*servers.Server) ([]*servers.Server, error) {
+	var f *handlers.Filter
+	if t.GetWorkerFilter() != "" {
+		var err error
+		if f, err = handlers.NewFilter(t.GetWorkerFilter()); err != nil {
+			return nil, fmt.Errorf("error parsing worker filter of target %s: %w", t.GetPublicId(), err)
+		}
+	}
+	byName := make(map[string]*servers.Server, len(workers))
+	downstream := make(map[string]bool)
+	for _, w := range workers {
+		byName[w.GetName()] = w
+		for _, d := range w.GetDownstreamWorkers() {
+			downstream[d] = true
+		}
+	}
+	var matched []*servers.Server
+	for _, w := range workers {
+		if downstream[w.GetName()] {
+			continue
+		}
+		if f == nil || f.MatchDocument(w.FilterDocument()) {
+			matched = append(matched, w)
+			continue
+		}
+		for _, d := range w.GetDownstreamWorkers() {
+			if dw, ok := byName[d]; ok && f.MatchDocument(dw.FilterDocument()) {
+				matched = append(matched, w)
+				break
+			}
+		}
+	}
+	return matched, nil
+}
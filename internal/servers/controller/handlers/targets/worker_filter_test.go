@@ -0,0 +1,77 @@
+package targets
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterWorkers(t *testing.T) {
+	east := &servers.Server{Name: "east", Tags: map[string]*servers.TagValues{
+		"region": {Values: []string{"us-east"}},
+	}}
+	west := &servers.Server{Name: "west", Tags: map[string]*servers.TagValues{
+		"region": {Values: []string{"us-west"}},
+	}}
+	untagged := &servers.Server{Name: "untagged"}
+	workers := []*servers.Server{east, west, untagged}
+
+	tests := []struct {
+		name    string
+		filter  string
+		want    []*servers.Server
+		wantErr bool
+	}{
+		{name: "no-filter", filter: "", want: workers},
+		{name: "tag", filter: `"us-east" in "/tags/region"`, want: []*servers.Server{east}},
+		{name: "name", filter: `"/name" == "west"`, want: []*servers.Server{west}},
+		{name: "untagged", filter: `"/tags/region" is empty`, want: []*servers.Server{untagged}},
+		{name: "no-match", filter: `"eu" in "/tags/region"`, want: nil},
+		{name: "invalid", filter: `"/name" ==`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tar := &target.TcpTarget{TcpTarget: &store.TcpTarget{WorkerFilter: tt.filter}}
+			got, err := filterWorkers(tar, workers)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilterWorkers_Downstream(t *testing.T) {
+	isolated := &servers.Server{Name: "isolated", Tags: map[string]*servers.TagValues{
+		"network": {Values: []string{"isolated"}},
+	}}
+	ingress := &servers.Server{Name: "ingress", DownstreamWorkers: []string{"isolated", "gone"}}
+	public := &servers.Server{Name: "public"}
+	workers := []*servers.Server{isolated, ingress, public}
+
+	tests := []struct {
+		name   string
+		filter string
+		want   []*servers.Server
+	}{
+		{name: "no-filter", filter: "", want: []*servers.Server{ingress, public}},
+		{name: "downstream-match", filter: `"isolated" in "/tags/network"`, want: []*servers.Server{ingress}},
+		{name: "direct-match", filter: `"/name" == "public"`, want: []*servers.Server{public}},
+		{name: "downstream-not-returned", filter: `"/name" == "isolated"`, want: []*servers.Server{ingress}},
+		{name: "unknown-downstream", filter: `"/name" == "gone"`, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tar := &target.TcpTarget{TcpTarget: &store.TcpTarget{WorkerFilter: tt.filter}}
+			got, err := filterWorkers(tar, workers)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
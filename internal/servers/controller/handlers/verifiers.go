@@ -22,6 +22,7 @@ type ApiResource interface {
 	GetCreatedTime() *timestamp.Timestamp
 	GetUpdatedTime() *timestamp.Timestamp
 	GetVersion() uint32
+	GetAuthorizedActions() []string
 }
 
 func ValidateCreateRequest(i ApiResource, fn CustomValidatorFunc) error {
@@ -44,6 +45,9 @@ func ValidateCreateRequest(i ApiResource, fn CustomValidatorFunc) error {
 	if i.GetVersion() != 0 {
 		badFields["version"] = "Cannot specify this field in a create request."
 	}
+	if i.GetAuthorizedActions() != nil {
+		badFields["authorized_actions"] = "This is a read only field."
+	}
 	for k, v := range fn() {
 		badFields[k] = v
 	}
@@ -100,6 +104,9 @@ func ValidateUpdateRequest(prefix string, r UpdateRequest, i ApiResource, fn Cus
 	if i.GetUpdatedTime() != nil {
 		badFields["updated_time"] = "This is a read only field and cannot be specified in an update request."
 	}
+	if i.GetAuthorizedActions() != nil {
+		badFields["authorized_actions"] = "This is a read only field and cannot be specified in an update request."
+	}
 
 	for k, v := range fn() {
 		badFields[k] = v
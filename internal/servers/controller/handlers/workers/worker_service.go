@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/kms"
@@ -95,11 +96,23 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 			if len(sessionInfo.States) == 0 {
 				return nil, status.Error(codes.Internal, "Empty session states during lookup at status time.")
 			}
+			currState := sessionInfo.States[0].Status
+			// If the worker found the session idle, terminate it. This only
+			// succeeds once the worker has closed all of the session's
+			// connections, so until then it is retried on the next status.
+			if si.GetIdle() && currState != session.StatusTerminated {
+				terminated, err := sessRepo.TerminateSession(ctx, sessionId, sessionInfo.Version, session.IdleTimeout)
+				if err != nil {
+					ws.logger.Debug("unable to terminate idle session yet", "session_id", sessionId, "error", err)
+				} else {
+					ws.logger.Info("terminated idle session", "session_id", sessionId)
+					currState = terminated.States[0].Status
+				}
+			}
 			// If the session from the DB is in canceling status, and we're
 			// here, it means the job is in pending or active; cancel it. If
 			// it's in termianted status something went wrong and we're
 			// mismatched, so ensure we cancel it also.
-			currState := sessionInfo.States[0].Status
 			if currState.ProtoVal() != si.Status {
 				switch currState {
 				case session.StatusCanceling,
@@ -149,30 +162,37 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 			SessionId:   sessionInfo.GetPublicId(),
 			Certificate: sessionInfo.Certificate,
 		},
-		Status:          sessionInfo.States[0].Status.ProtoVal(),
-		Version:         sessionInfo.Version,
-		TofuToken:       string(sessionInfo.TofuToken),
-		Endpoint:        sessionInfo.Endpoint,
-		Expiration:      sessionInfo.ExpirationTime.Timestamp,
-		ConnectionLimit: sessionInfo.ConnectionLimit,
-		ConnectionsLeft: authzSummary.ConnectionLimit,
-		HostId:          sessionInfo.HostId,
-		HostSetId:       sessionInfo.HostSetId,
-		TargetId:        sessionInfo.TargetId,
-		UserId:          sessionInfo.UserId,
+		Status:                      sessionInfo.States[0].Status.ProtoVal(),
+		Version:                     sessionInfo.Version,
+		TofuToken:                   string(sessionInfo.TofuToken),
+		Endpoint:                    sessionInfo.Endpoint,
+		Expiration:                  sessionInfo.ExpirationTime.Timestamp,
+		ConnectionLimit:             sessionInfo.ConnectionLimit,
+		ConnectionsLeft:             authzSummary.ConnectionLimit,
+		HostId:                      sessionInfo.HostId,
+		HostSetId:                   sessionInfo.HostSetId,
+		TargetId:                    sessionInfo.TargetId,
+		UserId:                      sessionInfo.UserId,
+		RecordingEnabled:            sessionInfo.RecordingEnabled,
+		ConnectionMaxBytesPerSecond: sessionInfo.ConnectionMaxBytesPerSecond,
+		SessionMaxBytesPerSecond:    sessionInfo.SessionMaxBytesPerSecond,
+		IdleTimeoutSeconds:          sessionInfo.IdleTimeoutSeconds,
+		WorkerFilter:                sessionInfo.WorkerFilter,
 	}
 	if resp.ConnectionsLeft != -1 {
 		resp.ConnectionsLeft -= int32(authzSummary.CurrentConnectionCount)
 	}
 
-	wrapper, err := ws.kms.GetWrapper(ctx, sessionInfo.ScopeId, kms.KeyPurposeSessions)
+	wrapper, err := ws.kms.GetWrapper(ctx, sessionInfo.ScopeId, kms.KeyPurposeSessions, kms.WithKeyId(sessionInfo.SessionKeyVersionId))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Error getting sessions wrapper: %v", err)
 	}
 
 	// Derive the private key, which should match. Deriving on both ends allows
-	// us to not store it in the DB.
-	_, resp.Authorization.PrivateKey, err = session.DeriveED25519Key(wrapper, sessionInfo.UserId, sessionInfo.GetPublicId())
+	// us to not store it in the DB. It is derived from the version of the
+	// sessions key the session was created with, which is no longer the
+	// current one once the keys of the scope are rotated.
+	_, resp.Authorization.PrivateKey, err = session.DeriveED25519Key(wrapper, sessionInfo.SessionKeyVersionId, sessionInfo.UserId, sessionInfo.GetPublicId())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Error deriving session key: %v", err)
 	}
@@ -291,6 +311,11 @@ func (ws *workerServiceServer) ConnectConnection(ctx context.Context, req *pbs.C
 			"endpoint_tcp_address", connectionInfo.EndpointTcpAddress,
 			"endpoint_tcp_port", connectionInfo.EndpointTcpPort,
 		)
+	case "udp":
+		loggerPairs = append(loggerPairs,
+			"endpoint_udp_address", connectionInfo.EndpointTcpAddress,
+			"endpoint_udp_port", connectionInfo.EndpointTcpPort,
+		)
 	}
 
 	ws.logger.Info("connection established", loggerPairs...)
@@ -309,12 +334,23 @@ func (ws *workerServiceServer) CloseConnection(ctx context.Context, req *pbs.Clo
 
 	for _, v := range req.GetCloseRequestData() {
 		closeIds = append(closeIds, v.GetConnectionId())
-		closeWiths = append(closeWiths, session.CloseWith{
+		cw := session.CloseWith{
 			ConnectionId: v.GetConnectionId(),
 			BytesUp:      v.GetBytesUp(),
 			BytesDown:    v.GetBytesDown(),
 			ClosedReason: session.ClosedReason(v.GetReason()),
-		})
+		}
+		if rec := v.GetRecording(); rec != nil {
+			cw.Recording = &session.ConnectionRecording{
+				WorkerId:    rec.GetWorkerId(),
+				StoragePath: rec.GetStoragePath(),
+				BytesUp:     rec.GetBytesUp(),
+				BytesDown:   rec.GetBytesDown(),
+				StartTime:   &timestamp.Timestamp{Timestamp: rec.GetStartTime()},
+				EndTime:     &timestamp.Timestamp{Timestamp: rec.GetEndTime()},
+			}
+		}
+		closeWiths = append(closeWiths, cw)
 	}
 	ws.logger.Trace("got connection close information from worker", "connection_ids", closeIds)
 
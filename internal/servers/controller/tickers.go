@@ -11,8 +11,10 @@ import (
 
 // In the future we could make this configurable
 const (
-	statusInterval      = 10 * time.Second
-	terminationInterval = 1 * time.Minute
+	statusInterval        = 10 * time.Second
+	terminationInterval   = 1 * time.Minute
+	inventorySyncInterval = 30 * time.Second
+	keyRewrapInterval     = 30 * time.Second
 )
 
 // This is exported so it can be tweaked in tests
@@ -116,3 +118,48 @@ func (c *Controller) startTerminateCompletedSessionsTicking(cancelCtx context.Co
 		}
 	}()
 }
+
+func (c *Controller) startInventorySyncTicking(cancelCtx context.Context) {
+	go func() {
+		timer := time.NewTimer(0)
+		for {
+			select {
+			case <-cancelCtx.Done():
+				c.logger.Info("inventory sync ticking shutting down")
+				return
+
+			case <-timer.C:
+				repo, err := c.InventoryHostRepoFn()
+				if err != nil {
+					c.logger.Error("error fetching repository for inventory sync", "error", err)
+				} else if err := repo.SyncCatalogs(cancelCtx); err != nil {
+					c.logger.Error("error performing inventory sync", "error", err)
+				}
+				timer.Reset(inventorySyncInterval)
+			}
+		}
+	}()
+}
+
+func (c *Controller) startKeyRewrapTicking(cancelCtx context.Context) {
+	go func() {
+		timer := time.NewTimer(0)
+		for {
+			select {
+			case <-cancelCtx.Done():
+				c.logger.Info("key rewrap ticking shutting down")
+				return
+
+			case <-timer.C:
+				rewrapCount, err := c.kms.RewrapKeys(cancelCtx)
+				if err != nil {
+					c.logger.Error("error performing key rewrap", "error", err)
+				}
+				if rewrapCount > 0 {
+					c.logger.Info("key rewrap successful", "values_rewrapped", rewrapCount)
+				}
+				timer.Reset(keyRewrapInterval)
+			}
+		}
+	}()
+}
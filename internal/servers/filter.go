@@ -0,0 +1,19 @@
+package servers
+
+// FilterDocument returns the document which worker filters are matched
+// against for the server: its "name" and its "tags", each of which is a list
+// of values.
+func (s *Server) FilterDocument() map[string]interface{} {
+	tags := make(map[string]interface{}, len(s.GetTags()))
+	for k, v := range s.GetTags() {
+		values := make([]interface{}, 0, len(v.GetValues()))
+		for _, val := range v.GetValues() {
+			values = append(values, val)
+		}
+		tags[k] = values
+	}
+	return map[string]interface{}{
+		"name": s.GetName(),
+		"tags": tags,
+	}
+}
@@ -3,3 +3,28 @@ package servers
 func (s *Server) TableName() string {
 	return "server"
 }
+
+// ServerTag is a tag of a server. Workers report their tags, from their
+// configuration, with each status update.
+type ServerTag struct {
+	ServerId   string
+	ServerType string
+	Key        string
+	Value      string
+}
+
+func (t *ServerTag) TableName() string {
+	return "server_tag"
+}
+
+// WorkerDownstream is a worker connected to a worker as its downstream
+// worker. Workers report their downstream workers with each status update.
+type WorkerDownstream struct {
+	ServerId       string
+	ServerType     string
+	DownstreamName string
+}
+
+func (d *WorkerDownstream) TableName() string {
+	return "server_worker_downstream"
+}
@@ -2,4 +2,34 @@ package servers
 
 const (
 	deleteWhereSql = `create_time < $1`
+
+	deleteServerTags = `
+	delete from server_tag
+	where
+		server_id = $1 and
+		server_type = $2;
+	`
+
+	insertServerTag = `
+	insert into server_tag
+		(server_id, server_type, key, value)
+	values
+		($1, $2, $3, $4)
+	on conflict do nothing;
+	`
+
+	deleteWorkerDownstreams = `
+	delete from server_worker_downstream
+	where
+		server_id = $1 and
+		server_type = $2;
+	`
+
+	insertWorkerDownstream = `
+	insert into server_worker_downstream
+		(server_id, server_type, downstream_name)
+	values
+		($1, $2, $3)
+	on conflict do nothing;
+	`
 )
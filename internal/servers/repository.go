@@ -72,9 +72,69 @@ func (r *Repository) ListServers(ctx context.Context, serverType ServerType, opt
 	); err != nil {
 		return nil, fmt.Errorf("error listing servers: %w", err)
 	}
+	if serverType == ServerTypeWorker && len(servers) > 0 {
+		if err := r.loadTags(ctx, serverType, servers); err != nil {
+			return nil, err
+		}
+		if err := r.loadDownstreamWorkers(ctx, servers); err != nil {
+			return nil, err
+		}
+	}
 	return servers, nil
 }
 
+// loadTags sets the tags of the servers of the server type.
+func (r *Repository) loadTags(ctx context.Context, serverType ServerType, servers []*Server) error {
+	var tags []*ServerTag
+	if err := r.reader.SearchWhere(
+		ctx,
+		&tags,
+		"server_type = $1",
+		[]interface{}{serverType},
+		db.WithLimit(-1),
+	); err != nil {
+		return fmt.Errorf("error listing server tags: %w", err)
+	}
+	byServer := make(map[string]map[string]*TagValues, len(servers))
+	for _, t := range tags {
+		serverTags, ok := byServer[t.ServerId]
+		if !ok {
+			serverTags = make(map[string]*TagValues)
+			byServer[t.ServerId] = serverTags
+		}
+		if serverTags[t.Key] == nil {
+			serverTags[t.Key] = &TagValues{}
+		}
+		serverTags[t.Key].Values = append(serverTags[t.Key].Values, t.Value)
+	}
+	for _, s := range servers {
+		s.Tags = byServer[s.PrivateId]
+	}
+	return nil
+}
+
+// loadDownstreamWorkers sets the downstream workers of the workers.
+func (r *Repository) loadDownstreamWorkers(ctx context.Context, servers []*Server) error {
+	var downstreams []*WorkerDownstream
+	if err := r.reader.SearchWhere(
+		ctx,
+		&downstreams,
+		"server_type = $1",
+		[]interface{}{ServerTypeWorker},
+		db.WithLimit(-1),
+	); err != nil {
+		return fmt.Errorf("error listing downstream workers: %w", err)
+	}
+	byServer := make(map[string][]string, len(servers))
+	for _, d := range downstreams {
+		byServer[d.ServerId] = append(byServer[d.ServerId], d.DownstreamName)
+	}
+	for _, s := range servers {
+		s.DownstreamWorkers = byServer[s.PrivateId]
+	}
+	return nil
+}
+
 // UpsertServer adds or updates a server in the DB
 func (r *Repository) UpsertServer(ctx context.Context, server *Server, opt ...Option) ([]*Server, int, error) {
 	if server == nil {
@@ -96,19 +156,57 @@ func (r *Repository) UpsertServer(ctx context.Context, server *Server, opt ...Op
 		update_time = $6;
 	`
 
-	rowsAffected, err := r.writer.Exec(ctx, q,
-		[]interface{}{server.PrivateId,
-			server.Type,
-			server.Name,
-			server.Description,
-			server.Address,
-			time.Now().Format(time.RFC3339)})
+	var rowsAffected int
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			var err error
+			rowsAffected, err = w.Exec(ctx, q,
+				[]interface{}{server.PrivateId,
+					server.Type,
+					server.Name,
+					server.Description,
+					server.Address,
+					time.Now().Format(time.RFC3339)})
+			if err != nil {
+				return fmt.Errorf("error performing status upsert: %w", err)
+			}
+			if server.Type != resource.Worker.String() {
+				return nil
+			}
+			// Workers report all of their tags with each status, so the
+			// stored tags are replaced.
+			if _, err := w.Exec(ctx, deleteServerTags, []interface{}{server.PrivateId, server.Type}); err != nil {
+				return fmt.Errorf("error deleting server tags: %w", err)
+			}
+			for key, values := range server.Tags {
+				for _, value := range values.GetValues() {
+					if _, err := w.Exec(ctx, insertServerTag, []interface{}{server.PrivateId, server.Type, key, value}); err != nil {
+						return fmt.Errorf("error inserting server tag: %w", err)
+					}
+				}
+			}
+			// The same goes for the workers connected to it as its
+			// downstream workers.
+			if _, err := w.Exec(ctx, deleteWorkerDownstreams, []interface{}{server.PrivateId, server.Type}); err != nil {
+				return fmt.Errorf("error deleting downstream workers: %w", err)
+			}
+			for _, name := range server.DownstreamWorkers {
+				if _, err := w.Exec(ctx, insertWorkerDownstream, []interface{}{server.PrivateId, server.Type, name}); err != nil {
+					return fmt.Errorf("error inserting downstream worker: %w", err)
+				}
+			}
+			return nil
+		},
+	)
 	if err != nil {
-		return nil, db.NoRowsAffected, fmt.Errorf("error performing status upsert: %w", err)
+		return nil, db.NoRowsAffected, err
 	}
 	// If updating a controller, done
 	if server.Type == resource.Controller.String() {
-		return nil, int(rowsAffected), nil
+		return nil, rowsAffected, nil
 	}
 	// Fetch current controllers to feed to the workers
 	controllers, err := r.ListServers(ctx, ServerTypeController)
@@ -1,13 +1,17 @@
 package servers_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/boundary/api/roles"
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/recovery"
 	"github.com/stretchr/testify/assert"
@@ -78,3 +82,79 @@ func TestRecoveryNonces(t *testing.T) {
 		assert.Len(nonces, 0)
 	}
 }
+
+func TestRepository_WorkerTags(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	repo, err := servers.NewRepository(rw, rw, kms.TestKms(t, conn, wrapper))
+	require.NoError(err)
+	ctx := context.Background()
+
+	worker := &servers.Server{
+		Name:    "tagged-worker",
+		Type:    resource.Worker.String(),
+		Address: "127.0.0.1",
+		Tags: map[string]*servers.TagValues{
+			"region": {Values: []string{"us-east"}},
+			"type":   {Values: []string{"prod", "web"}},
+		},
+	}
+	_, _, err = repo.UpsertServer(ctx, worker)
+	require.NoError(err)
+
+	workers, err := repo.ListServers(ctx, servers.ServerTypeWorker)
+	require.NoError(err)
+	require.Len(workers, 1)
+	got := workers[0].GetTags()
+	require.Len(got, 2)
+	assert.Equal([]string{"us-east"}, got["region"].GetValues())
+	assert.ElementsMatch([]string{"prod", "web"}, got["type"].GetValues())
+
+	// The tags of each status replace the stored tags.
+	worker.Tags = map[string]*servers.TagValues{
+		"region": {Values: []string{"us-west"}},
+	}
+	_, _, err = repo.UpsertServer(ctx, worker)
+	require.NoError(err)
+	workers, err = repo.ListServers(ctx, servers.ServerTypeWorker)
+	require.NoError(err)
+	require.Len(workers, 1)
+	got = workers[0].GetTags()
+	require.Len(got, 1)
+	assert.Equal([]string{"us-west"}, got["region"].GetValues())
+}
+
+func TestRepository_WorkerDownstreams(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	repo, err := servers.NewRepository(rw, rw, kms.TestKms(t, conn, wrapper))
+	require.NoError(err)
+	ctx := context.Background()
+
+	worker := &servers.Server{
+		Name:              "ingress-worker",
+		Type:              resource.Worker.String(),
+		Address:           "127.0.0.1",
+		DownstreamWorkers: []string{"egress-1", "egress-2"},
+	}
+	_, _, err = repo.UpsertServer(ctx, worker)
+	require.NoError(err)
+
+	workers, err := repo.ListServers(ctx, servers.ServerTypeWorker)
+	require.NoError(err)
+	require.Len(workers, 1)
+	assert.ElementsMatch([]string{"egress-1", "egress-2"}, workers[0].GetDownstreamWorkers())
+
+	// The downstream workers of each status replace the stored ones.
+	worker.DownstreamWorkers = nil
+	_, _, err = repo.UpsertServer(ctx, worker)
+	require.NoError(err)
+	workers, err = repo.ListServers(ctx, servers.ServerTypeWorker)
+	require.NoError(err)
+	require.Len(workers, 1)
+	assert.Empty(workers[0].GetDownstreamWorkers())
+}
@@ -0,0 +1,87 @@
+// Package bandwidth limits the throughput of the data a worker proxies for a
+// session.
+//
+// A Limiter allows a number of bytes per second.  A connection is limited by
+// its own Limiter and by the Limiter shared by every connection of its
+// session, with a separate pair of limiters for each direction.
+package bandwidth
+
+import (
+	"context"
+	"io"
+
+	"golang.org/x/time/rate"
+)
+
+// Limiter limits the number of bytes allowed per second.  A nil Limiter allows
+// an unlimited number of bytes.  It is safe for concurrent use.
+type Limiter struct {
+	l *rate.Limiter
+}
+
+// NewLimiter returns a Limiter allowing bytesPerSecond bytes per second, or
+// nil if bytesPerSecond is 0, meaning no limit.  Up to one second's worth of
+// bytes may be used at once.
+func NewLimiter(bytesPerSecond uint32) *Limiter {
+	if bytesPerSecond == 0 {
+		return nil
+	}
+	return &Limiter{
+		l: rate.NewLimiter(rate.Limit(bytesPerSecond), int(bytesPerSecond)),
+	}
+}
+
+// WaitN blocks until the Limiter allows n bytes or ctx is done.
+func (l *Limiter) WaitN(ctx context.Context, n int) error {
+	if l == nil {
+		return nil
+	}
+	burst := l.l.Burst()
+	for n > 0 {
+		chunk := n
+		if chunk > burst {
+			chunk = burst
+		}
+		if err := l.l.WaitN(ctx, chunk); err != nil {
+			return err
+		}
+		n -= chunk
+	}
+	return nil
+}
+
+// WaitN blocks until each of the limiters allows n bytes or ctx is done.
+func WaitN(ctx context.Context, n int, limiters ...*Limiter) error {
+	for _, l := range limiters {
+		if err := l.WaitN(ctx, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewReader returns a Reader that reads from r.  The bytes returned from each
+// read are held back until the limiters allow them.
+func NewReader(ctx context.Context, r io.Reader, limiters ...*Limiter) io.Reader {
+	return &reader{
+		ctx:      ctx,
+		r:        r,
+		limiters: limiters,
+	}
+}
+
+type reader struct {
+	ctx      context.Context
+	r        io.Reader
+	limiters []*Limiter
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		if werr := WaitN(r.ctx, n, r.limiters...); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
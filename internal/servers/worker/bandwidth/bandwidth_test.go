@@ -0,0 +1,75 @@
+package bandwidth
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLimiter(t *testing.T) {
+	assert := assert.New(t)
+	assert.Nil(NewLimiter(0))
+	l := NewLimiter(1024)
+	assert.NotNil(l)
+	assert.Equal(1024, l.l.Burst())
+}
+
+func TestLimiter_WaitN(t *testing.T) {
+	tests := []struct {
+		name    string
+		limiter *Limiter
+		n       int
+		wantMin time.Duration
+	}{
+		{
+			name: "no-limit",
+			n:    1 << 20,
+		},
+		{
+			name:    "within-burst",
+			limiter: NewLimiter(1024),
+			n:       1024,
+		},
+		{
+			name:    "larger-than-burst",
+			limiter: NewLimiter(1024),
+			n:       1024 + 512,
+			wantMin: 400 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			start := time.Now()
+			require.NoError(tt.limiter.WaitN(context.Background(), tt.n))
+			assert.GreaterOrEqual(int64(time.Since(start)), int64(tt.wantMin))
+		})
+	}
+}
+
+func TestLimiter_WaitNCanceled(t *testing.T) {
+	assert := assert.New(t)
+	l := NewLimiter(10)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(l.WaitN(ctx, 20))
+}
+
+func TestNewReader(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	data := bytes.Repeat([]byte("a"), 1024+512)
+	conn, session := NewLimiter(4096), NewLimiter(1024)
+
+	start := time.Now()
+	got, err := ioutil.ReadAll(NewReader(context.Background(), bytes.NewReader(data), conn, session))
+	require.NoError(err)
+	assert.Equal(data, got)
+	// The session limiter is the lower of the two, so it determines how long
+	// reading the data takes.
+	assert.GreaterOrEqual(int64(time.Since(start)), int64(400*time.Millisecond))
+}
@@ -123,6 +123,7 @@ func (w Worker) workerAuthTLSConfig() (*tls.Config, *base.WorkerAuthInfo, error)
 	info := &base.WorkerAuthInfo{
 		Name:        w.conf.RawConfig.Worker.Name,
 		Description: w.conf.RawConfig.Worker.Description,
+		Tags:        w.conf.RawConfig.Worker.Tags,
 	}
 	if info.ConnectionNonce, err = base62.Random(20); err != nil {
 		return nil, nil, err
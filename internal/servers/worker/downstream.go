@@ -0,0 +1,262 @@
+package worker
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/proxy"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/patrickmn/go-cache"
+)
+
+// downstreamWorker is a worker connected to this worker as its downstream
+// worker. Each of its idle connections is used to forward a single connection
+// of a session to it.
+type downstreamWorker struct {
+	sync.Mutex
+	name  string
+	tags  map[string][]string
+	conns []*hopConn
+}
+
+// hopConn is an idle connection from a downstream worker. While it is idle, a
+// goroutine reads from it so that it is removed once the downstream worker
+// closes it.
+type hopConn struct {
+	net.Conn
+	idleDone chan struct{}
+}
+
+func (d *downstreamWorker) add(conn net.Conn, tags map[string][]string) {
+	c := &hopConn{Conn: conn, idleDone: make(chan struct{})}
+	d.Lock()
+	d.tags = tags
+	d.conns = append(d.conns, c)
+	d.Unlock()
+	go d.watch(c)
+}
+
+// watch reads from the idle connection until it is either closed or taken,
+// which interrupts the read. Nothing is sent over an idle connection, so the
+// read never returns data.
+func (d *downstreamWorker) watch(c *hopConn) {
+	defer close(c.idleDone)
+	_, _ = c.Read(make([]byte, 1))
+	if d.remove(c) {
+		// The connection was not taken, so it is no longer usable.
+		c.Close()
+	}
+}
+
+func (d *downstreamWorker) remove(c *hopConn) bool {
+	d.Lock()
+	defer d.Unlock()
+	for i, v := range d.conns {
+		if v == c {
+			d.conns = append(d.conns[:i], d.conns[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// take removes an idle connection and returns it, or returns nil if there are
+// none.
+func (d *downstreamWorker) take() net.Conn {
+	d.Lock()
+	if len(d.conns) == 0 {
+		d.Unlock()
+		return nil
+	}
+	c := d.conns[0]
+	d.conns = d.conns[1:]
+	d.Unlock()
+
+	// Interrupt the read of the watching goroutine.
+	c.SetReadDeadline(time.Now())
+	<-c.idleDone
+	c.SetReadDeadline(time.Time{})
+	return c.Conn
+}
+
+func (d *downstreamWorker) connected() bool {
+	d.Lock()
+	defer d.Unlock()
+	return len(d.conns) > 0
+}
+
+// filterDocument returns the document which worker filters are matched
+// against for the downstream worker.
+func (d *downstreamWorker) filterDocument() map[string]interface{} {
+	d.Lock()
+	defer d.Unlock()
+	return (&servers.Server{Name: d.name, Tags: tagValues(d.tags)}).FilterDocument()
+}
+
+// getProxyTls returns the TLS config for a connection to a proxy listener,
+// which is either made by a downstream worker or by a client of a session.
+func (w *Worker) getProxyTls(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+	for _, p := range hello.SupportedProtos {
+		if strings.HasPrefix(p, "v1workerauth-") {
+			tlsConf, info, err := base.V1WorkerAuthConfig(w.baseContext, w.conf.WorkerAuthKms, hello.SupportedProtos)
+			if err != nil {
+				w.logger.Trace("invalid downstream worker auth", "error", err)
+				return nil, err
+			}
+			// As with the controllers, the nonce must be the first thing sent
+			// over the connection to prevent replays of the hello.
+			w.downstreamAuthCache.Set(info.ConnectionNonce, info, cache.DefaultExpiration)
+			return tlsConf, nil
+		}
+	}
+	return w.getSessionTls(hello)
+}
+
+// downstreamListener passes the connections of clients on to the proxy
+// handler, and adds the connections of downstream workers to their idle
+// connections.
+type downstreamListener struct {
+	net.Listener
+	w *Worker
+}
+
+func (l *downstreamListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		tlsConn, ok := conn.(*tls.Conn)
+		if !ok || !strings.HasPrefix(tlsConn.ConnectionState().NegotiatedProtocol, "v1workerauth-") {
+			return conn, nil
+		}
+		go l.w.addDownstreamConn(tlsConn)
+	}
+}
+
+func (w *Worker) addDownstreamConn(conn net.Conn) {
+	nonce := make([]byte, 20)
+	conn.SetReadDeadline(time.Now().Add(hopTimeout))
+	if _, err := io.ReadFull(conn, nonce); err != nil {
+		w.logger.Error("error reading nonce from downstream worker", "error", err)
+		conn.Close()
+		return
+	}
+	conn.SetReadDeadline(time.Time{})
+	infoRaw, found := w.downstreamAuthCache.Get(string(nonce))
+	if !found {
+		w.logger.Error("did not find valid nonce for incoming downstream worker")
+		conn.Close()
+		return
+	}
+	w.downstreamAuthCache.Delete(string(nonce))
+	info := infoRaw.(*base.WorkerAuthInfo)
+
+	dRaw, _ := w.downstreamWorkers.LoadOrStore(info.Name, &downstreamWorker{name: info.Name})
+	dRaw.(*downstreamWorker).add(conn, info.Tags)
+	w.logger.Trace("downstream worker connected", "name", info.Name)
+}
+
+// downstreamWorkerNames returns the names of the downstream workers which are
+// connected to this worker.
+func (w *Worker) downstreamWorkerNames() []string {
+	var names []string
+	w.downstreamWorkers.Range(func(_, value interface{}) bool {
+		d := value.(*downstreamWorker)
+		if d.connected() {
+			names = append(names, d.name)
+		}
+		return true
+	})
+	sort.Strings(names)
+	return names
+}
+
+// downstreamFilter returns the parsed worker filter of a session if this
+// worker does not match it, in which case the session's connections must be
+// forwarded to a downstream worker which does. It returns nil if this worker
+// dials the endpoint itself.
+func (w *Worker) downstreamFilter(workerFilter string) (*handlers.Filter, error) {
+	if workerFilter == "" {
+		return nil, nil
+	}
+	f, err := handlers.NewFilter(workerFilter)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing worker filter: %w", err)
+	}
+	self := &servers.Server{Name: w.conf.RawConfig.Worker.Name, Tags: w.tags()}
+	if f.MatchDocument(self.FilterDocument()) {
+		return nil, nil
+	}
+	return f, nil
+}
+
+// dialDownstream forwards a connection of a session to a connected downstream
+// worker matching the filter, which dials the endpoint. It returns the
+// connection to the downstream worker, over which the connection's data is
+// then sent, and the address of the endpoint.
+func (w *Worker) dialDownstream(ctx context.Context, f *handlers.Filter, req *proxy.HopRequest) (net.Conn, *net.TCPAddr, error) {
+	var candidates []*downstreamWorker
+	w.downstreamWorkers.Range(func(_, value interface{}) bool {
+		d := value.(*downstreamWorker)
+		if f.MatchDocument(d.filterDocument()) {
+			candidates = append(candidates, d)
+		}
+		return true
+	})
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	for _, d := range candidates {
+		for conn := d.take(); conn != nil; conn = d.take() {
+			resp, err := exchangeHop(ctx, conn, req)
+			if err != nil {
+				// The connection may have been closed without us noticing
+				// yet, so try the next one.
+				w.logger.Debug("error forwarding connection to downstream worker", "name", d.name, "error", err)
+				conn.Close()
+				continue
+			}
+			if resp.GetError() != "" {
+				conn.Close()
+				return nil, nil, fmt.Errorf("downstream worker %s: %s", d.name, resp.GetError())
+			}
+			return conn, &net.TCPAddr{
+				IP:   net.ParseIP(resp.GetEndpointAddress()),
+				Port: int(resp.GetEndpointPort()),
+			}, nil
+		}
+	}
+	return nil, nil, errors.New("no downstream worker matching the session's worker filter is connected")
+}
+
+// exchangeHop sends the hop request over the connection and reads the
+// response.
+func exchangeHop(ctx context.Context, conn net.Conn, req *proxy.HopRequest) (*proxy.HopResponse, error) {
+	deadline := time.Now().Add(hopTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+	defer conn.SetDeadline(time.Time{})
+	if err := writeHopMessage(conn, req); err != nil {
+		return nil, err
+	}
+	resp := new(proxy.HopResponse)
+	if err := readHopMessage(conn, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
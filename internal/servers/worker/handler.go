@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/event"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/proxy"
 	"github.com/hashicorp/shared-secure-libs/configutil"
@@ -71,14 +72,30 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 		tofuToken := si.lookupSessionResponse.GetTofuToken()
 		version := si.lookupSessionResponse.GetVersion()
 		endpoint := si.lookupSessionResponse.GetEndpoint()
-		//userId := si.lookupSessionResponse.GetAuthorization()
+		recordingEnabled := si.lookupSessionResponse.GetRecordingEnabled()
+		userId := si.lookupSessionResponse.GetUserId()
+		targetId := si.lookupSessionResponse.GetTargetId()
+		scopeId := si.lookupSessionResponse.GetAuthorization().GetScope().GetId()
 		sessStatus := si.status
+		idle := si.idle
 		si.RUnlock()
 
 		w.logger.Trace("found session in session info map")
 
+		if recordingEnabled && w.conf.RawConfig.Worker.RecordingStoragePath == "" {
+			w.logger.Error("session requires recording but no recording storage path is configured", "session_id", sessionId)
+			wr.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if idle {
+			w.logger.Error("session has exceeded its idle timeout", "session_id", sessionId)
+			wr.WriteHeader(http.StatusForbidden)
+			return
+		}
+
 		opts := &websocket.AcceptOptions{
-			Subprotocols: []string{globals.TcpProxyV1},
+			Subprotocols: []string{globals.TcpProxyV1, globals.UdpProxyV1},
 		}
 		conn, err := websocket.Accept(wr, r, opts)
 		if err != nil {
@@ -127,6 +144,16 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 				conn.Close(websocket.StatusInternalError, "unable to activate session")
 				return
 			}
+			w.conf.Eventer.Session(&event.Session{
+				Stage:      event.SessionActivated,
+				SessionId:  sessionId,
+				UserId:     userId,
+				TargetId:   targetId,
+				ScopeId:    scopeId,
+				WorkerId:   w.conf.RawConfig.Worker.Name,
+				ClientAddr: clientAddr.String(),
+				Endpoint:   endpoint,
+			})
 		}
 
 		var ci *connInfo
@@ -138,13 +165,30 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 			return
 		}
 
+		connEvent := &event.Session{
+			Stage:        event.ConnectionAuthorized,
+			SessionId:    sessionId,
+			ConnectionId: ci.id,
+			UserId:       userId,
+			TargetId:     targetId,
+			ScopeId:      scopeId,
+			WorkerId:     w.conf.RawConfig.Worker.Name,
+			ClientAddr:   clientAddr.String(),
+			Endpoint:     endpoint,
+		}
+		w.conf.Eventer.Session(connEvent)
+
 		defer func() {
 			connectionId := ci.id
+			closedEvent := *connEvent
+			closedEvent.Stage = event.ConnectionClosed
+			closedEvent.BytesUp, closedEvent.BytesDown = w.connectionBytes(si.id, connectionId)
 			if err := w.closeConnections(r.Context(), map[string]string{
 				connectionId: si.id,
 			}); err != nil {
 				w.logger.Error("error marking connection closed", "error", err, "connection_id", connectionId)
 			}
+			w.conf.Eventer.Session(&closedEvent)
 		}()
 
 		si.Lock()
@@ -171,6 +215,8 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 		switch conn.Subprotocol() {
 		case globals.TcpProxyV1:
 			w.handleTcpProxyV1(connCtx, clientAddr, conn, si, ci.id, endpoint)
+		case globals.UdpProxyV1:
+			w.handleUdpProxyV1(connCtx, clientAddr, conn, si, ci.id, endpoint)
 		default:
 			conn.Close(websocket.StatusProtocolError, "unsupported-protocol")
 			return
@@ -0,0 +1,68 @@
+package worker
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// A worker which cannot accept inbound connections, for example because it
+// runs in an isolated network, can still proxy sessions by connecting to the
+// proxy listener of another worker as its downstream worker. The downstream
+// worker keeps idle connections open to its upstream worker, authenticated
+// with the worker-auth KMS in the same way as its connections to the
+// controllers. When a client connects to the upstream worker for a session
+// whose worker filter only the downstream worker matches, the upstream worker
+// sends a HopRequest over one of the idle connections. The downstream worker
+// dials the endpoint, replies with a HopResponse, and from then on the
+// connection carries the data of the session's connection.
+const (
+	// hopPoolSize is the number of idle connections a downstream worker keeps
+	// open to each of its upstream workers.
+	hopPoolSize = 3
+
+	// hopTimeout limits the exchange of the hop request and response, and
+	// the time a downstream worker has to send its connection nonce.
+	hopTimeout = 30 * time.Second
+
+	// maxHopMessageSize limits the size of the hop request and response.
+	maxHopMessageSize = 64 * 1024
+)
+
+// writeHopMessage writes the message prefixed with its length.
+func writeHopMessage(w io.Writer, m proto.Message) error {
+	b, err := proto.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("error marshaling hop message: %w", err)
+	}
+	buf := make([]byte, 4+len(b))
+	binary.BigEndian.PutUint32(buf, uint32(len(b)))
+	copy(buf[4:], b)
+	if _, err := w.Write(buf); err != nil {
+		return fmt.Errorf("error writing hop message: %w", err)
+	}
+	return nil
+}
+
+// readHopMessage reads a message written by writeHopMessage.
+func readHopMessage(r io.Reader, m proto.Message) error {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n > maxHopMessageSize {
+		return fmt.Errorf("hop message of %d bytes exceeds the maximum of %d bytes", n, maxHopMessageSize)
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return fmt.Errorf("error reading hop message: %w", err)
+	}
+	if err := proto.Unmarshal(b, m); err != nil {
+		return fmt.Errorf("error unmarshaling hop message: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package worker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/proxy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHopMessage(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	req := &proxy.HopRequest{
+		SessionId:    "s_1234567890",
+		ConnectionId: "sc_1234567890",
+		Endpoint:     "tcp://10.0.0.1:22",
+	}
+	var buf bytes.Buffer
+	require.NoError(writeHopMessage(&buf, req))
+	got := new(proxy.HopRequest)
+	require.NoError(readHopMessage(&buf, got))
+	assert.True(proto.Equal(req, got))
+
+	// Messages over the maximum size are rejected before they are read.
+	buf.Reset()
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], maxHopMessageSize+1)
+	buf.Write(size[:])
+	assert.Error(readHopMessage(&buf, new(proxy.HopRequest)))
+}
+
+func TestDownstreamWorker(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	d := &downstreamWorker{name: "egress"}
+	assert.Nil(d.take())
+	assert.False(d.connected())
+
+	closed, closedRemote := net.Pipe()
+	idle, idleRemote := net.Pipe()
+	defer idleRemote.Close()
+	d.add(closed, map[string][]string{"network": {"isolated"}})
+	d.add(idle, map[string][]string{"network": {"isolated"}})
+	assert.True(d.connected())
+	assert.Equal(map[string]interface{}{
+		"name": "egress",
+		"tags": map[string]interface{}{"network": []interface{}{"isolated"}},
+	}, d.filterDocument())
+
+	// A connection closed by the downstream worker while idle is removed.
+	closedRemote.Close()
+	require.Eventually(func() bool {
+		d.Lock()
+		defer d.Unlock()
+		return len(d.conns) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// A taken connection is no longer read from by the pool, so data sent by
+	// the downstream worker is received by the taker.
+	conn := d.take()
+	require.NotNil(conn)
+	assert.Nil(d.take())
+	go idleRemote.Write([]byte("data"))
+	buf := make([]byte, 4)
+	_, err := conn.Read(buf)
+	require.NoError(err)
+	assert.Equal("data", string(buf))
+}
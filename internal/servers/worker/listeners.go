@@ -65,7 +65,7 @@ func (w *Worker) startListeners() error {
 			ln.Mux.UnregisterProto(alpnmux.DefaultProto)
 			ln.Mux.UnregisterProto(alpnmux.NoProto)
 			l, err := ln.Mux.RegisterProto(alpnmux.DefaultProto, &tls.Config{
-				GetConfigForClient: w.getSessionTls,
+				GetConfigForClient: w.getProxyTls,
 			})
 			if err != nil {
 				return fmt.Errorf("error getting tls listener: %w", err)
@@ -74,8 +74,10 @@ func (w *Worker) startListeners() error {
 				return errors.New("could not get tls listener")
 			}
 
+			dl := &downstreamListener{Listener: l, w: w}
+
 			servers = append(servers, func() {
-				go server.Serve(l)
+				go server.Serve(dl)
 			})
 		}
 	}
@@ -0,0 +1,185 @@
+// Package recording records the data a worker proxies for a session
+// connection.
+//
+// A recording is a file of newline-delimited JSON objects.  The first object is
+// a Header describing the connection; it is followed by one Frame for every
+// chunk of data proxied in either direction, in the order the worker saw them.
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Version is the version of the recording format written by a Recorder.
+const Version = 1
+
+// Direction of the data in a Frame.
+type Direction string
+
+const (
+	// Up is data sent from the client to the endpoint.
+	Up Direction = "up"
+	// Down is data sent from the endpoint to the client.
+	Down Direction = "down"
+)
+
+// Header is the first entry of a recording.
+type Header struct {
+	Version      int       `json:"version"`
+	SessionId    string    `json:"session_id"`
+	ConnectionId string    `json:"connection_id"`
+	StartTime    time.Time `json:"start_time"`
+}
+
+// Frame is a chunk of data proxied for the connection.  Offset is the time
+// elapsed since the Header's StartTime.
+type Frame struct {
+	Offset    time.Duration `json:"offset"`
+	Direction Direction     `json:"direction"`
+	Data      []byte        `json:"data"`
+}
+
+// Summary describes a completed recording.
+type Summary struct {
+	Path      string
+	BytesUp   uint64
+	BytesDown uint64
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// Recorder writes a recording for a single connection.  It is safe for
+// concurrent use, so each direction of a connection can be recorded by its own
+// goroutine.
+type Recorder struct {
+	mu        sync.Mutex
+	file      *os.File
+	buf       *bufio.Writer
+	enc       *json.Encoder
+	path      string
+	start     time.Time
+	bytesUp   uint64
+	bytesDown uint64
+	err       error
+	closed    bool
+}
+
+// New creates a Recorder which writes to a new file for the connection within
+// dir.  The file is named after the connection and placed in a directory named
+// after the session.
+func New(dir, sessionId, connectionId string) (*Recorder, error) {
+	switch {
+	case dir == "":
+		return nil, errors.New("new recorder: missing storage directory")
+	case sessionId == "":
+		return nil, errors.New("new recorder: missing session id")
+	case connectionId == "":
+		return nil, errors.New("new recorder: missing connection id")
+	}
+	sessionDir := filepath.Join(dir, sessionId)
+	if err := os.MkdirAll(sessionDir, 0700); err != nil {
+		return nil, fmt.Errorf("new recorder: unable to create session directory: %w", err)
+	}
+	path := filepath.Join(sessionDir, connectionId+".rec")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("new recorder: unable to create recording file: %w", err)
+	}
+	r := &Recorder{
+		file:  f,
+		buf:   bufio.NewWriter(f),
+		path:  path,
+		start: time.Now(),
+	}
+	r.enc = json.NewEncoder(r.buf)
+	if err := r.enc.Encode(Header{
+		Version:      Version,
+		SessionId:    sessionId,
+		ConnectionId: connectionId,
+		StartTime:    r.start,
+	}); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("new recorder: unable to write header: %w", err)
+	}
+	return r, nil
+}
+
+// Path returns the location of the recording.
+func (r *Recorder) Path() string {
+	return r.path
+}
+
+// Writer returns an io.Writer which records everything written to it as data
+// sent in direction d.
+func (r *Recorder) Writer(d Direction) io.Writer {
+	return writerFunc(func(p []byte) (int, error) {
+		return r.record(d, p)
+	})
+}
+
+func (r *Recorder) record(d Direction, p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch {
+	case r.closed:
+		return 0, errors.New("recorder is closed")
+	case r.err != nil:
+		return 0, r.err
+	}
+	if err := r.enc.Encode(Frame{
+		Offset:    time.Since(r.start),
+		Direction: d,
+		Data:      p,
+	}); err != nil {
+		r.err = fmt.Errorf("unable to write frame: %w", err)
+		return 0, r.err
+	}
+	switch d {
+	case Up:
+		r.bytesUp += uint64(len(p))
+	case Down:
+		r.bytesDown += uint64(len(p))
+	}
+	return len(p), nil
+}
+
+// Close flushes and closes the recording and returns its Summary.
+func (r *Recorder) Close() (*Summary, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil, errors.New("recorder is already closed")
+	}
+	r.closed = true
+	err := r.err
+	if ferr := r.buf.Flush(); ferr != nil && err == nil {
+		err = fmt.Errorf("unable to flush recording: %w", ferr)
+	}
+	if cerr := r.file.Close(); cerr != nil && err == nil {
+		err = fmt.Errorf("unable to close recording: %w", cerr)
+	}
+	return &Summary{
+		Path:      r.path,
+		BytesUp:   r.bytesUp,
+		BytesDown: r.bytesDown,
+		StartTime: r.start,
+		EndTime:   time.Now(),
+	}, err
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
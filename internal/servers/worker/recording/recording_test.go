@@ -0,0 +1,148 @@
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	dir, err := ioutil.TempDir("", "boundary-recording-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name         string
+		dir          string
+		sessionId    string
+		connectionId string
+		wantErr      bool
+	}{
+		{
+			name:         "valid",
+			dir:          dir,
+			sessionId:    "s_1234567890",
+			connectionId: "sc_1234567890",
+		},
+		{
+			name:         "missing-dir",
+			sessionId:    "s_1234567890",
+			connectionId: "sc_1234567891",
+			wantErr:      true,
+		},
+		{
+			name:         "missing-session-id",
+			dir:          dir,
+			connectionId: "sc_1234567892",
+			wantErr:      true,
+		},
+		{
+			name:      "missing-connection-id",
+			dir:       dir,
+			sessionId: "s_1234567890",
+			wantErr:   true,
+		},
+		{
+			name:         "existing-recording",
+			dir:          dir,
+			sessionId:    "s_1234567890",
+			connectionId: "sc_1234567890",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			r, err := New(tt.dir, tt.sessionId, tt.connectionId)
+			if tt.wantErr {
+				require.Error(err)
+				assert.Nil(r)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(filepath.Join(tt.dir, tt.sessionId, tt.connectionId+".rec"), r.Path())
+			_, err = r.Close()
+			require.NoError(err)
+		})
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	dir, err := ioutil.TempDir("", "boundary-recording-test")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	r, err := New(dir, "s_1234567890", "sc_1234567890")
+	require.NoError(err)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			n, err := r.Writer(Up).Write([]byte("ping"))
+			assert.NoError(err)
+			assert.Equal(4, n)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			n, err := r.Writer(Down).Write([]byte("pong!"))
+			assert.NoError(err)
+			assert.Equal(5, n)
+		}
+	}()
+	wg.Wait()
+
+	summary, err := r.Close()
+	require.NoError(err)
+	assert.Equal(r.Path(), summary.Path)
+	assert.Equal(uint64(40), summary.BytesUp)
+	assert.Equal(uint64(50), summary.BytesDown)
+	assert.False(summary.EndTime.Before(summary.StartTime))
+
+	_, err = r.Writer(Up).Write([]byte("late"))
+	assert.Error(err)
+	_, err = r.Close()
+	assert.Error(err)
+
+	f, err := os.Open(summary.Path)
+	require.NoError(err)
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	require.True(scanner.Scan())
+	var h Header
+	require.NoError(json.Unmarshal(scanner.Bytes(), &h))
+	assert.Equal(Version, h.Version)
+	assert.Equal("s_1234567890", h.SessionId)
+	assert.Equal("sc_1234567890", h.ConnectionId)
+	assert.True(h.StartTime.Equal(summary.StartTime))
+
+	var up, down int
+	for scanner.Scan() {
+		var fr Frame
+		require.NoError(json.Unmarshal(scanner.Bytes(), &fr))
+		switch fr.Direction {
+		case Up:
+			assert.Equal("ping", string(fr.Data))
+			up += len(fr.Data)
+		case Down:
+			assert.Equal("pong!", string(fr.Data))
+			down += len(fr.Data)
+		default:
+			t.Fatalf("unexpected direction %q", fr.Direction)
+		}
+	}
+	require.NoError(scanner.Err())
+	assert.Equal(40, up)
+	assert.Equal(50, down)
+}
@@ -11,8 +11,12 @@ import (
 	"sync"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/servers/worker/bandwidth"
+	"github.com/hashicorp/boundary/internal/servers/worker/recording"
 	"github.com/hashicorp/boundary/internal/session"
+	"go.uber.org/atomic"
 )
 
 const (
@@ -25,6 +29,43 @@ type connInfo struct {
 	connCancel context.CancelFunc
 	status     pbs.CONNECTIONSTATUS
 	closeTime  time.Time
+	recording  *recording.Summary
+
+	// bytesUp and bytesDown count the bytes proxied from the client to the
+	// endpoint and from the endpoint to the client respectively.
+	bytesUp   byteCounter
+	bytesDown byteCounter
+}
+
+// byteCounter is an io.Writer that counts the bytes written to it. It is safe
+// for concurrent use.
+type byteCounter struct {
+	atomic.Uint64
+}
+
+func (b *byteCounter) Write(p []byte) (int, error) {
+	b.Add(uint64(len(p)))
+	return len(p), nil
+}
+
+// activityTracker is an io.Writer that records the last time data was written
+// to it. It is safe for concurrent use.
+type activityTracker struct {
+	atomic.Int64
+}
+
+func (a *activityTracker) Write(p []byte) (int, error) {
+	a.touch()
+	return len(p), nil
+}
+
+func (a *activityTracker) touch() {
+	a.Store(time.Now().UnixNano())
+}
+
+// idleFor returns how long it has been since data was last written.
+func (a *activityTracker) idleFor() time.Duration {
+	return time.Since(time.Unix(0, a.Load()))
 }
 
 type sessionInfo struct {
@@ -34,6 +75,18 @@ type sessionInfo struct {
 	status                pbs.SESSIONSTATUS
 	lookupSessionResponse *pbs.LookupSessionResponse
 	connInfoMap           map[string]*connInfo
+
+	// bandwidthUp and bandwidthDown limit the combined throughput of all the
+	// session's connections in each direction. They are nil if the session
+	// has no limit.
+	bandwidthUp   *bandwidth.Limiter
+	bandwidthDown *bandwidth.Limiter
+
+	// lastActivity tracks when data was last proxied for any of the session's
+	// connections. Once the session's idle timeout passes without any data
+	// being proxied, idle is set and the session's connections are closed.
+	lastActivity activityTracker
+	idle         bool
 }
 
 func (w *Worker) getSessionTls(hello *tls.ClientHelloInfo) (*tls.Config, error) {
@@ -109,7 +162,10 @@ func (w *Worker) getSessionTls(hello *tls.ClientHelloInfo) (*tls.Config, error)
 		lookupSessionResponse: resp,
 		status:                resp.GetStatus(),
 		connInfoMap:           make(map[string]*connInfo),
+		bandwidthUp:           bandwidth.NewLimiter(resp.GetSessionMaxBytesPerSecond()),
+		bandwidthDown:         bandwidth.NewLimiter(resp.GetSessionMaxBytesPerSecond()),
 	}
+	si.lastActivity.touch()
 	// TODO: Periodicially clean this up. We can't rely on things in here but
 	// not in cancellation because they could be on the way to being
 	// established. However, since cert lifetimes are short, we can simply range
@@ -231,10 +287,14 @@ func (w *Worker) closeConnections(ctx context.Context, closeMap map[string]strin
 	w.logger.Trace("marking connections as closed", "session_and_connection_ids", fmt.Sprintf("%#v", closeMap))
 
 	closeData := make([]*pbs.CloseConnectionRequestData, 0, len(closeMap))
-	for connId := range closeMap {
+	for connId, sessionId := range closeMap {
+		bytesUp, bytesDown := w.connectionBytes(sessionId, connId)
 		closeData = append(closeData, &pbs.CloseConnectionRequestData{
 			ConnectionId: connId,
+			BytesUp:      bytesUp,
+			BytesDown:    bytesDown,
 			Reason:       session.UnknownReason.String(),
+			Recording:    w.connectionRecording(sessionId, connId),
 		})
 	}
 	closeInfo := &pbs.CloseConnectionRequest{
@@ -273,3 +333,54 @@ func (w *Worker) closeConnections(ctx context.Context, closeMap map[string]strin
 	w.logger.Trace("connections successfully marked closed", "connection_ids", closedIds)
 	return nil
 }
+
+// connectionBytes returns the number of bytes proxied up and down for the
+// connection so far.
+func (w *Worker) connectionBytes(sessionId, connectionId string) (uint64, uint64) {
+	siRaw, ok := w.sessionInfoMap.Load(sessionId)
+	if !ok {
+		return 0, 0
+	}
+	si := siRaw.(*sessionInfo)
+	si.RLock()
+	defer si.RUnlock()
+	ci, ok := si.connInfoMap[connectionId]
+	if !ok {
+		return 0, 0
+	}
+	return ci.bytesUp.Load(), ci.bytesDown.Load()
+}
+
+// connectionRecording returns the metadata of the connection's recording to
+// report to the controller, or nil if the connection was not recorded.
+func (w *Worker) connectionRecording(sessionId, connectionId string) *pbs.ConnectionRecording {
+	siRaw, ok := w.sessionInfoMap.Load(sessionId)
+	if !ok {
+		return nil
+	}
+	si := siRaw.(*sessionInfo)
+	si.RLock()
+	defer si.RUnlock()
+	ci, ok := si.connInfoMap[connectionId]
+	if !ok || ci.recording == nil {
+		return nil
+	}
+	startTime, err := ptypes.TimestampProto(ci.recording.StartTime)
+	if err != nil {
+		w.logger.Error("error converting recording start time", "error", err, "session_id", sessionId, "connection_id", connectionId)
+		return nil
+	}
+	endTime, err := ptypes.TimestampProto(ci.recording.EndTime)
+	if err != nil {
+		w.logger.Error("error converting recording end time", "error", err, "session_id", sessionId, "connection_id", connectionId)
+		return nil
+	}
+	return &pbs.ConnectionRecording{
+		StoragePath: ci.recording.Path,
+		BytesUp:     ci.recording.BytesUp,
+		BytesDown:   ci.recording.BytesDown,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		WorkerId:    w.conf.RawConfig.Worker.Name,
+	}
+}
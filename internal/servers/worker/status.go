@@ -54,6 +54,7 @@ func (w *Worker) startStatusTicking(cancelCtx context.Context) {
 					si := value.(*sessionInfo)
 					si.RLock()
 					status := si.status
+					idle := si.idle
 					connections := make([]*pbs.Connection, 0, len(si.connInfoMap))
 					for k, v := range si.connInfoMap {
 						connections = append(connections, &pbs.Connection{
@@ -71,6 +72,7 @@ func (w *Worker) startStatusTicking(cancelCtx context.Context) {
 									SessionId:   sessionId,
 									Status:      status,
 									Connections: connections,
+									Idle:        idle,
 								},
 							},
 						},
@@ -81,11 +83,13 @@ func (w *Worker) startStatusTicking(cancelCtx context.Context) {
 				result, err := client.Status(cancelCtx, &pbs.StatusRequest{
 					Jobs: activeJobs,
 					Worker: &servers.Server{
-						PrivateId:   w.conf.RawConfig.Worker.Name,
-						Name:        w.conf.RawConfig.Worker.Name,
-						Type:        resource.Worker.String(),
-						Description: w.conf.RawConfig.Worker.Description,
-						Address:     w.conf.RawConfig.Worker.PublicAddr,
+						PrivateId:         w.conf.RawConfig.Worker.Name,
+						Name:              w.conf.RawConfig.Worker.Name,
+						Type:              resource.Worker.String(),
+						Description:       w.conf.RawConfig.Worker.Description,
+						Address:           w.conf.RawConfig.Worker.PublicAddr,
+						Tags:              w.tags(),
+						DownstreamWorkers: w.downstreamWorkerNames(),
 					},
 				})
 				if err != nil {
@@ -129,15 +133,30 @@ func (w *Worker) startStatusTicking(cancelCtx context.Context) {
 				}
 
 				// Cleanup: Run through current jobs. Cancel connections for any
-				// canceling session or any session that is expired. Clear out
-				// sessions that are canceled or expired with all connections
-				// marked as closed. Close any that aren't marked as such.
+				// canceling session or any session that is expired or idle.
+				// Clear out sessions that are canceled or expired with all
+				// connections marked as closed. Close any that aren't marked as
+				// such. Idle sessions are kept until the controller reports
+				// them as terminated.
 				closeInfo := make(map[string]string)
 				cleanSessionIds := make([]string, 0)
 				w.sessionInfoMap.Range(func(key, value interface{}) bool {
 					si := value.(*sessionInfo)
 					si.Lock()
+					if idleTimeout := si.lookupSessionResponse.GetIdleTimeoutSeconds(); idleTimeout > 0 && !si.idle &&
+						si.lastActivity.idleFor() > time.Duration(idleTimeout)*time.Second {
+						w.logger.Info("session exceeded its idle timeout", "session_id", si.id)
+						si.idle = true
+					}
 					switch {
+					case si.idle && si.status != pbs.SESSIONSTATUS_SESSIONSTATUS_TERMINATED:
+						for k, v := range si.connInfoMap {
+							if v.closeTime.IsZero() {
+								v.connCancel()
+								w.logger.Info("terminated connection due to idle timeout", "session_id", si.id, "connection_id", k)
+								closeInfo[k] = si.id
+							}
+						}
 					case si.status == pbs.SESSIONSTATUS_SESSIONSTATUS_CANCELING,
 						si.status == pbs.SESSIONSTATUS_SESSIONSTATUS_TERMINATED,
 						time.Until(si.lookupSessionResponse.Expiration.AsTime()) < 0:
@@ -182,6 +201,25 @@ func (w *Worker) startStatusTicking(cancelCtx context.Context) {
 	}()
 }
 
+// tags returns the worker's tags from its configuration as they are reported
+// to the controller.
+func (w *Worker) tags() map[string]*servers.TagValues {
+	return tagValues(w.conf.RawConfig.Worker.Tags)
+}
+
+// tagValues converts tags from their form in the configuration to the form in
+// which they are reported to the controller.
+func tagValues(tags map[string][]string) map[string]*servers.TagValues {
+	if len(tags) == 0 {
+		return nil
+	}
+	ret := make(map[string]*servers.TagValues, len(tags))
+	for k, v := range tags {
+		ret[k] = &servers.TagValues{Values: v}
+	}
+	return ret
+}
+
 func (w *Worker) LastStatusSuccess() *LastStatusInformation {
 	return w.lastStatusSuccess.Load().(*LastStatusInformation)
 }
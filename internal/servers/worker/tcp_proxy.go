@@ -10,11 +10,17 @@ import (
 	"nhooyr.io/websocket"
 
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/proxy"
+	"github.com/hashicorp/boundary/internal/servers/worker/bandwidth"
+	"github.com/hashicorp/boundary/internal/servers/worker/recording"
 )
 
 func (w *Worker) handleTcpProxyV1(connCtx context.Context, clientAddr *net.TCPAddr, conn *websocket.Conn, si *sessionInfo, connectionId, endpoint string) {
 	si.RLock()
 	sessionId := si.lookupSessionResponse.GetAuthorization().GetSessionId()
+	recordingEnabled := si.lookupSessionResponse.GetRecordingEnabled()
+	connMaxBytesPerSecond := si.lookupSessionResponse.GetConnectionMaxBytesPerSecond()
+	workerFilter := si.lookupSessionResponse.GetWorkerFilter()
 	si.RUnlock()
 
 	sessionUrl, err := url.Parse(endpoint)
@@ -28,16 +34,40 @@ func (w *Worker) handleTcpProxyV1(connCtx context.Context, clientAddr *net.TCPAd
 		conn.Close(websocket.StatusInternalError, "invalid scheme for type")
 		return
 	}
-	remoteConn, err := net.Dial("tcp", sessionUrl.Host)
+	downstreamFilter, err := w.downstreamFilter(workerFilter)
 	if err != nil {
-		w.logger.Error("error dialing endpoint", "error", err, "endpoint", endpoint)
-		conn.Close(websocket.StatusInternalError, "endpoint dialing failed")
+		w.logger.Error("error checking worker filter", "error", err, "session_id", sessionId)
+		conn.Close(websocket.StatusInternalError, "invalid worker filter")
 		return
 	}
-	// Assert this for better Go 1.11 splice support
-	tcpRemoteConn := remoteConn.(*net.TCPConn)
 
-	endpointAddr := tcpRemoteConn.RemoteAddr().(*net.TCPAddr)
+	// If this worker does not match the session's worker filter, the endpoint
+	// is dialed by a downstream worker which does.
+	var remoteConn net.Conn
+	var endpointAddr *net.TCPAddr
+	switch {
+	case downstreamFilter != nil:
+		remoteConn, endpointAddr, err = w.dialDownstream(connCtx, downstreamFilter, &proxy.HopRequest{
+			SessionId:    sessionId,
+			ConnectionId: connectionId,
+			Endpoint:     endpoint,
+		})
+		if err != nil {
+			w.logger.Error("error forwarding connection to downstream worker", "error", err, "session_id", sessionId, "endpoint", endpoint)
+			conn.Close(websocket.StatusInternalError, "endpoint dialing failed")
+			return
+		}
+	default:
+		remoteConn, err = net.Dial("tcp", sessionUrl.Host)
+		if err != nil {
+			w.logger.Error("error dialing endpoint", "error", err, "endpoint", endpoint)
+			conn.Close(websocket.StatusInternalError, "endpoint dialing failed")
+			return
+		}
+		endpointAddr = remoteConn.RemoteAddr().(*net.TCPAddr)
+	}
+	defer remoteConn.Close()
+
 	connectionInfo := &pbs.ConnectConnectionRequest{
 		ConnectionId:       connectionId,
 		ClientTcpAddress:   clientAddr.IP.String(),
@@ -54,22 +84,53 @@ func (w *Worker) handleTcpProxyV1(connCtx context.Context, clientAddr *net.TCPAd
 		return
 	}
 	si.Lock()
-	si.connInfoMap[connectionId].status = connStatus
+	ci := si.connInfoMap[connectionId]
+	ci.status = connStatus
 	si.Unlock()
 
 	// Get a wrapped net.Conn so we can use io.Copy
 	netConn := websocket.NetConn(connCtx, conn, websocket.MessageBinary)
 
+	// Data read from either side is counted, and passed through the recorder if
+	// any, before being written to the other side.
+	upWriters, downWriters := []io.Writer{&ci.bytesUp, &si.lastActivity}, []io.Writer{&ci.bytesDown, &si.lastActivity}
+	if recordingEnabled {
+		rec, err := recording.New(w.conf.RawConfig.Worker.RecordingStoragePath, sessionId, connectionId)
+		if err != nil {
+			w.logger.Error("error creating session recording", "error", err, "session_id", sessionId, "connection_id", connectionId)
+			conn.Close(websocket.StatusInternalError, "unable to record connection")
+			return
+		}
+		defer func() {
+			summary, err := rec.Close()
+			if err != nil {
+				w.logger.Error("error closing session recording", "error", err, "session_id", sessionId, "connection_id", connectionId)
+			}
+			si.Lock()
+			si.connInfoMap[connectionId].recording = summary
+			si.Unlock()
+		}()
+		upWriters = append(upWriters, rec.Writer(recording.Up))
+		downWriters = append(downWriters, rec.Writer(recording.Down))
+	}
+	fromEndpoint := io.TeeReader(remoteConn, io.MultiWriter(downWriters...))
+	fromClient := io.TeeReader(netConn, io.MultiWriter(upWriters...))
+
+	// Data read from either side is held back until both the connection's and
+	// the session's bandwidth limits allow it.
+	fromEndpoint = bandwidth.NewReader(connCtx, fromEndpoint, bandwidth.NewLimiter(connMaxBytesPerSecond), si.bandwidthDown)
+	fromClient = bandwidth.NewReader(connCtx, fromClient, bandwidth.NewLimiter(connMaxBytesPerSecond), si.bandwidthUp)
+
 	connWg := new(sync.WaitGroup)
 	connWg.Add(2)
 	go func() {
 		defer connWg.Done()
-		_, err := io.Copy(netConn, tcpRemoteConn)
+		_, err := io.Copy(netConn, fromEndpoint)
 		w.logger.Debug("copy from client to endpoint done", "error", err)
 	}()
 	go func() {
 		defer connWg.Done()
-		_, err := io.Copy(tcpRemoteConn, netConn)
+		_, err := io.Copy(remoteConn, fromClient)
 		w.logger.Debug("copy from endpoint to client done", "error", err)
 	}()
 	connWg.Wait()
@@ -0,0 +1,294 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"nhooyr.io/websocket"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/proxy"
+	"github.com/hashicorp/boundary/internal/servers/worker/bandwidth"
+	"github.com/hashicorp/boundary/internal/servers/worker/recording"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// udpPeerIdleTimeout is how long the endpoint socket of a peer of the
+	// client is kept open without datagrams in either direction.
+	udpPeerIdleTimeout = time.Minute
+
+	// udpMaxPeers is the largest number of peers of the client with an open
+	// endpoint socket on a single connection. Datagrams of more peers are
+	// dropped until the sockets of others are closed.
+	udpMaxPeers = 1024
+)
+
+// handleUdpProxyV1 proxies datagrams between the client and the endpoint. Each
+// binary websocket message carries exactly one datagram so datagram boundaries
+// are preserved in both directions. The datagrams of all the peers of the
+// client's local listener share the connection; see udpPeerProxy.
+func (w *Worker) handleUdpProxyV1(connCtx context.Context, clientAddr *net.TCPAddr, conn *websocket.Conn, si *sessionInfo, connectionId, endpoint string) {
+	si.RLock()
+	sessionId := si.lookupSessionResponse.GetAuthorization().GetSessionId()
+	recordingEnabled := si.lookupSessionResponse.GetRecordingEnabled()
+	connMaxBytesPerSecond := si.lookupSessionResponse.GetConnectionMaxBytesPerSecond()
+	workerFilter := si.lookupSessionResponse.GetWorkerFilter()
+	si.RUnlock()
+
+	sessionUrl, err := url.Parse(endpoint)
+	if err != nil {
+		w.logger.Error("error parsing endpoint information", "error", err, "session_id", sessionId, "endpoint", endpoint)
+		conn.Close(websocket.StatusInternalError, "cannot parse endpoint url")
+		return
+	}
+	if sessionUrl.Scheme != "udp" {
+		w.logger.Error("invalid scheme for udp proxy", "error", err, "session_id", sessionId, "endpoint", endpoint)
+		conn.Close(websocket.StatusInternalError, "invalid scheme for type")
+		return
+	}
+	// Only the connections of tcp sessions can be forwarded to a downstream
+	// worker.
+	if downstreamFilter, err := w.downstreamFilter(workerFilter); err != nil || downstreamFilter != nil {
+		w.logger.Error("udp session does not match this worker's worker filter", "error", err, "session_id", sessionId)
+		conn.Close(websocket.StatusInternalError, "cannot forward udp session to downstream worker")
+		return
+	}
+	endpointAddr, err := net.ResolveUDPAddr("udp", sessionUrl.Host)
+	if err != nil {
+		w.logger.Error("error resolving endpoint", "error", err, "endpoint", endpoint)
+		conn.Close(websocket.StatusInternalError, "endpoint dialing failed")
+		return
+	}
+
+	connectionInfo := &pbs.ConnectConnectionRequest{
+		ConnectionId:       connectionId,
+		ClientTcpAddress:   clientAddr.IP.String(),
+		ClientTcpPort:      uint32(clientAddr.Port),
+		EndpointTcpAddress: endpointAddr.IP.String(),
+		EndpointTcpPort:    uint32(endpointAddr.Port),
+		Type:               "udp",
+	}
+
+	connStatus, err := w.connectConnection(connCtx, connectionInfo)
+	if err != nil {
+		w.logger.Error("error marking connection as connected", "error", err)
+		conn.Close(websocket.StatusInternalError, "failed to mark connection as connected")
+		return
+	}
+	si.Lock()
+	ci := si.connInfoMap[connectionId]
+	ci.status = connStatus
+	si.Unlock()
+
+	// Datagrams from either side are counted, and passed to the recorder if
+	// any, before being written to the other side.
+	upWriters, downWriters := []io.Writer{&ci.bytesUp, &si.lastActivity}, []io.Writer{&ci.bytesDown, &si.lastActivity}
+	if recordingEnabled {
+		rec, err := recording.New(w.conf.RawConfig.Worker.RecordingStoragePath, sessionId, connectionId)
+		if err != nil {
+			w.logger.Error("error creating session recording", "error", err, "session_id", sessionId, "connection_id", connectionId)
+			conn.Close(websocket.StatusInternalError, "unable to record connection")
+			return
+		}
+		defer func() {
+			summary, err := rec.Close()
+			if err != nil {
+				w.logger.Error("error closing session recording", "error", err, "session_id", sessionId, "connection_id", connectionId)
+			}
+			si.Lock()
+			si.connInfoMap[connectionId].recording = summary
+			si.Unlock()
+		}()
+		upWriters = append(upWriters, rec.Writer(recording.Up))
+		downWriters = append(downWriters, rec.Writer(recording.Down))
+	}
+	toUp, toDown := io.MultiWriter(upWriters...), io.MultiWriter(downWriters...)
+
+	// Datagrams from either side are held back until both the connection's and
+	// the session's bandwidth limits allow them.
+	connBandwidthUp, connBandwidthDown := bandwidth.NewLimiter(connMaxBytesPerSecond), bandwidth.NewLimiter(connMaxBytesPerSecond)
+
+	p := &udpPeerProxy{
+		conn:        conn,
+		endpoint:    endpointAddr,
+		logger:      w.logger,
+		idleTimeout: udpPeerIdleTimeout,
+		maxPeers:    udpMaxPeers,
+		up: func(ctx context.Context, datagram []byte) error {
+			toUp.Write(datagram)
+			return bandwidth.WaitN(ctx, len(datagram), connBandwidthUp, si.bandwidthUp)
+		},
+		down: func(ctx context.Context, datagram []byte) error {
+			toDown.Write(datagram)
+			return bandwidth.WaitN(ctx, len(datagram), connBandwidthDown, si.bandwidthDown)
+		},
+	}
+	p.run(connCtx)
+}
+
+// A udpPeerProxy proxies the datagrams of the peers of the client's local
+// listener, which share a single connection, to the endpoint. The datagrams
+// of each peer are sent from their own endpoint socket, which is opened with
+// the first datagram of the peer and closed once it has been idle for the
+// idle timeout.
+type udpPeerProxy struct {
+	conn        *websocket.Conn
+	endpoint    *net.UDPAddr
+	logger      hclog.Logger
+	idleTimeout time.Duration
+	maxPeers    int
+
+	// up and down are called with each datagram before it is written to the
+	// endpoint and to the client respectively. An error ends the proxying.
+	up, down func(ctx context.Context, datagram []byte) error
+
+	mu    sync.Mutex
+	peers map[uint32]*udpPeer
+	wg    sync.WaitGroup
+}
+
+// A udpPeer is the endpoint socket of a peer of the client.
+type udpPeer struct {
+	conn *net.UDPConn
+	// lastActive is the time, in unix nanoseconds, of the last datagram sent
+	// or received on the socket.
+	lastActive atomic.Int64
+}
+
+func (p *udpPeer) touch() {
+	p.lastActive.Store(time.Now().UnixNano())
+}
+
+func (p *udpPeer) idleFor() time.Duration {
+	return time.Since(time.Unix(0, p.lastActive.Load()))
+}
+
+// run proxies datagrams until the connection to the client ends or ctx is
+// done, and then closes the endpoint sockets.
+func (p *udpPeerProxy) run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	p.peers = make(map[uint32]*udpPeer)
+	p.conn.SetReadLimit(proxy.MaxUdpFrameSize)
+
+	defer func() {
+		// Closing the sockets unblocks the reads of their goroutines.
+		p.mu.Lock()
+		for _, peer := range p.peers {
+			peer.conn.Close()
+		}
+		p.mu.Unlock()
+		p.wg.Wait()
+	}()
+	for {
+		typ, frame, err := p.conn.Read(ctx)
+		if err != nil {
+			p.logger.Debug("read from client done", "error", err)
+			return
+		}
+		if typ != websocket.MessageBinary {
+			continue
+		}
+		id, datagram, err := proxy.ParseUdpFrame(frame)
+		if err != nil {
+			p.logger.Debug("invalid frame from client", "error", err)
+			p.conn.Close(websocket.StatusProtocolError, "invalid udp frame")
+			return
+		}
+		peer, err := p.peer(ctx, cancel, id)
+		if err != nil {
+			p.logger.Debug("dropping datagram", "error", err, "peer_id", id)
+			continue
+		}
+		if err := p.up(ctx, datagram); err != nil {
+			p.logger.Debug("write to endpoint done", "error", err)
+			return
+		}
+		if _, err := peer.conn.Write(datagram); err != nil {
+			p.logger.Debug("write to endpoint failed", "error", err, "peer_id", id)
+		}
+	}
+}
+
+// peer returns the endpoint socket of the peer id, opening it if needed, and
+// marks it active. The datagrams received on a new socket are sent to the
+// client until it is idle, or until sending one fails, which cancels the
+// proxying.
+func (p *udpPeerProxy) peer(ctx context.Context, cancel context.CancelFunc, id uint32) (*udpPeer, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if peer, ok := p.peers[id]; ok {
+		peer.touch()
+		return peer, nil
+	}
+	if len(p.peers) >= p.maxPeers {
+		return nil, errors.New("too many peers")
+	}
+	conn, err := net.DialUDP("udp", nil, p.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	peer := &udpPeer{conn: conn}
+	peer.touch()
+	p.peers[id] = peer
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer func() {
+			p.mu.Lock()
+			if p.peers[id] == peer {
+				delete(p.peers, id)
+			}
+			p.mu.Unlock()
+			conn.Close()
+		}()
+		frame := make([]byte, proxy.MaxUdpFrameSize)
+		proxy.PutUdpFrameHeader(frame, id)
+		for {
+			conn.SetReadDeadline(time.Now().Add(p.idleTimeout))
+			n, err := conn.Read(frame[proxy.UdpFrameHeaderSize:])
+			if err != nil {
+				if ne, ok := err.(net.Error); ok && ne.Timeout() && !p.expire(id, peer) {
+					// Datagrams were sent to the endpoint in the meantime.
+					continue
+				}
+				p.logger.Debug("read from endpoint done", "error", err, "peer_id", id)
+				return
+			}
+			peer.touch()
+			datagram := frame[proxy.UdpFrameHeaderSize : proxy.UdpFrameHeaderSize+n]
+			if err := p.down(ctx, datagram); err != nil {
+				p.logger.Debug("write to client done", "error", err)
+				cancel()
+				return
+			}
+			if err := p.conn.Write(ctx, websocket.MessageBinary, frame[:proxy.UdpFrameHeaderSize+n]); err != nil {
+				p.logger.Debug("write to client done", "error", err)
+				cancel()
+				return
+			}
+		}
+	}()
+	return peer, nil
+}
+
+// expire removes the socket of the peer id if it has been idle for the idle
+// timeout, and reports whether it did. The check is made under the lock which
+// peer takes, so that a socket is never handed a datagram once removed.
+func (p *udpPeerProxy) expire(id uint32, peer *udpPeer) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if peer.idleFor() < p.idleTimeout {
+		return false
+	}
+	delete(p.peers, id)
+	return true
+}
@@ -0,0 +1,130 @@
+package worker
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/proxy"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"nhooyr.io/websocket"
+)
+
+// testUdpEchoServer starts a udp server which answers each datagram with the
+// address it came from, and returns its address.
+func testUdpEchoServer(t *testing.T) *net.UDPAddr {
+	t.Helper()
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	go func() {
+		buf := make([]byte, proxy.MaxUdpDatagramSize)
+		for {
+			_, addr, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			_, _ = conn.WriteToUDP([]byte(addr.String()), addr)
+		}
+	}()
+	return conn.LocalAddr().(*net.UDPAddr)
+}
+
+// testUdpPeerProxy serves p over a websocket and returns the client's end.
+func testUdpPeerProxy(t *testing.T, p *udpPeerProxy) *websocket.Conn {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		p.conn = conn
+		p.run(ctx)
+	}))
+	t.Cleanup(srv.Close)
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close(websocket.StatusNormalClosure, "done") })
+	return conn
+}
+
+func TestUdpPeerProxy(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	noop := func(context.Context, []byte) error { return nil }
+	var upCount atomic.Int32
+	p := &udpPeerProxy{
+		endpoint:    testUdpEchoServer(t),
+		logger:      hclog.NewNullLogger(),
+		idleTimeout: 200 * time.Millisecond,
+		maxPeers:    3,
+		up: func(context.Context, []byte) error {
+			upCount.Inc()
+			return nil
+		},
+		down: noop,
+	}
+	conn := testUdpPeerProxy(t, p)
+
+	send := func(id uint32) {
+		frame := make([]byte, proxy.UdpFrameHeaderSize+len("query"))
+		proxy.PutUdpFrameHeader(frame, id)
+		copy(frame[proxy.UdpFrameHeaderSize:], "query")
+		require.NoError(conn.Write(ctx, websocket.MessageBinary, frame))
+	}
+	// receive returns the source address the endpoint saw for each peer.
+	receive := func(n int) map[uint32]string {
+		got := make(map[uint32]string)
+		for i := 0; i < n; i++ {
+			readCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			_, frame, err := conn.Read(readCtx)
+			cancel()
+			require.NoError(err)
+			id, datagram, err := proxy.ParseUdpFrame(frame)
+			require.NoError(err)
+			got[id] = string(datagram)
+		}
+		return got
+	}
+	peerCount := func() int {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return len(p.peers)
+	}
+
+	// The datagrams of each peer of the client are sent from a socket of
+	// their own, and the answers are sent back to the right peer.
+	send(1)
+	send(2)
+	send(3)
+	first := receive(3)
+	require.Len(first, 3)
+	assert.NotEqual(first[1], first[2])
+	assert.NotEqual(first[1], first[3])
+	assert.NotEqual(first[2], first[3])
+	send(2)
+	assert.Equal(map[uint32]string{2: first[2]}, receive(1))
+
+	// Datagrams of peers beyond the maximum are dropped, and are not
+	// counted as sent up.
+	send(4)
+	send(1)
+	assert.Equal(map[uint32]string{1: first[1]}, receive(1))
+	assert.Equal(int32(5), upCount.Load())
+
+	// Idle sockets are closed, making room for new peers.
+	require.Eventually(func() bool { return peerCount() == 0 }, 5*time.Second, 10*time.Millisecond)
+	send(4)
+	got := receive(1)
+	assert.Contains(got, uint32(4))
+	assert.Equal(1, peerCount())
+}
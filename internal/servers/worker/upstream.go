@@ -0,0 +1,153 @@
+package worker
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/proxy"
+)
+
+// upstreamRetryInterval is how long a downstream worker waits before
+// reconnecting to an upstream worker after a connection failed.
+const upstreamRetryInterval = 5 * time.Second
+
+func (w *Worker) startUpstreamConnections() error {
+	for _, addr := range w.conf.RawConfig.Worker.Upstreams {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil && strings.Contains(err.Error(), "missing port in address") {
+			w.logger.Trace("missing port in upstream address, using port 9202", "address", addr)
+			host, port, err = net.SplitHostPort(fmt.Sprintf("%s:%s", addr, "9202"))
+		}
+		if err != nil {
+			return fmt.Errorf("error parsing upstream address: %w", err)
+		}
+		upstreamAddr := net.JoinHostPort(host, port)
+		for i := 0; i < hopPoolSize; i++ {
+			go w.keepUpstreamConnection(upstreamAddr)
+		}
+	}
+	return nil
+}
+
+// keepUpstreamConnection keeps an idle connection open to the upstream worker
+// at addr. Each time the upstream worker uses the connection to forward a
+// connection of a session, a new one is opened in its place.
+func (w *Worker) keepUpstreamConnection(addr string) {
+	dial := w.controllerDialerFunc()
+	retry := func() bool {
+		select {
+		case <-w.baseContext.Done():
+			return false
+		case <-time.After(upstreamRetryInterval):
+			return true
+		}
+	}
+	for {
+		select {
+		case <-w.baseContext.Done():
+			return
+		default:
+		}
+
+		conn, err := dial(w.baseContext, addr)
+		if err != nil {
+			w.logger.Error("error connecting to upstream worker", "address", addr, "error", err)
+			if !retry() {
+				return
+			}
+			continue
+		}
+
+		// Close the connection if the worker shuts down while it is idle.
+		idle := make(chan struct{})
+		go func() {
+			select {
+			case <-w.baseContext.Done():
+				conn.Close()
+			case <-idle:
+			}
+		}()
+		req := new(proxy.HopRequest)
+		err = readHopMessage(conn, req)
+		close(idle)
+		if err != nil {
+			conn.Close()
+			if err != io.EOF {
+				w.logger.Error("error reading hop request from upstream worker", "address", addr, "error", err)
+			}
+			if !retry() {
+				return
+			}
+			continue
+		}
+		go w.handleHop(conn, req)
+	}
+}
+
+// handleHop dials the endpoint the upstream worker asked for and forwards the
+// data of the session's connection between the upstream worker and the
+// endpoint.
+func (w *Worker) handleHop(conn net.Conn, req *proxy.HopRequest) {
+	defer conn.Close()
+	endpointConn, err := dialHopEndpoint(req.GetEndpoint())
+	resp := new(proxy.HopResponse)
+	if err != nil {
+		w.logger.Error("error dialing endpoint for upstream worker", "error", err, "session_id", req.GetSessionId(), "endpoint", req.GetEndpoint())
+		resp.Error = "endpoint dialing failed"
+	} else {
+		defer endpointConn.Close()
+		endpointAddr := endpointConn.RemoteAddr().(*net.TCPAddr)
+		resp.EndpointAddress = endpointAddr.IP.String()
+		resp.EndpointPort = uint32(endpointAddr.Port)
+	}
+	conn.SetWriteDeadline(time.Now().Add(hopTimeout))
+	if err := writeHopMessage(conn, resp); err != nil {
+		w.logger.Error("error sending hop response to upstream worker", "error", err, "session_id", req.GetSessionId())
+		return
+	}
+	conn.SetWriteDeadline(time.Time{})
+	if endpointConn == nil {
+		return
+	}
+	w.logger.Debug("forwarding connection for upstream worker", "session_id", req.GetSessionId(), "connection_id", req.GetConnectionId())
+
+	// Once either side is done, close both so that the other copy returns.
+	var closeOnce sync.Once
+	closeBoth := func() {
+		closeOnce.Do(func() {
+			conn.Close()
+			endpointConn.Close()
+		})
+	}
+	connWg := new(sync.WaitGroup)
+	connWg.Add(2)
+	go func() {
+		defer connWg.Done()
+		defer closeBoth()
+		_, err := io.Copy(conn, endpointConn)
+		w.logger.Debug("copy from endpoint to upstream worker done", "error", err)
+	}()
+	go func() {
+		defer connWg.Done()
+		defer closeBoth()
+		_, err := io.Copy(endpointConn, conn)
+		w.logger.Debug("copy from upstream worker to endpoint done", "error", err)
+	}()
+	connWg.Wait()
+}
+
+func dialHopEndpoint(endpoint string) (net.Conn, error) {
+	endpointUrl, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing endpoint: %w", err)
+	}
+	if endpointUrl.Scheme != "tcp" {
+		return nil, fmt.Errorf("invalid scheme %q for forwarded connection", endpointUrl.Scheme)
+	}
+	return net.Dial("tcp", endpointUrl.Host)
+}
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/cmd/config"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/vault/sdk/helper/base62"
 	"github.com/hashicorp/vault/sdk/helper/mlock"
+	"github.com/patrickmn/go-cache"
 	ua "go.uber.org/atomic"
 	"google.golang.org/grpc/resolver"
 	"google.golang.org/grpc/resolver/manual"
@@ -32,6 +34,12 @@ type Worker struct {
 
 	controllerSessionConn *atomic.Value
 	sessionInfoMap        *sync.Map
+
+	// downstreamWorkers contains the workers connected to this worker as its
+	// downstream workers, by name, and downstreamAuthCache the nonces of
+	// their connections which have yet to be received.
+	downstreamWorkers   *sync.Map
+	downstreamAuthCache *cache.Cache
 }
 
 func New(conf *Config) (*Worker, error) {
@@ -44,6 +52,8 @@ func New(conf *Config) (*Worker, error) {
 		controllerResolverCleanup: new(atomic.Value),
 		controllerSessionConn:     new(atomic.Value),
 		sessionInfoMap:            new(sync.Map),
+		downstreamWorkers:         new(sync.Map),
+		downstreamAuthCache:       cache.New(hopTimeout, time.Minute),
 	}
 
 	w.lastStatusSuccess.Store((*LastStatusInformation)(nil))
@@ -102,6 +112,9 @@ func (w *Worker) Start() error {
 	if err := w.startControllerConnections(); err != nil {
 		return fmt.Errorf("error making controller connections: %w", err)
 	}
+	if err := w.startUpstreamConnections(); err != nil {
+		return fmt.Errorf("error making upstream worker connections: %w", err)
+	}
 
 	w.startStatusTicking(w.baseContext)
 	w.started.Store(true)
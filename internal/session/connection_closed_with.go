@@ -13,6 +13,9 @@ type CloseWith struct {
 	BytesUp      uint64
 	BytesDown    uint64
 	ClosedReason ClosedReason
+	// Recording is optional and contains the metadata of the connection's
+	// recording when recording is enabled for the session.
+	Recording *ConnectionRecording
 }
 
 func (c CloseWith) validate() error {
@@ -23,5 +26,10 @@ func (c CloseWith) validate() error {
 		return fmt.Errorf("missing closed reason: %w", db.ErrInvalidParameter)
 	}
 	// 0 is valid for BytesUp and BytesDown
+	if c.Recording != nil {
+		if err := c.Recording.validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
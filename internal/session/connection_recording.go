@@ -0,0 +1,105 @@
+package session
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultConnectionRecordingTableName = "session_connection_recording"
+)
+
+// ConnectionRecording contains the metadata of a recording made by a worker for
+// a session's connection.  The recording itself is stored by the worker.
+type ConnectionRecording struct {
+	// ConnectionId of the recorded connection
+	ConnectionId string `json:"connection_id,omitempty" gorm:"primary_key"`
+	// SessionId of the recorded connection
+	SessionId string `json:"session_id,omitempty" gorm:"default:null"`
+	// WorkerId of the worker that made the recording
+	WorkerId string `json:"worker_id,omitempty" gorm:"default:null"`
+	// StoragePath is the location of the recording on the worker
+	StoragePath string `json:"storage_path,omitempty" gorm:"default:null"`
+	// BytesUp recorded from the client to the endpoint
+	BytesUp uint64 `json:"bytes_up,omitempty" gorm:"default:null"`
+	// BytesDown recorded from the endpoint to the client
+	BytesDown uint64 `json:"bytes_down,omitempty" gorm:"default:null"`
+	// StartTime of the recording
+	StartTime *timestamp.Timestamp `json:"start_time,omitempty" gorm:"default:null"`
+	// EndTime of the recording
+	EndTime *timestamp.Timestamp `json:"end_time,omitempty" gorm:"default:null"`
+	// CreateTime from the RDBMS
+	CreateTime *timestamp.Timestamp `json:"create_time,omitempty" gorm:"default:current_timestamp"`
+
+	tableName string `gorm:"-"`
+}
+
+var _ Cloneable = (*ConnectionRecording)(nil)
+
+// Clone creates a clone of the ConnectionRecording
+func (c *ConnectionRecording) Clone() interface{} {
+	clone := &ConnectionRecording{
+		ConnectionId: c.ConnectionId,
+		SessionId:    c.SessionId,
+		WorkerId:     c.WorkerId,
+		StoragePath:  c.StoragePath,
+		BytesUp:      c.BytesUp,
+		BytesDown:    c.BytesDown,
+	}
+	clone.StartTime = cloneTimestamp(c.StartTime)
+	clone.EndTime = cloneTimestamp(c.EndTime)
+	clone.CreateTime = cloneTimestamp(c.CreateTime)
+	return clone
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (c *ConnectionRecording) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return defaultConnectionRecordingTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (c *ConnectionRecording) SetTableName(n string) {
+	c.tableName = n
+}
+
+// validate checks the recording metadata reported by a worker when it closes a
+// connection.  The ConnectionId and SessionId are taken from the closed
+// connection.
+func (c *ConnectionRecording) validate() error {
+	if c.WorkerId == "" {
+		return fmt.Errorf("missing recording worker id: %w", db.ErrInvalidParameter)
+	}
+	if c.StoragePath == "" {
+		return fmt.Errorf("missing recording storage path: %w", db.ErrInvalidParameter)
+	}
+	if c.StartTime.GetTimestamp() == nil {
+		return fmt.Errorf("missing recording start time: %w", db.ErrInvalidParameter)
+	}
+	if c.EndTime.GetTimestamp() == nil {
+		return fmt.Errorf("missing recording end time: %w", db.ErrInvalidParameter)
+	}
+	if c.EndTime.GetTimestamp().AsTime().Before(c.StartTime.GetTimestamp().AsTime()) {
+		return fmt.Errorf("recording end time is before start time: %w", db.ErrInvalidParameter)
+	}
+	return nil
+}
+
+func cloneTimestamp(t *timestamp.Timestamp) *timestamp.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return &timestamp.Timestamp{
+		Timestamp: &timestamppb.Timestamp{
+			Seconds: t.GetTimestamp().GetSeconds(),
+			Nanos:   t.GetTimestamp().GetNanos(),
+		},
+	}
+}
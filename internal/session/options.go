@@ -18,14 +18,15 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withLimit          int
-	withOrder          string
-	withScopeId        string
-	withUserId         string
-	withExpirationTime *timestamp.Timestamp
-	withTestTofu       []byte
-	withListingConvert bool
-	withSessionIds     []string
+	withLimit            int
+	withStartPageAfterId string
+	withOrder            string
+	withScopeId          string
+	withUserId           string
+	withExpirationTime   *timestamp.Timestamp
+	withTestTofu         []byte
+	withListingConvert   bool
+	withSessionIds       []string
 }
 
 func getDefaultOptions() options {
@@ -89,3 +90,11 @@ func withListingConvert(withListingConvert bool) Option {
 		o.withListingConvert = withListingConvert
 	}
 }
+
+// WithStartPageAfterId provides an option to only list resources whose public
+// ids sort after the given id. Used to retrieve the next page of results.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
@@ -144,14 +144,29 @@ select expiration_time, connection_limit, current_connection_count
 from  
 	session_connection_limit, session_connection_count;	
 `
+	// insertConnectionRecording inserts the recording metadata for a
+	// connection, taking the session id from the connection.  A worker may
+	// report a closed connection more than once, so an existing recording is
+	// left unchanged.
+	insertConnectionRecording = `
+insert into session_connection_recording
+	(connection_id, session_id, worker_id, storage_path, bytes_up, bytes_down, start_time, end_time)
+select
+	public_id, session_id, $2, $3, $4, $5, $6, $7
+from
+	session_connection
+where
+	public_id = $1
+on conflict (connection_id) do nothing;
+`
+
 	sessionList = `
 select * 
 from
-	(select public_id from session %s) s,
+	(select public_id from session %s order by public_id collate "C" %s) s,
 	session_with_state ss
 where 
 	s.public_id = ss.public_id 
-	%s
 %s
 `
 
@@ -231,5 +246,49 @@ where
                	end_time is null
     )
 )
+`
+
+	// activeSessionCountsByHost counts the pending and active sessions of the
+	// hosts, whose ids are substituted into the in clause.
+	activeSessionCountsByHost = `
+select
+	s.host_id,
+	count(*) as session_count
+from
+	session s,
+	session_state ss
+where
+	s.public_id = ss.session_id and
+	ss.state in ('pending', 'active') and
+	ss.end_time is null and
+	s.host_id in (%s)
+group by s.host_id;
+`
+
+	// lastSessionHost returns the host of the most recently created session
+	// of the target. Additional conditions are substituted into the where
+	// clause.
+	lastSessionHost = `
+select
+	host_id
+from
+	session
+where
+	target_id = $1 and
+	host_id is not null
+	%s
+order by create_time desc, public_id desc
+limit 1;
+`
+
+	// rewrapSessionQuery replaces the encrypted tofu token unless it was
+	// changed since it was read.
+	rewrapSessionQuery = `
+update session
+   set tofu_token = ?,
+       key_id = ?
+ where public_id = ?
+   and key_id = ?
+   and tofu_token = ?;
 `
 )
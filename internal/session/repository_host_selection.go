@@ -0,0 +1,73 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// ActiveSessionCountsByHost returns the number of pending and active sessions
+// of each of the hosts, across all targets. Hosts without any pending or
+// active sessions are not included in the returned map.
+func (r *Repository) ActiveSessionCountsByHost(ctx context.Context, hostIds []string) (map[string]int, error) {
+	if len(hostIds) == 0 {
+		return nil, fmt.Errorf("active session counts by host: missing host ids: %w", db.ErrInvalidParameter)
+	}
+	inClause := make([]string, 0, len(hostIds))
+	args := make([]interface{}, 0, len(hostIds))
+	for i, id := range hostIds {
+		inClause, args = append(inClause, fmt.Sprintf("$%d", i+1)), append(args, id)
+	}
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(activeSessionCountsByHost, strings.Join(inClause, ",")), args)
+	if err != nil {
+		return nil, fmt.Errorf("active session counts by host: query failed: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int, len(hostIds))
+	for rows.Next() {
+		var c struct {
+			HostId       string
+			SessionCount int
+		}
+		if err := r.reader.ScanRows(rows, &c); err != nil {
+			return nil, fmt.Errorf("active session counts by host: scan row failed: %w", err)
+		}
+		counts[c.HostId] = c.SessionCount
+	}
+	return counts, nil
+}
+
+// LastSessionHostId returns the id of the host of the most recently created
+// session of the target, or an empty string if the target has no sessions.
+// Supports the WithUserId option to only consider the sessions of the user.
+func (r *Repository) LastSessionHostId(ctx context.Context, targetId string, opt ...Option) (string, error) {
+	if targetId == "" {
+		return "", fmt.Errorf("last session host id: missing target id: %w", db.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	var where string
+	args := []interface{}{targetId}
+	if opts.withUserId != "" {
+		where, args = "and user_id = $2", append(args, opts.withUserId)
+	}
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(lastSessionHost, where), args)
+	if err != nil {
+		return "", fmt.Errorf("last session host id: query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var hostId string
+	for rows.Next() {
+		var h struct {
+			HostId string
+		}
+		if err := r.reader.ScanRows(rows, &h); err != nil {
+			return "", fmt.Errorf("last session host id: scan row failed: %w", err)
+		}
+		hostId = h.HostId
+	}
+	return hostId, nil
+}
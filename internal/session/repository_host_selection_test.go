@@ -0,0 +1,79 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ActiveSessionCountsByHost(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	ctx := context.Background()
+
+	_, err = repo.ActiveSessionCountsByHost(ctx, nil)
+	require.Error(err)
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+
+	composedOf := TestSessionParams(t, conn, wrapper, iamRepo)
+	s1 := TestSession(t, conn, wrapper, composedOf)
+	_ = TestSession(t, conn, wrapper, composedOf)
+
+	counts, err := repo.ActiveSessionCountsByHost(ctx, []string{composedOf.HostId, "hst_unknown"})
+	require.NoError(err)
+	assert.Equal(map[string]int{composedOf.HostId: 2}, counts)
+
+	_, err = repo.TerminateSession(ctx, s1.PublicId, s1.Version, ClosedByUser)
+	require.NoError(err)
+	counts, err = repo.ActiveSessionCountsByHost(ctx, []string{composedOf.HostId})
+	require.NoError(err)
+	assert.Equal(map[string]int{composedOf.HostId: 1}, counts)
+}
+
+func TestRepository_LastSessionHostId(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	ctx := context.Background()
+
+	_, err = repo.LastSessionHostId(ctx, "")
+	require.Error(err)
+	assert.True(errors.Is(err, db.ErrInvalidParameter))
+
+	composedOf := TestSessionParams(t, conn, wrapper, iamRepo)
+	got, err := repo.LastSessionHostId(ctx, composedOf.TargetId)
+	require.NoError(err)
+	assert.Empty(got)
+
+	_ = TestSession(t, conn, wrapper, composedOf)
+	got, err = repo.LastSessionHostId(ctx, composedOf.TargetId)
+	require.NoError(err)
+	assert.Equal(composedOf.HostId, got)
+
+	got, err = repo.LastSessionHostId(ctx, composedOf.TargetId, WithUserId(composedOf.UserId))
+	require.NoError(err)
+	assert.Equal(composedOf.HostId, got)
+
+	got, err = repo.LastSessionHostId(ctx, composedOf.TargetId, WithUserId("u_unknown"))
+	require.NoError(err)
+	assert.Empty(got)
+}
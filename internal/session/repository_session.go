@@ -75,6 +75,7 @@ func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.
 	}
 	newSession.Certificate = certBytes
 	newSession.PublicId = id
+	newSession.SessionKeyVersionId = sessionWrapper.KeyID()
 
 	var returnedSession *Session
 	_, err = r.writer.DoTx(
@@ -111,9 +112,9 @@ func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.
 }
 
 // LookupSession will look up a session in the repository and return the session
-// with its states.  Returned States are ordered by start time descending.  If the
-// session is not found, it will return nil, nil, nil. No options are currently
-// supported.
+// with its states and connection recordings.  Returned States are ordered by
+// start time descending.  If the session is not found, it will return nil, nil,
+// nil. No options are currently supported.
 func (r *Repository) LookupSession(ctx context.Context, sessionId string, opt ...Option) (*Session, *ConnectionAuthzSummary, error) {
 	if sessionId == "" {
 		return nil, nil, fmt.Errorf("lookup session: missing sessionId id: %w", db.ErrInvalidParameter)
@@ -133,6 +134,16 @@ func (r *Repository) LookupSession(ctx context.Context, sessionId string, opt ..
 				return err
 			}
 			session.States = states
+			recordings, err := fetchRecordings(ctx, read, sessionId, db.WithOrder("start_time asc"))
+			if err != nil {
+				return err
+			}
+			session.Recordings = recordings
+			connections, err := fetchConnections(ctx, read, sessionId, db.WithOrder("create_time asc"))
+			if err != nil {
+				return err
+			}
+			session.Connections = connections
 			return nil
 		},
 	)
@@ -162,7 +173,9 @@ func (r *Repository) LookupSession(ctx context.Context, sessionId string, opt ..
 	return &session, authzSummary, nil
 }
 
-// ListSessions will sessions.  Supports the WithLimit, WithScopeId and WithSessionIds options.
+// ListSessions will sessions.  Supports the WithLimit, WithScopeId,
+// WithSessionIds and WithStartPageAfterId options.  Sessions are ordered by
+// public id unless the WithOrder option is used.
 func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Session, error) {
 	opts := getOpts(opt...)
 	var where []string
@@ -183,7 +196,11 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 			inClauseCnt += 1
 			idsInClause, args = append(idsInClause, fmt.Sprintf("$%d", inClauseCnt)), append(args, id)
 		}
-		where = append(where, fmt.Sprintf("s.public_id in(%s)", strings.Join(idsInClause, ",")))
+		where = append(where, fmt.Sprintf("public_id in(%s)", strings.Join(idsInClause, ",")))
+	}
+	if opts.withStartPageAfterId != "" {
+		inClauseCnt += 1
+		where, args = append(where, fmt.Sprintf("public_id collate \"C\" > $%d", inClauseCnt)), append(args, opts.withStartPageAfterId)
 	}
 
 	var limit string
@@ -196,16 +213,19 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 		limit = fmt.Sprintf("limit %d", opts.withLimit)
 	}
 
+	// The limit is applied to the sessions, not to their states, so the
+	// conditions and the order are part of the sessions subquery.
+	order := `order by ss.public_id collate "C"`
 	if opts.withOrder != "" {
-		opts.withOrder = fmt.Sprintf("order by %s", opts.withOrder)
+		order = fmt.Sprintf("order by %s", opts.withOrder)
 	}
 
 	var whereClause string
 	if len(where) > 0 {
-		whereClause = " and " + strings.Join(where, " and")
+		whereClause = "where " + strings.Join(where, " and ")
 	}
 	q := sessionList
-	query := fmt.Sprintf(q, limit, whereClause, opts.withOrder)
+	query := fmt.Sprintf(q, whereClause, limit, order)
 
 	rows, err := r.reader.Query(ctx, query, args)
 	if err != nil {
@@ -533,6 +553,20 @@ func (r *Repository) CloseConnections(ctx context.Context, closeWith []CloseWith
 				if rowsUpdated != 1 {
 					return fmt.Errorf("%d would have been updated for connection %s", rowsUpdated, cw.ConnectionId)
 				}
+				if cw.Recording != nil {
+					_, err := w.Exec(ctx, insertConnectionRecording, []interface{}{
+						cw.ConnectionId,
+						cw.Recording.WorkerId,
+						cw.Recording.StoragePath,
+						cw.Recording.BytesUp,
+						cw.Recording.BytesDown,
+						cw.Recording.StartTime.GetTimestamp().AsTime(),
+						cw.Recording.EndTime.GetTimestamp().AsTime(),
+					})
+					if err != nil {
+						return fmt.Errorf("unable to insert recording for connection %s: %w", cw.ConnectionId, err)
+					}
+				}
 				states, err := fetchConnectionStates(ctx, reader, cw.ConnectionId, db.WithOrder("start_time desc"))
 				if err != nil {
 					return err
@@ -711,3 +745,25 @@ func fetchStates(ctx context.Context, r db.Reader, sessionId string, opt ...db.O
 	}
 	return states, nil
 }
+
+func fetchRecordings(ctx context.Context, r db.Reader, sessionId string, opt ...db.Option) ([]*ConnectionRecording, error) {
+	var recordings []*ConnectionRecording
+	if err := r.SearchWhere(ctx, &recordings, "session_id = ?", []interface{}{sessionId}, opt...); err != nil {
+		return nil, fmt.Errorf("fetch session connection recordings: %w", err)
+	}
+	if len(recordings) == 0 {
+		return nil, nil
+	}
+	return recordings, nil
+}
+
+func fetchConnections(ctx context.Context, r db.Reader, sessionId string, opt ...db.Option) ([]*Connection, error) {
+	var connections []*Connection
+	if err := r.SearchWhere(ctx, &connections, "session_id = ?", []interface{}{sessionId}, opt...); err != nil {
+		return nil, fmt.Errorf("fetch session connections: %w", err)
+	}
+	if len(connections) == 0 {
+		return nil, nil
+	}
+	return connections, nil
+}
@@ -2,6 +2,7 @@ package session
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
 	"testing"
 	"time"
@@ -314,6 +315,64 @@ func TestRepository_CreateSession(t *testing.T) {
 	}
 }
 
+func TestRepository_LookupSession_KeyRotation(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+
+	composedOf := TestSessionParams(t, conn, wrapper, iamRepo)
+	s := &Session{
+		UserId:          composedOf.UserId,
+		HostId:          composedOf.HostId,
+		TargetId:        composedOf.TargetId,
+		HostSetId:       composedOf.HostSetId,
+		AuthTokenId:     composedOf.AuthTokenId,
+		ScopeId:         composedOf.ScopeId,
+		Endpoint:        "tcp://127.0.0.1:22",
+		ExpirationTime:  composedOf.ExpirationTime,
+		ConnectionLimit: composedOf.ConnectionLimit,
+	}
+	sessionWrapper, err := kmsCache.GetWrapper(ctx, composedOf.ScopeId, kms.KeyPurposeSessions)
+	require.NoError(err)
+	ses, privKey, err := repo.CreateSession(ctx, sessionWrapper, s)
+	require.NoError(err)
+	assert.Equal(sessionWrapper.KeyID(), ses.SessionKeyVersionId)
+
+	_, err = kmsCache.RotateKeys(ctx, composedOf.ScopeId)
+	require.NoError(err)
+
+	// The key of a session authorized before the rotation is derived from
+	// the sessions key version it was created with, so that it still matches
+	// the session's certificate.
+	found, _, err := repo.LookupSession(ctx, ses.PublicId)
+	require.NoError(err)
+	assert.Equal(ses.SessionKeyVersionId, found.SessionKeyVersionId)
+	sessionWrapper, err = kmsCache.GetWrapper(ctx, found.ScopeId, kms.KeyPurposeSessions, kms.WithKeyId(found.SessionKeyVersionId))
+	require.NoError(err)
+	assert.NotEqual(found.SessionKeyVersionId, sessionWrapper.KeyID())
+	_, derived, err := DeriveED25519Key(sessionWrapper, found.SessionKeyVersionId, found.UserId, found.PublicId)
+	require.NoError(err)
+	assert.Equal(privKey, derived)
+	cert, err := x509.ParseCertificate(found.Certificate)
+	require.NoError(err)
+	assert.Equal(derived.Public(), cert.PublicKey)
+
+	// Sessions created after the rotation use the new version.
+	s.PublicId = ""
+	s.Certificate = nil
+	newSes, _, err := repo.CreateSession(ctx, sessionWrapper, s)
+	require.NoError(err)
+	assert.Equal(sessionWrapper.KeyID(), newSes.SessionKeyVersionId)
+	assert.NotEqual(ses.SessionKeyVersionId, newSes.SessionKeyVersionId)
+}
+
 func TestRepository_updateState(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -663,6 +722,11 @@ func TestRepository_TerminateSession(t *testing.T) {
 			session: TestDefaultSession(t, conn, wrapper, iamRepo),
 			reason:  ClosedByUser,
 		},
+		{
+			name:    "valid-idle-timeout",
+			session: setupFn(),
+			reason:  IdleTimeout,
+		},
 		{
 			name: "empty-session-id",
 			session: func() *Session {
@@ -953,6 +1017,22 @@ func TestRepository_TerminateCompletedSessions(t *testing.T) {
 	}
 }
 
+func testRecording(t *testing.T) *ConnectionRecording {
+	t.Helper()
+	start, err := ptypes.TimestampProto(time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	end, err := ptypes.TimestampProto(time.Now())
+	require.NoError(t, err)
+	return &ConnectionRecording{
+		WorkerId:    "test-worker",
+		StoragePath: "/tmp/recordings/test.rec",
+		BytesUp:     1,
+		BytesDown:   2,
+		StartTime:   &timestamp.Timestamp{Timestamp: start},
+		EndTime:     &timestamp.Timestamp{Timestamp: end},
+	}
+}
+
 func TestRepository_CloseConnections(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -1013,6 +1093,29 @@ func TestRepository_CloseConnections(t *testing.T) {
 			wantErr:     true,
 			wantIsError: db.ErrInvalidParameter,
 		},
+		{
+			name: "with-recording",
+			closeWith: func() []CloseWith {
+				cw := setupFn(2)
+				for i := range cw {
+					cw[i].Recording = testRecording(t)
+				}
+				return cw
+			}(),
+			reason: ClosedByUser,
+		},
+		{
+			name: "recording-missing-StoragePath",
+			closeWith: func() []CloseWith {
+				cw := setupFn(1)
+				cw[0].Recording = testRecording(t)
+				cw[0].Recording.StoragePath = ""
+				return cw
+			}(),
+			reason:      ClosedByUser,
+			wantErr:     true,
+			wantIsError: db.ErrInvalidParameter,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1031,6 +1134,25 @@ func TestRepository_CloseConnections(t *testing.T) {
 				require.NotNil(r.Connection)
 				require.NotNil(r.ConnectionStates)
 				assert.Equal(StatusClosed, r.ConnectionStates[0].Status)
+				assert.Equal(uint64(1), r.Connection.BytesUp)
+				assert.Equal(uint64(2), r.Connection.BytesDown)
+			}
+			c, _, err := repo.LookupConnection(context.Background(), tt.closeWith[0].ConnectionId)
+			require.NoError(err)
+			found, _, err := repo.LookupSession(context.Background(), c.SessionId)
+			require.NoError(err)
+			require.NotNil(found)
+			assert.Len(found.Connections, len(tt.closeWith))
+			for _, fc := range found.Connections {
+				assert.Equal(uint64(1), fc.BytesUp)
+				assert.Equal(uint64(2), fc.BytesDown)
+			}
+			if tt.closeWith[0].Recording != nil {
+				assert.Len(found.Recordings, len(tt.closeWith))
+				for _, rec := range found.Recordings {
+					assert.Equal(found.PublicId, rec.SessionId)
+					assert.Equal(tt.closeWith[0].Recording.StoragePath, rec.StoragePath)
+				}
 			}
 		})
 	}
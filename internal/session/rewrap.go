@@ -0,0 +1,39 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+func init() {
+	kms.RegisterTableRewrapFn(defaultSessionTableName, "key_id", kms.KeyPurposeDatabase, rewrapSessions)
+}
+
+// rewrapSessions re-encrypts the tofu tokens of sessions encrypted with
+// previous versions of the database key.
+func rewrapSessions(ctx context.Context, r db.Reader, w db.Writer, wrapper wrapping.Wrapper, keyVersionIds []string, limit int) (int, error) {
+	var sessions []*Session
+	if err := r.SearchWhere(ctx, &sessions, "key_id in (?)", []interface{}{keyVersionIds}, db.WithLimit(limit)); err != nil {
+		return 0, fmt.Errorf("rewrap sessions: %w", err)
+	}
+	var rewrapped int
+	for _, s := range sessions {
+		oldKeyId, oldTofuToken := s.KeyId, s.CtTofuToken
+		if err := s.decrypt(ctx, wrapper); err != nil {
+			return rewrapped, fmt.Errorf("rewrap sessions: %w for %s", err, s.PublicId)
+		}
+		if err := s.encrypt(ctx, wrapper); err != nil {
+			return rewrapped, fmt.Errorf("rewrap sessions: %w for %s", err, s.PublicId)
+		}
+		n, err := w.Exec(ctx, rewrapSessionQuery, []interface{}{s.CtTofuToken, s.KeyId, s.PublicId, oldKeyId, oldTofuToken})
+		if err != nil {
+			return rewrapped, fmt.Errorf("rewrap sessions: %w for %s", err, s.PublicId)
+		}
+		rewrapped += n
+	}
+	return rewrapped, nil
+}
@@ -44,6 +44,18 @@ type ComposedOf struct {
 	ExpirationTime *timestamp.Timestamp
 	// Max connections for the session
 	ConnectionLimit int32
+	// RecordingEnabled determines if the worker records the session's
+	// connections
+	RecordingEnabled bool
+	// Max throughput of each connection in bytes per second
+	ConnectionMaxBytesPerSecond uint32
+	// Max combined throughput of the session's connections in bytes per second
+	SessionMaxBytesPerSecond uint32
+	// Seconds without any data proxied before the session is terminated
+	IdleTimeoutSeconds uint32
+	// WorkerFilter is the filter which the worker dialing the endpoint must
+	// match
+	WorkerFilter string
 }
 
 // Session contains information about a user's session with a target
@@ -88,6 +100,25 @@ type Session struct {
 	Endpoint string `json:"-" gorm:"default:null"`
 	// Maximum number of connections in a session
 	ConnectionLimit int32 `json:"connection_limit,omitempty" gorm:"default:null"`
+	// RecordingEnabled determines if the worker records the session's
+	// connections
+	RecordingEnabled bool `json:"recording_enabled,omitempty" gorm:"default:null"`
+	// Maximum throughput of each connection in bytes per second. 0 equals no
+	// limit.
+	ConnectionMaxBytesPerSecond uint32 `json:"connection_max_bytes_per_second,omitempty" gorm:"default:null"`
+	// Maximum combined throughput of the session's connections in bytes per
+	// second. 0 equals no limit.
+	SessionMaxBytesPerSecond uint32 `json:"session_max_bytes_per_second,omitempty" gorm:"default:null"`
+	// Number of seconds the session may go without any data being proxied for
+	// any of its connections before it is terminated. 0 equals no idle timeout.
+	IdleTimeoutSeconds uint32 `json:"idle_timeout_seconds,omitempty" gorm:"default:null"`
+	// WorkerFilter is the filter which the worker dialing the endpoint must
+	// match. Workers which do not match it forward the session's connections
+	// to a matching downstream worker.
+	WorkerFilter string `json:"worker_filter,omitempty" gorm:"default:null"`
+	// SessionKeyVersionId is the version of the scope's session DEK from
+	// which the key of the session is derived.
+	SessionKeyVersionId string `json:"session_key_version_id,omitempty" gorm:"default:null"`
 
 	// key_id is the key ID that was used for the encryption operation. It can be
 	// used to identify a specific version of the key needed to decrypt the value,
@@ -97,8 +128,16 @@ type Session struct {
 
 	// States for the session which are for read only and are ignored during
 	// write operations
-	States    []*State `gorm:"-"`
-	tableName string   `gorm:"-"`
+	States []*State `gorm:"-"`
+
+	// Recordings for the session's connections which are for read only and
+	// are ignored during write operations
+	Recordings []*ConnectionRecording `gorm:"-"`
+
+	// Connections for the session which are for read only and are ignored
+	// during write operations
+	Connections []*Connection `gorm:"-"`
+	tableName   string        `gorm:"-"`
 }
 
 func (s *Session) GetPublicId() string {
@@ -111,15 +150,20 @@ var _ db.VetForWriter = (*Session)(nil)
 // New creates a new in memory session.
 func New(c ComposedOf, opt ...Option) (*Session, error) {
 	s := Session{
-		UserId:          c.UserId,
-		HostId:          c.HostId,
-		TargetId:        c.TargetId,
-		HostSetId:       c.HostSetId,
-		AuthTokenId:     c.AuthTokenId,
-		ScopeId:         c.ScopeId,
-		Endpoint:        c.Endpoint,
-		ExpirationTime:  c.ExpirationTime,
-		ConnectionLimit: c.ConnectionLimit,
+		UserId:                      c.UserId,
+		HostId:                      c.HostId,
+		TargetId:                    c.TargetId,
+		HostSetId:                   c.HostSetId,
+		AuthTokenId:                 c.AuthTokenId,
+		ScopeId:                     c.ScopeId,
+		Endpoint:                    c.Endpoint,
+		ExpirationTime:              c.ExpirationTime,
+		ConnectionLimit:             c.ConnectionLimit,
+		RecordingEnabled:            c.RecordingEnabled,
+		ConnectionMaxBytesPerSecond: c.ConnectionMaxBytesPerSecond,
+		SessionMaxBytesPerSecond:    c.SessionMaxBytesPerSecond,
+		IdleTimeoutSeconds:          c.IdleTimeoutSeconds,
+		WorkerFilter:                c.WorkerFilter,
 	}
 	if err := s.validateNewSession("new session:"); err != nil {
 		return nil, err
@@ -135,19 +179,25 @@ func AllocSession() Session {
 // Clone creates a clone of the Session
 func (s *Session) Clone() interface{} {
 	clone := &Session{
-		PublicId:          s.PublicId,
-		UserId:            s.UserId,
-		HostId:            s.HostId,
-		ServerId:          s.ServerId,
-		ServerType:        s.ServerType,
-		TargetId:          s.TargetId,
-		HostSetId:         s.HostSetId,
-		AuthTokenId:       s.AuthTokenId,
-		ScopeId:           s.ScopeId,
-		TerminationReason: s.TerminationReason,
-		Version:           s.Version,
-		Endpoint:          s.Endpoint,
-		ConnectionLimit:   s.ConnectionLimit,
+		PublicId:                    s.PublicId,
+		UserId:                      s.UserId,
+		HostId:                      s.HostId,
+		ServerId:                    s.ServerId,
+		ServerType:                  s.ServerType,
+		TargetId:                    s.TargetId,
+		HostSetId:                   s.HostSetId,
+		AuthTokenId:                 s.AuthTokenId,
+		ScopeId:                     s.ScopeId,
+		TerminationReason:           s.TerminationReason,
+		Version:                     s.Version,
+		Endpoint:                    s.Endpoint,
+		ConnectionLimit:             s.ConnectionLimit,
+		RecordingEnabled:            s.RecordingEnabled,
+		ConnectionMaxBytesPerSecond: s.ConnectionMaxBytesPerSecond,
+		SessionMaxBytesPerSecond:    s.SessionMaxBytesPerSecond,
+		IdleTimeoutSeconds:          s.IdleTimeoutSeconds,
+		WorkerFilter:                s.WorkerFilter,
+		SessionKeyVersionId:         s.SessionKeyVersionId,
 	}
 	if len(s.States) > 0 {
 		clone.States = make([]*State, 0, len(s.States))
@@ -156,6 +206,20 @@ func (s *Session) Clone() interface{} {
 			clone.States = append(clone.States, cp)
 		}
 	}
+	if len(s.Recordings) > 0 {
+		clone.Recordings = make([]*ConnectionRecording, 0, len(s.Recordings))
+		for _, r := range s.Recordings {
+			cp := r.Clone().(*ConnectionRecording)
+			clone.Recordings = append(clone.Recordings, cp)
+		}
+	}
+	if len(s.Connections) > 0 {
+		clone.Connections = make([]*Connection, 0, len(s.Connections))
+		for _, c := range s.Connections {
+			cp := c.Clone().(*Connection)
+			clone.Connections = append(clone.Connections, cp)
+		}
+	}
 	if s.TofuToken != nil {
 		clone.TofuToken = make([]byte, len(s.TofuToken))
 		copy(clone.TofuToken, s.TofuToken)
@@ -236,6 +300,18 @@ func (s *Session) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType
 			return fmt.Errorf("session vet for write: expiration time is immutable: %w", db.ErrInvalidParameter)
 		case contains(opts.WithFieldMaskPaths, "ConnectionLimit"):
 			return fmt.Errorf("session vet for write: connection limit is immutable: %w", db.ErrInvalidParameter)
+		case contains(opts.WithFieldMaskPaths, "RecordingEnabled"):
+			return fmt.Errorf("session vet for write: recording enabled is immutable: %w", db.ErrInvalidParameter)
+		case contains(opts.WithFieldMaskPaths, "ConnectionMaxBytesPerSecond"):
+			return fmt.Errorf("session vet for write: connection max bytes per second is immutable: %w", db.ErrInvalidParameter)
+		case contains(opts.WithFieldMaskPaths, "SessionMaxBytesPerSecond"):
+			return fmt.Errorf("session vet for write: session max bytes per second is immutable: %w", db.ErrInvalidParameter)
+		case contains(opts.WithFieldMaskPaths, "IdleTimeoutSeconds"):
+			return fmt.Errorf("session vet for write: idle timeout seconds is immutable: %w", db.ErrInvalidParameter)
+		case contains(opts.WithFieldMaskPaths, "WorkerFilter"):
+			return fmt.Errorf("session vet for write: worker filter is immutable: %w", db.ErrInvalidParameter)
+		case contains(opts.WithFieldMaskPaths, "SessionKeyVersionId"):
+			return fmt.Errorf("session vet for write: session key version id is immutable: %w", db.ErrInvalidParameter)
 		case contains(opts.WithFieldMaskPaths, "TerminationReason"):
 			if _, err := convertToReason(s.TerminationReason); err != nil {
 				return fmt.Errorf("session vet for write: termination reason '%s' is invalid: %w", s.TerminationReason, db.ErrInvalidParameter)
@@ -323,7 +399,7 @@ func newCert(wrapper wrapping.Wrapper, userId, jobId string, exp time.Time) (ed2
 	if jobId == "" {
 		return nil, nil, fmt.Errorf("new session cert: missing job id: %w", db.ErrInvalidParameter)
 	}
-	pubKey, privKey, err := DeriveED25519Key(wrapper, userId, jobId)
+	pubKey, privKey, err := DeriveED25519Key(wrapper, "", userId, jobId)
 	if err != nil {
 		return nil, nil, fmt.Errorf("new session cert: ")
 	}
@@ -365,26 +441,32 @@ func (s *Session) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
 
 type sessionView struct {
 	// Session fields
-	PublicId          string               `json:"public_id,omitempty" gorm:"primary_key"`
-	UserId            string               `json:"user_id,omitempty" gorm:"default:null"`
-	HostId            string               `json:"host_id,omitempty" gorm:"default:null"`
-	ServerId          string               `json:"server_id,omitempty" gorm:"default:null"`
-	ServerType        string               `json:"server_type,omitempty" gorm:"default:null"`
-	TargetId          string               `json:"target_id,omitempty" gorm:"default:null"`
-	HostSetId         string               `json:"host_set_id,omitempty" gorm:"default:null"`
-	AuthTokenId       string               `json:"auth_token_id,omitempty" gorm:"default:null"`
-	ScopeId           string               `json:"scope_id,omitempty" gorm:"default:null"`
-	Certificate       []byte               `json:"certificate,omitempty" gorm:"default:null"`
-	ExpirationTime    *timestamp.Timestamp `json:"expiration_time,omitempty" gorm:"default:null"`
-	CtTofuToken       []byte               `json:"ct_tofu_token,omitempty" gorm:"column:tofu_token;default:null" wrapping:"ct,tofu_token"`
-	TofuToken         []byte               `json:"tofu_token,omitempty" gorm:"-" wrapping:"pt,tofu_token"`
-	TerminationReason string               `json:"termination_reason,omitempty" gorm:"default:null"`
-	CreateTime        *timestamp.Timestamp `json:"create_time,omitempty" gorm:"default:current_timestamp"`
-	UpdateTime        *timestamp.Timestamp `json:"update_time,omitempty" gorm:"default:current_timestamp"`
-	Version           uint32               `json:"version,omitempty" gorm:"default:null"`
-	Endpoint          string               `json:"-" gorm:"default:null"`
-	ConnectionLimit   int32                `json:"connection_limit,omitempty" gorm:"default:null"`
-	KeyId             string               `json:"key_id,omitempty" gorm:"not_null"`
+	PublicId                    string               `json:"public_id,omitempty" gorm:"primary_key"`
+	UserId                      string               `json:"user_id,omitempty" gorm:"default:null"`
+	HostId                      string               `json:"host_id,omitempty" gorm:"default:null"`
+	ServerId                    string               `json:"server_id,omitempty" gorm:"default:null"`
+	ServerType                  string               `json:"server_type,omitempty" gorm:"default:null"`
+	TargetId                    string               `json:"target_id,omitempty" gorm:"default:null"`
+	HostSetId                   string               `json:"host_set_id,omitempty" gorm:"default:null"`
+	AuthTokenId                 string               `json:"auth_token_id,omitempty" gorm:"default:null"`
+	ScopeId                     string               `json:"scope_id,omitempty" gorm:"default:null"`
+	Certificate                 []byte               `json:"certificate,omitempty" gorm:"default:null"`
+	ExpirationTime              *timestamp.Timestamp `json:"expiration_time,omitempty" gorm:"default:null"`
+	CtTofuToken                 []byte               `json:"ct_tofu_token,omitempty" gorm:"column:tofu_token;default:null" wrapping:"ct,tofu_token"`
+	TofuToken                   []byte               `json:"tofu_token,omitempty" gorm:"-" wrapping:"pt,tofu_token"`
+	TerminationReason           string               `json:"termination_reason,omitempty" gorm:"default:null"`
+	CreateTime                  *timestamp.Timestamp `json:"create_time,omitempty" gorm:"default:current_timestamp"`
+	UpdateTime                  *timestamp.Timestamp `json:"update_time,omitempty" gorm:"default:current_timestamp"`
+	Version                     uint32               `json:"version,omitempty" gorm:"default:null"`
+	Endpoint                    string               `json:"-" gorm:"default:null"`
+	ConnectionLimit             int32                `json:"connection_limit,omitempty" gorm:"default:null"`
+	RecordingEnabled            bool                 `json:"recording_enabled,omitempty" gorm:"default:null"`
+	ConnectionMaxBytesPerSecond uint32               `json:"connection_max_bytes_per_second,omitempty" gorm:"default:null"`
+	SessionMaxBytesPerSecond    uint32               `json:"session_max_bytes_per_second,omitempty" gorm:"default:null"`
+	IdleTimeoutSeconds          uint32               `json:"idle_timeout_seconds,omitempty" gorm:"default:null"`
+	WorkerFilter                string               `json:"worker_filter,omitempty" gorm:"default:null"`
+	SessionKeyVersionId         string               `json:"session_key_version_id,omitempty" gorm:"default:null"`
+	KeyId                       string               `json:"key_id,omitempty" gorm:"not_null"`
 
 	// State fields
 	Status          string               `json:"state,omitempty" gorm:"column:state"`
@@ -0,0 +1,69 @@
+package session
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// sshCertificateClockSkew is how far in the past an ssh certificate becomes
+// valid to allow for clock skew between the controller and the hosts.
+const sshCertificateClockSkew = 30 * time.Second
+
+// NewSshCertificate generates a key pair for the session and returns an ssh
+// user certificate for it, signed by the certificate authority ca, along with
+// its PEM encoded private key. The certificate is valid for the principals
+// until validBefore, and its key id is the session id so the host's auth log
+// can be correlated with the session.
+func NewSshCertificate(ca ssh.Signer, sessionId string, principals []string, validBefore time.Time) (certificate string, privateKey string, retErr error) {
+	if ca == nil {
+		return "", "", errors.New("missing certificate authority")
+	}
+	if sessionId == "" {
+		return "", "", errors.New("missing session id")
+	}
+	if len(principals) == 0 {
+		return "", "", errors.New("missing principals")
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("error generating ssh key: %w", err)
+	}
+	pub, err := ssh.NewPublicKey(&key.PublicKey)
+	if err != nil {
+		return "", "", fmt.Errorf("error creating ssh public key: %w", err)
+	}
+	cert := &ssh.Certificate{
+		Key:             pub,
+		CertType:        ssh.UserCert,
+		KeyId:           sessionId,
+		ValidPrincipals: principals,
+		ValidAfter:      uint64(time.Now().Add(-sshCertificateClockSkew).Unix()),
+		ValidBefore:     uint64(validBefore.Unix()),
+		Permissions: ssh.Permissions{
+			Extensions: map[string]string{
+				"permit-X11-forwarding":   "",
+				"permit-agent-forwarding": "",
+				"permit-port-forwarding":  "",
+				"permit-pty":              "",
+				"permit-user-rc":          "",
+			},
+		},
+	}
+	if err := cert.SignCert(rand.Reader, ca); err != nil {
+		return "", "", fmt.Errorf("error signing ssh certificate: %w", err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", "", fmt.Errorf("error marshaling ssh private key: %w", err)
+	}
+	privPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	return string(ssh.MarshalAuthorizedKey(cert)), string(privPem), nil
+}
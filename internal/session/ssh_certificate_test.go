@@ -0,0 +1,91 @@
+package session
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestNewSshCertificate(t *testing.T) {
+	t.Parallel()
+	_, caKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ca, err := ssh.NewSignerFromKey(caKey)
+	require.NoError(t, err)
+	validBefore := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		ca         ssh.Signer
+		sessionId  string
+		principals []string
+		wantErr    bool
+	}{
+		{
+			name:    "missing-ca",
+			wantErr: true,
+		},
+		{
+			name:       "missing-session-id",
+			ca:         ca,
+			principals: []string{"admin"},
+			wantErr:    true,
+		},
+		{
+			name:      "missing-principals",
+			ca:        ca,
+			sessionId: "s_1234567890",
+			wantErr:   true,
+		},
+		{
+			name:       "valid",
+			ca:         ca,
+			sessionId:  "s_1234567890",
+			principals: []string{"admin", "deploy"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			certificate, privateKey, err := NewSshCertificate(tt.ca, tt.sessionId, tt.principals, validBefore)
+			if tt.wantErr {
+				require.Error(err)
+				assert.Empty(certificate)
+				assert.Empty(privateKey)
+				return
+			}
+			require.NoError(err)
+
+			pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(certificate))
+			require.NoError(err)
+			cert, ok := pub.(*ssh.Certificate)
+			require.True(ok)
+			assert.Equal(uint32(ssh.UserCert), cert.CertType)
+			assert.Equal(tt.sessionId, cert.KeyId)
+			assert.Equal(tt.principals, cert.ValidPrincipals)
+			assert.Equal(uint64(validBefore.Unix()), cert.ValidBefore)
+
+			checker := &ssh.CertChecker{
+				IsUserAuthority: func(auth ssh.PublicKey) bool {
+					return string(auth.Marshal()) == string(ca.PublicKey().Marshal())
+				},
+			}
+			assert.NoError(checker.CheckCert(tt.principals[0], cert))
+
+			block, _ := pem.Decode([]byte(privateKey))
+			require.NotNil(block)
+			key, err := x509.ParseECPrivateKey(block.Bytes)
+			require.NoError(err)
+			keyPub, err := ssh.NewPublicKey(&key.PublicKey)
+			require.NoError(err)
+			assert.Equal(keyPub.Marshal(), cert.Key.Marshal())
+		})
+	}
+}
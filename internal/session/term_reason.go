@@ -18,6 +18,7 @@ const (
 	SystemError        TerminationReason = "system error"
 	ConnectionLimit    TerminationReason = "connection limit"
 	SessionCanceled    TerminationReason = "canceled"
+	IdleTimeout        TerminationReason = "idle timeout"
 )
 
 // String representation of the termination reason
@@ -40,6 +41,8 @@ func convertToReason(s string) (TerminationReason, error) {
 		return SystemError, nil
 	case ConnectionLimit.String():
 		return ConnectionLimit, nil
+	case IdleTimeout.String():
+		return IdleTimeout, nil
 	default:
 		return "", fmt.Errorf("termination reason: %s is not a valid reason: %w", s, db.ErrInvalidParameter)
 	}
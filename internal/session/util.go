@@ -4,6 +4,7 @@ import (
 	"crypto/ed25519"
 	"crypto/sha256"
 	"errors"
+	"fmt"
 	"io"
 
 	wrapping "github.com/hashicorp/go-kms-wrapping"
@@ -12,18 +13,28 @@ import (
 	"golang.org/x/crypto/hkdf"
 )
 
-// DeriveED25519Key generates a key based on the scope's session DEK, the
-// requesting user, and the generated job ID.
-func DeriveED25519Key(wrapper wrapping.Wrapper, userId, jobId string) (ed25519.PublicKey, ed25519.PrivateKey, error) {
+// DeriveED25519Key generates a key based on the version of the scope's
+// session DEK with the id keyVersionId, the requesting user, and the generated
+// job ID. An empty keyVersionId uses the current version of the DEK.
+func DeriveED25519Key(wrapper wrapping.Wrapper, keyVersionId, userId, jobId string) (ed25519.PublicKey, ed25519.PrivateKey, error) {
 	var aeadWrapper *aead.Wrapper
 	switch w := wrapper.(type) {
 	case *multiwrapper.MultiWrapper:
-		raw := w.WrapperForKeyID("__base__")
+		if keyVersionId == "" {
+			keyVersionId = "__base__"
+		}
+		raw := w.WrapperForKeyID(keyVersionId)
+		if raw == nil {
+			return nil, nil, fmt.Errorf("session key version %q not found", keyVersionId)
+		}
 		var ok bool
 		if aeadWrapper, ok = raw.(*aead.Wrapper); !ok {
-			return nil, nil, errors.New("unexpected wrapper type from multiwrapper base")
+			return nil, nil, errors.New("unexpected wrapper type from multiwrapper")
 		}
 	case *aead.Wrapper:
+		if keyVersionId != "" && keyVersionId != w.KeyID() {
+			return nil, nil, fmt.Errorf("session key version %q not found", keyVersionId)
+		}
 		aeadWrapper = w
 	default:
 		return nil, nil, errors.New("unknown wrapper type")
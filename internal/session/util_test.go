@@ -0,0 +1,62 @@
+package session
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/hashicorp/go-kms-wrapping/wrappers/aead"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/multiwrapper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAeadWrapper(t *testing.T, keyId string) *aead.Wrapper {
+	t.Helper()
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	w := aead.NewWrapper(nil)
+	_, err = w.SetConfig(map[string]string{"key_id": keyId})
+	require.NoError(t, err)
+	require.NoError(t, w.SetAESGCMKeyBytes(key))
+	return w
+}
+
+func TestDeriveED25519Key(t *testing.T) {
+	t.Parallel()
+	previous := testAeadWrapper(t, "skv_previous")
+	current := testAeadWrapper(t, "skv_current")
+	multi := multiwrapper.NewMultiWrapper(current)
+	multi.AddWrapper(previous)
+
+	t.Run("current version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, want, err := DeriveED25519Key(current, "", "u_1234567890", "s_1234567890")
+		require.NoError(err)
+		_, got, err := DeriveED25519Key(multi, "", "u_1234567890", "s_1234567890")
+		require.NoError(err)
+		assert.Equal(want, got)
+		_, got, err = DeriveED25519Key(multi, current.KeyID(), "u_1234567890", "s_1234567890")
+		require.NoError(err)
+		assert.Equal(want, got)
+	})
+	t.Run("previous version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, want, err := DeriveED25519Key(previous, previous.KeyID(), "u_1234567890", "s_1234567890")
+		require.NoError(err)
+		_, got, err := DeriveED25519Key(multi, previous.KeyID(), "u_1234567890", "s_1234567890")
+		require.NoError(err)
+		assert.Equal(want, got)
+
+		_, other, err := DeriveED25519Key(multi, "", "u_1234567890", "s_1234567890")
+		require.NoError(err)
+		assert.NotEqual(want, other)
+	})
+	t.Run("unknown version", func(t *testing.T) {
+		assert := assert.New(t)
+		_, _, err := DeriveED25519Key(multi, "skv_unknown", "u_1234567890", "s_1234567890")
+		assert.Error(err)
+		_, _, err = DeriveED25519Key(current, previous.KeyID(), "u_1234567890", "s_1234567890")
+		assert.Error(err)
+	})
+}
@@ -0,0 +1,48 @@
+package target
+
+import "strings"
+
+// HostSelectionStrategy defines how the host of a session is chosen from the
+// hosts of a target's host sets when the user does not request one.
+type HostSelectionStrategy string
+
+const (
+	UnknownHostSelection HostSelectionStrategy = "unknown"
+
+	// RandomHostSelection chooses a host at random.
+	RandomHostSelection HostSelectionStrategy = "random"
+
+	// RoundRobinHostSelection chooses the host which follows, in host id
+	// order, the host of the target's most recently created session.
+	RoundRobinHostSelection HostSelectionStrategy = "round-robin"
+
+	// LeastActiveSessionsHostSelection chooses the host with the fewest
+	// pending and active sessions, across all targets.
+	LeastActiveSessionsHostSelection HostSelectionStrategy = "least-active-sessions"
+
+	// StickyPerUserHostSelection chooses the host of the user's most recently
+	// created session for the target, if it is still available.
+	StickyPerUserHostSelection HostSelectionStrategy = "sticky-per-user"
+)
+
+// String returns a string representation of the host selection strategy.
+func (s HostSelectionStrategy) String() string {
+	return string(s)
+}
+
+// HostSelectionStrategyFromString converts a string to a
+// HostSelectionStrategy. Returns UnknownHostSelection if no strategy with that
+// name is found.
+func HostSelectionStrategyFromString(s string) HostSelectionStrategy {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case RandomHostSelection.String():
+		return RandomHostSelection
+	case RoundRobinHostSelection.String():
+		return RoundRobinHostSelection
+	case LeastActiveSessionsHostSelection.String():
+		return LeastActiveSessionsHostSelection
+	case StickyPerUserHostSelection.String():
+		return StickyPerUserHostSelection
+	}
+	return UnknownHostSelection
+}
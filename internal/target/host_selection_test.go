@@ -0,0 +1,26 @@
+package target
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostSelectionStrategyFromString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want HostSelectionStrategy
+	}{
+		{in: "random", want: RandomHostSelection},
+		{in: "round-robin", want: RoundRobinHostSelection},
+		{in: " Least-Active-Sessions ", want: LeastActiveSessionsHostSelection},
+		{in: "sticky-per-user", want: StickyPerUserHostSelection},
+		{in: "", want: UnknownHostSelection},
+		{in: "fastest", want: UnknownHostSelection},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.want, HostSelectionStrategyFromString(tt.in))
+		})
+	}
+}
@@ -8,6 +8,8 @@ import (
 
 const (
 	TcpTargetPrefix = "ttcp"
+	UdpTargetPrefix = "tudp"
+	SshTargetPrefix = "tssh"
 )
 
 func newTcpTargetId() (string, error) {
@@ -17,3 +19,19 @@ func newTcpTargetId() (string, error) {
 	}
 	return id, nil
 }
+
+func newUdpTargetId() (string, error) {
+	id, err := db.NewPublicId(UdpTargetPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new udp target id: %w", err)
+	}
+	return id, nil
+}
+
+func newSshTargetId() (string, error) {
+	id, err := db.NewPublicId(SshTargetPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new ssh target id: %w", err)
+	}
+	return id, nil
+}
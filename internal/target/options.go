@@ -16,32 +16,49 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withName                   string
-	withDescription            string
-	withDefaultPort            uint32
-	withLimit                  int
-	withScopeId                string
-	withUserId                 string
-	withTargetType             *TargetType
-	withHostSets               []string
-	withSessionMaxSeconds      uint32
-	withSessionConnectionLimit int32
-	withPublicId               string
+	withName                        string
+	withDescription                 string
+	withDefaultPort                 uint32
+	withLimit                       int
+	withStartPageAfterId            string
+	withScopeId                     string
+	withUserId                      string
+	withTargetType                  *TargetType
+	withHostSets                    []string
+	withSessionMaxSeconds           uint32
+	withSessionConnectionLimit      int32
+	withPublicId                    string
+	withEnableSessionRecording      bool
+	withConnectionMaxBytesPerSecond uint32
+	withSessionMaxBytesPerSecond    uint32
+	withSessionIdleTimeoutSeconds   uint32
+	withPrincipals                  []string
+	withHostSelectionStrategy       HostSelectionStrategy
+	withRestrictHostId              bool
+	withWorkerFilter                string
 }
 
 func getDefaultOptions() options {
 	return options{
-		withName:                   "",
-		withDescription:            "",
-		withLimit:                  0,
-		withDefaultPort:            0,
-		withScopeId:                "",
-		withUserId:                 "",
-		withTargetType:             nil,
-		withHostSets:               nil,
-		withSessionMaxSeconds:      uint32((8 * time.Hour).Seconds()),
-		withSessionConnectionLimit: 1,
-		withPublicId:               "",
+		withName:                        "",
+		withDescription:                 "",
+		withLimit:                       0,
+		withDefaultPort:                 0,
+		withScopeId:                     "",
+		withUserId:                      "",
+		withTargetType:                  nil,
+		withHostSets:                    nil,
+		withSessionMaxSeconds:           uint32((8 * time.Hour).Seconds()),
+		withSessionConnectionLimit:      1,
+		withPublicId:                    "",
+		withEnableSessionRecording:      false,
+		withConnectionMaxBytesPerSecond: 0,
+		withSessionMaxBytesPerSecond:    0,
+		withSessionIdleTimeoutSeconds:   0,
+		withPrincipals:                  nil,
+		withHostSelectionStrategy:       RandomHostSelection,
+		withRestrictHostId:              false,
+		withWorkerFilter:                "",
 	}
 }
 
@@ -115,9 +132,84 @@ func WithSessionConnectionLimit(limit int32) Option {
 	}
 }
 
+// WithEnableSessionRecording provides an option to record the data proxied for
+// each connection of sessions created for the target
+func WithEnableSessionRecording(enable bool) Option {
+	return func(o *options) {
+		o.withEnableSessionRecording = enable
+	}
+}
+
+// WithConnectionMaxBytesPerSecond provides an option to limit the throughput
+// of each connection of sessions created for the target. 0 equals no limit.
+func WithConnectionMaxBytesPerSecond(limit uint32) Option {
+	return func(o *options) {
+		o.withConnectionMaxBytesPerSecond = limit
+	}
+}
+
+// WithSessionMaxBytesPerSecond provides an option to limit the combined
+// throughput of all the connections of sessions created for the target. 0
+// equals no limit.
+func WithSessionMaxBytesPerSecond(limit uint32) Option {
+	return func(o *options) {
+		o.withSessionMaxBytesPerSecond = limit
+	}
+}
+
+// WithSessionIdleTimeoutSeconds provides an option to terminate sessions
+// created for the target once no data has been proxied for any of their
+// connections for the number of seconds. 0 equals no idle timeout.
+func WithSessionIdleTimeoutSeconds(timeout uint32) Option {
+	return func(o *options) {
+		o.withSessionIdleTimeoutSeconds = timeout
+	}
+}
+
+// WithPrincipals provides an optional list of principals to include in the
+// ssh certificates issued for sessions to an ssh target
+func WithPrincipals(p []string) Option {
+	return func(o *options) {
+		o.withPrincipals = p
+	}
+}
+
+// WithHostSelectionStrategy provides an option to set how the host of a
+// session is chosen when the user does not request one. Defaults to
+// RandomHostSelection.
+func WithHostSelectionStrategy(strategy HostSelectionStrategy) Option {
+	return func(o *options) {
+		o.withHostSelectionStrategy = strategy
+	}
+}
+
+// WithRestrictHostId provides an option to prevent users from requesting the
+// host of a session, so it is always chosen by the host selection strategy.
+func WithRestrictHostId(restrict bool) Option {
+	return func(o *options) {
+		o.withRestrictHostId = restrict
+	}
+}
+
+// WithWorkerFilter provides an optional filter expression which workers must
+// match to proxy sessions created for the target.
+func WithWorkerFilter(filter string) Option {
+	return func(o *options) {
+		o.withWorkerFilter = filter
+	}
+}
+
 // WithPublicId provides an optional public id
 func WithPublicId(id string) Option {
 	return func(o *options) {
 		o.withPublicId = id
 	}
 }
+
+// WithStartPageAfterId provides an option to only list resources whose public
+// ids sort after the given id. Used to retrieve the next page of results.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
@@ -90,4 +90,67 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withHostSets = []string{"alice", "bob"}
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithEnableSessionRecording", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithEnableSessionRecording(true))
+		testOpts := getDefaultOptions()
+		testOpts.withEnableSessionRecording = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithConnectionMaxBytesPerSecond", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithConnectionMaxBytesPerSecond(1024))
+		testOpts := getDefaultOptions()
+		testOpts.withConnectionMaxBytesPerSecond = 1024
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithSessionMaxBytesPerSecond", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithSessionMaxBytesPerSecond(4096))
+		testOpts := getDefaultOptions()
+		testOpts.withSessionMaxBytesPerSecond = 4096
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithSessionIdleTimeoutSeconds", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithSessionIdleTimeoutSeconds(600))
+		testOpts := getDefaultOptions()
+		testOpts.withSessionIdleTimeoutSeconds = 600
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithPrincipals", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithPrincipals([]string{"admin", "deploy"}))
+		testOpts := getDefaultOptions()
+		testOpts.withPrincipals = []string{"admin", "deploy"}
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithHostSelectionStrategy", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithHostSelectionStrategy(RoundRobinHostSelection))
+		testOpts := getDefaultOptions()
+		testOpts.withHostSelectionStrategy = RoundRobinHostSelection
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithRestrictHostId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithRestrictHostId(true))
+		testOpts := getDefaultOptions()
+		testOpts.withRestrictHostId = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithWorkerFilter", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithWorkerFilter(`"us-east" in "/tags/region"`))
+		testOpts := getDefaultOptions()
+		testOpts.withWorkerFilter = `"us-east" in "/tags/region"`
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithStartPageAfterId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithStartPageAfterId("id_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withStartPageAfterId = "id_1234567890"
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -11,6 +11,16 @@ import (
 	"github.com/hashicorp/boundary/internal/oplog"
 )
 
+func init() {
+	oplog.RegisterTypes(
+		&TcpTarget{},
+		&UdpTarget{},
+		&SshTarget{},
+		&TargetHostSet{},
+		&TargetCredentialLibrary{},
+	)
+}
+
 var (
 	ErrMetadataScopeNotFound = errors.New("scope not found for metadata")
 )
@@ -104,7 +114,8 @@ func fetchSets(ctx context.Context, r db.Reader, targetId string) ([]*TargetSet,
 	return hostSets, nil
 }
 
-// ListTargets in targets in a scope.  Supports the WithScopeId, WithLimit, WithTargetType options.
+// ListTargets in targets in a scope ordered by public id.  Supports the
+// WithScopeId, WithLimit, WithTargetType and WithStartPageAfterId options.
 func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target, error) {
 	opts := getOpts(opt...)
 	if opts.withScopeId == "" && opts.withUserId == "" {
@@ -138,8 +149,8 @@ func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target,
 	return targets, nil
 }
 
-// list will return a listing of resources and honor the WithLimit option or the
-// repo defaultLimit
+// list will return a listing of resources ordered by public id and honor the
+// WithLimit option or the repo defaultLimit and the WithStartPageAfterId option
 func (r *Repository) list(ctx context.Context, resources interface{}, where string, args []interface{}, opt ...Option) error {
 	opts := getOpts(opt...)
 	limit := r.defaultLimit
@@ -148,7 +159,10 @@ func (r *Repository) list(ctx context.Context, resources interface{}, where stri
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
-	dbOpts = append(dbOpts, db.WithLimit(limit))
+	if opts.withStartPageAfterId != "" {
+		where, args = fmt.Sprintf("(%s) and public_id collate \"C\" > ?", where), append(args, opts.withStartPageAfterId)
+	}
+	dbOpts = append(dbOpts, db.WithLimit(limit), db.WithOrder(`public_id collate "C"`))
 	return r.reader.SearchWhere(ctx, resources, where, args, dbOpts...)
 }
 
@@ -170,6 +184,16 @@ func (r *Repository) DeleteTarget(ctx context.Context, publicId string, opt ...O
 		tcpT.PublicId = publicId
 		deleteTarget = &tcpT
 		metadata = tcpT.oplog(oplog.OpType_OP_TYPE_DELETE)
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = publicId
+		deleteTarget = &udpT
+		metadata = udpT.oplog(oplog.OpType_OP_TYPE_DELETE)
+	case SshTargetType.String():
+		sshT := allocSshTarget()
+		sshT.PublicId = publicId
+		deleteTarget = &sshT
+		metadata = sshT.oplog(oplog.OpType_OP_TYPE_DELETE)
 	default:
 		return db.NoRowsAffected, fmt.Errorf("delete target: %s is an unsupported target type %s", publicId, t.Type)
 	}
@@ -305,6 +329,20 @@ func (r *Repository) AddTargetHostSets(ctx context.Context, targetId string, tar
 		target = &tcpT
 		metadata = tcpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
 		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = t.PublicId
+		udpT.Version = targetVersion + 1
+		target = &udpT
+		metadata = udpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
+	case SshTargetType.String():
+		sshT := allocSshTarget()
+		sshT.PublicId = t.PublicId
+		sshT.Version = targetVersion + 1
+		target = &sshT
+		metadata = sshT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
 	default:
 		return nil, nil, fmt.Errorf("delete target host sets: %s is an unsupported target type %s", t.PublicId, t.Type)
 	}
@@ -396,6 +434,20 @@ func (r *Repository) DeleteTargeHostSets(ctx context.Context, targetId string, t
 		target = &tcpT
 		metadata = tcpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
 		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = t.PublicId
+		udpT.Version = targetVersion + 1
+		target = &udpT
+		metadata = udpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
+	case SshTargetType.String():
+		sshT := allocSshTarget()
+		sshT.PublicId = t.PublicId
+		sshT.Version = targetVersion + 1
+		target = &sshT
+		metadata = sshT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
 	default:
 		return db.NoRowsAffected, fmt.Errorf("delete target host sets: %s is an unsupported target type %s", t.PublicId, t.Type)
 	}
@@ -517,6 +569,18 @@ func (r *Repository) SetTargetHostSets(ctx context.Context, targetId string, tar
 		tcpT.Version = targetVersion + 1
 		target = &tcpT
 		metadata = tcpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = t.PublicId
+		udpT.Version = targetVersion + 1
+		target = &udpT
+		metadata = udpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+	case SshTargetType.String():
+		sshT := allocSshTarget()
+		sshT.PublicId = t.PublicId
+		sshT.Version = targetVersion + 1
+		target = &sshT
+		metadata = sshT.oplog(oplog.OpType_OP_TYPE_UPDATE)
 	default:
 		return nil, db.NoRowsAffected, fmt.Errorf("set target host sets: %s is an unsupported target type %s", t.PublicId, t.Type)
 	}
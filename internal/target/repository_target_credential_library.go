@@ -0,0 +1,351 @@
+package target
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// ListTargetCredentialLibraries returns the credential libraries of the target
+// (targetId). No options are currently supported.
+func (r *Repository) ListTargetCredentialLibraries(ctx context.Context, targetId string, opt ...Option) ([]*TargetLibrary, error) {
+	if targetId == "" {
+		return nil, fmt.Errorf("list target credential libraries: missing target id: %w", db.ErrInvalidParameter)
+	}
+	libs, err := fetchLibraries(ctx, r.reader, targetId)
+	if err != nil {
+		return nil, fmt.Errorf("list target credential libraries: %w", err)
+	}
+	return libs, nil
+}
+
+func fetchLibraries(ctx context.Context, r db.Reader, targetId string) ([]*TargetLibrary, error) {
+	var libs []*TargetLibrary
+	if err := r.SearchWhere(ctx, &libs, "target_id = ?", []interface{}{targetId}, db.WithOrder("public_id asc")); err != nil {
+		return nil, fmt.Errorf("fetch credential libraries: %w", err)
+	}
+	if len(libs) == 0 {
+		return nil, nil
+	}
+	return libs, nil
+}
+
+// versionedTarget returns the target of the target view t with its version set
+// to targetVersion+1 along with the oplog metadata for updating it.
+func versionedTarget(t *targetView, targetVersion uint32) (interface{}, oplog.Metadata, error) {
+	switch t.Type {
+	case TcpTargetType.String():
+		tcpT := allocTcpTarget()
+		tcpT.PublicId = t.PublicId
+		tcpT.Version = targetVersion + 1
+		return &tcpT, tcpT.oplog(oplog.OpType_OP_TYPE_UPDATE), nil
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = t.PublicId
+		udpT.Version = targetVersion + 1
+		return &udpT, udpT.oplog(oplog.OpType_OP_TYPE_UPDATE), nil
+	case SshTargetType.String():
+		sshT := allocSshTarget()
+		sshT.PublicId = t.PublicId
+		sshT.Version = targetVersion + 1
+		return &sshT, sshT.oplog(oplog.OpType_OP_TYPE_UPDATE), nil
+	default:
+		return nil, nil, fmt.Errorf("%s is an unsupported target type %s", t.PublicId, t.Type)
+	}
+}
+
+// AddTargetCredentialLibraries provides the ability to add credential
+// libraries (credentialLibraryIds) to a target (targetId). The target's
+// current db version must match the targetVersion or an error will be
+// returned. The target and a list of its current credential libraries will be
+// returned on success. Zero is not a valid value for the targetVersion and
+// will return an error.
+func (r *Repository) AddTargetCredentialLibraries(ctx context.Context, targetId string, targetVersion uint32, credentialLibraryIds []string, opt ...Option) (Target, []*TargetLibrary, error) {
+	if targetId == "" {
+		return nil, nil, fmt.Errorf("add target credential libraries: missing target id: %w", db.ErrInvalidParameter)
+	}
+	if targetVersion == 0 {
+		return nil, nil, fmt.Errorf("add target credential libraries: version cannot be zero: %w", db.ErrInvalidParameter)
+	}
+	if len(credentialLibraryIds) == 0 {
+		return nil, nil, fmt.Errorf("add target credential libraries: missing credential library ids: %w", db.ErrInvalidParameter)
+	}
+	newLibs := make([]interface{}, 0, len(credentialLibraryIds))
+	for _, id := range credentialLibraryIds {
+		tcl, err := NewTargetCredentialLibrary(targetId, id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("add target credential libraries: unable to create in memory target credential library: %w", err)
+		}
+		newLibs = append(newLibs, tcl)
+	}
+	t := allocTargetView()
+	t.PublicId = targetId
+	if err := r.reader.LookupByPublicId(ctx, &t); err != nil {
+		return nil, nil, fmt.Errorf("add target credential libraries: failed %w for %s", err, targetId)
+	}
+	target, metadata, err := versionedTarget(&t, targetVersion)
+	if err != nil {
+		return nil, nil, fmt.Errorf("add target credential libraries: %w", err)
+	}
+	metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
+	oplogWrapper, err := r.kms.GetWrapper(ctx, t.GetScopeId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, nil, fmt.Errorf("add target credential libraries: unable to get oplog wrapper: %w", err)
+	}
+	var currentLibs []*TargetLibrary
+	var updatedTarget interface{}
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			msgs := make([]*oplog.Message, 0, 2)
+			targetTicket, err := w.GetTicket(target)
+			if err != nil {
+				return fmt.Errorf("add target credential libraries: unable to get ticket: %w", err)
+			}
+			updatedTarget = target.(Cloneable).Clone()
+			var targetOplogMsg oplog.Message
+			rowsUpdated, err := w.Update(ctx, updatedTarget, []string{"Version"}, nil, db.NewOplogMsg(&targetOplogMsg), db.WithVersion(&targetVersion))
+			if err != nil {
+				return fmt.Errorf("add target credential libraries: unable to update target version: %w", err)
+			}
+			if rowsUpdated != 1 {
+				return fmt.Errorf("add target credential libraries: updated target and %d rows updated", rowsUpdated)
+			}
+			msgs = append(msgs, &targetOplogMsg)
+
+			libOplogMsgs := make([]*oplog.Message, 0, len(newLibs))
+			if err := w.CreateItems(ctx, newLibs, db.NewOplogMsgs(&libOplogMsgs)); err != nil {
+				return fmt.Errorf("add target credential libraries: unable to add target credential libraries: %w", err)
+			}
+			msgs = append(msgs, libOplogMsgs...)
+
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, targetTicket, metadata, msgs); err != nil {
+				return fmt.Errorf("add target credential libraries: unable to write oplog: %w", err)
+			}
+			currentLibs, err = fetchLibraries(ctx, reader, targetId)
+			if err != nil {
+				return fmt.Errorf("add target credential libraries: unable to retrieve current credential libraries after adds: %w", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("add target credential libraries: error creating credential libraries: %w", err)
+	}
+	return updatedTarget.(Target), currentLibs, nil
+}
+
+// DeleteTargetCredentialLibraries deletes credential libraries from a target
+// (targetId). The target's current db version must match the targetVersion or
+// an error will be returned. Zero is not a valid value for the targetVersion
+// and will return an error.
+func (r *Repository) DeleteTargetCredentialLibraries(ctx context.Context, targetId string, targetVersion uint32, credentialLibraryIds []string, opt ...Option) (int, error) {
+	if targetId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete target credential libraries: missing target id: %w", db.ErrInvalidParameter)
+	}
+	if targetVersion == 0 {
+		return db.NoRowsAffected, fmt.Errorf("delete target credential libraries: version cannot be zero: %w", db.ErrInvalidParameter)
+	}
+	if len(credentialLibraryIds) == 0 {
+		return db.NoRowsAffected, fmt.Errorf("delete target credential libraries: missing credential library ids: %w", db.ErrInvalidParameter)
+	}
+	deleteLibs := make([]interface{}, 0, len(credentialLibraryIds))
+	for _, id := range credentialLibraryIds {
+		tcl, err := NewTargetCredentialLibrary(targetId, id)
+		if err != nil {
+			return db.NoRowsAffected, fmt.Errorf("delete target credential libraries: unable to create in memory target credential library: %w", err)
+		}
+		deleteLibs = append(deleteLibs, tcl)
+	}
+
+	t := allocTargetView()
+	t.PublicId = targetId
+	if err := r.reader.LookupByPublicId(ctx, &t); err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete target credential libraries: failed %w for %s", err, targetId)
+	}
+	target, metadata, err := versionedTarget(&t, targetVersion)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete target credential libraries: %w", err)
+	}
+	metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
+	oplogWrapper, err := r.kms.GetWrapper(ctx, t.GetScopeId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete target credential libraries: unable to get oplog wrapper: %w", err)
+	}
+
+	var totalRowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			msgs := make([]*oplog.Message, 0, 2)
+			targetTicket, err := w.GetTicket(target)
+			if err != nil {
+				return fmt.Errorf("delete target credential libraries: unable to get ticket: %w", err)
+			}
+			updatedTarget := target.(Cloneable).Clone()
+			var targetOplogMsg oplog.Message
+			rowsUpdated, err := w.Update(ctx, updatedTarget, []string{"Version"}, nil, db.NewOplogMsg(&targetOplogMsg), db.WithVersion(&targetVersion))
+			if err != nil {
+				return fmt.Errorf("delete target credential libraries: unable to update target version: %w", err)
+			}
+			if rowsUpdated != 1 {
+				return fmt.Errorf("delete target credential libraries: updated target and %d rows updated", rowsUpdated)
+			}
+			msgs = append(msgs, &targetOplogMsg)
+
+			libOplogMsgs := make([]*oplog.Message, 0, len(deleteLibs))
+			rowsDeleted, err := w.DeleteItems(ctx, deleteLibs, db.NewOplogMsgs(&libOplogMsgs))
+			if err != nil {
+				return fmt.Errorf("delete target credential libraries: unable to delete target credential libraries: %w", err)
+			}
+			if rowsDeleted != len(deleteLibs) {
+				return fmt.Errorf("delete target credential libraries: target credential libraries deleted %d did not match request for %d", rowsDeleted, len(deleteLibs))
+			}
+			totalRowsDeleted += rowsDeleted
+			msgs = append(msgs, libOplogMsgs...)
+
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, targetTicket, metadata, msgs); err != nil {
+				return fmt.Errorf("delete target credential libraries: unable to write oplog: %w", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete target credential libraries: error deleting target credential libraries: %w", err)
+	}
+	return totalRowsDeleted, nil
+}
+
+// SetTargetCredentialLibraries will set the target's credential libraries.
+// Set adds and/or deletes target credential libraries as needed to reconcile
+// the existing libraries with the libraries requested. If credentialLibraryIds
+// is empty, the target credential libraries will be cleared. Zero is not a
+// valid value for the targetVersion and will return an error.
+func (r *Repository) SetTargetCredentialLibraries(ctx context.Context, targetId string, targetVersion uint32, credentialLibraryIds []string, opt ...Option) ([]*TargetLibrary, int, error) {
+	if targetId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("set target credential libraries: missing target id: %w", db.ErrInvalidParameter)
+	}
+	if targetVersion == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("set target credential libraries: version cannot be zero: %w", db.ErrInvalidParameter)
+	}
+	t := allocTargetView()
+	t.PublicId = targetId
+	if err := r.reader.LookupByPublicId(ctx, &t); err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set target credential libraries: failed %w for %s", err, targetId)
+	}
+
+	// NOTE: calculating the changes can safely happen outside of the write
+	// transaction since targetVersion ensures we only operate on the data
+	// these queries returned.
+	foundLibs, err := fetchLibraries(ctx, r.reader, targetId)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set target credential libraries: unable to search for existing target credential libraries: %w", err)
+	}
+	found := map[string]*TargetLibrary{}
+	for _, l := range foundLibs {
+		found[l.PublicId] = l
+	}
+	addLibs := make([]interface{}, 0, len(credentialLibraryIds))
+	for _, id := range credentialLibraryIds {
+		if _, ok := found[id]; ok {
+			// found a match, so do nothing (we want to keep it), but remove it
+			// from found
+			delete(found, id)
+			continue
+		}
+		tcl, err := NewTargetCredentialLibrary(targetId, id)
+		if err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("set target credential libraries: unable to create in memory target credential library: %w", err)
+		}
+		addLibs = append(addLibs, tcl)
+	}
+	deleteLibs := make([]interface{}, 0, len(found))
+	for _, l := range found {
+		tcl, err := NewTargetCredentialLibrary(targetId, l.PublicId)
+		if err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("set target credential libraries: unable to create in memory target credential library: %w", err)
+		}
+		deleteLibs = append(deleteLibs, tcl)
+	}
+	if len(addLibs) == 0 && len(deleteLibs) == 0 {
+		return foundLibs, db.NoRowsAffected, nil
+	}
+
+	target, metadata, err := versionedTarget(&t, targetVersion)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set target credential libraries: %w", err)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, t.GetScopeId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set target credential libraries: unable to get oplog wrapper: %w", err)
+	}
+
+	var totalRowsAffected int
+	var currentLibs []*TargetLibrary
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			msgs := make([]*oplog.Message, 0, 2)
+			targetTicket, err := w.GetTicket(target)
+			if err != nil {
+				return fmt.Errorf("set target credential libraries: unable to get ticket: %w", err)
+			}
+			updatedTarget := target.(Cloneable).Clone()
+			var targetOplogMsg oplog.Message
+			rowsUpdated, err := w.Update(ctx, updatedTarget, []string{"Version"}, nil, db.NewOplogMsg(&targetOplogMsg), db.WithVersion(&targetVersion))
+			if err != nil {
+				return fmt.Errorf("set target credential libraries: unable to update target version: %w", err)
+			}
+			if rowsUpdated != 1 {
+				return fmt.Errorf("set target credential libraries: updated target and %d rows updated", rowsUpdated)
+			}
+			msgs = append(msgs, &targetOplogMsg)
+
+			if len(addLibs) > 0 {
+				libOplogMsgs := make([]*oplog.Message, 0, len(addLibs))
+				if err := w.CreateItems(ctx, addLibs, db.NewOplogMsgs(&libOplogMsgs)); err != nil {
+					return fmt.Errorf("set target credential libraries: unable to add target credential libraries: %w", err)
+				}
+				totalRowsAffected += len(addLibs)
+				msgs = append(msgs, libOplogMsgs...)
+				metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
+			}
+
+			if len(deleteLibs) > 0 {
+				libOplogMsgs := make([]*oplog.Message, 0, len(deleteLibs))
+				rowsDeleted, err := w.DeleteItems(ctx, deleteLibs, db.NewOplogMsgs(&libOplogMsgs))
+				if err != nil {
+					return fmt.Errorf("set target credential libraries: unable to delete target credential libraries: %w", err)
+				}
+				if rowsDeleted != len(deleteLibs) {
+					return fmt.Errorf("set target credential libraries: target credential libraries deleted %d did not match request for %d", rowsDeleted, len(deleteLibs))
+				}
+				totalRowsAffected += rowsDeleted
+				msgs = append(msgs, libOplogMsgs...)
+				metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
+			}
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, targetTicket, metadata, msgs); err != nil {
+				return fmt.Errorf("set target credential libraries: unable to write oplog: %w", err)
+			}
+
+			currentLibs, err = fetchLibraries(ctx, reader, targetId)
+			if err != nil {
+				return fmt.Errorf("set target credential libraries: unable to retrieve current target credential libraries after set: %w", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set target credential libraries: error setting target credential libraries: %w", err)
+	}
+	return currentLibs, totalRowsAffected, nil
+}
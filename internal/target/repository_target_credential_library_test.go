@@ -0,0 +1,265 @@
+package target
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCredentialLibraryIds(t *testing.T, conn *gorm.DB, kmsCache *kms.Kms, scopeId string, count int) []string {
+	t.Helper()
+	wrapper, err := kmsCache.GetWrapper(context.Background(), scopeId, kms.KeyPurposeCredentials)
+	require.NoError(t, err)
+	stores := static.TestCredentialStores(t, conn, scopeId, 1)
+	libs := static.TestCredentialLibraries(t, conn, wrapper, stores[0].GetPublicId(), count)
+	ids := make([]string, 0, len(libs))
+	for _, l := range libs {
+		ids = append(ids, l.GetPublicId())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func targetLibraryIds(libs []*TargetLibrary) []string {
+	ids := make([]string, 0, len(libs))
+	for _, l := range libs {
+		ids = append(ids, l.PublicId)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestRepository_AddTargetCredentialLibraries(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, proj := iam.TestScopes(t, iamRepo)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		targetVersion uint32
+		libraryIds    func() []string
+		wantErr       bool
+		wantErrIs     error
+	}{
+		{
+			name:          "valid",
+			targetVersion: 1,
+			libraryIds:    func() []string { return testCredentialLibraryIds(t, conn, testKms, proj.PublicId, 2) },
+		},
+		{
+			name:          "bad-version",
+			targetVersion: 1000,
+			libraryIds:    func() []string { return testCredentialLibraryIds(t, conn, testKms, proj.PublicId, 1) },
+			wantErr:       true,
+		},
+		{
+			name:          "zero-version",
+			targetVersion: 0,
+			libraryIds:    func() []string { return testCredentialLibraryIds(t, conn, testKms, proj.PublicId, 1) },
+			wantErr:       true,
+			wantErrIs:     db.ErrInvalidParameter,
+		},
+		{
+			name:          "no-libraries",
+			targetVersion: 1,
+			libraryIds:    func() []string { return nil },
+			wantErr:       true,
+			wantErrIs:     db.ErrInvalidParameter,
+		},
+		{
+			name:          "library-in-other-scope",
+			targetVersion: 1,
+			libraryIds:    func() []string { return testCredentialLibraryIds(t, conn, testKms, org.PublicId, 1) },
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			projTarget := TestTcpTarget(t, conn, proj.PublicId, tt.name)
+			ids := tt.libraryIds()
+
+			gotTarget, gotLibs, err := repo.AddTargetCredentialLibraries(context.Background(), projTarget.PublicId, tt.targetVersion, ids)
+			if tt.wantErr {
+				require.Error(err)
+				if tt.wantErrIs != nil {
+					assert.Truef(errors.Is(err, tt.wantErrIs), "unexpected error %s", err.Error())
+				}
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.targetVersion+1, gotTarget.GetVersion())
+			assert.Equal(ids, targetLibraryIds(gotLibs))
+
+			listed, err := repo.ListTargetCredentialLibraries(context.Background(), projTarget.PublicId)
+			require.NoError(err)
+			assert.Equal(ids, targetLibraryIds(listed))
+		})
+	}
+}
+
+func TestRepository_DeleteTargetCredentialLibraries(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, iamRepo)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		targetVersion   uint32
+		deleteCount     int
+		wantRowsDeleted int
+		wantErr         bool
+	}{
+		{
+			name:            "valid",
+			targetVersion:   2,
+			deleteCount:     2,
+			wantRowsDeleted: 2,
+		},
+		{
+			name:          "bad-version",
+			targetVersion: 1000,
+			deleteCount:   1,
+			wantErr:       true,
+		},
+		{
+			name:          "zero-version",
+			targetVersion: 0,
+			deleteCount:   1,
+			wantErr:       true,
+		},
+		{
+			name:          "no-libraries",
+			targetVersion: 2,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			projTarget := TestTcpTarget(t, conn, proj.PublicId, tt.name)
+			ids := testCredentialLibraryIds(t, conn, testKms, proj.PublicId, 3)
+			_, _, err := repo.AddTargetCredentialLibraries(context.Background(), projTarget.PublicId, 1, ids)
+			require.NoError(err)
+
+			deleted, err := repo.DeleteTargetCredentialLibraries(context.Background(), projTarget.PublicId, tt.targetVersion, ids[:tt.deleteCount])
+			if tt.wantErr {
+				require.Error(err)
+				assert.Equal(db.NoRowsAffected, deleted)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantRowsDeleted, deleted)
+
+			listed, err := repo.ListTargetCredentialLibraries(context.Background(), projTarget.PublicId)
+			require.NoError(err)
+			assert.Equal(ids[tt.deleteCount:], targetLibraryIds(listed))
+		})
+	}
+}
+
+func TestRepository_SetTargetCredentialLibraries(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, iamRepo)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		targetVersion    uint32
+		keep             bool
+		addCount         int
+		wantAffectedRows int
+		wantErr          bool
+	}{
+		{
+			name:             "clear",
+			targetVersion:    2,
+			wantAffectedRows: 3,
+		},
+		{
+			name:             "no-change",
+			targetVersion:    2,
+			keep:             true,
+			wantAffectedRows: 0,
+		},
+		{
+			name:             "add-libraries",
+			targetVersion:    2,
+			keep:             true,
+			addCount:         2,
+			wantAffectedRows: 2,
+		},
+		{
+			name:             "replace-libraries",
+			targetVersion:    2,
+			addCount:         2,
+			wantAffectedRows: 5,
+		},
+		{
+			name:          "bad-version",
+			targetVersion: 1000,
+			addCount:      1,
+			wantErr:       true,
+		},
+		{
+			name:          "zero-version",
+			targetVersion: 0,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			projTarget := TestTcpTarget(t, conn, proj.PublicId, tt.name)
+			orig := testCredentialLibraryIds(t, conn, testKms, proj.PublicId, 3)
+			_, _, err := repo.AddTargetCredentialLibraries(context.Background(), projTarget.PublicId, 1, orig)
+			require.NoError(err)
+
+			var want []string
+			if tt.keep {
+				want = append(want, orig...)
+			}
+			if tt.addCount > 0 {
+				want = append(want, testCredentialLibraryIds(t, conn, testKms, proj.PublicId, tt.addCount)...)
+			}
+			sort.Strings(want)
+
+			got, affected, err := repo.SetTargetCredentialLibraries(context.Background(), projTarget.PublicId, tt.targetVersion, want)
+			if tt.wantErr {
+				require.Error(err)
+				assert.Equal(db.NoRowsAffected, affected)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantAffectedRows, affected)
+			assert.ElementsMatch(want, targetLibraryIds(got))
+		})
+	}
+}
@@ -127,6 +127,13 @@ func (r *Repository) UpdateTcpTarget(ctx context.Context, target *TcpTarget, ver
 		case strings.EqualFold("defaultport", f):
 		case strings.EqualFold("sessionmaxseconds", f):
 		case strings.EqualFold("sessionconnectionlimit", f):
+		case strings.EqualFold("enablesessionrecording", f):
+		case strings.EqualFold("connectionmaxbytespersecond", f):
+		case strings.EqualFold("sessionmaxbytespersecond", f):
+		case strings.EqualFold("sessionidletimeoutseconds", f):
+		case strings.EqualFold("hostselectionstrategy", f):
+		case strings.EqualFold("restricthostid", f):
+		case strings.EqualFold("workerfilter", f):
 		default:
 			return nil, nil, db.NoRowsAffected, fmt.Errorf("update tcp target: field: %s: %w", f, db.ErrInvalidFieldMask)
 		}
@@ -134,14 +141,21 @@ func (r *Repository) UpdateTcpTarget(ctx context.Context, target *TcpTarget, ver
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbcommon.BuildUpdatePaths(
 		map[string]interface{}{
-			"Name":                   target.Name,
-			"Description":            target.Description,
-			"DefaultPort":            target.DefaultPort,
-			"SessionMaxSeconds":      target.SessionMaxSeconds,
-			"SessionConnectionLimit": target.SessionConnectionLimit,
+			"Name":                        target.Name,
+			"Description":                 target.Description,
+			"DefaultPort":                 target.DefaultPort,
+			"SessionMaxSeconds":           target.SessionMaxSeconds,
+			"SessionConnectionLimit":      target.SessionConnectionLimit,
+			"EnableSessionRecording":      target.EnableSessionRecording,
+			"ConnectionMaxBytesPerSecond": target.ConnectionMaxBytesPerSecond,
+			"SessionMaxBytesPerSecond":    target.SessionMaxBytesPerSecond,
+			"SessionIdleTimeoutSeconds":   target.SessionIdleTimeoutSeconds,
+			"HostSelectionStrategy":       target.HostSelectionStrategy,
+			"RestrictHostId":              target.RestrictHostId,
+			"WorkerFilter":                target.WorkerFilter,
 		},
 		fieldMaskPaths,
-		[]string{"SessionMaxSeconds", "SessionConnectionLimit"},
+		[]string{"SessionMaxSeconds", "SessionConnectionLimit", "EnableSessionRecording", "ConnectionMaxBytesPerSecond", "SessionMaxBytesPerSecond", "SessionIdleTimeoutSeconds", "RestrictHostId"},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, nil, db.NoRowsAffected, fmt.Errorf("update tcp target: %w", db.ErrEmptyFieldMask)
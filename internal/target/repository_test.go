@@ -136,6 +136,26 @@ func TestRepository_ListTargets(t *testing.T) {
 			wantCnt: 5,
 			wantErr: false,
 		},
+		{
+			name:          "udp-target",
+			createCnt:     5,
+			createScopeId: proj.PublicId,
+			args: args{
+				opt: []Option{WithTargetType(UdpTargetType), WithScopeId(proj.PublicId)},
+			},
+			wantCnt: 0,
+			wantErr: false,
+		},
+		{
+			name:          "ssh-target",
+			createCnt:     5,
+			createScopeId: proj.PublicId,
+			args: args{
+				opt: []Option{WithTargetType(SshTargetType), WithScopeId(proj.PublicId)},
+			},
+			wantCnt: 0,
+			wantErr: false,
+		},
 		{
 			name:          "no-limit",
 			createCnt:     testLimit + 1,
@@ -232,6 +252,22 @@ func TestRepository_DeleteTarget(t *testing.T) {
 			wantRowsDeleted: 1,
 			wantErr:         false,
 		},
+		{
+			name: "valid-udp",
+			args: args{
+				target: TestUdpTarget(t, conn, proj.PublicId, "valid-udp"),
+			},
+			wantRowsDeleted: 1,
+			wantErr:         false,
+		},
+		{
+			name: "valid-ssh",
+			args: args{
+				target: TestSshTarget(t, conn, proj.PublicId, "valid-ssh"),
+			},
+			wantRowsDeleted: 1,
+			wantErr:         false,
+		},
 		{
 			name: "no-public-id",
 			args: args{
@@ -0,0 +1,123 @@
+package target
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultSshTableName = "target_ssh"
+)
+
+type SshTarget struct {
+	*store.SshTarget
+	tableName string `gorm:"-"`
+}
+
+var _ Target = (*SshTarget)(nil)
+var _ db.VetForWriter = (*SshTarget)(nil)
+var _ oplog.ReplayableMessage = (*SshTarget)(nil)
+
+// NewSshTarget creates a new in memory ssh target.  WithName, WithDescription,
+// WithDefaultPort and WithPrincipals options are supported
+func NewSshTarget(scopeId string, opt ...Option) (*SshTarget, error) {
+	opts := getOpts(opt...)
+	if scopeId == "" {
+		return nil, fmt.Errorf("new ssh target: missing scope id: %w", db.ErrInvalidParameter)
+	}
+	t := &SshTarget{
+		SshTarget: &store.SshTarget{
+			ScopeId:                     scopeId,
+			Name:                        opts.withName,
+			Description:                 opts.withDescription,
+			DefaultPort:                 opts.withDefaultPort,
+			SessionConnectionLimit:      opts.withSessionConnectionLimit,
+			SessionMaxSeconds:           opts.withSessionMaxSeconds,
+			EnableSessionRecording:      opts.withEnableSessionRecording,
+			ConnectionMaxBytesPerSecond: opts.withConnectionMaxBytesPerSecond,
+			SessionMaxBytesPerSecond:    opts.withSessionMaxBytesPerSecond,
+			SessionIdleTimeoutSeconds:   opts.withSessionIdleTimeoutSeconds,
+			HostSelectionStrategy:       opts.withHostSelectionStrategy.String(),
+			RestrictHostId:              opts.withRestrictHostId,
+			WorkerFilter:                opts.withWorkerFilter,
+			Principals:                  strings.Join(opts.withPrincipals, ","),
+		},
+	}
+	return t, nil
+}
+
+// allocSshTarget will allocate a ssh target
+func allocSshTarget() SshTarget {
+	return SshTarget{
+		SshTarget: &store.SshTarget{},
+	}
+}
+
+// Clone creates a clone of the SshTarget
+func (t *SshTarget) Clone() interface{} {
+	cp := proto.Clone(t.SshTarget)
+	return &SshTarget{
+		SshTarget: cp.(*store.SshTarget),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the ssh target
+// before it's written.
+func (t *SshTarget) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if t.PublicId == "" {
+		return fmt.Errorf("ssh target vet for write: missing public id: %w", db.ErrInvalidParameter)
+	}
+	if opType == db.CreateOp {
+		if t.ScopeId == "" {
+			return fmt.Errorf("ssh target vet for write: missing scope id: %w", db.ErrInvalidParameter)
+		}
+		if t.Name == "" {
+			return fmt.Errorf("ssh target vet for write: missing name id: %w", db.ErrInvalidParameter)
+		}
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (t *SshTarget) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return DefaultSshTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (t *SshTarget) SetTableName(n string) {
+	t.tableName = n
+}
+
+func (t *SshTarget) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{t.PublicId},
+		"resource-type":      []string{"ssh target"},
+		"op-type":            []string{op.String()},
+		"scope-id":           []string{t.ScopeId},
+	}
+	return metadata
+}
+
+func (t SshTarget) GetType() string {
+	return "ssh"
+}
+
+// PrincipalList returns the principals included in the certificates issued
+// for sessions to the target.
+func (t *SshTarget) PrincipalList() []string {
+	if t.GetPrincipals() == "" {
+		return nil
+	}
+	return strings.Split(t.GetPrincipals(), ",")
+}
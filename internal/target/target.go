@@ -21,6 +21,13 @@ type Target interface {
 	GetUpdateTime() *timestamp.Timestamp
 	GetSessionMaxSeconds() uint32
 	GetSessionConnectionLimit() int32
+	GetEnableSessionRecording() bool
+	GetConnectionMaxBytesPerSecond() uint32
+	GetSessionMaxBytesPerSecond() uint32
+	GetSessionIdleTimeoutSeconds() uint32
+	GetHostSelectionStrategy() string
+	GetRestrictHostId() bool
+	GetWorkerFilter() string
 	oplog(op oplog.OpType) oplog.Metadata
 }
 
@@ -30,6 +37,8 @@ type TargetType uint32
 const (
 	UnknownTargetType TargetType = 0
 	TcpTargetType     TargetType = 1
+	UdpTargetType     TargetType = 2
+	SshTargetType     TargetType = 3
 )
 
 // String returns a string representation of the target type.
@@ -37,6 +46,8 @@ func (t TargetType) String() string {
 	return [...]string{
 		"unknown",
 		"tcp",
+		"udp",
+		"ssh",
 	}[t]
 }
 
@@ -92,7 +103,55 @@ func (t *targetView) targetSubType() (Target, error) {
 		tcpTarget.Version = t.Version
 		tcpTarget.SessionMaxSeconds = t.SessionMaxSeconds
 		tcpTarget.SessionConnectionLimit = t.SessionConnectionLimit
+		tcpTarget.EnableSessionRecording = t.EnableSessionRecording
+		tcpTarget.ConnectionMaxBytesPerSecond = t.ConnectionMaxBytesPerSecond
+		tcpTarget.SessionMaxBytesPerSecond = t.SessionMaxBytesPerSecond
+		tcpTarget.SessionIdleTimeoutSeconds = t.SessionIdleTimeoutSeconds
+		tcpTarget.HostSelectionStrategy = t.HostSelectionStrategy
+		tcpTarget.RestrictHostId = t.RestrictHostId
+		tcpTarget.WorkerFilter = t.WorkerFilter
 		return &tcpTarget, nil
+	case UdpTargetType.String():
+		udpTarget := allocUdpTarget()
+		udpTarget.PublicId = t.PublicId
+		udpTarget.ScopeId = t.ScopeId
+		udpTarget.Name = t.Name
+		udpTarget.Description = t.Description
+		udpTarget.DefaultPort = t.DefaultPort
+		udpTarget.CreateTime = t.CreateTime
+		udpTarget.UpdateTime = t.UpdateTime
+		udpTarget.Version = t.Version
+		udpTarget.SessionMaxSeconds = t.SessionMaxSeconds
+		udpTarget.SessionConnectionLimit = t.SessionConnectionLimit
+		udpTarget.EnableSessionRecording = t.EnableSessionRecording
+		udpTarget.ConnectionMaxBytesPerSecond = t.ConnectionMaxBytesPerSecond
+		udpTarget.SessionMaxBytesPerSecond = t.SessionMaxBytesPerSecond
+		udpTarget.SessionIdleTimeoutSeconds = t.SessionIdleTimeoutSeconds
+		udpTarget.HostSelectionStrategy = t.HostSelectionStrategy
+		udpTarget.RestrictHostId = t.RestrictHostId
+		udpTarget.WorkerFilter = t.WorkerFilter
+		return &udpTarget, nil
+	case SshTargetType.String():
+		sshTarget := allocSshTarget()
+		sshTarget.PublicId = t.PublicId
+		sshTarget.ScopeId = t.ScopeId
+		sshTarget.Name = t.Name
+		sshTarget.Description = t.Description
+		sshTarget.DefaultPort = t.DefaultPort
+		sshTarget.CreateTime = t.CreateTime
+		sshTarget.UpdateTime = t.UpdateTime
+		sshTarget.Version = t.Version
+		sshTarget.SessionMaxSeconds = t.SessionMaxSeconds
+		sshTarget.SessionConnectionLimit = t.SessionConnectionLimit
+		sshTarget.EnableSessionRecording = t.EnableSessionRecording
+		sshTarget.ConnectionMaxBytesPerSecond = t.ConnectionMaxBytesPerSecond
+		sshTarget.SessionMaxBytesPerSecond = t.SessionMaxBytesPerSecond
+		sshTarget.SessionIdleTimeoutSeconds = t.SessionIdleTimeoutSeconds
+		sshTarget.HostSelectionStrategy = t.HostSelectionStrategy
+		sshTarget.RestrictHostId = t.RestrictHostId
+		sshTarget.WorkerFilter = t.WorkerFilter
+		sshTarget.Principals = t.Principals
+		return &sshTarget, nil
 	}
 	return nil, fmt.Errorf("%s is an unknown target subtype of %s", t.PublicId, t.Type)
 }
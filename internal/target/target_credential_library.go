@@ -0,0 +1,105 @@
+package target
+
+import (
+	"context"
+	"fmt"
+
+	credStore "github.com/hashicorp/boundary/internal/credential/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultTargetCredentialLibraryTableName = "target_credential_library"
+)
+
+type TargetCredentialLibrary struct {
+	*store.TargetCredentialLibrary
+	tableName string `gorm:"-"`
+}
+
+var _ db.VetForWriter = (*TargetCredentialLibrary)(nil)
+
+// NewTargetCredentialLibrary creates a new in memory target credential
+// library. No options are currently supported.
+func NewTargetCredentialLibrary(targetId, credentialLibraryId string, opt ...Option) (*TargetCredentialLibrary, error) {
+	if targetId == "" {
+		return nil, fmt.Errorf("new target credential library: missing target id: %w", db.ErrInvalidParameter)
+	}
+	if credentialLibraryId == "" {
+		return nil, fmt.Errorf("new target credential library: missing credential library id: %w", db.ErrInvalidParameter)
+	}
+	t := &TargetCredentialLibrary{
+		TargetCredentialLibrary: &store.TargetCredentialLibrary{
+			TargetId:            targetId,
+			CredentialLibraryId: credentialLibraryId,
+		},
+	}
+	return t, nil
+}
+
+// allocTargetCredentialLibrary will allocate a target credential library
+func allocTargetCredentialLibrary() TargetCredentialLibrary {
+	return TargetCredentialLibrary{
+		TargetCredentialLibrary: &store.TargetCredentialLibrary{},
+	}
+}
+
+// Clone creates a clone of the target credential library
+func (t *TargetCredentialLibrary) Clone() interface{} {
+	cp := proto.Clone(t.TargetCredentialLibrary)
+	return &TargetCredentialLibrary{
+		TargetCredentialLibrary: cp.(*store.TargetCredentialLibrary),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the target
+// credential library before it's written.
+func (t *TargetCredentialLibrary) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if opType == db.CreateOp {
+		if t.TargetId == "" {
+			return fmt.Errorf("target credential library: vet for write: missing target id: %w", db.ErrInvalidParameter)
+		}
+		if t.CredentialLibraryId == "" {
+			return fmt.Errorf("target credential library: vet for write: missing credential library id: %w", db.ErrInvalidParameter)
+		}
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (t *TargetCredentialLibrary) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return DefaultTargetCredentialLibraryTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (t *TargetCredentialLibrary) SetTableName(n string) {
+	t.tableName = n
+}
+
+func (t *TargetCredentialLibrary) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{fmt.Sprintf("%s:%s", t.TargetId, t.CredentialLibraryId)},
+		"resource-type":      []string{"target credential library"},
+		"op-type":            []string{op.String()},
+	}
+	return metadata
+}
+
+// TargetLibrary is returned from repo operations as the target's credential
+// library.
+type TargetLibrary struct {
+	*credStore.Library
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (tl *TargetLibrary) TableName() string {
+	return "target_library"
+}
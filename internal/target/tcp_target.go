@@ -32,12 +32,19 @@ func NewTcpTarget(scopeId string, opt ...Option) (*TcpTarget, error) {
 	}
 	t := &TcpTarget{
 		TcpTarget: &store.TcpTarget{
-			ScopeId:                scopeId,
-			Name:                   opts.withName,
-			Description:            opts.withDescription,
-			DefaultPort:            opts.withDefaultPort,
-			SessionConnectionLimit: opts.withSessionConnectionLimit,
-			SessionMaxSeconds:      opts.withSessionMaxSeconds,
+			ScopeId:                     scopeId,
+			Name:                        opts.withName,
+			Description:                 opts.withDescription,
+			DefaultPort:                 opts.withDefaultPort,
+			SessionConnectionLimit:      opts.withSessionConnectionLimit,
+			SessionMaxSeconds:           opts.withSessionMaxSeconds,
+			EnableSessionRecording:      opts.withEnableSessionRecording,
+			ConnectionMaxBytesPerSecond: opts.withConnectionMaxBytesPerSecond,
+			SessionMaxBytesPerSecond:    opts.withSessionMaxBytesPerSecond,
+			SessionIdleTimeoutSeconds:   opts.withSessionIdleTimeoutSeconds,
+			HostSelectionStrategy:       opts.withHostSelectionStrategy.String(),
+			RestrictHostId:              opts.withRestrictHostId,
+			WorkerFilter:                opts.withWorkerFilter,
 		},
 	}
 	return t, nil
@@ -51,6 +51,94 @@ func TestTcpTarget_Create(t *testing.T) {
 				t.Name = "valid-proj-scope"
 				t.SessionMaxSeconds = uint32((8 * time.Hour).Seconds())
 				t.SessionConnectionLimit = 1
+				t.HostSelectionStrategy = RandomHostSelection.String()
+				return &t
+			}(),
+			create: true,
+		},
+		{
+			name: "valid-with-bandwidth-limits",
+			args: args{
+				scopeId: prj.PublicId,
+				opt: []Option{
+					WithName("valid-with-bandwidth-limits"),
+					WithConnectionMaxBytesPerSecond(1024),
+					WithSessionMaxBytesPerSecond(4096),
+				},
+			},
+			want: func() *TcpTarget {
+				t := allocTcpTarget()
+				t.ScopeId = prj.PublicId
+				t.Name = "valid-with-bandwidth-limits"
+				t.SessionMaxSeconds = uint32((8 * time.Hour).Seconds())
+				t.SessionConnectionLimit = 1
+				t.HostSelectionStrategy = RandomHostSelection.String()
+				t.ConnectionMaxBytesPerSecond = 1024
+				t.SessionMaxBytesPerSecond = 4096
+				return &t
+			}(),
+			create: true,
+		},
+		{
+			name: "valid-with-idle-timeout",
+			args: args{
+				scopeId: prj.PublicId,
+				opt: []Option{
+					WithName("valid-with-idle-timeout"),
+					WithSessionIdleTimeoutSeconds(600),
+				},
+			},
+			want: func() *TcpTarget {
+				t := allocTcpTarget()
+				t.ScopeId = prj.PublicId
+				t.Name = "valid-with-idle-timeout"
+				t.SessionMaxSeconds = uint32((8 * time.Hour).Seconds())
+				t.SessionConnectionLimit = 1
+				t.HostSelectionStrategy = RandomHostSelection.String()
+				t.SessionIdleTimeoutSeconds = 600
+				return &t
+			}(),
+			create: true,
+		},
+		{
+			name: "valid-with-host-selection",
+			args: args{
+				scopeId: prj.PublicId,
+				opt: []Option{
+					WithName("valid-with-host-selection"),
+					WithHostSelectionStrategy(LeastActiveSessionsHostSelection),
+					WithRestrictHostId(true),
+				},
+			},
+			want: func() *TcpTarget {
+				t := allocTcpTarget()
+				t.ScopeId = prj.PublicId
+				t.Name = "valid-with-host-selection"
+				t.SessionMaxSeconds = uint32((8 * time.Hour).Seconds())
+				t.SessionConnectionLimit = 1
+				t.HostSelectionStrategy = LeastActiveSessionsHostSelection.String()
+				t.RestrictHostId = true
+				return &t
+			}(),
+			create: true,
+		},
+		{
+			name: "valid-with-worker-filter",
+			args: args{
+				scopeId: prj.PublicId,
+				opt: []Option{
+					WithName("valid-with-worker-filter"),
+					WithWorkerFilter(`"us-east" in "/tags/region"`),
+				},
+			},
+			want: func() *TcpTarget {
+				t := allocTcpTarget()
+				t.ScopeId = prj.PublicId
+				t.Name = "valid-with-worker-filter"
+				t.SessionMaxSeconds = uint32((8 * time.Hour).Seconds())
+				t.SessionConnectionLimit = 1
+				t.HostSelectionStrategy = RandomHostSelection.String()
+				t.WorkerFilter = `"us-east" in "/tags/region"`
 				return &t
 			}(),
 			create: true,
@@ -0,0 +1,99 @@
+package oplogentries_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/boundary/api/oplogentries"
+	"github.com/hashicorp/boundary/api/users"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/servers/controller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	tc := controller.NewTestController(t, nil)
+	defer tc.Shutdown()
+
+	client := tc.Client()
+	token := tc.Token()
+	client.SetToken(token.Token)
+	org := iam.TestOrg(t, tc.IamRepo(), iam.WithUserId(token.UserId))
+	userClient := users.NewClient(client)
+	oplogClient := oplogentries.NewClient(client)
+
+	var userIds []string
+	for i := 0; i < 5; i++ {
+		u, err := userClient.Create(tc.Context(), org.GetPublicId(), users.WithName(fmt.Sprint(i)))
+		require.NoError(err)
+		userIds = append(userIds, u.Item.Id)
+	}
+	_, err := userClient.Update(tc.Context(), userIds[0], 0, users.WithName("updated"), users.WithAutomaticVersioning(true))
+	require.NoError(err)
+
+	all, err := oplogClient.List(tc.Context(), org.GetPublicId(), oplogentries.WithAggregateName("iam_user"))
+	require.NoError(err)
+	require.Len(all.Items, 6)
+	for i, id := range userIds {
+		assert.Equal(id, all.Items[i].ResourceId)
+		assert.Equal("create", all.Items[i].Messages[0].Operation)
+	}
+	assert.Equal(userIds[0], all.Items[5].ResourceId)
+	assert.Equal("update", all.Items[5].Messages[0].Operation)
+	assert.Equal("updated", all.Items[5].Messages[0].Value["name"])
+
+	el, err := oplogClient.List(tc.Context(), org.GetPublicId(), oplogentries.WithAggregateName("iam_user"), oplogentries.WithOperation("update"))
+	require.NoError(err)
+	require.Len(el.Items, 1)
+	assert.Equal(userIds[0], el.Items[0].ResourceId)
+
+	var paged []*oplogentries.OplogEntry
+	var pageToken string
+	for {
+		page, err := oplogClient.ListPage(tc.Context(), org.GetPublicId(), pageToken, oplogentries.WithAggregateName("iam_user"), oplogentries.WithPageSize(2))
+		require.NoError(err)
+		assert.LessOrEqual(len(page.Items), 2)
+		paged = append(paged, page.Items...)
+		if page.NextPageToken == "" {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+	require.Len(paged, 6)
+	for i := range paged {
+		assert.Equal(all.Items[i].Id, paged[i].Id)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	tc := controller.NewTestController(t, nil)
+	defer tc.Shutdown()
+
+	client := tc.Client()
+	token := tc.Token()
+	client.SetToken(token.Token)
+	org := iam.TestOrg(t, tc.IamRepo(), iam.WithUserId(token.UserId))
+	userClient := users.NewClient(client)
+	oplogClient := oplogentries.NewClient(client)
+
+	wr, err := oplogClient.Watch(tc.Context(), org.GetPublicId(), "", oplogentries.WithWaitSeconds(1))
+	require.NoError(err)
+	assert.Empty(wr.Items)
+	require.NotEmpty(wr.Cursor)
+
+	u, err := userClient.Create(tc.Context(), org.GetPublicId())
+	require.NoError(err)
+
+	wr, err = oplogClient.Watch(tc.Context(), org.GetPublicId(), wr.Cursor, oplogentries.WithAggregateName("iam_user"))
+	require.NoError(err)
+	require.Len(wr.Items, 1)
+	assert.Equal(u.Item.Id, wr.Items[0].ResourceId)
+	assert.Equal("create", wr.Items[0].Messages[0].Operation)
+
+	wr, err = oplogClient.Watch(tc.Context(), org.GetPublicId(), wr.Cursor, oplogentries.WithWaitSeconds(1))
+	require.NoError(err)
+	assert.Empty(wr.Items)
+}
@@ -83,3 +83,9 @@ func TestAction(t *testing.T) {
 		})
 	}
 }
+
+func TestActionSet_Strings(t *testing.T) {
+	assert.Nil(t, ActionSet(nil).Strings())
+	assert.Equal(t, []string{}, ActionSet{}.Strings())
+	assert.Equal(t, []string{"read", "update", "add-host-sets"}, ActionSet{Read, Update, AddHostSets}.Strings())
+}
@@ -17,7 +17,7 @@ export default [
   },
   {
     category: 'api-clients',
-    content: ['api', 'cli', 'go-sdk'],
+    content: ['api', 'cli', 'go-sdk', 'filtering'],
   },
   {
     category: 'concepts',
@@ -35,6 +35,8 @@ export default [
         content: [
           'accounts',
           'auth-methods',
+          'credential-libraries',
+          'credential-stores',
           'groups',
           'hosts',
           'host-catalogs',
@@ -77,6 +79,7 @@ export default [
       },
       'controller',
       'worker',
+      'events',
     ],
   },
   {